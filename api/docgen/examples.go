@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"time"
 
 	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -24,6 +25,7 @@ import (
 	"github.com/celestiaorg/celestia-node/blob"
 	"github.com/celestiaorg/celestia-node/das"
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/audit"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
@@ -111,6 +113,19 @@ func init() {
 
 	addToExampleValues(txResponse)
 	addToExampleValues(samplingStats)
+	addToExampleValues(das.SampleRecord{
+		Height:  42,
+		Time:    time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+		Latency: 2 * time.Second,
+		Samples: []das.ShareSample{{Row: 1, Col: 2, Source: "ipld", Latency: time.Second}},
+	})
+	addToExampleValues(audit.Entry{
+		Time:       time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+		Method:     "blob.Submit",
+		Subject:    "1f2d5e0b8a9c4d3e",
+		ParamsHash: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		Result:     "ok",
+	})
 	addToExampleValues(extendedHeader)
 	addToExampleValues(resourceMngrStats)
 
@@ -146,7 +161,19 @@ func init() {
 	addToExampleValues(generatedBlob)
 
 	proof := nmt.NewInclusionProof(0, 4, [][]byte{[]byte("test")}, true)
-	blobProof := &blob.Proof{&proof}
+	blobProof := &blob.Proof{
+		Namespace:  namespace,
+		Commitment: generatedBlob.Commitment,
+		Rows: []*blob.ProofRow{{
+			Index:  0,
+			Root:   []byte("root"),
+			Shares: []share.Share{[]byte("share")},
+			Proof:  &proof,
+		}},
+		Start: 0,
+		End:   1,
+		DAH:   &share.Root{RowRoots: [][]byte{[]byte("root")}, ColumnRoots: [][]byte{[]byte("root")}},
+	}
 	addToExampleValues(blobProof)
 }
 
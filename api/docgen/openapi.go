@@ -0,0 +1,223 @@
+package docgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/jsonschema"
+
+	"github.com/celestiaorg/celestia-node/api/gateway"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+const (
+	GatewayAPIName        = "Celestia Node Gateway"
+	GatewayAPIDescription = "The Celestia Node Gateway is the collection of HTTP endpoints that " +
+		"can be used to query Celestia Data Availability Nodes without a JSON-RPC client."
+)
+
+type openAPIDocument struct {
+	OpenAPI      string                                  `json:"openapi"`
+	Info         openAPIInfo                             `json:"info"`
+	ExternalDocs openAPIExternalDocs                     `json:"externalDocs"`
+	Paths        map[string]map[string]*openAPIOperation `json:"paths"`
+	Components   openAPIComponents                       `json:"components"`
+	Security     []map[string][]string                   `json:"security"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type openAPIExternalDocs struct {
+	Description string `json:"description"`
+	URL         string `json:"url"`
+}
+
+type openAPIOperation struct {
+	Summary     string                      `json:"summary"`
+	Deprecated  bool                        `json:"deprecated,omitempty"`
+	Parameters  []openAPIParameter          `json:"parameters,omitempty"`
+	RequestBody *openAPIBody                `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name        string           `json:"name"`
+	In          string           `json:"in"`
+	Description string           `json:"description"`
+	Required    bool             `json:"required"`
+	Schema      *jsonschema.Type `json:"schema"`
+}
+
+type openAPIBody struct {
+	Required bool                         `json:"required,omitempty"`
+	Content  map[string]*openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *jsonschema.Type `json:"schema,omitempty"`
+}
+
+type openAPIComponents struct {
+	Schemas         jsonschema.Definitions           `json:"schemas"`
+	SecuritySchemes map[string]openAPISecurityScheme `json:"securitySchemes"`
+}
+
+type openAPISecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+}
+
+// NewOpenAPIDocument generates the OpenAPI document of the gateway from the Go types of the
+// requests and the responses of its endpoints.
+func NewOpenAPIDocument() ([]byte, error) {
+	doc := &openAPIDocument{
+		OpenAPI: "3.1.0",
+		Info: openAPIInfo{
+			Title:       GatewayAPIName,
+			Description: GatewayAPIDescription,
+			Version:     node.APIVersion,
+		},
+		ExternalDocs: openAPIExternalDocs{Description: DocsName, URL: DocsURL},
+		Paths:        make(map[string]map[string]*openAPIOperation),
+		Components: openAPIComponents{
+			Schemas: make(jsonschema.Definitions),
+			SecuritySchemes: map[string]openAPISecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer"},
+			},
+		},
+		// tokens are optional, unless the gateway requires them
+		Security: []map[string][]string{{}, {"bearerAuth": {}}},
+	}
+
+	reflector := &jsonschema.Reflector{TypeMapper: openAPITypeMapper}
+	schema := func(ty reflect.Type) (*jsonschema.Type, error) {
+		s := reflector.ReflectFromType(ty)
+		for name, def := range s.Definitions {
+			if known, ok := doc.Components.Schemas[name]; ok && !sameSchema(known, def) {
+				return nil, fmt.Errorf("docgen: conflicting schemas of type %s", name)
+			}
+			doc.Components.Schemas[name] = def
+		}
+		s.Type.Version = ""
+		return s.Type, nil
+	}
+
+	for _, endpoint := range gateway.Endpoints() {
+		op := &openAPIOperation{
+			Summary:    endpoint.Summary,
+			Deprecated: endpoint.Deprecated,
+			Responses: map[string]*openAPIResponse{
+				strconv.Itoa(http.StatusBadRequest):          {Description: "Invalid request."},
+				strconv.Itoa(http.StatusInternalServerError): {Description: "Failed request."},
+			},
+		}
+		for _, param := range endpoint.Params {
+			schemaType := "string"
+			if param.In == "query" {
+				schemaType = "boolean"
+			}
+			op.Parameters = append(op.Parameters, openAPIParameter{
+				Name:        param.Name,
+				In:          param.In,
+				Description: param.Description,
+				Required:    param.In == "path",
+				Schema:      &jsonschema.Type{Type: schemaType},
+			})
+		}
+		if endpoint.Request != nil {
+			s, err := schema(endpoint.Request)
+			if err != nil {
+				return nil, err
+			}
+			op.RequestBody = &openAPIBody{
+				Required: true,
+				Content:  map[string]*openAPIMediaType{"application/json": {Schema: s}},
+			}
+		}
+
+		status, resp := http.StatusOK, &openAPIResponse{Description: "Successful request."}
+		switch {
+		case endpoint.Response != nil:
+			s, err := schema(endpoint.Response)
+			if err != nil {
+				return nil, err
+			}
+			resp.Content = map[string]*openAPIMediaType{"application/json": {Schema: s}}
+		case endpoint.ContentType != "":
+			resp.Content = map[string]*openAPIMediaType{endpoint.ContentType: {}}
+		default:
+			// WebSocket streams are served once the connection is upgraded
+			status, resp.Description = http.StatusSwitchingProtocols, "Switching to the WebSocket protocol."
+		}
+		op.Responses[strconv.Itoa(status)] = resp
+
+		if doc.Paths[endpoint.Path] == nil {
+			doc.Paths[endpoint.Path] = make(map[string]*openAPIOperation)
+		}
+		doc.Paths[endpoint.Path][strings.ToLower(endpoint.Method)] = op
+	}
+
+	out, err := json.MarshalIndent(doc, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	// definitions of JSON schemas are components of OpenAPI documents
+	out = []byte(strings.ReplaceAll(string(out), `"#/definitions/`, `"#/components/schemas/`))
+	return append(out, '\n'), nil
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// openAPITypeMapper describes types with custom JSON encodings by example, as their Go types don't
+// match their JSON.
+func openAPITypeMapper(ty reflect.Type) *jsonschema.Type {
+	if ty == timeType || ty.Kind() == reflect.Ptr ||
+		!(ty.Implements(jsonMarshalerType) || reflect.PtrTo(ty).Implements(jsonMarshalerType)) {
+		return nil
+	}
+
+	schema := &jsonschema.Type{Title: ty.Name(), Description: "See the example for the JSON encoding."}
+	example, ok := ExampleValues[reflect.PtrTo(ty)]
+	if !ok {
+		var err error
+		if example, err = ExampleValue(ty, ty); err != nil {
+			return schema
+		}
+	}
+	if example == nil {
+		return schema
+	}
+	raw, err := json.Marshal(example)
+	if err != nil {
+		return schema
+	}
+	var value interface{}
+	if err = json.Unmarshal(raw, &value); err != nil {
+		return schema
+	}
+	schema.Examples = []interface{}{value}
+	return schema
+}
+
+func sameSchema(a, b *jsonschema.Type) bool {
+	rawA, errA := json.Marshal(a)
+	rawB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(rawA) == string(rawB)
+}
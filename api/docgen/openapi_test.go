@@ -0,0 +1,17 @@
+package docgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/api/gateway"
+)
+
+func TestOpenAPIDocumentUpToDate(t *testing.T) {
+	doc, err := NewOpenAPIDocument()
+	require.NoError(t, err)
+	assert.Equal(t, string(doc), string(gateway.OpenAPISpec()),
+		"the OpenAPI document of the gateway is outdated, regenerate it with `make openapi-gen`")
+}
@@ -20,14 +20,13 @@ type AvailabilityResponse struct {
 }
 
 func (h *Handler) handleHeightAvailabilityRequest(w http.ResponseWriter, r *http.Request) {
-	heightStr := mux.Vars(r)[heightKey]
-	height, err := strconv.Atoi(heightStr)
+	height, err := h.parseHeight(r, mux.Vars(r)[heightKey])
 	if err != nil {
 		writeError(w, http.StatusBadRequest, heightAvailabilityEndpoint, err)
 		return
 	}
 
-	header, err := h.header.GetByHeight(r.Context(), uint64(height))
+	header, err := h.header.GetByHeight(r.Context(), height)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, heightAvailabilityEndpoint, err)
 		return
@@ -38,7 +37,7 @@ func (h *Handler) handleHeightAvailabilityRequest(w http.ResponseWriter, r *http
 			h.share.ProbabilityOfAvailability(r.Context()), 'g', -1, 64),
 	}
 
-	err = h.share.SharesAvailable(r.Context(), header.DAH)
+	err = h.share.SharesAvailable(share.WithHeight(r.Context(), height), header.DAH)
 	switch err {
 	case nil:
 		availResp.Available = true
@@ -0,0 +1,45 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/blob"
+)
+
+const namespacedBlobsEndpoint = "/namespaced_blobs"
+
+// NamespacedBlobsResponse represents the response to a
+// BlobsByNamespace request.
+type NamespacedBlobsResponse struct {
+	Blobs  []*blob.Blob `json:"blobs"`
+	Height uint64       `json:"height"`
+}
+
+func (h *Handler) handleBlobsByNamespaceRequest(w http.ResponseWriter, r *http.Request) {
+	height, nID, err := h.parseGetByNamespaceArgs(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, namespacedBlobsEndpoint, err)
+		return
+	}
+	blobs, err := h.blob.GetAll(r.Context(), height, []namespace.ID{nID})
+	if err != nil && !errors.Is(err, blob.ErrBlobNotFound) {
+		writeError(w, http.StatusInternalServerError, namespacedBlobsEndpoint, err)
+		return
+	}
+	resp, err := json.Marshal(&NamespacedBlobsResponse{
+		Blobs:  blobs,
+		Height: height,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, namespacedBlobsEndpoint, err)
+		return
+	}
+	_, err = w.Write(resp)
+	if err != nil {
+		log.Errorw("serving request", "endpoint", namespacedBlobsEndpoint, "err", err)
+	}
+}
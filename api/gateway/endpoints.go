@@ -9,45 +9,95 @@ func (h *Handler) RegisterEndpoints(rpc *Server, deprecatedEndpointsEnabled bool
 	if deprecatedEndpointsEnabled {
 		log.Warn("Deprecated endpoints will be removed from the gateway in the next release. Use the RPC instead.")
 		// state endpoints
-		rpc.RegisterHandlerFunc(balanceEndpoint, h.handleBalanceRequest, http.MethodGet)
-		rpc.RegisterHandlerFunc(submitPFBEndpoint, h.handleSubmitPFB, http.MethodPost)
+		rpc.RegisterHandlerFunc(balanceEndpoint, requireMethods(h.handleBalanceRequest, "state.Balance"),
+			http.MethodGet)
+		rpc.RegisterHandlerFunc(submitPFBEndpoint, requireMethods(h.handleSubmitPFB, "state.SubmitPayForBlob"),
+			http.MethodPost)
 
 		// staking queries
-		rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", queryDelegationEndpoint, addrKey), h.handleQueryDelegation,
+		rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", queryDelegationEndpoint, addrKey),
+			requireMethods(h.handleQueryDelegation, "state.QueryDelegation"),
 			http.MethodGet)
-		rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", queryUnbondingEndpoint, addrKey), h.handleQueryUnbonding,
+		rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", queryUnbondingEndpoint, addrKey),
+			requireMethods(h.handleQueryUnbonding, "state.QueryUnbonding"),
 			http.MethodGet)
-		rpc.RegisterHandlerFunc(queryRedelegationsEndpoint, h.handleQueryRedelegations,
+		rpc.RegisterHandlerFunc(queryRedelegationsEndpoint,
+			requireMethods(h.handleQueryRedelegations, "state.QueryRedelegations"),
 			http.MethodPost)
 
 		// DASer endpoints
 		// only register if DASer service is available
 		if h.das != nil {
-			rpc.RegisterHandlerFunc(dasStateEndpoint, h.handleDASStateRequest, http.MethodGet)
+			rpc.RegisterHandlerFunc(dasStateEndpoint, requireMethods(h.handleDASStateRequest, "das.SamplingStats"),
+				http.MethodGet)
 		}
 	}
 
 	// state endpoints
-	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", balanceEndpoint, addrKey), h.handleBalanceRequest,
-		http.MethodGet)
-	rpc.RegisterHandlerFunc(submitTxEndpoint, h.handleSubmitTx, http.MethodPost)
+	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", balanceEndpoint, addrKey),
+		requireMethods(h.handleBalanceRequest, "state.BalanceForAddress"), http.MethodGet)
+	rpc.RegisterHandlerFunc(submitTxEndpoint, requireMethods(h.handleSubmitTx, "state.SubmitTx"), http.MethodPost)
 
 	// share endpoints
+	shareMethods := []string{"header.GetByHeight", "share.GetSharesByNamespace"}
 	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}/height/{%s}", namespacedSharesEndpoint, nIDKey, heightKey),
-		h.handleSharesByNamespaceRequest, http.MethodGet)
+		requireMethods(h.handleSharesByNamespaceRequest, shareMethods...), http.MethodGet)
 	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", namespacedSharesEndpoint, nIDKey),
-		h.handleSharesByNamespaceRequest, http.MethodGet)
+		requireMethods(h.handleSharesByNamespaceRequest, shareMethods...), http.MethodGet)
 	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}/height/{%s}", namespacedDataEndpoint, nIDKey, heightKey),
-		h.handleDataByNamespaceRequest, http.MethodGet)
+		requireMethods(h.handleDataByNamespaceRequest, shareMethods...), http.MethodGet)
 	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", namespacedDataEndpoint, nIDKey),
-		h.handleDataByNamespaceRequest, http.MethodGet)
+		requireMethods(h.handleDataByNamespaceRequest, shareMethods...), http.MethodGet)
+
+	// blob endpoints
+	// only register if blob service is available
+	if h.blob != nil {
+		rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}/height/{%s}", namespacedBlobsEndpoint, nIDKey, heightKey),
+			requireMethods(h.handleBlobsByNamespaceRequest, "blob.GetAll"), http.MethodGet)
+		rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", namespacedBlobsEndpoint, nIDKey),
+			requireMethods(h.handleBlobsByNamespaceRequest, "blob.GetAll"), http.MethodGet)
+	}
 
 	// DAS endpoints
 	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", heightAvailabilityEndpoint, heightKey),
-		h.handleHeightAvailabilityRequest, http.MethodGet)
+		requireMethods(h.handleHeightAvailabilityRequest, "header.GetByHeight", "share.SharesAvailable"),
+		http.MethodGet)
 
 	// header endpoints
-	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", headerByHeightEndpoint, heightKey), h.handleHeaderRequest,
+	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}/{%s}", headerRangeEndpoint, fromKey, toKey),
+		requireMethods(h.handleHeaderRangeRequest, "header.GetByHeight", "header.GetVerifiedRangeByHeight"),
 		http.MethodGet)
-	rpc.RegisterHandlerFunc(headEndpoint, h.handleHeadRequest, http.MethodGet)
+	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", headerByHeightEndpoint, heightKey),
+		requireMethods(h.handleHeaderRequest, "header.GetByHeight"), http.MethodGet)
+	rpc.RegisterHandlerFunc(headEndpoint, requireMethods(h.handleHeadRequest, "header.LocalHead"), http.MethodGet)
+
+	rpc.RegisterHandlerFunc(openAPIEndpoint, handleOpenAPIRequest, http.MethodGet)
+
+	// IPFS endpoints
+	// only register if serving blocks is enabled
+	if h.blockstore != nil {
+		// blocks hold shares, so they are only served to tokens scoped to the whole share module
+		handleIPFS := requireMethods(h.handleIPFSRequest, "share")
+		rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", ipfsEndpoint, cidKey), handleIPFS, http.MethodGet)
+		rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", ipfsEndpoint, cidKey), handleIPFS, http.MethodHead)
+	}
+
+	// events endpoints
+	rpc.RegisterHandlerFunc(eventsEndpoint, requireMethods(h.handleEventsRequest, "header.Subscribe"),
+		http.MethodGet)
+	rpc.RegisterHandlerFunc(wsEventsEndpoint, requireMethods(h.handleWSEventsRequest, "header.Subscribe"),
+		http.MethodGet)
+	if h.blob != nil || h.share != nil {
+		methods := []string{"header.Subscribe"}
+		if h.share != nil {
+			methods = append(methods, "share.GetSharesByNamespace")
+		}
+		if h.blob != nil {
+			methods = append(methods, "blob.GetAll")
+		}
+		rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", eventsEndpoint, nIDKey),
+			requireMethods(h.handleEventsRequest, methods...), http.MethodGet)
+		rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", wsEventsEndpoint, nIDKey),
+			requireMethods(h.handleWSEventsRequest, methods...), http.MethodGet)
+	}
 }
@@ -0,0 +1,157 @@
+package gateway
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/blob"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+const eventsEndpoint = "/events"
+
+const (
+	headEvent   = "head"
+	sharesEvent = "shares"
+	blobsEvent  = "blobs"
+	errorEvent  = "error"
+)
+
+// eventsKeepAlive is how often a comment is sent to idle event streams, so that proxies don't
+// close them.
+var eventsKeepAlive = 15 * time.Second
+
+// handleEventsRequest streams new verified heads as server-sent events, so that browsers and
+// lightweight clients can follow the chain without WebSocket subscriptions. If a namespace is given,
+// every head is followed by the shares and the blobs of the namespace at its height.
+func (h *Handler) handleEventsRequest(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, eventsEndpoint, errors.New("streaming is not supported"))
+		return
+	}
+	nID, err := parseEventsNamespace(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, eventsEndpoint, err)
+		return
+	}
+	release, ok := h.subscriptions.acquire(r)
+	if !ok {
+		writeError(w, http.StatusTooManyRequests, eventsEndpoint, errTooManySubscriptions)
+		return
+	}
+	defer release()
+
+	heads, err := h.header.Subscribe(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, eventsEndpoint, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(eventsKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case head, ok := <-heads:
+			if !ok {
+				return
+			}
+			for _, e := range h.headEvents(r.Context(), head, nID) {
+				if err = writeEvent(w, e); err != nil {
+					log.Debugw("writing events", "endpoint", eventsEndpoint, "err", err)
+					return
+				}
+			}
+		case <-keepAlive.C:
+			if _, err = fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// event is an event of a new head, or of the data of the namespace at its height.
+type event struct {
+	Event string      `json:"event"`
+	Data  interface{} `json:"data"`
+}
+
+// parseEventsNamespace returns the namespace of the events request, if given.
+func parseEventsNamespace(r *http.Request) (namespace.ID, error) {
+	hexNID, ok := mux.Vars(r)[nIDKey]
+	if !ok {
+		return nil, nil
+	}
+	return hex.DecodeString(hexNID)
+}
+
+// headEvents returns the event of the head, followed by the events of the shares and the blobs of
+// the namespace at its height, if given. Failures to get the data of the namespace are returned as
+// error events, not to break the stream.
+func (h *Handler) headEvents(
+	ctx context.Context,
+	head *header.ExtendedHeader,
+	nID namespace.ID,
+) []event {
+	events := []event{{Event: headEvent, Data: head}}
+	if nID == nil {
+		return events
+	}
+
+	height := uint64(head.Height())
+	if h.share != nil {
+		shares, err := h.share.GetSharesByNamespace(share.WithHeight(ctx, height), head.DAH, nID)
+		if err != nil {
+			log.Errorw("getting shares", "endpoint", eventsEndpoint, "height", height, "err", err)
+			events = append(events, event{Event: errorEvent, Data: err.Error()})
+		} else {
+			flattened, _ := shares.Flatten()
+			events = append(events, event{Event: sharesEvent, Data: &NamespacedSharesResponse{
+				Shares: flattened,
+				Height: height,
+			}})
+		}
+	}
+	if h.blob != nil {
+		blobs, err := h.blob.GetAll(ctx, height, []namespace.ID{nID})
+		if err != nil && !errors.Is(err, blob.ErrBlobNotFound) {
+			log.Errorw("getting blobs", "endpoint", eventsEndpoint, "height", height, "err", err)
+			events = append(events, event{Event: errorEvent, Data: err.Error()})
+		} else {
+			events = append(events, event{Event: blobsEvent, Data: &NamespacedBlobsResponse{
+				Blobs:  blobs,
+				Height: height,
+			}})
+		}
+	}
+	return events
+}
+
+// writeEvent writes the JSON-encoded data of the event as a server-sent event.
+func writeEvent(w http.ResponseWriter, e event) error {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Event, data)
+	return err
+}
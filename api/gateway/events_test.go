@@ -0,0 +1,145 @@
+package gateway
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/blob"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	blobMock "github.com/celestiaorg/celestia-node/nodebuilder/blob/mocks"
+	headerMock "github.com/celestiaorg/celestia-node/nodebuilder/header/mocks"
+	shareMock "github.com/celestiaorg/celestia-node/nodebuilder/share/mocks"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestHandleEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	headerMod := headerMock.NewMockModule(ctrl)
+	blobMod := blobMock.NewMockModule(ctrl)
+	handler := NewHandler(nil, nil, headerMod, blobMod, nil)
+
+	head := headertest.RandExtendedHeader(t)
+	nID, err := share.NewNamespaceV0([]byte("events"))
+	require.NoError(t, err)
+	b, err := blob.NewBlob(0, nID, []byte("data"))
+	require.NoError(t, err)
+
+	subscribe := func() {
+		heads := make(chan *header.ExtendedHeader, 1)
+		heads <- head
+		close(heads)
+		headerMod.EXPECT().Subscribe(gomock.Any()).Return(heads, nil)
+	}
+
+	t.Run("heads", func(t *testing.T) {
+		subscribe()
+		rec := httptest.NewRecorder()
+		handler.handleEventsRequest(rec, httptest.NewRequest(http.MethodGet, eventsEndpoint, nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(rec.Body.String(), "event: head\ndata: "))
+		assert.NotContains(t, rec.Body.String(), "event: blobs")
+	})
+
+	t.Run("namespace blobs", func(t *testing.T) {
+		subscribe()
+		blobMod.EXPECT().GetAll(gomock.Any(), uint64(head.Height()), []namespace.ID{namespace.ID(nID)}).
+			Return([]*blob.Blob{b}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, eventsEndpoint, nil)
+		req = mux.SetURLVars(req, map[string]string{nIDKey: hex.EncodeToString(nID)})
+		rec := httptest.NewRecorder()
+		handler.handleEventsRequest(rec, req)
+
+		events := strings.Split(strings.TrimSpace(rec.Body.String()), "\n\n")
+		require.Len(t, events, 2)
+		assert.True(t, strings.HasPrefix(events[0], "event: head\n"))
+		assert.True(t, strings.HasPrefix(events[1], "event: blobs\n"))
+	})
+}
+
+func TestHandleWSEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	headerMod := headerMock.NewMockModule(ctrl)
+	shareMod := shareMock.NewMockModule(ctrl)
+	handler := NewHandler(nil, shareMod, headerMod, nil, nil)
+
+	head := headertest.RandExtendedHeader(t)
+	nID, err := share.NewNamespaceV0([]byte("events"))
+	require.NoError(t, err)
+
+	heads := make(chan *header.ExtendedHeader, 1)
+	heads <- head
+	close(heads)
+	headerMod.EXPECT().Subscribe(gomock.Any()).Return(heads, nil)
+	shareMod.EXPECT().GetSharesByNamespace(gomock.Any(), head.DAH, namespace.ID(nID)).
+		Return(share.NamespacedShares{}, nil)
+
+	router := mux.NewRouter()
+	router.HandleFunc(fmt.Sprintf("%s/{%s}", wsEventsEndpoint, nIDKey), handler.handleWSEventsRequest)
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + wsEventsEndpoint + "/" + hex.EncodeToString(nID)
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	var e struct {
+		Event string          `json:"event"`
+		Data  json.RawMessage `json:"data"`
+	}
+	require.NoError(t, conn.ReadJSON(&e))
+	assert.Equal(t, headEvent, e.Event)
+	require.NoError(t, conn.ReadJSON(&e))
+	assert.Equal(t, sharesEvent, e.Event)
+
+	// the connection is closed once the subscription ends
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway))
+}
+
+func TestSubscriptionLimiter(t *testing.T) {
+	limiter := newSubscriptionLimiter(2)
+	req := httptest.NewRequest(http.MethodGet, eventsEndpoint, nil)
+	req.RemoteAddr = "10.0.0.1:1000"
+	other := httptest.NewRequest(http.MethodGet, eventsEndpoint, nil)
+	other.RemoteAddr = "10.0.0.2:1000"
+
+	release, ok := limiter.acquire(req)
+	require.True(t, ok)
+	// the connections of a client count together
+	req.RemoteAddr = "10.0.0.1:2000"
+	_, ok = limiter.acquire(req)
+	require.True(t, ok)
+	_, ok = limiter.acquire(req)
+	assert.False(t, ok)
+	// other clients are not affected
+	_, ok = limiter.acquire(other)
+	assert.True(t, ok)
+
+	release()
+	_, ok = limiter.acquire(req)
+	assert.True(t, ok)
+
+	// the events endpoint rejects subscriptions over the limit
+	handler := NewHandler(nil, nil, nil, nil, nil, WithMaxSubscriptions(0))
+	rec := httptest.NewRecorder()
+	handler.handleEventsRequest(rec, req)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
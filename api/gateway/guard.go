@@ -0,0 +1,62 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+
+	"github.com/celestiaorg/celestia-node/api/rpc"
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+)
+
+// Guards are the guards of the RPC server, i.e. disabled modules, the audit log and rate limits,
+// which the Handler applies to the endpoints submitting transactions as well.
+type Guards interface {
+	Guard(ctx context.Context, module, method string, perm auth.Permission, params ...interface{}) (func(error), error)
+}
+
+// WithGuards makes the Handler apply the given guards to the endpoints submitting transactions.
+func WithGuards(guards Guards) HandlerOption {
+	return func(h *Handler) {
+		h.guards = guards
+	}
+}
+
+// guard applies the guards of the Handler, if any, to the call of the method of the module made by
+// the request. The guard error is written to the response, if any. Otherwise, the result of
+// the call must be passed to the returned func once the call is done.
+func (h *Handler) guard(
+	w http.ResponseWriter,
+	r *http.Request,
+	endpoint, module, method string,
+	params ...interface{},
+) (func(error), bool) {
+	if h.guards == nil {
+		return func(error) {}, true
+	}
+
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	token := strings.TrimPrefix(r.Header.Get(perms.AuthKey), "Bearer ")
+	ctx := rpc.WithClient(r.Context(), token, ip)
+
+	done, err := h.guards.Guard(ctx, module, method, methodPerm(module, method), params...)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, rpc.ErrModuleDisabled):
+			status = http.StatusServiceUnavailable
+		case errors.Is(err, rpc.ErrRateLimited):
+			status = http.StatusTooManyRequests
+		}
+		writeError(w, status, endpoint, err)
+		return nil, false
+	}
+	return done, true
+}
@@ -1,9 +1,15 @@
 package gateway
 
 import (
+	"github.com/cristalhq/jwt"
+	"github.com/gorilla/websocket"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
 	logging "github.com/ipfs/go-log/v2"
 
 	"github.com/celestiaorg/celestia-node/das"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	"github.com/celestiaorg/celestia-node/libs/httputil"
+	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
 	"github.com/celestiaorg/celestia-node/nodebuilder/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/share"
 	"github.com/celestiaorg/celestia-node/nodebuilder/state"
@@ -11,23 +17,108 @@ import (
 
 var log = logging.Logger("gateway")
 
+// DefaultMaxRangeSize is the default maximum amount of heights that can be requested
+// through a single range route.
+const DefaultMaxRangeSize = 100
+
+// DefaultMaxSubscriptions is the default maximum amount of event streams and WebSocket
+// subscriptions a single client can have open at once.
+const DefaultMaxSubscriptions = 16
+
 type Handler struct {
 	state  state.Module
 	share  share.Module
 	header header.Module
+	blob   blob.Module
 	das    *das.DASer
+	// blockstore serves NMT nodes by their CIDs. The IPFS endpoint is not registered if nil.
+	blockstore blockstore.Blockstore
+
+	maxRangeSize uint64
+	// signer verifies tokens of authenticated requests. Requests are not authenticated if nil.
+	signer       jwt.Signer
+	authRequired bool
+	// revocations rejects revoked tokens, if set
+	revocations *authtoken.Revocations
+	// guards are applied to the endpoints submitting transactions, if set
+	guards Guards
+	// upgrader upgrades requests of the WebSocket endpoint from allowed origins
+	upgrader websocket.Upgrader
+	// subscriptions caps the event subscriptions open by every client
+	subscriptions *subscriptionLimiter
+}
+
+// HandlerOption is a functional option that configures the Handler.
+type HandlerOption func(*Handler)
+
+// WithMaxRangeSize sets the maximum amount of heights that can be requested through a single
+// range route.
+func WithMaxRangeSize(size uint64) HandlerOption {
+	return func(h *Handler) {
+		h.maxRangeSize = size
+	}
+}
+
+// WithMaxSubscriptions sets the maximum amount of event streams and WebSocket subscriptions a
+// single client can have open at once.
+func WithMaxSubscriptions(limit int) HandlerOption {
+	return func(h *Handler) {
+		h.subscriptions = newSubscriptionLimiter(limit)
+	}
+}
+
+// WithAuth enables authentication of requests with tokens signed by the given signer.
+// Requests with namespace-scoped tokens can only access blobs and shares of their namespaces.
+// If required, requests without a token are rejected.
+func WithAuth(signer jwt.Signer, required bool) HandlerOption {
+	return func(h *Handler) {
+		h.signer = signer
+		h.authRequired = required
+	}
+}
+
+// WithRevocations makes authentication reject the given revoked tokens.
+func WithRevocations(revocations *authtoken.Revocations) HandlerOption {
+	return func(h *Handler) {
+		h.revocations = revocations
+	}
+}
+
+// WithAllowedOrigins makes the WebSocket endpoint accept the origins allowed by the CORS config of
+// the server. Only same-origin requests are accepted by default.
+func WithAllowedOrigins(cfg httputil.CORSConfig) HandlerOption {
+	return func(h *Handler) {
+		h.upgrader.CheckOrigin = httputil.CheckOrigin(cfg)
+	}
+}
+
+// WithBlockstore enables the IPFS endpoint serving NMT nodes of the given blockstore by their CIDs.
+func WithBlockstore(bs blockstore.Blockstore) HandlerOption {
+	return func(h *Handler) {
+		h.blockstore = bs
+	}
 }
 
 func NewHandler(
 	state state.Module,
 	share share.Module,
 	header header.Module,
+	blob blob.Module,
 	das *das.DASer,
+	opts ...HandlerOption,
 ) *Handler {
-	return &Handler{
-		state:  state,
-		share:  share,
-		header: header,
-		das:    das,
+	h := &Handler{
+		state:        state,
+		share:        share,
+		header:       header,
+		blob:         blob,
+		das:          das,
+		maxRangeSize: DefaultMaxRangeSize,
+
+		subscriptions: newSubscriptionLimiter(DefaultMaxSubscriptions),
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
@@ -3,7 +3,6 @@ package gateway
 import (
 	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -13,10 +12,13 @@ import (
 const (
 	headEndpoint           = "/head"
 	headerByHeightEndpoint = "/header"
+	headerRangeEndpoint    = "/header/range"
 )
 
 var (
 	heightKey = "height"
+	fromKey   = "from"
+	toKey     = "to"
 )
 
 func (h *Handler) handleHeadRequest(w http.ResponseWriter, r *http.Request) {
@@ -63,14 +65,13 @@ func (h *Handler) performGetHeaderRequest(
 ) (*header.ExtendedHeader, error) {
 	// read and parse request
 	vars := mux.Vars(r)
-	heightStr := vars[heightKey]
-	height, err := strconv.Atoi(heightStr)
+	height, err := h.parseHeight(r, vars[heightKey])
 	if err != nil {
 		writeError(w, http.StatusBadRequest, endpoint, err)
 		return nil, err
 	}
 
-	header, err := h.header.GetByHeight(r.Context(), uint64(height))
+	header, err := h.header.GetByHeight(r.Context(), height)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, endpoint, err)
 		return nil, err
@@ -78,3 +79,38 @@ func (h *Handler) performGetHeaderRequest(
 
 	return header, nil
 }
+
+// handleHeaderRangeRequest serves the verified headers in the inclusive range [from, to].
+func (h *Handler) handleHeaderRangeRequest(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	from, to, err := h.parseRange(r, vars[fromKey], vars[toKey])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, headerRangeEndpoint, err)
+		return
+	}
+
+	fromHeader, err := h.header.GetByHeight(r.Context(), from)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, headerRangeEndpoint, err)
+		return
+	}
+	headers := []*header.ExtendedHeader{fromHeader}
+	if to > from {
+		rng, err := h.header.GetVerifiedRangeByHeight(r.Context(), fromHeader, to+1)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, headerRangeEndpoint, err)
+			return
+		}
+		headers = append(headers, rng...)
+	}
+
+	resp, err := json.Marshal(headers)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, headerRangeEndpoint, err)
+		return
+	}
+	_, err = w.Write(resp)
+	if err != nil {
+		log.Errorw("writing response", "endpoint", headerRangeEndpoint, "err", err)
+	}
+}
@@ -0,0 +1,101 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// latestHeight is an alias that can be passed instead of a numeric height to any
+	// height-parameterized route. It resolves to the height of the local verified head.
+	latestHeight = "latest"
+	// sampledKey is an optional query parameter that makes the `latest` alias resolve to the
+	// highest height that was also successfully sampled by the DASer.
+	sampledKey = "sampled"
+)
+
+var (
+	errSamplingUnsupported = errors.New("sampled heights are not tracked by this node")
+	errZeroHeight          = errors.New("height must be greater than 0")
+)
+
+// parseHeight parses the given height string, resolving the `latest` alias if needed.
+// An empty height string is treated as `latest`.
+func (h *Handler) parseHeight(r *http.Request, heightStr string) (uint64, error) {
+	if heightStr == "" || heightStr == latestHeight {
+		sampled, err := parseSampled(r)
+		if err != nil {
+			return 0, err
+		}
+		return h.latestHeight(r.Context(), sampled)
+	}
+
+	height, err := strconv.ParseUint(heightStr, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if height == 0 {
+		return 0, errZeroHeight
+	}
+	return height, nil
+}
+
+// latestHeight returns the height of the local verified head. If sampled is set, the result is
+// capped by the head of the chain sampled by the DASer.
+func (h *Handler) latestHeight(ctx context.Context, sampled bool) (uint64, error) {
+	head, err := h.header.LocalHead(ctx)
+	if err != nil {
+		return 0, err
+	}
+	height := uint64(head.Height())
+	if !sampled {
+		return height, nil
+	}
+
+	if h.das == nil {
+		return 0, errSamplingUnsupported
+	}
+	stats, err := h.das.SamplingStats(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if stats.SampledChainHead == 0 {
+		return 0, fmt.Errorf("no heights have been sampled yet")
+	}
+	if stats.SampledChainHead < height {
+		height = stats.SampledChainHead
+	}
+	return height, nil
+}
+
+// parseSampled reads the optional `sampled` query parameter.
+func parseSampled(r *http.Request) (bool, error) {
+	sampledStr := r.URL.Query().Get(sampledKey)
+	if sampledStr == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(sampledStr)
+}
+
+// parseRange parses and resolves the given inclusive range bounds, ensuring the range is well
+// formed and does not exceed the maximum range size allowed by the Handler.
+func (h *Handler) parseRange(r *http.Request, fromStr, toStr string) (from, to uint64, err error) {
+	from, err = h.parseHeight(r, fromStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err = h.parseHeight(r, toStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if from > to {
+		return 0, 0, fmt.Errorf("invalid range: from %d is higher than to %d", from, to)
+	}
+	if size := to - from + 1; size > h.maxRangeSize {
+		return 0, 0, fmt.Errorf("range size %d exceeds the maximum of %d", size, h.maxRangeSize)
+	}
+	return from, to, nil
+}
@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	headerMock "github.com/celestiaorg/celestia-node/nodebuilder/header/mocks"
+)
+
+func TestHandler_parseHeight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := headerMock.NewMockModule(ctrl)
+	handler := NewHandler(nil, nil, mock, nil, nil, WithMaxRangeSize(10))
+
+	head := headertest.RandExtendedHeader(t)
+	mock.EXPECT().LocalHead(gomock.Any()).Return(head, nil).AnyTimes()
+
+	t.Run("numeric", func(t *testing.T) {
+		height, err := handler.parseHeight(httptest.NewRequest("GET", "/", nil), "42")
+		require.NoError(t, err)
+		assert.EqualValues(t, 42, height)
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		_, err := handler.parseHeight(httptest.NewRequest("GET", "/", nil), "0")
+		require.ErrorIs(t, err, errZeroHeight)
+	})
+
+	t.Run("latest", func(t *testing.T) {
+		height, err := handler.parseHeight(httptest.NewRequest("GET", "/", nil), latestHeight)
+		require.NoError(t, err)
+		assert.EqualValues(t, head.Height(), height)
+	})
+
+	t.Run("empty defaults to latest", func(t *testing.T) {
+		height, err := handler.parseHeight(httptest.NewRequest("GET", "/", nil), "")
+		require.NoError(t, err)
+		assert.EqualValues(t, head.Height(), height)
+	})
+
+	t.Run("sampled without DASer", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?sampled=true", nil)
+		_, err := handler.parseHeight(req, latestHeight)
+		require.ErrorIs(t, err, errSamplingUnsupported)
+	})
+
+	t.Run("range", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		from, to, err := handler.parseRange(req, "1", "10")
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, from)
+		assert.EqualValues(t, 10, to)
+
+		_, _, err = handler.parseRange(req, "1", "11")
+		require.Error(t, err)
+
+		_, _, err = handler.parseRange(req, "10", "1")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,95 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/ipfs/go-cid"
+	ipldformat "github.com/ipfs/go-ipld-format"
+
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+const (
+	ipfsEndpoint = "/ipfs"
+	cidKey       = "cid"
+	// rawBlockContentType is the content type of raw blocks of the trustless IPFS gateway
+	// specification.
+	rawBlockContentType = "application/vnd.ipld.raw"
+	// blocks are content-addressed, so they can be cached forever
+	immutableCacheControl = "public, max-age=29030400, immutable"
+)
+
+// handleIPFSRequest serves raw NMT nodes of the local blockstore by their CIDs, in the format of
+// the trustless IPFS gateway specification. Inner nodes hold the hashes of their children and
+// leaves hold the namespaced shares, so whole DAH trees can be traversed starting with the CIDs of
+// row and column roots.
+func (h *Handler) handleIPFSRequest(w http.ResponseWriter, r *http.Request) {
+	id, err := cid.Decode(mux.Vars(r)[cidKey])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ipfsEndpoint, err)
+		return
+	}
+	if err = ipld.ValidateCID(id); err != nil {
+		writeError(w, http.StatusBadRequest, ipfsEndpoint, err)
+		return
+	}
+	if format := r.URL.Query().Get("format"); format != "" && format != "raw" {
+		writeError(w, http.StatusBadRequest, ipfsEndpoint, fmt.Errorf("unsupported format %q", format))
+		return
+	}
+	if !acceptsRawBlock(r.Header.Get("Accept")) {
+		writeError(w, http.StatusNotAcceptable, ipfsEndpoint,
+			fmt.Errorf("only %s responses are supported", rawBlockContentType))
+		return
+	}
+
+	block, err := h.blockstore.Get(r.Context(), id)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ipldformat.ErrNotFound{}) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, ipfsEndpoint, err)
+		return
+	}
+
+	data := block.RawData()
+	w.Header().Set("Content-Type", rawBlockContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("Cache-Control", immutableCacheControl)
+	w.Header().Set("Etag", fmt.Sprintf(`"%s.raw"`, id))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("X-Ipfs-Path", r.URL.Path)
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, err = w.Write(data)
+	if err != nil {
+		log.Errorw("serving request", "endpoint", ipfsEndpoint, "err", err)
+	}
+}
+
+// acceptsRawBlock reports whether the given Accept header allows for raw blocks. Requests without
+// preferences get raw blocks, so that blocks can be fetched with plain HTTP clients.
+func acceptsRawBlock(accept string) bool {
+	if accept == "" {
+		return true
+	}
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(mediaRange)
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case rawBlockContentType, "application/octet-stream", "application/*", "*/*":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ipfs/go-blockservice"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+func TestIPFSEndpoint(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	bs := blockstore.NewBlockstore(dssync.MutexWrap(ds.NewMapDatastore()))
+	eds, err := share.AddShares(ctx, share.RandShares(t, 4), blockservice.New(bs, offline.Exchange(bs)))
+	require.NoError(t, err)
+	rowRoots := eds.RowRoots()
+	root := ipld.MustCidFromNamespacedSha256(rowRoots[0])
+
+	handler := NewHandler(nil, nil, nil, nil, nil, WithBlockstore(bs))
+	srv := NewServer("localhost", "0")
+	handler.RegisterEndpoints(srv, false)
+	handler.RegisterMiddleware(srv)
+
+	get := func(method, path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// the root links its children, which can be fetched in turn
+	rec := get(http.MethodGet, ipfsEndpoint+"/"+root.String(), rawBlockContentType)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, rawBlockContentType, rec.Header().Get("Content-Type"))
+	node, err := ipld.GetNode(ctx, blockservice.New(bs, offline.Exchange(bs)), root)
+	require.NoError(t, err)
+	assert.Equal(t, node.RawData(), rec.Body.Bytes())
+	for _, link := range node.Links() {
+		rec = get(http.MethodGet, ipfsEndpoint+"/"+link.Cid.String()+"?format=raw", "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	rec = get(http.MethodHead, ipfsEndpoint+"/"+root.String(), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Body.Bytes())
+
+	// unknown nodes are not found, while other CIDs and formats are rejected
+	unknown := make([]byte, ipld.NmtHashSize)
+	rec = get(http.MethodGet, ipfsEndpoint+"/"+ipld.MustCidFromNamespacedSha256(unknown).String(), "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = get(http.MethodGet, ipfsEndpoint+"/bafkqaaa", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = get(http.MethodGet, ipfsEndpoint+"/"+root.String()+"?format=car", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = get(http.MethodGet, ipfsEndpoint+"/"+root.String(), "application/vnd.ipld.car")
+	assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+}
@@ -2,20 +2,34 @@ package gateway
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
 	"time"
 
+	"github.com/filecoin-project/go-jsonrpc/auth"
 	"github.com/gorilla/mux"
 
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
+	"github.com/celestiaorg/celestia-node/nodebuilder/das"
+	"github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/share"
 	"github.com/celestiaorg/celestia-node/nodebuilder/state"
 )
 
 const timeout = time.Minute
 
 func (h *Handler) RegisterMiddleware(srv *Server) {
+	srv.RegisterMiddleware(setContentType)
+	if h.signer != nil {
+		srv.RegisterMiddleware(h.authenticate)
+	}
 	srv.RegisterMiddleware(
-		setContentType,
 		checkPostDisabled(h.state),
 		wrapRequestContext,
 	)
@@ -43,11 +57,124 @@ func checkPostDisabled(state state.Module) mux.MiddlewareFunc {
 }
 
 // wrapRequestContext ensures we implement a deadline on serving requests
-// via the gateway server-side to prevent context leaks.
+// via the gateway server-side to prevent context leaks. Event streams are long-lived and end with
+// their requests instead.
 func wrapRequestContext(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, eventsEndpoint) || strings.HasPrefix(r.URL.Path, wsEventsEndpoint) {
+			next.ServeHTTP(w, r)
+			return
+		}
 		ctx, cancel := context.WithTimeout(r.Context(), timeout)
 		defer cancel()
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// authenticate verifies the token provided in the header of the request the same way the RPC does.
+// Requests get the permissions of the token, which are checked by every endpoint against the
+// methods it invokes. Requests with namespace-scoped tokens get their context scoped to the namespaces and are only
+// allowed to access namespaces of the token. Requests with method-scoped tokens get their context
+// scoped to the methods.
+func (h *Handler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get(perms.AuthKey)
+		if token == "" {
+			if h.authRequired && r.URL.Path != openAPIEndpoint {
+				writeError(w, http.StatusUnauthorized, r.URL.Path, errors.New("missing token"))
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		payload, err := authtoken.VerifyPayload(h.signer, h.revocations, strings.TrimPrefix(token, "Bearer "))
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, r.URL.Path, err)
+			return
+		}
+		ctx := auth.WithPerm(r.Context(), payload.Allow)
+		if len(payload.Methods) != 0 {
+			ctx = perms.WithMethods(ctx, payload.Methods)
+		}
+		if len(payload.Namespaces) == 0 {
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
+		ctx = perms.WithNamespaces(ctx, payload.Namespaces)
+		// arbitrary transactions may pay for blobs of any namespace and blocks may hold shares of any
+		// namespace
+		if r.URL.Path == submitTxEndpoint || strings.HasPrefix(r.URL.Path, ipfsEndpoint+"/") {
+			writeError(w, http.StatusForbidden, r.URL.Path, perms.ErrNamespaceNotAllowed)
+			return
+		}
+		if hexNID, ok := mux.Vars(r)[nIDKey]; ok {
+			nID, err := hex.DecodeString(hexNID)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, r.URL.Path, err)
+				return
+			}
+			if err = perms.CheckNamespace(ctx, nID); err != nil {
+				writeError(w, http.StatusForbidden, r.URL.Path, err)
+				return
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requireMethods restricts the handler to requests with tokens granting the permissions of the
+// given methods of modules, e.g. "header.GetByHeight", or of whole modules, e.g. "share", as they
+// are served by invoking them. Tokens scoped to methods must be scoped to the given ones as well.
+// Requests without a token are not restricted, as they are only served if authentication is not
+// required.
+func requireMethods(next http.HandlerFunc, methods ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for _, method := range methods {
+			module, name, _ := strings.Cut(method, ".")
+			if perm := methodPerm(module, name); !auth.HasPerm(r.Context(), perms.AllPerms, perm) {
+				err := fmt.Errorf("missing permission to invoke '%s' (need '%s')", method, perm)
+				writeError(w, http.StatusForbidden, r.URL.Path, err)
+				return
+			}
+			if err := perms.CheckMethod(r.Context(), module, name); err != nil {
+				writeError(w, http.StatusForbidden, r.URL.Path, err)
+				return
+			}
+		}
+		next(w, r)
+	}
+}
+
+// methodPerms are the permissions of the methods of the modules served by the gateway, as tagged
+// on their RPC APIs.
+var methodPerms = map[string]map[string]auth.Permission{
+	"blob":   apiPerms(&blob.API{}),
+	"das":    apiPerms(&das.API{}),
+	"header": apiPerms(&header.API{}),
+	"share":  apiPerms(&share.API{}),
+	"state":  apiPerms(&state.API{}),
+}
+
+func apiPerms(api interface{}) map[string]auth.Permission {
+	internal := reflect.ValueOf(api).Elem().FieldByName("Internal").Type()
+	methods := make(map[string]auth.Permission, internal.NumField())
+	for i := 0; i < internal.NumField(); i++ {
+		field := internal.Field(i)
+		methods[field.Name] = auth.Permission(field.Tag.Get("perm"))
+	}
+	return methods
+}
+
+// methodPerm returns the permission required to invoke the method of the module. Whole modules
+// require the read permission, while unknown methods require the admin one.
+func methodPerm(module, method string) auth.Permission {
+	if method == "" {
+		return "read"
+	}
+	if perm, ok := methodPerms[module][method]; ok {
+		return perm
+	}
+	return "admin"
+}
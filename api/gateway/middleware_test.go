@@ -0,0 +1,89 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cristalhq/jwt"
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+)
+
+func TestAuthenticate(t *testing.T) {
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+	token, err := authtoken.NewSignedJWT(signer, perms.ReadWritePerms)
+	require.NoError(t, err)
+	scopedToken, err := authtoken.NewSignedJWT(signer, perms.ReadWritePerms, namespace.ID{0x01})
+	require.NoError(t, err)
+	shareToken, err := authtoken.NewSignedPayloadJWT(signer, &perms.JWTPayload{
+		Allow:   perms.ReadWritePerms,
+		Methods: []string{"share"},
+	})
+	require.NoError(t, err)
+	headerToken, err := authtoken.NewSignedPayloadJWT(signer, &perms.JWTPayload{
+		Allow:   perms.ReadWritePerms,
+		Methods: []string{"header.LocalHead"},
+	})
+	require.NoError(t, err)
+	readToken, err := authtoken.NewSignedJWT(signer, perms.ReadPerms)
+	require.NoError(t, err)
+	revokedToken, err := authtoken.NewSignedJWT(signer, perms.ReadWritePerms)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	revocations, err := authtoken.NewRevocations(ctx, ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	payload, err := authtoken.ExtractSignedPayload(signer, revokedToken)
+	require.NoError(t, err)
+	require.NoError(t, revocations.Revoke(ctx, payload.ID))
+
+	h := NewHandler(nil, nil, nil, nil, nil, WithAuth(signer, true), WithRevocations(revocations))
+	server := NewServer("localhost", "0")
+	server.RegisterMiddleware(h.authenticate)
+	ping := new(ping)
+	server.RegisterHandlerFunc(namespacedSharesEndpoint+"/{"+nIDKey+"}",
+		requireMethods(ping.ServeHTTP, "share.GetSharesByNamespace"), http.MethodGet)
+	server.RegisterHandlerFunc(submitTxEndpoint, requireMethods(ping.ServeHTTP, "state.SubmitTx"), http.MethodPost)
+
+	var tests = []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		status int
+	}{
+		{"missing token", http.MethodGet, namespacedSharesEndpoint + "/01", "", http.StatusUnauthorized},
+		{"invalid token", http.MethodGet, namespacedSharesEndpoint + "/01", "invalid", http.StatusUnauthorized},
+		{"unscoped token", http.MethodGet, namespacedSharesEndpoint + "/02", token, http.StatusOK},
+		{"allowed namespace", http.MethodGet, namespacedSharesEndpoint + "/01", scopedToken, http.StatusOK},
+		{"other namespace", http.MethodGet, namespacedSharesEndpoint + "/02", scopedToken, http.StatusForbidden},
+		{"scoped submit tx", http.MethodPost, submitTxEndpoint, scopedToken, http.StatusForbidden},
+		{"submit tx", http.MethodPost, submitTxEndpoint, token, http.StatusOK},
+		{"read-only submit tx", http.MethodPost, submitTxEndpoint, readToken, http.StatusForbidden},
+		{"read-only token", http.MethodGet, namespacedSharesEndpoint + "/01", readToken, http.StatusOK},
+		{"revoked token", http.MethodGet, namespacedSharesEndpoint + "/01", revokedToken, http.StatusUnauthorized},
+		{"allowed module", http.MethodGet, namespacedSharesEndpoint + "/01", shareToken, http.StatusOK},
+		{"other method", http.MethodGet, namespacedSharesEndpoint + "/01", headerToken, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set(perms.AuthKey, "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
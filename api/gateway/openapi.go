@@ -0,0 +1,274 @@
+package gateway
+
+import (
+	_ "embed"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/celestiaorg/celestia-node/das"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/state"
+)
+
+const openAPIEndpoint = "/openapi.json"
+
+// openAPISpec is the OpenAPI document of the gateway, generated from Endpoints by `make
+// openapi-gen`.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// OpenAPISpec returns the OpenAPI document of the gateway served on `/openapi.json`.
+func OpenAPISpec() []byte {
+	return openAPISpec
+}
+
+// Endpoint describes a route of the gateway for its OpenAPI document.
+type Endpoint struct {
+	// Path is the route in the format of the gateway's multiplexer, e.g. "/header/{height}".
+	Path    string
+	Method  string
+	Summary string
+	Params  []Param
+	// Request is the type of the JSON body of the request, if any.
+	Request reflect.Type
+	// Response is the type of the JSON body of the response, if any.
+	Response reflect.Type
+	// ContentType is the content type of responses which are not JSON, e.g. streams of events.
+	ContentType string
+	Deprecated  bool
+}
+
+// Param describes a parameter of a route.
+type Param struct {
+	Name string
+	// In is the location of the parameter, i.e. "path" or "query".
+	In          string
+	Description string
+}
+
+var (
+	heightParam = Param{
+		Name: heightKey,
+		In:   "path",
+		Description: "Height of the block. `" + latestHeight + "` resolves to the height of the local " +
+			"verified head.",
+	}
+	nIDParam = Param{
+		Name:        nIDKey,
+		In:          "path",
+		Description: "Hex-encoded namespace.",
+	}
+	addrParam = Param{
+		Name:        addrKey,
+		In:          "path",
+		Description: "Bech32-encoded account or validator address.",
+	}
+	cidParam = Param{
+		Name:        cidKey,
+		In:          "path",
+		Description: "CID of an inner or a leaf node of a row or column NMT.",
+	}
+	sampledParam = Param{
+		Name: sampledKey,
+		In:   "query",
+		Description: "Makes `" + latestHeight + "` resolve to the highest height sampled by the DASer " +
+			"instead.",
+	}
+)
+
+// Endpoints returns all routes of the gateway, including the deprecated ones and the ones only
+// registered when the respective modules are available.
+func Endpoints() []Endpoint {
+	return []Endpoint{
+		{
+			Path:     fmt.Sprintf("%s/{%s}", balanceEndpoint, addrKey),
+			Method:   http.MethodGet,
+			Summary:  "Balance of the given address.",
+			Params:   []Param{addrParam},
+			Response: reflect.TypeOf(state.Balance{}),
+		},
+		{
+			Path:       balanceEndpoint,
+			Method:     http.MethodGet,
+			Summary:    "Balance of the node's account.",
+			Response:   reflect.TypeOf(state.Balance{}),
+			Deprecated: true,
+		},
+		{
+			Path:     submitTxEndpoint,
+			Method:   http.MethodPost,
+			Summary:  "Submits the given hex-encoded raw transaction.",
+			Request:  reflect.TypeOf(submitTxRequest{}),
+			Response: reflect.TypeOf(state.TxResponse{}),
+		},
+		{
+			Path:       submitPFBEndpoint,
+			Method:     http.MethodPost,
+			Summary:    "Submits a PayForBlob transaction paying for the given hex-encoded data.",
+			Request:    reflect.TypeOf(submitPFBRequest{}),
+			Response:   reflect.TypeOf(state.TxResponse{}),
+			Deprecated: true,
+		},
+		{
+			Path:       fmt.Sprintf("%s/{%s}", queryDelegationEndpoint, addrKey),
+			Method:     http.MethodGet,
+			Summary:    "Delegation of the node's account to the given validator.",
+			Params:     []Param{addrParam},
+			Response:   reflect.TypeOf(stakingtypes.QueryDelegationResponse{}),
+			Deprecated: true,
+		},
+		{
+			Path:       fmt.Sprintf("%s/{%s}", queryUnbondingEndpoint, addrKey),
+			Method:     http.MethodGet,
+			Summary:    "Unbonding delegation of the node's account from the given validator.",
+			Params:     []Param{addrParam},
+			Response:   reflect.TypeOf(stakingtypes.QueryUnbondingDelegationResponse{}),
+			Deprecated: true,
+		},
+		{
+			Path:       queryRedelegationsEndpoint,
+			Method:     http.MethodPost,
+			Summary:    "Redelegations of the node's account between the given validators.",
+			Request:    reflect.TypeOf(queryRedelegationsRequest{}),
+			Response:   reflect.TypeOf(stakingtypes.QueryRedelegationsResponse{}),
+			Deprecated: true,
+		},
+		{
+			Path:       dasStateEndpoint,
+			Method:     http.MethodGet,
+			Summary:    "Sampling statistics of the DASer.",
+			Response:   reflect.TypeOf(das.SamplingStats{}),
+			Deprecated: true,
+		},
+		{
+			Path:     fmt.Sprintf("%s/{%s}/height/{%s}", namespacedSharesEndpoint, nIDKey, heightKey),
+			Method:   http.MethodGet,
+			Summary:  "Shares of the given namespace at the given height, along with their proofs.",
+			Params:   []Param{nIDParam, heightParam, sampledParam},
+			Response: reflect.TypeOf(NamespacedSharesResponse{}),
+		},
+		{
+			Path:     fmt.Sprintf("%s/{%s}", namespacedSharesEndpoint, nIDKey),
+			Method:   http.MethodGet,
+			Summary:  "Shares of the given namespace at the latest height, along with their proofs.",
+			Params:   []Param{nIDParam, sampledParam},
+			Response: reflect.TypeOf(NamespacedSharesResponse{}),
+		},
+		{
+			Path:     fmt.Sprintf("%s/{%s}/height/{%s}", namespacedDataEndpoint, nIDKey, heightKey),
+			Method:   http.MethodGet,
+			Summary:  "Data of the given namespace at the given height.",
+			Params:   []Param{nIDParam, heightParam, sampledParam},
+			Response: reflect.TypeOf(NamespacedDataResponse{}),
+		},
+		{
+			Path:     fmt.Sprintf("%s/{%s}", namespacedDataEndpoint, nIDKey),
+			Method:   http.MethodGet,
+			Summary:  "Data of the given namespace at the latest height.",
+			Params:   []Param{nIDParam, sampledParam},
+			Response: reflect.TypeOf(NamespacedDataResponse{}),
+		},
+		{
+			Path:     fmt.Sprintf("%s/{%s}/height/{%s}", namespacedBlobsEndpoint, nIDKey, heightKey),
+			Method:   http.MethodGet,
+			Summary:  "Blobs of the given namespace at the given height.",
+			Params:   []Param{nIDParam, heightParam, sampledParam},
+			Response: reflect.TypeOf(NamespacedBlobsResponse{}),
+		},
+		{
+			Path:     fmt.Sprintf("%s/{%s}", namespacedBlobsEndpoint, nIDKey),
+			Method:   http.MethodGet,
+			Summary:  "Blobs of the given namespace at the latest height.",
+			Params:   []Param{nIDParam, sampledParam},
+			Response: reflect.TypeOf(NamespacedBlobsResponse{}),
+		},
+		{
+			Path:     fmt.Sprintf("%s/{%s}", heightAvailabilityEndpoint, heightKey),
+			Method:   http.MethodGet,
+			Summary:  "Whether the data at the given height is available.",
+			Params:   []Param{heightParam, sampledParam},
+			Response: reflect.TypeOf(AvailabilityResponse{}),
+		},
+		{
+			Path:   fmt.Sprintf("%s/{%s}/{%s}", headerRangeEndpoint, fromKey, toKey),
+			Method: http.MethodGet,
+			Summary: "Verified headers in the given inclusive range of heights, up to the maximum range size of " +
+				"the gateway.",
+			Params: []Param{
+				{Name: fromKey, In: "path", Description: "First height of the range."},
+				{Name: toKey, In: "path", Description: "Last height of the range."},
+			},
+			Response: reflect.TypeOf([]*header.ExtendedHeader{}),
+		},
+		{
+			Path:     fmt.Sprintf("%s/{%s}", headerByHeightEndpoint, heightKey),
+			Method:   http.MethodGet,
+			Summary:  "Header at the given height.",
+			Params:   []Param{heightParam, sampledParam},
+			Response: reflect.TypeOf(header.ExtendedHeader{}),
+		},
+		{
+			Path:     headEndpoint,
+			Method:   http.MethodGet,
+			Summary:  "Local verified head of the chain.",
+			Response: reflect.TypeOf(header.ExtendedHeader{}),
+		},
+		{
+			Path:        eventsEndpoint,
+			Method:      http.MethodGet,
+			Summary:     "Server-sent events of new verified heads.",
+			ContentType: "text/event-stream",
+		},
+		{
+			Path:   fmt.Sprintf("%s/{%s}", eventsEndpoint, nIDKey),
+			Method: http.MethodGet,
+			Summary: "Server-sent events of new verified heads, each followed by the shares and the blobs of the " +
+				"given namespace.",
+			Params:      []Param{nIDParam},
+			ContentType: "text/event-stream",
+		},
+		{
+			Path:    wsEventsEndpoint,
+			Method:  http.MethodGet,
+			Summary: "WebSocket stream of new verified heads.",
+		},
+		{
+			Path:   fmt.Sprintf("%s/{%s}", wsEventsEndpoint, nIDKey),
+			Method: http.MethodGet,
+			Summary: "WebSocket stream of new verified heads, each followed by the shares and the blobs of the " +
+				"given namespace.",
+			Params: []Param{nIDParam},
+		},
+		{
+			Path:        fmt.Sprintf("%s/{%s}", ipfsEndpoint, cidKey),
+			Method:      http.MethodGet,
+			Summary:     "Raw NMT node of the given CID from the local blockstore, if the IPFS endpoint is enabled.",
+			Params:      []Param{cidParam},
+			ContentType: rawBlockContentType,
+		},
+		{
+			Path:        fmt.Sprintf("%s/{%s}", ipfsEndpoint, cidKey),
+			Method:      http.MethodHead,
+			Summary:     "Whether the local blockstore has the NMT node of the given CID.",
+			Params:      []Param{cidParam},
+			ContentType: rawBlockContentType,
+		},
+		{
+			Path:        openAPIEndpoint,
+			Method:      http.MethodGet,
+			Summary:     "OpenAPI document of the gateway.",
+			ContentType: "application/json",
+		},
+	}
+}
+
+func handleOpenAPIRequest(w http.ResponseWriter, _ *http.Request) {
+	_, err := w.Write(openAPISpec)
+	if err != nil {
+		log.Errorw("writing response", "endpoint", openAPIEndpoint, "err", err)
+	}
+}
@@ -0,0 +1,67 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cristalhq/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/das"
+	blobMock "github.com/celestiaorg/celestia-node/nodebuilder/blob/mocks"
+)
+
+func TestOpenAPI(t *testing.T) {
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+	ctrl := gomock.NewController(t)
+	bs := blockstore.NewBlockstore(dssync.MutexWrap(ds.NewMapDatastore()))
+	handler := NewHandler(nil, nil, nil, blobMock.NewMockModule(ctrl), &das.DASer{},
+		WithAuth(signer, true), WithBlockstore(bs))
+	srv := NewServer("localhost", "0")
+	handler.RegisterEndpoints(srv, true)
+
+	// all routes are described, so that the OpenAPI document covers them
+	documented := make(map[string]bool)
+	for _, endpoint := range Endpoints() {
+		documented[endpoint.Method+" "+endpoint.Path] = true
+	}
+	var routes int
+	err = srv.srvMux.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return err
+		}
+		for _, method := range methods {
+			assert.True(t, documented[method+" "+path], fmt.Sprintf("%s %s is not described", method, path))
+			routes++
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, len(documented), routes)
+
+	// the document is served without auth
+	handler.RegisterMiddleware(srv)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, openAPIEndpoint, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var doc struct {
+		Paths map[string]any `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Contains(t, doc.Paths, headEndpoint)
+}
@@ -2,12 +2,15 @@ package gateway
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 	"net/http"
 	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/mux"
+
+	"github.com/celestiaorg/celestia-node/libs/httputil"
 )
 
 // Server represents a gateway server on the Node.
@@ -17,6 +20,11 @@ type Server struct {
 	listener net.Listener
 
 	started atomic.Bool
+
+	// cors allows browsers to call the server from other origins, if set
+	cors http.Handler
+	// tls terminates TLS of the connections, if set
+	tls *tls.Config
 }
 
 // NewServer returns a new gateway Server.
@@ -36,6 +44,17 @@ func NewServer(address, port string) *Server {
 	return server
 }
 
+// SetTLS makes the server serve HTTPS with the given config. It must be called before Start.
+func (s *Server) SetTLS(cfg *tls.Config) {
+	s.tls = cfg
+}
+
+// SetCORS makes the server allow browsers to call it from the configured origins. It must be
+// called before Start.
+func (s *Server) SetCORS(cfg httputil.CORSConfig) {
+	s.cors = httputil.WithCORS(cfg, s.srvMux)
+}
+
 // Start starts the gateway Server, listening on the given address.
 func (s *Server) Start(context.Context) error {
 	couldStart := s.started.CompareAndSwap(false, true)
@@ -47,8 +66,11 @@ func (s *Server) Start(context.Context) error {
 	if err != nil {
 		return err
 	}
+	if s.tls != nil {
+		listener = tls.NewListener(listener, s.tls)
+	}
 	s.listener = listener
-	log.Infow("server started", "listening on", s.srv.Addr)
+	log.Infow("server started", "listening on", s.srv.Addr, "tls", s.tls != nil)
 	//nolint:errcheck
 	go s.srv.Serve(listener)
 	return nil
@@ -87,6 +109,10 @@ func (s *Server) RegisterHandlerFunc(pattern string, handlerFunc http.HandlerFun
 
 // ServeHTTP serves inbound requests on the Server.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.cors != nil {
+		s.cors.ServeHTTP(w, r)
+		return
+	}
 	s.srvMux.ServeHTTP(w, r)
 }
 
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/libs/httputil"
 )
 
 func TestServer(t *testing.T) {
@@ -42,6 +45,39 @@ func TestServer(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestServer_TLSAndCORS(t *testing.T) {
+	// borrow the self-signed certificate of the test server and the client trusting it
+	ts := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(ts.Close)
+
+	server := NewServer("127.0.0.1", "0")
+	server.SetTLS(ts.TLS.Clone())
+	server.SetCORS(httputil.CORSConfig{AllowedOrigins: []string{"https://example.com"}})
+	server.RegisterHandlerFunc("/ping", new(ping).ServeHTTP, http.MethodGet)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	require.NoError(t, server.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop(ctx))
+	})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("https://%s/ping", server.ListenAddr()), nil)
+	require.NoError(t, err)
+	req.Header.Set("Origin", "https://example.com")
+	resp, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		resp.Body.Close()
+	})
+
+	buf, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "pong", string(buf))
+	assert.Equal(t, "https://example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
 // TestServer_contextLeakProtection tests to ensure a context
 // deadline was added by the context wrapper middleware server-side.
 func TestServer_contextLeakProtection(t *testing.T) {
@@ -5,7 +5,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -37,7 +36,7 @@ type NamespacedDataResponse struct {
 }
 
 func (h *Handler) handleSharesByNamespaceRequest(w http.ResponseWriter, r *http.Request) {
-	height, nID, err := parseGetByNamespaceArgs(r)
+	height, nID, err := h.parseGetByNamespaceArgs(r)
 	if err != nil {
 		writeError(w, http.StatusBadRequest, namespacedSharesEndpoint, err)
 		return
@@ -62,7 +61,7 @@ func (h *Handler) handleSharesByNamespaceRequest(w http.ResponseWriter, r *http.
 }
 
 func (h *Handler) handleDataByNamespaceRequest(w http.ResponseWriter, r *http.Request) {
-	height, nID, err := parseGetByNamespaceArgs(r)
+	height, nID, err := h.parseGetByNamespaceArgs(r)
 	if err != nil {
 		writeError(w, http.StatusBadRequest, namespacedDataEndpoint, err)
 		return
@@ -97,12 +96,13 @@ func (h *Handler) getShares(ctx context.Context, height uint64, nID namespace.ID
 		return nil, err
 	}
 
-	shares, err := h.share.GetSharesByNamespace(ctx, header.DAH, nID)
+	shares, err := h.share.GetSharesByNamespace(share.WithHeight(ctx, height), header.DAH, nID)
 	if err != nil {
 		return nil, err
 	}
 
-	return shares.Flatten(), nil
+	flattened, _ := shares.Flatten()
+	return flattened, nil
 }
 
 func dataFromShares(input []share.Share) (data [][]byte, err error) {
@@ -124,14 +124,12 @@ func dataFromShares(input []share.Share) (data [][]byte, err error) {
 	return data, nil
 }
 
-func parseGetByNamespaceArgs(r *http.Request) (height uint64, nID namespace.ID, err error) {
+func (h *Handler) parseGetByNamespaceArgs(r *http.Request) (height uint64, nID namespace.ID, err error) {
 	vars := mux.Vars(r)
 	// if a height was given, parse it, otherwise get namespaced shares/data from the latest header
-	if strHeight, ok := vars[heightKey]; ok {
-		height, err = strconv.ParseUint(strHeight, 10, 64)
-		if err != nil {
-			return 0, nil, err
-		}
+	height, err = h.parseHeight(r, vars[heightKey])
+	if err != nil {
+		return 0, nil, err
 	}
 	hexNID := vars[nIDKey]
 	nID, err = hex.DecodeString(hexNID)
@@ -11,6 +11,7 @@ import (
 
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
 	"github.com/celestiaorg/celestia-node/blob"
 	"github.com/celestiaorg/celestia-node/state"
 )
@@ -105,8 +106,13 @@ func (h *Handler) handleSubmitTx(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, submitTxEndpoint, err)
 		return
 	}
+	done, ok := h.guard(w, r, submitTxEndpoint, "state", "SubmitTx", rawTx)
+	if !ok {
+		return
+	}
 	// perform request
 	txResp, err := h.state.SubmitTx(r.Context(), rawTx)
+	done(err)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, submitTxEndpoint, err)
 		return
@@ -136,6 +142,10 @@ func (h *Handler) handleSubmitPFB(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, submitPFBEndpoint, err)
 		return
 	}
+	if err = perms.CheckNamespace(r.Context(), nID); err != nil {
+		writeError(w, http.StatusForbidden, submitPFBEndpoint, err)
+		return
+	}
 	data, err := hex.DecodeString(req.Data)
 	if err != nil {
 		writeError(w, http.StatusBadRequest, submitPFBEndpoint, err)
@@ -149,8 +159,14 @@ func (h *Handler) handleSubmitPFB(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	blobs := []*blob.Blob{constructedBlob}
+	done, ok := h.guard(w, r, submitPFBEndpoint, "state", "SubmitPayForBlob", fee, req.GasLimit, blobs)
+	if !ok {
+		return
+	}
 	// perform request
-	txResp, txerr := h.state.SubmitPayForBlob(r.Context(), fee, req.GasLimit, []*blob.Blob{constructedBlob})
+	txResp, txerr := h.state.SubmitPayForBlob(r.Context(), fee, req.GasLimit, blobs)
+	done(txerr)
 	if txerr != nil && txResp == nil {
 		// no tx data to return
 		writeError(w, http.StatusInternalServerError, submitPFBEndpoint, err)
@@ -2,16 +2,20 @@ package gateway
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/filecoin-project/go-jsonrpc/auth"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/celestiaorg/celestia-node/api/rpc"
 	stateMock "github.com/celestiaorg/celestia-node/nodebuilder/state/mocks"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/state"
@@ -20,7 +24,7 @@ import (
 func TestHandleSubmitPFB(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mock := stateMock.NewMockModule(ctrl)
-	handler := NewHandler(mock, nil, nil, nil)
+	handler := NewHandler(mock, nil, nil, nil, nil)
 
 	t.Run("partial response", func(t *testing.T) {
 		txResponse := state.TxResponse{
@@ -55,3 +59,45 @@ func TestHandleSubmitPFB(t *testing.T) {
 		require.Equal(t, resp, txResponse)
 	})
 }
+
+func TestHandleSubmitTx_Guarded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := stateMock.NewMockModule(ctrl)
+	guards := &fakeGuards{err: fmt.Errorf("%w: state", rpc.ErrModuleDisabled)}
+	handler := NewHandler(mock, nil, nil, nil, nil, WithGuards(guards))
+
+	bs, err := json.Marshal(submitTxRequest{Tx: "DEADBEEF"})
+	require.NoError(t, err)
+	respRec := httptest.NewRecorder()
+	handler.handleSubmitTx(respRec, httptest.NewRequest(http.MethodPost, submitTxEndpoint, bytes.NewReader(bs)))
+	// the state module is not called while disabled
+	require.Equal(t, http.StatusServiceUnavailable, respRec.Code)
+	require.Equal(t, "state.SubmitTx", guards.method)
+
+	guards.err = nil
+	mock.EXPECT().SubmitTx(gomock.Any(), gomock.Any()).Return(&state.TxResponse{}, nil)
+	respRec = httptest.NewRecorder()
+	handler.handleSubmitTx(respRec, httptest.NewRequest(http.MethodPost, submitTxEndpoint, bytes.NewReader(bs)))
+	require.Equal(t, http.StatusOK, respRec.Code)
+	require.True(t, guards.done)
+}
+
+// fakeGuards records the guarded method and fails with err, if set.
+type fakeGuards struct {
+	err    error
+	method string
+	done   bool
+}
+
+func (g *fakeGuards) Guard(
+	_ context.Context,
+	module, method string,
+	_ auth.Permission,
+	_ ...interface{},
+) (func(error), error) {
+	g.method = module + "." + method
+	if g.err != nil {
+		return nil, g.err
+	}
+	return func(error) { g.done = true }, nil
+}
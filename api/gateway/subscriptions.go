@@ -0,0 +1,132 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const wsEventsEndpoint = "/ws/events"
+
+// wsWriteTimeout is the time a WebSocket client has to receive a message.
+var wsWriteTimeout = 10 * time.Second
+
+// handleWSEventsRequest pushes new verified heads to WebSocket clients as JSON messages of the
+// form {"event": ..., "data": ...}. If a namespace is given, every head is followed by the shares
+// and the blobs of the namespace at its height. The messages are the same as the events of the
+// server-sent events endpoint.
+func (h *Handler) handleWSEventsRequest(w http.ResponseWriter, r *http.Request) {
+	nID, err := parseEventsNamespace(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, wsEventsEndpoint, err)
+		return
+	}
+	release, ok := h.subscriptions.acquire(r)
+	if !ok {
+		writeError(w, http.StatusTooManyRequests, wsEventsEndpoint, errTooManySubscriptions)
+		return
+	}
+	defer release()
+
+	// the connection outlives the request once hijacked, so it ends when the client is gone
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	heads, err := h.header.Subscribe(ctx)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, wsEventsEndpoint, err)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader responds with the error
+		log.Debugw("upgrading connection", "endpoint", wsEventsEndpoint, "err", err)
+		return
+	}
+	defer conn.Close()
+
+	// reading is required to process control messages, and detects closed connections
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	keepAlive := time.NewTicker(eventsKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case head, ok := <-heads:
+			if !ok {
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, "subscription ended"),
+					time.Now().Add(wsWriteTimeout))
+				return
+			}
+			for _, e := range h.headEvents(ctx, head, nID) {
+				_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+				if err = conn.WriteJSON(e); err != nil {
+					log.Debugw("writing events", "endpoint", wsEventsEndpoint, "err", err)
+					return
+				}
+			}
+		case <-keepAlive.C:
+			if err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+var errTooManySubscriptions = errors.New("too many open subscriptions")
+
+// subscriptionLimiter caps the event streams and WebSocket subscriptions open by every client, so
+// that a single client can't make the node hold an unbounded amount of header subscriptions.
+// Clients are told apart by their address, as they may open every subscription over a connection
+// of its own.
+type subscriptionLimiter struct {
+	limit int
+
+	lk   sync.Mutex
+	open map[string]int
+}
+
+func newSubscriptionLimiter(limit int) *subscriptionLimiter {
+	return &subscriptionLimiter{
+		limit: limit,
+		open:  make(map[string]int),
+	}
+}
+
+// acquire reserves a subscription for the client of the request, unless it has the maximum
+// amount of subscriptions open already. The returned func releases the reserved subscription.
+func (l *subscriptionLimiter) acquire(r *http.Request) (release func(), ok bool) {
+	client, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		client = r.RemoteAddr
+	}
+
+	l.lk.Lock()
+	defer l.lk.Unlock()
+	if l.open[client] >= l.limit {
+		return nil, false
+	}
+	l.open[client]++
+	return func() {
+		l.lk.Lock()
+		defer l.lk.Unlock()
+		if l.open[client]--; l.open[client] == 0 {
+			delete(l.open, client)
+		}
+	}, true
+}
@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+)
+
+// authKey is the metadata key of the token, which gRPC lowercases.
+var authKey = strings.ToLower(perms.AuthKey)
+
+const (
+	headerServicePrefix = "/api.grpc.node.HeaderService/"
+	shareServicePrefix  = "/api.grpc.node.ShareService/"
+	// blobServicePrefix prefixes the methods of the blob service, which is the only one enforcing
+	// the namespaces of namespace-scoped tokens.
+	blobServicePrefix = "/api.grpc.node.BlobService/"
+)
+
+// methodPerms are the permissions required by methods other than reading ones. They match the
+// permissions of the methods of the JSON-RPC.
+var methodPerms = map[string]auth.Permission{
+	headerServicePrefix + "GetByHash":           "public",
+	headerServicePrefix + "GetByHeight":         "public",
+	headerServicePrefix + "NetworkHead":         "public",
+	headerServicePrefix + "Subscribe":           "public",
+	shareServicePrefix + "SharesAvailable":      "public",
+	shareServicePrefix + "GetShare":             "public",
+	shareServicePrefix + "GetEDS":               "public",
+	shareServicePrefix + "GetSharesByNamespace": "public",
+	blobServicePrefix + "Submit":                "write",
+}
+
+func (s *Server) unaryAuth(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	ctx, err := s.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) streamAuth(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := s.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authorize grants the context the permissions of the token in the metadata of the request and
+// checks they allow the method, the same way the JSON-RPC does. Requests without a token are granted
+// the default permissions.
+func (s *Server) authorize(ctx context.Context, method string) (context.Context, error) {
+	allowed := perms.DefaultPerms
+	var namespaces []namespace.ID
+	md, _ := metadata.FromIncomingContext(ctx)
+	if tokens := md.Get(authKey); len(tokens) != 0 {
+		token, ok := strings.CutPrefix(tokens[0], "Bearer ")
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "missing Bearer prefix in auth token")
+		}
+		payload, err := authtoken.VerifyPayload(s.auth, s.revocations, token)
+		if errors.Is(err, authtoken.ErrRevoked) {
+			log.Warnw("revoked JWT used", "err", err)
+			return nil, status.Error(codes.Unauthenticated, "revoked auth token")
+		}
+		if err != nil {
+			log.Warnw("JWT verification failed", "err", err)
+			return nil, status.Error(codes.Unauthenticated, "invalid auth token")
+		}
+		if len(payload.Methods) != 0 {
+			module, name := moduleMethod(method)
+			err = perms.CheckMethod(perms.WithMethods(ctx, payload.Methods), module, name)
+			if err != nil {
+				return nil, status.Error(codes.PermissionDenied, err.Error())
+			}
+		}
+		allowed, namespaces = payload.Allow, payload.Namespaces
+		if len(namespaces) != 0 && !strings.HasPrefix(method, blobServicePrefix) {
+			// namespace-scoped tokens are granted the default permissions on other services
+			allowed = perms.DefaultPerms
+		}
+	}
+
+	perm := methodPerm(method)
+	ctx = auth.WithPerm(ctx, allowed)
+	if !auth.HasPerm(ctx, perms.DefaultPerms, perm) {
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission to invoke '%s' (need '%s')", method, perm)
+	}
+	if len(namespaces) != 0 {
+		ctx = perms.WithNamespaces(ctx, namespaces)
+	}
+	return ctx, nil
+}
+
+// methodPerm returns the permission required by the full gRPC method.
+func methodPerm(method string) auth.Permission {
+	perm, ok := methodPerms[method]
+	if !ok {
+		perm = "read"
+	}
+	return perm
+}
+
+// moduleMethod returns the module mirrored by the service of the full gRPC method, and the name of
+// the method, e.g. "blob" and "Submit" for "/api.grpc.node.BlobService/Submit".
+func moduleMethod(fullMethod string) (string, string) {
+	service, name, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	service = service[strings.LastIndex(service, ".")+1:]
+	return strings.ToLower(strings.TrimSuffix(service, "Service")), name
+}
+
+// authedStream is a stream with the authorized context.
+type authedStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *authedStream) Context() context.Context {
+	return s.ctx
+}
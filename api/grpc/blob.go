@@ -0,0 +1,184 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	pb "github.com/celestiaorg/celestia-node/api/grpc/pb"
+	"github.com/celestiaorg/celestia-node/blob"
+	blobMod "github.com/celestiaorg/celestia-node/nodebuilder/blob"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var errNamespaceSize = errors.New("invalid namespace size")
+
+type blobService struct {
+	mod blobMod.Module
+}
+
+func (s *blobService) Submit(ctx context.Context, req *pb.SubmitRequest) (*pb.SubmitResponse, error) {
+	blobs := make([]*blob.Blob, len(req.Blobs))
+	for i, b := range req.Blobs {
+		var err error
+		if blobs[i], err = fromBlob(b); err != nil {
+			return nil, invalidArgument(err)
+		}
+	}
+
+	var opts *blob.GasOptions
+	if req.Options != nil {
+		opts = &blob.GasOptions{GasLimit: req.Options.GasLimit, GasPrice: req.Options.GasPrice}
+	}
+	height, err := s.mod.SubmitAll(ctx, blobs, opts)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.SubmitResponse{Height: height}, nil
+}
+
+func (s *blobService) Get(ctx context.Context, req *pb.GetBlobRequest) (*pb.Blob, error) {
+	if len(req.Namespace) != share.NamespaceSize {
+		return nil, invalidArgument(errNamespaceSize)
+	}
+	b, err := s.mod.Get(ctx, req.Height, req.Namespace, req.Commitment)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toBlob(b), nil
+}
+
+func (s *blobService) GetAll(req *pb.GetAllRequest, stream pb.BlobService_GetAllServer) error {
+	nIDs := make([]namespace.ID, len(req.Namespaces))
+	for i, nID := range req.Namespaces {
+		if len(nID) != share.NamespaceSize {
+			return invalidArgument(errNamespaceSize)
+		}
+		nIDs[i] = nID
+	}
+	blobs, err := s.mod.GetAll(stream.Context(), req.Height, nIDs)
+	if err != nil {
+		return statusError(err)
+	}
+	for _, b := range blobs {
+		if err = stream.Send(toBlob(b)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *blobService) GetProof(ctx context.Context, req *pb.GetBlobRequest) (*pb.BlobProof, error) {
+	if len(req.Namespace) != share.NamespaceSize {
+		return nil, invalidArgument(errNamespaceSize)
+	}
+	proof, err := s.mod.GetProof(ctx, req.Height, req.Namespace, req.Commitment)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toBlobProof(proof), nil
+}
+
+func (s *blobService) Included(ctx context.Context, req *pb.IncludedRequest) (*pb.IncludedResponse, error) {
+	if len(req.Namespace) != share.NamespaceSize {
+		return nil, invalidArgument(errNamespaceSize)
+	}
+	proof, err := fromBlobProof(req.Proof)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	included, err := s.mod.Included(ctx, req.Height, req.Namespace, proof, req.Commitment)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.IncludedResponse{Included: included}, nil
+}
+
+func (s *blobService) Subscribe(req *pb.SubscribeBlobsRequest, stream pb.BlobService_SubscribeServer) error {
+	if len(req.Namespace) != share.NamespaceSize {
+		return invalidArgument(errNamespaceSize)
+	}
+	events, err := s.mod.Subscribe(stream.Context(), req.Namespace)
+	if err != nil {
+		return statusError(err)
+	}
+	for e := range events {
+		event := &pb.BlobEvent{Height: e.Height, Error: e.Error}
+		for _, b := range e.Blobs {
+			event.Blobs = append(event.Blobs, toBlob(b))
+		}
+		if err = stream.Send(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toBlob(b *blob.Blob) *pb.Blob {
+	return &pb.Blob{
+		Namespace:    b.Namespace(),
+		Data:         b.Data,
+		ShareVersion: b.ShareVersion,
+		Commitment:   b.Commitment,
+	}
+}
+
+// fromBlob converts the blob the same way it is decoded from JSON, so that missing commitments are
+// computed and given ones are verified on submission.
+func fromBlob(b *pb.Blob) (*blob.Blob, error) {
+	if len(b.Namespace) != share.NamespaceSize {
+		return nil, errNamespaceSize
+	}
+	out := &blob.Blob{Commitment: b.Commitment}
+	out.Blob.NamespaceVersion = uint32(b.Namespace[0])
+	out.Blob.NamespaceId = b.Namespace[1:]
+	out.Blob.Data = b.Data
+	out.Blob.ShareVersion = b.ShareVersion
+	return out, nil
+}
+
+func toBlobProof(proof *blob.Proof) *pb.BlobProof {
+	out := &pb.BlobProof{
+		Namespace:  proof.Namespace,
+		Commitment: proof.Commitment,
+		Start:      uint32(proof.Start),
+		End:        uint32(proof.End),
+		Dah:        toRoot(proof.DAH),
+	}
+	for _, row := range proof.Rows {
+		out.Rows = append(out.Rows, &pb.BlobProofRow{
+			Index:  uint32(row.Index),
+			Root:   row.Root,
+			Shares: row.Shares,
+			Proof:  toNamespaceProof(row.Proof),
+		})
+	}
+	return out
+}
+
+func fromBlobProof(proof *pb.BlobProof) (*blob.Proof, error) {
+	if proof == nil {
+		return nil, errors.New("missing proof")
+	}
+	dah, err := fromRoot(proof.Dah)
+	if err != nil {
+		return nil, err
+	}
+	out := &blob.Proof{
+		Namespace:  proof.Namespace,
+		Commitment: proof.Commitment,
+		Start:      int(proof.Start),
+		End:        int(proof.End),
+		DAH:        dah,
+	}
+	for _, row := range proof.Rows {
+		out.Rows = append(out.Rows, &blob.ProofRow{
+			Index:  int(row.Index),
+			Root:   row.Root,
+			Shares: row.Shares,
+			Proof:  fromNamespaceProof(row.Proof),
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+
+	pb "github.com/celestiaorg/celestia-node/api/grpc/pb"
+	dasMod "github.com/celestiaorg/celestia-node/nodebuilder/das"
+)
+
+type dasService struct {
+	mod dasMod.Module
+}
+
+func (s *dasService) SamplingStats(ctx context.Context, _ *pb.SamplingStatsRequest) (*pb.SamplingStatsResponse, error) {
+	stats, err := s.mod.SamplingStats(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	failed := make(map[uint64]int64, len(stats.Failed))
+	for height, tries := range stats.Failed {
+		failed[height] = int64(tries)
+	}
+	return &pb.SamplingStatsResponse{
+		SampledChainHead: stats.SampledChainHead,
+		CatchupHead:      stats.CatchupHead,
+		NetworkHead:      stats.NetworkHead,
+		Failed:           failed,
+		Concurrency:      int64(stats.Concurrency),
+		ConcurrencyLimit: int64(stats.ConcurrencyLimit),
+		RetryQueueDepth:  int64(stats.RetryQueueDepth),
+		CatchUpDone:      stats.CatchUpDone,
+		Confidence:       stats.Confidence,
+		IsRunning:        stats.IsRunning,
+	}, nil
+}
+
+func (s *dasService) WaitCatchUp(ctx context.Context, _ *pb.WaitCatchUpRequest) (*pb.WaitCatchUpResponse, error) {
+	if err := s.mod.WaitCatchUp(ctx); err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.WaitCatchUpResponse{}, nil
+}
@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/blob"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// statusError converts the error of a module into a gRPC status error, so that clients can tell
+// missing data and denied access apart from failures.
+func statusError(err error) error {
+	var code codes.Code
+	switch {
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, perms.ErrNamespaceNotAllowed):
+		code = codes.PermissionDenied
+	case errors.Is(err, libhead.ErrNotFound),
+		errors.Is(err, share.ErrNotFound),
+		errors.Is(err, blob.ErrBlobNotFound):
+		code = codes.NotFound
+	default:
+		code = codes.Internal
+	}
+	return status.Error(code, err.Error())
+}
+
+// invalidArgument returns the status error of a malformed request.
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
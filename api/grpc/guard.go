@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/celestiaorg/celestia-node/api/rpc"
+)
+
+// Guards are the guards of the JSON-RPC server, i.e. disabled modules, the audit log, rate limits
+// and subscription limits, which the Server applies to its calls as well.
+type Guards interface {
+	Guard(ctx context.Context, module, method string, perm auth.Permission, params ...interface{}) (func(error), error)
+	GuardSubscription(ctx context.Context, module, method string) (func(), error)
+}
+
+// subscriptionMethods are the methods streaming subscriptions, which are bound by the subscription
+// limits.
+var subscriptionMethods = map[string]bool{
+	headerServicePrefix + "Subscribe": true,
+	blobServicePrefix + "Subscribe":   true,
+}
+
+// SetGuards makes the server apply the given guards to its calls. It must be called before the Server
+// is started.
+func (s *Server) SetGuards(guards Guards) {
+	s.guards = guards
+}
+
+func (s *Server) unaryGuard(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if s.guards == nil {
+		return handler(ctx, req)
+	}
+	ctx = withClient(ctx)
+	module, name := moduleMethod(info.FullMethod)
+	done, err := s.guards.Guard(ctx, module, name, methodPerm(info.FullMethod), req)
+	if err != nil {
+		return nil, guardError(err)
+	}
+	resp, err := handler(ctx, req)
+	done(err)
+	return resp, err
+}
+
+func (s *Server) streamGuard(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if s.guards == nil {
+		return handler(srv, ss)
+	}
+	ctx := withClient(ss.Context())
+	module, name := moduleMethod(info.FullMethod)
+	// streams are served with a single request, which is only received by the handler
+	done, err := s.guards.Guard(ctx, module, name, methodPerm(info.FullMethod))
+	if err != nil {
+		return guardError(err)
+	}
+	if subscriptionMethods[info.FullMethod] {
+		release, err := s.guards.GuardSubscription(ctx, module, name)
+		if err != nil {
+			done(err)
+			return guardError(err)
+		}
+		defer release()
+	}
+	err = handler(srv, &authedStream{ServerStream: ss, ctx: ctx})
+	done(err)
+	return err
+}
+
+// withClient stores the token and the IP of the client of the request in its context, so that the
+// guards tell clients apart the same way the JSON-RPC does.
+func withClient(ctx context.Context) context.Context {
+	var token, ip string
+	md, _ := metadata.FromIncomingContext(ctx)
+	if tokens := md.Get(authKey); len(tokens) != 0 {
+		token = strings.TrimPrefix(tokens[0], "Bearer ")
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+	return rpc.WithClient(ctx, token, ip)
+}
+
+// guardError converts the error of a guard into a gRPC status error.
+func guardError(err error) error {
+	var code codes.Code
+	switch {
+	case errors.Is(err, rpc.ErrModuleDisabled):
+		code = codes.Unavailable
+	case errors.Is(err, rpc.ErrRateLimited), errors.Is(err, rpc.ErrTooManySubscriptions):
+		code = codes.ResourceExhausted
+	default:
+		code = codes.Internal
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+
+	pb "github.com/celestiaorg/celestia-node/api/grpc/pb"
+	"github.com/celestiaorg/celestia-node/header"
+	headerMod "github.com/celestiaorg/celestia-node/nodebuilder/header"
+)
+
+type headerService struct {
+	mod headerMod.Module
+}
+
+func (s *headerService) LocalHead(ctx context.Context, _ *pb.LocalHeadRequest) (*pb.ExtendedHeader, error) {
+	return toExtendedHeader(s.mod.LocalHead(ctx))
+}
+
+func (s *headerService) NetworkHead(ctx context.Context, _ *pb.NetworkHeadRequest) (*pb.ExtendedHeader, error) {
+	return toExtendedHeader(s.mod.NetworkHead(ctx))
+}
+
+func (s *headerService) GetByHash(ctx context.Context, req *pb.GetByHashRequest) (*pb.ExtendedHeader, error) {
+	return toExtendedHeader(s.mod.GetByHash(ctx, req.Hash))
+}
+
+func (s *headerService) GetByHeight(ctx context.Context, req *pb.GetByHeightRequest) (*pb.ExtendedHeader, error) {
+	return toExtendedHeader(s.mod.GetByHeight(ctx, req.Height))
+}
+
+func (s *headerService) WaitForHeight(ctx context.Context, req *pb.GetByHeightRequest) (*pb.ExtendedHeader, error) {
+	return toExtendedHeader(s.mod.WaitForHeight(ctx, req.Height))
+}
+
+func (s *headerService) Subscribe(_ *pb.SubscribeHeadersRequest, stream pb.HeaderService_SubscribeServer) error {
+	heads, err := s.mod.Subscribe(stream.Context())
+	if err != nil {
+		return statusError(err)
+	}
+	for head := range heads {
+		resp, err := toExtendedHeader(head, nil)
+		if err != nil {
+			return err
+		}
+		if err = stream.Send(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toExtendedHeader(eh *header.ExtendedHeader, err error) (*pb.ExtendedHeader, error) {
+	if err != nil {
+		return nil, statusError(err)
+	}
+	bin, err := eh.MarshalBinary()
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.ExtendedHeader{
+		Height: uint64(eh.Height()),
+		Hash:   eh.Hash(),
+		Header: bin,
+	}, nil
+}
@@ -0,0 +1,137 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync/atomic"
+
+	"github.com/cristalhq/jwt"
+	logging "github.com/ipfs/go-log/v2"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc"
+
+	pb "github.com/celestiaorg/celestia-node/api/grpc/pb"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
+	"github.com/celestiaorg/celestia-node/nodebuilder/das"
+	"github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/share"
+)
+
+var log = logging.Logger("grpc")
+
+// Server serves the share, header, blob and das modules over gRPC, alongside the JSON-RPC. It
+// authorizes requests with the same tokens as the JSON-RPC, which are passed in the
+// "authorization" metadata as "Bearer <token>".
+type Server struct {
+	srv      *grpc.Server
+	addr     string
+	listener net.Listener
+	// tls terminates TLS of the connections, if set
+	tls *tls.Config
+
+	started atomic.Bool
+
+	auth jwt.Signer
+	// revocations rejects revoked tokens, if set
+	revocations *authtoken.Revocations
+	// guards are applied to authorized calls, if set
+	guards Guards
+}
+
+// NewServer returns a new gRPC Server.
+func NewServer(address, port string, secret jwt.Signer) *Server {
+	s := &Server{
+		addr: address + ":" + port,
+		auth: secret,
+	}
+	s.srv = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.unaryAuth, s.unaryGuard),
+		grpc.ChainStreamInterceptor(s.streamAuth, s.streamGuard),
+	)
+	return s
+}
+
+// RegisterServices registers the services of the given modules on the Server. It must be called
+// before the Server is started.
+func (s *Server) RegisterServices(
+	headerMod header.Module,
+	shareMod share.Module,
+	blobMod blob.Module,
+	dasMod das.Module,
+) {
+	pb.RegisterHeaderServiceServer(s.srv, &headerService{mod: headerMod})
+	pb.RegisterShareServiceServer(s.srv, &shareService{mod: shareMod})
+	pb.RegisterBlobServiceServer(s.srv, &blobService{mod: blobMod})
+	pb.RegisterDASServiceServer(s.srv, &dasService{mod: dasMod})
+}
+
+// SetRevocations makes the server reject the given revoked tokens.
+func (s *Server) SetRevocations(revocations *authtoken.Revocations) {
+	s.revocations = revocations
+}
+
+// SetTLS makes the server serve gRPC over TLS with the given config. It must be called before
+// Start.
+func (s *Server) SetTLS(cfg *tls.Config) {
+	if cfg != nil && !slices.Contains(cfg.NextProtos, "h2") {
+		// gRPC runs over HTTP/2, which clients negotiate over ALPN
+		cfg = cfg.Clone()
+		cfg.NextProtos = append([]string{"h2"}, cfg.NextProtos...)
+	}
+	s.tls = cfg
+}
+
+// Start starts the gRPC Server, listening on the given address.
+func (s *Server) Start(context.Context) error {
+	couldStart := s.started.CompareAndSwap(false, true)
+	if !couldStart {
+		log.Warn("cannot start server: already started")
+		return nil
+	}
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	if s.tls != nil {
+		listener = tls.NewListener(listener, s.tls)
+	}
+	s.listener = listener
+	log.Infow("server started", "listening on", listener.Addr().String(), "tls", s.tls != nil)
+	//nolint:errcheck
+	go s.srv.Serve(listener)
+	return nil
+}
+
+// Stop stops the gRPC Server. Streams still open once the context is done are closed.
+func (s *Server) Stop(ctx context.Context) error {
+	couldStop := s.started.CompareAndSwap(true, false)
+	if !couldStop {
+		log.Warn("cannot stop server: already stopped")
+		return nil
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		// subscriptions don't end on their own
+		s.srv.Stop()
+	}
+	s.listener = nil
+	log.Info("server stopped")
+	return nil
+}
+
+// ListenAddr returns the listen address of the server.
+func (s *Server) ListenAddr() string {
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
@@ -0,0 +1,320 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cristalhq/jwt"
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/nmt/namespace"
+
+	pb "github.com/celestiaorg/celestia-node/api/grpc/pb"
+	"github.com/celestiaorg/celestia-node/api/rpc"
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/blob"
+	daspkg "github.com/celestiaorg/celestia-node/das"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/libs/audit"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	blobMod "github.com/celestiaorg/celestia-node/nodebuilder/blob"
+	blobMock "github.com/celestiaorg/celestia-node/nodebuilder/blob/mocks"
+	dasMod "github.com/celestiaorg/celestia-node/nodebuilder/das"
+	dasMock "github.com/celestiaorg/celestia-node/nodebuilder/das/mocks"
+	headerMod "github.com/celestiaorg/celestia-node/nodebuilder/header"
+	headerMock "github.com/celestiaorg/celestia-node/nodebuilder/header/mocks"
+	shareMock "github.com/celestiaorg/celestia-node/nodebuilder/share/mocks"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+type mockModules struct {
+	header *headerMock.MockModule
+	share  *shareMock.MockModule
+	blob   *blobMock.MockModule
+	das    *dasMock.MockModule
+}
+
+func setupServer(t *testing.T, signer jwt.Signer) (*Server, *grpc.ClientConn, *mockModules) {
+	ctrl := gomock.NewController(t)
+	mods := &mockModules{
+		header: headerMock.NewMockModule(ctrl),
+		share:  shareMock.NewMockModule(ctrl),
+		blob:   blobMock.NewMockModule(ctrl),
+		das:    dasMock.NewMockModule(ctrl),
+	}
+
+	srv := NewServer("127.0.0.1", "0", signer)
+	srv.RegisterServices(mods.header, mods.share, mods.blob, mods.das)
+	require.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, srv.Stop(ctx))
+	})
+
+	conn, err := grpc.Dial(srv.ListenAddr(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+	return srv, conn, mods
+}
+
+func withToken(
+	ctx context.Context,
+	t *testing.T,
+	signer jwt.Signer,
+	allow []auth.Permission,
+	nIDs ...namespace.ID,
+) context.Context {
+	token, err := authtoken.NewSignedJWT(signer, allow, nIDs...)
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(ctx, authKey, "Bearer "+token)
+}
+
+func TestAuth(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+	_, conn, mods := setupServer(t, signer)
+	headers := pb.NewHeaderServiceClient(conn)
+	blobs := pb.NewBlobServiceClient(conn)
+	das := pb.NewDASServiceClient(conn)
+
+	head := headertest.RandExtendedHeader(t)
+
+	// public methods don't require a token
+	mods.header.EXPECT().GetByHeight(gomock.Any(), uint64(head.Height())).Return(head, nil)
+	resp, err := headers.GetByHeight(ctx, &pb.GetByHeightRequest{Height: uint64(head.Height())})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(head.Height()), resp.Height)
+	assert.Equal(t, []byte(head.Hash()), resp.Hash)
+	decoded, err := header.UnmarshalExtendedHeader(resp.Header)
+	require.NoError(t, err)
+	assert.Equal(t, head.Hash(), decoded.Hash())
+
+	// others do
+	_, err = headers.LocalHead(ctx, &pb.LocalHeadRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	mods.header.EXPECT().LocalHead(gomock.Any()).Return(head, nil)
+	_, err = headers.LocalHead(withToken(ctx, t, signer, perms.ReadPerms), &pb.LocalHeadRequest{})
+	require.NoError(t, err)
+
+	// tokens must be signed by the node
+	otherSigner, err := jwt.NewHS256([]byte("other"))
+	require.NoError(t, err)
+	_, err = headers.LocalHead(withToken(ctx, t, otherSigner, perms.AllPerms), &pb.LocalHeadRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// submitting requires writing
+	nID, err := share.NewNamespaceV0([]byte("grpc"))
+	require.NoError(t, err)
+	submit := &pb.SubmitRequest{Blobs: []*pb.Blob{{Namespace: nID, Data: []byte("data")}}}
+	_, err = blobs.Submit(withToken(ctx, t, signer, perms.ReadPerms), submit)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	mods.blob.EXPECT().SubmitAll(gomock.Any(), gomock.Any(), nil).DoAndReturn(
+		func(_ context.Context, blobs []*blob.Blob, _ *blob.GasOptions) (uint64, error) {
+			require.Len(t, blobs, 1)
+			assert.Equal(t, namespace.ID(nID), blobs[0].Namespace())
+			assert.Equal(t, []byte("data"), blobs[0].Data)
+			return 10, nil
+		})
+	submitted, err := blobs.Submit(withToken(ctx, t, signer, perms.ReadWritePerms), submit)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), submitted.Height)
+
+	// namespace-scoped tokens are granted their permissions on the blob service only
+	scopedCtx := withToken(ctx, t, signer, perms.ReadWritePerms, namespace.ID(nID))
+	mods.blob.EXPECT().GetAll(gomock.Any(), uint64(1), []namespace.ID{namespace.ID(nID)}).DoAndReturn(
+		func(ctx context.Context, _ uint64, _ []namespace.ID) ([]*blob.Blob, error) {
+			nIDs, ok := perms.Namespaces(ctx)
+			require.True(t, ok)
+			assert.Equal(t, []namespace.ID{namespace.ID(nID)}, nIDs)
+			return nil, nil
+		})
+	stream, err := blobs.GetAll(scopedCtx, &pb.GetAllRequest{Height: 1, Namespaces: [][]byte{nID}})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	_, err = das.SamplingStats(scopedCtx, &pb.SamplingStatsRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestMethodScopesAndRevocations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+	srv, conn, mods := setupServer(t, signer)
+	revocations, err := authtoken.NewRevocations(ctx, ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	srv.SetRevocations(revocations)
+	headers := pb.NewHeaderServiceClient(conn)
+	das := pb.NewDASServiceClient(conn)
+
+	token, err := authtoken.NewSignedPayloadJWT(signer, &perms.JWTPayload{
+		Allow:   perms.ReadPerms,
+		Methods: []string{"das.SamplingStats"},
+	})
+	require.NoError(t, err)
+	scopedCtx := metadata.AppendToOutgoingContext(ctx, authKey, "Bearer "+token)
+
+	// method-scoped tokens can only invoke the methods they are scoped to
+	mods.das.EXPECT().SamplingStats(gomock.Any()).Return(daspkg.SamplingStats{}, nil)
+	_, err = das.SamplingStats(scopedCtx, &pb.SamplingStatsRequest{})
+	require.NoError(t, err)
+	_, err = headers.LocalHead(scopedCtx, &pb.LocalHeadRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// revoked tokens are rejected
+	payload, err := authtoken.ExtractSignedPayload(signer, token)
+	require.NoError(t, err)
+	require.NoError(t, revocations.Revoke(ctx, payload.ID))
+	_, err = das.SamplingStats(scopedCtx, &pb.SamplingStatsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestStreams(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+	_, conn, mods := setupServer(t, signer)
+
+	t.Run("headers", func(t *testing.T) {
+		heads := make(chan *header.ExtendedHeader, 2)
+		for _, h := range headertest.NewTestSuite(t, 3).GenExtendedHeaders(2) {
+			heads <- h
+		}
+		close(heads)
+		mods.header.EXPECT().Subscribe(gomock.Any()).Return(heads, nil)
+
+		stream, err := pb.NewHeaderServiceClient(conn).Subscribe(ctx, &pb.SubscribeHeadersRequest{})
+		require.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			_, err = stream.Recv()
+			require.NoError(t, err)
+		}
+		_, err = stream.Recv()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("eds", func(t *testing.T) {
+		eds := share.RandEDS(t, 4)
+		dah := da.NewDataAvailabilityHeader(eds)
+		mods.share.EXPECT().GetEDS(gomock.Any(), gomock.Any()).Return(eds, nil)
+
+		stream, err := pb.NewShareServiceClient(conn).GetEDS(ctx, &pb.GetEDSRequest{Root: toRoot(&dah)})
+		require.NoError(t, err)
+		for i := 0; i < 4; i++ {
+			row, err := stream.Recv()
+			require.NoError(t, err)
+			assert.Equal(t, uint32(i), row.Index)
+			assert.Equal(t, eds.Row(uint(i))[:4], row.Shares)
+		}
+		_, err = stream.Recv()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("invalid root", func(t *testing.T) {
+		stream, err := pb.NewShareServiceClient(conn).GetEDS(ctx, &pb.GetEDSRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestGuards(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+	srv, conn, mods := setupServer(t, signer)
+
+	rpcSrv := rpc.NewServer("127.0.0.1", "0", signer)
+	rpcSrv.RegisterAuthedService("header", mods.header, &headerMod.API{})
+	rpcSrv.RegisterAuthedService("blob", mods.blob, &blobMod.API{})
+	rpcSrv.RegisterAuthedService("das", mods.das, &dasMod.API{})
+	rpcSrv.SetRateLimits(rpc.RateLimits{
+		Default: rpc.Limit{Rate: 100, Burst: 100},
+		Methods: map[string]rpc.Limit{"das.SamplingStats": {Rate: 0.001, Burst: 1}},
+	})
+	rpcSrv.SetSubscriptionLimits(rpc.SubscriptionLimits{MaxPerClient: 1})
+	auditLog, err := audit.Open(t.TempDir(), 1<<20, 1)
+	require.NoError(t, err)
+	t.Cleanup(func() { auditLog.Close() })
+	rpcSrv.SetAuditLog(auditLog)
+	srv.SetGuards(rpcSrv)
+
+	headers := pb.NewHeaderServiceClient(conn)
+	blobs := pb.NewBlobServiceClient(conn)
+	das := pb.NewDASServiceClient(conn)
+
+	t.Run("rate limits", func(t *testing.T) {
+		readCtx := withToken(ctx, t, signer, perms.ReadPerms)
+		mods.das.EXPECT().SamplingStats(gomock.Any()).Return(daspkg.SamplingStats{}, nil)
+		_, err := das.SamplingStats(readCtx, &pb.SamplingStatsRequest{})
+		require.NoError(t, err)
+		_, err = das.SamplingStats(readCtx, &pb.SamplingStatsRequest{})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("subscription limits", func(t *testing.T) {
+		subCtx, subCancel := context.WithCancel(ctx)
+		defer subCancel()
+		mods.header.EXPECT().Subscribe(gomock.Any()).Return(make(chan *header.ExtendedHeader), nil)
+		_, err := headers.Subscribe(subCtx, &pb.SubscribeHeadersRequest{})
+		require.NoError(t, err)
+
+		// the server accepts the first subscription asynchronously
+		require.Eventually(t, func() bool {
+			stream, err := headers.Subscribe(ctx, &pb.SubscribeHeadersRequest{})
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			return status.Code(err) == codes.ResourceExhausted
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("audit", func(t *testing.T) {
+		nID, err := share.NewNamespaceV0([]byte("grpc"))
+		require.NoError(t, err)
+		submit := &pb.SubmitRequest{Blobs: []*pb.Blob{{Namespace: nID, Data: []byte("data")}}}
+		mods.blob.EXPECT().SubmitAll(gomock.Any(), gomock.Any(), nil).Return(uint64(10), nil)
+		_, err = blobs.Submit(withToken(ctx, t, signer, perms.ReadWritePerms), submit)
+		require.NoError(t, err)
+
+		entries, err := rpcSrv.AuditEntries(0)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "blob.Submit", entries[0].Method)
+		assert.Equal(t, "ok", entries[0].Result)
+	})
+
+	t.Run("disabled modules", func(t *testing.T) {
+		require.NoError(t, rpcSrv.SetModuleEnabled("header", false))
+		_, err := headers.GetByHeight(ctx, &pb.GetByHeightRequest{Height: 1})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
@@ -0,0 +1,132 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/celestiaorg/nmt"
+
+	pb "github.com/celestiaorg/celestia-node/api/grpc/pb"
+	shareMod "github.com/celestiaorg/celestia-node/nodebuilder/share"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+type shareService struct {
+	mod shareMod.Module
+}
+
+func (s *shareService) SharesAvailable(
+	ctx context.Context,
+	req *pb.SharesAvailableRequest,
+) (*pb.SharesAvailableResponse, error) {
+	root, err := fromRoot(req.Root)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	if err = s.mod.SharesAvailable(ctx, root); err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.SharesAvailableResponse{}, nil
+}
+
+func (s *shareService) GetShare(ctx context.Context, req *pb.GetShareRequest) (*pb.GetShareResponse, error) {
+	root, err := fromRoot(req.Root)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	width := uint32(len(root.RowRoots))
+	if req.Row >= width || req.Col >= width {
+		return nil, invalidArgument(errors.New("share out of the square"))
+	}
+	sh, err := s.mod.GetShare(ctx, root, int(req.Row), int(req.Col))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.GetShareResponse{Share: sh}, nil
+}
+
+func (s *shareService) GetEDS(req *pb.GetEDSRequest, stream pb.ShareService_GetEDSServer) error {
+	root, err := fromRoot(req.Root)
+	if err != nil {
+		return invalidArgument(err)
+	}
+	eds, err := s.mod.GetEDS(stream.Context(), root)
+	if err != nil {
+		return statusError(err)
+	}
+	odsWidth := eds.Width() / 2
+	for i := uint(0); i < odsWidth; i++ {
+		row := &pb.SquareRow{Index: uint32(i), Shares: eds.Row(i)[:odsWidth]}
+		if err = stream.Send(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *shareService) GetSharesByNamespace(
+	req *pb.GetSharesByNamespaceRequest,
+	stream pb.ShareService_GetSharesByNamespaceServer,
+) error {
+	root, err := fromRoot(req.Root)
+	if err != nil {
+		return invalidArgument(err)
+	}
+	if len(req.Namespace) != share.NamespaceSize {
+		return invalidArgument(errors.New("invalid namespace size"))
+	}
+	shares, err := s.mod.GetSharesByNamespace(stream.Context(), root, req.Namespace)
+	if err != nil {
+		return statusError(err)
+	}
+	for _, row := range shares {
+		if err = stream.Send(&pb.NamespacedRow{Shares: row.Shares, Proof: toNamespaceProof(row.Proof)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fromRoot(root *pb.DataAvailabilityHeader) (*share.Root, error) {
+	if root == nil {
+		return nil, errors.New("missing root")
+	}
+	dah := &share.Root{RowRoots: root.RowRoots, ColumnRoots: root.ColumnRoots}
+	if err := dah.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	return dah, nil
+}
+
+func toRoot(root *share.Root) *pb.DataAvailabilityHeader {
+	if root == nil {
+		return nil
+	}
+	return &pb.DataAvailabilityHeader{RowRoots: root.RowRoots, ColumnRoots: root.ColumnRoots}
+}
+
+func toNamespaceProof(proof *nmt.Proof) *pb.NamespaceProof {
+	if proof == nil {
+		return nil
+	}
+	return &pb.NamespaceProof{
+		Start:                 int64(proof.Start()),
+		End:                   int64(proof.End()),
+		Nodes:                 proof.Nodes(),
+		LeafHash:              proof.LeafHash(),
+		IsMaxNamespaceIgnored: proof.IsMaxNamespaceIDIgnored(),
+	}
+}
+
+func fromNamespaceProof(proof *pb.NamespaceProof) *nmt.Proof {
+	if proof == nil {
+		return nil
+	}
+	var p nmt.Proof
+	if len(proof.LeafHash) != 0 {
+		p = nmt.NewAbsenceProof(int(proof.Start), int(proof.End), proof.Nodes, proof.LeafHash, proof.IsMaxNamespaceIgnored)
+	} else {
+		p = nmt.NewInclusionProof(int(proof.Start), int(proof.End), proof.Nodes, proof.IsMaxNamespaceIgnored)
+	}
+	return &p
+}
@@ -0,0 +1,145 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/libs/audit"
+)
+
+// ErrAuditDisabled is returned when the audit log is requested from a server without one.
+var ErrAuditDisabled = errors.New("rpc: audit log is disabled")
+
+// auditedPerms are the permissions of the methods recorded in the audit log.
+var auditedPerms = map[string]bool{
+	"write": true,
+	"admin": true,
+}
+
+type subjectKey struct{}
+
+// withSubject stores the subject of the token the request is authenticated with in the context.
+func withSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, subjectKey{}, subject)
+}
+
+func subjectFrom(ctx context.Context) (string, bool) {
+	subject, ok := ctx.Value(subjectKey{}).(string)
+	return subject, ok
+}
+
+// tokenSubject identifies the token without revealing it, as tokens don't carry identities of their
+// holders.
+func tokenSubject(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
+
+// SetAuditLog makes the server record authenticated calls of write and admin methods to the given
+// log.
+func (s *Server) SetAuditLog(log *audit.Log) {
+	s.audit = log
+}
+
+// AuditEntries returns up to the given amount of the latest entries of the audit log.
+func (s *Server) AuditEntries(amount int) ([]audit.Entry, error) {
+	if s.audit == nil {
+		return nil, ErrAuditDisabled
+	}
+	return s.audit.Entries(amount)
+}
+
+// guardAudit wraps the write and admin methods of the internal struct, so that their authenticated
+// calls are recorded in the audit log along with their results.
+func (s *Server) guardAudit(module string, internal interface{}) {
+	v := reflect.ValueOf(internal).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !auditedPerms[v.Type().Field(i).Tag.Get("perm")] || field.Kind() != reflect.Func || field.IsNil() ||
+			field.Type().NumIn() == 0 || field.Type().In(0) != ctxType {
+			continue
+		}
+
+		name := module + "." + v.Type().Field(i).Name
+		typ := field.Type()
+		method := reflect.ValueOf(field.Interface())
+		field.Set(reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+			subject, ok := subjectFrom(args[0].Interface().(context.Context))
+			if s.audit == nil || !ok {
+				return method.Call(args)
+			}
+
+			entry := audit.Entry{
+				Time:       time.Now().UTC(),
+				Method:     name,
+				Subject:    subject,
+				ParamsHash: paramsHash(values(args[1:])),
+				Result:     "ok",
+			}
+			out := method.Call(args)
+			if len(out) != 0 && typ.Out(len(out)-1) == errType && !out[len(out)-1].IsNil() {
+				entry.Result = out[len(out)-1].Interface().(error).Error()
+			}
+			if err := s.audit.Record(entry); err != nil {
+				log.Errorw("recording audit entry", "method", name, "err", err)
+			}
+			return out
+		}))
+	}
+}
+
+// values returns the values of the given reflected args.
+func values(args []reflect.Value) []interface{} {
+	params := make([]interface{}, len(args))
+	for i, arg := range args {
+		params[i] = arg.Interface()
+	}
+	return params
+}
+
+// auditedParam is implemented by params, which are identified in the audit log by some of their
+// fields only, e.g. blobs by their commitments rather than their data, so that recording a call
+// doesn't encode its whole payload.
+type auditedParam interface {
+	AuditFields() interface{}
+}
+
+var auditedParamType = reflect.TypeOf((*auditedParam)(nil)).Elem()
+
+// auditFields returns the fields identifying the given param, if it implements auditedParam, or
+// of each of its elements, if it is a slice of such params. Other params are returned as is.
+func auditFields(param interface{}) interface{} {
+	if p, ok := param.(auditedParam); ok {
+		return p.AuditFields()
+	}
+	v := reflect.ValueOf(param)
+	if v.Kind() != reflect.Slice || !v.Type().Elem().Implements(auditedParamType) {
+		return param
+	}
+	fields := make([]interface{}, v.Len())
+	for i := range fields {
+		fields[i] = v.Index(i).Interface().(auditedParam).AuditFields()
+	}
+	return fields
+}
+
+// paramsHash returns the hex-encoded SHA256 of the JSON-encoded fields of the params identifying
+// them.
+func paramsHash(params []interface{}) string {
+	fields := make([]interface{}, len(params))
+	for i, param := range params {
+		fields[i] = auditFields(param)
+	}
+
+	h := sha256.New()
+	if err := json.NewEncoder(h).Encode(fields); err != nil {
+		log.Warnw("encoding params for audit", "err", err)
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
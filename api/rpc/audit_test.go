@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/libs/audit"
+)
+
+func TestServer_Audit(t *testing.T) {
+	ctx := context.Background()
+
+	var internal struct {
+		Read  func(context.Context, int) (int, error) `perm:"read"`
+		Write func(context.Context, int) (int, error) `perm:"write"`
+		Admin func(context.Context) error             `perm:"admin"`
+	}
+	internal.Read = func(_ context.Context, i int) (int, error) { return i, nil }
+	internal.Write = func(_ context.Context, i int) (int, error) { return i, nil }
+	internal.Admin = func(context.Context) error { return errors.New("denied") }
+
+	srv := NewServer("localhost", "0", nil)
+	_, err := srv.AuditEntries(0)
+	require.ErrorIs(t, err, ErrAuditDisabled)
+
+	auditLog, err := audit.Open(t.TempDir(), 1<<20, 1)
+	require.NoError(t, err)
+	t.Cleanup(func() { auditLog.Close() })
+	srv.SetAuditLog(auditLog)
+	srv.guardAudit("test", &internal)
+
+	// unauthenticated calls are not recorded
+	_, err = internal.Write(ctx, 1)
+	require.NoError(t, err)
+
+	authed := withSubject(ctx, tokenSubject("token"))
+	_, err = internal.Read(authed, 1)
+	require.NoError(t, err)
+	_, err = internal.Write(authed, 1)
+	require.NoError(t, err)
+	_, err = internal.Write(authed, 2)
+	require.NoError(t, err)
+	require.Error(t, internal.Admin(authed))
+
+	entries, err := srv.AuditEntries(0)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "test.Write", entries[0].Method)
+	assert.Equal(t, tokenSubject("token"), entries[0].Subject)
+	assert.Equal(t, "ok", entries[0].Result)
+	assert.NotEqual(t, entries[0].ParamsHash, entries[1].ParamsHash)
+	assert.Equal(t, "test.Admin", entries[2].Method)
+	assert.Equal(t, "denied", entries[2].Result)
+}
+
+// payload is a param identified in the audit log by its id only.
+type payload struct {
+	ID   int
+	Data []byte
+}
+
+func (p *payload) AuditFields() interface{} {
+	return p.ID
+}
+
+func TestParamsHash(t *testing.T) {
+	hash := paramsHash([]interface{}{1, []*payload{{ID: 1, Data: []byte("a")}}})
+	// fields not identifying params don't change the hash
+	assert.Equal(t, hash, paramsHash([]interface{}{1, []*payload{{ID: 1, Data: []byte("b")}}}))
+	assert.Equal(t, hash, paramsHash([]interface{}{1, []interface{}{1}}))
+	assert.NotEqual(t, hash, paramsHash([]interface{}{1, []*payload{{ID: 2, Data: []byte("a")}}}))
+	assert.NotEqual(t, hash, paramsHash([]interface{}{2, []*payload{{ID: 1, Data: []byte("a")}}}))
+	assert.Equal(t,
+		paramsHash([]interface{}{&payload{ID: 1}}),
+		paramsHash([]interface{}{&payload{ID: 1, Data: []byte("a")}}),
+	)
+}
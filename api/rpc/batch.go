@@ -0,0 +1,152 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/filecoin-project/go-jsonrpc"
+)
+
+// errCodeInvalidRequest is the JSON-RPC error code of invalid requests.
+const errCodeInvalidRequest = -32600
+
+// BatchLimits limits the JSON-RPC batches, i.e. the arrays of requests clients send in one round
+// trip.
+type BatchLimits struct {
+	// MaxSize is the maximum amount of requests in a batch. Zero means no limit.
+	MaxSize int
+	// Concurrency is the maximum amount of requests of a batch served at once. Zero means they are
+	// served one by one.
+	Concurrency int
+}
+
+// SetBatchLimits makes the server limit the size of batches and serve their requests concurrently.
+func (s *Server) SetBatchLimits(limits BatchLimits) {
+	s.batch = &limits
+}
+
+// batchHandler serves JSON-RPC batches by fanning their requests out to the next handler
+// concurrently and joining the responses in the order of the requests. Every request of a batch
+// goes through the method guards on its own, so it is e.g. rate limited separately.
+func (s *Server) batchHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.batch == nil || r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, jsonrpc.DEFAULT_MAX_REQUEST_SIZE))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var reqs []json.RawMessage
+		if !isBatch(body) || json.Unmarshal(body, &reqs) != nil || len(reqs) == 0 {
+			// leave the single requests and the malformed batches to the RPC server
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+			return
+		}
+		if s.batch.MaxSize > 0 && len(reqs) > s.batch.MaxSize {
+			log.Debugw("rejecting oversized batch", "size", len(reqs), "max", s.batch.MaxSize)
+			writeBatchError(w, "batch exceeds the maximum amount of requests")
+			return
+		}
+
+		concurrency := s.batch.Concurrency
+		if concurrency <= 0 {
+			concurrency = 1
+		}
+		sem := make(chan struct{}, concurrency)
+		resps := make([]*bufferedResponse, len(reqs))
+		var wg sync.WaitGroup
+		for i, req := range reqs {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(i int, req json.RawMessage) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				sub := r.Clone(r.Context())
+				sub.Body = io.NopCloser(bytes.NewReader(req))
+				sub.ContentLength = int64(len(req))
+				resps[i] = newBufferedResponse()
+				next.ServeHTTP(resps[i], sub)
+			}(i, req)
+		}
+		wg.Wait()
+
+		out := make([]byte, 0, len(resps)*64)
+		out = append(out, '[')
+		var written int
+		for _, resp := range resps {
+			// notifications have no responses
+			resp := bytes.TrimSpace(resp.body.Bytes())
+			if len(resp) == 0 {
+				continue
+			}
+			if written > 0 {
+				out = append(out, ',')
+			}
+			out = append(out, resp...)
+			written++
+		}
+		out = append(out, ']')
+
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(out); err != nil {
+			log.Debugw("writing batch response", "err", err)
+		}
+	})
+}
+
+// isBatch reports whether the body is a JSON array.
+func isBatch(body []byte) bool {
+	body = bytes.TrimLeft(body, " \t\r\n")
+	return len(body) != 0 && body[0] == '['
+}
+
+// writeBatchError responds to a rejected batch with a single JSON-RPC error, as its requests were
+// not read.
+func writeBatchError(w http.ResponseWriter, msg string) {
+	resp := struct {
+		Jsonrpc string      `json:"jsonrpc"`
+		ID      interface{} `json:"id"`
+		Error   struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}{Jsonrpc: "2.0"}
+	resp.Error.Code, resp.Error.Message = errCodeInvalidRequest, msg
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Debugw("writing batch error", "err", err)
+	}
+}
+
+// bufferedResponse buffers the response of a request of a batch.
+type bufferedResponse struct {
+	header http.Header
+	body   bytes.Buffer
+}
+
+func newBufferedResponse() *bufferedResponse {
+	return &bufferedResponse{header: make(http.Header)}
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+// WriteHeader discards the status, as a batch responds with a single one.
+func (b *bufferedResponse) WriteHeader(int) {}
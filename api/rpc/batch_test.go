@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type batchService struct {
+	running, peak atomic.Int64
+}
+
+func (s *batchService) Square(_ context.Context, n int) (int, error) {
+	running := s.running.Add(1)
+	defer s.running.Add(-1)
+	for peak := s.peak.Load(); running > peak; peak = s.peak.Load() {
+		if s.peak.CompareAndSwap(peak, running) {
+			break
+		}
+	}
+	// later requests finish first, so that responses must be reordered
+	time.Sleep(time.Duration(20-n) * time.Millisecond)
+	return n * n, nil
+}
+
+func TestServer_Batch(t *testing.T) {
+	service := &batchService{}
+	srv := NewServer("localhost", "0", nil)
+	srv.SetBatchLimits(BatchLimits{MaxSize: 10, Concurrency: 4})
+	srv.RegisterService("test", service)
+
+	post := func(body string) (int, []byte) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		srv.srv.Handler.ServeHTTP(rec, req)
+		return rec.Code, rec.Body.Bytes()
+	}
+	batch := func(size int) string {
+		reqs := make([]string, size)
+		for i := range reqs {
+			reqs[i] = fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"test.Square","params":[%d]}`, i, i)
+		}
+		return "[" + strings.Join(reqs, ",") + "]"
+	}
+
+	// responses of batches keep the order of their requests
+	code, body := post(batch(10))
+	require.Equal(t, http.StatusOK, code)
+	var resps []struct {
+		ID     int `json:"id"`
+		Result int `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(body, &resps))
+	require.Len(t, resps, 10)
+	for i, resp := range resps {
+		assert.Equal(t, i, resp.ID)
+		assert.Equal(t, i*i, resp.Result)
+	}
+	// while their requests are served concurrently, up to the limit
+	assert.Greater(t, service.peak.Load(), int64(1))
+	assert.LessOrEqual(t, service.peak.Load(), int64(4))
+
+	// oversized batches are rejected as a whole
+	code, body = post(batch(11))
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, string(body), "-32600")
+
+	// single requests are served as usual
+	code, body = post(`{"jsonrpc":"2.0","id":1,"method":"test.Square","params":[3]}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Contains(t, string(body), `"result":9`)
+}
@@ -7,7 +7,9 @@ import (
 
 	"github.com/filecoin-project/go-jsonrpc"
 
+	"github.com/celestiaorg/celestia-node/api/rpc"
 	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/libs/encoding"
 	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
 	"github.com/celestiaorg/celestia-node/nodebuilder/fraud"
@@ -71,11 +73,16 @@ func NewClient(ctx context.Context, addr string, token string) (*Client, error)
 	return newClient(ctx, addr, authHeader)
 }
 
-func newClient(ctx context.Context, addr string, authHeader http.Header) (*Client, error) {
+func newClient(ctx context.Context, addr string, header http.Header) (*Client, error) {
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set(encoding.VersionHeader, encoding.Version)
+
 	var multiCloser multiClientCloser
 	var client Client
 	for name, module := range moduleMap(&client) {
-		closer, err := jsonrpc.NewClient(ctx, addr, name, module, authHeader)
+		closer, err := jsonrpc.NewMergeClient(ctx, addr, name, []interface{}{module}, header, rpc.ParamEncoders()...)
 		if err != nil {
 			return nil, err
 		}
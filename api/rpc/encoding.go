@@ -0,0 +1,203 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/filecoin-project/go-jsonrpc"
+
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/blob"
+	"github.com/celestiaorg/celestia-node/libs/encoding"
+)
+
+// ParamDecoders returns server options decoding RPC params with binary fields in the encoding
+// version declared by the request. Types of the node only decode the current version themselves,
+// while external types can't implement json.Unmarshaler at all.
+func ParamDecoders() []jsonrpc.ServerOption {
+	return []jsonrpc.ServerOption{
+		versioned(unmarshal[namespace.ID], func(data []byte) (namespace.ID, error) {
+			return encoding.UnmarshalHex(data)
+		}),
+		versioned(unmarshal[[]namespace.ID], func(data []byte) ([]namespace.ID, error) {
+			var nIDs []encoding.Hex
+			err := json.Unmarshal(data, &nIDs)
+			if err != nil {
+				return nil, err
+			}
+
+			out := make([]namespace.ID, len(nIDs))
+			for i, nID := range nIDs {
+				out[i] = namespace.ID(nID)
+			}
+			return out, nil
+		}),
+		versioned(func(data []byte) (blob.Commitment, error) {
+			b, err := unmarshal[[]byte](data)
+			return b, err
+		}, unmarshal[blob.Commitment]),
+		versioned(func(data []byte) ([]*blob.Blob, error) {
+			var raws []json.RawMessage
+			err := json.Unmarshal(data, &raws)
+			if err != nil {
+				return nil, err
+			}
+
+			blobs := make([]*blob.Blob, len(raws))
+			for i, raw := range raws {
+				blobs[i] = new(blob.Blob)
+				if err := blobs[i].UnmarshalLegacyJSON(raw); err != nil {
+					return nil, err
+				}
+			}
+			return blobs, nil
+		}, unmarshal[[]*blob.Blob]),
+		// header hashes used to be unprefixed hex
+		versioned(unmarshal[libhead.Hash], func(data []byte) (libhead.Hash, error) {
+			return encoding.UnmarshalHex(data)
+		}),
+	}
+}
+
+// ParamEncoders returns client options encoding RPC params of external binary types with the
+// current encoding Version.
+func ParamEncoders() []jsonrpc.Option {
+	return []jsonrpc.Option{
+		jsonrpc.WithParamEncoder(new(namespace.ID), func(v reflect.Value) (reflect.Value, error) {
+			return reflect.ValueOf(encoding.Hex(v.Interface().(namespace.ID))), nil
+		}),
+		jsonrpc.WithParamEncoder(new([]namespace.ID), func(v reflect.Value) (reflect.Value, error) {
+			nIDs := v.Interface().([]namespace.ID)
+			out := make([]encoding.Hex, len(nIDs))
+			for i, nID := range nIDs {
+				out[i] = encoding.Hex(nID)
+			}
+			return reflect.ValueOf(out), nil
+		}),
+		jsonrpc.WithParamEncoder(new(libhead.Hash), func(v reflect.Value) (reflect.Value, error) {
+			return reflect.ValueOf(encoding.Hex(v.Interface().(libhead.Hash))), nil
+		}),
+	}
+}
+
+// versioned returns a server option decoding params of type T with the legacy decoder for requests
+// in encoding.LegacyVersion and with the current decoder otherwise.
+func versioned[T any](legacy, current func([]byte) (T, error)) jsonrpc.ServerOption {
+	return jsonrpc.WithParamDecoder(new(T), func(ctx context.Context, data []byte) (reflect.Value, error) {
+		decode := current
+		if encoding.VersionFromContext(ctx) == encoding.LegacyVersion {
+			decode = legacy
+		}
+
+		v, err := decode(data)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(v), nil
+	})
+}
+
+func unmarshal[T any](data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+// withEncodingVersion negotiates the encoding version of requests. Requests are decoded and
+// responded to in the version declared in the encoding.VersionHeader, or in the
+// encoding.LegacyVersion if there is none, and requests declaring unsupported versions are
+// rejected. The current encoding.Version is advertised in the response header.
+func withEncodingVersion(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(encoding.VersionHeader, encoding.Version)
+		version, err := encoding.ParseVersion(r.Header.Get(encoding.VersionHeader))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(encoding.WithVersion(r.Context(), version)))
+	})
+}
+
+// respondLegacy wraps all methods of the internal struct returning values implementing
+// encoding.Legacy, directly or as elements of slices or channels, so that requests in
+// encoding.LegacyVersion get them encoded in it.
+func respondLegacy(internal interface{}) {
+	v := reflect.ValueOf(internal).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Func || field.IsNil() ||
+			field.Type().NumIn() == 0 || field.Type().In(0) != ctxType ||
+			field.Type().NumOut() == 0 || !hasLegacy(field.Type().Out(0)) {
+			continue
+		}
+
+		typ := field.Type()
+		method := reflect.ValueOf(field.Interface())
+		field.Set(reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+			results := method.Call(args)
+			ctx := args[0].Interface().(context.Context)
+			if encoding.VersionFromContext(ctx) == encoding.LegacyVersion {
+				results[0] = toLegacy(ctx, results[0])
+			}
+			return results
+		}))
+	}
+}
+
+// hasLegacy reports whether values of the type implement encoding.Legacy or hold values which do.
+func hasLegacy(typ reflect.Type) bool {
+	if method, ok := typ.MethodByName("Legacy"); ok &&
+		method.Type.NumIn() == 1 && method.Type.NumOut() == 1 && method.Type.Out(0) == typ {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Chan:
+		return hasLegacy(typ.Elem())
+	default:
+		return false
+	}
+}
+
+// toLegacy returns the value encoding JSON in encoding.LegacyVersion. Channels are forwarded
+// until they are closed or the context is done.
+func toLegacy(ctx context.Context, v reflect.Value) reflect.Value {
+	typ := v.Type()
+	if hasLegacy(typ) && typ.Kind() != reflect.Slice && typ.Kind() != reflect.Chan {
+		return v.MethodByName("Legacy").Call(nil)[0]
+	}
+
+	switch {
+	case v.IsNil():
+		return v
+	case typ.Kind() == reflect.Slice:
+		out := reflect.MakeSlice(typ, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(toLegacy(ctx, v.Index(i)))
+		}
+		return out
+	default:
+		out := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, typ.Elem()), 0)
+		go func() {
+			defer out.Close()
+			for {
+				elem, ok := v.Recv()
+				if !ok {
+					return
+				}
+				chosen, _, _ := reflect.Select([]reflect.SelectCase{
+					{Dir: reflect.SelectSend, Chan: out, Send: toLegacy(ctx, elem)},
+					{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+				})
+				if chosen == 1 {
+					return
+				}
+			}
+		}()
+		return out.Convert(typ)
+	}
+}
@@ -0,0 +1,84 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+
+	"github.com/celestiaorg/celestia-node/libs/audit"
+)
+
+// WithClient stores the client of a request served by another transport than the JSON-RPC, i.e.
+// gRPC, in its context, so that the guards of the server tell clients apart the same way. The token
+// is the one the request is authenticated with, if any.
+func WithClient(ctx context.Context, token, ip string) context.Context {
+	if token != "" {
+		ctx = withSubject(ctx, tokenSubject(token))
+	}
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// Guard applies the guards of the authed modules of the server to a call of the method of the module
+// served by another transport than the JSON-RPC, i.e. gRPC. The call fails with ErrModuleDisabled
+// while the module is disabled, and with ErrRateLimited while its client exceeds its rate limit.
+// Otherwise, the result of the call must be passed to the returned func once the call is done, so
+// that calls of write and admin methods are recorded in the audit log.
+func (s *Server) Guard(
+	ctx context.Context,
+	module, method string,
+	perm auth.Permission,
+	params ...interface{},
+) (func(error), error) {
+	if !s.moduleEnabled(module) {
+		return nil, fmt.Errorf("%w: %s", ErrModuleDisabled, module)
+	}
+
+	name := module + "." + method
+	if s.limiter != nil {
+		client, clientType := clientFrom(ctx)
+		if !s.limiter.allow(client, name) {
+			s.limiter.observeThrottled(ctx, name, clientType)
+			return nil, ErrRateLimited
+		}
+	}
+
+	subject, ok := subjectFrom(ctx)
+	if s.audit == nil || !ok || !auditedPerms[string(perm)] {
+		return func(error) {}, nil
+	}
+	entry := audit.Entry{
+		Time:       time.Now().UTC(),
+		Method:     name,
+		Subject:    subject,
+		ParamsHash: paramsHash(params),
+		Result:     "ok",
+	}
+	return func(err error) {
+		if err != nil {
+			entry.Result = err.Error()
+		}
+		if err := s.audit.Record(entry); err != nil {
+			log.Errorw("recording audit entry", "method", name, "err", err)
+		}
+	}, nil
+}
+
+// GuardSubscription applies the subscription limits of the server to a subscription of the method of
+// the module served by another transport than the JSON-RPC, i.e. gRPC. The subscription fails with
+// ErrTooManySubscriptions while its client is at its limit of active subscriptions. Otherwise, the
+// returned func must be called once the subscription ends.
+func (s *Server) GuardSubscription(ctx context.Context, module, method string) (func(), error) {
+	if s.subs == nil {
+		return func() {}, nil
+	}
+	client, _ := clientFrom(ctx)
+	if !s.subs.acquire(client) {
+		s.subs.observeRejected(ctx, module+"."+method)
+		return nil, ErrTooManySubscriptions
+	}
+	return func() {
+		s.subs.release(client)
+	}, nil
+}
@@ -0,0 +1,49 @@
+package perms
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrMethodNotAllowed is returned when a request authorized by a method-scoped token invokes a
+// method the token is not scoped to.
+var ErrMethodNotAllowed = errors.New("perms: method is not allowed by the token")
+
+type methodsKey struct{}
+
+// WithMethods returns a copy of the context scoped to the given methods. Methods are either modules,
+// e.g. "blob", allowing all methods of the module, or methods of modules, e.g. "blob.Submit".
+func WithMethods(ctx context.Context, methods []string) context.Context {
+	return context.WithValue(ctx, methodsKey{}, methods)
+}
+
+// Methods returns the methods the context is scoped to and whether it is scoped at all.
+func Methods(ctx context.Context) ([]string, bool) {
+	methods, ok := ctx.Value(methodsKey{}).([]string)
+	return methods, ok
+}
+
+// CheckMethod returns ErrMethodNotAllowed if the context is scoped to methods other than the given
+// method of the given module.
+func CheckMethod(ctx context.Context, module, method string) error {
+	methods, ok := Methods(ctx)
+	if !ok {
+		return nil
+	}
+	for _, allowed := range methods {
+		if allowed == module || allowed == module+"."+method {
+			return nil
+		}
+	}
+	return ErrMethodNotAllowed
+}
+
+// ValidateMethod checks the method a token can be scoped to is well-formed.
+func ValidateMethod(method string) error {
+	module, name, _ := strings.Cut(method, ".")
+	if module == "" || strings.Contains(name, ".") || (strings.Contains(method, ".") && name == "") {
+		return errors.New("perms: method must be either 'module' or 'module.Method'")
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package perms
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/celestiaorg/nmt/namespace"
+)
+
+// ErrNamespaceNotAllowed is returned when a request authorized by a namespace-scoped token accesses
+// a namespace the token is not scoped to.
+var ErrNamespaceNotAllowed = errors.New("perms: namespace is not allowed by the token")
+
+type namespacesKey struct{}
+
+// WithNamespaces returns a copy of the context scoped to the given namespaces.
+func WithNamespaces(ctx context.Context, nIDs []namespace.ID) context.Context {
+	return context.WithValue(ctx, namespacesKey{}, nIDs)
+}
+
+// Namespaces returns the namespaces the context is scoped to and whether it is scoped at all.
+func Namespaces(ctx context.Context) ([]namespace.ID, bool) {
+	nIDs, ok := ctx.Value(namespacesKey{}).([]namespace.ID)
+	return nIDs, ok
+}
+
+// CheckNamespace returns ErrNamespaceNotAllowed if the context is scoped to namespaces other than
+// the given one.
+func CheckNamespace(ctx context.Context, nID namespace.ID) error {
+	nIDs, ok := Namespaces(ctx)
+	if !ok {
+		return nil
+	}
+	for _, allowed := range nIDs {
+		if bytes.Equal(allowed, nID) {
+			return nil
+		}
+	}
+	return ErrNamespaceNotAllowed
+}
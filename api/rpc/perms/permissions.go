@@ -5,6 +5,8 @@ import (
 
 	"github.com/cristalhq/jwt"
 	"github.com/filecoin-project/go-jsonrpc/auth"
+
+	"github.com/celestiaorg/nmt/namespace"
 )
 
 var (
@@ -19,7 +21,16 @@ var AuthKey = "Authorization"
 // JWTPayload is a utility struct for marshaling/unmarshalling
 // permissions into for token signing/verifying.
 type JWTPayload struct {
+	// ID identifies the token, so that it can be revoked. Tokens signed before IDs were introduced
+	// don't have one.
+	ID    string `json:",omitempty"`
 	Allow []auth.Permission
+	// Namespaces restricts blob reads and submissions of the token to the given namespaces.
+	// Tokens without namespaces are not restricted.
+	Namespaces []namespace.ID `json:",omitempty"`
+	// Methods restricts the token to the given modules or methods of modules, e.g. "blob" or
+	// "blob.Submit". Tokens without methods are not restricted.
+	Methods []string `json:",omitempty"`
 }
 
 func (j *JWTPayload) MarshalBinary() (data []byte, err error) {
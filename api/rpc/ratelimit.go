@@ -0,0 +1,206 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"golang.org/x/time/rate"
+
+	"github.com/celestiaorg/celestia-node/libs/httputil"
+)
+
+// ErrRateLimited is returned by methods called by clients exceeding their rate limits.
+var ErrRateLimited = errors.New("rpc: rate limit exceeded")
+
+// limiterIdleTimeout is the time after which the limiters of idle clients are forgotten.
+var limiterIdleTimeout = 10 * time.Minute
+
+const (
+	throttledMethodKey = "method"
+	throttledClientKey = "client"
+)
+
+var meter = global.MeterProvider().Meter("rpc")
+
+// Limit allows a client Rate calls per second, in bursts of up to Burst calls.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimits configures the rate limits of the clients of the server. Clients are told apart by the
+// tokens they authenticate with, or by their IPs otherwise.
+type RateLimits struct {
+	// Default limits the calls of a client to the methods without limits of their own.
+	Default Limit
+	// Methods limits the calls of a client to expensive methods, keyed by "module.Method", e.g.
+	// "share.GetEDS". Each of them is limited separately from the other methods.
+	Methods map[string]Limit
+}
+
+// rateLimiter keeps a token bucket per client and limited method.
+type rateLimiter struct {
+	limits RateLimits
+	// throttled is set, once metrics are enabled.
+	throttled syncint64.Counter // attributes: method[string], client[string]
+
+	lk        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+}
+
+// bucketKey identifies the bucket of a client. Methods without limits of their own share the
+// bucket with the empty method.
+type bucketKey struct {
+	client string
+	method string
+}
+
+type bucket struct {
+	*rate.Limiter
+	lastSeen time.Time
+}
+
+func newRateLimiter(limits RateLimits) *rateLimiter {
+	return &rateLimiter{
+		limits:    limits,
+		buckets:   make(map[bucketKey]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// allow reports whether the client can call the method now, taking a token from its bucket if so.
+func (l *rateLimiter) allow(client, method string) bool {
+	limit, ok := l.limits.Methods[method]
+	key := bucketKey{client: client, method: method}
+	if !ok {
+		limit, key.method = l.limits.Default, ""
+	}
+
+	now := time.Now()
+	l.lk.Lock()
+	defer l.lk.Unlock()
+	if now.Sub(l.lastSweep) > limiterIdleTimeout {
+		l.sweep(now)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{Limiter: rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+	return b.AllowN(now, 1)
+}
+
+// sweep forgets the buckets of clients idle for longer than limiterIdleTimeout, which are refilled by
+// then anyway.
+func (l *rateLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > limiterIdleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+func (l *rateLimiter) observeThrottled(ctx context.Context, method, clientType string) {
+	if l.throttled == nil {
+		return
+	}
+	l.throttled.Add(ctx, 1,
+		attribute.String(throttledMethodKey, method),
+		attribute.String(throttledClientKey, clientType),
+	)
+}
+
+// SetRateLimits makes the server limit the rate of calls of its clients.
+func (s *Server) SetRateLimits(limits RateLimits) {
+	s.limiter = newRateLimiter(limits)
+}
+
+// WithMetrics enables the metrics of the calls throttled by the rate limits of the server and of
+// its subscriptions.
+func (s *Server) WithMetrics() error {
+	if s.subs != nil {
+		if err := s.subs.initMetrics(); err != nil {
+			return err
+		}
+	}
+	if s.limiter == nil {
+		return nil
+	}
+	throttled, err := meter.SyncInt64().Counter("rpc_throttled_requests",
+		instrument.WithDescription("amount of calls rejected by the rate limits of the RPC"))
+	if err != nil {
+		return err
+	}
+	s.limiter.throttled = throttled
+	return nil
+}
+
+type clientIPKey struct{}
+
+// withClientIP stores the IP of the client of the request in its context, so that rate limits can
+// tell clients without tokens apart. Requests of trusted proxies are attributed to the clients they
+// forward.
+func (s *Server) withClientIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := s.proxies.ClientIP(r)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientIPKey{}, ip)))
+	})
+}
+
+// SetTrustedProxies makes the server attribute requests of the given reverse proxies to the clients
+// they report in the X-Forwarded-For header.
+func (s *Server) SetTrustedProxies(proxies *httputil.TrustedProxies) {
+	s.proxies = proxies
+}
+
+// clientFrom returns the client the request is limited as, along with its type.
+func clientFrom(ctx context.Context) (client string, clientType string) {
+	if subject, ok := subjectFrom(ctx); ok {
+		return "token:" + subject, "token"
+	}
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return "ip:" + ip, "ip"
+}
+
+// guardRateLimit wraps the methods of the internal struct, so that they fail with ErrRateLimited
+// while their client exceeds its rate limit.
+func (s *Server) guardRateLimit(module string, internal interface{}) {
+	v := reflect.ValueOf(internal).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Func || field.IsNil() ||
+			field.Type().NumIn() == 0 || field.Type().In(0) != ctxType {
+			continue
+		}
+		typ := field.Type()
+		if typ.NumOut() == 0 || typ.Out(typ.NumOut()-1) != errType {
+			continue
+		}
+
+		name := module + "." + v.Type().Field(i).Name
+		method := reflect.ValueOf(field.Interface())
+		field.Set(reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+			if s.limiter == nil {
+				return method.Call(args)
+			}
+			ctx := args[0].Interface().(context.Context)
+			client, clientType := clientFrom(ctx)
+			if s.limiter.allow(client, name) {
+				return method.Call(args)
+			}
+			s.limiter.observeThrottled(ctx, name, clientType)
+			return errResults(typ, ErrRateLimited)
+		}))
+	}
+}
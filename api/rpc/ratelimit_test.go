@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/libs/httputil"
+)
+
+func TestServer_RateLimit(t *testing.T) {
+	ctx := context.Background()
+
+	var internal struct {
+		Cheap      func(context.Context) error
+		Expensive  func(context.Context) error
+		Infallible func(context.Context) int
+	}
+	internal.Cheap = func(context.Context) error { return nil }
+	internal.Expensive = func(context.Context) error { return nil }
+	internal.Infallible = func(context.Context) int { return 1 }
+
+	srv := NewServer("localhost", "0", nil)
+	srv.SetRateLimits(RateLimits{
+		Default: Limit{Rate: 0.001, Burst: 3},
+		Methods: map[string]Limit{
+			"test.Expensive": {Rate: 0.001, Burst: 1},
+		},
+	})
+	require.NoError(t, srv.WithMetrics())
+	srv.guardRateLimit("test", &internal)
+
+	first := context.WithValue(ctx, clientIPKey{}, "10.0.0.1")
+	second := context.WithValue(ctx, clientIPKey{}, "10.0.0.2")
+	authed := withSubject(first, tokenSubject("token"))
+
+	// expensive methods are limited separately from the other ones
+	require.NoError(t, internal.Expensive(first))
+	require.ErrorIs(t, internal.Expensive(first), ErrRateLimited)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, internal.Cheap(first))
+	}
+	require.ErrorIs(t, internal.Cheap(first), ErrRateLimited)
+	// methods which can't fail are not limited
+	assert.Equal(t, 1, internal.Infallible(first))
+
+	// clients are limited by their tokens, or by their IPs otherwise
+	require.NoError(t, internal.Expensive(second))
+	require.NoError(t, internal.Expensive(authed))
+	require.ErrorIs(t, internal.Expensive(authed), ErrRateLimited)
+}
+
+func TestServer_TrustedProxies(t *testing.T) {
+	srv := NewServer("localhost", "0", nil)
+	var ip string
+	handler := srv.withClientIP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _ = r.Context().Value(clientIPKey{}).(string)
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("X-Forwarded-For", "1.1.1.1")
+
+	// forwarded IPs are ignored, unless the proxy is trusted
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	assert.Equal(t, "10.0.0.1", ip)
+
+	proxies, err := httputil.ParseTrustedProxies([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	srv.SetTrustedProxies(proxies)
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	assert.Equal(t, "1.1.1.1", ip)
+}
@@ -2,9 +2,14 @@ package rpc
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"reflect"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -14,11 +19,25 @@ import (
 	logging "github.com/ipfs/go-log/v2"
 
 	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/blob"
+	"github.com/celestiaorg/celestia-node/libs/audit"
 	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	"github.com/celestiaorg/celestia-node/libs/httputil"
 )
 
 var log = logging.Logger("rpc")
 
+var (
+	// ErrModuleDisabled is returned by methods of modules disabled at runtime.
+	ErrModuleDisabled = errors.New("rpc: module is disabled")
+	// ErrDebugDisabled is returned by debug methods while the server is not in debug mode.
+	ErrDebugDisabled = errors.New("rpc: debug mode is disabled")
+)
+
+// adminModule is the module serving the admin API, which can't be disabled, as it is the one
+// enabling modules back.
+const adminModule = "node"
+
 type Server struct {
 	srv      *http.Server
 	rpc      *jsonrpc.RPCServer
@@ -27,10 +46,30 @@ type Server struct {
 	started atomic.Bool
 
 	auth jwt.Signer
+
+	modulesLk sync.RWMutex
+	// modules maps the registered authed modules onto whether they are enabled
+	modules map[string]bool
+	// debug enables methods tagged with `debug:"true"`
+	debug atomic.Bool
+	// audit records authenticated calls of write and admin methods, if set
+	audit *audit.Log
+	// revocations rejects revoked tokens, if set
+	revocations *authtoken.Revocations
+	// limiter limits the rate of calls of clients, if set
+	limiter *rateLimiter
+	// subs limits the active subscriptions of clients, if set
+	subs *subscriptions
+	// batch limits the batches of requests and serves them concurrently, if set
+	batch *BatchLimits
+	// proxies report the IPs of the clients they forward, if set
+	proxies *httputil.TrustedProxies
+	// tls terminates TLS of the connections, if set
+	tls *tls.Config
 }
 
 func NewServer(address, port string, secret jwt.Signer) *Server {
-	rpc := jsonrpc.NewServer()
+	rpc := jsonrpc.NewServer(ParamDecoders()...)
 	srv := &Server{
 		rpc: rpc,
 		srv: &http.Server{
@@ -38,20 +77,49 @@ func NewServer(address, port string, secret jwt.Signer) *Server {
 			// the amount of time allowed to read request headers. set to the default 2 seconds
 			ReadHeaderTimeout: 2 * time.Second,
 		},
-		auth: secret,
-	}
-	srv.srv.Handler = &auth.Handler{
-		Verify: srv.verifyAuth,
-		Next:   rpc.ServeHTTP,
+		auth:    secret,
+		modules: make(map[string]bool),
 	}
+	srv.srv.Handler = withEncodingVersion(srv.withClientIP(srv.authHandler(srv.batchHandler(rpc))))
 	return srv
 }
 
-// verifyAuth is the RPC server's auth middleware. This middleware is only
-// reached if a token is provided in the header of the request, otherwise only
-// methods with `read` permissions are accessible.
-func (s *Server) verifyAuth(_ context.Context, token string) ([]auth.Permission, error) {
-	return authtoken.ExtractSignedPermissions(s.auth, token)
+// authHandler is the RPC server's auth middleware. It grants the request the permissions of the
+// token provided in the header, otherwise only methods with `public` permissions are accessible.
+// Requests with namespace-scoped tokens get their context scoped to the namespaces as well.
+func (s *Server) authHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// tokens are only accepted in the header, as tokens in the query string leak into logs of
+		// proxies and into the browser history
+		token := r.Header.Get(perms.AuthKey)
+		if token == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !strings.HasPrefix(token, "Bearer ") {
+			log.Warn("missing Bearer prefix in auth header")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		token = strings.TrimPrefix(token, "Bearer ")
+		payload, err := authtoken.VerifyPayload(s.auth, s.revocations, token)
+		if err != nil {
+			log.Warnw("JWT verification failed", "err", err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		ctx := auth.WithPerm(r.Context(), payload.Allow)
+		ctx = withSubject(ctx, tokenSubject(token))
+		if len(payload.Namespaces) != 0 {
+			ctx = perms.WithNamespaces(ctx, payload.Namespaces)
+		}
+		if len(payload.Methods) != 0 {
+			ctx = perms.WithMethods(ctx, payload.Methods)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
 }
 
 // RegisterService registers a service onto the RPC server. All methods on the service will then be
@@ -62,15 +130,241 @@ func (s *Server) RegisterService(namespace string, service interface{}) {
 
 // RegisterAuthedService registers a service onto the RPC server. All methods on the service will
 // then be exposed over the RPC.
+//
+// Namespace-scoped tokens are only granted their permissions on services enforcing the namespaces
+// of the token, i.e. the blob service, and on methods submitting blobs of the namespaces of the
+// token. Otherwise, they are granted the default permissions.
+// Method-scoped tokens can only invoke the methods they are scoped to.
 func (s *Server) RegisterAuthedService(namespace string, service interface{}, out interface{}) {
-	auth.PermissionedProxy(perms.AllPerms, perms.DefaultPerms, service, getInternalStruct(out))
+	internal := getInternalStruct(out)
+	auth.PermissionedProxy(perms.AllPerms, perms.DefaultPerms, service, internal)
+	if !namespaceScopedServices[namespace] {
+		restrictScoped(internal)
+	}
+	restrictMethods(namespace, internal)
+	respondLegacy(internal)
+	s.guardDebug(internal)
+	s.guardDisabled(namespace, internal)
+	s.guardAudit(namespace, internal)
+	s.guardSubscriptions(namespace, internal)
+	s.guardRateLimit(namespace, internal)
 	s.RegisterService(namespace, out)
 }
 
+// Modules returns the registered authed modules mapped onto whether they are enabled.
+func (s *Server) Modules() map[string]bool {
+	s.modulesLk.RLock()
+	defer s.modulesLk.RUnlock()
+
+	modules := make(map[string]bool, len(s.modules))
+	for module, enabled := range s.modules {
+		modules[module] = enabled
+	}
+	return modules
+}
+
+// SetModuleEnabled enables or disables the given registered authed module at runtime. Methods of a
+// disabled module fail with ErrModuleDisabled until it is enabled again.
+func (s *Server) SetModuleEnabled(module string, enabled bool) error {
+	if module == adminModule && !enabled {
+		return fmt.Errorf("rpc: module %s serves the admin API and can't be disabled", module)
+	}
+
+	s.modulesLk.Lock()
+	defer s.modulesLk.Unlock()
+	if _, ok := s.modules[module]; !ok {
+		return fmt.Errorf("rpc: unknown module %s", module)
+	}
+	s.modules[module] = enabled
+	log.Infow("module toggled", "module", module, "enabled", enabled)
+	return nil
+}
+
+func (s *Server) moduleEnabled(module string) bool {
+	s.modulesLk.RLock()
+	defer s.modulesLk.RUnlock()
+	return s.modules[module]
+}
+
+// SetDebug enables or disables the debug mode of the server at runtime. Methods tagged with
+// `debug:"true"` fail with ErrDebugDisabled unless the server is in debug mode.
+func (s *Server) SetDebug(enabled bool) {
+	s.debug.Store(enabled)
+}
+
+var errType = reflect.TypeOf((*error)(nil)).Elem()
+
+// guardDebug wraps the methods of the internal struct tagged with `debug:"true"`, so that they fail
+// with ErrDebugDisabled while the server is not in debug mode.
+func (s *Server) guardDebug(internal interface{}) {
+	v := reflect.ValueOf(internal).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if v.Type().Field(i).Tag.Get("debug") != "true" || field.Kind() != reflect.Func || field.IsNil() {
+			continue
+		}
+		typ := field.Type()
+		if typ.NumOut() == 0 || typ.Out(typ.NumOut()-1) != errType {
+			continue
+		}
+
+		method := reflect.ValueOf(field.Interface())
+		field.Set(reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+			if s.debug.Load() {
+				return method.Call(args)
+			}
+			return errResults(typ, ErrDebugDisabled)
+		}))
+	}
+}
+
+// errResults returns the zero results of the given func type with the given error as the last one.
+func errResults(typ reflect.Type, err error) []reflect.Value {
+	out := make([]reflect.Value, typ.NumOut())
+	for i := range out[:len(out)-1] {
+		out[i] = reflect.Zero(typ.Out(i))
+	}
+	out[len(out)-1] = reflect.ValueOf(&err).Elem()
+	return out
+}
+
+// guardDisabled registers the module as enabled and wraps all methods of its internal struct, so
+// that they fail with ErrModuleDisabled while the module is disabled.
+func (s *Server) guardDisabled(module string, internal interface{}) {
+	s.modulesLk.Lock()
+	s.modules[module] = true
+	s.modulesLk.Unlock()
+
+	errDisabled := fmt.Errorf("%w: %s", ErrModuleDisabled, module)
+	v := reflect.ValueOf(internal).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Func || field.IsNil() {
+			continue
+		}
+		typ := field.Type()
+		if typ.NumOut() == 0 || typ.Out(typ.NumOut()-1) != errType {
+			continue
+		}
+
+		method := reflect.ValueOf(field.Interface())
+		field.Set(reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+			if s.moduleEnabled(module) {
+				return method.Call(args)
+			}
+			return errResults(typ, errDisabled)
+		}))
+	}
+}
+
+// namespaceScopedServices are the services enforcing namespaces of scoped tokens.
+var namespaceScopedServices = map[string]bool{
+	"blob": true,
+}
+
+var (
+	ctxType   = reflect.TypeOf((*context.Context)(nil)).Elem()
+	blobsType = reflect.TypeOf([]*blob.Blob(nil))
+)
+
+// restrictScoped wraps all methods of the internal struct, so that requests with namespace-scoped
+// tokens only get the default permissions on them. Methods submitting blobs, e.g.
+// state.SubmitPayForBlob, keep the permissions of the token, if the namespaces of all the blobs are
+// the ones of the token. Other write and admin methods, which can't enforce the namespaces, e.g.
+// state.SubmitTx, fail with perms.ErrNamespaceNotAllowed for them instead.
+func restrictScoped(internal interface{}) {
+	v := reflect.ValueOf(internal).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Func || field.IsNil() ||
+			field.Type().NumIn() == 0 || field.Type().In(0) != ctxType {
+			continue
+		}
+
+		typ := field.Type()
+		perm := v.Type().Field(i).Tag.Get("perm")
+		canFail := typ.NumOut() != 0 && typ.Out(typ.NumOut()-1) == errType
+		reject := (perm == "write" || perm == "admin") && canFail
+		blobsIdx := -1
+		for in := 1; in < typ.NumIn() && canFail; in++ {
+			if typ.In(in) == blobsType {
+				blobsIdx = in
+			}
+		}
+		method := reflect.ValueOf(field.Interface())
+		field.Set(reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+			ctx := args[0].Interface().(context.Context)
+			if _, ok := perms.Namespaces(ctx); ok {
+				switch {
+				case blobsIdx != -1:
+					for _, b := range args[blobsIdx].Interface().([]*blob.Blob) {
+						if b == nil {
+							continue
+						}
+						if err := perms.CheckNamespace(ctx, b.Namespace()); err != nil {
+							return errResults(typ, err)
+						}
+					}
+				case reject:
+					return errResults(typ, perms.ErrNamespaceNotAllowed)
+				default:
+					args[0] = reflect.ValueOf(auth.WithPerm(ctx, perms.DefaultPerms))
+				}
+			}
+			return method.Call(args)
+		}))
+	}
+}
+
+// restrictMethods wraps all methods of the internal struct, so that requests with method-scoped
+// tokens fail with perms.ErrMethodNotAllowed on methods the tokens are not scoped to. Methods which
+// can't fail are invoked with the default permissions instead.
+func restrictMethods(module string, internal interface{}) {
+	v := reflect.ValueOf(internal).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Func || field.IsNil() ||
+			field.Type().NumIn() == 0 || field.Type().In(0) != ctxType {
+			continue
+		}
+
+		typ := field.Type()
+		canFail := typ.NumOut() != 0 && typ.Out(typ.NumOut()-1) == errType
+		name := v.Type().Field(i).Name
+		method := reflect.ValueOf(field.Interface())
+		field.Set(reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+			ctx := args[0].Interface().(context.Context)
+			if err := perms.CheckMethod(ctx, module, name); err != nil {
+				if canFail {
+					return errResults(typ, err)
+				}
+				args[0] = reflect.ValueOf(auth.WithPerm(ctx, perms.DefaultPerms))
+			}
+			return method.Call(args)
+		}))
+	}
+}
+
 func getInternalStruct(api interface{}) interface{} {
 	return reflect.ValueOf(api).Elem().FieldByName("Internal").Addr().Interface()
 }
 
+// SetRevocations makes the server reject the given revoked tokens.
+func (s *Server) SetRevocations(revocations *authtoken.Revocations) {
+	s.revocations = revocations
+}
+
+// SetTLS makes the server serve HTTPS with the given config. It must be called before Start.
+func (s *Server) SetTLS(cfg *tls.Config) {
+	s.tls = cfg
+}
+
+// SetCORS makes the server allow browsers to call it from the configured origins. It must be
+// called before Start.
+func (s *Server) SetCORS(cfg httputil.CORSConfig) {
+	s.srv.Handler = httputil.WithCORS(cfg, s.srv.Handler)
+}
+
 // Start starts the RPC Server.
 func (s *Server) Start(context.Context) error {
 	couldStart := s.started.CompareAndSwap(false, true)
@@ -82,8 +376,11 @@ func (s *Server) Start(context.Context) error {
 	if err != nil {
 		return err
 	}
+	if s.tls != nil {
+		listener = tls.NewListener(listener, s.tls)
+	}
 	s.listener = listener
-	log.Infow("server started", "listening on", s.srv.Addr)
+	log.Infow("server started", "listening on", s.srv.Addr, "tls", s.tls != nil)
 	//nolint:errcheck
 	go s.srv.Serve(listener)
 	return nil
@@ -0,0 +1,38 @@
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cristalhq/jwt"
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+)
+
+func TestAuthHandler_HeaderOnly(t *testing.T) {
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+	token, err := authtoken.NewSignedJWT(signer, perms.ReadWritePerms)
+	require.NoError(t, err)
+
+	srv := NewServer("localhost", "0", signer)
+	var authorized bool
+	handler := srv.authHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorized = auth.HasPerm(r.Context(), perms.DefaultPerms, "read")
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(perms.AuthKey, "Bearer "+token)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.True(t, authorized)
+
+	// tokens in the query string are ignored
+	req = httptest.NewRequest(http.MethodPost, "/?token="+token, nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.False(t, authorized)
+}
@@ -0,0 +1,217 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+)
+
+// ErrTooManySubscriptions is returned by subscription methods called by clients which are at their
+// limit of active subscriptions.
+var ErrTooManySubscriptions = errors.New("rpc: too many active subscriptions")
+
+const subscriptionMethodKey = "method"
+
+// SubscriptionLimits limits the subscriptions of the clients of the server, i.e. the calls of
+// methods returning channels. Clients are told apart by the tokens they authenticate with, or by
+// their IPs otherwise.
+type SubscriptionLimits struct {
+	// MaxPerClient is the maximum amount of active subscriptions of a client. Zero means no limit.
+	MaxPerClient int
+	// StallTimeout is the time after which subscriptions whose consumers stopped reading are closed.
+	// Zero means they are never closed.
+	StallTimeout time.Duration
+}
+
+// subscriptions tracks the active subscriptions of clients and closes the stalled ones.
+type subscriptions struct {
+	limits SubscriptionLimits
+
+	lk     sync.Mutex
+	active map[string]int
+
+	// metrics are set, once enabled
+	rejected syncint64.Counter // attributes: method[string]
+	reaped   syncint64.Counter // attributes: method[string]
+}
+
+func newSubscriptions(limits SubscriptionLimits) *subscriptions {
+	return &subscriptions{
+		limits: limits,
+		active: make(map[string]int),
+	}
+}
+
+// acquire reserves a subscription of the client, unless the client is at its limit.
+func (s *subscriptions) acquire(client string) bool {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	if s.limits.MaxPerClient > 0 && s.active[client] >= s.limits.MaxPerClient {
+		return false
+	}
+	s.active[client]++
+	return true
+}
+
+func (s *subscriptions) release(client string) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.active[client]--
+	if s.active[client] <= 0 {
+		delete(s.active, client)
+	}
+}
+
+func (s *subscriptions) total() int64 {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	var total int64
+	for _, amount := range s.active {
+		total += int64(amount)
+	}
+	return total
+}
+
+// forward forwards the values of the subscription from the in channel to the out channel, which is
+// closed once the subscription ends. The subscription is canceled, if its consumer doesn't receive
+// a value within the stall timeout.
+func (s *subscriptions) forward(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	client, method string,
+	in, out reflect.Value,
+) {
+	defer s.release(client)
+	defer out.Close()
+	defer cancel()
+
+	done := reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+	for {
+		chosen, val, ok := reflect.Select([]reflect.SelectCase{
+			done,
+			{Dir: reflect.SelectRecv, Chan: in},
+		})
+		if chosen == 0 || !ok {
+			return
+		}
+
+		cases := []reflect.SelectCase{done, {Dir: reflect.SelectSend, Chan: out, Send: val}}
+		if s.limits.StallTimeout > 0 {
+			timer := time.NewTimer(s.limits.StallTimeout)
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)})
+			chosen, _, _ = reflect.Select(cases)
+			timer.Stop()
+		} else {
+			chosen, _, _ = reflect.Select(cases)
+		}
+		switch chosen {
+		case 0:
+			return
+		case 2:
+			log.Warnw("closing stalled subscription", "method", method, "client", client)
+			s.observeReaped(ctx, method)
+			return
+		}
+	}
+}
+
+func (s *subscriptions) observeRejected(ctx context.Context, method string) {
+	if s.rejected == nil {
+		return
+	}
+	s.rejected.Add(ctx, 1, attribute.String(subscriptionMethodKey, method))
+}
+
+func (s *subscriptions) observeReaped(ctx context.Context, method string) {
+	if s.reaped == nil {
+		return
+	}
+	s.reaped.Add(ctx, 1, attribute.String(subscriptionMethodKey, method))
+}
+
+func (s *subscriptions) initMetrics() error {
+	rejected, err := meter.SyncInt64().Counter("rpc_rejected_subscriptions",
+		instrument.WithDescription("amount of subscriptions rejected by the limit of active subscriptions"))
+	if err != nil {
+		return err
+	}
+
+	reaped, err := meter.SyncInt64().Counter("rpc_reaped_subscriptions",
+		instrument.WithDescription("amount of subscriptions closed as their consumers stopped reading"))
+	if err != nil {
+		return err
+	}
+
+	active, err := meter.AsyncInt64().Gauge("rpc_active_subscriptions",
+		instrument.WithDescription("amount of active subscriptions of all clients"))
+	if err != nil {
+		return err
+	}
+
+	s.rejected, s.reaped = rejected, reaped
+	return meter.RegisterCallback(
+		[]instrument.Asynchronous{active},
+		func(ctx context.Context) {
+			active.Observe(ctx, s.total())
+		},
+	)
+}
+
+// SetSubscriptionLimits makes the server limit the active subscriptions of its clients and close the
+// stalled ones.
+func (s *Server) SetSubscriptionLimits(limits SubscriptionLimits) {
+	s.subs = newSubscriptions(limits)
+}
+
+// guardSubscriptions wraps the methods of the internal struct returning channels, so that they fail
+// with ErrTooManySubscriptions while their client is at its limit of active subscriptions, and
+// their channels are closed once their consumers stop reading.
+func (s *Server) guardSubscriptions(module string, internal interface{}) {
+	v := reflect.ValueOf(internal).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Func || field.IsNil() ||
+			field.Type().NumIn() == 0 || field.Type().In(0) != ctxType {
+			continue
+		}
+		typ := field.Type()
+		if typ.NumOut() != 2 || typ.Out(0).Kind() != reflect.Chan || typ.Out(1) != errType {
+			continue
+		}
+
+		name := module + "." + v.Type().Field(i).Name
+		chanType := reflect.ChanOf(reflect.BothDir, typ.Out(0).Elem())
+		method := reflect.ValueOf(field.Interface())
+		field.Set(reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+			if s.subs == nil {
+				return method.Call(args)
+			}
+			ctx := args[0].Interface().(context.Context)
+			client, _ := clientFrom(ctx)
+			if !s.subs.acquire(client) {
+				s.subs.observeRejected(ctx, name)
+				return errResults(typ, ErrTooManySubscriptions)
+			}
+
+			ctx, cancel := context.WithCancel(ctx)
+			args[0] = reflect.ValueOf(ctx)
+			results := method.Call(args)
+			if !results[1].IsNil() || results[0].IsNil() {
+				cancel()
+				s.subs.release(client)
+				return results
+			}
+
+			out := reflect.MakeChan(chanType, 0)
+			go s.subs.forward(ctx, cancel, client, name, results[0], out)
+			results[0] = out.Convert(typ.Out(0))
+			return results
+		}))
+	}
+}
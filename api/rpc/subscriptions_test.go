@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Subscriptions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	var internal struct {
+		Subscribe func(context.Context) (<-chan int, error)
+	}
+	// producers stop once their subscriptions are canceled
+	canceled := make(chan struct{}, 4)
+	internal.Subscribe = func(ctx context.Context) (<-chan int, error) {
+		ch := make(chan int)
+		go func() {
+			defer close(ch)
+			defer func() { canceled <- struct{}{} }()
+			for i := 0; ; i++ {
+				select {
+				case ch <- i:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return ch, nil
+	}
+
+	srv := NewServer("localhost", "0", nil)
+	srv.SetSubscriptionLimits(SubscriptionLimits{MaxPerClient: 2, StallTimeout: 100 * time.Millisecond})
+	require.NoError(t, srv.WithMetrics())
+	srv.guardSubscriptions("test", &internal)
+
+	client := context.WithValue(ctx, clientIPKey{}, "10.0.0.1")
+	first, err := internal.Subscribe(client)
+	require.NoError(t, err)
+	assert.Equal(t, 0, <-first)
+	assert.Equal(t, 1, <-first)
+	_, err = internal.Subscribe(client)
+	require.NoError(t, err)
+
+	// clients can't exceed their limit, while other clients can still subscribe
+	_, err = internal.Subscribe(client)
+	require.ErrorIs(t, err, ErrTooManySubscriptions)
+	other := context.WithValue(ctx, clientIPKey{}, "10.0.0.2")
+	_, err = internal.Subscribe(other)
+	require.NoError(t, err)
+
+	// subscriptions whose consumers stopped reading are closed and free their slots
+	for i := 0; i < 3; i++ {
+		select {
+		case <-canceled:
+		case <-ctx.Done():
+			t.Fatal("stalled subscription wasn't closed")
+		}
+	}
+	_, ok := <-first
+	assert.False(t, ok)
+	require.Eventually(t, func() bool {
+		return srv.subs.total() == 0
+	}, time.Second, 10*time.Millisecond)
+	_, err = internal.Subscribe(client)
+	require.NoError(t, err)
+}
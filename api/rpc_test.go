@@ -3,8 +3,11 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"io"
+	"net/http"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,11 +18,17 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/fx"
 
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/nmt/namespace"
+
 	"github.com/celestiaorg/celestia-node/api/rpc"
 	"github.com/celestiaorg/celestia-node/api/rpc/client"
 	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	blobpkg "github.com/celestiaorg/celestia-node/blob"
 	daspkg "github.com/celestiaorg/celestia-node/das"
 	headerpkg "github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	"github.com/celestiaorg/celestia-node/libs/encoding"
 	"github.com/celestiaorg/celestia-node/nodebuilder"
 	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
 	blobMock "github.com/celestiaorg/celestia-node/nodebuilder/blob/mocks"
@@ -37,6 +46,7 @@ import (
 	shareMock "github.com/celestiaorg/celestia-node/nodebuilder/share/mocks"
 	statemod "github.com/celestiaorg/celestia-node/nodebuilder/state"
 	stateMock "github.com/celestiaorg/celestia-node/nodebuilder/state/mocks"
+	"github.com/celestiaorg/celestia-node/share/getters"
 	"github.com/celestiaorg/celestia-node/state"
 )
 
@@ -73,6 +83,75 @@ func TestRPCCallsUnderlyingNode(t *testing.T) {
 	require.Equal(t, expectedBalance, balance)
 }
 
+func TestRPCEncoding(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+	// results are encoded in the version of the request by the services registered with auth
+	nd, server := setupNodeWithAuthedRPC(t, signer)
+	url := "http://" + nd.RPCServer.ListenAddr()
+	token, err := perms.NewTokenWithPerms(signer, perms.ReadPerms)
+	require.NoError(t, err)
+
+	var respBody []byte
+	post := func(version, body string) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(perms.AuthKey, "Bearer "+string(token))
+		if version != "" {
+			req.Header.Set(encoding.VersionHeader, version)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		respBody, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, encoding.Version, resp.Header.Get(encoding.VersionHeader))
+		return resp
+	}
+
+	nID := namespace.ID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	hash := libhead.Hash{0xde, 0xad, 0xbe, 0xef}
+	b := &blobpkg.Blob{Commitment: blobpkg.Commitment{0xca, 0xfe}}
+	b.NamespaceId, b.Data = nID[1:], []byte{0x01}
+	server.Blob.EXPECT().GetAll(gomock.Any(), uint64(1), []namespace.ID{nID}).Return([]*blobpkg.Blob{b}, nil).Times(4)
+	server.Header.EXPECT().GetByHash(gomock.Any(), hash).Return(new(headerpkg.ExtendedHeader), nil).Times(4)
+
+	// params are decoded and results are encoded in the encoding of the version the request declares
+	for _, tc := range []struct {
+		version, nID, hash, commitment string
+	}{
+		{version: "", nID: `"AAECAwQFBgcICQ=="`, hash: `"DEADBEEF"`, commitment: `"yv4="`},
+		{version: encoding.LegacyVersion, nID: `"AAECAwQFBgcICQ=="`, hash: `"DEADBEEF"`, commitment: `"yv4="`},
+		{version: encoding.Version, nID: `"0x00010203040506070809"`, hash: `"0xdeadbeef"`, commitment: `"0xcafe"`},
+	} {
+		resp := post(tc.version, `{"jsonrpc":"2.0","id":1,"method":"blob.GetAll","params":[1,[`+tc.nID+`]]}`)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Contains(t, string(respBody), `"namespace":`+tc.nID)
+		require.Contains(t, string(respBody), `"commitment":`+tc.commitment)
+		resp = post(tc.version, `{"jsonrpc":"2.0","id":1,"method":"header.GetByHash","params":[`+tc.hash+`]}`)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// encodings of other versions are not guessed
+	resp := post(encoding.Version, `{"jsonrpc":"2.0","id":1,"method":"blob.GetAll","params":[1,["AAECAwQFBgcICQ=="]]}`)
+	require.NotEqual(t, http.StatusOK, resp.StatusCode)
+	// unsupported versions are rejected
+	resp = post("42", `{"jsonrpc":"2.0","id":1,"method":"blob.GetAll","params":[1,["0x00010203040506070809"]]}`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	rpcClient, err := client.NewClient(ctx, url, string(token))
+	require.NoError(t, err)
+	t.Cleanup(rpcClient.Close)
+	blobs, err := rpcClient.Blob.GetAll(ctx, 1, []namespace.ID{nID})
+	require.NoError(t, err)
+	require.Equal(t, b.Commitment, blobs[0].Commitment)
+	_, err = rpcClient.Header.GetByHash(ctx, hash)
+	require.NoError(t, err)
+}
+
 // api contains all modules that are made available as the node's
 // public API surface
 type api struct {
@@ -213,6 +292,196 @@ func TestAuthedRPC(t *testing.T) {
 	}
 }
 
+// TestNamespaceScopedRPC tests that namespace-scoped tokens only grant their permissions
+// on the blob service, which receives the namespaces of the token.
+func TestNamespaceScopedRPC(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+
+	nd, server := setupNodeWithAuthedRPC(t, signer)
+	url := nd.RPCServer.ListenAddr()
+
+	nID := namespace.ID{1, 2, 3, 4, 5, 6, 7, 8}
+	token, err := authtoken.NewSignedJWT(signer, perms.ReadWritePerms, nID)
+	require.NoError(t, err)
+
+	var rpcClient *client.Client
+	for i := 0; i < 3; i++ {
+		time.Sleep(time.Second * 1)
+		rpcClient, err = client.NewClient(ctx, "http://"+url, token)
+		if err == nil {
+			break
+		}
+	}
+	require.NoError(t, err)
+	t.Cleanup(rpcClient.Close)
+
+	// the blob service gets the namespaces of the token to enforce them
+	server.Blob.EXPECT().GetAll(gomock.Any(), uint64(1), []namespace.ID{nID}).DoAndReturn(
+		func(ctx context.Context, _ uint64, _ []namespace.ID) ([]*blobpkg.Blob, error) {
+			nIDs, ok := perms.Namespaces(ctx)
+			require.True(t, ok)
+			require.Equal(t, []namespace.ID{nID}, nIDs)
+			return nil, nil
+		})
+	_, err = rpcClient.Blob.GetAll(ctx, 1, []namespace.ID{nID})
+	require.NoError(t, err)
+
+	// public methods of other services are still accessible
+	server.Header.EXPECT().NetworkHead(gomock.Any()).Return(new(headerpkg.ExtendedHeader), nil)
+	_, err = rpcClient.Header.NetworkHead(ctx)
+	require.NoError(t, err)
+
+	// blobs of the namespaces of the token can be paid for, but not blobs of other namespaces
+	allowed := &blobpkg.Blob{}
+	allowed.NamespaceVersion, allowed.NamespaceId = uint32(nID[0]), nID[1:]
+	other := &blobpkg.Blob{}
+	other.NamespaceId = namespace.ID{9, 9, 9, 9, 9, 9, 9}
+	server.State.EXPECT().SubmitPayForBlob(gomock.Any(), gomock.Any(), uint64(1), gomock.Len(1)).
+		Return(&state.TxResponse{}, nil)
+	_, err = rpcClient.State.SubmitPayForBlob(ctx, sdk.NewInt(1), 1, []*blobpkg.Blob{allowed})
+	require.NoError(t, err)
+	_, err = rpcClient.State.SubmitPayForBlob(ctx, sdk.NewInt(1), 1, []*blobpkg.Blob{allowed, other})
+	require.ErrorContains(t, err, perms.ErrNamespaceNotAllowed.Error())
+
+	// but other services are not accessible with permissions of the token, and their write methods
+	// can't be used to bypass the namespaces
+	_, err = rpcClient.State.SubmitTx(ctx, []byte{})
+	require.ErrorContains(t, err, perms.ErrNamespaceNotAllowed.Error())
+	_, err = rpcClient.DAS.SamplingStats(ctx)
+	require.ErrorContains(t, err, "missing permission")
+}
+
+// TestMethodScopedRPC tests that method-scoped tokens can only invoke the methods they are scoped
+// to.
+func TestMethodScopedRPC(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+
+	nd, server := setupNodeWithAuthedRPC(t, signer)
+	url := nd.RPCServer.ListenAddr()
+
+	token, err := authtoken.NewSignedPayloadJWT(signer, &perms.JWTPayload{
+		Allow:   perms.ReadWritePerms,
+		Methods: []string{"blob.GetAll", "header"},
+	})
+	require.NoError(t, err)
+
+	var rpcClient *client.Client
+	for i := 0; i < 3; i++ {
+		time.Sleep(time.Second * 1)
+		rpcClient, err = client.NewClient(ctx, "http://"+url, token)
+		if err == nil {
+			break
+		}
+	}
+	require.NoError(t, err)
+	t.Cleanup(rpcClient.Close)
+
+	// the token can invoke the methods and modules it is scoped to
+	server.Blob.EXPECT().GetAll(gomock.Any(), uint64(1), gomock.Any()).Return(nil, nil)
+	_, err = rpcClient.Blob.GetAll(ctx, 1, []namespace.ID{{1}})
+	require.NoError(t, err)
+	server.Header.EXPECT().LocalHead(gomock.Any()).Return(new(headerpkg.ExtendedHeader), nil)
+	_, err = rpcClient.Header.LocalHead(ctx)
+	require.NoError(t, err)
+
+	// but no other methods, even if its permissions allow them
+	_, err = rpcClient.Blob.Submit(ctx, nil)
+	require.ErrorContains(t, err, perms.ErrMethodNotAllowed.Error())
+	_, err = rpcClient.DAS.SamplingStats(ctx)
+	require.ErrorContains(t, err, perms.ErrMethodNotAllowed.Error())
+}
+
+// TestRPCModuleToggle tests that methods of modules disabled at runtime fail until they are enabled
+// again.
+func TestRPCModuleToggle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+
+	nd, server := setupNodeWithAuthedRPC(t, signer)
+	url := nd.RPCServer.ListenAddr()
+
+	token, err := authtoken.NewSignedJWT(signer, perms.AllPerms)
+	require.NoError(t, err)
+
+	var rpcClient *client.Client
+	for i := 0; i < 3; i++ {
+		time.Sleep(time.Second * 1)
+		rpcClient, err = client.NewClient(ctx, "http://"+url, token)
+		if err == nil {
+			break
+		}
+	}
+	require.NoError(t, err)
+	t.Cleanup(rpcClient.Close)
+
+	require.NoError(t, nd.RPCServer.SetModuleEnabled("das", false))
+	require.False(t, nd.RPCServer.Modules()["das"])
+	_, err = rpcClient.DAS.SamplingStats(ctx)
+	require.ErrorContains(t, err, rpc.ErrModuleDisabled.Error())
+
+	// other modules are still served
+	server.Header.EXPECT().NetworkHead(gomock.Any()).Return(new(headerpkg.ExtendedHeader), nil)
+	_, err = rpcClient.Header.NetworkHead(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, nd.RPCServer.SetModuleEnabled("das", true))
+	server.Das.EXPECT().SamplingStats(gomock.Any()).Return(daspkg.SamplingStats{}, nil)
+	_, err = rpcClient.DAS.SamplingStats(ctx)
+	require.NoError(t, err)
+
+	require.Error(t, nd.RPCServer.SetModuleEnabled("node", false))
+	require.Error(t, nd.RPCServer.SetModuleEnabled("unknown", false))
+}
+
+// TestRPCDebugMode tests that debug methods are only served in debug mode.
+func TestRPCDebugMode(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+
+	nd, server := setupNodeWithAuthedRPC(t, signer)
+	url := nd.RPCServer.ListenAddr()
+
+	token, err := authtoken.NewSignedJWT(signer, perms.AllPerms)
+	require.NoError(t, err)
+
+	var rpcClient *client.Client
+	for i := 0; i < 3; i++ {
+		time.Sleep(time.Second * 1)
+		rpcClient, err = client.NewClient(ctx, "http://"+url, token)
+		if err == nil {
+			break
+		}
+	}
+	require.NoError(t, err)
+	t.Cleanup(rpcClient.Close)
+
+	_, err = rpcClient.Share.GetShareWithTrace(ctx, nil, 0, 0)
+	require.ErrorContains(t, err, rpc.ErrDebugDisabled.Error())
+
+	nd.RPCServer.SetDebug(true)
+	expected := &share.TracedShare{Trace: &getters.CascadeTrace{
+		Attempts: []getters.CascadeAttempt{{Getter: "getters.StoreGetter", Duration: time.Second}},
+	}}
+	server.Share.EXPECT().GetShareWithTrace(gomock.Any(), gomock.Any(), 0, 0).Return(expected, nil)
+	traced, err := rpcClient.Share.GetShareWithTrace(ctx, nil, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, expected.Trace.Attempts, traced.Trace.Attempts)
+}
+
 // TestPublicClient tests that the public rpc client can only
 // access public methods.
 func TestPublicClient(t *testing.T) {
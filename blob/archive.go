@@ -0,0 +1,293 @@
+package blob
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+const (
+	// ArchiveVersion is the version of the Archive format written by Export.
+	ArchiveVersion = 1
+	// MaxArchiveHeights is the maximum amount of heights exported into a single Archive.
+	MaxArchiveHeights = 1000
+)
+
+// MaxArchiveSize is the maximum size of the decompressed archive files read by ReadArchive.
+var MaxArchiveSize int64 = 1 << 30
+
+var ErrInvalidArchive = errors.New("blob: invalid archive")
+
+// Archive is a portable collection of blobs of a namespace over a range of heights. Every blob
+// comes with its Proof, so that the Archive is verified elsewhere, e.g. by another node with Import,
+// without access to the node it was exported from. Heights without blobs of the namespace are
+// left out.
+//
+// The Archive proves that its blobs were published, but not that it holds all the blobs of the
+// namespace, as the absence of the others is not proven.
+type Archive struct {
+	Version   int          `json:"version"`
+	Namespace namespace.ID `json:"namespace"`
+	// From and To are the first and the last height of the exported range.
+	From    uint64           `json:"from"`
+	To      uint64           `json:"to"`
+	Heights []*ArchiveHeight `json:"heights"`
+}
+
+// ArchiveHeight holds the blobs of the namespace at a height.
+type ArchiveHeight struct {
+	Height uint64 `json:"height"`
+	// Error describes the failure to export the height, if any. It is only set by Export.
+	Error string `json:"error,omitempty"`
+	// HeaderHash is the hash of the header at the height, which DataHash is the data root the
+	// Proofs are verified against.
+	HeaderHash libhead.Hash `json:"header_hash"`
+	DataHash   libhead.Hash `json:"data_hash"`
+	Blobs      []*Blob      `json:"blobs"`
+	// Proofs are the proofs of the Blobs, in the same order.
+	Proofs []*Proof `json:"proofs"`
+}
+
+// Legacy returns a copy of the ArchiveHeight encoding JSON in encoding.LegacyVersion.
+func (h *ArchiveHeight) Legacy() *ArchiveHeight {
+	if h == nil {
+		return nil
+	}
+	legacy := *h
+	legacy.Blobs = legacyBlobs(h.Blobs)
+	legacy.Proofs = make([]*Proof, len(h.Proofs))
+	for i, proof := range h.Proofs {
+		legacy.Proofs[i] = proof.Legacy()
+	}
+	return &legacy
+}
+
+// Verify checks that the Archive is well-formed and that all its blobs are proven against the data
+// roots of their heights. The data roots themselves are not checked against any chain, which is
+// done by Import.
+func (a *Archive) Verify() error {
+	if a == nil {
+		return fmt.Errorf("%w: no archive", ErrInvalidArchive)
+	}
+	if a.Version != ArchiveVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidArchive, a.Version)
+	}
+	if len(a.Namespace) != share.NamespaceSize {
+		return fmt.Errorf("%w: invalid namespace size %d", ErrInvalidArchive, len(a.Namespace))
+	}
+	if a.From == 0 || a.From > a.To || a.To-a.From >= MaxArchiveHeights {
+		return fmt.Errorf("%w: invalid height range [%d:%d]", ErrInvalidArchive, a.From, a.To)
+	}
+
+	for i, h := range a.Heights {
+		if h == nil {
+			return fmt.Errorf("%w: height %d is missing", ErrInvalidArchive, i)
+		}
+		if h.Height < a.From || h.Height > a.To || i > 0 && h.Height <= a.Heights[i-1].Height {
+			return fmt.Errorf("%w: height %d is out of order or range", ErrInvalidArchive, h.Height)
+		}
+		if h.Error != "" {
+			return fmt.Errorf("%w: height %d failed to be exported: %s", ErrInvalidArchive, h.Height, h.Error)
+		}
+		if len(h.Blobs) == 0 || len(h.Blobs) != len(h.Proofs) {
+			return fmt.Errorf("%w: height %d: %d blobs and %d proofs", ErrInvalidArchive, h.Height,
+				len(h.Blobs), len(h.Proofs))
+		}
+		for j, b := range h.Blobs {
+			if b == nil {
+				return fmt.Errorf("%w: height %d: blob %d is missing", ErrInvalidArchive, h.Height, j)
+			}
+			if err := prepareBlobs([]*Blob{b}); err != nil {
+				return fmt.Errorf("%w: height %d: blob %d: %w", ErrInvalidArchive, h.Height, j, err)
+			}
+			proof := h.Proofs[j]
+			if proof == nil || !bytes.Equal(b.Namespace(), a.Namespace) ||
+				!bytes.Equal(proof.Namespace, a.Namespace) || !proof.Commitment.Equal(b.Commitment) {
+				return fmt.Errorf("%w: height %d: proof %d is not of the blob", ErrInvalidArchive, h.Height, j)
+			}
+			if err := VerifyProof(proof, h.DataHash); err != nil {
+				return fmt.Errorf("%w: height %d: blob %d: %w", ErrInvalidArchive, h.Height, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Blobs returns all the blobs of the Archive in order of their heights.
+func (a *Archive) Blobs() []*Blob {
+	blobs := make([]*Blob, 0, len(a.Heights))
+	for _, h := range a.Heights {
+		blobs = append(blobs, h.Blobs...)
+	}
+	return blobs
+}
+
+// WriteArchive writes the Archive to w as gzipped JSON, the format of archive files.
+func WriteArchive(w io.Writer, a *Archive) error {
+	zw := gzip.NewWriter(w)
+	if err := json.NewEncoder(zw).Encode(a); err != nil {
+		return fmt.Errorf("blob: writing archive: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("blob: writing archive: %w", err)
+	}
+	return nil
+}
+
+// ReadArchive reads the Archive written by WriteArchive from r. Archives larger than
+// MaxArchiveSize once decompressed are rejected. The Archive is not verified.
+func ReadArchive(r io.Reader) (*Archive, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("blob: reading archive: %w", err)
+	}
+	defer zr.Close()
+
+	lr := &io.LimitedReader{R: zr, N: MaxArchiveSize + 1}
+	a := &Archive{}
+	if err = json.NewDecoder(lr).Decode(a); err != nil {
+		if lr.N == 0 {
+			err = fmt.Errorf("%w: larger than %d bytes", ErrInvalidArchive, MaxArchiveSize)
+		}
+		return nil, fmt.Errorf("blob: reading archive: %w", err)
+	}
+	return a, nil
+}
+
+// CollectArchive collects the heights emitted by Export for the namespace over the given range of
+// heights into an Archive. It fails, if any height failed to be exported or is missing.
+func CollectArchive(nID namespace.ID, from, to uint64, heights <-chan *ArchiveHeight) (*Archive, error) {
+	archive := &Archive{
+		Version:   ArchiveVersion,
+		Namespace: nID,
+		From:      from,
+		To:        to,
+		Heights:   make([]*ArchiveHeight, 0),
+	}
+	next := from
+	for h := range heights {
+		if h.Error != "" {
+			return nil, fmt.Errorf("blob: exporting height %d: %s", h.Height, h.Error)
+		}
+		if h.Height != next {
+			return nil, fmt.Errorf("blob: height %d is exported instead of %d", h.Height, next)
+		}
+		next++
+		if len(h.Blobs) != 0 {
+			archive.Heights = append(archive.Heights, h)
+		}
+	}
+	if next != to+1 {
+		return nil, fmt.Errorf("blob: export ended at height %d instead of %d", next-1, to)
+	}
+	return archive, nil
+}
+
+// Export exports all the blobs of the namespace from the given height up to the given one
+// (inclusive) along with their proofs, emitting the heights one by one in order, so that they
+// are not held in memory at once. Heights without blobs of the namespace are emitted without
+// blobs. At most MaxArchiveHeights heights are exported at once. If a height fails to be exported,
+// it is emitted with the error and the channel is closed, as it is once all the heights are
+// emitted or the context is canceled. CollectArchive collects the heights into an Archive.
+func (s *Service) Export(ctx context.Context, nID namespace.ID, from, to uint64) (<-chan *ArchiveHeight, error) {
+	if len(nID) != share.NamespaceSize {
+		return nil, fmt.Errorf("blob: invalid namespace size %d", len(nID))
+	}
+	if from == 0 || from > to || to-from >= MaxArchiveHeights {
+		return nil, fmt.Errorf("blob: invalid height range [%d:%d], at most %d heights are exported",
+			from, to, MaxArchiveHeights)
+	}
+
+	heights := make(chan *ArchiveHeight)
+	go func() {
+		defer close(heights)
+		for height := from; height <= to; height++ {
+			h, err := s.exportHeight(ctx, height, nID)
+			if err != nil {
+				h = &ArchiveHeight{Height: height, Error: err.Error()}
+			}
+			select {
+			case heights <- h:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return heights, nil
+}
+
+// exportHeight returns the blobs of the namespace at the height with their proofs.
+func (s *Service) exportHeight(ctx context.Context, height uint64, nID namespace.ID) (*ArchiveHeight, error) {
+	header, err := s.headerGetter(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	h := &ArchiveHeight{
+		Height:     height,
+		HeaderHash: header.Hash(),
+		DataHash:   libhead.Hash(header.DAH.Hash()),
+	}
+
+	namespacedShares, err := s.shareGetter.GetSharesByNamespace(share.WithHeight(ctx, height), header.DAH, nID)
+	if errors.Is(err, share.ErrNamespaceNotFound) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	namespacedShares = dropAbsent(namespacedShares)
+	rawShares, idxs := namespacedShares.Flatten()
+	if len(rawShares) == 0 {
+		return h, nil
+	}
+
+	blobs, err := SharesToBlobs(rawShares)
+	if err != nil {
+		return nil, err
+	}
+	proofs := make([]*Proof, len(blobs))
+	for i, b := range blobs {
+		_, start, end, err := findBlob(rawShares, b.Commitment)
+		if err != nil {
+			return nil, err
+		}
+		proofs[i], err = newProof(header.DAH, nID, b.Commitment, namespacedShares, idxs, start, end)
+		if err != nil {
+			return nil, err
+		}
+	}
+	h.Blobs, h.Proofs = blobs, proofs
+	return h, nil
+}
+
+// Import verifies the Archive and checks that its heights match the headers of the chain known to
+// the node, proving that its blobs were published on the chain. It returns the blobs of the
+// Archive, once it is verified.
+func (s *Service) Import(ctx context.Context, archive *Archive) ([]*Blob, error) {
+	if err := archive.Verify(); err != nil {
+		return nil, err
+	}
+
+	for _, h := range archive.Heights {
+		header, err := s.headerGetter(ctx, h.Height)
+		if err != nil {
+			return nil, fmt.Errorf("blob: importing height %d: %w", h.Height, err)
+		}
+		if !bytes.Equal(header.Hash(), h.HeaderHash) || !bytes.Equal(header.DAH.Hash(), h.DataHash) {
+			return nil, fmt.Errorf("%w: height %d does not match the header of the chain", ErrInvalidArchive, h.Height)
+		}
+	}
+	return archive.Blobs(), nil
+}
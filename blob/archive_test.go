@@ -0,0 +1,101 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/blob/blobtest"
+)
+
+func TestService_ExportImport(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	appBlobs, err := blobtest.GenerateBlobs([]int{10, 1, 5}, true)
+	require.NoError(t, err)
+	blobs, err := convertBlobs(appBlobs...)
+	require.NoError(t, err)
+	service := createService(ctx, t, blobs)
+	nID := blobs[0].Namespace()
+
+	heights, err := service.Export(ctx, nID, 1, 1)
+	require.NoError(t, err)
+	archive, err := CollectArchive(nID, 1, 1, heights)
+	require.NoError(t, err)
+	require.Len(t, archive.Heights, 1)
+	require.NoError(t, archive.Verify())
+	assert.Len(t, archive.Blobs(), len(blobs))
+
+	var buf bytes.Buffer
+	err = WriteArchive(&buf, archive)
+	require.NoError(t, err)
+	read, err := ReadArchive(&buf)
+	require.NoError(t, err)
+
+	imported, err := service.Import(ctx, read)
+	require.NoError(t, err)
+	require.Len(t, imported, len(blobs))
+	// blobs are ordered within the square, not as submitted
+	data := make(map[string][]byte, len(blobs))
+	for _, b := range blobs {
+		data[b.Commitment.String()] = b.Data
+	}
+	for _, b := range imported {
+		assert.Equal(t, data[b.Commitment.String()], b.Data)
+	}
+
+	t.Run("tampered blob", func(t *testing.T) {
+		read.Heights[0].Blobs[0].Data[0] ^= 0xFF
+		_, err := service.Import(ctx, read)
+		assert.ErrorIs(t, err, ErrInvalidArchive)
+		read.Heights[0].Blobs[0].Data[0] ^= 0xFF
+	})
+
+	t.Run("other chain", func(t *testing.T) {
+		hash := read.Heights[0].HeaderHash
+		read.Heights[0].HeaderHash = make([]byte, len(hash))
+		_, err := service.Import(ctx, read)
+		assert.ErrorIs(t, err, ErrInvalidArchive)
+		read.Heights[0].HeaderHash = hash
+	})
+
+	t.Run("missing archive", func(t *testing.T) {
+		var nilArchive *Archive
+		assert.ErrorIs(t, nilArchive.Verify(), ErrInvalidArchive)
+		_, err := service.Import(ctx, nil)
+		assert.ErrorIs(t, err, ErrInvalidArchive)
+	})
+
+	t.Run("failed height", func(t *testing.T) {
+		heights := make(chan *ArchiveHeight, 2)
+		heights <- &ArchiveHeight{Height: 1}
+		heights <- &ArchiveHeight{Height: 2, Error: "failed"}
+		close(heights)
+		_, err := CollectArchive(nID, 1, 3, heights)
+		assert.Error(t, err)
+	})
+
+	t.Run("oversized archive", func(t *testing.T) {
+		maxSize := MaxArchiveSize
+		MaxArchiveSize = 16
+		t.Cleanup(func() {
+			MaxArchiveSize = maxSize
+		})
+		var buf bytes.Buffer
+		require.NoError(t, WriteArchive(&buf, archive))
+		_, err := ReadArchive(&buf)
+		assert.ErrorIs(t, err, ErrInvalidArchive)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := service.Export(ctx, nID, 2, 1)
+		assert.Error(t, err)
+		_, err = service.Export(ctx, nID, 1, MaxArchiveHeights+1)
+		assert.Error(t, err)
+	})
+}
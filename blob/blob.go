@@ -2,15 +2,22 @@ package blob
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 
 	appns "github.com/celestiaorg/celestia-app/pkg/namespace"
 	"github.com/celestiaorg/celestia-app/x/blob/types"
-	"github.com/celestiaorg/nmt"
 	"github.com/celestiaorg/nmt/namespace"
 
-	"github.com/celestiaorg/celestia-node/share/ipld"
+	"github.com/celestiaorg/celestia-node/libs/encoding"
+)
+
+var (
+	_ encoding.Legacy[*Blob]          = (*Blob)(nil)
+	_ encoding.Legacy[*Proof]         = (*Proof)(nil)
+	_ encoding.Legacy[*BlobEvent]     = (*BlobEvent)(nil)
+	_ encoding.Legacy[*ArchiveHeight] = (*ArchiveHeight)(nil)
 )
 
 // Commitment is a Merkle Root of the subtree built from shares of the Blob.
@@ -22,85 +29,34 @@ func (com Commitment) String() string {
 	return string(com)
 }
 
-// Equal ensures that commitments are the same
-func (com Commitment) Equal(c Commitment) bool {
-	return bytes.Equal(com, c)
+// MarshalJSON encodes Commitment as a 0x-prefixed hex string.
+func (com Commitment) MarshalJSON() ([]byte, error) {
+	return encoding.MarshalHex(com)
 }
 
-// Proof is a collection of nmt.Proofs that verifies the inclusion of the data.
-type Proof []*nmt.Proof
-
-func (p Proof) Len() int { return len(p) }
-
-// equal is a temporary method that compares two proofs.
-// should be removed in BlobService V1.
-func (p Proof) equal(input Proof) error {
-	if p.Len() != input.Len() {
-		return ErrInvalidProof
-	}
-
-	for i, proof := range p {
-		pNodes := proof.Nodes()
-		inputNodes := input[i].Nodes()
-		for i, node := range pNodes {
-			if !bytes.Equal(node, inputNodes[i]) {
-				return ErrInvalidProof
-			}
-		}
-
-		if proof.Start() != input[i].Start() || proof.End() != input[i].End() {
-			return ErrInvalidProof
-		}
-
-		if !bytes.Equal(proof.LeafHash(), input[i].LeafHash()) {
-			return ErrInvalidProof
-		}
-
-	}
-	return nil
-}
-
-type jsonProof struct {
-	Start int      `json:"start"`
-	End   int      `json:"end"`
-	Nodes [][]byte `json:"nodes"`
-}
-
-func (p *Proof) MarshalJSON() ([]byte, error) {
-	proofs := make([]jsonProof, 0, p.Len())
-	for _, pp := range *p {
-		proofs = append(proofs, jsonProof{
-			Start: pp.Start(),
-			End:   pp.End(),
-			Nodes: pp.Nodes(),
-		})
-	}
-
-	return json.Marshal(proofs)
-}
-
-func (p *Proof) UnmarshalJSON(data []byte) error {
-	var proofs []jsonProof
-	err := json.Unmarshal(data, &proofs)
+// UnmarshalJSON decodes Commitment from a 0x-prefixed hex string.
+func (com *Commitment) UnmarshalJSON(data []byte) error {
+	b, err := encoding.UnmarshalHex(data)
 	if err != nil {
 		return err
 	}
-
-	nmtProofs := make([]*nmt.Proof, len(proofs))
-	for i, jProof := range proofs {
-		nmtProof := nmt.NewInclusionProof(jProof.Start, jProof.End, jProof.Nodes, ipld.NMTIgnoreMaxNamespace)
-		nmtProofs[i] = &nmtProof
-	}
-
-	*p = nmtProofs
+	*com = b
 	return nil
 }
 
+// Equal ensures that commitments are the same
+func (com Commitment) Equal(c Commitment) bool {
+	return bytes.Equal(com, c)
+}
+
 // Blob represents any application-specific binary data that anyone can submit to Celestia.
 type Blob struct {
 	types.Blob `json:"blob"`
 
 	Commitment Commitment `json:"commitment"`
+
+	// legacy makes the Blob encode JSON in encoding.LegacyVersion
+	legacy bool
 }
 
 // NewBlob constructs a new blob from the provided namespace.ID and data.
@@ -131,16 +87,46 @@ func (b *Blob) Namespace() namespace.ID {
 	return append([]byte{uint8(b.NamespaceVersion)}, b.NamespaceId...)
 }
 
+// AuditFields returns the fields identifying the blob in the audit log of the node's API: its
+// namespace and commitment, or the hash of its data, if the commitment isn't computed yet.
+func (b *Blob) AuditFields() interface{} {
+	if b == nil {
+		return nil
+	}
+	fields := struct {
+		Namespace  encoding.Hex `json:"namespace"`
+		Commitment Commitment   `json:"commitment,omitempty"`
+		DataHash   encoding.Hex `json:"data_hash,omitempty"`
+	}{
+		Namespace:  encoding.Hex(b.Namespace()),
+		Commitment: b.Commitment,
+	}
+	if len(b.Commitment) == 0 {
+		sum := sha256.Sum256(b.Data)
+		fields.DataHash = sum[:]
+	}
+	return fields
+}
+
 type jsonBlob struct {
-	Namespace    namespace.ID `json:"namespace"`
-	Data         []byte       `json:"data"`
-	ShareVersion uint32       `json:"share_version"`
-	Commitment   Commitment   `json:"commitment"`
+	Namespace    encoding.Hex    `json:"namespace"`
+	Data         encoding.Base64 `json:"data"`
+	ShareVersion uint32          `json:"share_version"`
+	Commitment   Commitment      `json:"commitment"`
 }
 
 func (b *Blob) MarshalJSON() ([]byte, error) {
+	if b.legacy {
+		return json.Marshal(&legacyJSONBlob{
+			Namespace:    b.Namespace(),
+			Data:         b.Data,
+			ShareVersion: b.ShareVersion,
+			Commitment:   b.Commitment,
+		})
+	}
+
 	blob := &jsonBlob{
-		Namespace:    b.Namespace(),
+		Namespace:    encoding.Hex(b.Namespace()),
 		Data:         b.Data,
 		ShareVersion: b.ShareVersion,
 		Commitment:   b.Commitment,
@@ -154,11 +140,56 @@ func (b *Blob) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
+	return b.fromJSON(namespace.ID(blob.Namespace), blob.Data, blob.ShareVersion, blob.Commitment)
+}
+
+// legacyJSONBlob is jsonBlob in encoding.LegacyVersion, in which all the binary fields are base64.
+type legacyJSONBlob struct {
+	Namespace    []byte `json:"namespace"`
+	Data         []byte `json:"data"`
+	ShareVersion uint32 `json:"share_version"`
+	Commitment   []byte `json:"commitment"`
+}
+
+// Legacy returns a copy of the Blob encoding JSON in encoding.LegacyVersion.
+func (b *Blob) Legacy() *Blob {
+	if b == nil {
+		return nil
+	}
+	legacy := *b
+	legacy.legacy = true
+	return &legacy
+}
+
+func legacyBlobs(blobs []*Blob) []*Blob {
+	if blobs == nil {
+		return nil
+	}
+	legacy := make([]*Blob, len(blobs))
+	for i, b := range blobs {
+		legacy[i] = b.Legacy()
+	}
+	return legacy
+}
+
+// UnmarshalLegacyJSON decodes Blob from JSON in encoding.LegacyVersion.
+func (b *Blob) UnmarshalLegacyJSON(data []byte) error {
+	var blob legacyJSONBlob
+	err := json.Unmarshal(data, &blob)
+	if err != nil {
+		return err
+	}
+	return b.fromJSON(blob.Namespace, blob.Data, blob.ShareVersion, blob.Commitment)
+}
 
-	b.Blob.NamespaceVersion = uint32(blob.Namespace[0])
-	b.Blob.NamespaceId = blob.Namespace[1:]
-	b.Blob.Data = blob.Data
-	b.Blob.ShareVersion = blob.ShareVersion
-	b.Commitment = blob.Commitment
+func (b *Blob) fromJSON(nID namespace.ID, data []byte, shareVersion uint32, com Commitment) error {
+	if len(nID) == 0 {
+		return fmt.Errorf("blob: namespace is missing")
+	}
+	b.Blob.NamespaceVersion = uint32(nID[0])
+	b.Blob.NamespaceId = nID[1:]
+	b.Blob.Data = data
+	b.Blob.ShareVersion = shareVersion
+	b.Commitment = com
 	return nil
 }
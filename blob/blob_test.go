@@ -1,6 +1,7 @@
 package blob
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -74,6 +75,40 @@ func TestBlob(t *testing.T) {
 				require.True(t, reflect.DeepEqual(blob[0], newBlob))
 			},
 		},
+		{
+			name: "legacy blob unmarshaling",
+			expectedRes: func(t *testing.T) {
+				// before encoding versioning, all the binary fields were encoded as base64
+				data, err := json.Marshal(map[string]interface{}{
+					"namespace":     []byte(blob[0].Namespace()),
+					"data":          blob[0].Data,
+					"share_version": blob[0].ShareVersion,
+					"commitment":    []byte(blob[0].Commitment),
+				})
+				require.NoError(t, err)
+
+				newBlob := &Blob{}
+				require.NoError(t, newBlob.UnmarshalLegacyJSON(data))
+				require.True(t, reflect.DeepEqual(blob[0], newBlob))
+				// the current encoding doesn't accept base64 namespaces and commitments
+				require.Error(t, (&Blob{}).UnmarshalJSON(data))
+			},
+		},
+		{
+			name: "legacy blob marshaling",
+			expectedRes: func(t *testing.T) {
+				data, err := json.Marshal(blob[0].Legacy())
+				require.NoError(t, err)
+
+				newBlob := &Blob{}
+				require.NoError(t, newBlob.UnmarshalLegacyJSON(data))
+				require.True(t, reflect.DeepEqual(blob[0], newBlob))
+				// the Blob itself is still encoded in the current version
+				data, err = json.Marshal(blob[0])
+				require.NoError(t, err)
+				require.NoError(t, (&Blob{}).UnmarshalJSON(data))
+			},
+		},
 	}
 
 	for _, tt := range test {
@@ -0,0 +1,127 @@
+package blob
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+)
+
+// FeeEstimate is the suggested gas and fee of a PFB transaction.
+type FeeEstimate struct {
+	// GasLimit is the estimated gas of the transaction, multiplied by the configured gas multiplier.
+	GasLimit uint64 `json:"gas_limit"`
+	// GasPrice is the minimum gas price of the node, multiplied by the configured gas price
+	// multiplier.
+	GasPrice float64 `json:"gas_price"`
+	// Fee is the amount of utia paid for the transaction, i.e. GasLimit times GasPrice.
+	Fee math.Int `json:"fee"`
+}
+
+// WithGasMultiplier makes the Service multiply estimated gas by the given factor, to leave headroom
+// for changes of gas consumption.
+func WithGasMultiplier(multiplier float64) Option {
+	return func(s *Service) {
+		s.gasMultiplier = multiplier
+	}
+}
+
+// gasPerBlobByteTTL is how long the gas per blob byte queried from the network is reused for.
+var gasPerBlobByteTTL = time.Hour
+
+// feeParams caches the params fees are estimated from, so that submissions don't query them each.
+type feeParams struct {
+	lk sync.Mutex
+	// minGasPrice is the minimum gas price of the node, which doesn't change while it runs, if
+	// minGasPriceSet.
+	minGasPrice    float64
+	minGasPriceSet bool
+	// gasPerBlobByte is the gas per blob byte of the network, queried at gasPerBlobByteQueried.
+	gasPerBlobByte        uint32
+	gasPerBlobByteQueried time.Time
+}
+
+// WithGasPriceMultiplier makes the Service multiply the minimum gas price of the node by the given
+// factor, to get transactions included faster when the network is congested.
+func WithGasPriceMultiplier(multiplier float64) Option {
+	return func(s *Service) {
+		s.gasPriceMultiplier = multiplier
+	}
+}
+
+// EstimateGas estimates the gas required to submit the blobs in a single PFB transaction. It
+// accounts for the shares the blobs occupy and the gas currently charged per blob byte.
+func (s *Service) EstimateGas(ctx context.Context, blobs []*Blob) (uint64, error) {
+	if err := validateBlobs(blobs); err != nil {
+		return 0, err
+	}
+	return s.estimateGas(ctx, blobs), nil
+}
+
+// EstimateFee suggests the gas limit, gas price and fee to submit the blobs in a single PFB
+// transaction.
+func (s *Service) EstimateFee(ctx context.Context, blobs []*Blob) (*FeeEstimate, error) {
+	if err := validateBlobs(blobs); err != nil {
+		return nil, err
+	}
+	gasLimit, gasPrice := s.estimateGas(ctx, blobs), s.gasPrice(ctx)
+	return &FeeEstimate{
+		GasLimit: gasLimit,
+		GasPrice: gasPrice,
+		Fee:      calculateFee(gasLimit, gasPrice),
+	}, nil
+}
+
+func (s *Service) estimateGas(ctx context.Context, blobs []*Blob) uint64 {
+	return uint64(float64(estimateGas(s.gasPerBlobByte(ctx), blobs...)) * s.gasMultiplier)
+}
+
+// gasPerBlobByte returns the gas per blob byte of the network, querying it once per
+// gasPerBlobByteTTL. Failed queries fall back to the default and are retried by the next call.
+func (s *Service) gasPerBlobByte(ctx context.Context) uint32 {
+	s.fees.lk.Lock()
+	defer s.fees.lk.Unlock()
+	if !s.fees.gasPerBlobByteQueried.IsZero() && time.Since(s.fees.gasPerBlobByteQueried) < gasPerBlobByteTTL {
+		return s.fees.gasPerBlobByte
+	}
+
+	gasPerByte, err := s.blobSumitter.GasPerBlobByte(ctx)
+	if err != nil {
+		log.Warnw("falling back to the default gas per blob byte", "err", err)
+		return appconsts.DefaultGasPerBlobByte
+	}
+	s.fees.gasPerBlobByte, s.fees.gasPerBlobByteQueried = gasPerByte, time.Now()
+	return gasPerByte
+}
+
+// gasPrice returns the minimum gas price of the node multiplied by the gas price multiplier. The
+// minimum gas price is queried once. Failed queries fall back to the default and are retried by the
+// next call.
+func (s *Service) gasPrice(ctx context.Context) float64 {
+	s.fees.lk.Lock()
+	defer s.fees.lk.Unlock()
+	if !s.fees.minGasPriceSet {
+		minGasPrice, err := s.blobSumitter.MinGasPrice(ctx)
+		if err != nil {
+			log.Warnw("falling back to the default minimum gas price", "err", err)
+			return appconsts.DefaultMinGasPrice * s.gasPriceMultiplier
+		}
+		s.fees.minGasPrice, s.fees.minGasPriceSet = minGasPrice, true
+	}
+	return s.fees.minGasPrice * s.gasPriceMultiplier
+}
+
+// calculateFee returns the fee paying the given gas at the given price, rounded up, so that the
+// price is never below the requested one.
+func calculateFee(gasLimit uint64, gasPrice float64) math.Int {
+	exact := gasPrice * float64(gasLimit)
+	fee := int64(exact)
+	if float64(fee) < exact {
+		fee++
+	}
+	return types.NewInt(fee)
+}
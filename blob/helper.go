@@ -2,12 +2,15 @@ package blob
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"sort"
 
 	"github.com/tendermint/tendermint/types"
 
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	"github.com/celestiaorg/celestia-app/pkg/shares"
+	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
 
 	"github.com/celestiaorg/celestia-node/share"
 )
@@ -86,24 +89,51 @@ const (
 	pfbGasFixedCost     = 80000
 )
 
-// estimateGas estimates the gas required to pay for a set of blobs in a PFB.
-func estimateGas(blobs ...*Blob) uint64 {
-	totalByteCount := 0
+// estimateGas estimates the gas required to pay for a set of blobs in a PFB, given the gas charged
+// per blob byte. Blobs are charged for the whole shares they occupy, so that many small blobs in a
+// single PFB are not underestimated.
+func estimateGas(gasPerByte uint32, blobs ...*Blob) uint64 {
+	variableGasAmount := 0
 	for _, blob := range blobs {
-		totalByteCount += len(blob.Data) + appconsts.NamespaceSize
+		sharesBytes := shares.SparseSharesNeeded(uint32(len(blob.Data))) * appconsts.ShareSize
+		variableGasAmount += int(gasPerByte)*sharesBytes +
+			perByteGasTolerance*(len(blob.Data)+appconsts.NamespaceSize)
 	}
-	variableGasAmount := (appconsts.DefaultGasPerBlobByte + perByteGasTolerance) * totalByteCount
 
 	return uint64(variableGasAmount + pfbGasFixedCost)
 }
 
-// constructAndVerifyBlob reconstruct a Blob from the passed shares and compares commitments.
-func constructAndVerifyBlob(sh []share.Share, commitment Commitment) (*Blob, bool, error) {
-	blob, err := SharesToBlobs(sh)
-	if err != nil {
-		return nil, false, err
+// validateBlobs checks that there are blobs to be submitted and that they are valid.
+func validateBlobs(blobs []*Blob) error {
+	if len(blobs) == 0 {
+		return errors.New("blob: no blobs provided")
+	}
+	for i, blob := range blobs {
+		if err := apptypes.ValidateBlobs(&blob.Blob); err != nil {
+			return fmt.Errorf("blob: invalid blob %d: %w", i, err)
+		}
 	}
+	return nil
+}
 
-	equal := blob[0].Commitment.Equal(commitment)
-	return blob[0], equal, nil
+// prepareBlobs validates the blobs to be submitted, computing their commitments if missing and
+// verifying them otherwise.
+func prepareBlobs(blobs []*Blob) error {
+	if err := validateBlobs(blobs); err != nil {
+		return err
+	}
+	for i, blob := range blobs {
+		com, err := apptypes.CreateCommitment(&blob.Blob)
+		if err != nil {
+			return fmt.Errorf("blob: computing commitment of blob %d: %w", i, err)
+		}
+		if len(blob.Commitment) == 0 {
+			blob.Commitment = com
+			continue
+		}
+		if !blob.Commitment.Equal(com) {
+			return fmt.Errorf("blob: commitment of blob %d does not match its data", i)
+		}
+	}
+	return nil
 }
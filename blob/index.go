@@ -0,0 +1,239 @@
+package blob
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/celestiaorg/celestia-app/pkg/shares"
+	nmtns "github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var (
+	// indexPrefix is the prefix of keys of the Index.
+	indexPrefix = datastore.NewKey("blob_index")
+	// scannedHeightKey holds the height stored squares are scanned up to.
+	scannedHeightKey = datastore.NewKey("scanned")
+	// heightsKey is the prefix of keys marking indexed heights.
+	heightsKey = datastore.NewKey("heights")
+	// entriesKey is the prefix of keys of index entries.
+	entriesKey = datastore.NewKey("entries")
+)
+
+// IndexEntry references a blob indexed at a height. Blobs are not copied into the Index, but read
+// from the stored square by the referenced shares.
+type IndexEntry struct {
+	Height     uint64     `json:"height"`
+	Commitment Commitment `json:"commitment"`
+	// Start and End are the indexes of the first share of the blob and the share after its last one
+	// among the shares of its namespace within the original data square.
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Index is a local index of blobs stored by the node, keyed by height and namespace, so that
+// historical blobs are found without parsing their data squares again.
+//
+// Heights are scanned in order, but only those with stored squares are indexed. An indexed height
+// without entries for a namespace is known to have no blobs of the namespace.
+type Index struct {
+	ds datastore.Batching
+	// scanned is the height stored squares are scanned up to
+	scanned atomic.Uint64
+}
+
+// NewIndex creates a new Index on top of the given datastore.
+func NewIndex(ds datastore.Batching) *Index {
+	return &Index{ds: namespace.Wrap(ds, indexPrefix)}
+}
+
+func (idx *Index) Start(ctx context.Context) error {
+	b, err := idx.ds.Get(ctx, scannedHeightKey)
+	switch {
+	case errors.Is(err, datastore.ErrNotFound):
+		return nil
+	case err != nil:
+		return fmt.Errorf("blob/index: loading scanned height: %w", err)
+	default:
+		idx.scanned.Store(binary.BigEndian.Uint64(b))
+		return nil
+	}
+}
+
+// Scanned returns the height stored squares are scanned up to.
+func (idx *Index) Scanned() uint64 {
+	return idx.scanned.Load()
+}
+
+// Put indexes the blobs of the square at a height after the scanned one.
+func (idx *Index) Put(ctx context.Context, height uint64, eds *rsmt2d.ExtendedDataSquare) error {
+	entries, err := extractEntries(height, eds)
+	if err != nil {
+		return fmt.Errorf("blob/index: extracting blobs at height %d: %w", height, err)
+	}
+
+	batch, err := idx.ds.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	for ns, nsEntries := range entries {
+		for _, entry := range nsEntries {
+			data, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			if err = batch.Put(ctx, entryKey(height, nmtns.ID(ns), entry.Start), data); err != nil {
+				return err
+			}
+		}
+	}
+	// the height is marked indexed with its entries, so that they are never partially indexed
+	if err = batch.Put(ctx, heightKey(height), []byte{}); err != nil {
+		return err
+	}
+	return idx.commitScanned(ctx, batch, height)
+}
+
+// Skip marks the height after the scanned one as scanned without indexing it, e.g. as its square is
+// not stored.
+func (idx *Index) Skip(ctx context.Context, height uint64) error {
+	batch, err := idx.ds.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	return idx.commitScanned(ctx, batch, height)
+}
+
+func (idx *Index) commitScanned(ctx context.Context, batch datastore.Batch, height uint64) error {
+	if scanned := idx.scanned.Load(); height <= scanned {
+		return fmt.Errorf("blob/index: height %d is already scanned up to %d", height, scanned)
+	}
+
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, height)
+	if err := batch.Put(ctx, scannedHeightKey, b); err != nil {
+		return err
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return err
+	}
+	idx.scanned.Store(height)
+	return nil
+}
+
+// Get returns the entries of the namespace at the height in the order of the square. It reports
+// whether the height is indexed, as entries of heights not indexed are unknown.
+func (idx *Index) Get(ctx context.Context, height uint64, nID nmtns.ID) ([]*IndexEntry, bool, error) {
+	if height == 0 || height > idx.scanned.Load() {
+		return nil, false, nil
+	}
+	indexed, err := idx.ds.Has(ctx, heightKey(height))
+	if err != nil || !indexed {
+		return nil, false, err
+	}
+
+	results, err := idx.ds.Query(ctx, query.Query{
+		Prefix: namespaceKey(height, nID).String(),
+		Orders: []query.Order{query.OrderByKey{}},
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	defer results.Close()
+
+	entries := make([]*IndexEntry, 0)
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, false, result.Error
+		}
+		entry := new(IndexEntry)
+		if err = json.Unmarshal(result.Value, entry); err != nil {
+			return nil, false, fmt.Errorf("blob/index: decoding entry %s: %w", result.Key, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, true, nil
+}
+
+// extractEntries parses the blobs out of the original data square and returns their entries by
+// namespace.
+func extractEntries(height uint64, eds *rsmt2d.ExtendedDataSquare) (map[string][]*IndexEntry, error) {
+	ods := share.ExtractODS(eds)
+	entries := make(map[string][]*IndexEntry)
+	// offsets counts the shares of every namespace seen, so that blobs are referenced by their
+	// position among the shares of their namespace
+	offsets := make(map[string]int)
+	for i := 0; i < len(ods); {
+		sh, err := shares.NewShare(ods[i])
+		if err != nil {
+			return nil, err
+		}
+		ns, err := sh.Namespace()
+		if err != nil {
+			return nil, err
+		}
+		if ns.IsReserved() || ns.IsTailPadding() || ns.IsParityShares() {
+			i++
+			continue
+		}
+		key := string(ns.Bytes())
+		// namespace padding shares are not the part of any blob
+		isPadding, err := sh.IsPadding()
+		if err != nil {
+			return nil, err
+		}
+		if isPadding {
+			offsets[key]++
+			i++
+			continue
+		}
+
+		length, err := sh.SequenceLen()
+		if err != nil {
+			return nil, err
+		}
+		end := i + shares.SparseSharesNeeded(length)
+		if end > len(ods) {
+			return nil, fmt.Errorf("blob at share %d exceeds the square", i)
+		}
+		blobs, err := SharesToBlobs(ods[i:end])
+		if err != nil {
+			return nil, err
+		}
+		start := offsets[key]
+		offsets[key] += end - i
+		entries[key] = append(entries[key], &IndexEntry{
+			Height:     height,
+			Commitment: blobs[0].Commitment,
+			Start:      start,
+			End:        offsets[key],
+		})
+		i = end
+	}
+	return entries, nil
+}
+
+func namespaceKey(height uint64, nID nmtns.ID) datastore.Key {
+	return entriesKey.ChildString(strconv.FormatUint(height, 10)).ChildString(hex.EncodeToString(nID))
+}
+
+func heightKey(height uint64) datastore.Key {
+	return heightsKey.ChildString(strconv.FormatUint(height, 10))
+}
+
+func entryKey(height uint64, nID nmtns.ID, start int) datastore.Key {
+	// the start is padded, so that entries are ordered as in the square
+	return namespaceKey(height, nID).ChildString(fmt.Sprintf("%010d", start))
+}
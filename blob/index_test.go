@@ -0,0 +1,140 @@
+package blob
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	mdutils "github.com/ipfs/go-merkledag/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/pkg/shares"
+	"github.com/celestiaorg/go-header/store"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/blob/blobtest"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/getters"
+)
+
+func TestIndex(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	appBlobs, err := blobtest.GenerateBlobs([]int{9, 5, 1}, false)
+	require.NoError(t, err)
+	blobs, err := convertBlobs(appBlobs...)
+	require.NoError(t, err)
+	rawShares, err := BlobsToShares(blobs...)
+	require.NoError(t, err)
+	// the square is completed with tail padding, which is not indexed
+	for size := 1; ; size *= 2 {
+		if size*size >= len(rawShares) {
+			rawShares = append(rawShares, shares.ToBytes(shares.TailPaddingShares(size*size-len(rawShares)))...)
+			break
+		}
+	}
+
+	bs := mdutils.Bserv()
+	eds, err := share.AddShares(ctx, rawShares, bs)
+	require.NoError(t, err)
+	headerStore, err := store.NewStore[*header.ExtendedHeader](ds_sync.MutexWrap(ds.NewMapDatastore()))
+	require.NoError(t, err)
+	err = headerStore.Init(ctx, headertest.ExtendedHeaderFromEDS(t, 1, eds))
+	require.NoError(t, err)
+
+	batching := ds_sync.MutexWrap(ds.NewMapDatastore())
+	index := NewIndex(batching)
+	require.NoError(t, index.Start(ctx))
+	storedHead := func(context.Context) (uint64, error) { return 1, nil }
+	// heights before the first stored one are not scanned
+	indexer := NewIndexer(index, getters.NewIPLDGetter(bs), headerStore.GetByHeight, storedHead, 2, time.Hour)
+	require.NoError(t, indexer.indexStored(ctx))
+	assert.EqualValues(t, 0, index.Scanned())
+
+	indexer = NewIndexer(index, getters.NewIPLDGetter(bs), headerStore.GetByHeight, storedHead, 1, time.Hour)
+	require.NoError(t, indexer.indexStored(ctx))
+	assert.EqualValues(t, 1, index.Scanned())
+
+	for _, b := range blobs {
+		entries, indexed, err := index.Get(ctx, 1, b.Namespace())
+		require.NoError(t, err)
+		require.True(t, indexed)
+		require.Len(t, entries, 1)
+		assert.Equal(t, b.Commitment, entries[0].Commitment)
+		assert.Equal(t, 0, entries[0].Start)
+		assert.Equal(t, shares.SparseSharesNeeded(uint32(len(b.Data))), entries[0].End)
+	}
+	_, indexed, err := index.Get(ctx, 2, blobs[0].Namespace())
+	require.NoError(t, err)
+	assert.False(t, indexed)
+
+	// indexed heights are answered by reading only the referenced shares
+	service := NewService(nil, getters.NewIPLDGetter(bs), headerStore.GetByHeight, WithIndex(index))
+	got, err := service.Get(ctx, 1, blobs[1].Namespace(), blobs[1].Commitment)
+	require.NoError(t, err)
+	assert.Equal(t, blobs[1].Data, got.Data)
+
+	all, err := service.GetAll(ctx, 1, []namespace.ID{blobs[0].Namespace(), blobs[2].Namespace()})
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	otherBlobs, err := blobtest.GenerateBlobs([]int{1}, false)
+	require.NoError(t, err)
+	other, err := convertBlobs(otherBlobs...)
+	require.NoError(t, err)
+	_, err = service.GetAll(ctx, 1, []namespace.ID{other[0].Namespace()})
+	require.ErrorIs(t, err, ErrBlobNotFound)
+
+	chunks, err := service.GetStream(ctx, 1, blobs[0].Namespace(), blobs[0].Commitment)
+	require.NoError(t, err)
+	data := make([]byte, 0)
+	for chunk := range chunks {
+		data = append(data, chunk...)
+	}
+	assert.Equal(t, blobs[0].Data, data)
+
+	// the scanned height survives restarts
+	index = NewIndex(batching)
+	require.NoError(t, index.Start(ctx))
+	assert.EqualValues(t, 1, index.Scanned())
+	_, indexed, err = index.Get(ctx, 1, blobs[0].Namespace())
+	require.NoError(t, err)
+	assert.True(t, indexed)
+}
+
+func TestIndexer_SkipsNotStored(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	headerStore, err := store.NewStore[*header.ExtendedHeader](ds_sync.MutexWrap(ds.NewMapDatastore()))
+	require.NoError(t, err)
+	head := headertest.ExtendedHeaderFromEDS(t, 1, share.RandEDS(t, 4))
+	require.NoError(t, headerStore.Init(ctx, head))
+
+	index := NewIndex(ds_sync.MutexWrap(ds.NewMapDatastore()))
+	require.NoError(t, index.Start(ctx))
+	storedHead := func(context.Context) (uint64, error) { return uint64(head.Height()), nil }
+	indexer := NewIndexer(index, notStoredGetter{}, headerStore.GetByHeight, storedHead, uint64(head.Height()), time.Hour)
+	require.NoError(t, indexer.indexStored(ctx))
+
+	// the height is scanned, but not indexed, so that it is answered by retrieving shares
+	assert.EqualValues(t, head.Height(), index.Scanned())
+	_, indexed, err := index.Get(ctx, uint64(head.Height()), namespace.ID("namespace"))
+	require.NoError(t, err)
+	assert.False(t, indexed)
+}
+
+type notStoredGetter struct {
+	share.Getter
+}
+
+func (notStoredGetter) GetEDS(context.Context, *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
+	return nil, share.ErrNotFound
+}
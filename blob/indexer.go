@@ -0,0 +1,125 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// indexTimeout bounds a single indexing round.
+const indexTimeout = time.Minute
+
+// Indexer indexes blobs of the squares stored by the node in the background. Heights are scanned in
+// order, from the first height squares are stored from up to the height squares are known to be
+// stored up to. Heights without stored squares, e.g. as they failed to be retrieved, are skipped.
+type Indexer struct {
+	index *Index
+	// getter must only retrieve locally stored squares.
+	getter       share.Getter
+	headerGetter func(context.Context, uint64) (*header.ExtendedHeader, error)
+	// storedHead returns the height squares are stored up to.
+	storedHead func(context.Context) (uint64, error)
+	// from is the first height squares are stored from.
+	from uint64
+
+	interval time.Duration
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewIndexer creates a new Indexer indexing blobs of squares stored from the given height every
+// interval.
+func NewIndexer(
+	index *Index,
+	getter share.Getter,
+	headerGetter func(context.Context, uint64) (*header.ExtendedHeader, error),
+	storedHead func(context.Context) (uint64, error),
+	from uint64,
+	interval time.Duration,
+) *Indexer {
+	return &Indexer{
+		index:        index,
+		getter:       getter,
+		headerGetter: headerGetter,
+		storedHead:   storedHead,
+		from:         from,
+		interval:     interval,
+		done:         make(chan struct{}),
+	}
+}
+
+func (i *Indexer) Start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	i.cancel = cancel
+	go i.run(ctx)
+	return nil
+}
+
+func (i *Indexer) Stop(ctx context.Context) error {
+	i.cancel()
+	select {
+	case <-i.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (i *Indexer) run(ctx context.Context) {
+	defer close(i.done)
+
+	ticker := time.NewTicker(i.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			indexCtx, cancel := context.WithTimeout(ctx, indexTimeout)
+			err := i.indexStored(indexCtx)
+			cancel()
+			if err != nil && ctx.Err() == nil && !errors.Is(err, context.DeadlineExceeded) {
+				log.Errorw("indexing blobs", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// indexStored indexes heights after the scanned one up to the stored head.
+func (i *Indexer) indexStored(ctx context.Context) error {
+	head, err := i.storedHead(ctx)
+	if err != nil {
+		return fmt.Errorf("getting stored head: %w", err)
+	}
+
+	height := i.index.Scanned() + 1
+	if height < i.from {
+		height = i.from
+	}
+	for ; height <= head; height++ {
+		h, err := i.headerGetter(ctx, height)
+		if err != nil {
+			return fmt.Errorf("getting header at height %d: %w", height, err)
+		}
+		eds, err := i.getter.GetEDS(share.WithHeight(ctx, height), h.DAH)
+		if errors.Is(err, share.ErrNotFound) {
+			log.Debugw("square is not stored, skipping", "height", height)
+			if err = i.index.Skip(ctx, height); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("getting square at height %d: %w", height, err)
+		}
+		if err = i.index.Put(ctx, height, eds); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package blob
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/pkg/shares"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/libs/encoding"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+// Proof proves the inclusion of a Blob in a block. It is self-contained, so that it can be
+// serialized and verified later against the data root of the block with VerifyProof, without
+// access to a node.
+type Proof struct {
+	// Namespace and Commitment of the proven Blob.
+	Namespace  namespace.ID `json:"namespace"`
+	Commitment Commitment   `json:"commitment"`
+	// Rows are the rows of the data square the Blob spans.
+	Rows []*ProofRow `json:"rows"`
+	// Start and End are the indexes of the first share of the Blob and the share after its last one
+	// within the shares of the Rows.
+	Start int `json:"start"`
+	End   int `json:"end"`
+	// DAH is the DataAvailabilityHeader of the block, which commits to the roots of the Rows.
+	DAH *share.Root `json:"dah"`
+
+	// legacy makes the Proof encode JSON in encoding.LegacyVersion
+	legacy bool
+}
+
+// ProofRow is a row of the data square spanned by a proven Blob. It holds all the shares of the
+// Blob's namespace in the row, so that their NMT proof can be verified against the row root.
+type ProofRow struct {
+	// Index is the index of the row in the data square.
+	Index  int           `json:"index"`
+	Root   encoding.Hex  `json:"root"`
+	Shares []share.Share `json:"shares"`
+	Proof  *nmt.Proof    `json:"proof"`
+
+	// legacy makes the ProofRow encode JSON in encoding.LegacyVersion
+	legacy bool
+}
+
+// VerifyProof verifies the Proof against the data root of the block the Blob is included in. The
+// Blob is proven to be included, if the Proof is valid.
+func VerifyProof(proof *Proof, dataRoot []byte) error {
+	if proof == nil || proof.DAH == nil || len(proof.Rows) == 0 {
+		return fmt.Errorf("%w: incomplete proof", ErrInvalidProof)
+	}
+	if !bytes.Equal(proof.DAH.Hash(), dataRoot) {
+		return fmt.Errorf("%w: DAH does not match the data root", ErrInvalidProof)
+	}
+
+	blobShares := make([]share.Share, 0)
+	for i, row := range proof.Rows {
+		// the blob spans consecutive rows of the original data square
+		if row.Index != proof.Rows[0].Index+i || row.Index < 0 || row.Index >= len(proof.DAH.RowRoots)/2 {
+			return fmt.Errorf("%w: invalid index %d of row %d", ErrInvalidProof, row.Index, i)
+		}
+		if !bytes.Equal(row.Root, proof.DAH.RowRoots[row.Index]) {
+			return fmt.Errorf("%w: root of row %d does not match the DAH", ErrInvalidProof, row.Index)
+		}
+		if row.Proof == nil {
+			return fmt.Errorf("%w: missing NMT proof of row %d", ErrInvalidProof, row.Index)
+		}
+
+		leaves := make([][]byte, 0, len(row.Shares))
+		for _, sh := range row.Shares {
+			if len(sh) != share.Size {
+				return fmt.Errorf("%w: invalid share size in row %d", ErrInvalidProof, row.Index)
+			}
+			leaves = append(leaves, append(sh[:share.NamespaceSize:share.NamespaceSize], sh...))
+		}
+		if !row.Proof.VerifyNamespace(sha256.New(), proof.Namespace, leaves, row.Root) {
+			return fmt.Errorf("%w: shares of row %d are not proven by the NMT proof", ErrInvalidProof, row.Index)
+		}
+		blobShares = append(blobShares, row.Shares...)
+	}
+
+	if proof.Start < 0 || proof.Start >= proof.End || proof.End > len(blobShares) {
+		return fmt.Errorf("%w: invalid shares range [%d:%d)", ErrInvalidProof, proof.Start, proof.End)
+	}
+	blobShares = blobShares[proof.Start:proof.End]
+	sh, err := shares.NewShare(blobShares[0])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	length, err := sh.SequenceLen()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	if shares.SparseSharesNeeded(length) != len(blobShares) {
+		return fmt.Errorf("%w: shares range does not match the blob length", ErrInvalidProof)
+	}
+	blob, err := parseBlob(blobShares)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	if !blob.Commitment.Equal(proof.Commitment) {
+		return fmt.Errorf("%w: shares do not match the commitment", ErrInvalidProof)
+	}
+	return nil
+}
+
+// newProof creates the Proof of the Blob occupying the given range of the flattened namespaced
+// shares, which are located in their rows by the given indices returned by Flatten.
+func newProof(
+	root *share.Root,
+	nID namespace.ID,
+	commitment Commitment,
+	namespacedShares share.NamespacedShares,
+	idxs []share.Index,
+	start, end int,
+) (*Proof, error) {
+	if start < 0 || start >= end || end > len(idxs) {
+		return nil, errors.New("blob: shares range is out of the namespace")
+	}
+
+	// namespaced shares are retrieved from the rows, which may contain the namespace, in order
+	rowIdxs := make([]int, 0, len(namespacedShares))
+	for i, row := range root.RowRoots[:len(root.RowRoots)/2] {
+		if !ipld.NamespaceIsOutsideRange(row, row, nID) {
+			rowIdxs = append(rowIdxs, i)
+		}
+	}
+	if len(rowIdxs) != len(namespacedShares) {
+		return nil, fmt.Errorf("amount of rows differs between root and namespace shares: expected %d, got %d",
+			len(rowIdxs), len(namespacedShares))
+	}
+
+	first, last := idxs[start], idxs[end-1]
+	// the range starts at the position of its first share within the shares of its first row
+	rowStart := 0
+	if proof := namespacedShares[first.Row].Proof; proof != nil {
+		rowStart = proof.Start()
+	}
+	proof := &Proof{
+		Namespace:  nID,
+		Commitment: commitment,
+		DAH:        root,
+		Start:      first.Col - rowStart,
+	}
+	proof.End = proof.Start + end - start
+	for i := first.Row; i <= last.Row; i++ {
+		row := namespacedShares[i]
+		proof.Rows = append(proof.Rows, &ProofRow{
+			Index:  rowIdxs[i],
+			Root:   root.RowRoots[rowIdxs[i]],
+			Shares: row.Shares,
+			Proof:  row.Proof,
+		})
+	}
+	return proof, nil
+}
+
+type jsonProofRow struct {
+	Index  int               `json:"index"`
+	Root   encoding.Hex      `json:"root"`
+	Shares []encoding.Base64 `json:"shares"`
+	Proof  jsonProof         `json:"proof"`
+}
+
+type jsonProof struct {
+	Start int            `json:"start"`
+	End   int            `json:"end"`
+	Nodes []encoding.Hex `json:"nodes"`
+}
+
+// Legacy returns a copy of the Proof encoding JSON in encoding.LegacyVersion.
+func (p *Proof) Legacy() *Proof {
+	if p == nil {
+		return nil
+	}
+	legacy := *p
+	legacy.legacy = true
+	legacy.Rows = make([]*ProofRow, len(p.Rows))
+	for i, row := range p.Rows {
+		if row != nil {
+			legacyRow := *row
+			legacyRow.legacy = true
+			legacy.Rows[i] = &legacyRow
+		}
+	}
+	return &legacy
+}
+
+func (p *Proof) MarshalJSON() ([]byte, error) {
+	// proof drops the methods of Proof, so that it is encoded by encoding/json
+	type proof Proof
+	if !p.legacy {
+		return json.Marshal((*proof)(p))
+	}
+	return json.Marshal(&struct {
+		*proof
+		Commitment []byte `json:"commitment"`
+	}{proof: (*proof)(p), Commitment: p.Commitment})
+}
+
+// legacyJSONProofRow is jsonProofRow in encoding.LegacyVersion, in which all the binary fields are
+// base64.
+type legacyJSONProofRow struct {
+	Index  int           `json:"index"`
+	Root   []byte        `json:"root"`
+	Shares []share.Share `json:"shares"`
+	Proof  struct {
+		Start int      `json:"start"`
+		End   int      `json:"end"`
+		Nodes [][]byte `json:"nodes"`
+	} `json:"proof"`
+}
+
+func (row *ProofRow) MarshalJSON() ([]byte, error) {
+	if row.legacy {
+		jsonRow := legacyJSONProofRow{
+			Index:  row.Index,
+			Root:   row.Root,
+			Shares: row.Shares,
+		}
+		if row.Proof != nil {
+			jsonRow.Proof.Start, jsonRow.Proof.End = row.Proof.Start(), row.Proof.End()
+			jsonRow.Proof.Nodes = row.Proof.Nodes()
+		}
+		return json.Marshal(jsonRow)
+	}
+
+	shares := make([]encoding.Base64, len(row.Shares))
+	for i, sh := range row.Shares {
+		shares[i] = sh
+	}
+	jsonRow := jsonProofRow{
+		Index:  row.Index,
+		Root:   row.Root,
+		Shares: shares,
+	}
+	if row.Proof != nil {
+		nodes := make([]encoding.Hex, len(row.Proof.Nodes()))
+		for i, node := range row.Proof.Nodes() {
+			nodes[i] = node
+		}
+		jsonRow.Proof = jsonProof{
+			Start: row.Proof.Start(),
+			End:   row.Proof.End(),
+			Nodes: nodes,
+		}
+	}
+	return json.Marshal(jsonRow)
+}
+
+func (row *ProofRow) UnmarshalJSON(data []byte) error {
+	var jsonRow jsonProofRow
+	err := json.Unmarshal(data, &jsonRow)
+	if err != nil {
+		return err
+	}
+
+	row.Index, row.Root = jsonRow.Index, jsonRow.Root
+	row.Shares = make([]share.Share, len(jsonRow.Shares))
+	for i, sh := range jsonRow.Shares {
+		row.Shares[i] = sh
+	}
+	nodes := make([][]byte, len(jsonRow.Proof.Nodes))
+	for i, node := range jsonRow.Proof.Nodes {
+		nodes[i] = node
+	}
+	proof := nmt.NewInclusionProof(jsonRow.Proof.Start, jsonRow.Proof.End, nodes, ipld.NMTIgnoreMaxNamespace)
+	row.Proof = &proof
+	return nil
+}
@@ -1,6 +1,7 @@
 package blob
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -10,8 +11,6 @@ import (
 	"github.com/cosmos/cosmos-sdk/types"
 	logging "github.com/ipfs/go-log/v2"
 
-	"github.com/celestiaorg/celestia-app/pkg/appconsts"
-	"github.com/celestiaorg/celestia-app/pkg/shares"
 	"github.com/celestiaorg/nmt/namespace"
 
 	"github.com/celestiaorg/celestia-node/header"
@@ -29,7 +28,19 @@ var (
 // avoid a circular dependency between the blob and the state package, since the state package needs
 // the blob.Blob type for this signature.
 type Submitter interface {
-	SubmitPayForBlob(ctx context.Context, fee math.Int, gasLim uint64, blobs []*Blob) (*types.TxResponse, error)
+	// SubmitPayForBlobFrom submits a PFB transaction paid by the account of the node's keyring with
+	// the given name. An empty name stands for the default account of the node.
+	SubmitPayForBlobFrom(
+		ctx context.Context,
+		account string,
+		fee math.Int,
+		gasLim uint64,
+		blobs []*Blob,
+	) (*types.TxResponse, error)
+	// GasPerBlobByte returns the amount of gas the network currently charges per byte of blob data.
+	GasPerBlobByte(ctx context.Context) (uint32, error)
+	// MinGasPrice returns the minimum gas price the node submitting transactions accepts.
+	MinGasPrice(ctx context.Context) (float64, error)
 }
 
 type Service struct {
@@ -39,32 +50,96 @@ type Service struct {
 	shareGetter share.Getter
 	//  headerGetter fetches header by the provided height
 	headerGetter func(context.Context, uint64) (*header.ExtendedHeader, error)
+	// index answers requests of indexed heights, if set.
+	index *Index
+	// headerSubscribe subscribes to new headers to serve blob subscriptions, if set.
+	headerSubscribe func(context.Context) (<-chan *header.ExtendedHeader, error)
+	// gasMultiplier and gasPriceMultiplier adjust estimated gas and the default gas price.
+	gasMultiplier      float64
+	gasPriceMultiplier float64
+	// fees caches the network params fees are estimated from.
+	fees feeParams
+}
+
+// Option configures the Service.
+type Option func(*Service)
+
+// WithIndex makes the Service read blobs of heights indexed by the given Index from it.
+func WithIndex(index *Index) Option {
+	return func(s *Service) {
+		s.index = index
+	}
 }
 
 func NewService(
 	submitter Submitter,
 	getter share.Getter,
 	headerGetter func(context.Context, uint64) (*header.ExtendedHeader, error),
+	opts ...Option,
 ) *Service {
-	return &Service{
-		blobSumitter: submitter,
-		shareGetter:  getter,
-		headerGetter: headerGetter,
+	s := &Service{
+		blobSumitter:       submitter,
+		shareGetter:        getter,
+		headerGetter:       headerGetter,
+		gasMultiplier:      1,
+		gasPriceMultiplier: 1,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GasOptions configures the gas and the paying account of PFB transactions. Zero values are
+// estimated or defaulted.
+type GasOptions struct {
+	// GasPrice is the price paid per unit of gas. It defaults to the gas price suggested by
+	// EstimateFee.
+	GasPrice float64 `json:"gas_price"`
+	// GasLimit is the maximum amount of gas the transaction may consume. It defaults to the amount
+	// estimated for the blobs.
+	GasLimit uint64 `json:"gas_limit"`
+	// Account is the name of the account of the node's keyring signing and paying for the
+	// transaction. It defaults to the account of the node.
+	Account string `json:"account,omitempty"`
 }
 
 // Submit sends PFB transaction and reports the height in which it was included.
 // Allows sending multiple Blobs atomically synchronously.
 // Uses default wallet registered on the Node.
 func (s *Service) Submit(ctx context.Context, blobs []*Blob) (uint64, error) {
+	return s.SubmitAll(ctx, blobs, nil)
+}
+
+// SubmitAll packs the blobs, which may belong to different namespaces, into a single PFB
+// transaction and reports the height in which it was included, so that submitters of many blobs
+// pay the overhead of a transaction only once. Commitments of the blobs are computed if missing
+// and verified otherwise. Gas is estimated for the blobs, unless set by the options, and the
+// transaction is paid by the default wallet registered on the Node, unless the options select
+// another account.
+func (s *Service) SubmitAll(ctx context.Context, blobs []*Blob, opts *GasOptions) (uint64, error) {
 	log.Debugw("submitting blobs", "amount", len(blobs))
 
+	if err := prepareBlobs(blobs); err != nil {
+		return 0, err
+	}
+
 	var (
-		gasLimit = estimateGas(blobs...)
-		fee      = int64(appconsts.DefaultMinGasPrice * float64(gasLimit))
+		gasLimit uint64
+		account  string
 	)
+	gasPrice := s.gasPrice(ctx)
+	if opts != nil {
+		gasLimit, account = opts.GasLimit, opts.Account
+		if opts.GasPrice != 0 {
+			gasPrice = opts.GasPrice
+		}
+	}
+	if gasLimit == 0 {
+		gasLimit = s.estimateGas(ctx, blobs)
+	}
 
-	resp, err := s.blobSumitter.SubmitPayForBlob(ctx, types.NewInt(fee), gasLimit, blobs)
+	resp, err := s.blobSumitter.SubmitPayForBlobFrom(ctx, account, calculateFee(gasLimit, gasPrice), gasLimit, blobs)
 	if err != nil {
 		return 0, err
 	}
@@ -73,6 +148,23 @@ func (s *Service) Submit(ctx context.Context, blobs []*Blob) (uint64, error) {
 
 // Get retrieves all the blobs for given namespaces at the given height by commitment.
 func (s *Service) Get(ctx context.Context, height uint64, nID namespace.ID, commitment Commitment) (*Blob, error) {
+	entries, indexed, err := s.indexed(ctx, height, nID)
+	if err != nil {
+		return nil, err
+	}
+	if indexed {
+		for _, entry := range entries {
+			if entry.Commitment.Equal(commitment) {
+				blobs, err := s.readIndexed(ctx, height, nID, []*IndexEntry{entry})
+				if err != nil {
+					return nil, err
+				}
+				return blobs[0], nil
+			}
+		}
+		return nil, ErrBlobNotFound
+	}
+
 	blob, _, err := s.getByCommitment(ctx, height, nID, commitment)
 	if err != nil {
 		return nil, err
@@ -80,8 +172,8 @@ func (s *Service) Get(ctx context.Context, height uint64, nID namespace.ID, comm
 	return blob, nil
 }
 
-// GetProof retrieves all blobs in the given namespaces at the given height by commitment
-// and returns their Proof.
+// GetProof retrieves the blob in the given namespace at the given height by commitment and returns
+// its Proof, which can be verified later with VerifyProof without access to a node.
 func (s *Service) GetProof(
 	ctx context.Context,
 	height uint64,
@@ -98,6 +190,11 @@ func (s *Service) GetProof(
 // GetAll returns all blobs under the given namespaces at the given height.
 // GetAll can return blobs and an error in case if some requests failed.
 func (s *Service) GetAll(ctx context.Context, height uint64, nIDs []namespace.ID) ([]*Blob, error) {
+	indexedBlobs, indexed, err := s.getAllIndexed(ctx, height, nIDs)
+	if indexed || err != nil {
+		return indexedBlobs, err
+	}
+
 	header, err := s.headerGetter(ctx, height)
 	if err != nil {
 		return nil, err
@@ -136,10 +233,71 @@ func (s *Service) GetAll(ctx context.Context, height uint64, nIDs []namespace.ID
 	return blobs, errors.Join(resultErr...)
 }
 
-// Included verifies that the blob was included in a specific height.
-// To ensure that blob was included in a specific height, we need:
-// 1. verify the provided commitment by recomputing it;
-// 2. verify the provided Proof against subtree roots that were used in 1.;
+// getAllIndexed returns all blobs under the given namespaces at the given height from the index.
+// It reports whether the height is indexed.
+func (s *Service) getAllIndexed(ctx context.Context, height uint64, nIDs []namespace.ID) ([]*Blob, bool, error) {
+	blobs := make([]*Blob, 0)
+	for _, nID := range nIDs {
+		entries, indexed, err := s.indexed(ctx, height, nID)
+		if err != nil || !indexed {
+			return nil, false, err
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		nsBlobs, err := s.readIndexed(ctx, height, nID, entries)
+		if err != nil {
+			return nil, true, err
+		}
+		blobs = append(blobs, nsBlobs...)
+	}
+	if len(blobs) == 0 {
+		return nil, true, ErrBlobNotFound
+	}
+	return blobs, true, nil
+}
+
+// indexed returns the index entries of the namespace at the height and reports whether the height
+// is indexed.
+func (s *Service) indexed(ctx context.Context, height uint64, nID namespace.ID) ([]*IndexEntry, bool, error) {
+	if s.index == nil {
+		return nil, false, nil
+	}
+	return s.index.Get(ctx, height, nID)
+}
+
+// readIndexed reads the blobs referenced by the index entries of the namespace from its shares,
+// parsing only the referenced ones.
+func (s *Service) readIndexed(
+	ctx context.Context,
+	height uint64,
+	nID namespace.ID,
+	entries []*IndexEntry,
+) ([]*Blob, error) {
+	header, err := s.headerGetter(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	namespacedShares, err := s.shareGetter.GetSharesByNamespace(share.WithHeight(ctx, height), header.DAH, nID)
+	if err != nil {
+		return nil, err
+	}
+	rawShares, _ := dropAbsent(namespacedShares).Flatten()
+
+	blobs := make([]*Blob, 0, len(entries))
+	for _, entry := range entries {
+		blob, err := indexedBlob(rawShares, entry)
+		if err != nil {
+			return nil, fmt.Errorf("reading indexed blob at height %d: %w", height, err)
+		}
+		blobs = append(blobs, blob)
+	}
+	return blobs, nil
+}
+
+// Included verifies that the blob was included in a specific height. The proof is verified
+// against the data root of the header at the height, so only the header is requested, unless the
+// proof is of another blob.
 func (s *Service) Included(
 	ctx context.Context,
 	height uint64,
@@ -147,28 +305,31 @@ func (s *Service) Included(
 	proof *Proof,
 	com Commitment,
 ) (bool, error) {
-	// In the current implementation, LNs will have to download all shares to recompute the commitment.
-	// To achieve 1. we need to modify Proof structure and to store all subtree roots, that were
-	// involved in commitment creation and then call `merkle.HashFromByteSlices`(tendermint package).
-	// nmt.Proof is verifying share inclusion by recomputing row roots, so, theoretically, we can do
-	// the same but using subtree roots. For this case, we need an extra method in nmt.Proof
-	// that will perform all reconstructions,
-	// but we have to guarantee that all our stored subtree roots will be on the same height(e.g. one
-	// level above shares).
-	// TODO(@vgonkivs): rework the implementation to perform all verification without network requests.
-	_, resProof, err := s.getByCommitment(ctx, height, nID, com)
-	switch err {
-	case nil:
-	case ErrBlobNotFound:
-		return false, nil
-	default:
+	if proof == nil || !bytes.Equal(proof.Namespace, nID) || !proof.Commitment.Equal(com) {
+		// the proof can't tell whether the requested blob is included, so the blob is looked up, and
+		// blobs missing at the height are reported as not included
+		_, _, err := s.getByCommitment(ctx, height, nID, com)
+		switch {
+		case errors.Is(err, ErrBlobNotFound):
+			return false, nil
+		case err != nil:
+			return false, err
+		}
+		return false, fmt.Errorf("%w: proof is not of the requested blob", ErrInvalidProof)
+	}
+
+	header, err := s.headerGetter(ctx, height)
+	if err != nil {
 		return false, err
 	}
-	return true, resProof.equal(*proof)
+	if err = VerifyProof(proof, header.DAH.Hash()); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
-// getByCommitment retrieves the DAH row by row, fetching shares and constructing blobs in order to
-// compare Commitments. Retrieving is stopped once the requested blob/proof is found.
+// getByCommitment retrieves the shares of the namespace and finds the blob among them by
+// comparing Commitments.
 func (s *Service) getByCommitment(
 	ctx context.Context,
 	height uint64,
@@ -184,14 +345,7 @@ func (s *Service) getByCommitment(
 		return nil, nil, err
 	}
 
-	var (
-		rawShares = make([]share.Share, 0)
-		proofs    = make(Proof, 0)
-		amount    int
-		blobShare *shares.Share
-	)
-
-	namespacedShares, err := s.shareGetter.GetSharesByNamespace(ctx, header.DAH, nID)
+	namespacedShares, err := s.shareGetter.GetSharesByNamespace(share.WithHeight(ctx, height), header.DAH, nID)
 	if err != nil {
 		if errors.Is(err, share.ErrNamespaceNotFound) ||
 			errors.Is(err, share.ErrNotFound) {
@@ -199,84 +353,31 @@ func (s *Service) getByCommitment(
 		}
 		return nil, nil, err
 	}
-	for _, row := range namespacedShares {
-		if len(row.Shares) == 0 {
-			break
-		}
-
-		rawShares = append(rawShares, row.Shares...)
-		proofs = append(proofs, row.Proof)
-
-		// reconstruct the `blobShare` from the first rawShare in range
-		// in order to get blob's length(first share will contain this info)
-		if blobShare == nil {
-			for i, sh := range rawShares {
-				bShare, err := shares.NewShare(sh)
-				if err != nil {
-					return nil, nil, err
-				}
-
-				// ensure that the first share is not a NamespacePaddingShare
-				// these shares are used to satisfy the non-interactive default rules
-				// and are not the part of the blob, so should be removed.
-				isPadding, err := bShare.IsPadding()
-				if err != nil {
-					return nil, nil, err
-				}
-				if isPadding {
-					continue
-				}
-
-				blobShare = bShare
-				// save the length.
-				length, err := blobShare.SequenceLen()
-				if err != nil {
-					return nil, nil, err
-				}
-				amount = shares.SparseSharesNeeded(length)
-				rawShares = rawShares[i:]
-				break
-			}
-		}
-
-		// move to the next row if the blob is incomplete.
-		if amount > len(rawShares) {
-			continue
-		}
-
-		blob, same, err := constructAndVerifyBlob(rawShares[:amount], commitment)
-		if err != nil {
-			return nil, nil, err
-		}
-		if same {
-			return blob, &proofs, nil
-		}
-
-		// drop info of the checked blob
-		rawShares = rawShares[amount:]
-		if len(rawShares) > 0 {
-			// save proof for the last row in case we have rawShares
-			proofs = proofs[len(proofs)-1:]
-		} else {
-			// otherwise clear proofs
-			proofs = nil
-		}
-		blobShare = nil
-	}
-
-	if len(rawShares) == 0 {
-		return nil, nil, ErrBlobNotFound
-	}
+	namespacedShares = dropAbsent(namespacedShares)
+	rawShares, idxs := namespacedShares.Flatten()
 
-	blob, same, err := constructAndVerifyBlob(rawShares, commitment)
+	blob, start, end, err := findBlob(rawShares, commitment)
 	if err != nil {
 		return nil, nil, err
 	}
-	if same {
-		return blob, &proofs, nil
+	proof, err := newProof(header.DAH, nID, commitment, namespacedShares, idxs, start, end)
+	if err != nil {
+		return nil, nil, err
 	}
+	return blob, proof, nil
+}
 
-	return nil, nil, ErrBlobNotFound
+// dropAbsent drops the shares of the rows proving the absence of the namespace, as they are not of
+// the namespace.
+func dropAbsent(namespacedShares share.NamespacedShares) share.NamespacedShares {
+	rows := make(share.NamespacedShares, len(namespacedShares))
+	for i, row := range namespacedShares {
+		rows[i] = row
+		if row.Proof != nil && row.Proof.IsOfAbsence() {
+			rows[i].Shares = nil
+		}
+	}
+	return rows
 }
 
 // getBlobs retrieves the DAH and fetches all shares from the requested namespace.ID and converts
@@ -286,5 +387,6 @@ func (s *Service) getBlobs(ctx context.Context, nID namespace.ID, root *share.Ro
 	if err != nil {
 		return nil, err
 	}
-	return SharesToBlobs(namespacedShares.Flatten())
+	shares, _ := namespacedShares.Flatten()
+	return SharesToBlobs(shares)
 }
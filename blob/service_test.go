@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	ds "github.com/ipfs/go-datastore"
 	ds_sync "github.com/ipfs/go-datastore/sync"
 	mdutils "github.com/ipfs/go-merkledag/test"
@@ -156,25 +160,21 @@ func TestBlobService_Get(t *testing.T) {
 
 				proof, ok := res.(*Proof)
 				assert.True(t, ok)
+				require.NoError(t, VerifyProof(proof, header.DAH.Hash()))
 
-				verifyFn := func(t *testing.T, rawShares [][]byte, proof *Proof, nID namespace.ID) {
-					for _, row := range header.DAH.RowRoots {
-						to := 0
-						for _, p := range *proof {
-							from := to
-							to = p.End() - p.Start() + from
-							eq := p.VerifyInclusion(sha256.New(), nID, rawShares[from:to], row)
-							if eq == true {
-								return
-							}
-						}
-					}
-					t.Fatal("could not prove the shares")
-				}
-
+				// the proof covers the shares of the blob
 				rawShares, err := BlobsToShares(blobs0[1])
 				require.NoError(t, err)
-				verifyFn(t, rawShares, proof, blobs0[1].Namespace())
+				proven := make([]share.Share, 0)
+				for _, row := range proof.Rows {
+					proven = append(proven, row.Shares...)
+				}
+				assert.Equal(t, rawShares, proven[proof.Start:proof.End])
+
+				// the proof doesn't verify against other data roots
+				require.ErrorIs(t, VerifyProof(proof, sha256.New().Sum(nil)), ErrInvalidProof)
+				proof.Commitment = blobs0[0].Commitment
+				require.ErrorIs(t, VerifyProof(proof, header.DAH.Hash()), ErrInvalidProof)
 			},
 		},
 		{
@@ -203,7 +203,7 @@ func TestBlobService_Get(t *testing.T) {
 				require.ErrorIs(t, err, ErrInvalidProof)
 				included, ok := res.(bool)
 				require.True(t, ok)
-				require.True(t, included)
+				require.False(t, included)
 			},
 		},
 		{
@@ -225,6 +225,20 @@ func TestBlobService_Get(t *testing.T) {
 				require.False(t, included)
 			},
 		},
+		{
+			name: "no proof of a blob not included",
+			doFn: func() (interface{}, error) {
+				appBlob, err := blobtest.GenerateBlobs([]int{10}, false)
+				require.NoError(t, err)
+				blob, err := convertBlobs(appBlob...)
+				require.NoError(t, err)
+
+				return service.GetProof(ctx, 1, blob[0].Namespace(), blob[0].Commitment)
+			},
+			expectedResult: func(res interface{}, err error) {
+				require.ErrorIs(t, err, ErrBlobNotFound)
+			},
+		},
 		{
 			name: "count proofs for the blob",
 			doFn: func() (interface{}, error) {
@@ -249,7 +263,7 @@ func TestBlobService_Get(t *testing.T) {
 				originalDataWidth := len(h.DAH.RowRoots) / 2
 				sizes := []int{blobSize0, blobSize1}
 				for i, proof := range proofs {
-					require.True(t, sizes[i]/originalDataWidth+1 == proof.Len())
+					require.True(t, sizes[i]/originalDataWidth+1 == len(proof.Rows))
 				}
 			},
 		},
@@ -273,18 +287,19 @@ func TestBlobService_Get(t *testing.T) {
 			doFn: func() (interface{}, error) {
 				proof, err := service.GetProof(ctx, 1, blobs0[1].Namespace(), blobs0[1].Commitment)
 				require.NoError(t, err)
-				return proof.MarshalJSON()
+				return json.Marshal(proof)
 			},
 			expectedResult: func(i interface{}, err error) {
 				require.NoError(t, err)
 				jsonData, ok := i.([]byte)
 				require.True(t, ok)
 				var proof Proof
-				require.NoError(t, proof.UnmarshalJSON(jsonData))
+				require.NoError(t, json.Unmarshal(jsonData, &proof))
 
-				newProof, err := service.GetProof(ctx, 1, blobs0[1].Namespace(), blobs0[1].Commitment)
+				// the decoded proof is verified without the service
+				header, err := service.headerGetter(ctx, 1)
 				require.NoError(t, err)
-				require.NoError(t, proof.equal(*newProof))
+				require.NoError(t, VerifyProof(&proof, header.DAH.Hash()))
 			},
 		},
 	}
@@ -429,3 +444,239 @@ func createService(ctx context.Context, t *testing.T, blobs []*Blob) *Service {
 	}
 	return NewService(nil, getters.NewIPLDGetter(bs), fn)
 }
+
+func TestService_Subscribe(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+	retryDelay := subscriptionRetryDelay
+	subscriptionRetryDelay = time.Millisecond
+	t.Cleanup(func() {
+		subscriptionRetryDelay = retryDelay
+	})
+
+	appBlobs, err := blobtest.GenerateBlobs([]int{2, 2}, false)
+	require.NoError(t, err)
+	blobs, err := convertBlobs(appBlobs...)
+	require.NoError(t, err)
+
+	bs := mdutils.Bserv()
+	rawShares, err := BlobsToShares(blobs...)
+	require.NoError(t, err)
+	eds, err := share.AddShares(ctx, rawShares, bs)
+	require.NoError(t, err)
+
+	// blobs can't be retrieved at the last height
+	const unavailable = 4
+	getByHeight := func(_ context.Context, height uint64) (*header.ExtendedHeader, error) {
+		if height == unavailable {
+			return nil, errors.New("unavailable")
+		}
+		return headertest.ExtendedHeaderFromEDS(t, height, eds), nil
+	}
+	heads := make(chan *header.ExtendedHeader, 3)
+	subscribe := func(context.Context) (<-chan *header.ExtendedHeader, error) {
+		return heads, nil
+	}
+	service := NewService(nil, getters.NewIPLDGetter(bs), getByHeight, WithHeaderSubscription(subscribe))
+
+	events, err := service.Subscribe(ctx, blobs[0].Namespace())
+	require.NoError(t, err)
+	// the subscription fills the gap between heights 1 and 3
+	for _, height := range []uint64{1, 3, unavailable} {
+		heads <- headertest.ExtendedHeaderFromEDS(t, height, eds)
+	}
+	close(heads)
+
+	for height := uint64(1); height < unavailable; height++ {
+		event := <-events
+		require.Empty(t, event.Error)
+		assert.Equal(t, height, event.Height)
+		require.Len(t, event.Blobs, 1)
+		assert.Equal(t, blobs[0].Commitment, event.Blobs[0].Commitment)
+	}
+	event := <-events
+	assert.Equal(t, uint64(unavailable), event.Height)
+	assert.Empty(t, event.Blobs)
+	assert.NotEmpty(t, event.Error)
+
+	_, ok := <-events
+	assert.False(t, ok)
+
+	_, err = NewService(nil, nil, nil).Subscribe(ctx, blobs[0].Namespace())
+	require.Error(t, err)
+}
+
+func TestService_SubmitAll(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	appBlobs, err := blobtest.GenerateBlobs([]int{1, 1, 1}, false)
+	require.NoError(t, err)
+	blobs, err := convertBlobs(appBlobs...)
+	require.NoError(t, err)
+	expected := make([]Commitment, len(blobs))
+	for i, b := range blobs {
+		expected[i] = b.Commitment
+		// commitments are computed for blobs missing them
+		b.Commitment = nil
+	}
+
+	submitter := &fakeSubmitter{height: 10}
+	service := NewService(submitter, nil, nil)
+
+	height, err := service.SubmitAll(ctx, blobs, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), height)
+	require.Len(t, submitter.blobs, len(blobs))
+	for i, b := range submitter.blobs {
+		assert.Equal(t, expected[i], b.Commitment)
+	}
+	// every small blob is charged for a whole share
+	assert.GreaterOrEqual(t, submitter.gasLimit,
+		uint64(len(blobs)*appconsts.ShareSize*appconsts.DefaultGasPerBlobByte))
+
+	_, err = service.SubmitAll(ctx, blobs, &GasOptions{GasPrice: 0.5, GasLimit: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), submitter.gasLimit)
+	assert.Equal(t, int64(500), submitter.fee.Int64())
+	assert.Empty(t, submitter.account)
+
+	_, err = service.SubmitAll(ctx, blobs, &GasOptions{Account: "other"})
+	require.NoError(t, err)
+	assert.Equal(t, "other", submitter.account)
+
+	blobs[1].Commitment = blobs[0].Commitment
+	_, err = service.SubmitAll(ctx, blobs, nil)
+	require.Error(t, err)
+
+	_, err = service.SubmitAll(ctx, nil, nil)
+	require.Error(t, err)
+}
+
+type fakeSubmitter struct {
+	height      int64
+	gasPerByte  uint32
+	minGasPrice float64
+	// paramsQueries is the amount of queries of the gas per blob byte and the minimum gas price
+	paramsQueries int
+
+	account  string
+	fee      math.Int
+	gasLimit uint64
+	blobs    []*Blob
+}
+
+func (s *fakeSubmitter) SubmitPayForBlobFrom(
+	_ context.Context,
+	account string,
+	fee math.Int,
+	gasLim uint64,
+	blobs []*Blob,
+) (*sdk.TxResponse, error) {
+	s.account, s.fee, s.gasLimit, s.blobs = account, fee, gasLim, blobs
+	return &sdk.TxResponse{Height: s.height}, nil
+}
+
+func (s *fakeSubmitter) GasPerBlobByte(context.Context) (uint32, error) {
+	s.paramsQueries++
+	if s.gasPerByte == 0 {
+		return 0, errors.New("unavailable")
+	}
+	return s.gasPerByte, nil
+}
+
+func (s *fakeSubmitter) MinGasPrice(context.Context) (float64, error) {
+	s.paramsQueries++
+	if s.minGasPrice == 0 {
+		return 0, errors.New("unavailable")
+	}
+	return s.minGasPrice, nil
+}
+
+func TestService_EstimateFee(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	appBlobs, err := blobtest.GenerateBlobs([]int{1, 2}, false)
+	require.NoError(t, err)
+	blobs, err := convertBlobs(appBlobs...)
+	require.NoError(t, err)
+
+	// the default gas per blob byte is used, if it can't be queried
+	submitter := &fakeSubmitter{}
+	service := NewService(submitter, nil, nil)
+	gas, err := service.EstimateGas(ctx, blobs)
+	require.NoError(t, err)
+	assert.Equal(t, estimateGas(appconsts.DefaultGasPerBlobByte, blobs...), gas)
+
+	// gas follows the gas per blob byte of the network
+	submitter.gasPerByte = appconsts.DefaultGasPerBlobByte * 2
+	doubled, err := service.EstimateGas(ctx, blobs)
+	require.NoError(t, err)
+	assert.Greater(t, doubled, gas)
+
+	// the default minimum gas price is used, if it can't be queried
+	service = NewService(submitter, nil, nil, WithGasMultiplier(1.5), WithGasPriceMultiplier(2))
+	estimate, err := service.EstimateFee(ctx, blobs)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(float64(doubled)*1.5), estimate.GasLimit)
+	assert.Equal(t, appconsts.DefaultMinGasPrice*2, estimate.GasPrice)
+	assert.Equal(t, calculateFee(estimate.GasLimit, estimate.GasPrice), estimate.Fee)
+
+	// the gas price follows the minimum gas price of the node
+	submitter.minGasPrice = appconsts.DefaultMinGasPrice * 3
+	estimate, err = service.EstimateFee(ctx, blobs)
+	require.NoError(t, err)
+	assert.Equal(t, appconsts.DefaultMinGasPrice*6, estimate.GasPrice)
+
+	// submissions without gas options pay the suggested fee, without querying the params again
+	queries := submitter.paramsQueries
+	_, err = service.SubmitAll(ctx, blobs, nil)
+	require.NoError(t, err)
+	assert.Equal(t, estimate.GasLimit, submitter.gasLimit)
+	assert.Equal(t, estimate.Fee, submitter.fee)
+	assert.Equal(t, queries, submitter.paramsQueries)
+
+	_, err = service.EstimateFee(ctx, nil)
+	require.Error(t, err)
+}
+
+func TestService_GetStream(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	appBlobs, err := blobtest.GenerateBlobs([]int{10, 1, 5}, true)
+	require.NoError(t, err)
+	blobs, err := convertBlobs(appBlobs...)
+	require.NoError(t, err)
+	service := createService(ctx, t, blobs)
+
+	streamChunkSize = 1024
+	t.Cleanup(func() {
+		streamChunkSize = 1 << 16
+	})
+	for _, b := range blobs {
+		chunks, err := service.GetStream(ctx, 1, b.Namespace(), b.Commitment)
+		require.NoError(t, err)
+		data := make([]byte, 0, len(b.Data))
+		for chunk := range chunks {
+			require.LessOrEqual(t, len(chunk), streamChunkSize)
+			data = append(data, chunk...)
+		}
+		assert.Equal(t, b.Data, data)
+	}
+
+	// the stream ends once its consumer is gone
+	streamCtx, streamCancel := context.WithCancel(ctx)
+	chunks, err := service.GetStream(streamCtx, 1, blobs[0].Namespace(), blobs[0].Commitment)
+	require.NoError(t, err)
+	<-chunks
+	streamCancel()
+	// the chunks sent before the cancellation are drained until the channel is closed
+	for chunk := range chunks {
+		require.NotEmpty(t, chunk)
+	}
+
+	_, err = service.GetStream(ctx, 1, blobs[0].Namespace(), sha256.New().Sum(nil))
+	require.ErrorIs(t, err, ErrBlobNotFound)
+}
@@ -0,0 +1,119 @@
+package blob
+
+import (
+	"context"
+	"errors"
+
+	"github.com/celestiaorg/celestia-app/pkg/shares"
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// streamChunkSize is the maximum size of the chunks of data emitted by GetStream.
+var streamChunkSize = 1 << 16
+
+// GetStream emits the data of the blob with the given commitment in the namespace at the given
+// height in chunks of up to 64KiB, in order, until the data is emitted or the context is canceled.
+// The channel is closed afterwards.
+//
+// The blob is found and verified against its commitment as Get does, before any data is emitted,
+// so that consumers, i.e. RPC clients, receive multi-MB blobs piece by piece instead of in a single
+// response.
+func (s *Service) GetStream(
+	ctx context.Context,
+	height uint64,
+	nID namespace.ID,
+	commitment Commitment,
+) (<-chan []byte, error) {
+	blob, err := s.Get(ctx, height, nID, commitment)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(chan []byte)
+	go func() {
+		defer close(chunks)
+		for data := blob.Data; len(data) > 0; {
+			size := streamChunkSize
+			if size > len(data) {
+				size = len(data)
+			}
+			select {
+			case chunks <- data[:size]:
+				data = data[size:]
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return chunks, nil
+}
+
+// findBlob returns the blob with the given commitment and its range within the shares of its
+// namespace.
+func findBlob(rawShares []share.Share, commitment Commitment) (*Blob, int, int, error) {
+	for i := 0; i < len(rawShares); {
+		sh, err := shares.NewShare(rawShares[i])
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		// namespace padding shares are not the part of any blob
+		isPadding, err := sh.IsPadding()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		if isPadding {
+			i++
+			continue
+		}
+
+		length, err := sh.SequenceLen()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		end := i + shares.SparseSharesNeeded(length)
+		if end > len(rawShares) {
+			return nil, 0, 0, errors.New("blob: blob exceeds the shares of its namespace")
+		}
+
+		blob, err := parseBlob(rawShares[i:end])
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		if commitment.Equal(blob.Commitment) {
+			return blob, i, end, nil
+		}
+		i = end
+	}
+	return nil, 0, 0, ErrBlobNotFound
+}
+
+// indexedBlob returns the blob referenced by the index entry, verifying that its shares match the
+// commitment of the entry.
+func indexedBlob(rawShares []share.Share, entry *IndexEntry) (*Blob, error) {
+	if entry.Start < 0 || entry.Start >= entry.End || entry.End > len(rawShares) {
+		return nil, errors.New("blob: indexed blob is out of the shares of its namespace")
+	}
+	blob, err := parseBlob(rawShares[entry.Start:entry.End])
+	if err != nil {
+		return nil, err
+	}
+	if !entry.Commitment.Equal(blob.Commitment) {
+		return nil, errors.New("blob: indexed blob does not match its commitment")
+	}
+	return blob, nil
+}
+
+// parseBlob parses the blob out of its shares. Its commitment is computed from its data by NewBlob,
+// as for any other blob.
+func parseBlob(blobShares []share.Share) (*Blob, error) {
+	blobs, err := SharesToBlobs(blobShares)
+	if err != nil {
+		return nil, err
+	}
+	if len(blobs) != 1 || blobs[0] == nil {
+		return nil, errors.New("blob: shares are not of a single blob")
+	}
+	return blobs[0], nil
+}
@@ -0,0 +1,144 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+var (
+	// subscriptionBufferSize is the amount of events buffered for slow subscribers.
+	subscriptionBufferSize = 16
+	// subscriptionRetries is the amount of times retrieval of blobs of a height is retried, before
+	// the failure is reported in the event of the height.
+	subscriptionRetries = 3
+	// subscriptionRetryDelay is the delay before the first retry, which doubles with every retry.
+	subscriptionRetryDelay = time.Second
+)
+
+// BlobEvent is emitted by Subscribe for every new height.
+type BlobEvent struct {
+	Height uint64 `json:"height"`
+	// Blobs are the blobs of the namespace at the height. It is empty, if there are none.
+	Blobs []*Blob `json:"blobs"`
+	// Error describes the failure to retrieve the blobs of the height, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// Legacy returns a copy of the BlobEvent encoding JSON in encoding.LegacyVersion.
+func (e *BlobEvent) Legacy() *BlobEvent {
+	if e == nil {
+		return nil
+	}
+	legacy := *e
+	legacy.Blobs = legacyBlobs(e.Blobs)
+	return &legacy
+}
+
+// WithHeaderSubscription makes the Service subscribe to new headers with the given function to
+// serve blob subscriptions.
+func WithHeaderSubscription(subscribe func(context.Context) (<-chan *header.ExtendedHeader, error)) Option {
+	return func(s *Service) {
+		s.headerSubscribe = subscribe
+	}
+}
+
+// Subscribe emits the blobs of the given namespace for every new height, in order and without
+// gaps, until the context is canceled. Retrieval of blobs is retried, and the heights which blobs
+// couldn't be retrieved are reported in events with an error, so that subscribers can request them
+// again later.
+func (s *Service) Subscribe(ctx context.Context, nID namespace.ID) (<-chan *BlobEvent, error) {
+	if s.headerSubscribe == nil {
+		return nil, errors.New("blob: subscriptions are not supported")
+	}
+	heads, err := s.headerSubscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan *BlobEvent, subscriptionBufferSize)
+	go func() {
+		defer close(events)
+		var last uint64
+		for {
+			var head *header.ExtendedHeader
+			select {
+			case head = <-heads:
+				if head == nil {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			height := uint64(head.Height())
+			if height <= last {
+				continue
+			}
+			from := height
+			if last != 0 {
+				// heights skipped by the header subscription are emitted as well
+				from = last + 1
+			}
+			for h := from; h <= height; h++ {
+				select {
+				case events <- s.blobEvent(ctx, h, nID):
+				case <-ctx.Done():
+					return
+				}
+			}
+			last = height
+		}
+	}()
+	return events, nil
+}
+
+// blobEvent retrieves the blobs of the namespace at the height, retrying failures.
+func (s *Service) blobEvent(ctx context.Context, height uint64, nID namespace.ID) *BlobEvent {
+	var err error
+	for attempt := 0; ; attempt++ {
+		var blobs []*Blob
+		blobs, err = s.getNamespaceBlobs(ctx, height, nID)
+		if err == nil {
+			return &BlobEvent{Height: height, Blobs: blobs}
+		}
+		if attempt == subscriptionRetries {
+			break
+		}
+
+		log.Warnw("retrying retrieval of blobs for subscription",
+			"height", height,
+			"nID", nID.String(),
+			"attempt", attempt,
+			"err", err,
+		)
+		select {
+		case <-time.After(subscriptionRetryDelay << attempt):
+		case <-ctx.Done():
+			return &BlobEvent{Height: height, Error: ctx.Err().Error()}
+		}
+	}
+	return &BlobEvent{Height: height, Error: err.Error()}
+}
+
+// getNamespaceBlobs returns the blobs of the namespace at the height, which is empty if there are
+// none.
+func (s *Service) getNamespaceBlobs(ctx context.Context, height uint64, nID namespace.ID) ([]*Blob, error) {
+	blobs, indexed, err := s.getAllIndexed(ctx, height, []namespace.ID{nID})
+	if !indexed && err == nil {
+		var header *header.ExtendedHeader
+		header, err = s.headerGetter(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		blobs, err = s.getBlobs(ctx, nID, header.DAH)
+	}
+	if errors.Is(err, ErrBlobNotFound) {
+		return []*Blob{}, nil
+	}
+	return blobs, err
+}
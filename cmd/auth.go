@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -13,6 +14,8 @@ import (
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
+	"github.com/celestiaorg/nmt/namespace"
+
 	"github.com/celestiaorg/celestia-node/api/rpc/perms"
 	"github.com/celestiaorg/celestia-node/libs/authtoken"
 	"github.com/celestiaorg/celestia-node/libs/keystore"
@@ -31,9 +34,24 @@ func AuthCmd(fsets ...*flag.FlagSet) *cobra.Command {
 	for _, set := range fsets {
 		cmd.Flags().AddFlagSet(set)
 	}
+	cmd.Flags().StringSlice(
+		namespaceFlag,
+		nil,
+		"Scopes the token to the given hex-encoded namespace IDs, so that it can only read and submit their blobs",
+	)
+	cmd.Flags().StringSlice(
+		methodFlag,
+		nil,
+		"Scopes the token to the given modules or methods of modules, e.g. 'blob' or 'blob.Submit'",
+	)
 	return cmd
 }
 
+var (
+	namespaceFlag = "namespace"
+	methodFlag    = "method"
+)
+
 func newToken(cmd *cobra.Command, args []string) error {
 	if len(args) != 1 {
 		return fmt.Errorf("must specify permissions")
@@ -43,6 +61,19 @@ func newToken(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	namespaces, err := parseNamespaces(cmd)
+	if err != nil {
+		return err
+	}
+	methods, err := cmd.Flags().GetStringSlice(methodFlag)
+	if err != nil {
+		return err
+	}
+	for _, method := range methods {
+		if err = perms.ValidateMethod(method); err != nil {
+			return fmt.Errorf("invalid method %s: %w", method, err)
+		}
+	}
 
 	expanded, err := homedir.Expand(filepath.Clean(StorePath(cmd.Context())))
 	if err != nil {
@@ -71,7 +102,11 @@ func newToken(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	token, err := authtoken.NewSignedJWT(signer, permissions)
+	token, err := authtoken.NewSignedPayloadJWT(signer, &perms.JWTPayload{
+		Allow:      permissions,
+		Namespaces: namespaces,
+		Methods:    methods,
+	})
 	if err != nil {
 		return err
 	}
@@ -94,6 +129,22 @@ func generateNewKey(ks keystore.Keystore) (keystore.PrivKey, error) {
 	return key, nil
 }
 
+func parseNamespaces(cmd *cobra.Command) ([]namespace.ID, error) {
+	hexNIDs, err := cmd.Flags().GetStringSlice(namespaceFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	nIDs := make([]namespace.ID, len(hexNIDs))
+	for i, hexNID := range hexNIDs {
+		nIDs[i], err = hex.DecodeString(hexNID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace %s: %w", hexNID, err)
+		}
+	}
+	return nIDs, nil
+}
+
 func convertToPerms(perm string) ([]auth.Permission, error) {
 	perms, ok := stringsToPerms[perm]
 	if !ok {
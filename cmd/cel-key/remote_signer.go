@@ -0,0 +1,91 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+
+	"github.com/celestiaorg/celestia-node/libs/remotesigner"
+	"github.com/celestiaorg/celestia-node/nodebuilder/state"
+)
+
+var (
+	remoteSignerKeysFlag    = "keys"
+	remoteSignerTLSCertFlag = "tls.cert"
+	remoteSignerTLSKeyFlag  = "tls.key"
+)
+
+func init() {
+	remoteSignerCmd.Flags().StringSlice(remoteSignerKeysFlag, nil, "Names of the keys to serve (required)")
+	remoteSignerCmd.Flags().String(remoteSignerTLSCertFlag, "",
+		"Path of the PEM-encoded TLS certificate to serve TCP addresses with")
+	remoteSignerCmd.Flags().String(remoteSignerTLSKeyFlag, "", "Path of the PEM-encoded key of the TLS certificate")
+	rootCmd.AddCommand(remoteSignerCmd)
+}
+
+var remoteSignerCmd = &cobra.Command{
+	Use:   "remote-signer [listen-address]",
+	Short: "Serves signing with the keys of the keyring to nodes configured with the remote signer",
+	Long: "Serves signing with the given keys of the keyring over JSON-RPC, so that nodes don't need hot keys " +
+		"on their machines. Only transactions of the network set with --" + networkKey + " are signed.\n" +
+		"The listen address is either unix:///path/to/socket or a TCP address served over TLS with " +
+		"the certificate set with --" + remoteSignerTLSCertFlag + " and --" + remoteSignerTLSKeyFlag + ". " +
+		"Clients must present the token set in the " + state.RemoteSignerTokenEnv + " environment variable.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token := os.Getenv(state.RemoteSignerTokenEnv)
+		if token == "" {
+			return errors.New("remote signer token must be set in " + state.RemoteSignerTokenEnv)
+		}
+		keys, err := cmd.Flags().GetStringSlice(remoteSignerKeysFlag)
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			return errors.New("keys to serve must be set with --" + remoteSignerKeysFlag)
+		}
+		network, err := cmd.Flags().GetString(networkKey)
+		if err != nil {
+			return err
+		}
+		tlsCfg, err := remoteSignerTLS(cmd)
+		if err != nil {
+			return err
+		}
+		clientCtx, err := client.GetClientQueryContext(cmd)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+		handler := remotesigner.Handler(clientCtx.Keyring, token, keys, remotesigner.ChainPolicy(network))
+		cmd.Printf("serving keys %v of network %s on %s\n", keys, network, args[0])
+		return remotesigner.Serve(ctx, args[0], tlsCfg, handler)
+	},
+}
+
+// remoteSignerTLS returns the TLS config of the signer or nil if no certificate is set.
+func remoteSignerTLS(cmd *cobra.Command) (*tls.Config, error) {
+	certFile, err := cmd.Flags().GetString(remoteSignerTLSCertFlag)
+	if err != nil {
+		return nil, err
+	}
+	keyFile, err := cmd.Flags().GetString(remoteSignerTLSKeyFlag)
+	if err != nil {
+		return nil, err
+	}
+	if certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, nil
+}
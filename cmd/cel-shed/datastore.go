@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func init() {
+	datastoreCmd.AddCommand(datastoreMigrate)
+}
+
+var datastoreCmd = &cobra.Command{
+	Use:   "datastore [subcommand]",
+	Short: "Collection of node datastore related utilities",
+}
+
+var datastoreMigrate = &cobra.Command{
+	Use: "migrate [node-type] [network] [backend]",
+	Short: `Copy the datastore into the given backend and switch the node config over to it. Requires the node being stopped.
+The data of the previous backend is kept. Custom store path is not supported yet.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 3 {
+			return fmt.Errorf("not enough arguments")
+		}
+
+		tp := node.ParseType(args[0])
+		if !tp.IsValid() {
+			return fmt.Errorf("invalid node-type")
+		}
+
+		path := fmt.Sprintf("~/.celestia-%s-%s", strings.ToLower(tp.String()), strings.ToLower(args[1]))
+		migrated, err := nodebuilder.MigrateDatastore(cmd.Context(), path, args[2])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("migrated %d entries to %s\n", migrated, args[2])
+		return nil
+	},
+}
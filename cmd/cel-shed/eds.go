@@ -0,0 +1,424 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+)
+
+var (
+	quarantineFlag = "quarantine"
+	workersFlag    = "workers"
+)
+
+func init() {
+	edsVerify.Flags().Bool(quarantineFlag, false, "Move corrupted CAR files out of the store")
+	edsRebuildIndexes.Flags().Int(workersFlag, runtime.NumCPU(), "Amount of CAR files indexed in parallel")
+	edsCmd.AddCommand(edsVerify, edsExport, edsImport, edsVerifySnapshot, edsDump, edsLoad, edsMigrate, edsRebuildIndexes)
+}
+
+var edsCmd = &cobra.Command{
+	Use:   "eds [subcommand]",
+	Short: "Collection of EDS store related utilities",
+}
+
+var edsVerify = &cobra.Command{
+	Use: "verify [node-type] [network] [from] [to]",
+	Short: `Verify integrity of the EDSes stored in the given inclusive height range. Requires the node being stopped.
+Custom store path is not supported yet.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 4 {
+			return fmt.Errorf("not enough arguments")
+		}
+
+		from, to, err := parseRange(args[2], args[3])
+		if err != nil {
+			return err
+		}
+
+		quarantine, err := cmd.Flags().GetBool(quarantineFlag)
+		if err != nil {
+			return err
+		}
+
+		edsStore, hstore, closer, err := openEDSStore(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		res, err := edsStore.Verify(cmd.Context(), from, to, hstore.GetByHeight, quarantine)
+		if res != nil {
+			fmt.Printf("checked: %d, missing: %d, corrupted: %d\n", res.Checked, len(res.Missing), len(res.Corrupted))
+			for _, c := range res.Corrupted {
+				fmt.Printf("height: %d, hash: %s, quarantined: %t, err: %s\n", c.Height, c.DataHash, c.Quarantined, c.Err)
+			}
+		}
+		return err
+	},
+}
+
+var edsExport = &cobra.Command{
+	Use: "export [node-type] [network] [from] [to] [file]",
+	Short: `Export EDSes of the given inclusive height range into a snapshot archive. Requires the node being stopped.
+Custom store path is not supported yet.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 5 {
+			return fmt.Errorf("not enough arguments")
+		}
+
+		from, to, err := parseRange(args[2], args[3])
+		if err != nil {
+			return err
+		}
+
+		edsStore, hstore, closer, err := openEDSStore(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		f, err := os.Create(args[4])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		manifest, err := edsStore.ExportSnapshot(cmd.Context(), f, from, to, hstore.GetByHeight)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("exported heights: [%d; %d]\n", manifest.From, manifest.To)
+		return f.Sync()
+	},
+}
+
+var edsImport = &cobra.Command{
+	Use: "import [node-type] [network] [file]",
+	Short: `Import EDSes from a snapshot archive. Requires the node being stopped.
+EDSes of heights which headers are stored are validated against them. Headers of the other imported heights are
+synced by the node as usual. Custom store path is not supported yet.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 3 {
+			return fmt.Errorf("not enough arguments")
+		}
+
+		edsStore, hstore, closer, err := openEDSStore(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		getter, err := storedHeaders(cmd.Context(), hstore)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(args[2])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		manifest, err := edsStore.ImportSnapshot(cmd.Context(), f, getter)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("imported heights: [%d; %d]\n", manifest.From, manifest.To)
+		return nil
+	},
+}
+
+var edsVerifySnapshot = &cobra.Command{
+	Use: "verify-snapshot [file] [hash...]",
+	Short: `Check CAR files of a snapshot archive against their archived checksums without importing them.
+Only the EDSes of the given hex encoded DataHashes are checked, if any are given.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return fmt.Errorf("not enough arguments")
+		}
+
+		roots := make([]share.DataHash, 0, len(args)-1)
+		for _, arg := range args[1:] {
+			root, err := hex.DecodeString(arg)
+			if err != nil {
+				return fmt.Errorf("invalid hash %s: %w", arg, err)
+			}
+			roots = append(roots, root)
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		manifest, res, err := eds.VerifySnapshot(cmd.Context(), f, roots...)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("heights: [%d; %d], checked: %d, missing: %d, corrupted: %d\n",
+			manifest.From, manifest.To, res.Checked, len(res.Missing), len(res.Corrupted))
+		for _, root := range res.Missing {
+			fmt.Printf("missing hash: %s\n", root)
+		}
+		for _, c := range res.Corrupted {
+			fmt.Printf("corrupted hash: %s, err: %s\n", c.DataHash, c.Err)
+		}
+		if len(res.Missing) != 0 || len(res.Corrupted) != 0 {
+			return fmt.Errorf("snapshot verification failed")
+		}
+		return nil
+	},
+}
+
+var edsDump = &cobra.Command{
+	Use: "dump [node-type] [network] [height] [file]",
+	Short: `Write the EDS of the given height into a file in the canonical byte encoding. Requires the node being stopped.
+Custom store path is not supported yet.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 4 {
+			return fmt.Errorf("not enough arguments")
+		}
+
+		height, err := strconv.ParseUint(args[2], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid height: %w", err)
+		}
+
+		edsStore, hstore, closer, err := openEDSStore(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		h, err := hstore.GetByHeight(cmd.Context(), height)
+		if err != nil {
+			return err
+		}
+		square, err := edsStore.Get(cmd.Context(), h.DAH.Hash())
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(args[3])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if err = eds.Encode(f, square); err != nil {
+			return err
+		}
+		fmt.Printf("dumped height: %d, hash: %X\n", height, h.DAH.Hash())
+		return f.Sync()
+	},
+}
+
+var edsLoad = &cobra.Command{
+	Use: "load [node-type] [network] [file] [hash]",
+	Short: `Store the EDS from a file in the canonical byte encoding, e.g. written by dump, after verifying it against
+the given hex encoded DataHash. Requires the node being stopped. Custom store path is not supported yet.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 4 {
+			return fmt.Errorf("not enough arguments")
+		}
+
+		root, err := hex.DecodeString(args[3])
+		if err != nil {
+			return fmt.Errorf("invalid hash %s: %w", args[3], err)
+		}
+
+		f, err := os.Open(args[2])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		square, err := eds.Decode(bufio.NewReader(f), root)
+		if err != nil {
+			return err
+		}
+
+		edsStore, _, closer, err := openEDSStore(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		if err = edsStore.Put(cmd.Context(), root, square); err != nil {
+			return err
+		}
+		fmt.Printf("loaded hash: %X\n", root)
+		return nil
+	},
+}
+
+var edsMigrate = &cobra.Command{
+	Use: "migrate [node-type] [network]",
+	Short: `Rewrite stored CAR files into the current layout. Requires the node being stopped.
+Use the MigrateCARs option of the node config to migrate while the node is running.
+Interrupted migration resumes on the next run. Custom store path is not supported yet.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return fmt.Errorf("not enough arguments")
+		}
+
+		edsStore, _, closer, err := openEDSStore(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		progress, err := edsStore.Migrate(cmd.Context(), eds.CanonicalMigration)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("migrated: %d/%d, failed: %d\n", progress.Migrated, progress.Total, progress.Failed)
+		if !progress.Done() {
+			return fmt.Errorf("migration incomplete")
+		}
+		return nil
+	},
+}
+
+var edsRebuildIndexes = &cobra.Command{
+	Use: "rebuild-indexes [node-type] [network]",
+	Short: `Rebuild indexes of all stored CAR files, e.g. if they are lost or corrupted. Requires the node being stopped.
+Custom store path is not supported yet.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return fmt.Errorf("not enough arguments")
+		}
+
+		workers, err := cmd.Flags().GetInt(workersFlag)
+		if err != nil {
+			return err
+		}
+
+		edsStore, _, closer, err := openEDSStore(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+		defer closer()
+
+		progress, err := edsStore.RebuildIndexes(cmd.Context(), workers, func(p eds.RebuildProgress) {
+			fmt.Printf("\rrebuilt: %d/%d, failed: %d", p.Rebuilt, p.Total, p.Failed)
+		})
+		fmt.Println()
+		if err != nil {
+			return err
+		}
+		if !progress.Done() {
+			return fmt.Errorf("rebuilding indexes incomplete")
+		}
+		return nil
+	},
+}
+
+func parseRange(fromArg, toArg string) (uint64, uint64, error) {
+	from, err := strconv.ParseUint(fromArg, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid from height: %w", err)
+	}
+	to, err := strconv.ParseUint(toArg, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid to height: %w", err)
+	}
+	return from, to, nil
+}
+
+// openEDSStore opens and starts the EDS store of the given node along with its header store. The
+// returned closer stops the EDS store and closes the node store.
+func openEDSStore(
+	ctx context.Context,
+	nodeType, network string,
+) (*eds.Store, *store.Store[*header.ExtendedHeader], func(), error) {
+	tp := node.ParseType(nodeType)
+	if tp != node.Bridge && tp != node.Full {
+		return nil, nil, nil, fmt.Errorf("invalid node-type: only bridge and full nodes store EDSes")
+	}
+
+	s, err := nodebuilder.OpenStore(fmt.Sprintf("~/.celestia-%s-%s", strings.ToLower(tp.String()),
+		strings.ToLower(network)), nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ds, err := s.Datastore()
+	if err != nil {
+		s.Close()
+		return nil, nil, nil, err
+	}
+
+	hstore, err := store.NewStore[*header.ExtendedHeader](ds)
+	if err != nil {
+		s.Close()
+		return nil, nil, nil, err
+	}
+
+	edsStore, err := eds.NewStore(s.Path(), ds)
+	if err != nil {
+		s.Close()
+		return nil, nil, nil, err
+	}
+	err = edsStore.Start(ctx)
+	if err != nil {
+		s.Close()
+		return nil, nil, nil, err
+	}
+
+	closer := func() {
+		_ = edsStore.Stop(ctx)
+		_ = s.Close()
+	}
+	return edsStore, hstore, closer, nil
+}
+
+// storedHeaders returns the HeaderGetter of the headers stored locally. Heights above the stored head
+// are reported with header.ErrNotFound instead of being waited for.
+func storedHeaders(
+	ctx context.Context,
+	hstore *store.Store[*header.ExtendedHeader],
+) (eds.HeaderGetter, error) {
+	head, err := hstore.Head(ctx)
+	switch {
+	case errors.Is(err, libhead.ErrNoHead):
+		return func(context.Context, uint64) (*header.ExtendedHeader, error) {
+			return nil, libhead.ErrNotFound
+		}, nil
+	case err != nil:
+		return nil, err
+	}
+	return func(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+		if height > uint64(head.Height()) {
+			return nil, libhead.ErrNotFound
+		}
+		return hstore.GetByHeight(ctx, height)
+	}, nil
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/celestiaorg/celestia-node/api/rpc/client"
 	"github.com/celestiaorg/celestia-node/blob"
+	"github.com/celestiaorg/celestia-node/libs/encoding"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/state"
 )
@@ -117,7 +118,7 @@ func parseParams(method string, params []string) []interface{} {
 		if err != nil {
 			panic(fmt.Sprintf("Error parsing namespace ID: %v", err))
 		}
-		parsedParams[1] = nID
+		parsedParams[1] = encoding.Hex(nID)
 	case "Submit":
 		// 1. NamespaceID
 		var err error
@@ -206,13 +207,13 @@ func parseParams(method string, params []string) []interface{} {
 		if err != nil {
 			panic(fmt.Sprintf("Error parsing namespace ID: %v", err))
 		}
-		parsedParams[1] = nID
+		parsedParams[1] = encoding.Hex(nID)
 		// 3: Commitment
-		commitment, err := base64.StdEncoding.DecodeString(params[2])
+		commitment, err := decodeToBytes(params[2])
 		if err != nil {
-			panic("Error decoding commitment: base64 string could not be decoded.")
+			panic("Error decoding commitment: base64 or hex string could not be decoded.")
 		}
-		parsedParams[2] = commitment
+		parsedParams[2] = blob.Commitment(commitment)
 		return parsedParams
 	case "GetAll": // NOTE: Over the cli, you can only pass one namespace
 		// 1. Height
@@ -226,7 +227,7 @@ func parseParams(method string, params []string) []interface{} {
 		if err != nil {
 			panic(fmt.Sprintf("Error parsing namespace ID: %v", err))
 		}
-		parsedParams[1] = []namespace.ID{nID}
+		parsedParams[1] = []encoding.Hex{encoding.Hex(nID)}
 		return parsedParams
 	case "QueryDelegation", "QueryUnbonding", "BalanceForAddress":
 		var err error
@@ -346,6 +347,7 @@ func sendJSONRPCRequest(namespace, method string, params []interface{}) {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(encoding.VersionHeader, encoding.Version)
 
 	authToken := authTokenFlag
 	if authToken == "" {
@@ -43,6 +43,24 @@ var rootCmd = &cobra.Command{
 	},
 }
 
+var openAPICmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "openapi generates the OpenAPI document of the Celestia Node gateway",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		doc, err := docgen.NewOpenAPIDocument()
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(doc)
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(openAPICmd)
+}
+
 func main() {
 	err := run()
 	if err != nil {
@@ -22,7 +22,8 @@ func Start(fsets ...*flag.FlagSet) *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "start",
 		Short: `Starts Node daemon. First stopping signal gracefully stops the Node and second terminates it.
-Options passed on start override configuration options only on start and are not persisted in config.`,
+Options passed on start override configuration options only on start and are not persisted in config.
+SIGHUP reloads runtime-safe parameters of the config without restarting the Node.`,
 		Aliases:      []string{"run", "daemon"},
 		Args:         cobra.NoArgs,
 		SilenceUsage: true,
@@ -64,6 +65,28 @@ Options passed on start override configuration options only on start and are not
 				return err
 			}
 
+			// reload runtime-safe parameters of the config on SIGHUP
+			reload := make(chan os.Signal, 1)
+			signal.Notify(reload, syscall.SIGHUP)
+			defer signal.Stop(reload)
+			go func() {
+				for {
+					select {
+					case <-reload:
+						report, err := nd.AdminServ.ConfigReload(ctx)
+						if err != nil {
+							log.Errorw("reloading config", "err", err)
+							continue
+						}
+						for param, reason := range report.Rejected {
+							log.Warnw("config change rejected", "param", param, "reason", reason)
+						}
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+
 			<-ctx.Done()
 			cancel() // ensure we stop reading more signals for start context
 
@@ -12,6 +12,7 @@ import (
 	"github.com/celestiaorg/celestia-app/pkg/square"
 	"github.com/celestiaorg/rsmt2d"
 
+	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds"
 )
@@ -37,12 +38,21 @@ func extendBlock(data types.Data) (*rsmt2d.ExtendedDataSquare, error) {
 	return da.ExtendShares(shares)
 }
 
-// storeEDS will only store extended block if it is not empty and doesn't already exist.
-func storeEDS(ctx context.Context, hash share.DataHash, eds *rsmt2d.ExtendedDataSquare, store *eds.Store) error {
-	if eds == nil {
+// storeEDS will only store extended block if it is not empty and doesn't already exist. The EDS is
+// validated against the header beforehand, so that stored squares always match their headers.
+func storeEDS(
+	ctx context.Context,
+	eh *header.ExtendedHeader,
+	square *rsmt2d.ExtendedDataSquare,
+	store *eds.Store,
+) error {
+	if square == nil {
 		return nil
 	}
-	err := store.Put(ctx, hash, eds)
+	if err := eds.ValidateAgainstHeader(square, eh); err != nil {
+		return err
+	}
+	err := store.Put(ctx, eh.DAH.Hash(), square)
 	if errors.Is(err, dagstore.ErrShardExists) {
 		// block with given root already exists, return nil
 		return nil
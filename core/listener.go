@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -14,6 +15,7 @@ import (
 	libhead "github.com/celestiaorg/go-header"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/watchdog"
 	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexsub"
 )
@@ -37,8 +39,13 @@ type Listener struct {
 	hashBroadcaster   shrexsub.BroadcastFn
 
 	listenerTimeout time.Duration
+	// heartbeats is nil unless the listener loop is watched for getting stuck
+	heartbeats *watchdog.Registry
 
+	// lk guards the loop against being started, stopped and restarted concurrently
+	lk     sync.Mutex
 	cancel context.CancelFunc
+	done   chan struct{}
 }
 
 func NewListener(
@@ -59,35 +66,89 @@ func NewListener(
 	}
 }
 
+// WithHeartbeats makes the listener loop check in with the given watchdog.Registry, so that it is
+// flagged once stuck.
+func (cl *Listener) WithHeartbeats(r *watchdog.Registry) {
+	cl.heartbeats = r
+}
+
 // Start kicks off the Listener listener loop.
-func (cl *Listener) Start(context.Context) error {
+func (cl *Listener) Start(ctx context.Context) error {
+	cl.lk.Lock()
+	defer cl.lk.Unlock()
+	return cl.start(ctx)
+}
+
+// Stop stops the listener loop.
+func (cl *Listener) Stop(ctx context.Context) error {
+	cl.lk.Lock()
+	defer cl.lk.Unlock()
+	return cl.stop(ctx)
+}
+
+// Restart restarts the listener loop along with the subscription to new blocks, e.g. once the loop
+// got stuck. The loop is only restarted once the previous one exited, so that they never broadcast
+// concurrently; if it doesn't exit before the context is done, the restart fails and may be retried.
+func (cl *Listener) Restart(ctx context.Context) error {
+	cl.lk.Lock()
+	defer cl.lk.Unlock()
+	if cl.cancel == nil {
+		// stopped or not started yet, so there is nothing to restart
+		return nil
+	}
+	if err := cl.stop(ctx); err != nil {
+		return err
+	}
+	if err := cl.fetcher.UnsubscribeNewBlockEvent(ctx); err != nil {
+		log.Warnw("listener: unsubscribe error on restart", "err", err)
+	}
+	return cl.start(ctx)
+}
+
+func (cl *Listener) start(context.Context) error {
 	if cl.cancel != nil {
 		return fmt.Errorf("listener: already started")
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
-	cl.cancel = cancel
-
 	sub, err := cl.fetcher.SubscribeNewBlockEvent(ctx)
 	if err != nil {
+		cancel()
 		return err
 	}
-	go cl.runSubscriber(ctx, sub)
+	cl.cancel = cancel
+	cl.done = make(chan struct{})
+	go cl.runSubscriber(ctx, sub, cl.done)
 	return nil
 }
 
-// Stop stops the listener loop.
-func (cl *Listener) Stop(context.Context) error {
+// stop stops the listener loop and waits for it to exit.
+func (cl *Listener) stop(ctx context.Context) error {
+	if cl.cancel == nil {
+		return nil
+	}
 	cl.cancel()
-	cl.cancel = nil
-	return nil
+	select {
+	case <-cl.done:
+		cl.cancel = nil
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("listener: waiting for the loop to exit: %w", ctx.Err())
+	}
 }
 
 // runSubscriber runs a subscriber to receive event data of new signed blocks. It will attempt to
 // resubscribe in case error happens during listening of subscription
-func (cl *Listener) runSubscriber(ctx context.Context, sub <-chan types.EventDataSignedBlock) {
+func (cl *Listener) runSubscriber(
+	ctx context.Context,
+	sub <-chan types.EventDataSignedBlock,
+	done chan struct{},
+) {
+	defer close(done)
+	hb := cl.heartbeats.Register("core", "core/listener")
+	defer hb.Stop()
 	for {
-		err := cl.listen(ctx, sub)
+		err := cl.listen(ctx, sub, hb)
 		if ctx.Err() != nil {
 			// listener stopped because external context was canceled
 			return
@@ -111,7 +172,11 @@ func (cl *Listener) runSubscriber(ctx context.Context, sub <-chan types.EventDat
 // listen kicks off a loop, listening for new block events from Core,
 // generating ExtendedHeaders and broadcasting them to the header-sub
 // gossipsub network.
-func (cl *Listener) listen(ctx context.Context, sub <-chan types.EventDataSignedBlock) error {
+func (cl *Listener) listen(
+	ctx context.Context,
+	sub <-chan types.EventDataSignedBlock,
+	hb *watchdog.Heartbeat,
+) error {
 	defer log.Info("listener: listening stopped")
 	timeout := time.NewTimer(cl.listenerTimeout)
 	defer timeout.Stop()
@@ -135,6 +200,8 @@ func (cl *Listener) listen(ctx context.Context, sub <-chan types.EventDataSigned
 				<-timeout.C
 			}
 			timeout.Reset(cl.listenerTimeout)
+		case <-hb.Tick():
+			hb.Beat()
 		case <-timeout.C:
 			return errors.New("underlying subscription is stuck")
 		case <-ctx.Done():
@@ -161,7 +228,7 @@ func (cl *Listener) handleNewSignedBlock(ctx context.Context, b types.EventDataS
 	}
 
 	// attempt to store block data if not empty
-	err = storeEDS(ctx, b.Header.DataHash.Bytes(), eds, cl.store)
+	err = storeEDS(ctx, eh, eds, cl.store)
 	if err != nil {
 		return fmt.Errorf("storing EDS: %w", err)
 	}
@@ -0,0 +1,104 @@
+package das
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/p2p/attestsub"
+)
+
+// attestationWindow is the amount of the latest heights attestations of peers are kept for.
+const attestationWindow = 1000
+
+// attestations shares sampling results with peers as attestations and tracks theirs. Attestations
+// of peers are never trusted to conclude availability, as peer identities are free to create. They
+// only make the node sample a header again, when peers disagree with its result.
+type attestations struct {
+	pubsub  *attestsub.PubSub
+	tracker *attestsub.Tracker
+	self    peer.ID
+}
+
+func (a *attestations) init() {
+	a.tracker = attestsub.NewTracker(attestationWindow)
+	a.self = a.pubsub.ID()
+}
+
+// publish attests the result of sampling the header to peers. Errors other than unavailability of
+// data, e.g. timeouts, are not attested, as they don't conclude anything about the header.
+func (a *attestations) publish(ctx context.Context, h *header.ExtendedHeader, sampleErr error) {
+	if sampleErr != nil && !errors.Is(sampleErr, share.ErrNotAvailable) {
+		return
+	}
+
+	att, err := a.pubsub.Publish(ctx, attestsub.Attestation{
+		Height:    uint64(h.Height()),
+		DataHash:  h.DAH.Hash(),
+		Available: sampleErr == nil,
+	})
+	if err != nil {
+		log.Warnw("publishing sampling attestation", "height", h.Height(), "err", err)
+		return
+	}
+	a.tracker.Add(att)
+}
+
+// runAttestations tracks attestations of peers and samples headers again, when peers disagree with
+// the node about their availability.
+func (d *DASer) runAttestations(ctx context.Context, sub *attestsub.Subscription) {
+	defer sub.Cancel()
+
+	for {
+		att, err := sub.Next(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Errorw("receiving sampling attestation", "err", err)
+			}
+			return
+		}
+		if att.Peer == d.attest.self {
+			continue
+		}
+
+		// do not let peers evict attestations by heights from the future
+		head, err := d.getter.Head(ctx)
+		if err != nil || att.Height > uint64(head.Height()) {
+			continue
+		}
+		if !d.attest.tracker.Add(att) || att.Available {
+			continue
+		}
+
+		own, ok := d.attest.tracker.Get(att.Height, d.attest.self)
+		if !ok || !own.Available || !bytes.Equal(own.DataHash, att.DataHash) {
+			continue
+		}
+		// sample the suspicious header once, when the first peer disagrees
+		if _, disagreed := d.attest.tracker.Tally(att.Height, att.DataHash, d.attest.self); disagreed == 1 {
+			go d.resample(ctx, att.Height, att.Peer)
+		}
+	}
+}
+
+// resample samples the header again with new random samples, bypassing the cached result. The
+// cached result is kept, as a claim of a peer is not a proof of unavailability.
+func (d *DASer) resample(ctx context.Context, height uint64, suspectedBy peer.ID) {
+	log.Infow("sampling header again, as peer attested it unavailable", "height", height, "peer", suspectedBy)
+
+	ctx, cancel := context.WithTimeout(ctx, d.params.SampleTimeout)
+	defer cancel()
+
+	h, err := d.getter.GetByHeight(ctx, height)
+	if err != nil {
+		log.Errorw("getting header to sample again", "height", height, "err", err)
+		return
+	}
+	if err = d.sample(share.WithRecheck(ctx), h); err != nil {
+		log.Errorw("sampling header again", "height", height, "err", err)
+	}
+}
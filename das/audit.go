@@ -0,0 +1,126 @@
+package das
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// SampleRecord is an entry of the sampling audit log, describing a single attempt to sample a
+// header.
+type SampleRecord struct {
+	Height uint64 `json:"height"`
+	// Time is the time the attempt started at.
+	Time    time.Time     `json:"time"`
+	Latency time.Duration `json:"latency"`
+	// Error is empty if the header was sampled successfully.
+	Error string `json:"error,omitempty"`
+	// Samples lists shares sampled during the attempt. It is empty if availability was validated
+	// without sampling single shares, e.g. by retrieving the whole EDS or from cache.
+	Samples []ShareSample `json:"samples,omitempty"`
+}
+
+// ShareSample describes a single share sampled during a sampling attempt.
+type ShareSample struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+	// Source is the name of the getter the share was retrieved by. It is empty if unknown.
+	Source  string        `json:"source,omitempty"`
+	Latency time.Duration `json:"latency"`
+	// Error is empty if the share was retrieved successfully.
+	Error string `json:"error,omitempty"`
+}
+
+// auditLog keeps the latest sampling attempts in a ring buffer.
+type auditLog struct {
+	lk      sync.Mutex
+	records []SampleRecord
+	// next is the position the next record is written to
+	next int
+	full bool
+}
+
+// newAuditLog creates an auditLog keeping up to size records. Zero size disables the log.
+func newAuditLog(size int) *auditLog {
+	return &auditLog{records: make([]SampleRecord, size)}
+}
+
+// sample runs sampleFn, recording the attempt with all sampled shares to the log.
+func (l *auditLog) sample(ctx context.Context, height uint64, sampleFn func(context.Context) error) error {
+	if len(l.records) == 0 {
+		return sampleFn(ctx)
+	}
+
+	var (
+		samplesLk sync.Mutex
+		samples   []ShareSample
+	)
+	ctx = share.WithSampleObserver(ctx, func(res share.SampleResult) {
+		s := ShareSample{
+			Row:     res.Row,
+			Col:     res.Col,
+			Source:  res.Source,
+			Latency: res.Latency,
+		}
+		if res.Err != nil {
+			s.Error = res.Err.Error()
+		}
+
+		samplesLk.Lock()
+		defer samplesLk.Unlock()
+		samples = append(samples, s)
+	})
+
+	start := time.Now()
+	err := sampleFn(ctx)
+	rec := SampleRecord{
+		Height:  height,
+		Time:    start,
+		Latency: time.Since(start),
+	}
+	if err != nil {
+		rec.Error = err.Error()
+	}
+
+	samplesLk.Lock()
+	// copy samples, as shares sampled after cancellation may still be reported
+	rec.Samples = append([]ShareSample(nil), samples...)
+	samplesLk.Unlock()
+
+	l.add(rec)
+	return err
+}
+
+func (l *auditLog) add(rec SampleRecord) {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+
+	l.records[l.next] = rec
+	l.next = (l.next + 1) % len(l.records)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// get returns the recorded attempts for heights in the given range [from; to], oldest first.
+// Zero to means no upper bound.
+func (l *auditLog) get(from, to uint64) []SampleRecord {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+
+	ordered := l.records[:l.next]
+	if l.full {
+		ordered = append(append([]SampleRecord(nil), l.records[l.next:]...), ordered...)
+	}
+
+	out := make([]SampleRecord, 0)
+	for _, rec := range ordered {
+		if rec.Height < from || (to != 0 && rec.Height > to) {
+			continue
+		}
+		out = append(out, rec)
+	}
+	return out
+}
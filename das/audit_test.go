@@ -0,0 +1,55 @@
+package das
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestAuditLog(t *testing.T) {
+	ctx := context.Background()
+	l := newAuditLog(3)
+
+	errSample := errors.New("sampling failed")
+	for h := uint64(1); h <= 4; h++ {
+		h := h
+		err := l.sample(ctx, h, func(ctx context.Context) error {
+			share.ObserveSample(ctx, share.SampleResult{Row: int(h), Col: 1, Source: "ipld"})
+			if h == 3 {
+				return errSample
+			}
+			return nil
+		})
+		if h == 3 {
+			require.ErrorIs(t, err, errSample)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+
+	// the oldest record is overwritten
+	records := l.get(0, 0)
+	require.Len(t, records, 3)
+	for i, rec := range records {
+		assert.EqualValues(t, i+2, rec.Height)
+		require.Len(t, rec.Samples, 1)
+		assert.Equal(t, int(rec.Height), rec.Samples[0].Row)
+		assert.Equal(t, "ipld", rec.Samples[0].Source)
+	}
+	assert.Equal(t, errSample.Error(), records[1].Error)
+	assert.Empty(t, records[0].Error)
+
+	records = l.get(3, 3)
+	require.Len(t, records, 1)
+	assert.EqualValues(t, 3, records[0].Height)
+
+	// disabled log keeps nothing
+	l = newAuditLog(0)
+	require.NoError(t, l.sample(ctx, 1, func(context.Context) error { return nil }))
+	assert.Empty(t, l.get(0, 0))
+}
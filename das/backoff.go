@@ -1,6 +1,7 @@
 package das
 
 import (
+	"math/rand"
 	"time"
 )
 
@@ -12,12 +13,17 @@ var (
 	// after defaultBackoffMaxRetryCount amount of attempts retry backoff interval will stop growing
 	// and each retry attempt will produce WARN log
 	defaultBackoffMaxRetryCount = 4
+	// retry delays are extended by a random fraction of up to defaultBackoffJitter, so that heights
+	// failed together are not retried all at once
+	defaultBackoffJitter = 0.2
 )
 
 // retryStrategy defines a backoff for retries.
 type retryStrategy struct {
 	// attempts delays will follow durations stored in retryIntervals
 	retryIntervals []time.Duration
+	// jitter is the maximum fraction of the delay added to it at random
+	jitter float64
 }
 
 // newRetryStrategy creates and initializes a new retry backoff.
@@ -38,14 +44,23 @@ func (s retryStrategy) nextRetry(lastRetry retryAttempt, lastAttempt time.Time,
 
 	if lastRetry.count > len(s.retryIntervals) {
 		// try count exceeded backoff try limit
-		lastRetry.after = lastAttempt.Add(s.retryIntervals[len(s.retryIntervals)-1])
+		lastRetry.after = lastAttempt.Add(s.withJitter(s.retryIntervals[len(s.retryIntervals)-1]))
 		return lastRetry, true
 	}
 
-	lastRetry.after = lastAttempt.Add(s.retryIntervals[lastRetry.count-1])
+	lastRetry.after = lastAttempt.Add(s.withJitter(s.retryIntervals[lastRetry.count-1]))
 	return lastRetry, false
 }
 
+// withJitter extends the delay by a random fraction of it up to the jitter of the strategy.
+func (s retryStrategy) withJitter(delay time.Duration) time.Duration {
+	if s.jitter <= 0 {
+		return delay
+	}
+	//nolint:gosec // jitter doesn't need to be cryptographically secure
+	return delay + time.Duration(rand.Float64()*s.jitter*float64(delay))
+}
+
 // exponentialBackoff generates an array of time.Duration values using an exponential growth
 // multiplier.
 func exponentialBackoff(baseInterval time.Duration, multiplier, amount int) []time.Duration {
@@ -106,3 +106,16 @@ func Test_retryStrategy_nextRetry(t *testing.T) {
 		})
 	}
 }
+
+func Test_retryStrategy_jitter(t *testing.T) {
+	tNow := time.Now()
+	s := retryStrategy{
+		retryIntervals: []time.Duration{time.Minute},
+		jitter:         0.5,
+	}
+	for i := 0; i < 100; i++ {
+		retry, _ := s.nextRetry(retryAttempt{}, tNow)
+		assert.False(t, retry.after.Before(tNow.Add(time.Minute)))
+		assert.False(t, retry.after.After(tNow.Add(time.Minute*3/2)))
+	}
+}
@@ -2,6 +2,7 @@ package das
 
 import (
 	"fmt"
+	"time"
 )
 
 type checkpoint struct {
@@ -9,6 +10,9 @@ type checkpoint struct {
 	NetworkHead uint64 `json:"network_head"`
 	// Failed heights will be retried
 	Failed map[uint64]int `json:"failed,omitempty"`
+	// RetryAfter keeps the time failed heights are due to be retried, so that backoff survives
+	// restarts
+	RetryAfter map[uint64]time.Time `json:"retry_after,omitempty"`
 	// Workers will resume on restart from previous state
 	Workers []workerCheckpoint `json:"workers,omitempty"`
 }
@@ -41,6 +45,33 @@ func newCheckpoint(stats SamplingStats) checkpoint {
 	}
 }
 
+// skipBelow drops all sampling progress below the given height, so that it is not sampled.
+func (c checkpoint) skipBelow(height uint64) checkpoint {
+	if c.SampleFrom < height {
+		c.SampleFrom = height
+	}
+
+	for h := range c.Failed {
+		if h < height {
+			delete(c.Failed, h)
+			delete(c.RetryAfter, h)
+		}
+	}
+
+	var workers []workerCheckpoint
+	for _, w := range c.Workers {
+		if w.To < height {
+			continue
+		}
+		if w.From < height {
+			w.From = height
+		}
+		workers = append(workers, w)
+	}
+	c.Workers = workers
+	return c
+}
+
 func (c checkpoint) String() string {
 	str := fmt.Sprintf("SampleFrom: %v, NetworkHead: %v", c.SampleFrom, c.NetworkHead)
 
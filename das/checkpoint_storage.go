@@ -0,0 +1,112 @@
+package das
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+)
+
+var storePrefix = datastore.NewKey("das")
+
+// ErrCheckpointNotFound is returned by CheckpointStorage when there is no value stored under a key.
+var ErrCheckpointNotFound = errors.New("das: checkpoint not found")
+
+// CheckpointStorage is a backend the DASer checkpoint is persisted in, e.g. the node datastore, a
+// local file or a remote key-value store.
+//
+// Put must be atomic, so that an interrupted write never leaves a partially written value behind.
+type CheckpointStorage interface {
+	// Get returns the value stored under the given key or ErrCheckpointNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Put stores the value under the given key, replacing the previous one.
+	Put(ctx context.Context, key string, value []byte) error
+}
+
+// datastoreCheckpointStorage keeps the checkpoint in a datastore under the `das` prefix.
+type datastoreCheckpointStorage struct {
+	ds datastore.Datastore
+}
+
+// NewDatastoreCheckpointStorage creates a CheckpointStorage over the given datastore.Datastore,
+// which may be backed by a remote key-value store.
+func NewDatastoreCheckpointStorage(ds datastore.Datastore) CheckpointStorage {
+	return &datastoreCheckpointStorage{ds: namespace.Wrap(ds, storePrefix)}
+}
+
+func (s *datastoreCheckpointStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	bs, err := s.ds.Get(ctx, datastore.NewKey(key))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, ErrCheckpointNotFound
+	}
+	return bs, err
+}
+
+func (s *datastoreCheckpointStorage) Put(ctx context.Context, key string, value []byte) error {
+	return s.ds.Put(ctx, datastore.NewKey(key), value)
+}
+
+// fileCheckpointStorage keeps every key in a separate file of a directory.
+type fileCheckpointStorage struct {
+	dir string
+}
+
+// NewFileCheckpointStorage creates a CheckpointStorage keeping the checkpoint in files of the given
+// directory. Files are replaced atomically by writing a temporary file first and renaming it.
+func NewFileCheckpointStorage(dir string) (CheckpointStorage, error) {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("das: creating checkpoint directory: %w", err)
+	}
+	return &fileCheckpointStorage{dir: dir}, nil
+}
+
+func (s *fileCheckpointStorage) Get(_ context.Context, key string) ([]byte, error) {
+	bs, err := os.ReadFile(filepath.Join(s.dir, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrCheckpointNotFound
+	}
+	return bs, err
+}
+
+func (s *fileCheckpointStorage) Put(_ context.Context, key string, value []byte) (err error) {
+	f, err := os.CreateTemp(s.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	if _, err = f.Write(value); err != nil {
+		return err
+	}
+	// the data has to reach the disk before the rename, otherwise a crash may leave an empty file
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(f.Name(), filepath.Join(s.dir, key)); err != nil {
+		return err
+	}
+	return syncDir(s.dir)
+}
+
+// syncDir persists renames of files in the directory.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -2,6 +2,9 @@ package das
 
 import (
 	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -12,11 +15,80 @@ import (
 )
 
 func TestCheckpointStore(t *testing.T) {
-	ds := newCheckpointStore(sync.MutexWrap(datastore.NewMapDatastore()))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer t.Cleanup(cancel)
+
+	fileStorage, err := NewFileCheckpointStorage(t.TempDir())
+	require.NoError(t, err)
+	storages := map[string]CheckpointStorage{
+		"datastore": NewDatastoreCheckpointStorage(sync.MutexWrap(datastore.NewMapDatastore())),
+		"file":      fileStorage,
+	}
+	for name, storage := range storages {
+		t.Run(name, func(t *testing.T) {
+			ds := newCheckpointStore(storage)
+			_, err := ds.load(ctx)
+			require.ErrorIs(t, err, ErrCheckpointNotFound)
+
+			cp := testCheckpoint()
+			assert.NoError(t, ds.store(ctx, cp))
+			got, err := ds.load(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, cp, got)
+		})
+	}
+}
+
+func TestCheckpointStore_Recovery(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	storage, err := NewFileCheckpointStorage(dir)
+	require.NoError(t, err)
+	ds := newCheckpointStore(storage)
+
+	cp := testCheckpoint()
+	require.NoError(t, ds.store(ctx, cp))
+
+	// corrupt the latest checkpoint, so it is recovered from the backup
+	path := filepath.Join(dir, checkpointKey)
+	bs, err := os.ReadFile(path)
+	require.NoError(t, err)
+	bs[len(bs)/2] ^= 0xFF
+	require.NoError(t, os.WriteFile(path, bs, 0600))
+
+	got, err := ds.load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, cp, got)
+
+	// truncate the backup as well, so there is nothing to recover from
+	require.NoError(t, os.WriteFile(filepath.Join(dir, backupCheckpointKey), bs[:len(bs)/2], 0600))
+	_, err = ds.load(ctx)
+	require.ErrorIs(t, err, errCheckpointCorrupted)
+}
+
+func TestCheckpointStore_LegacyFormat(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer t.Cleanup(cancel)
+
+	storage := NewDatastoreCheckpointStorage(sync.MutexWrap(datastore.NewMapDatastore()))
+	cp := testCheckpoint()
+	bs, err := json.Marshal(cp)
+	require.NoError(t, err)
+	require.NoError(t, storage.Put(ctx, checkpointKey, bs))
+
+	ds := newCheckpointStore(storage)
+	got, err := ds.load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, cp, got)
+}
+
+func testCheckpoint() checkpoint {
 	failed := make(map[uint64]int)
 	failed[2] = 1
 	failed[3] = 2
-	cp := checkpoint{
+	return checkpoint{
 		SampleFrom:  1,
 		NetworkHead: 6,
 		Failed:      failed,
@@ -33,10 +105,29 @@ func TestCheckpointStore(t *testing.T) {
 			},
 		},
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
-	defer t.Cleanup(cancel)
-	assert.NoError(t, ds.store(ctx, cp))
-	got, err := ds.load(ctx)
-	require.NoError(t, err)
-	assert.Equal(t, cp, got)
+}
+
+func TestCheckpoint_SkipBelow(t *testing.T) {
+	cp := checkpoint{
+		SampleFrom:  50,
+		NetworkHead: 200,
+		Failed:      map[uint64]int{5: 1, 30: 2, 120: 1},
+		RetryAfter:  map[uint64]time.Time{5: time.Now(), 120: time.Now()},
+		Workers: []workerCheckpoint{
+			{From: 1, To: 10, JobType: catchupJob},
+			{From: 20, To: 40, JobType: catchupJob},
+		},
+	}
+
+	got := cp.skipBelow(25)
+	assert.EqualValues(t, 50, got.SampleFrom)
+	assert.EqualValues(t, 200, got.NetworkHead)
+	assert.Equal(t, map[uint64]int{30: 2, 120: 1}, got.Failed)
+	assert.Len(t, got.RetryAfter, 1)
+	assert.Equal(t, []workerCheckpoint{{From: 25, To: 40, JobType: catchupJob}}, got.Workers)
+
+	got = cp.skipBelow(100)
+	assert.EqualValues(t, 100, got.SampleFrom)
+	assert.Equal(t, map[uint64]int{120: 1}, got.Failed)
+	assert.Empty(t, got.Workers)
 }
@@ -0,0 +1,131 @@
+package das
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+const (
+	// concurrencyAdjustInterval is the period the concurrency limit is adjusted with.
+	concurrencyAdjustInterval = 10 * time.Second
+	// minSuccessRate is the rate of successful samples below which concurrency is decreased.
+	minSuccessRate = 0.9
+	// workersPerPeer limits the concurrency to the amount of workers the peer pool can serve.
+	workersPerPeer = 4
+	// defaultMinConcurrencyLimit is the minimum concurrency limit, unless configured otherwise.
+	defaultMinConcurrencyLimit = 2
+)
+
+// concurrencyController adapts the amount of parallel sampling workers between the minimum and
+// maximum concurrency limits. Every adjustment, the limit is halved if too many samples failed or
+// took longer than the target latency, and is increased by one otherwise. The limit is also capped
+// by the size of the peer pool, so that small pools are not overwhelmed.
+//
+// Samples are observed by workers, while the limit is only read and adjusted by the coordinator.
+type concurrencyController struct {
+	min, max      int
+	limit         int
+	targetLatency time.Duration
+	// peerCount returns the size of the peer pool. Concurrency is not capped by peers if nil.
+	peerCount func() int
+
+	lk       sync.Mutex
+	samples  int
+	failures int
+	latency  time.Duration
+}
+
+func newConcurrencyController(params Parameters, peerCount func() int) *concurrencyController {
+	minLimit := params.MinConcurrencyLimit
+	if minLimit <= 0 {
+		minLimit = defaultMinConcurrencyLimit
+	}
+	if minLimit > params.ConcurrencyLimit {
+		minLimit = params.ConcurrencyLimit
+	}
+	c := &concurrencyController{
+		min:           minLimit,
+		max:           params.ConcurrencyLimit,
+		limit:         params.ConcurrencyLimit,
+		targetLatency: params.TargetSampleLatency,
+		peerCount:     peerCount,
+	}
+	c.limit = c.ceiling()
+	return c
+}
+
+// observe wraps the sampleFn, so that every sample is observed by the controller.
+func (c *concurrencyController) observe(sample sampleFn) sampleFn {
+	return func(ctx context.Context, h *header.ExtendedHeader) error {
+		start := time.Now()
+		err := sample(ctx, h)
+		if errors.Is(err, context.Canceled) {
+			// canceled samples say nothing about the network
+			return err
+		}
+
+		c.lk.Lock()
+		defer c.lk.Unlock()
+		c.samples++
+		c.latency += time.Since(start)
+		if err != nil {
+			c.failures++
+		}
+		return err
+	}
+}
+
+// adjust updates the limit based on the samples observed since the previous adjustment.
+func (c *concurrencyController) adjust() {
+	c.lk.Lock()
+	samples, failures, latency := c.samples, c.failures, c.latency
+	c.samples, c.failures, c.latency = 0, 0, 0
+	c.lk.Unlock()
+
+	prev := c.limit
+	if samples > 0 {
+		successRate := float64(samples-failures) / float64(samples)
+		avgLatency := latency / time.Duration(samples)
+		switch {
+		case successRate < minSuccessRate || (c.targetLatency > 0 && avgLatency > c.targetLatency):
+			c.limit /= 2
+		default:
+			c.limit++
+		}
+	}
+
+	if ceiling := c.ceiling(); c.limit > ceiling {
+		c.limit = ceiling
+	}
+	if c.limit < c.min {
+		c.limit = c.min
+	}
+
+	if c.limit != prev {
+		log.Debugw("adjusted sampling concurrency",
+			"from", prev,
+			"to", c.limit,
+			"samples", samples,
+			"failures", failures,
+		)
+	}
+}
+
+// ceiling returns the maximum limit the peer pool allows for.
+func (c *concurrencyController) ceiling() int {
+	if c.peerCount == nil {
+		return c.max
+	}
+	ceiling := c.peerCount() * workersPerPeer
+	if ceiling > c.max {
+		return c.max
+	}
+	if ceiling < c.min {
+		return c.min
+	}
+	return ceiling
+}
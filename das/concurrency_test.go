@@ -0,0 +1,99 @@
+package das
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+func TestConcurrencyController(t *testing.T) {
+	params := DefaultParameters()
+	params.ConcurrencyLimit = 8
+	params.MinConcurrencyLimit = 2
+	params.TargetSampleLatency = time.Millisecond * 50
+
+	var (
+		peers   = 100
+		latency time.Duration
+		err     error
+	)
+	c := newConcurrencyController(params, func() int { return peers })
+	sample := c.observe(func(context.Context, *header.ExtendedHeader) error {
+		time.Sleep(latency)
+		return err
+	})
+	observe := func(n int) {
+		for i := 0; i < n; i++ {
+			_ = sample(context.Background(), nil)
+		}
+	}
+	assert.Equal(t, 8, c.limit)
+
+	// failures halve the limit
+	err = errors.New("failed")
+	observe(10)
+	c.adjust()
+	assert.Equal(t, 4, c.limit)
+
+	// successful samples increase it by one
+	err = nil
+	observe(10)
+	c.adjust()
+	assert.Equal(t, 5, c.limit)
+
+	// slow samples decrease it down to the minimum
+	latency = params.TargetSampleLatency * 2
+	for i := 0; i < 3; i++ {
+		observe(1)
+		c.adjust()
+	}
+	assert.Equal(t, 2, c.limit)
+
+	// canceled samples are ignored
+	latency, err = 0, context.Canceled
+	observe(10)
+	c.adjust()
+	assert.Equal(t, 2, c.limit)
+
+	// the limit is capped by the peer pool
+	err = nil
+	peers = 1
+	for i := 0; i < 10; i++ {
+		observe(1)
+		c.adjust()
+	}
+	assert.Equal(t, workersPerPeer, c.limit)
+
+	// but never by more than the maximum
+	peers = 100
+	for i := 0; i < 10; i++ {
+		observe(1)
+		c.adjust()
+	}
+	assert.Equal(t, 8, c.limit)
+}
+
+// TestConcurrencyController_DefaultMin ensures configs predating MinConcurrencyLimit still load and
+// get the default minimum.
+func TestConcurrencyController_DefaultMin(t *testing.T) {
+	params := DefaultParameters()
+	params.MinConcurrencyLimit = 0
+	assert.NoError(t, params.Validate())
+
+	c := newConcurrencyController(params, func() int { return 0 })
+	assert.Equal(t, defaultMinConcurrencyLimit, c.min)
+	assert.Equal(t, defaultMinConcurrencyLimit, c.limit)
+
+	// the default never exceeds the maximum
+	params.ConcurrencyLimit = 1
+	c = newConcurrencyController(params, nil)
+	assert.Equal(t, 1, c.min)
+
+	params.MinConcurrencyLimit = -1
+	assert.Error(t, params.Validate())
+}
@@ -0,0 +1,55 @@
+package das
+
+import (
+	"sync"
+)
+
+// recentConfidenceSize is the amount of the latest sampled heights effective confidence is kept for.
+const recentConfidenceSize = 100
+
+// confidenceLog keeps the effective confidence of availability reached for the latest sampled
+// heights.
+type confidenceLog struct {
+	lk         sync.Mutex
+	confidence map[uint64]float64
+	// heights is a ring buffer of heights in the order they were recorded in
+	heights []uint64
+	next    int
+}
+
+func newConfidenceLog(size int) *confidenceLog {
+	return &confidenceLog{
+		confidence: make(map[uint64]float64, size),
+		heights:    make([]uint64, 0, size),
+	}
+}
+
+func (l *confidenceLog) add(height uint64, confidence float64) {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+
+	if _, ok := l.confidence[height]; ok {
+		l.confidence[height] = confidence
+		return
+	}
+
+	if len(l.heights) < cap(l.heights) {
+		l.heights = append(l.heights, height)
+	} else {
+		delete(l.confidence, l.heights[l.next])
+		l.heights[l.next] = height
+		l.next = (l.next + 1) % len(l.heights)
+	}
+	l.confidence[height] = confidence
+}
+
+func (l *confidenceLog) get() map[uint64]float64 {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+
+	out := make(map[uint64]float64, len(l.confidence))
+	for h, c := range l.confidence {
+		out[h] = c
+	}
+	return out
+}
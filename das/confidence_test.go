@@ -0,0 +1,20 @@
+package das
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfidenceLog(t *testing.T) {
+	l := newConfidenceLog(2)
+	l.add(1, 0.9)
+	l.add(2, 0.99)
+	l.add(2, 0.999)
+	assert.Equal(t, map[uint64]float64{1: 0.9, 2: 0.999}, l.get())
+
+	// the oldest height is evicted
+	l.add(3, 0.9999)
+	l.add(4, 0.99999)
+	assert.Equal(t, map[uint64]float64{3: 0.9999, 4: 0.99999}, l.get())
+}
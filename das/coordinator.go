@@ -8,19 +8,30 @@ import (
 	libhead "github.com/celestiaorg/go-header"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/budget"
+	"github.com/celestiaorg/celestia-node/libs/watchdog"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexsub"
 )
 
+// retryConcurrencyShare is the inverse share of workers retry jobs may take before catchup jobs.
+const retryConcurrencyShare = 4
+
 // samplingCoordinator runs and coordinates sampling workers and updates current sampling state
 type samplingCoordinator struct {
-	concurrencyLimit int
-	samplingTimeout  time.Duration
+	concurrency     *concurrencyController
+	samplingTimeout time.Duration
 
 	getter      libhead.Getter[*header.ExtendedHeader]
 	sampleFn    sampleFn
 	broadcastFn shrexsub.BroadcastFn
+	// budget is nil unless sampling shares the retrieval budget with user requests
+	budget *budget.Budget
+	// heartbeats is nil unless the coordinator is watched for getting stuck
+	heartbeats *watchdog.Registry
 
 	state coordinatorState
+	// catchupWorkers keeps catchup workers in progress, which may be preempted by recent jobs
+	catchupWorkers map[int]*worker
 
 	// resultCh fans-in sampling results from worker to coordinator
 	resultCh chan result
@@ -39,6 +50,9 @@ type result struct {
 	job
 	failed map[uint64]int
 	err    error
+	// resumeFrom is the first height left unsampled by a preempted job. It is zero for jobs that
+	// were not preempted.
+	resumeFrom uint64
 }
 
 func newSamplingCoordinator(
@@ -46,24 +60,35 @@ func newSamplingCoordinator(
 	getter libhead.Getter[*header.ExtendedHeader],
 	sample sampleFn,
 	broadcast shrexsub.BroadcastFn,
+	peerCount func() int,
+	budget *budget.Budget,
 ) *samplingCoordinator {
+	concurrency := newConcurrencyController(params, peerCount)
 	return &samplingCoordinator{
-		concurrencyLimit: params.ConcurrencyLimit,
-		samplingTimeout:  params.SampleTimeout,
-		getter:           getter,
-		sampleFn:         sample,
-		broadcastFn:      broadcast,
-		state:            newCoordinatorState(params),
-		resultCh:         make(chan result),
-		updHeadCh:        make(chan *header.ExtendedHeader),
-		waitCh:           make(chan *sync.WaitGroup),
-		done:             newDone("sampling coordinator"),
+		concurrency:     concurrency,
+		samplingTimeout: params.SampleTimeout,
+		getter:          getter,
+		sampleFn:        concurrency.observe(sample),
+		broadcastFn:     broadcast,
+		budget:          budget,
+		state:           newCoordinatorState(params),
+		catchupWorkers:  make(map[int]*worker),
+		resultCh:        make(chan result),
+		updHeadCh:       make(chan *header.ExtendedHeader),
+		waitCh:          make(chan *sync.WaitGroup),
+		done:            newDone("sampling coordinator"),
 	}
 }
 
 func (sc *samplingCoordinator) run(ctx context.Context, cp checkpoint) {
 	sc.state.resumeFromCheckpoint(cp)
 
+	adjustTicker := time.NewTicker(concurrencyAdjustInterval)
+	defer adjustTicker.Stop()
+
+	hb := sc.heartbeats.Register("das", "das/sampling-coordinator")
+	defer hb.Stop()
+
 	// resume workers
 	for _, wk := range cp.Workers {
 		sc.runWorker(ctx, sc.state.newJob(wk.JobType, wk.From, wk.To))
@@ -71,7 +96,7 @@ func (sc *samplingCoordinator) run(ctx context.Context, cp checkpoint) {
 
 	for {
 		for !sc.concurrencyLimitReached() {
-			next, found := sc.state.nextJob()
+			next, found := sc.state.nextJob(sc.retryLimit())
 			if !found {
 				break
 			}
@@ -81,17 +106,19 @@ func (sc *samplingCoordinator) run(ctx context.Context, cp checkpoint) {
 		select {
 		case head := <-sc.updHeadCh:
 			if sc.state.isNewHead(head.Height()) {
-				if !sc.recentJobsLimitReached() {
-					sc.runWorker(ctx, sc.state.recentJob(head))
-				}
+				sc.runRecent(ctx, head)
 				sc.state.updateHead(head.Height())
-				// run worker without concurrency limit restrictions to reduced delay
 				sc.metrics.observeNewHead(ctx)
 			}
 		case res := <-sc.resultCh:
+			delete(sc.catchupWorkers, res.id)
 			sc.state.handleResult(res)
 		case wg := <-sc.waitCh:
 			wg.Wait()
+		case <-adjustTicker.C:
+			sc.concurrency.adjust()
+		case <-hb.Tick():
+			hb.Beat()
 		case <-ctx.Done():
 			sc.workersWg.Wait()
 			sc.indicateDone()
@@ -100,10 +127,47 @@ func (sc *samplingCoordinator) run(ctx context.Context, cp checkpoint) {
 	}
 }
 
+// runRecent runs a job for the recent header right away to reduce the delay of its sampling. If the
+// concurrency limit is reached, a catchup worker is preempted to give its slot to the recent job.
+// Recent jobs exceeding twice the limit wait for a free worker in the queue.
+func (sc *samplingCoordinator) runRecent(ctx context.Context, h *header.ExtendedHeader) {
+	if sc.concurrencyLimitReached() {
+		sc.preemptCatchup()
+	}
+	if sc.recentJobsLimitReached() {
+		sc.state.queueRecent(h)
+		return
+	}
+	sc.runWorker(ctx, sc.state.recentJob(h))
+}
+
+// preemptCatchup preempts the catchup worker sampling the highest headers, so that sampling of
+// lower headers is not delayed.
+func (sc *samplingCoordinator) preemptCatchup() {
+	var (
+		target *worker
+		from   uint64
+	)
+	for _, w := range sc.catchupWorkers {
+		if w.preempted.Load() {
+			continue
+		}
+		if state := w.getState(); target == nil || state.from > from {
+			target, from = w, state.from
+		}
+	}
+	if target != nil && target.preempt() {
+		log.Debugw("preempting catchup worker", "from", from)
+	}
+}
+
 // runWorker runs job in separate worker go-routine
 func (sc *samplingCoordinator) runWorker(ctx context.Context, j job) {
-	w := newWorker(j, sc.getter, sc.sampleFn, sc.broadcastFn, sc.metrics)
+	w := newWorker(j, sc.getter, sc.sampleFn, sc.broadcastFn, sc.budget, sc.metrics)
 	sc.state.putInProgress(j.id, w.getState)
+	if j.jobType == catchupJob {
+		sc.catchupWorkers[j.id] = &w
+	}
 
 	// launch worker go-routine
 	sc.workersWg.Add(1)
@@ -113,6 +177,13 @@ func (sc *samplingCoordinator) runWorker(ctx context.Context, j job) {
 	}()
 }
 
+// reset resets the state of the coordinator to run it again, once it exited.
+func (sc *samplingCoordinator) reset(params Parameters) {
+	sc.state = newCoordinatorState(params)
+	sc.catchupWorkers = make(map[int]*worker)
+	sc.done = newDone("sampling coordinator")
+}
+
 // listen notifies the coordinator about a new network head received via subscription.
 func (sc *samplingCoordinator) listen(ctx context.Context, h *header.ExtendedHeader) {
 	select {
@@ -133,23 +204,40 @@ func (sc *samplingCoordinator) stats(ctx context.Context) (SamplingStats, error)
 		return SamplingStats{}, ctx.Err()
 	}
 
-	return sc.state.unsafeStats(), nil
+	stats := sc.state.unsafeStats()
+	stats.ConcurrencyLimit = sc.concurrency.limit
+	return stats, nil
 }
 
 func (sc *samplingCoordinator) getCheckpoint(ctx context.Context) (checkpoint, error) {
-	stats, err := sc.stats(ctx)
-	if err != nil {
-		return checkpoint{}, err
+	var wg sync.WaitGroup
+	wg.Add(1)
+	defer wg.Done()
+
+	select {
+	case sc.waitCh <- &wg:
+	case <-ctx.Done():
+		return checkpoint{}, ctx.Err()
 	}
-	return newCheckpoint(stats), nil
+
+	return sc.state.unsafeCheckpoint(), nil
 }
 
-// concurrencyLimitReached indicates whether concurrencyLimit has been reached
+// concurrencyLimitReached indicates whether the current concurrency limit has been reached
 func (sc *samplingCoordinator) concurrencyLimitReached() bool {
-	return len(sc.state.inProgress) >= sc.concurrencyLimit
+	return len(sc.state.inProgress) >= sc.concurrency.limit
+}
+
+// retryLimit returns the amount of retry jobs that may run before catchup jobs
+func (sc *samplingCoordinator) retryLimit() int {
+	limit := sc.concurrency.limit / retryConcurrencyShare
+	if limit < 1 {
+		return 1
+	}
+	return limit
 }
 
 // recentJobsLimitReached indicates whether concurrency limit for recent jobs has been reached
 func (sc *samplingCoordinator) recentJobsLimitReached() bool {
-	return len(sc.state.inProgress) >= 2*sc.concurrencyLimit
+	return len(sc.state.inProgress) >= 2*sc.concurrency.limit
 }
@@ -22,7 +22,7 @@ func TestCoordinator(t *testing.T) {
 
 		ctx, cancel := context.WithTimeout(context.Background(), testParams.timeoutDelay)
 		sampler := newMockSampler(testParams.sampleFrom, testParams.networkHead)
-		coordinator := newSamplingCoordinator(testParams.dasParams, getterStub{}, onceMiddleWare(sampler.sample), nil)
+		coordinator := newSamplingCoordinator(testParams.dasParams, getterStub{}, onceMiddleWare(sampler.sample), nil, nil, nil)
 
 		go coordinator.run(ctx, sampler.checkpoint)
 
@@ -31,7 +31,7 @@ func TestCoordinator(t *testing.T) {
 
 		// wait for coordinator to indicateDone catchup
 		assert.NoError(t, coordinator.state.waitCatchUp(ctx))
-		assert.Emptyf(t, coordinator.state.failed, "failed list should be empty")
+		assert.Zerof(t, coordinator.state.failed.len(), "failed list should be empty")
 
 		cancel()
 		stopCtx, cancel := context.WithTimeout(context.Background(), testParams.timeoutDelay)
@@ -48,7 +48,7 @@ func TestCoordinator(t *testing.T) {
 		sampler := newMockSampler(testParams.sampleFrom, testParams.networkHead)
 
 		newhead := testParams.networkHead + 200
-		coordinator := newSamplingCoordinator(testParams.dasParams, getterStub{}, sampler.sample, newBroadcastMock(1))
+		coordinator := newSamplingCoordinator(testParams.dasParams, getterStub{}, sampler.sample, newBroadcastMock(1), nil, nil)
 		go coordinator.run(ctx, sampler.checkpoint)
 
 		// discover new height
@@ -59,7 +59,7 @@ func TestCoordinator(t *testing.T) {
 
 		// wait for coordinator to indicateDone catchup
 		assert.NoError(t, coordinator.state.waitCatchUp(ctx))
-		assert.Emptyf(t, coordinator.state.failed, "failed list should be empty")
+		assert.Zerof(t, coordinator.state.failed.len(), "failed list should be empty")
 
 		cancel()
 		stopCtx, cancel := context.WithTimeout(context.Background(), testParams.timeoutDelay)
@@ -99,6 +99,8 @@ func TestCoordinator(t *testing.T) {
 				order.middleWare(sampler.sample),
 			),
 			newBroadcastMock(1),
+			nil,
+			nil,
 		)
 		go coordinator.run(ctx, sampler.checkpoint)
 
@@ -123,7 +125,84 @@ func TestCoordinator(t *testing.T) {
 
 		// wait for coordinator to indicateDone catchup
 		assert.NoError(t, coordinator.state.waitCatchUp(ctx))
-		assert.Emptyf(t, coordinator.state.failed, "failed list should be empty")
+		assert.Zerof(t, coordinator.state.failed.len(), "failed list should be empty")
+
+		cancel()
+		stopCtx, cancel := context.WithTimeout(context.Background(), testParams.timeoutDelay)
+		defer cancel()
+		assert.NoError(t, coordinator.wait(stopCtx))
+		assert.Equal(t, sampler.finalState(), newCheckpoint(coordinator.state.unsafeStats()))
+	})
+
+	t.Run("preempt catchup for recent headers", func(t *testing.T) {
+		testParams := defaultTestParams()
+
+		testParams.dasParams.ConcurrencyLimit = 1
+		testParams.dasParams.SamplingRange = 10
+
+		testParams.sampleFrom = 1
+		testParams.networkHead = 10
+		sampler := newMockSampler(testParams.sampleFrom, testParams.networkHead)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testParams.timeoutDelay)
+
+		// lock catchup and the first recent worker, so that next recent headers are queued
+		lk := newLock(testParams.sampleFrom, testParams.sampleFrom)
+		lk.add(11)
+
+		// expect catchup worker to be preempted after the first header and to be resumed only after
+		// queued recent headers
+		order := newCheckOrder().
+			addInterval(1, 1).
+			addInterval(11, 13).
+			addInterval(2, 10)
+
+		sample := lk.middleWare(
+			order.middleWare(
+				onceMiddleWare(sampler.sample),
+			),
+		)
+		// signal once the catchup worker is blocked on the first header, so that it is preempted
+		// only after the header is sampled
+		started := make(chan struct{})
+		coordinator := newSamplingCoordinator(testParams.dasParams, getterStub{},
+			func(ctx context.Context, h *header.ExtendedHeader) error {
+				if h.Height() == int64(testParams.sampleFrom) {
+					close(started)
+				}
+				return sample(ctx, h)
+			},
+			newBroadcastMock(3),
+			nil,
+			nil,
+		)
+		go coordinator.run(ctx, sampler.checkpoint)
+
+		select {
+		case <-started:
+		case <-ctx.Done():
+			t.Fatal("catchup worker did not start in time")
+		}
+
+		for h := uint64(11); h <= 13; h++ {
+			sampler.discover(ctx, h, coordinator.listen)
+		}
+		// ensure all discovered headers are handled by the coordinator
+		_, err := coordinator.stats(ctx)
+		require.NoError(t, err)
+
+		lk.release(1)
+		require.Eventually(t, func() bool {
+			return sampler.sampledAmount() == 1
+		}, testParams.timeoutDelay, time.Millisecond)
+		lk.release(11)
+
+		// check if all jobs were sampled successfully
+		assert.NoError(t, sampler.finished(ctx), "not all headers were sampled")
+
+		// wait for coordinator to indicateDone catchup
+		assert.NoError(t, coordinator.state.waitCatchUp(ctx))
+		assert.Zerof(t, coordinator.state.failed.len(), "failed list should be empty")
 
 		cancel()
 		stopCtx, cancel := context.WithTimeout(context.Background(), testParams.timeoutDelay)
@@ -142,7 +221,7 @@ func TestCoordinator(t *testing.T) {
 
 		lk := newLock(testParams.sampleFrom, testParams.networkHead) // lock all workers before start
 		coordinator := newSamplingCoordinator(testParams.dasParams, getterStub{},
-			lk.middleWare(sampler.sample), newBroadcastMock(1))
+			lk.middleWare(sampler.sample), newBroadcastMock(1), nil, nil)
 		go coordinator.run(ctx, sampler.checkpoint)
 
 		// discover new height and lock it
@@ -171,7 +250,7 @@ func TestCoordinator(t *testing.T) {
 
 		// wait for coordinator to indicateDone catchup
 		assert.NoError(t, coordinator.state.waitCatchUp(ctx))
-		assert.Emptyf(t, coordinator.state.failed, "failed list is not empty")
+		assert.Zerof(t, coordinator.state.failed.len(), "failed list is not empty")
 
 		cancel()
 		stopCtx, cancel := context.WithTimeout(context.Background(), testParams.timeoutDelay)
@@ -193,6 +272,8 @@ func TestCoordinator(t *testing.T) {
 			getterStub{},
 			onceMiddleWare(sampler.sample),
 			newBroadcastMock(1),
+			nil,
+			nil,
 		)
 		go coordinator.run(ctx, sampler.checkpoint)
 
@@ -235,6 +316,8 @@ func TestCoordinator(t *testing.T) {
 			getterStub{},
 			onceMiddleWare(sampler.sample),
 			newBroadcastMock(1),
+			nil,
+			nil,
 		)
 		go coordinator.run(ctx, sampler.checkpoint)
 
@@ -259,8 +342,9 @@ func TestCoordinator(t *testing.T) {
 		testParams.dasParams.ConcurrencyLimit = 5
 		ctx, cancel := context.WithTimeout(context.Background(), testParams.timeoutDelay)
 
+		// catchup is done, so that failed heights are retried by all the workers
 		ch := checkpoint{
-			SampleFrom:  testParams.sampleFrom,
+			SampleFrom:  testParams.networkHead + 1,
 			NetworkHead: testParams.networkHead,
 			Failed:      map[uint64]int{1: 1, 2: 2, 3: 3, 4: 4, 5: 5},
 			Workers:     []workerCheckpoint{},
@@ -284,6 +368,8 @@ func TestCoordinator(t *testing.T) {
 			getterStub{},
 			sampleFn,
 			newBroadcastMock(1),
+			nil,
+			nil,
 		)
 
 		go coordinator.run(ctx, ch)
@@ -314,6 +400,8 @@ func BenchmarkCoordinator(b *testing.B) {
 			newBenchGetter(),
 			func(ctx context.Context, h *header.ExtendedHeader) error { return nil },
 			newBroadcastMock(1),
+			nil,
+			nil,
 		)
 		go coordinator.run(ctx, checkpoint{
 			SampleFrom:  1,
@@ -4,17 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 
 	"github.com/ipfs/go-datastore"
 	logging "github.com/ipfs/go-log/v2"
+	"github.com/libp2p/go-libp2p/core/event"
 
 	"github.com/celestiaorg/go-fraud"
 	libhead "github.com/celestiaorg/go-header"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/budget"
+	"github.com/celestiaorg/celestia-node/libs/watchdog"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
+	"github.com/celestiaorg/celestia-node/share/p2p/attestsub"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexsub"
 )
 
@@ -29,10 +34,25 @@ type DASer struct {
 	hsub   libhead.Subscriber[*header.ExtendedHeader] // listens for new headers in the network
 	getter libhead.Getter[*header.ExtendedHeader]     // retrieves past headers
 
-	sampler    *samplingCoordinator
+	sampler *samplingCoordinator
+	storage CheckpointStorage
+	// peerCount returns the size of the peer pool sampling concurrency is adapted to
+	peerCount  func() int
 	store      checkpointStore
 	subscriber subscriber
+	audit      *auditLog
+	confidence *confidenceLog
+	// attest is nil unless sampling attestations are shared with peers
+	attest *attestations
+	// bus is nil unless sampling of replaced heads is rolled back
+	bus event.Bus
+	// budget is nil unless sampling shares the retrieval budget with user requests
+	budget *budget.Budget
+	// heartbeats is nil unless the sampling coordinator is watched for getting stuck
+	heartbeats *watchdog.Registry
 
+	// lk serializes starting, stopping and restarting
+	lk             sync.Mutex
 	cancel         context.CancelFunc
 	subscriberDone chan struct{}
 	running        int32
@@ -57,8 +77,8 @@ func NewDASer(
 		bcast:          bcast,
 		hsub:           hsub,
 		getter:         getter,
-		store:          newCheckpointStore(dstore),
 		subscriber:     newSubscriber(),
+		confidence:     newConfidenceLog(recentConfidenceSize),
 		subscriberDone: make(chan struct{}),
 	}
 
@@ -70,26 +90,76 @@ func NewDASer(
 	if err != nil {
 		return nil, err
 	}
+	if d.params.SampleFrom < d.params.FirstSampleableHeight {
+		d.params.SampleFrom = d.params.FirstSampleableHeight
+	}
+
+	if d.storage == nil {
+		d.storage = NewDatastoreCheckpointStorage(dstore)
+		if d.params.CheckpointDir != "" {
+			d.storage, err = NewFileCheckpointStorage(d.params.CheckpointDir)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	d.store = newCheckpointStore(d.storage)
+	d.audit = newAuditLog(d.params.AuditLogSize)
+	if d.attest != nil {
+		d.attest.init()
+	}
 
-	d.sampler = newSamplingCoordinator(d.params, getter, d.sample, shrexBroadcast)
+	d.sampler = newSamplingCoordinator(d.params, getter, d.sample, shrexBroadcast, d.peerCount, d.budget)
+	d.sampler.heartbeats = d.heartbeats
 	return d, nil
 }
 
 // Start initiates subscription for new ExtendedHeaders and spawns a sampling routine.
 func (d *DASer) Start(ctx context.Context) error {
+	d.lk.Lock()
+	defer d.lk.Unlock()
 	if !atomic.CompareAndSwapInt32(&d.running, 0, 1) {
 		return fmt.Errorf("da: DASer already started")
 	}
+	return d.start(ctx)
+}
 
+// start subscribes to new headers and spawns the sampling routines from the stored checkpoint.
+func (d *DASer) start(ctx context.Context) error {
 	sub, err := d.hsub.Subscribe()
 	if err != nil {
 		return err
 	}
 
+	var attestSub *attestsub.Subscription
+	if d.attest != nil {
+		attestSub, err = d.attest.pubsub.Subscribe()
+		if err != nil {
+			sub.Cancel()
+			return err
+		}
+	}
+
+	var replacementSub event.Subscription
+	if d.bus != nil {
+		replacementSub, err = d.bus.Subscribe(new(header.HeadReplacement))
+		if err != nil {
+			sub.Cancel()
+			if attestSub != nil {
+				attestSub.Cancel()
+			}
+			return err
+		}
+	}
+
 	// load latest DASed checkpoint
 	cp, err := d.store.load(ctx)
 	if err != nil {
-		log.Warnw("checkpoint not found, initializing with height 1")
+		if errors.Is(err, ErrCheckpointNotFound) {
+			log.Warnw("checkpoint not found, initializing with height 1")
+		} else {
+			log.Errorw("loading checkpoint, initializing with height 1", "err", err)
+		}
 
 		cp = checkpoint{
 			SampleFrom:  d.params.SampleFrom,
@@ -102,6 +172,8 @@ func (d *DASer) Start(ctx context.Context) error {
 			cp.NetworkHead = uint64(h.Height())
 		}
 	}
+	// the checkpoint could be stored before the first sampleable height became known
+	cp = cp.skipBelow(d.params.FirstSampleableHeight)
 	log.Info("starting DASer from checkpoint: ", cp.String())
 
 	runCtx, cancel := context.WithCancel(context.Background())
@@ -110,12 +182,20 @@ func (d *DASer) Start(ctx context.Context) error {
 	go d.sampler.run(runCtx, cp)
 	go d.subscriber.run(runCtx, sub, d.sampler.listen)
 	go d.store.runBackgroundStore(runCtx, d.params.BackgroundStoreInterval, d.sampler.getCheckpoint)
+	if attestSub != nil {
+		go d.runAttestations(runCtx, attestSub)
+	}
+	if replacementSub != nil {
+		go d.runHeadReplacements(runCtx, replacementSub)
+	}
 
 	return nil
 }
 
 // Stop stops sampling.
 func (d *DASer) Stop(ctx context.Context) error {
+	d.lk.Lock()
+	defer d.lk.Unlock()
 	if !atomic.CompareAndSwapInt32(&d.running, 1, 0) {
 		return nil
 	}
@@ -136,7 +216,7 @@ func (d *DASer) Stop(ctx context.Context) error {
 	}
 
 	// save updated checkpoint after sampler and all workers are shut down
-	if err = d.store.store(ctx, newCheckpoint(d.sampler.state.unsafeStats())); err != nil {
+	if err = d.store.store(ctx, d.sampler.state.unsafeCheckpoint()); err != nil {
 		log.Errorw("storing checkpoint to disk", "err", err)
 	}
 
@@ -146,8 +226,56 @@ func (d *DASer) Stop(ctx context.Context) error {
 	return d.subscriber.wait(ctx)
 }
 
+// Restart restarts sampling from the last checkpoint, e.g. once the sampling coordinator got stuck.
+// Sampling is only restarted once the previous routines exited, so that they never run twice; if
+// they don't exit before the context is done, the restart fails and may be retried.
+func (d *DASer) Restart(ctx context.Context) error {
+	d.lk.Lock()
+	defer d.lk.Unlock()
+	if atomic.LoadInt32(&d.running) == 0 {
+		// stopped or not started yet, so there is nothing to restart
+		return nil
+	}
+
+	d.cancel()
+	if err := d.sampler.wait(ctx); err != nil {
+		return fmt.Errorf("da: restarting DASer: %w", err)
+	}
+	if err := d.store.wait(ctx); err != nil {
+		return fmt.Errorf("da: restarting DASer: %w", err)
+	}
+	if err := d.subscriber.wait(ctx); err != nil {
+		return fmt.Errorf("da: restarting DASer: %w", err)
+	}
+	if err := d.store.store(ctx, d.sampler.state.unsafeCheckpoint()); err != nil {
+		log.Errorw("storing checkpoint to disk", "err", err)
+	}
+
+	d.subscriber = newSubscriber()
+	d.store = newCheckpointStore(d.storage)
+	d.sampler.reset(d.params)
+	if err := d.start(ctx); err != nil {
+		atomic.StoreInt32(&d.running, 0)
+		return fmt.Errorf("da: restarting DASer: %w", err)
+	}
+	return nil
+}
+
 func (d *DASer) sample(ctx context.Context, h *header.ExtendedHeader) error {
-	err := d.da.SharesAvailable(ctx, h.DAH)
+	if uint64(h.Height()) < d.params.FirstSampleableHeight {
+		// the network did not publish data for the header
+		return nil
+	}
+	ctx = share.WithHeight(ctx, uint64(h.Height()))
+	ctx = share.WithConfidenceObserver(ctx, func(confidence float64) {
+		d.confidence.add(uint64(h.Height()), confidence)
+	})
+	err := d.audit.sample(ctx, uint64(h.Height()), func(ctx context.Context) error {
+		return d.da.SharesAvailable(ctx, h.DAH)
+	})
+	if d.attest != nil {
+		d.attest.publish(ctx, h, err)
+	}
 	if err != nil {
 		var byzantineErr *byzantine.ErrByzantine
 		if errors.As(err, &byzantineErr) {
@@ -157,6 +285,15 @@ func (d *DASer) sample(ctx context.Context, h *header.ExtendedHeader) error {
 				log.Errorw("fraud proof propagating failed", "err", sendErr)
 			}
 		}
+		var unorderedErr *byzantine.ErrUnorderedNamespaces
+		if errors.As(err, &unorderedErr) {
+			log.Warn("Propagating proof...")
+			sendErr := d.bcast.Broadcast(ctx,
+				byzantine.CreateUnorderedNamespacesProof(h.Hash(), uint64(h.Height()), unorderedErr))
+			if sendErr != nil {
+				log.Errorw("fraud proof propagating failed", "err", sendErr)
+			}
+		}
 		return err
 	}
 	return nil
@@ -164,7 +301,18 @@ func (d *DASer) sample(ctx context.Context, h *header.ExtendedHeader) error {
 
 // SamplingStats returns the current statistics over the DA sampling process.
 func (d *DASer) SamplingStats(ctx context.Context) (SamplingStats, error) {
-	return d.sampler.stats(ctx)
+	stats, err := d.sampler.stats(ctx)
+	if err != nil {
+		return stats, err
+	}
+	stats.Confidence = d.confidence.get()
+	return stats, nil
+}
+
+// SamplingAuditLog returns the latest recorded sampling attempts for heights in the given range
+// [from; to], oldest first. Zero to means no upper bound.
+func (d *DASer) SamplingAuditLog(_ context.Context, from, to uint64) ([]SampleRecord, error) {
+	return d.audit.get(from, to), nil
 }
 
 // WaitCatchUp waits for DASer to indicate catchup is done
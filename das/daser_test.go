@@ -141,6 +141,35 @@ func TestDASer_Restart(t *testing.T) {
 	assert.EqualValues(t, 60, checkpoint.SampleFrom-1)
 }
 
+func TestDASer_RestartInPlace(t *testing.T) {
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	bServ := mdutils.Bserv()
+	avail := light.TestAvailability(getters.NewIPLDGetter(bServ))
+	mockGet, sub, mockService := createDASerSubcomponents(t, bServ, 15, 15)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	t.Cleanup(cancel)
+
+	daser, err := NewDASer(avail, sub, mockGet, ds, mockService, newBroadcastMock(1))
+	require.NoError(t, err)
+	// restarting a DASer that is not started is a no-op
+	require.NoError(t, daser.Restart(ctx))
+
+	require.NoError(t, daser.Start(ctx))
+	require.NoError(t, daser.WaitCatchUp(ctx))
+
+	require.NoError(t, daser.Restart(ctx))
+	// the restarted coordinator resumes from the checkpoint stored on restart
+	stats, err := daser.SamplingStats(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 30, stats.SampledChainHead)
+
+	require.NoError(t, daser.Stop(ctx))
+	// restarting a stopped DASer does not start it again
+	require.NoError(t, daser.Restart(ctx))
+	assert.EqualValues(t, 0, daser.running)
+}
+
 func TestDASer_stopsAfter_BEFP(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*20)
 	t.Cleanup(cancel)
@@ -226,6 +255,32 @@ func TestDASerSampleTimeout(t *testing.T) {
 	}
 }
 
+// TestDASerHeadReplacement ensures the sampling result of a replaced head is invalidated and the
+// replacement gets sampled.
+func TestDASerHeadReplacement(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	replaced, replacement := headertest.RandExtendedHeader(t), headertest.RandExtendedHeader(t)
+
+	avail := mocks.NewMockAvailability(gomock.NewController(t))
+	gomock.InOrder(
+		avail.EXPECT().Invalidate(gomock.Any(), replaced.DAH).Return(nil),
+		avail.EXPECT().SharesAvailable(gomock.Any(), replacement.DAH).Return(nil),
+	)
+
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	daser, err := NewDASer(avail, new(headertest.Subscriber), getterStub{}, ds,
+		new(fraudtest.DummyService), newBroadcastMock(1))
+	require.NoError(t, err)
+
+	daser.replaceHead(ctx, header.HeadReplacement{
+		Height:      uint64(replacement.Height()),
+		Replaced:    replaced,
+		Replacement: replacement,
+	})
+}
+
 // createDASerSubcomponents takes numGetter (number of headers
 // to store in mockGetter) and numSub (number of headers to store
 // in the mock header.Subscriber), returning a newly instantiated
@@ -3,6 +3,12 @@ package das
 import (
 	"fmt"
 	"time"
+
+	"github.com/libp2p/go-libp2p/core/event"
+
+	"github.com/celestiaorg/celestia-node/libs/budget"
+	"github.com/celestiaorg/celestia-node/libs/watchdog"
+	"github.com/celestiaorg/celestia-node/share/p2p/attestsub"
 )
 
 // ErrInvalidOption is an error that is returned by Parameters.Validate
@@ -28,6 +34,16 @@ type Parameters struct {
 	// ConcurrencyLimit defines the maximum amount of sampling workers running in parallel.
 	ConcurrencyLimit int
 
+	// MinConcurrencyLimit defines the minimum amount of sampling workers running in parallel.
+	// Concurrency is adapted between MinConcurrencyLimit and ConcurrencyLimit based on the success
+	// rate and latency of sampling, and the size of the peer pool. Setting it to ConcurrencyLimit
+	// disables adaptation, while 0 defaults to 2.
+	MinConcurrencyLimit int
+
+	// TargetSampleLatency is the latency of sampling a single block above which the concurrency is
+	// decreased. TargetSampleLatency = 0 disables latency-based adaptation.
+	TargetSampleLatency time.Duration
+
 	// BackgroundStoreInterval is the period of time for background checkpointStore to perform a
 	// checkpoint backup.
 	BackgroundStoreInterval time.Duration
@@ -40,6 +56,28 @@ type Parameters struct {
 	// divided between parallel workers. SampleTimeout should be adjusted proportionally to
 	// ConcurrencyLimit.
 	SampleTimeout time.Duration
+
+	// FirstSampleableHeight is the first height the network published data at. Headers below it are
+	// not sampled, regardless of SampleFrom and the stored checkpoint. It is defined by the network,
+	// rather than configured.
+	FirstSampleableHeight uint64 `toml:"-"`
+
+	// CheckpointDir is the directory the sampling checkpoint is stored in. If empty, the checkpoint
+	// is stored in the node datastore.
+	CheckpointDir string
+
+	// AuditLogSize is the amount of the latest sampling attempts kept in the sampling audit log.
+	// AuditLogSize = 0 disables the audit log.
+	AuditLogSize int
+
+	// ShareAttestations enables publishing sampling results to peers and sampling headers they failed
+	// to sample again. It is only supported by light nodes.
+	ShareAttestations bool
+
+	// RetrievalBudget is the amount of retrievals sampling and user requests may run at once in
+	// total. User requests are favored, so sampling uses the tokens left after them.
+	// RetrievalBudget = 0 disables the budget.
+	RetrievalBudget int
 }
 
 // DefaultParameters returns the default configuration values for the daser parameters
@@ -50,10 +88,15 @@ func DefaultParameters() Parameters {
 	return Parameters{
 		SamplingRange:           100,
 		ConcurrencyLimit:        concurrencyLimit,
+		MinConcurrencyLimit:     defaultMinConcurrencyLimit,
+		TargetSampleLatency:     15 * time.Second,
 		BackgroundStoreInterval: 10 * time.Minute,
 		SampleFrom:              1,
 		// SampleTimeout = block time * max amount of catchup workers
 		SampleTimeout: 15 * time.Second * time.Duration(concurrencyLimit),
+		AuditLogSize:  1000,
+		// leaves as many tokens to user requests as sampling uses at most
+		RetrievalBudget: 2 * concurrencyLimit,
 	}
 }
 
@@ -62,6 +105,8 @@ func DefaultParameters() Parameters {
 //	All parameters must be positive and non-zero, except:
 //		BackgroundStoreInterval = 0 disables background storer,
 //		PriorityQueueSize = 0 disables prioritization of recently produced blocks for sampling
+//		AuditLogSize = 0 disables the sampling audit log
+//		RetrievalBudget = 0 disables the retrieval budget
 func (p *Parameters) Validate() error {
 	// SamplingRange = 0 will cause the jobs' queue to be empty
 	// Therefore no sampling jobs will be reserved and more importantly the DASer will break
@@ -81,6 +126,22 @@ func (p *Parameters) Validate() error {
 		)
 	}
 
+	// MinConcurrencyLimit = 0 is left unset by configs predating it and defaults to
+	// defaultMinConcurrencyLimit
+	if p.MinConcurrencyLimit < 0 {
+		return errInvalidOptionValue(
+			"MinConcurrencyLimit",
+			"negative",
+		)
+	}
+
+	if p.MinConcurrencyLimit > p.ConcurrencyLimit {
+		return errInvalidOptionValue(
+			"MinConcurrencyLimit",
+			"greater than ConcurrencyLimit",
+		)
+	}
+
 	// SampleFrom = 0 would tell the DASer to start sampling from block height 0
 	// which does not exist therefore breaking the DASer.
 	if p.SampleFrom <= 0 {
@@ -98,6 +159,20 @@ func (p *Parameters) Validate() error {
 		)
 	}
 
+	if p.AuditLogSize < 0 {
+		return errInvalidOptionValue(
+			"AuditLogSize",
+			"negative",
+		)
+	}
+
+	if p.RetrievalBudget < 0 {
+		return errInvalidOptionValue(
+			"RetrievalBudget",
+			"negative",
+		)
+	}
+
 	return nil
 }
 
@@ -130,6 +205,22 @@ func WithConcurrencyLimit(concurrencyLimit int) Option {
 	}
 }
 
+// WithMinConcurrencyLimit is a functional option to configure the daser's `MinConcurrencyLimit`
+// parameter Refer to WithSamplingRange documentation to see an example of how to use this
+func WithMinConcurrencyLimit(minConcurrencyLimit int) Option {
+	return func(d *DASer) {
+		d.params.MinConcurrencyLimit = minConcurrencyLimit
+	}
+}
+
+// WithTargetSampleLatency is a functional option to configure the daser's `TargetSampleLatency`
+// parameter Refer to WithSamplingRange documentation to see an example of how to use this
+func WithTargetSampleLatency(targetSampleLatency time.Duration) Option {
+	return func(d *DASer) {
+		d.params.TargetSampleLatency = targetSampleLatency
+	}
+}
+
 // WithBackgroundStoreInterval is a functional option to configure the daser's
 // `backgroundStoreInterval` parameter Refer to WithSamplingRange documentation to see an example
 // of how to use this
@@ -154,3 +245,76 @@ func WithSampleTimeout(sampleTimeout time.Duration) Option {
 		d.params.SampleTimeout = sampleTimeout
 	}
 }
+
+// WithFirstSampleableHeight is a functional option to configure the daser's `FirstSampleableHeight`
+// parameter
+// Refer to WithSamplingRange documentation to see an example of how to use this
+func WithFirstSampleableHeight(height uint64) Option {
+	return func(d *DASer) {
+		d.params.FirstSampleableHeight = height
+	}
+}
+
+// WithCheckpointDir is a functional option to configure the daser's `CheckpointDir` parameter
+// Refer to WithSamplingRange documentation to see an example of how to use this
+func WithCheckpointDir(dir string) Option {
+	return func(d *DASer) {
+		d.params.CheckpointDir = dir
+	}
+}
+
+// WithAuditLogSize is a functional option to configure the daser's `AuditLogSize` parameter
+// Refer to WithSamplingRange documentation to see an example of how to use this
+func WithAuditLogSize(size int) Option {
+	return func(d *DASer) {
+		d.params.AuditLogSize = size
+	}
+}
+
+// WithAttestations is a functional option making the daser publish and receive sampling
+// attestations over the given attestsub.PubSub.
+func WithAttestations(ps *attestsub.PubSub) Option {
+	return func(d *DASer) {
+		d.attest = &attestations{pubsub: ps}
+	}
+}
+
+// WithHeadReplacements is a functional option making the daser roll back sampling of heads replaced
+// by competing headers, as announced by header.HeadReplacement events on the given bus.
+func WithHeadReplacements(bus event.Bus) Option {
+	return func(d *DASer) {
+		d.bus = bus
+	}
+}
+
+// WithCheckpointStorage is a functional option to store the daser's checkpoint in the given
+// CheckpointStorage, e.g. a remote key-value store. It takes precedence over `CheckpointDir`.
+func WithCheckpointStorage(storage CheckpointStorage) Option {
+	return func(d *DASer) {
+		d.storage = storage
+	}
+}
+
+// WithPeerCounter is a functional option to cap the daser's sampling concurrency by the size of the
+// peer pool returned by the given function.
+func WithPeerCounter(peerCount func() int) Option {
+	return func(d *DASer) {
+		d.peerCount = peerCount
+	}
+}
+
+// WithRetrievalBudget is a functional option to make the daser share the given retrieval budget
+// with user requests. Sampling is a background retrieval, so user requests are favored.
+func WithRetrievalBudget(b *budget.Budget) Option {
+	return func(d *DASer) {
+		d.budget = b
+	}
+}
+
+// WithHeartbeats is a functional option making the sampling coordinator check in with the given
+// watchdog.Registry, so that it is flagged once stuck.
+func WithHeartbeats(r *watchdog.Registry) Option {
+	return func(d *DASer) {
+		d.heartbeats = r
+	}
+}
@@ -0,0 +1,48 @@
+package das
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p/core/event"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// runHeadReplacements rolls back sampling of heads replaced by competing headers.
+func (d *DASer) runHeadReplacements(ctx context.Context, sub event.Subscription) {
+	defer sub.Close()
+
+	for {
+		select {
+		case evt, ok := <-sub.Out():
+			if !ok {
+				return
+			}
+			r, ok := evt.(header.HeadReplacement)
+			if !ok {
+				continue
+			}
+			d.replaceHead(ctx, r)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// replaceHead invalidates the cached sampling result of the replaced head, so that its data is no
+// longer considered available, and samples the replacement. The data of the replaced head is kept
+// stored, if any, as Invalidate never removes it.
+func (d *DASer) replaceHead(ctx context.Context, r header.HeadReplacement) {
+	log.Warnw("sampling replacement of the head", "height", r.Height, "replacement", r.Replacement.Hash())
+
+	ctx, cancel := context.WithTimeout(ctx, d.params.SampleTimeout)
+	defer cancel()
+
+	if err := d.da.Invalidate(ctx, r.Replaced.DAH); err != nil {
+		log.Errorw("invalidating sampling result of the replaced head", "height", r.Height, "err", err)
+		return
+	}
+	if err := d.sample(ctx, r.Replacement); err != nil {
+		log.Errorw("sampling replacement of the head", "height", r.Height, "err", err)
+	}
+}
@@ -0,0 +1,101 @@
+package das
+
+import (
+	"container/heap"
+	"time"
+)
+
+// retryQueue keeps failed heights ordered by the time of their next retry attempt, so that the
+// heights due to be retried are found without going over all failed heights.
+type retryQueue struct {
+	// attempts is the source of truth for heights in the queue
+	attempts map[uint64]retryAttempt
+	// due orders heights by retry time. Entries of removed or re-pushed heights are left in the heap
+	// and skipped once popped.
+	due retryHeap
+}
+
+func newRetryQueue() retryQueue {
+	return retryQueue{attempts: make(map[uint64]retryAttempt)}
+}
+
+// push adds the height to the queue or replaces its previous attempt.
+func (q *retryQueue) push(height uint64, attempt retryAttempt) {
+	q.attempts[height] = attempt
+	heap.Push(&q.due, retryEntry{height: height, after: attempt.after})
+
+	// drop stale entries once they outnumber the live ones
+	if len(q.due) > 2*len(q.attempts)+64 {
+		q.compact()
+	}
+}
+
+// remove removes the height from the queue.
+func (q *retryQueue) remove(height uint64) {
+	delete(q.attempts, height)
+}
+
+// popDue removes and returns the earliest height that can be retried at the given time.
+func (q *retryQueue) popDue(now time.Time) (uint64, retryAttempt, bool) {
+	for len(q.due) > 0 {
+		next := q.due[0]
+		attempt, ok := q.attempts[next.height]
+		if !ok || !attempt.after.Equal(next.after) {
+			// stale entry
+			heap.Pop(&q.due)
+			continue
+		}
+		if !attempt.after.Before(now) {
+			return 0, retryAttempt{}, false
+		}
+
+		heap.Pop(&q.due)
+		delete(q.attempts, next.height)
+		return next.height, attempt, true
+	}
+	return 0, retryAttempt{}, false
+}
+
+// len returns the amount of heights in the queue.
+func (q *retryQueue) len() int {
+	return len(q.attempts)
+}
+
+func (q *retryQueue) compact() {
+	q.due = make(retryHeap, 0, len(q.attempts))
+	for h, attempt := range q.attempts {
+		q.due = append(q.due, retryEntry{height: h, after: attempt.after})
+	}
+	heap.Init(&q.due)
+}
+
+type retryEntry struct {
+	height uint64
+	after  time.Time
+}
+
+// retryHeap implements heap.Interface over retry entries ordered by time.
+type retryHeap []retryEntry
+
+func (h retryHeap) Len() int { return len(h) }
+
+func (h retryHeap) Less(i, j int) bool {
+	if h[i].after.Equal(h[j].after) {
+		return h[i].height < h[j].height
+	}
+	return h[i].after.Before(h[j].after)
+}
+
+func (h retryHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *retryHeap) Push(x interface{}) {
+	*h = append(*h, x.(retryEntry))
+}
+
+func (h *retryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	*h = old[:n-1]
+	return entry
+}
@@ -0,0 +1,42 @@
+package das
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryQueue(t *testing.T) {
+	now := time.Now()
+	q := newRetryQueue()
+	q.push(10, retryAttempt{count: 1, after: now.Add(-time.Second)})
+	q.push(20, retryAttempt{count: 1, after: now.Add(-time.Minute)})
+	q.push(30, retryAttempt{count: 1, after: now.Add(time.Minute)})
+	// re-pushed heights keep only the latest attempt
+	q.push(10, retryAttempt{count: 2, after: now.Add(-time.Hour)})
+	// removed heights are not retried
+	q.push(40, retryAttempt{count: 1, after: now.Add(-time.Hour)})
+	q.remove(40)
+	assert.Equal(t, 3, q.len())
+
+	// heights are popped in the order they are due
+	h, attempt, ok := q.popDue(now)
+	assert.True(t, ok)
+	assert.EqualValues(t, 10, h)
+	assert.Equal(t, 2, attempt.count)
+
+	h, _, ok = q.popDue(now)
+	assert.True(t, ok)
+	assert.EqualValues(t, 20, h)
+
+	// heights that are not due yet stay in the queue
+	_, _, ok = q.popDue(now)
+	assert.False(t, ok)
+	assert.Equal(t, 1, q.len())
+
+	h, _, ok = q.popDue(now.Add(time.Hour))
+	assert.True(t, ok)
+	assert.EqualValues(t, 30, h)
+	assert.Zero(t, q.len())
+}
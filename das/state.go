@@ -18,10 +18,15 @@ type coordinatorState struct {
 	// keeps track of running workers
 	inProgress map[int]func() workerState
 
+	// recentQueue keeps recent headers waiting for a free worker, oldest first
+	recentQueue []*header.ExtendedHeader
+	// interrupted keeps ranges of preempted catchup jobs, which are resumed before new catchup jobs
+	interrupted []job
+
 	// retryStrategy implements retry backoff
 	retryStrategy retryStrategy
-	// stores heights of failed headers with amount of retry attempt as value
-	failed map[uint64]retryAttempt
+	// queues heights of failed headers with their retry attempts until they are due to be retried
+	failed retryQueue
 	// inRetry stores (height -> attempt count) of failed headers that are currently being retried by
 	// workers
 	inRetry map[uint64]retryAttempt
@@ -39,6 +44,10 @@ type coordinatorState struct {
 	catchUpDoneCh chan struct{}
 }
 
+// maxRecentQueueSize limits the amount of recent headers waiting for a free worker. Once exceeded,
+// the oldest headers are left to catchup jobs.
+const maxRecentQueueSize = 16
+
 // retryAttempt represents a retry attempt with a backoff delay.
 type retryAttempt struct {
 	// count specifies the number of retry attempts made so far.
@@ -53,11 +62,14 @@ func newCoordinatorState(params Parameters) coordinatorState {
 		sampleFrom:    params.SampleFrom,
 		samplingRange: params.SamplingRange,
 		inProgress:    make(map[int]func() workerState),
-		retryStrategy: newRetryStrategy(exponentialBackoff(
-			defaultBackoffInitialInterval,
-			defaultBackoffMultiplier,
-			defaultBackoffMaxRetryCount)),
-		failed:        make(map[uint64]retryAttempt),
+		retryStrategy: retryStrategy{
+			retryIntervals: exponentialBackoff(
+				defaultBackoffInitialInterval,
+				defaultBackoffMultiplier,
+				defaultBackoffMaxRetryCount),
+			jitter: defaultBackoffJitter,
+		},
+		failed:        newRetryQueue(),
 		inRetry:       make(map[uint64]retryAttempt),
 		nextJobID:     0,
 		next:          params.SampleFrom,
@@ -71,17 +83,27 @@ func (s *coordinatorState) resumeFromCheckpoint(c checkpoint) {
 	s.networkHead = c.NetworkHead
 
 	for h, count := range c.Failed {
-		// resumed retries should start without backoff delay
-		s.failed[h] = retryAttempt{
-			count: count,
-			after: time.Now(),
+		// resumed retries keep their backoff delay if it was stored, otherwise start without delay
+		after, ok := c.RetryAfter[h]
+		if !ok {
+			after = time.Now()
 		}
+		s.failed.push(h, retryAttempt{
+			count: count,
+			after: after,
+		})
 	}
 }
 
 func (s *coordinatorState) handleResult(res result) {
 	delete(s.inProgress, res.id)
 
+	if res.resumeFrom != 0 {
+		// leave the unsampled rest of the preempted job for later and only handle the sampled part
+		s.interrupted = append(s.interrupted, job{jobType: res.jobType, from: res.resumeFrom, to: res.to})
+		res.to = res.resumeFrom - 1
+	}
+
 	switch res.jobType {
 	case recentJob, catchupJob:
 		s.handleRecentOrCatchupResult(res)
@@ -94,20 +116,20 @@ func (s *coordinatorState) handleResult(res result) {
 
 func (s *coordinatorState) handleRecentOrCatchupResult(res result) {
 	// check if the worker retried any of the previously failed heights
-	for h := range s.failed {
+	for h := range s.failed.attempts {
 		if h < res.from || h > res.to {
 			continue
 		}
 
 		if res.failed[h] == 0 {
-			delete(s.failed, h)
+			s.failed.remove(h)
 		}
 	}
 
 	// update failed heights
 	for h := range res.failed {
 		nextRetry, _ := s.retryStrategy.nextRetry(retryAttempt{}, time.Now())
-		s.failed[h] = nextRetry
+		s.failed.push(h, nextRetry)
 	}
 }
 
@@ -123,7 +145,7 @@ func (s *coordinatorState) handleRetryResult(res result) {
 				"height", h,
 				"attempts", nextRetry.count)
 		}
-		s.failed[h] = nextRetry
+		s.failed.push(h, nextRetry)
 	}
 
 	// processed height are either already moved to failed map or succeeded, cleanup inRetry
@@ -168,19 +190,67 @@ func (s *coordinatorState) recentJob(header *header.ExtendedHeader) job {
 	}
 }
 
-// nextJob will return next catchup or retry job according to priority (retry -> catchup)
-func (s *coordinatorState) nextJob() (next job, found bool) {
+// queueRecent queues the recent header until a worker is free to sample it.
+func (s *coordinatorState) queueRecent(header *header.ExtendedHeader) {
+	s.recentQueue = append(s.recentQueue, header)
+	if len(s.recentQueue) > maxRecentQueueSize {
+		// dropped headers are not lost, as they are above next and will be sampled by catchup
+		s.recentQueue = s.recentQueue[1:]
+	}
+}
+
+// queuedRecentJob creates a job to process the oldest queued recent header.
+func (s *coordinatorState) queuedRecentJob() (next job, found bool) {
+	for len(s.recentQueue) > 0 {
+		h := s.recentQueue[0]
+		s.recentQueue = s.recentQueue[1:]
+		if uint64(h.Height()) < s.next {
+			// already handed over to a catchup job while queued
+			continue
+		}
+		return s.recentJob(h), true
+	}
+	return job{}, false
+}
+
+// nextJob will return next job according to priority (recent -> retry -> catchup).
+// Retries are only prioritized while less than retryLimit of them are in progress, so that
+// failed heights don't block catchup progress. Once catchup is done, retries are not limited.
+func (s *coordinatorState) nextJob(retryLimit int) (next job, found bool) {
+	if job, found := s.queuedRecentJob(); found {
+		return job, found
+	}
+
 	// check for if any retry jobs are available
-	if job, found := s.retryJob(); found {
+	if len(s.inRetry) < retryLimit {
+		if job, found := s.retryJob(); found {
+			return job, found
+		}
+	}
+
+	if job, found := s.catchupJob(); found {
 		return job, found
 	}
 
-	// if no retry jobs, make a catchup job
-	return s.catchupJob()
+	// if no catchup jobs, retry without limits
+	return s.retryJob()
 }
 
-// catchupJob creates a catchup job if catchup is not finished
+// catchupJob creates a catchup job if catchup is not finished. Interrupted jobs are resumed first,
+// starting from the lowest one.
 func (s *coordinatorState) catchupJob() (next job, found bool) {
+	if len(s.interrupted) > 0 {
+		lowest := 0
+		for i, j := range s.interrupted {
+			if j.from < s.interrupted[lowest].from {
+				lowest = i
+			}
+		}
+		j := s.interrupted[lowest]
+		s.interrupted = append(s.interrupted[:lowest], s.interrupted[lowest+1:]...)
+		return s.newJob(j.jobType, j.from, j.to), true
+	}
+
 	if s.next > s.networkHead {
 		return job{}, false
 	}
@@ -194,22 +264,16 @@ func (s *coordinatorState) catchupJob() (next job, found bool) {
 	return j, true
 }
 
-// retryJob creates a job to retry previously failed header
+// retryJob creates a job to retry the previously failed header that is due the earliest
 func (s *coordinatorState) retryJob() (next job, found bool) {
-	for h, attempt := range s.failed {
-		if !attempt.canRetry() {
-			// height will be retried later
-			continue
-		}
-
-		// move header from failed into retry
-		delete(s.failed, h)
-		s.inRetry[h] = attempt
-		j := s.newJob(retryJob, h, h)
-		return j, true
+	h, attempt, ok := s.failed.popDue(time.Now())
+	if !ok {
+		return job{}, false
 	}
 
-	return job{}, false
+	// move header from failed into retry
+	s.inRetry[h] = attempt
+	return s.newJob(retryJob, h, h), true
 }
 
 func (s *coordinatorState) putInProgress(jobID int, getState func() workerState) {
@@ -259,8 +323,14 @@ func (s *coordinatorState) unsafeStats() SamplingStats {
 		}
 	}
 
+	for _, j := range s.interrupted {
+		if j.from < lowestFailedOrInProgress {
+			lowestFailedOrInProgress = j.from
+		}
+	}
+
 	// set lowestFailedOrInProgress to minimum failed - 1
-	for h, retry := range s.failed {
+	for h, retry := range s.failed.attempts {
 		failed[h] += retry.count
 		if h < lowestFailedOrInProgress {
 			lowestFailedOrInProgress = h
@@ -278,13 +348,14 @@ func (s *coordinatorState) unsafeStats() SamplingStats {
 		Failed:           failed,
 		Workers:          workers,
 		Concurrency:      len(workers),
+		RetryQueueDepth:  s.failed.len(),
 		CatchUpDone:      s.catchUpDone.Load(),
 		IsRunning:        len(workers) > 0 || s.catchUpDone.Load(),
 	}
 }
 
 func (s *coordinatorState) checkDone() {
-	if len(s.inProgress) == 0 && len(s.failed) == 0 && s.next > s.networkHead {
+	if len(s.inProgress) == 0 && len(s.interrupted) == 0 && s.failed.len() == 0 && s.next > s.networkHead {
 		if s.catchUpDone.CompareAndSwap(false, true) {
 			close(s.catchUpDoneCh)
 		}
@@ -311,7 +382,23 @@ func (s *coordinatorState) waitCatchUp(ctx context.Context) error {
 	return nil
 }
 
-// canRetry returns true if the time stored in the "after" has passed.
-func (r retryAttempt) canRetry() bool {
-	return r.after.Before(time.Now())
+// unsafeCheckpoint creates a checkpoint of the coordinator state without thread-safety.
+// Unlike checkpoints created from stats, it keeps the backoff of failed heights and the interrupted
+// jobs.
+func (s *coordinatorState) unsafeCheckpoint() checkpoint {
+	cp := newCheckpoint(s.unsafeStats())
+	for _, j := range s.interrupted {
+		cp.Workers = append(cp.Workers, workerCheckpoint{
+			From:    j.from,
+			To:      j.to,
+			JobType: j.jobType,
+		})
+	}
+	if s.failed.len() > 0 {
+		cp.RetryAfter = make(map[uint64]time.Time, s.failed.len())
+		for h, retry := range s.failed.attempts {
+			cp.RetryAfter[h] = retry.after
+		}
+	}
+	return cp
 }
@@ -47,11 +47,11 @@ func Test_coordinatorStats(t *testing.T) {
 						}
 					},
 				},
-				failed: map[uint64]retryAttempt{
+				failed: retryQueue{attempts: map[uint64]retryAttempt{
 					22: {count: 1},
 					23: {count: 1},
 					24: {count: 2},
-				},
+				}},
 				nextJobID:   0,
 				next:        31,
 				networkHead: 100,
@@ -77,9 +77,10 @@ func Test_coordinatorStats(t *testing.T) {
 						ErrMsg:  "12: failed\n13: failed",
 					},
 				},
-				Concurrency: 2,
-				CatchUpDone: false,
-				IsRunning:   true,
+				Concurrency:     2,
+				RetryQueueDepth: 3,
+				CatchUpDone:     false,
+				IsRunning:       true,
 			},
 		},
 	}
@@ -93,3 +94,26 @@ func Test_coordinatorStats(t *testing.T) {
 		})
 	}
 }
+
+func Test_coordinatorState_nextJob(t *testing.T) {
+	params := DefaultParameters()
+	params.SamplingRange = 10
+	state := newCoordinatorState(params)
+	state.resumeFromCheckpoint(checkpoint{
+		SampleFrom:  1,
+		NetworkHead: 20,
+		Failed:      map[uint64]int{3: 1, 5: 1, 7: 1},
+	})
+
+	// retries don't take more workers than the limit before catchup
+	jobTypes := make([]jobType, 0)
+	for i := 0; i < 5; i++ {
+		j, found := state.nextJob(1)
+		assert.True(t, found)
+		jobTypes = append(jobTypes, j.jobType)
+	}
+	assert.Equal(t, []jobType{retryJob, catchupJob, catchupJob, retryJob, retryJob}, jobTypes)
+
+	_, found := state.nextJob(1)
+	assert.False(t, found)
+}
@@ -15,8 +15,15 @@ type SamplingStats struct {
 	Workers []WorkerStats `json:"workers,omitempty"`
 	// Concurrency amount of currently running parallel workers
 	Concurrency int `json:"concurrency"`
+	// ConcurrencyLimit is the current limit of parallel workers adapted to the network conditions
+	ConcurrencyLimit int `json:"concurrency_limit"`
+	// RetryQueueDepth is the amount of failed headers waiting to be retried
+	RetryQueueDepth int `json:"retry_queue_depth"`
 	// CatchUpDone indicates whether all known headers are sampled
 	CatchUpDone bool `json:"catch_up_done"`
+	// Confidence is the effective confidence of availability reached for the latest sampled headers
+	// by height
+	Confidence map[uint64]float64 `json:"confidence,omitempty"`
 	// IsRunning tracks whether the DASer service is running
 	IsRunning bool `json:"is_running"`
 }
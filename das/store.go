@@ -1,65 +1,143 @@
 package das
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
-
-	"github.com/ipfs/go-datastore"
-	"github.com/ipfs/go-datastore/namespace"
 )
 
 var (
-	storePrefix   = datastore.NewKey("das")
-	checkpointKey = datastore.NewKey("checkpoint")
+	// checkpointKey holds the latest checkpoint and backupCheckpointKey a copy of it to recover from,
+	// if the latest gets corrupted. The copy is only written once the latest is, so a write torn
+	// under checkpointKey leaves the previous checkpoint intact under backupCheckpointKey.
+	checkpointKey       = "checkpoint"
+	backupCheckpointKey = "checkpoint.backup"
 )
 
+// errCheckpointCorrupted is returned by checkpointStore when the stored checkpoint fails checksum
+// verification or cannot be decoded.
+var errCheckpointCorrupted = errors.New("das: checkpoint corrupted")
+
 // The checkpointStore stores/loads the DASer's checkpoint to/from
-// disk using the checkpointKey. The checkpoint is stored as a struct
-// representation of the latest successfully DASed state.
+// the CheckpointStorage. The checkpoint is stored as a struct
+// representation of the latest successfully DASed state, together with its checksum.
+//
+// Every checkpoint is written twice: first under the checkpointKey and then under the
+// backupCheckpointKey, so that there is always at least one intact copy to load, either of the
+// latest checkpoint or of the previous one.
 type checkpointStore struct {
-	datastore.Datastore
+	storage CheckpointStorage
 	done
 }
 
-// newCheckpointStore wraps the given datastore.Datastore with the `das` prefix.
-func newCheckpointStore(ds datastore.Datastore) checkpointStore {
+// storedCheckpoint is the checksummed envelope the checkpoint is stored in.
+type storedCheckpoint struct {
+	Checksum   []byte          `json:"checksum"`
+	Checkpoint json.RawMessage `json:"checkpoint"`
+}
+
+// newCheckpointStore creates a checkpointStore over the given CheckpointStorage.
+func newCheckpointStore(storage CheckpointStorage) checkpointStore {
 	return checkpointStore{
-		namespace.Wrap(ds, storePrefix),
+		storage,
 		newDone("checkpoint store")}
 }
 
-// load loads the DAS checkpoint from disk and returns it.
+// load loads the DAS checkpoint from the storage and returns it.
+// If the latest checkpoint is corrupted, it falls back to the backup one.
 func (s *checkpointStore) load(ctx context.Context) (checkpoint, error) {
-	bs, err := s.Get(ctx, checkpointKey)
-	if err != nil {
+	cp, err := s.loadKey(ctx, checkpointKey)
+	if err == nil {
+		return cp, nil
+	}
+
+	backup, backupErr := s.loadKey(ctx, backupCheckpointKey)
+	if backupErr != nil {
+		if errors.Is(err, ErrCheckpointNotFound) {
+			return checkpoint{}, backupErr
+		}
 		return checkpoint{}, err
 	}
 
-	cp := checkpoint{}
-	err = json.Unmarshal(bs, &cp)
-	return cp, err
+	log.Warnw("recovered checkpoint from backup", "err", err, "checkpoint", backup.String())
+	return backup, nil
 }
 
-// checkpointStore stores the given DAS checkpoint to disk.
+func (s *checkpointStore) loadKey(ctx context.Context, key string) (checkpoint, error) {
+	bs, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return checkpoint{}, err
+	}
+	return decodeCheckpoint(bs)
+}
+
+// checkpointStore stores the given DAS checkpoint to the storage.
 func (s *checkpointStore) store(ctx context.Context, cp checkpoint) error {
 	// checkpointStore latest DASed checkpoint to disk here to ensure that if DASer is not yet
 	// fully caught up to network head, it will resume DASing from this checkpoint
 	// up to current network head
-	bs, err := json.Marshal(cp)
+	bs, err := encodeCheckpoint(cp)
 	if err != nil {
-		return fmt.Errorf("marshal checkpoint: %w", err)
+		return err
 	}
 
-	if err = s.Put(ctx, checkpointKey, bs); err != nil {
+	if err = s.storage.Put(ctx, checkpointKey, bs); err != nil {
 		return err
 	}
+	// the backup is only updated once the latest checkpoint is written, so that a failed write
+	// can always be recovered from
+	if err = s.storage.Put(ctx, backupCheckpointKey, bs); err != nil {
+		return fmt.Errorf("storing backup checkpoint: %w", err)
+	}
 
 	log.Info("stored checkpoint to disk: ", cp.String())
 	return nil
 }
 
+func encodeCheckpoint(cp checkpoint) ([]byte, error) {
+	bs, err := json.Marshal(cp)
+	if err != nil {
+		return nil, fmt.Errorf("marshal checkpoint: %w", err)
+	}
+	checksum := sha256.Sum256(bs)
+	return json.Marshal(storedCheckpoint{
+		Checksum:   checksum[:],
+		Checkpoint: bs,
+	})
+}
+
+func decodeCheckpoint(bs []byte) (checkpoint, error) {
+	stored := storedCheckpoint{}
+	err := json.Unmarshal(bs, &stored)
+	if err != nil {
+		return checkpoint{}, fmt.Errorf("%w: %w", errCheckpointCorrupted, err)
+	}
+
+	cp := checkpoint{}
+	if stored.Checksum == nil {
+		// checkpoints stored before checksums were introduced
+		err = json.Unmarshal(bs, &cp)
+		if err != nil {
+			return checkpoint{}, fmt.Errorf("%w: %w", errCheckpointCorrupted, err)
+		}
+		return cp, nil
+	}
+
+	checksum := sha256.Sum256(stored.Checkpoint)
+	if !bytes.Equal(checksum[:], stored.Checksum) {
+		return checkpoint{}, fmt.Errorf("%w: checksum mismatch", errCheckpointCorrupted)
+	}
+	err = json.Unmarshal(stored.Checkpoint, &cp)
+	if err != nil {
+		return checkpoint{}, fmt.Errorf("%w: %w", errCheckpointCorrupted, err)
+	}
+	return cp, nil
+}
+
 // runBackgroundStore periodically saves current sampling state in case of DASer force quit before
 // being able to store state on exit. The routine can be disabled by passing storeInterval = 0.
 func (s *checkpointStore) runBackgroundStore(
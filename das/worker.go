@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	libhead "github.com/celestiaorg/go-header"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/budget"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexsub"
 )
 
@@ -22,10 +24,14 @@ const (
 type worker struct {
 	lock  sync.Mutex
 	state workerState
+	// preempted signals the worker to stop before sampling the next header, so that its slot is
+	// given to recent headers
+	preempted atomic.Bool
 
 	getter    libhead.Getter[*header.ExtendedHeader]
 	sampleFn  sampleFn
 	broadcast shrexsub.BroadcastFn
+	budget    *budget.Budget
 	metrics   *metrics
 }
 
@@ -54,12 +60,14 @@ func newWorker(j job,
 	getter libhead.Getter[*header.ExtendedHeader],
 	sample sampleFn,
 	broadcast shrexsub.BroadcastFn,
+	budget *budget.Budget,
 	metrics *metrics,
 ) worker {
 	return worker{
 		getter:    getter,
 		sampleFn:  sample,
 		broadcast: broadcast,
+		budget:    budget,
 		metrics:   metrics,
 		state: workerState{
 			curr: j.from,
@@ -76,6 +84,12 @@ func (w *worker) run(ctx context.Context, timeout time.Duration, resultCh chan<-
 	log.Debugw("start sampling worker", "from", w.state.from, "to", w.state.to)
 
 	for curr := w.state.from; curr <= w.state.to; curr++ {
+		if w.preempted.Load() {
+			// the rest of the job will be resumed by the coordinator
+			w.setResumeFrom(curr)
+			break
+		}
+
 		err := w.sample(ctx, timeout, curr)
 		if errors.Is(err, context.Canceled) {
 			// sampling worker will resume upon restart
@@ -84,7 +98,16 @@ func (w *worker) run(ctx context.Context, timeout time.Duration, resultCh chan<-
 		w.setResult(curr, err)
 	}
 
-	if w.state.jobType != recentJob {
+	switch {
+	case w.state.resumeFrom != 0:
+		log.Debugw(
+			"preempted sampling headers",
+			"type", w.state.jobType,
+			"from", w.state.from,
+			"to", w.state.to,
+			"resume_from", w.state.resumeFrom,
+		)
+	case w.state.jobType != recentJob:
 		log.Infow(
 			"finished sampling headers",
 			"type", w.state.jobType,
@@ -107,6 +130,15 @@ func (w *worker) sample(ctx context.Context, timeout time.Duration, height uint6
 		return err
 	}
 
+	// waiting for the budget doesn't count towards the timeout of sampling
+	if w.budget != nil {
+		release, err := w.budget.Acquire(ctx, budget.Background)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
 	start := time.Now()
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
@@ -196,6 +228,19 @@ func (w *worker) setResult(curr uint64, err error) {
 	w.state.curr = curr
 }
 
+// setResumeFrom marks the job as preempted at the given height.
+func (w *worker) setResumeFrom(height uint64) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.state.resumeFrom = height
+}
+
+// preempt signals the worker to stop after the header being sampled. It returns false if the
+// worker was preempted before.
+func (w *worker) preempt() bool {
+	return w.preempted.CompareAndSwap(false, true)
+}
+
 func (w *worker) getState() workerState {
 	w.lock.Lock()
 	defer w.lock.Unlock()
@@ -0,0 +1,50 @@
+package header
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// Equivocation is evidence of two valid ExtendedHeaders for the same height with different hashes.
+// As both headers are signed by 2/3+ of their validator sets, it hints at consensus-level
+// misbehavior, e.g. validators double signing.
+//
+// Equivocations are also emitted as events on the libp2p event bus of the node.
+type Equivocation struct {
+	Height uint64 `json:"height"`
+	// First is the header observed first, while Second is the conflicting one.
+	First  *ExtendedHeader `json:"first"`
+	Second *ExtendedHeader `json:"second"`
+	// DetectedAt is the time the conflicting header was observed.
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// NewEquivocation creates evidence of equivocation from the two conflicting ExtendedHeaders.
+// It errors if the headers are not valid or do not conflict.
+func NewEquivocation(first, second *ExtendedHeader) (*Equivocation, error) {
+	if first.Height() != second.Height() {
+		return nil, fmt.Errorf("header: equivocating headers must have the same height, got %d and %d",
+			first.Height(), second.Height())
+	}
+	if first.ChainID() != second.ChainID() {
+		return nil, fmt.Errorf("header: equivocating headers must have the same chain id, got %s and %s",
+			first.ChainID(), second.ChainID())
+	}
+	if bytes.Equal(first.Hash(), second.Hash()) {
+		return nil, fmt.Errorf("header: equivocating headers must have different hashes")
+	}
+	if err := first.Validate(); err != nil {
+		return nil, fmt.Errorf("header: invalid first header: %w", err)
+	}
+	if err := second.Validate(); err != nil {
+		return nil, fmt.Errorf("header: invalid second header: %w", err)
+	}
+
+	return &Equivocation{
+		Height:     uint64(first.Height()),
+		First:      first,
+		Second:     second,
+		DetectedAt: time.Now().UTC(),
+	}, nil
+}
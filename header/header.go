@@ -110,6 +110,9 @@ func (eh *ExtendedHeader) Equals(header *ExtendedHeader) bool {
 
 // Validate performs *basic* validation to check for missed/incorrect fields.
 func (eh *ExtendedHeader) Validate() error {
+	if eh.IsTrimmed() {
+		return fmt.Errorf("%w: height %d", ErrTrimmed, eh.Height())
+	}
 	err := eh.RawHeader.ValidateBasic()
 	if err != nil {
 		return fmt.Errorf("ValidateBasic error on RawHeader at height %d: %w", eh.Height(), err)
@@ -192,21 +195,25 @@ func (eh *ExtendedHeader) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
 		RawHeader    json.RawMessage `json:"header"`
 		ValidatorSet json.RawMessage `json:"validator_set"`
+		Trimmed      bool            `json:"trimmed,omitempty"`
 		*Alias
 	}{
 		ValidatorSet: validatorSet,
 		RawHeader:    rawHeader,
+		Trimmed:      eh.IsTrimmed(),
 		Alias:        (*Alias)(eh),
 	})
 }
 
 // UnmarshalJSON unmarshals an ExtendedHeader from JSON. The ValidatorSet is wrapped with amino
-// encoding, to be able to unmarshal the crypto.PubKey type back from JSON.
+// encoding, to be able to unmarshal the crypto.PubKey type back from JSON. Trimmed headers are
+// unmarshalled without the ValidatorSet, so that they stay trimmed.
 func (eh *ExtendedHeader) UnmarshalJSON(data []byte) error {
 	type Alias ExtendedHeader
 	aux := &struct {
 		RawHeader    json.RawMessage `json:"header"`
 		ValidatorSet json.RawMessage `json:"validator_set"`
+		Trimmed      bool            `json:"trimmed,omitempty"`
 		*Alias
 	}{
 		Alias: (*Alias)(eh),
@@ -215,9 +222,12 @@ func (eh *ExtendedHeader) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	valSet := new(core.ValidatorSet)
-	if err := tmjson.Unmarshal(aux.ValidatorSet, valSet); err != nil {
-		return err
+	var valSet *core.ValidatorSet
+	if !aux.Trimmed {
+		valSet = new(core.ValidatorSet)
+		if err := tmjson.Unmarshal(aux.ValidatorSet, valSet); err != nil {
+			return err
+		}
 	}
 	rawHeader := new(RawHeader)
 	if err := tmjson.Unmarshal(aux.RawHeader, rawHeader); err != nil {
@@ -1,6 +1,7 @@
 package headertest
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -39,3 +40,40 @@ func equalExtendedHeader(t *testing.T, in, out *header.ExtendedHeader) {
 	assert.NotZero(t, out.RawHeader)
 	assert.NotNil(t, out.Commit)
 }
+
+func TestTrimmedExtendedHeader(t *testing.T) {
+	in := RandExtendedHeader(t)
+	binaryData, err := in.MarshalBinary()
+	require.NoError(t, err)
+
+	trimmed, err := header.UnmarshalTrimmedExtendedHeader(binaryData)
+	require.NoError(t, err)
+	assert.True(t, trimmed.IsTrimmed())
+	assert.Equal(t, in.Hash(), trimmed.Hash())
+	assert.Equal(t, in.Height(), trimmed.Height())
+	assert.True(t, in.DAH.Equals(trimmed.DAH))
+	assert.ErrorIs(t, trimmed.Validate(), header.ErrTrimmed)
+
+	// trimmed headers are serialized without the signatures and the validator set
+	trimmedData, err := header.MarshalTrimmedExtendedHeader(in)
+	require.NoError(t, err)
+	assert.Less(t, len(trimmedData), len(binaryData))
+	retrimmed, err := header.UnmarshalTrimmedExtendedHeader(trimmedData)
+	require.NoError(t, err)
+	assert.Equal(t, trimmed, retrimmed)
+
+	// trimmed headers stay trimmed over JSON
+	jsonData, err := json.Marshal(trimmed)
+	require.NoError(t, err)
+	out := &header.ExtendedHeader{}
+	require.NoError(t, json.Unmarshal(jsonData, out))
+	assert.True(t, out.IsTrimmed())
+	assert.Equal(t, in.Hash(), out.Hash())
+
+	jsonData, err = json.Marshal(in)
+	require.NoError(t, err)
+	out = &header.ExtendedHeader{}
+	require.NoError(t, json.Unmarshal(jsonData, out))
+	assert.False(t, out.IsTrimmed())
+	assert.NoError(t, out.Validate())
+}
@@ -0,0 +1,52 @@
+package header
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// HeadReplacement is evidence of a competing ExtendedHeader replacing the head of the chain known
+// to the node: a header for the same height as the head, with a different hash, which verifies
+// against the header the head extends. Finality makes such replacements rare, as they require
+// validators to double sign, but modules keeping state of the head have to roll it back then.
+//
+// HeadReplacements are emitted as events on the libp2p event bus of the node.
+type HeadReplacement struct {
+	Height uint64 `json:"height"`
+	// Replaced is the head known to the node, while Replacement is the competing one.
+	Replaced    *ExtendedHeader `json:"replaced"`
+	Replacement *ExtendedHeader `json:"replacement"`
+	// DetectedAt is the time the competing header was observed.
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// NewHeadReplacement creates evidence of the replacement of the given head extending the given
+// parent. It errors if the replacement does not verify against the parent or does not compete with
+// the head.
+func NewHeadReplacement(parent, replaced, replacement *ExtendedHeader) (*HeadReplacement, error) {
+	if replaced.Height() != parent.Height()+1 {
+		return nil, fmt.Errorf("header: replaced head at height %d must extend parent at height %d",
+			replaced.Height(), parent.Height())
+	}
+	if replacement.Height() != replaced.Height() {
+		return nil, fmt.Errorf("header: replacement must have the height of the head %d, got %d",
+			replaced.Height(), replacement.Height())
+	}
+	if bytes.Equal(replaced.Hash(), replacement.Hash()) {
+		return nil, fmt.Errorf("header: replacement must have a different hash than the head")
+	}
+	if err := replacement.Validate(); err != nil {
+		return nil, fmt.Errorf("header: invalid replacement: %w", err)
+	}
+	if err := parent.Verify(replacement); err != nil {
+		return nil, fmt.Errorf("header: replacement does not extend the parent: %w", err)
+	}
+
+	return &HeadReplacement{
+		Height:      uint64(replaced.Height()),
+		Replaced:    replaced,
+		Replacement: replacement,
+		DetectedAt:  time.Now().UTC(),
+	}, nil
+}
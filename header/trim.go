@@ -0,0 +1,79 @@
+package header
+
+import (
+	"errors"
+	"fmt"
+
+	core "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+
+	header_pb "github.com/celestiaorg/celestia-node/header/pb"
+)
+
+// ErrTrimmed is returned when a trimmed header is validated, as it lacks the fields to be
+// validated with.
+var ErrTrimmed = errors.New("header: header is trimmed")
+
+// IsTrimmed reports whether the header lacks the signatures of its commit and its validator set,
+// as it was read without them by consumers only needing its DAH and height. Trimmed headers can't
+// be used for verification.
+func (eh *ExtendedHeader) IsTrimmed() bool {
+	return eh.ValidatorSet == nil
+}
+
+// MarshalTrimmedExtendedHeader serializes the given ExtendedHeader without the signatures of its
+// commit and its validator set, so that it is unmarshalled by UnmarshalTrimmedExtendedHeader
+// without reading them.
+func MarshalTrimmedExtendedHeader(in *ExtendedHeader) ([]byte, error) {
+	commit := *in.Commit
+	commit.Signatures = nil
+	out := &header_pb.ExtendedHeader{
+		Header: in.RawHeader.ToProto(),
+		Commit: commit.ToProto(),
+	}
+
+	var err error
+	out.Dah, err = in.DAH.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return out.Marshal()
+}
+
+// UnmarshalTrimmedExtendedHeader deserializes the given ExtendedHeader serialized by
+// MarshalTrimmedExtendedHeader or MarshalExtendedHeader into a trimmed ExtendedHeader, skipping
+// the signatures of the commit and the validator set. As the signatures are not verified, it must
+// only be used for headers which were verified before, e.g. the ones of the local store.
+func UnmarshalTrimmedExtendedHeader(data []byte) (*ExtendedHeader, error) {
+	in := &header_pb.ExtendedHeader{}
+	err := in.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if in.Commit == nil {
+		return nil, fmt.Errorf("header: missing commit")
+	}
+
+	out := &ExtendedHeader{}
+	out.RawHeader, err = core.HeaderFromProto(in.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	blockID, err := core.BlockIDFromProto(&in.Commit.BlockID)
+	if err != nil {
+		return nil, err
+	}
+	out.Commit = &core.Commit{
+		Height:  in.Commit.Height,
+		Round:   in.Commit.Round,
+		BlockID: *blockID,
+	}
+
+	out.DAH, err = da.DataAvailabilityHeaderFromProto(in.Dah)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,171 @@
+// Package audit provides an append-only log of actions taken on the node.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const fileName = "audit.log"
+
+// Entry is a single action recorded in the Log.
+type Entry struct {
+	Time time.Time `json:"time"`
+	// Method is the full name of the called method, e.g. "blob.Submit".
+	Method string `json:"method"`
+	// Subject identifies the token the call was authenticated with.
+	Subject string `json:"subject"`
+	// ParamsHash is the hex-encoded SHA256 of the JSON-encoded params, so that calls are told
+	// apart without keeping their, possibly large or sensitive, params.
+	ParamsHash string `json:"params_hash"`
+	// Result is "ok" for successful calls, or the error of failed ones.
+	Result string `json:"result"`
+}
+
+// Log is an append-only log of Entries stored as JSON lines in a directory. The file is rotated
+// once it exceeds the maximum size, and only the given amount of rotated files is kept.
+type Log struct {
+	dir      string
+	maxSize  int64
+	maxFiles int
+
+	lk   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// Open opens the Log in the given directory, creating the directory if needed.
+func Open(dir string, maxSize int64, maxFiles int) (*Log, error) {
+	if maxSize <= 0 {
+		return nil, errors.New("audit: max size must be positive")
+	}
+	if maxFiles < 0 {
+		return nil, errors.New("audit: max files must not be negative")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("audit: creating directory: %w", err)
+	}
+
+	l := &Log{dir: dir, maxSize: maxSize, maxFiles: maxFiles}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Record appends the entry to the Log.
+func (l *Log) Record(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	l.lk.Lock()
+	defer l.lk.Unlock()
+	if l.size > 0 && l.size+int64(len(data)) > l.maxSize {
+		if err = l.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := l.file.Write(data)
+	l.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("audit: writing entry: %w", err)
+	}
+	return nil
+}
+
+// Entries returns up to the given amount of the latest entries, oldest first, including the ones of
+// rotated files. All the entries are returned if the amount is not positive.
+func (l *Log) Entries(amount int) ([]Entry, error) {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+
+	entries := make([]Entry, 0)
+	// rotated files are read from the oldest one
+	for i := l.maxFiles; i >= 0; i-- {
+		fileEntries, err := readEntries(l.path(i))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+	}
+	if amount > 0 && len(entries) > amount {
+		entries = entries[len(entries)-amount:]
+	}
+	return entries, nil
+}
+
+// Close closes the Log.
+func (l *Log) Close() error {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+	return l.file.Close()
+}
+
+func (l *Log) open() error {
+	file, err := os.OpenFile(l.path(0), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("audit: opening log: %w", err)
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		return errors.Join(err, file.Close())
+	}
+	l.file, l.size = file, stat.Size()
+	return nil
+}
+
+// rotate shifts the rotated files, dropping the oldest one, and starts a new file.
+func (l *Log) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Remove(l.path(l.maxFiles)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("audit: removing oldest log: %w", err)
+	}
+	for i := l.maxFiles - 1; i >= 0; i-- {
+		if err := os.Rename(l.path(i), l.path(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("audit: rotating log: %w", err)
+		}
+	}
+	return l.open()
+}
+
+// path returns the path of the file rotated the given amount of times.
+func (l *Log) path(rotated int) string {
+	if rotated == 0 {
+		return filepath.Join(l.dir, fileName)
+	}
+	return filepath.Join(l.dir, fmt.Sprintf("%s.%d", fileName, rotated))
+}
+
+func readEntries(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := make([]Entry, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 4096), 1<<20)
+	for scanner.Scan() {
+		var entry Entry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("audit: decoding entry of %s: %w", path, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
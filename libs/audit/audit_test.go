@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLog_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	l, err := Open(dir, 512, 2)
+	require.NoError(t, err)
+
+	const amount = 20
+	for i := 0; i < amount; i++ {
+		err = l.Record(Entry{
+			Time:    time.Now().UTC(),
+			Method:  fmt.Sprintf("blob.Submit%d", i),
+			Subject: "subject",
+			Result:  "ok",
+		})
+		require.NoError(t, err)
+	}
+
+	// the files are bounded by the max size, and the oldest are dropped
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 3)
+	for _, file := range files {
+		info, err := file.Info()
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(512))
+	}
+
+	entries, err := l.Entries(0)
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+	require.Less(t, len(entries), amount)
+	// the latest entries are kept in order
+	for i, entry := range entries {
+		assert.Equal(t, fmt.Sprintf("blob.Submit%d", amount-len(entries)+i), entry.Method)
+	}
+
+	latest, err := l.Entries(3)
+	require.NoError(t, err)
+	assert.Equal(t, entries[len(entries)-3:], latest)
+
+	// entries survive reopening
+	require.NoError(t, l.Close())
+	l, err = Open(dir, 512, 2)
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	reopened, err := l.Entries(0)
+	require.NoError(t, err)
+	assert.Equal(t, entries, reopened)
+	assert.FileExists(t, filepath.Join(dir, fileName))
+}
@@ -1,17 +1,31 @@
 package authtoken
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 
 	"github.com/cristalhq/jwt"
 	"github.com/filecoin-project/go-jsonrpc/auth"
 
+	"github.com/celestiaorg/nmt/namespace"
+
 	"github.com/celestiaorg/celestia-node/api/rpc/perms"
 )
 
 // ExtractSignedPermissions returns the permissions granted to the token by the passed signer.
 // If the token isn't signed by the signer, it will not pass verification.
 func ExtractSignedPermissions(signer jwt.Signer, token string) ([]auth.Permission, error) {
+	p, err := ExtractSignedPayload(signer, token)
+	if err != nil {
+		return nil, err
+	}
+	return p.Allow, nil
+}
+
+// ExtractSignedPayload returns the payload of the token signed by the passed signer, containing
+// its permissions and namespaces.
+func ExtractSignedPayload(signer jwt.Signer, token string) (*perms.JWTPayload, error) {
 	tk, err := jwt.ParseAndVerifyString(token, signer)
 	if err != nil {
 		return nil, err
@@ -21,14 +35,41 @@ func ExtractSignedPermissions(signer jwt.Signer, token string) ([]auth.Permissio
 	if err != nil {
 		return nil, err
 	}
-	return p.Allow, nil
+	return p, nil
+}
+
+// VerifyPayload returns the payload of the token signed by the passed signer, unless the token is
+// revoked. It is the verification shared by all APIs accepting tokens.
+func VerifyPayload(signer jwt.Signer, revocations *Revocations, token string) (*perms.JWTPayload, error) {
+	p, err := ExtractSignedPayload(signer, token)
+	if err != nil {
+		return nil, err
+	}
+	if revocations.IsRevoked(p.ID) {
+		return nil, ErrRevoked
+	}
+	return p, nil
 }
 
 // NewSignedJWT returns a signed JWT token with the passed permissions and signer.
-func NewSignedJWT(signer jwt.Signer, permissions []auth.Permission) (string, error) {
-	token, err := jwt.NewTokenBuilder(signer).Build(&perms.JWTPayload{
-		Allow: permissions,
+// If any namespaces are passed, the token is scoped to them.
+func NewSignedJWT(signer jwt.Signer, permissions []auth.Permission, namespaces ...namespace.ID) (string, error) {
+	return NewSignedPayloadJWT(signer, &perms.JWTPayload{
+		Allow:      permissions,
+		Namespaces: namespaces,
 	})
+}
+
+// NewSignedPayloadJWT returns a JWT token with the passed payload signed by the signer. The token is
+// given a random ID, so that it can be revoked.
+func NewSignedPayloadJWT(signer jwt.Signer, payload *perms.JWTPayload) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	payload.ID = hex.EncodeToString(id)
+
+	token, err := jwt.NewTokenBuilder(signer).Build(payload)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,74 @@
+package authtoken
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+)
+
+// ErrRevoked is returned for tokens which were revoked.
+var ErrRevoked = errors.New("authtoken: token is revoked")
+
+var revocationsPrefix = datastore.NewKey("auth_revoked")
+
+// Revocations tracks the IDs of revoked tokens, persisting them in the datastore, so that tokens
+// stay revoked across restarts.
+type Revocations struct {
+	ds datastore.Datastore
+
+	lk      sync.RWMutex
+	revoked map[string]struct{}
+}
+
+// NewRevocations loads the revoked tokens from the datastore.
+func NewRevocations(ctx context.Context, ds datastore.Datastore) (*Revocations, error) {
+	r := &Revocations{
+		ds:      namespace.Wrap(ds, revocationsPrefix),
+		revoked: make(map[string]struct{}),
+	}
+	res, err := r.ds.Query(ctx, query.Query{KeysOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		r.revoked[datastore.RawKey(e.Key).BaseNamespace()] = struct{}{}
+	}
+	return r, nil
+}
+
+// Revoke revokes the token of the given ID.
+func (r *Revocations) Revoke(ctx context.Context, id string) error {
+	if id == "" {
+		return errors.New("authtoken: token has no ID")
+	}
+	if err := r.ds.Put(ctx, datastore.NewKey(id), []byte{}); err != nil {
+		return err
+	}
+	if err := r.ds.Sync(ctx, datastore.NewKey(id)); err != nil {
+		return err
+	}
+
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	r.revoked[id] = struct{}{}
+	return nil
+}
+
+// IsRevoked reports whether the token of the given ID is revoked. Nil Revocations revoke no tokens.
+func (r *Revocations) IsRevoked(id string) bool {
+	if r == nil || id == "" {
+		return false
+	}
+	r.lk.RLock()
+	defer r.lk.RUnlock()
+	_, ok := r.revoked[id]
+	return ok
+}
@@ -0,0 +1,48 @@
+package authtoken
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cristalhq/jwt"
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+)
+
+func TestRevocations(t *testing.T) {
+	ctx := context.Background()
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+
+	signer, err := jwt.NewHS256(make([]byte, 32))
+	require.NoError(t, err)
+	token, err := NewSignedJWT(signer, perms.ReadPerms)
+	require.NoError(t, err)
+	payload, err := ExtractSignedPayload(signer, token)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload.ID)
+
+	revocations, err := NewRevocations(ctx, ds)
+	require.NoError(t, err)
+	assert.False(t, revocations.IsRevoked(payload.ID))
+
+	require.NoError(t, revocations.Revoke(ctx, payload.ID))
+	assert.True(t, revocations.IsRevoked(payload.ID))
+	assert.Error(t, revocations.Revoke(ctx, ""))
+
+	// revocations persist
+	revocations, err = NewRevocations(ctx, ds)
+	require.NoError(t, err)
+	assert.True(t, revocations.IsRevoked(payload.ID))
+	assert.False(t, revocations.IsRevoked(""))
+
+	// tokens get unique IDs
+	other, err := NewSignedJWT(signer, perms.ReadPerms)
+	require.NoError(t, err)
+	otherPayload, err := ExtractSignedPayload(signer, other)
+	require.NoError(t, err)
+	assert.False(t, revocations.IsRevoked(otherPayload.ID))
+}
@@ -0,0 +1,133 @@
+package budget
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Class is a class of retrievals sharing a Budget.
+type Class int
+
+const (
+	// Interactive retrievals are requested by users and are favored by the Budget.
+	Interactive Class = iota
+	// Background retrievals, e.g. sampling, use the tokens left by interactive ones.
+	Background
+
+	classes
+)
+
+// reserveTTL is how long tokens stay reserved for interactive retrievals after the last one.
+const reserveTTL = time.Minute
+
+// Budget is a pool of tokens shared by interactive and background retrievals, each retrieval
+// holding a token while in progress.
+//
+// Interactive retrievals are favored, so that heavy background work can't starve them. Waiting
+// interactive retrievals are granted released tokens first, and background retrievals are limited
+// to the tokens left after a reserve for interactive ones. The reserve grows with the concurrency
+// of interactive retrievals and is given back to background ones once interactive retrievals stop
+// for a while.
+//
+// Budget is safe for concurrent use.
+type Budget struct {
+	size int
+
+	lk      sync.Mutex
+	inUse   [classes]int
+	waiters [classes][]chan struct{}
+	// reserve is the amount of tokens background retrievals can't use
+	reserve         int
+	lastInteractive time.Time
+}
+
+// New creates a new Budget of the given amount of tokens.
+func New(size int) *Budget {
+	if size < 1 {
+		size = 1
+	}
+	return &Budget{size: size}
+}
+
+// Acquire blocks until a token is granted to a retrieval of the given class or the context is
+// done. The returned func gives the token back and must be called once the retrieval is done.
+func (b *Budget) Acquire(ctx context.Context, class Class) (release func(), err error) {
+	granted := make(chan struct{})
+
+	b.lk.Lock()
+	if class == Interactive {
+		b.lastInteractive = time.Now()
+		demand := b.inUse[Interactive] + len(b.waiters[Interactive]) + 1
+		if demand > b.size-1 {
+			demand = b.size - 1
+		}
+		if demand > b.reserve {
+			b.reserve = demand
+		}
+	}
+	b.waiters[class] = append(b.waiters[class], granted)
+	b.dispatch()
+	b.lk.Unlock()
+
+	release = func() {
+		b.lk.Lock()
+		defer b.lk.Unlock()
+		b.inUse[class]--
+		b.dispatch()
+	}
+
+	select {
+	case <-granted:
+		return release, nil
+	case <-ctx.Done():
+	}
+
+	b.lk.Lock()
+	defer b.lk.Unlock()
+	select {
+	case <-granted:
+		// the token was granted concurrently, so give it back
+		b.inUse[class]--
+		b.dispatch()
+	default:
+		for i, waiter := range b.waiters[class] {
+			if waiter == granted {
+				b.waiters[class] = append(b.waiters[class][:i], b.waiters[class][i+1:]...)
+				break
+			}
+		}
+	}
+	return nil, ctx.Err()
+}
+
+// dispatch grants free tokens to waiting retrievals, interactive ones first. It must be called
+// under the lock.
+func (b *Budget) dispatch() {
+	if b.reserve > 0 && len(b.waiters[Interactive]) == 0 && b.inUse[Interactive] == 0 &&
+		time.Since(b.lastInteractive) > reserveTTL {
+		b.reserve = 0
+	}
+
+	for b.free() > 0 && len(b.waiters[Interactive]) > 0 {
+		b.grant(Interactive)
+	}
+	// background retrievals always keep at least one token, so that they can't be starved either
+	backgroundLimit := b.size - b.reserve
+	if backgroundLimit < 1 {
+		backgroundLimit = 1
+	}
+	for b.free() > 0 && len(b.waiters[Background]) > 0 && b.inUse[Background] < backgroundLimit {
+		b.grant(Background)
+	}
+}
+
+func (b *Budget) grant(class Class) {
+	close(b.waiters[class][0])
+	b.waiters[class] = b.waiters[class][1:]
+	b.inUse[class]++
+}
+
+func (b *Budget) free() int {
+	return b.size - b.inUse[Interactive] - b.inUse[Background]
+}
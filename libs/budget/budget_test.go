@@ -0,0 +1,92 @@
+package budget
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudget_FavorsInteractive(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	b := New(3)
+	// background retrievals use the whole budget without interactive ones
+	releases := make([]func(), 0, 3)
+	for i := 0; i < 3; i++ {
+		release, err := b.Acquire(ctx, Background)
+		require.NoError(t, err)
+		releases = append(releases, release)
+	}
+
+	backgroundCh := acquireAsync(ctx, b, Background)
+	interactiveCh := acquireAsync(ctx, b, Interactive)
+	require.Eventually(t, func() bool {
+		b.lk.Lock()
+		defer b.lk.Unlock()
+		return len(b.waiters[Background]) == 1 && len(b.waiters[Interactive]) == 1
+	}, time.Second, time.Millisecond)
+
+	// the released token goes to the interactive retrieval, even though it came later
+	releases[0]()
+	interactiveRelease := <-interactiveCh
+	require.NotNil(t, interactiveRelease)
+	select {
+	case <-backgroundCh:
+		t.Fatal("background retrieval must wait for the reserve of interactive ones")
+	default:
+	}
+
+	// the token reserved for interactive retrievals is not granted to background ones
+	interactiveRelease()
+	select {
+	case <-backgroundCh:
+		t.Fatal("background retrieval must wait for the reserve of interactive ones")
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	// but background retrievals get the tokens left after the reserve
+	releases[1]()
+	assert.NotNil(t, <-backgroundCh)
+}
+
+func TestBudget_ReleasesReserve(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	b := New(2)
+	release, err := b.Acquire(ctx, Interactive)
+	require.NoError(t, err)
+	release()
+
+	_, err = b.Acquire(ctx, Background)
+	require.NoError(t, err)
+	shortCtx, shortCancel := context.WithTimeout(ctx, time.Millisecond*50)
+	defer shortCancel()
+	_, err = b.Acquire(shortCtx, Background)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the reserve is given back to background retrievals once interactive ones stop
+	b.lk.Lock()
+	b.lastInteractive = time.Now().Add(-reserveTTL * 2)
+	b.lk.Unlock()
+	_, err = b.Acquire(ctx, Background)
+	require.NoError(t, err)
+
+	b.lk.Lock()
+	defer b.lk.Unlock()
+	assert.Empty(t, b.waiters[Background])
+	assert.Equal(t, 2, b.inUse[Background])
+}
+
+func acquireAsync(ctx context.Context, b *Budget, class Class) <-chan func() {
+	ch := make(chan func(), 1)
+	go func() {
+		release, _ := b.Acquire(ctx, class)
+		ch <- release
+	}()
+	return ch
+}
@@ -0,0 +1,69 @@
+// Package bufpool provides byte buffers pooled by size classes, for the buffers of shares and
+// proofs which are allocated and thrown away in bulk on the hot paths of retrieval, serialization
+// and repair of squares. Pooling them cuts the allocation churn of syncing big squares.
+//
+// Buffers are borrowed with Get and given back with Release explicitly, once the borrower is done
+// with them.
+package bufpool
+
+import (
+	"math/bits"
+	"sync"
+)
+
+const (
+	// minClass is the size class of the smallest pooled buffers, fitting NMT node hashes.
+	minClass = 7 // 128B
+	// maxClass is the size class of the biggest pooled buffers. Bigger buffers are allocated and
+	// left to the garbage collector.
+	maxClass = 22 // 4MiB
+)
+
+// pools keeps buffers by their size class, i.e. the binary logarithm of their capacity.
+var pools [maxClass + 1]sync.Pool
+
+// Buffer is a byte buffer of shares or proofs borrowed from the pool. Once not used anymore, it
+// has to be given back with Release, after which neither the Buffer nor its Bytes may be used.
+// Bytes returned to callers outside of the borrower must be copied.
+type Buffer struct {
+	// Bytes is the buffer itself of the requested size.
+	Bytes []byte
+
+	class    int
+	released bool
+}
+
+// Get borrows a Buffer of the given size from the pool. Its Bytes are not zeroed.
+func Get(size int) *Buffer {
+	class := sizeClass(size)
+	if class > maxClass {
+		return &Buffer{Bytes: make([]byte, size), class: class}
+	}
+
+	if buf, ok := pools[class].Get().(*Buffer); ok {
+		buf.Bytes, buf.released = buf.Bytes[:size], false
+		return buf
+	}
+	return &Buffer{Bytes: make([]byte, size, 1<<class), class: class}
+}
+
+// Release gives the Buffer back to the pool. Releasing the Buffer twice panics, as the Buffer may
+// be borrowed by someone else already.
+func (b *Buffer) Release() {
+	if b.released {
+		panic("bufpool: buffer released twice")
+	}
+	b.released = true
+	if b.class > maxClass {
+		return
+	}
+	pools[b.class].Put(b)
+}
+
+// sizeClass returns the size class of buffers fitting the given size.
+func sizeClass(size int) int {
+	if size <= 1<<minClass {
+		return minClass
+	}
+	return bits.Len(uint(size - 1))
+}
@@ -0,0 +1,44 @@
+package bufpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	for _, size := range []int{0, 1, 90, 512, 541, 1 << 16, 1<<maxClass + 1} {
+		buf := Get(size)
+		assert.Len(t, buf.Bytes, size)
+		assert.GreaterOrEqual(t, cap(buf.Bytes), size)
+		buf.Release()
+	}
+}
+
+func TestRelease(t *testing.T) {
+	buf := Get(541)
+	buf.Bytes[0] = 1
+	buf.Release()
+	assert.Panics(t, buf.Release)
+
+	// buffers of the same class are reused, up to the full capacity
+	buf = Get(1000)
+	assert.Len(t, buf.Bytes, 1000)
+	assert.Equal(t, 1024, cap(buf.Bytes))
+	buf.Release()
+}
+
+func TestSizeClass(t *testing.T) {
+	assert.Equal(t, minClass, sizeClass(0))
+	assert.Equal(t, minClass, sizeClass(1<<minClass))
+	assert.Equal(t, minClass+1, sizeClass(1<<minClass+1))
+	assert.Equal(t, 10, sizeClass(541))
+	assert.Equal(t, 11, sizeClass(1025))
+}
+
+func BenchmarkGet(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Get(541).Release()
+	}
+}
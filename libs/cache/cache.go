@@ -0,0 +1,316 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Cache is a bounded LRU cache, which loads missing values only once for all concurrent callers
+// of the same key. Values expire after the TTL of the cache, if any.
+//
+// Cache is safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	// size is the maximum amount of cached values. The cache is unbounded if size is not positive.
+	size int
+	ttl  time.Duration
+	// onEvict is called for every value dropped from the cache, outside the lock of the cache.
+	onEvict func(K, V)
+
+	lk sync.Mutex
+	// order keeps cached entries from the most to the least recently used.
+	order   *list.List
+	entries map[K]*list.Element
+	// loading tracks loads in progress, so that concurrent callers wait for them instead of
+	// loading the same value again.
+	loading map[K]*call[V]
+
+	metrics *metrics
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// call is a load in progress.
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+	// keep marks loads which values are cached, i.e. the ones started by GetOrLoad.
+	keep bool
+	// forgotten marks loads of keys removed while loading, so their values are not cached.
+	forgotten bool
+}
+
+// Option configures the Cache.
+type Option func(*options)
+
+type options struct {
+	ttl time.Duration
+}
+
+// WithTTL sets the time after which cached values expire. Values never expire by default.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// New creates a new Cache keeping up to size values. The Cache is unbounded if size is not
+// positive.
+func New[K comparable, V any](size int, opts ...Option) *Cache[K, V] {
+	return NewWithEvict[K, V](size, nil, opts...)
+}
+
+// NewWithEvict creates a new Cache keeping up to size values, which calls onEvict for every value
+// evicted, expired or removed from it.
+func NewWithEvict[K comparable, V any](size int, onEvict func(K, V), opts ...Option) *Cache[K, V] {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Cache[K, V]{
+		size:    size,
+		ttl:     o.ttl,
+		onEvict: onEvict,
+		order:   list.New(),
+		entries: make(map[K]*list.Element),
+		loading: make(map[K]*call[V]),
+	}
+}
+
+// Get returns the cached value of the key, if any.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.lk.Lock()
+	v, ok, expired := c.unsafeGet(key)
+	c.lk.Unlock()
+
+	c.evict(expired...)
+	return v, ok
+}
+
+// GetOrLoad returns the cached value of the key or loads it with the given function. Concurrent
+// calls for the same key wait for a single load, and each of them returns once the load is done
+// or its own context is canceled. If the load fails because of a canceled context, waiting callers
+// with live contexts load the value again. Errors are never cached.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, load func(context.Context) (V, error)) (V, error) {
+	return c.getOrLoad(ctx, key, load, true)
+}
+
+// Do loads the value of the key with the given function, sharing the load among concurrent calls
+// for the same key the same way GetOrLoad does. Unlike GetOrLoad, it neither returns nor keeps
+// cached values, so the Cache only coalesces loads, e.g. of values too big to be kept.
+func (c *Cache[K, V]) Do(ctx context.Context, key K, load func(context.Context) (V, error)) (V, error) {
+	return c.getOrLoad(ctx, key, load, false)
+}
+
+func (c *Cache[K, V]) getOrLoad(
+	ctx context.Context,
+	key K,
+	load func(context.Context) (V, error),
+	cache bool,
+) (V, error) {
+	for {
+		var (
+			v       V
+			ok      bool
+			expired []*entry[K, V]
+		)
+		c.lk.Lock()
+		if cache {
+			v, ok, expired = c.unsafeGet(key)
+		}
+		if ok {
+			c.lk.Unlock()
+			c.metrics.observeHit(ctx)
+			return v, nil
+		}
+
+		cl, loading := c.loading[key]
+		if !loading {
+			cl = &call[V]{done: make(chan struct{}), keep: cache}
+			c.loading[key] = cl
+		}
+		c.lk.Unlock()
+		c.evict(expired...)
+
+		if !loading {
+			c.metrics.observeMiss(ctx)
+			return c.load(ctx, key, cl, load)
+		}
+
+		c.metrics.observeShared(ctx)
+		select {
+		case <-cl.done:
+		case <-ctx.Done():
+			var zero V
+			return zero, ctx.Err()
+		}
+		if isContextErr(cl.err) && ctx.Err() == nil {
+			// the load was abandoned by its caller, while this one is still interested
+			continue
+		}
+		return cl.value, cl.err
+	}
+}
+
+// GetOrAdd returns the cached value of the key or adds the value made by the given function. The
+// function is called under the lock of the Cache, so it must be cheap and must not use the Cache.
+func (c *Cache[K, V]) GetOrAdd(key K, create func() V) V {
+	c.lk.Lock()
+	v, ok, expired := c.unsafeGet(key)
+	if !ok {
+		v = create()
+		expired = append(expired, c.unsafeAdd(key, v)...)
+	}
+	c.lk.Unlock()
+
+	c.evict(expired...)
+	return v
+}
+
+// Add caches the value of the key. The previous value of the key, if any, is replaced without
+// being evicted.
+func (c *Cache[K, V]) Add(key K, value V) {
+	c.lk.Lock()
+	evicted := c.unsafeAdd(key, value)
+	c.lk.Unlock()
+
+	c.evict(evicted...)
+}
+
+// Remove drops the value of the key from the Cache. Values of loads in progress for the key are
+// not cached.
+func (c *Cache[K, V]) Remove(key K) {
+	c.lk.Lock()
+	if cl, ok := c.loading[key]; ok {
+		cl.forgotten = true
+		delete(c.loading, key)
+	}
+	var removed []*entry[K, V]
+	if elem, ok := c.entries[key]; ok {
+		removed = append(removed, c.unsafeRemove(elem))
+	}
+	c.lk.Unlock()
+
+	c.evict(removed...)
+}
+
+// Len returns the amount of values in the Cache, including expired values not dropped yet.
+func (c *Cache[K, V]) Len() int {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	return len(c.entries)
+}
+
+// Range calls f for every unexpired value in the Cache until f returns false. It iterates over a
+// snapshot of the Cache, so f is free to use the Cache. Range does not affect recency of values.
+func (c *Cache[K, V]) Range(f func(K, V) bool) {
+	c.lk.Lock()
+	now := time.Now()
+	snapshot := make([]*entry[K, V], 0, len(c.entries))
+	for elem := c.order.Front(); elem != nil; elem = elem.Next() {
+		e := elem.Value.(*entry[K, V])
+		if !c.isExpired(e, now) {
+			snapshot = append(snapshot, e)
+		}
+	}
+	c.lk.Unlock()
+
+	for _, e := range snapshot {
+		if !f(e.key, e.value) {
+			return
+		}
+	}
+}
+
+func (c *Cache[K, V]) load(ctx context.Context, key K, cl *call[V], load func(context.Context) (V, error)) (V, error) {
+	var evicted []*entry[K, V]
+	defer func() {
+		c.lk.Lock()
+		if !cl.forgotten {
+			delete(c.loading, key)
+			if cl.err == nil && cl.keep {
+				evicted = c.unsafeAdd(key, cl.value)
+			}
+		}
+		c.lk.Unlock()
+		close(cl.done)
+
+		c.evict(evicted...)
+	}()
+
+	cl.value, cl.err = load(ctx)
+	return cl.value, cl.err
+}
+
+// unsafeGet returns the value of the key without thread-safety. The value is dropped if expired,
+// and returned to be evicted.
+func (c *Cache[K, V]) unsafeGet(key K) (v V, ok bool, expired []*entry[K, V]) {
+	elem, ok := c.entries[key]
+	if !ok {
+		return v, false, nil
+	}
+
+	e := elem.Value.(*entry[K, V])
+	if c.isExpired(e, time.Now()) {
+		return v, false, []*entry[K, V]{c.unsafeRemove(elem)}
+	}
+	c.order.MoveToFront(elem)
+	return e.value, true, nil
+}
+
+// unsafeAdd adds the value of the key without thread-safety. It returns entries to be evicted
+// for the Cache to stay within its size.
+func (c *Cache[K, V]) unsafeAdd(key K, value V) (evicted []*entry[K, V]) {
+	var expiresAt time.Time
+	if c.ttl > 0 {
+		expiresAt = time.Now().Add(c.ttl)
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		e := elem.Value.(*entry[K, V])
+		e.value, e.expiresAt = value, expiresAt
+		c.order.MoveToFront(elem)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	for c.size > 0 && len(c.entries) > c.size {
+		evicted = append(evicted, c.unsafeRemove(c.order.Back()))
+	}
+	return evicted
+}
+
+func (c *Cache[K, V]) unsafeRemove(elem *list.Element) *entry[K, V] {
+	e := c.order.Remove(elem).(*entry[K, V])
+	delete(c.entries, e.key)
+	return e
+}
+
+func (c *Cache[K, V]) isExpired(e *entry[K, V], now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// evict hands dropped entries over to the eviction callback.
+func (c *Cache[K, V]) evict(entries ...*entry[K, V]) {
+	if len(entries) == 0 {
+		return
+	}
+	c.metrics.observeEvictions(context.Background(), len(entries))
+	if c.onEvict == nil {
+		return
+	}
+	for _, e := range entries {
+		c.onEvict(e.key, e.value)
+	}
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
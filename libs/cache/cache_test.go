@@ -0,0 +1,217 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_GetOrLoad(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	c := New[string, int](10)
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(context.Context) (int, error) {
+		loads.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.GetOrLoad(ctx, "key", load)
+			assert.NoError(t, err)
+			assert.Equal(t, 42, v)
+		}()
+	}
+	time.Sleep(time.Millisecond * 50)
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, loads.Load())
+	v, ok := c.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, 42, v)
+}
+
+func TestCache_GetOrLoadErr(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	c := New[string, int](10)
+	errLoad := errors.New("load failed")
+
+	_, err := c.GetOrLoad(ctx, "key", func(context.Context) (int, error) {
+		return 0, errLoad
+	})
+	require.ErrorIs(t, err, errLoad)
+
+	// errors are not cached
+	v, err := c.GetOrLoad(ctx, "key", func(context.Context) (int, error) {
+		return 1, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+}
+
+func TestCache_GetOrLoadCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	c := New[string, int](10)
+
+	leaderCtx, leaderCancel := context.WithCancel(ctx)
+	started := make(chan struct{})
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := c.GetOrLoad(leaderCtx, "key", func(ctx context.Context) (int, error) {
+			close(started)
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+		leaderErr <- err
+	}()
+	<-started
+
+	followerResult := make(chan int, 1)
+	go func() {
+		v, err := c.GetOrLoad(ctx, "key", func(context.Context) (int, error) {
+			return 7, nil
+		})
+		assert.NoError(t, err)
+		followerResult <- v
+	}()
+
+	// the follower takes over the load once the leader gives up
+	time.Sleep(time.Millisecond * 50)
+	leaderCancel()
+	assert.ErrorIs(t, <-leaderErr, context.Canceled)
+	assert.Equal(t, 7, <-followerResult)
+}
+
+func TestCache_Do(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	c := New[string, int](10)
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(context.Context) (int, error) {
+		loads.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.Do(ctx, "key", load)
+			assert.NoError(t, err)
+			assert.Equal(t, 42, v)
+		}()
+	}
+	time.Sleep(time.Millisecond * 50)
+	close(release)
+	wg.Wait()
+	assert.EqualValues(t, 1, loads.Load())
+
+	// loaded values are not cached
+	_, ok := c.Get("key")
+	assert.False(t, ok)
+	assert.Zero(t, c.Len())
+
+	// and cached values are not used
+	c.Add("key", 1)
+	v, err := c.Do(ctx, "key", load)
+	require.NoError(t, err)
+	assert.Equal(t, 42, v)
+	assert.EqualValues(t, 2, loads.Load())
+}
+
+func TestCache_Evict(t *testing.T) {
+	var evicted []string
+	c := NewWithEvict[string, int](2, func(k string, _ int) {
+		evicted = append(evicted, k)
+	})
+
+	c.Add("1", 1)
+	c.Add("2", 2)
+	// make "1" the most recently used
+	_, ok := c.Get("1")
+	require.True(t, ok)
+
+	c.Add("3", 3)
+	assert.Equal(t, []string{"2"}, evicted)
+	assert.Equal(t, 2, c.Len())
+
+	c.Remove("1")
+	assert.Equal(t, []string{"2", "1"}, evicted)
+	_, ok = c.Get("1")
+	assert.False(t, ok)
+}
+
+func TestCache_TTL(t *testing.T) {
+	var evicted int
+	c := NewWithEvict[string, int](0, func(string, int) {
+		evicted++
+	}, WithTTL(time.Millisecond*10))
+
+	c.Add("key", 1)
+	_, ok := c.Get("key")
+	require.True(t, ok)
+
+	time.Sleep(time.Millisecond * 20)
+	_, ok = c.Get("key")
+	assert.False(t, ok)
+	assert.Equal(t, 1, evicted)
+	assert.Zero(t, c.Len())
+}
+
+func TestCache_RemoveWhileLoading(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	c := New[string, int](10)
+	_, err := c.GetOrLoad(ctx, "key", func(context.Context) (int, error) {
+		c.Remove("key")
+		return 1, nil
+	})
+	require.NoError(t, err)
+
+	// values of removed keys are not cached
+	_, ok := c.Get("key")
+	assert.False(t, ok)
+}
+
+func TestCache_Range(t *testing.T) {
+	c := New[string, int](0)
+	for i, k := range []string{"1", "2", "3"} {
+		c.GetOrAdd(k, func() int { return i })
+	}
+	assert.Equal(t, 0, c.GetOrAdd("1", func() int { return 10 }))
+
+	seen := make(map[string]int)
+	c.Range(func(k string, v int) bool {
+		seen[k] = v
+		// the cache can be used while ranging
+		c.Remove(k)
+		return true
+	})
+	assert.Equal(t, map[string]int{"1": 0, "2": 1, "3": 2}, seen)
+	assert.Zero(t, c.Len())
+}
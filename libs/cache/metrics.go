@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+)
+
+const cacheNameKey = "cache"
+
+var meter = global.MeterProvider().Meter("cache")
+
+type metrics struct {
+	name attribute.KeyValue
+
+	hits      syncint64.Counter
+	misses    syncint64.Counter
+	shared    syncint64.Counter
+	evictions syncint64.Counter
+}
+
+// WithMetrics turns on metric collection in the Cache. Metrics of different caches are told apart
+// by the given name.
+func (c *Cache[K, V]) WithMetrics(name string) error {
+	hits, err := meter.SyncInt64().Counter("cache_hits_counter",
+		instrument.WithDescription("cache hits"))
+	if err != nil {
+		return fmt.Errorf("cache: init metrics: %w", err)
+	}
+
+	misses, err := meter.SyncInt64().Counter("cache_misses_counter",
+		instrument.WithDescription("cache misses, each resulting in a load"))
+	if err != nil {
+		return fmt.Errorf("cache: init metrics: %w", err)
+	}
+
+	shared, err := meter.SyncInt64().Counter("cache_shared_loads_counter",
+		instrument.WithDescription("cache misses served by a load in progress"))
+	if err != nil {
+		return fmt.Errorf("cache: init metrics: %w", err)
+	}
+
+	evictions, err := meter.SyncInt64().Counter("cache_evictions_counter",
+		instrument.WithDescription("values evicted, expired or removed from cache"))
+	if err != nil {
+		return fmt.Errorf("cache: init metrics: %w", err)
+	}
+
+	c.metrics = &metrics{
+		name:      attribute.String(cacheNameKey, name),
+		hits:      hits,
+		misses:    misses,
+		shared:    shared,
+		evictions: evictions,
+	}
+	return nil
+}
+
+func (m *metrics) observeHit(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	m.hits.Add(observeCtx(ctx), 1, m.name)
+}
+
+func (m *metrics) observeMiss(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	m.misses.Add(observeCtx(ctx), 1, m.name)
+}
+
+func (m *metrics) observeShared(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	m.shared.Add(observeCtx(ctx), 1, m.name)
+}
+
+func (m *metrics) observeEvictions(ctx context.Context, amount int) {
+	if m == nil {
+		return
+	}
+	m.evictions.Add(observeCtx(ctx), int64(amount), m.name)
+}
+
+// observeCtx ensures metrics are recorded even for canceled requests.
+func observeCtx(ctx context.Context) context.Context {
+	if ctx.Err() != nil {
+		return context.Background()
+	}
+	return ctx
+}
@@ -0,0 +1,163 @@
+// Package encoding defines canonical JSON encodings for binary fields exposed over the node APIs.
+//
+// Every version defines a single encoding per binary field, so decoding never has to guess:
+//   - In LegacyVersion, used by clients not sending the VersionHeader, binary fields are standard
+//     base64 strings, as encoding/json encodes byte slices, except header hashes, which are
+//     unprefixed hex strings.
+//   - Since Version 1, hashes, commitments and namespaces, including header hashes, are 0x-prefixed
+//     hex strings, while shares and other arbitrary data are standard base64 strings.
+//
+// Types of the node encode and decode JSON in the current Version. Decoding of the older versions
+// is up to the API servers, which decode requests in the version the client declares. Types
+// encoding older versions differently implement Legacy, so that API servers respond in the version
+// of the client as well.
+package encoding
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Version is the current version of the encodings defined in the package.
+	Version = "1"
+	// LegacyVersion is the version of the encodings from before versioning.
+	LegacyVersion = "0"
+)
+
+// VersionHeader is the HTTP header carrying the encoding version of RPC requests and responses.
+const VersionHeader = "Celestia-Encoding-Version"
+
+// ErrUnsupportedVersion is returned for encoding versions the node doesn't support.
+var ErrUnsupportedVersion = errors.New("encoding: unsupported version")
+
+const hexPrefix = "0x"
+
+// Legacy is implemented by types of the node encoding JSON differently in LegacyVersion.
+type Legacy[T any] interface {
+	// Legacy returns a copy of the value encoding JSON in LegacyVersion.
+	Legacy() T
+}
+
+// ParseVersion parses the value of the VersionHeader. Values of clients that don't send the header
+// are parsed as LegacyVersion.
+func ParseVersion(v string) (string, error) {
+	switch v {
+	case "":
+		return LegacyVersion, nil
+	case LegacyVersion, Version:
+		return v, nil
+	default:
+		return "", fmt.Errorf("%w: %s, supported: %s, %s", ErrUnsupportedVersion, v, LegacyVersion, Version)
+	}
+}
+
+type versionKey struct{}
+
+// WithVersion returns a context carrying the encoding version of a request.
+func WithVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, versionKey{}, version)
+}
+
+// VersionFromContext returns the encoding version of a request carried by the context, or the
+// current Version if there is none.
+func VersionFromContext(ctx context.Context) string {
+	if v, ok := ctx.Value(versionKey{}).(string); ok {
+		return v
+	}
+	return Version
+}
+
+// Hex is a byte slice encoded as a 0x-prefixed hex string. It is used for hashes and namespaces.
+type Hex []byte
+
+func (h Hex) String() string {
+	return hexPrefix + hex.EncodeToString(h)
+}
+
+func (h Hex) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.String())
+}
+
+func (h *Hex) UnmarshalJSON(data []byte) error {
+	b, err := UnmarshalHex(data)
+	if err != nil {
+		return err
+	}
+	*h = b
+	return nil
+}
+
+// Base64 is a byte slice encoded as a standard base64 string. It is used for shares and other
+// arbitrary data.
+type Base64 []byte
+
+func (b Base64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.StdEncoding.EncodeToString(b))
+}
+
+func (b *Base64) UnmarshalJSON(data []byte) error {
+	bs, err := UnmarshalBase64(data)
+	if err != nil {
+		return err
+	}
+	*b = bs
+	return nil
+}
+
+// MarshalHex encodes the given bytes into JSON as a Hex.
+func MarshalHex(b []byte) ([]byte, error) {
+	return Hex(b).MarshalJSON()
+}
+
+// DecodeHex decodes bytes from a 0x-prefixed hex string.
+func DecodeHex(s string) ([]byte, error) {
+	if !strings.HasPrefix(s, hexPrefix) {
+		return nil, fmt.Errorf("encoding: hex string must be prefixed with %s", hexPrefix)
+	}
+	b, err := hex.DecodeString(s[len(hexPrefix):])
+	if err != nil {
+		return nil, fmt.Errorf("encoding: decoding hex: %w", err)
+	}
+	return b, nil
+}
+
+// UnmarshalHex decodes bytes from a JSON 0x-prefixed hex string.
+func UnmarshalHex(data []byte) ([]byte, error) {
+	s, err := unmarshalString(data)
+	if err != nil || s == nil {
+		return nil, err
+	}
+	return DecodeHex(*s)
+}
+
+// UnmarshalBase64 decodes bytes from a JSON standard base64 string.
+func UnmarshalBase64(data []byte) ([]byte, error) {
+	s, err := unmarshalString(data)
+	if err != nil || s == nil {
+		return nil, err
+	}
+	b, err := base64.StdEncoding.DecodeString(*s)
+	if err != nil {
+		return nil, fmt.Errorf("encoding: decoding base64: %w", err)
+	}
+	return b, nil
+}
+
+func unmarshalString(data []byte) (*string, error) {
+	if string(data) == "null" {
+		return nil, nil
+	}
+
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return nil, fmt.Errorf("encoding: binary field must be a string: %w", err)
+	}
+	return &s, nil
+}
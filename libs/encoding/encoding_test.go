@@ -0,0 +1,105 @@
+package encoding
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHex(t *testing.T) {
+	data := Hex{0xde, 0xad, 0xbe, 0xef}
+
+	bs, err := json.Marshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, `"0xdeadbeef"`, string(bs))
+
+	var decoded Hex
+	err = json.Unmarshal(bs, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+}
+
+func TestBase64(t *testing.T) {
+	data := Base64{0xde, 0xad, 0xbe, 0xef}
+
+	bs, err := json.Marshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, `"3q2+7w=="`, string(bs))
+
+	var decoded Base64
+	err = json.Unmarshal(bs, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+}
+
+func TestUnmarshalHex(t *testing.T) {
+	var tests = []struct {
+		name    string
+		input   string
+		want    []byte
+		wantErr bool
+	}{
+		{name: "prefixed hex", input: `"0xdeadbeef"`, want: []byte{0xde, 0xad, 0xbe, 0xef}},
+		{name: "null", input: `null`},
+		// base64 is never decoded as hex, even if it's valid hex
+		{name: "base64", input: `"3q2+7w=="`, wantErr: true},
+		{name: "unprefixed hex", input: `"deadbeef"`, wantErr: true},
+		{name: "invalid hex", input: `"0xzz"`, wantErr: true},
+		{name: "not a string", input: `[1,2]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalHex([]byte(tt.input))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUnmarshalBase64(t *testing.T) {
+	var tests = []struct {
+		name    string
+		input   string
+		want    []byte
+		wantErr bool
+	}{
+		{name: "base64", input: `"3q2+7w=="`, want: []byte{0xde, 0xad, 0xbe, 0xef}},
+		// valid base64 starting with 0x is never decoded as hex
+		{name: "base64 with hex prefix", input: `"0xde"`, want: []byte{0xd3, 0x17, 0x5e}},
+		{name: "null", input: `null`},
+		{name: "prefixed hex", input: `"0xdeadbeef0"`, wantErr: true},
+		{name: "not a string", input: `[1,2]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalBase64([]byte(tt.input))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	v, err := ParseVersion("")
+	require.NoError(t, err)
+	assert.Equal(t, LegacyVersion, v)
+
+	v, err = ParseVersion(Version)
+	require.NoError(t, err)
+	assert.Equal(t, Version, v)
+
+	_, err = ParseVersion("42")
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
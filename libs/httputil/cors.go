@@ -0,0 +1,55 @@
+package httputil
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/rs/cors"
+)
+
+// CORSConfig configures the origins browsers are allowed to call a server from.
+type CORSConfig struct {
+	// AllowedOrigins are the origins allowed to call the server, e.g. "https://example.com".
+	// Wildcards are supported, e.g. "https://*.example.com" or "*" for any origin. Empty keeps
+	// browsers from calling the server from other origins.
+	AllowedOrigins []string `toml:",omitempty"`
+	// AllowedHeaders are the headers allowed in addition to Authorization and Content-Type.
+	AllowedHeaders []string `toml:",omitempty"`
+}
+
+// WithCORS wraps the handler, so that it answers preflight requests and tells browsers the
+// allowed origins. The handler is returned as is if no origins are allowed.
+func WithCORS(cfg CORSConfig, next http.Handler) http.Handler {
+	if len(cfg.AllowedOrigins) == 0 {
+		return next
+	}
+	return cors.New(cors.Options{
+		AllowedOrigins: cfg.AllowedOrigins,
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodOptions},
+		AllowedHeaders: append([]string{"Authorization", "Content-Type"}, cfg.AllowedHeaders...),
+		MaxAge:         600,
+	}).Handler(next)
+}
+
+// CheckOrigin returns the origin check of WebSocket upgrades matching WithCORS. Requests from the
+// allowed origins and requests without an origin, i.e. from non-browser clients, are accepted. If
+// no origins are allowed, only same-origin requests are.
+func CheckOrigin(cfg CORSConfig) func(*http.Request) bool {
+	var allowed func(*http.Request) bool
+	if len(cfg.AllowedOrigins) != 0 {
+		allowed = cors.New(cors.Options{AllowedOrigins: cfg.AllowedOrigins}).OriginAllowed
+	}
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		if allowed != nil {
+			return allowed(r)
+		}
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+}
@@ -0,0 +1,34 @@
+package httputil
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckOrigin(t *testing.T) {
+	var tests = []struct {
+		name    string
+		allowed []string
+		origin  string
+		ok      bool
+	}{
+		{"no origin", []string{"https://example.com"}, "", true},
+		{"allowed origin", []string{"https://example.com"}, "https://example.com", true},
+		{"wildcard origin", []string{"https://*.example.com"}, "https://app.example.com", true},
+		{"other origin", []string{"https://example.com"}, "https://evil.com", false},
+		{"same origin by default", nil, "http://node.example.com:26659", true},
+		{"cross origin by default", nil, "https://evil.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://node.example.com:26659/ws/events", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			check := CheckOrigin(CORSConfig{AllowedOrigins: tt.allowed})
+			assert.Equal(t, tt.ok, check(r))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package httputil
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// forwardedForHeader is the header reverse proxies append the IPs of their clients to.
+const forwardedForHeader = "X-Forwarded-For"
+
+// TrustedProxies are the reverse proxies trusted to report the IPs of the clients of a server
+// in the X-Forwarded-For header.
+type TrustedProxies struct {
+	nets []*net.IPNet
+}
+
+// ParseTrustedProxies parses the IPs or CIDR ranges of the trusted proxies, e.g. "10.0.0.1" or
+// "10.0.0.0/8".
+func ParseTrustedProxies(proxies []string) (*TrustedProxies, error) {
+	p := &TrustedProxies{nets: make([]*net.IPNet, 0, len(proxies))}
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("trusted proxies: invalid IP %s", proxy)
+			}
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			p.nets = append(p.nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxies: %w", err)
+		}
+		p.nets = append(p.nets, ipNet)
+	}
+	return p, nil
+}
+
+// ClientIP returns the IP of the client of the request. Requests of trusted proxies are attributed
+// to the last IP of the X-Forwarded-For header that isn't a trusted proxy, as the ones before it
+// can be forged by the client. Otherwise, the request is attributed to its remote address.
+func (p *TrustedProxies) ClientIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	if p == nil || len(p.nets) == 0 || !p.trusted(ip) {
+		return ip
+	}
+
+	var forwarded []string
+	for _, header := range r.Header.Values(forwardedForHeader) {
+		forwarded = append(forwarded, strings.Split(header, ",")...)
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(forwarded[i])
+		if net.ParseIP(hop) == nil {
+			// the hop was not added by a proxy, so the remaining ones can't be trusted either
+			break
+		}
+		ip = hop
+		if !p.trusted(hop) {
+			break
+		}
+	}
+	return ip
+}
+
+func (p *TrustedProxies) trusted(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, ipNet := range p.nets {
+		if ipNet.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustedProxies_ClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1", "::1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		proxies   *TrustedProxies
+		remote    string
+		forwarded []string
+		expected  string
+	}{
+		{"untrusted proxies", nil, "10.0.0.1:1234", []string{"1.1.1.1"}, "10.0.0.1"},
+		{"untrusted remote", proxies, "2.2.2.2:1234", []string{"1.1.1.1"}, "2.2.2.2"},
+		{"no header", proxies, "10.0.0.1:1234", nil, "10.0.0.1"},
+		{"client", proxies, "10.0.0.1:1234", []string{"1.1.1.1"}, "1.1.1.1"},
+		{"proxy chain", proxies, "10.0.0.1:1234", []string{"1.1.1.1, 192.168.1.1", "10.1.1.1"}, "1.1.1.1"},
+		{"forged hops", proxies, "10.0.0.1:1234", []string{"3.3.3.3, 1.1.1.1"}, "1.1.1.1"},
+		{"invalid hop", proxies, "10.0.0.1:1234", []string{"1.1.1.1, unknown"}, "10.0.0.1"},
+		{"ipv6", proxies, "[::1]:1234", []string{"2001:db8::1"}, "2001:db8::1"},
+		{"only proxies", proxies, "10.0.0.1:1234", []string{"10.0.0.2"}, "10.0.0.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remote
+			for _, header := range tt.forwarded {
+				r.Header.Add(forwardedForHeader, header)
+			}
+			assert.Equal(t, tt.expected, tt.proxies.ClientIP(r))
+		})
+	}
+
+	_, err = ParseTrustedProxies([]string{"10.0.0.0/33"})
+	require.Error(t, err)
+	_, err = ParseTrustedProxies([]string{"proxy"})
+	require.Error(t, err)
+}
+
+func TestWithCORS(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := WithCORS(CORSConfig{AllowedOrigins: []string{"https://example.com"}}, next)
+
+	r := httptest.NewRequest(http.MethodOptions, "/", nil)
+	r.Header.Set("Origin", "https://example.com")
+	r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	r.Header.Set("Access-Control-Request-Headers", "Authorization")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, "https://example.com", w.Header().Get("Access-Control-Allow-Origin"))
+
+	r = httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set("Origin", "https://other.com")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,102 @@
+// Package httputil provides TLS termination, CORS and handling of reverse proxies shared by the
+// HTTP servers of the node, i.e. the RPC and the gateway.
+package httputil
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// TLSConfig configures TLS termination of a server.
+type TLSConfig struct {
+	// Enabled makes the server serve HTTPS instead of HTTP.
+	Enabled bool
+	// CertFile and KeyFile are the paths of the PEM-encoded certificate of the server and its key.
+	CertFile string
+	KeyFile  string
+	// ACMEDomains makes the server obtain and renew certificates of the domains from Let's Encrypt
+	// instead of loading them from CertFile and KeyFile. The TLS-ALPN-01 challenge is used, which
+	// Let's Encrypt only sends to port 443, so port 443 of the domains must be forwarded to the
+	// port of the server, e.g. by a firewall rule or a TCP proxy passing TLS through. All servers
+	// of the node share a single ACME account, so any of them answers the challenges of all the
+	// domains.
+	ACMEDomains []string `toml:",omitempty"`
+	// ACMEEmail is the optional contact email of the ACME account.
+	ACMEEmail string `toml:",omitempty"`
+}
+
+// Validate performs basic validation of the config.
+func (cfg *TLSConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if len(cfg.ACMEDomains) != 0 {
+		if cfg.CertFile != "" || cfg.KeyFile != "" {
+			return errors.New("tls: either ACME domains or certificate files can be set")
+		}
+		return nil
+	}
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return errors.New("tls: certificate and key files or ACME domains must be set")
+	}
+	return nil
+}
+
+// NewACMEManager returns the autocert.Manager obtaining certificates of the ACME domains of all the
+// given configs, which caches them in the given directory, or nil if none of the enabled configs
+// use ACME. Servers must share a single manager, as managers sharing a cache directory race on
+// registering the ACME account and on renewing certificates.
+func NewACMEManager(cacheDir string, cfgs ...TLSConfig) *autocert.Manager {
+	var (
+		domains []string
+		email   string
+	)
+	for _, cfg := range cfgs {
+		if !cfg.Enabled || len(cfg.ACMEDomains) == 0 {
+			continue
+		}
+		domains = append(domains, cfg.ACMEDomains...)
+		if email == "" {
+			email = cfg.ACMEEmail
+		}
+	}
+	if len(domains) == 0 {
+		return nil
+	}
+
+	return &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      autocert.DirCache(cacheDir),
+		HostPolicy: autocert.HostWhitelist(domains...),
+		Email:      email,
+	}
+}
+
+// NewTLSConfig returns the tls.Config of a server or nil if TLS is disabled. Certificates of ACME
+// domains are obtained by the given manager, which must have been created with the config.
+func NewTLSConfig(cfg TLSConfig, acme *autocert.Manager) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	if len(cfg.ACMEDomains) != 0 {
+		if acme == nil {
+			return nil, errors.New("tls: ACME domains are set, but there is no ACME manager")
+		}
+		tlsCfg := acme.TLSConfig()
+		tlsCfg.MinVersion = tls.VersionTLS12
+		return tlsCfg, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("tls: loading certificate: %w", err)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
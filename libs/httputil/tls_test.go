@@ -0,0 +1,93 @@
+package httputil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	cfg, err := NewTLSConfig(TLSConfig{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+	tlsCfg := TLSConfig{Enabled: true, CertFile: certFile, KeyFile: keyFile}
+	require.NoError(t, tlsCfg.Validate())
+	cfg, err = NewTLSConfig(tlsCfg, nil)
+	require.NoError(t, err)
+	assert.Len(t, cfg.Certificates, 1)
+
+	acmeCfg := TLSConfig{Enabled: true, ACMEDomains: []string{"example.com"}}
+	require.NoError(t, acmeCfg.Validate())
+	_, err = NewTLSConfig(acmeCfg, nil)
+	assert.Error(t, err)
+	cfg, err = NewTLSConfig(acmeCfg, NewACMEManager(t.TempDir(), acmeCfg))
+	require.NoError(t, err)
+	assert.NotNil(t, cfg.GetCertificate)
+
+	invalid := []TLSConfig{
+		{Enabled: true},
+		{Enabled: true, CertFile: certFile},
+		{Enabled: true, CertFile: certFile, KeyFile: keyFile, ACMEDomains: []string{"example.com"}},
+	}
+	for _, cfg := range invalid {
+		assert.Error(t, cfg.Validate())
+	}
+}
+
+func TestNewACMEManager(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+	files := TLSConfig{Enabled: true, CertFile: certFile, KeyFile: keyFile}
+	assert.Nil(t, NewACMEManager(t.TempDir(), TLSConfig{}, files))
+
+	rpc := TLSConfig{Enabled: true, ACMEDomains: []string{"rpc.example.com"}, ACMEEmail: "ops@example.com"}
+	gateway := TLSConfig{Enabled: true, ACMEDomains: []string{"gateway.example.com"}}
+	disabled := TLSConfig{ACMEDomains: []string{"disabled.example.com"}}
+	manager := NewACMEManager(t.TempDir(), rpc, gateway, disabled, files)
+	require.NotNil(t, manager)
+	assert.Equal(t, "ops@example.com", manager.Email)
+
+	// a single manager serves the domains of all the servers
+	ctx := context.Background()
+	assert.NoError(t, manager.HostPolicy(ctx, "rpc.example.com"))
+	assert.NoError(t, manager.HostPolicy(ctx, "gateway.example.com"))
+	assert.Error(t, manager.HostPolicy(ctx, "disabled.example.com"))
+}
+
+// writeTestCertificate writes a self-signed certificate of localhost and its key into the given
+// directory.
+func writeTestCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	require.NoError(t, err)
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+	require.NoError(t, err)
+	return certFile, keyFile
+}
@@ -0,0 +1,245 @@
+// Package remotesigner signs transactions with keys held by a remote signer, so that the node
+// doesn't need hot keys on its machine. The signer serves the chosen keys of its keyring over
+// JSON-RPC on a unix socket or over TLS, and the node uses a keyring forwarding signing of the
+// remote keys to it.
+package remotesigner
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/filecoin-project/go-jsonrpc"
+	logging "github.com/ipfs/go-log/v2"
+)
+
+var log = logging.Logger("remotesigner")
+
+// namespace is the JSON-RPC namespace of the signer.
+const namespace = "signer"
+
+// authKey is the header carrying the token of the signer.
+const authKey = "Authorization"
+
+// SignTimeout is the time the signer has to sign, which includes manual approval, if the signer
+// requires it.
+var SignTimeout = time.Minute
+
+var (
+	// ErrInvalidSignature is returned when the signature returned by the signer doesn't verify
+	// against the public key of the signing key.
+	ErrInvalidSignature = errors.New("remotesigner: invalid signature")
+	// ErrKeyNotServed is returned by the signer for the keys it doesn't serve.
+	ErrKeyNotServed = errors.New("remotesigner: key is not served")
+)
+
+// Policy decides whether the signer signs the message with the key of the given name, returning an
+// error if it doesn't.
+type Policy func(name string, msg []byte) error
+
+// ChainPolicy makes the signer sign only the transactions of the given chain, i.e. the SignDocs
+// with its chain ID, so that signatures can't be replayed elsewhere or obtained for arbitrary data.
+func ChainPolicy(chainID string) Policy {
+	return func(_ string, msg []byte) error {
+		var doc tx.SignDoc
+		if err := doc.Unmarshal(msg); err != nil {
+			return fmt.Errorf("remotesigner: message is not a transaction: %w", err)
+		}
+		if doc.ChainId != chainID {
+			return fmt.Errorf("remotesigner: transaction of chain %q instead of %q", doc.ChainId, chainID)
+		}
+		return nil
+	}
+}
+
+// API is the JSON-RPC API of the signer.
+type API struct {
+	Internal struct {
+		PubKey func(ctx context.Context, name string) ([]byte, error)
+		Sign   func(ctx context.Context, name string, msg []byte) ([]byte, error)
+	}
+}
+
+// Handler serves signing with the keys of the given names of the keyring to the clients presenting
+// the token. Other keys of the keyring are not served. Messages are signed only if the policy, if
+// any, accepts them.
+func Handler(ring keyring.Keyring, token string, keys []string, policy Policy) http.Handler {
+	served := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		served[key] = true
+	}
+	rpc := jsonrpc.NewServer()
+	rpc.Register(namespace, &service{ring: ring, keys: served, policy: policy})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := []byte(r.Header.Get(authKey))
+		if subtle.ConstantTimeCompare(auth, []byte("Bearer "+token)) != 1 {
+			log.Warnw("unauthorized request", "remote", r.RemoteAddr)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		rpc.ServeHTTP(w, r)
+	})
+}
+
+type service struct {
+	ring   keyring.Keyring
+	keys   map[string]bool
+	policy Policy
+}
+
+func (s *service) PubKey(_ context.Context, name string) ([]byte, error) {
+	if !s.keys[name] {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotServed, name)
+	}
+	rec, err := s.ring.Key(name)
+	if err != nil {
+		return nil, err
+	}
+	pk, err := rec.GetPubKey()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := pk.(*secp256k1.PubKey); !ok {
+		return nil, fmt.Errorf("unsupported key type %s", pk.Type())
+	}
+	return pk.Bytes(), nil
+}
+
+func (s *service) Sign(_ context.Context, name string, msg []byte) ([]byte, error) {
+	if !s.keys[name] {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotServed, name)
+	}
+	if s.policy != nil {
+		if err := s.policy(name, msg); err != nil {
+			log.Warnw("refused to sign", "key", name, "err", err)
+			return nil, err
+		}
+	}
+	sig, _, err := s.ring.Sign(name, msg)
+	if err != nil {
+		return nil, err
+	}
+	log.Infow("signed", "key", name)
+	return sig, nil
+}
+
+// Keyring signs with the remote keys through the signer, and with the other keys of the wrapped
+// keyring locally.
+type Keyring struct {
+	keyring.Keyring
+
+	api    API
+	closer jsonrpc.ClientCloser
+	// remote are the public keys of the remote keys by their names.
+	remote map[string]cryptotypes.PubKey
+}
+
+// NewKeyring connects to the signer at the given address and wraps the local keyring, so that the
+// keys of the given names are signed with by the signer. The address must be a unix:///path/to/socket
+// or an https:// URL, which is connected to with the TLS config, if any. The public keys of the
+// remote keys are stored in the local keyring as offline keys, so that their accounts are looked up
+// as the local ones.
+func NewKeyring(
+	ctx context.Context,
+	local keyring.Keyring,
+	addr, token string,
+	tlsCfg *tls.Config,
+	names ...string,
+) (*Keyring, error) {
+	rpcAddr, client, err := dial(addr, tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &Keyring{Keyring: local, remote: make(map[string]cryptotypes.PubKey, len(names))}
+	header := http.Header{authKey: []string{"Bearer " + token}}
+	closer, err := jsonrpc.NewMergeClient(ctx, rpcAddr, namespace, []interface{}{&k.api.Internal}, header,
+		jsonrpc.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("remotesigner: connecting to signer: %w", err)
+	}
+	k.closer = closer
+
+	for _, name := range names {
+		if err = k.addRemoteKey(ctx, name); err != nil {
+			closer()
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// addRemoteKey stores the public key of the remote key as an offline key in the local keyring.
+func (k *Keyring) addRemoteKey(ctx context.Context, name string) error {
+	bin, err := k.api.Internal.PubKey(ctx, name)
+	if err != nil {
+		return fmt.Errorf("remotesigner: getting public key of %s: %w", name, err)
+	}
+	pk := &secp256k1.PubKey{Key: bin}
+
+	rec, err := k.Keyring.Key(name)
+	switch {
+	case err == nil:
+		local, err := rec.GetPubKey()
+		if err != nil {
+			return err
+		}
+		if !local.Equals(pk) {
+			return fmt.Errorf("remotesigner: local key %s differs from the remote one", name)
+		}
+	case errors.Is(err, sdkerrors.ErrKeyNotFound):
+		if _, err = k.Keyring.SaveOfflineKey(name, pk); err != nil {
+			return fmt.Errorf("remotesigner: storing public key of %s: %w", name, err)
+		}
+	default:
+		return err
+	}
+
+	k.remote[name] = pk
+	log.Infow("using remote key", "name", name, "address", sdk.AccAddress(pk.Address()).String())
+	return nil
+}
+
+// Sign signs the message with the key of the given name, through the signer, if the key is remote.
+func (k *Keyring) Sign(uid string, msg []byte) ([]byte, cryptotypes.PubKey, error) {
+	pk, ok := k.remote[uid]
+	if !ok {
+		return k.Keyring.Sign(uid, msg)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), SignTimeout)
+	defer cancel()
+	sig, err := k.api.Internal.Sign(ctx, uid, msg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("remotesigner: signing with %s: %w", uid, err)
+	}
+	if !pk.VerifySignature(msg, sig) {
+		return nil, nil, fmt.Errorf("%w: key %s", ErrInvalidSignature, uid)
+	}
+	return sig, pk, nil
+}
+
+// SignByAddress signs the message with the key of the given address, through the signer, if the
+// key is remote.
+func (k *Keyring) SignByAddress(address sdk.Address, msg []byte) ([]byte, cryptotypes.PubKey, error) {
+	rec, err := k.Keyring.KeyByAddress(address)
+	if err != nil {
+		return nil, nil, err
+	}
+	return k.Sign(rec.Name, msg)
+}
+
+// Close closes the connection to the signer.
+func (k *Keyring) Close() {
+	k.closer()
+}
@@ -0,0 +1,122 @@
+package remotesigner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+)
+
+func TestKeyring(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	remote := newKeyring(t)
+	rec, _, err := remote.NewMnemonic("remote", keyring.English, "", "", hd.Secp256k1)
+	require.NoError(t, err)
+	_, _, err = remote.NewMnemonic("unserved", keyring.English, "", "", hd.Secp256k1)
+	require.NoError(t, err)
+	srv := httptest.NewTLSServer(Handler(remote, "token", []string{"remote"}, nil))
+	t.Cleanup(srv.Close)
+	tlsCfg := srv.Client().Transport.(*http.Transport).TLSClientConfig
+
+	local := newKeyring(t)
+	_, _, err = local.NewMnemonic("local", keyring.English, "", "", hd.Secp256k1)
+	require.NoError(t, err)
+
+	_, err = NewKeyring(ctx, local, srv.URL, "wrong", tlsCfg, "remote")
+	require.Error(t, err)
+	// the signer is not connected to over plaintext HTTP
+	_, err = NewKeyring(ctx, local, "http"+strings.TrimPrefix(srv.URL, "https"), "token", nil, "remote")
+	require.ErrorIs(t, err, ErrInsecure)
+	// keys not served by the signer are not available
+	_, err = NewKeyring(ctx, local, srv.URL, "token", tlsCfg, "unserved")
+	require.Error(t, err)
+
+	k, err := NewKeyring(ctx, local, srv.URL, "token", tlsCfg, "remote")
+	require.NoError(t, err)
+	t.Cleanup(k.Close)
+
+	// the remote key is stored as an offline one, so it is found by its address
+	addr, err := rec.GetAddress()
+	require.NoError(t, err)
+	stored, err := k.KeyByAddress(addr)
+	require.NoError(t, err)
+	assert.Equal(t, keyring.TypeOffline, stored.GetType())
+
+	msg := []byte("msg")
+	sig, pk, err := k.SignByAddress(addr, msg)
+	require.NoError(t, err)
+	assert.True(t, pk.VerifySignature(msg, sig))
+
+	// local keys are signed with locally
+	sig, pk, err = k.Sign("local", msg)
+	require.NoError(t, err)
+	assert.True(t, pk.VerifySignature(msg, sig))
+
+	// signatures by a different key are rejected
+	_, _, err = remote.NewMnemonic("other", keyring.English, "", "", hd.Secp256k1)
+	require.NoError(t, err)
+	require.NoError(t, remote.Delete("remote"))
+	require.NoError(t, remote.Rename("other", "remote"))
+	_, _, err = k.Sign("remote", msg)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestChainPolicy(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	remote := newKeyring(t)
+	_, _, err := remote.NewMnemonic("remote", keyring.English, "", "", hd.Secp256k1)
+	require.NoError(t, err)
+	socket := unixScheme + filepath.Join(t.TempDir(), "signer.sock")
+	serveCtx, stop := context.WithCancel(ctx)
+	served := make(chan error, 1)
+	go func() {
+		served <- Serve(serveCtx, socket, nil, Handler(remote, "token", []string{"remote"}, ChainPolicy("chain")))
+	}()
+	t.Cleanup(func() {
+		stop()
+		require.NoError(t, <-served)
+	})
+
+	// plaintext TCP is not served
+	require.ErrorIs(t, Serve(ctx, "127.0.0.1:0", nil, http.NotFoundHandler()), ErrInsecure)
+
+	var k *Keyring
+	require.Eventually(t, func() bool {
+		k, err = NewKeyring(ctx, newKeyring(t), socket, "token", nil, "remote")
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+	t.Cleanup(k.Close)
+
+	doc, err := (&tx.SignDoc{ChainId: "chain"}).Marshal()
+	require.NoError(t, err)
+	_, _, err = k.Sign("remote", doc)
+	require.NoError(t, err)
+
+	doc, err = (&tx.SignDoc{ChainId: "other"}).Marshal()
+	require.NoError(t, err)
+	_, _, err = k.Sign("remote", doc)
+	require.Error(t, err)
+	_, _, err = k.Sign("remote", []byte("msg"))
+	require.Error(t, err)
+}
+
+func newKeyring(t *testing.T) keyring.Keyring {
+	cdc := encoding.MakeConfig(app.ModuleEncodingRegisters...).Codec
+	return keyring.NewInMemory(cdc)
+}
@@ -0,0 +1,95 @@
+package remotesigner
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// unixScheme is the scheme of the addresses of signers listening on unix sockets.
+const unixScheme = "unix://"
+
+// ErrInsecure is returned when the signer would be served or connected to over plaintext TCP, which
+// would expose the token and the signed messages.
+var ErrInsecure = errors.New("remotesigner: signer must be served over TLS or a unix socket")
+
+// Serve serves the handler on the given address until the context is canceled. Addresses of the
+// form unix:///path/to/socket are unix sockets, which only the owner of the socket can connect to.
+// Any other address is a TCP one, which requires the TLS config.
+func Serve(ctx context.Context, addr string, tlsCfg *tls.Config, handler http.Handler) error {
+	var (
+		listener net.Listener
+		err      error
+	)
+	if path, ok := strings.CutPrefix(addr, unixScheme); ok {
+		// stale sockets of previous runs are replaced
+		if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		listener, err = net.Listen("unix", path)
+		if err != nil {
+			return err
+		}
+		if err = os.Chmod(path, 0o600); err != nil {
+			listener.Close()
+			return err
+		}
+	} else {
+		if tlsCfg == nil {
+			return ErrInsecure
+		}
+		listener, err = net.Listen("tcp", addr)
+		if err != nil {
+			return err
+		}
+		listener = tls.NewListener(listener, tlsCfg)
+	}
+
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		// signing may wait for manual approval
+		WriteTimeout: SignTimeout + 30*time.Second,
+		IdleTimeout:  2 * time.Minute,
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(listener)
+	}()
+	select {
+	case err = <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// dial returns the JSON-RPC address of the signer at the given address and the HTTP client to
+// connect to it with. Addresses must be unix:///path/to/socket or https:// URLs. The TLS config is
+// used for the latter, e.g. to trust the certificate of the signer, and the system's roots are
+// trusted if it is nil.
+func dial(addr string, tlsCfg *tls.Config) (string, *http.Client, error) {
+	if path, ok := strings.CutPrefix(addr, unixScheme); ok {
+		transport := &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", path)
+			},
+		}
+		return "http://signer", &http.Client{Transport: transport}, nil
+	}
+	if !strings.HasPrefix(addr, "https://") {
+		return "", nil, fmt.Errorf("%w: %s", ErrInsecure, addr)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+	return addr, &http.Client{Transport: transport}, nil
+}
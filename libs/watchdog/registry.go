@@ -0,0 +1,144 @@
+package watchdog
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Registry keeps the heartbeats of long-running loops of the node, so that loops which stopped
+// checking in are detected as stuck.
+//
+// Registry is safe for concurrent use. A nil Registry registers nil Heartbeats, which are no-ops,
+// so that loops don't depend on whether the watchdog is enabled.
+type Registry struct {
+	interval  time.Duration
+	maxMissed int
+
+	lk    sync.Mutex
+	loops map[string]*Heartbeat
+}
+
+// NewRegistry creates a Registry of loops checking in every interval. Loops missing more than
+// maxMissed heartbeats in a row are considered stuck.
+func NewRegistry(interval time.Duration, maxMissed int) *Registry {
+	return &Registry{
+		interval:  interval,
+		maxMissed: maxMissed,
+		loops:     make(map[string]*Heartbeat),
+	}
+}
+
+// Register registers the loop with the given name owned by the given module and returns its
+// Heartbeat. A loop registered under a name already in use, e.g. after its module restarted,
+// replaces the previous one. The Heartbeat must be stopped once the loop exits.
+func (r *Registry) Register(module, name string) *Heartbeat {
+	if r == nil {
+		return nil
+	}
+
+	hb := &Heartbeat{
+		registry: r,
+		module:   module,
+		name:     name,
+		ticker:   time.NewTicker(r.interval),
+	}
+	hb.lastBeat.Store(time.Now().UnixNano())
+
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	if prev, ok := r.loops[name]; ok {
+		prev.ticker.Stop()
+	}
+	r.loops[name] = hb
+	return hb
+}
+
+// Status describes the liveness of a registered loop.
+type Status struct {
+	Name   string `json:"name"`
+	Module string `json:"module"`
+	// LastBeat is the time the loop checked in last.
+	LastBeat time.Time `json:"last_beat"`
+	// Missed is the amount of heartbeats missed since the last one.
+	Missed int `json:"missed"`
+	// Stuck is true if the loop missed more heartbeats than allowed.
+	Stuck bool `json:"stuck"`
+}
+
+// Status returns the liveness of all registered loops ordered by name.
+func (r *Registry) Status() []Status {
+	if r == nil {
+		return []Status{}
+	}
+
+	r.lk.Lock()
+	loops := make([]*Heartbeat, 0, len(r.loops))
+	for _, hb := range r.loops {
+		loops = append(loops, hb)
+	}
+	r.lk.Unlock()
+
+	now := time.Now()
+	statuses := make([]Status, len(loops))
+	for i, hb := range loops {
+		last := time.Unix(0, hb.lastBeat.Load())
+		missed := int(now.Sub(last) / r.interval)
+		statuses[i] = Status{
+			Name:     hb.name,
+			Module:   hb.module,
+			LastBeat: last,
+			Missed:   missed,
+			Stuck:    missed > r.maxMissed,
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// Heartbeat is the check-in of a loop registered in the Registry. All its methods are no-ops on a
+// nil Heartbeat.
+type Heartbeat struct {
+	registry *Registry
+	module   string
+	name     string
+	ticker   *time.Ticker
+	lastBeat atomic.Int64
+}
+
+// Tick returns the channel ticking every heartbeat interval. Loops waiting for events select on it
+// along with the events and Beat on every tick, so that they check in while idle. It returns a nil
+// channel on a nil Heartbeat, which never ticks.
+func (hb *Heartbeat) Tick() <-chan time.Time {
+	if hb == nil {
+		return nil
+	}
+	return hb.ticker.C
+}
+
+// Beat checks the loop in.
+func (hb *Heartbeat) Beat() {
+	if hb == nil {
+		return
+	}
+	hb.lastBeat.Store(time.Now().UnixNano())
+}
+
+// Stop unregisters the loop, once it exits.
+func (hb *Heartbeat) Stop() {
+	if hb == nil {
+		return
+	}
+	hb.ticker.Stop()
+
+	r := hb.registry
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	// the loop may have been replaced by a new one under the same name
+	if r.loops[hb.name] == hb {
+		delete(r.loops, hb.name)
+	}
+}
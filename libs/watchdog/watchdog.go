@@ -0,0 +1,144 @@
+package watchdog
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+)
+
+var log = logging.Logger("watchdog")
+
+// Restarter restarts a module, e.g. by stopping and starting its components.
+type Restarter func(context.Context) error
+
+// Watchdog periodically checks the loops of the Registry and flags the stuck ones. Modules with a
+// Restarter are restarted once any of their loops gets stuck, if restarts are enabled.
+type Watchdog struct {
+	registry *Registry
+	restart  bool
+
+	lk         sync.Mutex
+	restarters map[string]Restarter
+	// restarting holds the modules being restarted
+	restarting map[string]struct{}
+	// stuck holds the loops reported stuck, so that they are logged once
+	stuck map[string]struct{}
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewWatchdog creates a Watchdog of the loops of the given Registry. Stuck modules are restarted
+// if restart is true. A Watchdog of a nil Registry does nothing.
+func NewWatchdog(registry *Registry, restart bool) *Watchdog {
+	return &Watchdog{
+		registry:   registry,
+		restart:    restart,
+		restarters: make(map[string]Restarter),
+		restarting: make(map[string]struct{}),
+		stuck:      make(map[string]struct{}),
+	}
+}
+
+// SetRestarter sets the Restarter of the given module.
+func (w *Watchdog) SetRestarter(module string, restart Restarter) {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+	w.restarters[module] = restart
+}
+
+// Start starts checking the loops every heartbeat interval.
+func (w *Watchdog) Start(context.Context) error {
+	if w.registry == nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+	go w.run(ctx)
+	return nil
+}
+
+// Stop stops checking the loops.
+func (w *Watchdog) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Status returns the liveness of all registered loops.
+func (w *Watchdog) Status() []Status {
+	return w.registry.Status()
+}
+
+func (w *Watchdog) run(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.registry.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.check(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check flags the stuck loops and restarts their modules.
+func (w *Watchdog) check(ctx context.Context) {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	stuck := make(map[string]struct{})
+	for _, st := range w.Status() {
+		if !st.Stuck {
+			continue
+		}
+		stuck[st.Name] = struct{}{}
+		if _, ok := w.stuck[st.Name]; !ok {
+			log.Errorw("loop is stuck", "name", st.Name, "module", st.Module,
+				"last_beat", st.LastBeat, "missed", st.Missed)
+		}
+		w.restartModule(ctx, st.Module)
+	}
+	w.stuck = stuck
+}
+
+// restartModule restarts the module in the background, unless it is already being restarted.
+func (w *Watchdog) restartModule(ctx context.Context, module string) {
+	restart, ok := w.restarters[module]
+	if !w.restart || !ok {
+		return
+	}
+	if _, ok := w.restarting[module]; ok {
+		return
+	}
+	w.restarting[module] = struct{}{}
+
+	go func() {
+		// stuck loops may block stopping of the module, so the restart is bounded in time
+		timeout := w.registry.interval * time.Duration(w.registry.maxMissed+1)
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		log.Warnw("restarting module", "module", module)
+		if err := restart(ctx); err != nil {
+			log.Errorw("restarting module", "module", module, "err", err)
+		}
+
+		w.lk.Lock()
+		delete(w.restarting, module)
+		w.lk.Unlock()
+	}()
+}
@@ -0,0 +1,95 @@
+package watchdog
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry(10*time.Millisecond, 2)
+
+	alive := r.Register("das", "alive")
+	t.Cleanup(alive.Stop)
+	stuck := r.Register("core", "stuck")
+	t.Cleanup(stuck.Stop)
+
+	deadline := time.After(100 * time.Millisecond)
+	for done := false; !done; {
+		select {
+		case <-alive.Tick():
+			alive.Beat()
+		case <-deadline:
+			done = true
+		}
+	}
+
+	statuses := r.Status()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "alive", statuses[0].Name)
+	assert.Equal(t, "das", statuses[0].Module)
+	assert.False(t, statuses[0].Stuck)
+	assert.Equal(t, "stuck", statuses[1].Name)
+	assert.True(t, statuses[1].Stuck)
+	assert.Greater(t, statuses[1].Missed, 2)
+
+	// stopped heartbeats don't remove the loops replacing them
+	replaced := r.Register("core", "stuck")
+	stuck.Stop()
+	require.Len(t, r.Status(), 2)
+	replaced.Stop()
+	require.Len(t, r.Status(), 1)
+}
+
+func TestRegistry_Nil(t *testing.T) {
+	var r *Registry
+	hb := r.Register("das", "loop")
+	require.Nil(t, hb)
+	require.Nil(t, hb.Tick())
+	hb.Beat()
+	hb.Stop()
+	require.Empty(t, r.Status())
+}
+
+func TestWatchdog_Restart(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	r := NewRegistry(10*time.Millisecond, 1)
+	w := NewWatchdog(r, true)
+
+	var restarts atomic.Int32
+	// the restarted module registers its loop again, which keeps checking in
+	w.SetRestarter("das", func(context.Context) error {
+		restarts.Add(1)
+		hb := r.Register("das", "loop")
+		go func() {
+			for range hb.Tick() {
+				hb.Beat()
+			}
+		}()
+		t.Cleanup(hb.Stop)
+		return nil
+	})
+	r.Register("das", "loop")
+
+	require.NoError(t, w.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, w.Stop(ctx))
+	})
+
+	require.Eventually(t, func() bool {
+		return restarts.Load() == 1
+	}, time.Second, 5*time.Millisecond)
+	require.Eventually(t, func() bool {
+		statuses := w.Status()
+		return len(statuses) == 1 && !statuses[0].Stuck
+	}, time.Second, 5*time.Millisecond)
+	// the module is not restarted again, as its loop is alive
+	time.Sleep(50 * time.Millisecond)
+	require.EqualValues(t, 1, restarts.Load())
+}
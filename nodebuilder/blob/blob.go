@@ -18,24 +18,53 @@ type Module interface {
 	// Allows sending multiple Blobs atomically synchronously.
 	// Uses default wallet registered on the Node.
 	Submit(_ context.Context, _ []*blob.Blob) (height uint64, _ error)
+	// SubmitAll packs Blobs of any namespaces into a single PayForBlobs transaction and reports the
+	// height in which they were included. Gas is estimated and the default wallet registered on the
+	// Node pays, unless set otherwise by the options.
+	SubmitAll(_ context.Context, _ []*blob.Blob, _ *blob.GasOptions) (height uint64, _ error)
+	// EstimateGas estimates the gas required to submit Blobs in a single PayForBlobs transaction.
+	EstimateGas(_ context.Context, _ []*blob.Blob) (uint64, error)
+	// EstimateFee suggests the gas limit, gas price and fee to submit Blobs in a single PayForBlobs
+	// transaction.
+	EstimateFee(_ context.Context, _ []*blob.Blob) (*blob.FeeEstimate, error)
 	// Get retrieves the blob by commitment under the given namespace and height.
 	Get(_ context.Context, height uint64, _ namespace.ID, _ blob.Commitment) (*blob.Blob, error)
+	// GetStream emits the data of the blob by commitment under the given namespace and height in
+	// chunks, so that large blobs are received piece by piece.
+	GetStream(_ context.Context, height uint64, _ namespace.ID, _ blob.Commitment) (<-chan []byte, error)
 	// GetAll returns all blobs under the given namespaces and height.
 	GetAll(_ context.Context, height uint64, _ []namespace.ID) ([]*blob.Blob, error)
-	// GetProof retrieves proofs in the given namespaces at the given height by commitment.
+	// GetProof retrieves the self-contained proof of the blob in the given namespace at the given
+	// height by commitment, which can be verified later with blob.VerifyProof against the data root.
 	GetProof(_ context.Context, height uint64, _ namespace.ID, _ blob.Commitment) (*blob.Proof, error)
 	// Included checks whether a blob's given commitment(Merkle subtree root) is included at
-	// given height and under the namespace.
+	// given height and under the namespace, by verifying the proof against the header at the height.
 	Included(_ context.Context, height uint64, _ namespace.ID, _ *blob.Proof, _ blob.Commitment) (bool, error)
+	// Subscribe emits the blobs of the given namespace for every new height, in order and without
+	// gaps. Heights which blobs couldn't be retrieved are reported in events with an error.
+	Subscribe(_ context.Context, _ namespace.ID) (<-chan *blob.BlobEvent, error)
+	// Export exports all blobs of the namespace over the given range of heights (inclusive) along
+	// with their proofs into a portable archive, which can be verified elsewhere.
+	// The heights are emitted one by one and are collected into an archive with blob.CollectArchive.
+	Export(_ context.Context, _ namespace.ID, from, to uint64) (<-chan *blob.ArchiveHeight, error)
+	// Import verifies the archive against the headers of the chain and returns its blobs.
+	Import(_ context.Context, _ *blob.Archive) ([]*blob.Blob, error)
 }
 
 type API struct {
 	Internal struct {
-		Submit   func(context.Context, []*blob.Blob) (uint64, error)                                     `perm:"write"`
-		Get      func(context.Context, uint64, namespace.ID, blob.Commitment) (*blob.Blob, error)        `perm:"read"`
-		GetAll   func(context.Context, uint64, []namespace.ID) ([]*blob.Blob, error)                     `perm:"read"`
-		GetProof func(context.Context, uint64, namespace.ID, blob.Commitment) (*blob.Proof, error)       `perm:"read"`
-		Included func(context.Context, uint64, namespace.ID, *blob.Proof, blob.Commitment) (bool, error) `perm:"read"`
+		Submit      func(context.Context, []*blob.Blob) (uint64, error)                                     `perm:"write"`
+		SubmitAll   func(context.Context, []*blob.Blob, *blob.GasOptions) (uint64, error)                   `perm:"write"`
+		EstimateGas func(context.Context, []*blob.Blob) (uint64, error)                                     `perm:"read"`
+		EstimateFee func(context.Context, []*blob.Blob) (*blob.FeeEstimate, error)                          `perm:"read"`
+		Get         func(context.Context, uint64, namespace.ID, blob.Commitment) (*blob.Blob, error)        `perm:"read"`
+		GetStream   func(context.Context, uint64, namespace.ID, blob.Commitment) (<-chan []byte, error)     `perm:"read"`
+		GetAll      func(context.Context, uint64, []namespace.ID) ([]*blob.Blob, error)                     `perm:"read"`
+		GetProof    func(context.Context, uint64, namespace.ID, blob.Commitment) (*blob.Proof, error)       `perm:"read"`
+		Included    func(context.Context, uint64, namespace.ID, *blob.Proof, blob.Commitment) (bool, error) `perm:"read"`
+		Subscribe   func(context.Context, namespace.ID) (<-chan *blob.BlobEvent, error)                     `perm:"read"`
+		Export      func(context.Context, namespace.ID, uint64, uint64) (<-chan *blob.ArchiveHeight, error) `perm:"read"`
+		Import      func(context.Context, *blob.Archive) ([]*blob.Blob, error)                              `perm:"read"`
 	}
 }
 
@@ -43,6 +72,18 @@ func (api *API) Submit(ctx context.Context, blobs []*blob.Blob) (uint64, error)
 	return api.Internal.Submit(ctx, blobs)
 }
 
+func (api *API) SubmitAll(ctx context.Context, blobs []*blob.Blob, opts *blob.GasOptions) (uint64, error) {
+	return api.Internal.SubmitAll(ctx, blobs, opts)
+}
+
+func (api *API) EstimateGas(ctx context.Context, blobs []*blob.Blob) (uint64, error) {
+	return api.Internal.EstimateGas(ctx, blobs)
+}
+
+func (api *API) EstimateFee(ctx context.Context, blobs []*blob.Blob) (*blob.FeeEstimate, error) {
+	return api.Internal.EstimateFee(ctx, blobs)
+}
+
 func (api *API) Get(
 	ctx context.Context,
 	height uint64,
@@ -52,6 +93,15 @@ func (api *API) Get(
 	return api.Internal.Get(ctx, height, nID, commitment)
 }
 
+func (api *API) GetStream(
+	ctx context.Context,
+	height uint64,
+	nID namespace.ID,
+	commitment blob.Commitment,
+) (<-chan []byte, error) {
+	return api.Internal.GetStream(ctx, height, nID, commitment)
+}
+
 func (api *API) GetAll(ctx context.Context, height uint64, nIDs []namespace.ID) ([]*blob.Blob, error) {
 	return api.Internal.GetAll(ctx, height, nIDs)
 }
@@ -74,3 +124,19 @@ func (api *API) Included(
 ) (bool, error) {
 	return api.Internal.Included(ctx, height, nID, proof, commitment)
 }
+
+func (api *API) Subscribe(ctx context.Context, nID namespace.ID) (<-chan *blob.BlobEvent, error) {
+	return api.Internal.Subscribe(ctx, nID)
+}
+
+func (api *API) Export(
+	ctx context.Context,
+	nID namespace.ID,
+	from, to uint64,
+) (<-chan *blob.ArchiveHeight, error) {
+	return api.Internal.Export(ctx, nID, from, to)
+}
+
+func (api *API) Import(ctx context.Context, archive *blob.Archive) ([]*blob.Blob, error) {
+	return api.Internal.Import(ctx, archive)
+}
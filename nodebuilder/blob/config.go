@@ -0,0 +1,69 @@
+package blob
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+// Config contains configuration parameters for the blob module.
+type Config struct {
+	// Index configures the local index of blobs stored by the node.
+	Index IndexConfig
+	// Fee configures the suggested gas and fees of blob submissions.
+	Fee FeeConfig
+}
+
+// IndexConfig configures the local index of blobs, which lets full and bridge nodes answer
+// historical blob requests without parsing their data squares again.
+type IndexConfig struct {
+	Enabled bool
+	// Interval is how often newly stored heights are indexed.
+	Interval time.Duration
+}
+
+// FeeConfig configures the multipliers applied to the estimated gas and the minimum gas price of
+// the core node for blob submissions, which do not specify their own.
+type FeeConfig struct {
+	GasMultiplier      float64
+	GasPriceMultiplier float64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Index: IndexConfig{
+			Interval: time.Second * 30,
+		},
+		Fee: FeeConfig{
+			GasMultiplier:      1,
+			GasPriceMultiplier: 1,
+		},
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *Config) Validate(tp node.Type) error {
+	// configs written before the multipliers were introduced don't set them
+	if cfg.Fee.GasMultiplier == 0 {
+		cfg.Fee.GasMultiplier = 1
+	}
+	if cfg.Fee.GasPriceMultiplier == 0 {
+		cfg.Fee.GasPriceMultiplier = 1
+	}
+	if cfg.Fee.GasMultiplier < 1 || cfg.Fee.GasPriceMultiplier < 1 {
+		return errors.New("module/blob: fee multipliers must not be less than 1")
+	}
+
+	if !cfg.Index.Enabled {
+		return nil
+	}
+	if tp == node.Light {
+		return fmt.Errorf("module/blob: the index is only supported by full and bridge nodes, not %s", tp)
+	}
+	if cfg.Index.Interval <= 0 {
+		return errors.New("module/blob: index interval must be positive")
+	}
+	return nil
+}
@@ -7,22 +7,121 @@ import (
 
 	"github.com/celestiaorg/celestia-node/blob"
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/budget"
+	moddas "github.com/celestiaorg/celestia-node/nodebuilder/das"
 	headerService "github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/getters"
 	"github.com/celestiaorg/celestia-node/state"
 )
 
-func ConstructModule() fx.Option {
-	return fx.Module("blob",
+func ConstructModule(tp node.Type, cfg *Config) fx.Option {
+	// sanitize config values before constructing module
+	cfgErr := cfg.Validate(tp)
+
+	serviceOpts := []blob.Option{
+		blob.WithGasMultiplier(cfg.Fee.GasMultiplier),
+		blob.WithGasPriceMultiplier(cfg.Fee.GasPriceMultiplier),
+	}
+
+	baseComponents := fx.Options(
+		fx.Error(cfgErr),
+		fx.Provide(newGetter),
 		fx.Provide(
 			func(service headerService.Module) func(context.Context, uint64) (*header.ExtendedHeader, error) {
-				return service.GetByHeight
+				// blobs are retrieved and proven against the DAH only
+				return func(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+					return service.GetLazyByHeight(ctx, height, false)
+				}
+			}),
+	)
+
+	if !cfg.Index.Enabled || tp == node.Light {
+		return fx.Module("blob",
+			baseComponents,
+			fx.Provide(func(
+				state *state.CoreAccessor,
+				sGetter getter,
+				getByHeightFn func(context.Context, uint64) (*header.ExtendedHeader, error),
+				service headerService.Module,
+			) Module {
+				opts := append([]blob.Option{blob.WithHeaderSubscription(service.Subscribe)}, serviceOpts...)
+				return &scopedModule{Module: blob.NewService(state, sGetter, getByHeightFn, opts...)}
+			}))
+	}
+
+	return fx.Module("blob",
+		baseComponents,
+		fx.Provide(fx.Annotate(
+			blob.NewIndex,
+			fx.OnStart(func(ctx context.Context, index *blob.Index) error {
+				return index.Start(ctx)
+			}),
+		)),
+		fx.Invoke(func(*blob.Indexer) {}),
+		fx.Provide(fx.Annotate(
+			func(
+				index *blob.Index,
+				storeGetter *getters.StoreGetter,
+				getByHeightFn func(context.Context, uint64) (*header.ExtendedHeader, error),
+				service headerService.Module,
+				daser moddas.Module,
+				dasCfg moddas.Config,
+			) *blob.Indexer {
+				// bridge nodes store squares of all the headers they produce
+				from, storedHead := uint64(1), func(ctx context.Context) (uint64, error) {
+					head, err := service.LocalHead(ctx)
+					if err != nil {
+						return 0, err
+					}
+					return uint64(head.Height()), nil
+				}
+				if tp == node.Full {
+					// full nodes store squares once they sample them
+					from, storedHead = dasCfg.SampleFrom, func(ctx context.Context) (uint64, error) {
+						stats, err := daser.SamplingStats(ctx)
+						return stats.SampledChainHead, err
+					}
+				}
+				return blob.NewIndexer(index, storeGetter, getByHeightFn, storedHead, from, cfg.Index.Interval)
+			},
+			fx.OnStart(func(ctx context.Context, indexer *blob.Indexer) error {
+				return indexer.Start(ctx)
 			}),
+			fx.OnStop(func(ctx context.Context, indexer *blob.Indexer) error {
+				return indexer.Stop(ctx)
+			}),
+		)),
 		fx.Provide(func(
 			state *state.CoreAccessor,
-			sGetter share.Getter,
+			sGetter getter,
 			getByHeightFn func(context.Context, uint64) (*header.ExtendedHeader, error),
+			index *blob.Index,
+			service headerService.Module,
 		) Module {
-			return blob.NewService(state, sGetter, getByHeightFn)
+			opts := append([]blob.Option{blob.WithIndex(index), blob.WithHeaderSubscription(service.Subscribe)},
+				serviceOpts...)
+			return &scopedModule{Module: blob.NewService(state, sGetter, getByHeightFn, opts...)}
 		}))
 }
+
+// getter is the share.Getter blobs of user requests are retrieved with.
+type getter share.Getter
+
+type getterParams struct {
+	fx.In
+
+	Getter share.Getter
+	// Budget is shared with sampling on full and light nodes, if enabled
+	Budget *budget.Budget `optional:"true"`
+}
+
+// newGetter makes retrievals of blobs take their tokens from the retrieval budget, if any, as
+// interactive ones, the same way retrievals of the share module do.
+func newGetter(params getterParams) getter {
+	if params.Budget == nil {
+		return params.Getter
+	}
+	return getters.NewBudgetedGetter(params.Getter, params.Budget)
+}
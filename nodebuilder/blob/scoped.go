@@ -0,0 +1,121 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/blob"
+)
+
+// scopedModule enforces namespaces of namespace-scoped tokens on the wrapped Module,
+// so that their holders can't read or submit blobs of other namespaces.
+type scopedModule struct {
+	Module
+}
+
+func (m *scopedModule) Submit(ctx context.Context, blobs []*blob.Blob) (uint64, error) {
+	for _, b := range blobs {
+		if err := perms.CheckNamespace(ctx, b.Namespace()); err != nil {
+			return 0, err
+		}
+	}
+	return m.Module.Submit(ctx, blobs)
+}
+
+func (m *scopedModule) SubmitAll(ctx context.Context, blobs []*blob.Blob, opts *blob.GasOptions) (uint64, error) {
+	for _, b := range blobs {
+		if err := perms.CheckNamespace(ctx, b.Namespace()); err != nil {
+			return 0, err
+		}
+	}
+	return m.Module.SubmitAll(ctx, blobs, opts)
+}
+
+func (m *scopedModule) Get(
+	ctx context.Context,
+	height uint64,
+	nID namespace.ID,
+	commitment blob.Commitment,
+) (*blob.Blob, error) {
+	if err := perms.CheckNamespace(ctx, nID); err != nil {
+		return nil, err
+	}
+	return m.Module.Get(ctx, height, nID, commitment)
+}
+
+func (m *scopedModule) GetStream(
+	ctx context.Context,
+	height uint64,
+	nID namespace.ID,
+	commitment blob.Commitment,
+) (<-chan []byte, error) {
+	if err := perms.CheckNamespace(ctx, nID); err != nil {
+		return nil, err
+	}
+	return m.Module.GetStream(ctx, height, nID, commitment)
+}
+
+func (m *scopedModule) GetAll(ctx context.Context, height uint64, nIDs []namespace.ID) ([]*blob.Blob, error) {
+	for _, nID := range nIDs {
+		if err := perms.CheckNamespace(ctx, nID); err != nil {
+			return nil, err
+		}
+	}
+	return m.Module.GetAll(ctx, height, nIDs)
+}
+
+func (m *scopedModule) GetProof(
+	ctx context.Context,
+	height uint64,
+	nID namespace.ID,
+	commitment blob.Commitment,
+) (*blob.Proof, error) {
+	if err := perms.CheckNamespace(ctx, nID); err != nil {
+		return nil, err
+	}
+	return m.Module.GetProof(ctx, height, nID, commitment)
+}
+
+func (m *scopedModule) Included(
+	ctx context.Context,
+	height uint64,
+	nID namespace.ID,
+	proof *blob.Proof,
+	commitment blob.Commitment,
+) (bool, error) {
+	if err := perms.CheckNamespace(ctx, nID); err != nil {
+		return false, err
+	}
+	return m.Module.Included(ctx, height, nID, proof, commitment)
+}
+
+func (m *scopedModule) Subscribe(ctx context.Context, nID namespace.ID) (<-chan *blob.BlobEvent, error) {
+	if err := perms.CheckNamespace(ctx, nID); err != nil {
+		return nil, err
+	}
+	return m.Module.Subscribe(ctx, nID)
+}
+
+func (m *scopedModule) Export(
+	ctx context.Context,
+	nID namespace.ID,
+	from, to uint64,
+) (<-chan *blob.ArchiveHeight, error) {
+	if err := perms.CheckNamespace(ctx, nID); err != nil {
+		return nil, err
+	}
+	return m.Module.Export(ctx, nID, from, to)
+}
+
+func (m *scopedModule) Import(ctx context.Context, archive *blob.Archive) ([]*blob.Blob, error) {
+	if archive == nil {
+		return nil, fmt.Errorf("%w: no archive", blob.ErrInvalidArchive)
+	}
+	if err := perms.CheckNamespace(ctx, archive.Namespace); err != nil {
+		return nil, err
+	}
+	return m.Module.Import(ctx, archive)
+}
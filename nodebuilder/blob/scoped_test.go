@@ -0,0 +1,50 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/blob"
+	"github.com/celestiaorg/celestia-node/nodebuilder/blob/mocks"
+)
+
+func TestScopedModule(t *testing.T) {
+	// version 0 namespaces are prefixed with zero bytes
+	allowed := append(make(namespace.ID, 19), bytes.Repeat([]byte{1}, 10)...)
+	other := append(make(namespace.ID, 19), bytes.Repeat([]byte{2}, 10)...)
+
+	ctrl := gomock.NewController(t)
+	mock := mocks.NewMockModule(ctrl)
+	mod := &scopedModule{Module: mock}
+
+	// requests without scoped tokens are not restricted
+	mock.EXPECT().GetAll(gomock.Any(), uint64(1), []namespace.ID{other}).Return(nil, nil)
+	_, err := mod.GetAll(context.Background(), 1, []namespace.ID{other})
+	require.NoError(t, err)
+
+	ctx := perms.WithNamespaces(context.Background(), []namespace.ID{allowed})
+	mock.EXPECT().GetAll(gomock.Any(), uint64(1), []namespace.ID{allowed}).Return(nil, nil)
+	_, err = mod.GetAll(ctx, 1, []namespace.ID{allowed})
+	require.NoError(t, err)
+
+	_, err = mod.GetAll(ctx, 1, []namespace.ID{allowed, other})
+	require.ErrorIs(t, err, perms.ErrNamespaceNotAllowed)
+	_, err = mod.Get(ctx, 1, other, nil)
+	require.ErrorIs(t, err, perms.ErrNamespaceNotAllowed)
+	_, err = mod.GetProof(ctx, 1, other, nil)
+	require.ErrorIs(t, err, perms.ErrNamespaceNotAllowed)
+	_, err = mod.Included(ctx, 1, other, nil, nil)
+	require.ErrorIs(t, err, perms.ErrNamespaceNotAllowed)
+
+	b, err := blob.NewBlob(0, other, []byte("data"))
+	require.NoError(t, err)
+	_, err = mod.Submit(ctx, []*blob.Blob{b})
+	require.ErrorIs(t, err, perms.ErrNamespaceNotAllowed)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/imdario/mergo"
 
 	"github.com/celestiaorg/celestia-node/libs/fslock"
+	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
 	"github.com/celestiaorg/celestia-node/nodebuilder/core"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
 	"github.com/celestiaorg/celestia-node/nodebuilder/gateway"
@@ -34,6 +35,9 @@ type Config struct {
 	Share   share.Config
 	Header  header.Config
 	DASer   das.Config `toml:",omitempty"`
+	Blob    blob.Config
+	// Datastore selects and tunes the backend of the node's datastore.
+	Datastore DatastoreConfig
 }
 
 // DefaultConfig provides a default Config for a given Node Type 'tp'.
@@ -48,6 +52,9 @@ func DefaultConfig(tp node.Type) *Config {
 		Gateway: gateway.DefaultConfig(),
 		Share:   share.DefaultConfig(tp),
 		Header:  header.DefaultConfig(tp),
+		Blob:    blob.DefaultConfig(),
+
+		Datastore: DefaultDatastoreConfig(),
 	}
 
 	switch tp {
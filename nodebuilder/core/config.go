@@ -12,6 +12,17 @@ type Config struct {
 	IP       string
 	RPCPort  string
 	GRPCPort string
+	// FailoverEndpoints are additional Core endpoints of the same network. The state module
+	// health-checks all the endpoints, prefers the healthy one with the lowest latency and fails over
+	// to the others on connection errors.
+	FailoverEndpoints []EndpointConfig
+}
+
+// EndpointConfig is a Core endpoint.
+type EndpointConfig struct {
+	IP       string
+	RPCPort  string
+	GRPCPort string
 }
 
 // DefaultConfig returns default configuration for managing the
@@ -26,18 +37,35 @@ func DefaultConfig() Config {
 
 // Validate performs basic validation of the config.
 func (cfg *Config) Validate() error {
-	ip, err := utils.ValidateAddr(cfg.IP)
+	ip, err := validateEndpoint(cfg.IP, cfg.RPCPort, cfg.GRPCPort)
 	if err != nil {
 		return err
 	}
 	cfg.IP = ip
-	_, err = strconv.Atoi(cfg.RPCPort)
+	for i := range cfg.FailoverEndpoints {
+		endpoint := &cfg.FailoverEndpoints[i]
+		ip, err = validateEndpoint(endpoint.IP, endpoint.RPCPort, endpoint.GRPCPort)
+		if err != nil {
+			return fmt.Errorf("nodebuilder/core: invalid failover endpoint %d: %w", i, err)
+		}
+		endpoint.IP = ip
+	}
+	return nil
+}
+
+// validateEndpoint validates the endpoint and returns its sanitized address.
+func validateEndpoint(addr, rpcPort, grpcPort string) (string, error) {
+	ip, err := utils.ValidateAddr(addr)
 	if err != nil {
-		return fmt.Errorf("nodebuilder/core: invalid rpc port: %s", err.Error())
+		return "", err
 	}
-	_, err = strconv.Atoi(cfg.GRPCPort)
+	_, err = strconv.Atoi(rpcPort)
 	if err != nil {
-		return fmt.Errorf("nodebuilder/core: invalid grpc port: %s", err.Error())
+		return "", fmt.Errorf("nodebuilder/core: invalid rpc port: %s", err.Error())
 	}
-	return nil
+	_, err = strconv.Atoi(grpcPort)
+	if err != nil {
+		return "", fmt.Errorf("nodebuilder/core: invalid grpc port: %s", err.Error())
+	}
+	return ip, nil
 }
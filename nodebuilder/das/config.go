@@ -2,6 +2,7 @@ package das
 
 import (
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/celestiaorg/celestia-node/das"
@@ -23,14 +24,18 @@ func DefaultConfig(tp node.Type) Config {
 	switch tp {
 	case node.Light:
 		cfg.SampleTimeout = modp2p.BlockTime * time.Duration(cfg.ConcurrencyLimit)
+		// sampling a block should not take longer than producing one
+		cfg.TargetSampleLatency = modp2p.BlockTime
 	case node.Full:
 		// Default value for DASer concurrency limit is based on dasing using ipld getter.
 		// Full node will primarily use shrex protocol for sampling, that is much more efficient and can
 		// fully utilize nodes bandwidth with lower amount of parallel sampling workers
 		cfg.ConcurrencyLimit = 6
+		cfg.RetrievalBudget = 2 * cfg.ConcurrencyLimit
 		// Full node uses shrex with fallback to ipld to sample, so need 2x amount of time in worst case
 		// scenario
 		cfg.SampleTimeout = 2 * modp2p.BlockTime * time.Duration(cfg.ConcurrencyLimit)
+		cfg.TargetSampleLatency = 2 * modp2p.BlockTime
 	}
 	return Config(cfg)
 }
@@ -45,3 +50,11 @@ func (cfg *Config) Validate() error {
 
 	return nil
 }
+
+// checkpointDir resolves the configured checkpoint directory against the node store path.
+func (cfg *Config) checkpointDir(path node.StorePath) string {
+	if cfg.CheckpointDir == "" || filepath.IsAbs(cfg.CheckpointDir) {
+		return cfg.CheckpointDir
+	}
+	return filepath.Join(string(path), cfg.CheckpointDir)
+}
@@ -5,15 +5,22 @@ import (
 	"fmt"
 
 	"github.com/ipfs/go-datastore"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/host"
 
 	"github.com/celestiaorg/go-fraud"
 	libhead "github.com/celestiaorg/go-header"
 
 	"github.com/celestiaorg/celestia-node/das"
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/budget"
+	"github.com/celestiaorg/celestia-node/libs/watchdog"
 	modfraud "github.com/celestiaorg/celestia-node/nodebuilder/fraud"
+	modheader "github.com/celestiaorg/celestia-node/nodebuilder/header"
+	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	"github.com/celestiaorg/celestia-node/share"
-	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
+	"github.com/celestiaorg/celestia-node/share/p2p/attestsub"
+	disc "github.com/celestiaorg/celestia-node/share/p2p/discovery"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexsub"
 )
 
@@ -33,27 +40,69 @@ func (d daserStub) WaitCatchUp(context.Context) error {
 	return errStub
 }
 
+func (d daserStub) SamplingAuditLog(context.Context, uint64, uint64) ([]das.SampleRecord, error) {
+	return nil, errStub
+}
+
 func newDaserStub() Module {
 	return &daserStub{}
 }
 
+func newAttestPubSub(ps *pubsub.PubSub, h host.Host, network modp2p.Network) *attestsub.PubSub {
+	return attestsub.NewPubSub(ps, h, network.String())
+}
+
+// newRetrievalBudget creates the retrieval budget shared by sampling and user requests, if enabled.
+func newRetrievalBudget(cfg Config) *budget.Budget {
+	if cfg.RetrievalBudget == 0 {
+		return nil
+	}
+	return budget.New(cfg.RetrievalBudget)
+}
+
 func newDASer(
 	da share.Availability,
 	hsub libhead.Subscriber[*header.ExtendedHeader],
-	store libhead.Store[*header.ExtendedHeader],
+	getter modheader.LazyGetter,
 	batching datastore.Batching,
 	fraudServ fraud.Service,
 	bFn shrexsub.BroadcastFn,
+	host host.Host,
+	discovery *disc.Discovery,
+	rb *budget.Budget,
+	heartbeats *watchdog.Registry,
+	wd *watchdog.Watchdog,
 	options ...das.Option,
 ) (*das.DASer, *modfraud.ServiceBreaker[*das.DASer], error) {
-	ds, err := das.NewDASer(da, hsub, store, batching, fraudServ, bFn, options...)
+	options = append(options,
+		// sampling is served by the discovered full nodes, rather than by any connected peer
+		das.WithPeerCounter(discovery.Size),
+		das.WithHeadReplacements(host.EventBus()),
+		das.WithRetrievalBudget(rb),
+		das.WithHeartbeats(heartbeats),
+	)
+	ds, err := das.NewDASer(da, hsub, getter, batching, fraudServ, bFn, options...)
 	if err != nil {
 		return nil, nil, err
 	}
+	wd.SetRestarter("das", ds.Restart)
 
 	return ds, &modfraud.ServiceBreaker[*das.DASer]{
-		Service:   ds,
-		FraudServ: fraudServ,
-		FraudType: byzantine.BadEncoding,
+		Service:    ds,
+		FraudServ:  fraudServ,
+		FraudTypes: modfraud.ProofTypes(),
 	}, nil
 }
+
+// pruneGuard keeps headers not sampled by the DASer yet from being pruned.
+type pruneGuard struct {
+	daser *das.DASer
+}
+
+func (g pruneGuard) PrunableHeight(ctx context.Context) (uint64, error) {
+	stats, err := g.daser.SamplingStats(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return stats.SampledChainHead, nil
+}
@@ -14,14 +14,18 @@ type Module interface {
 	SamplingStats(ctx context.Context) (das.SamplingStats, error)
 	// WaitCatchUp blocks until DASer finishes catching up to the network head.
 	WaitCatchUp(ctx context.Context) error
+	// SamplingAuditLog returns the latest recorded sampling attempts for heights in the given range
+	// [from; to], oldest first. Zero to means no upper bound.
+	SamplingAuditLog(ctx context.Context, from, to uint64) ([]das.SampleRecord, error)
 }
 
 // API is a wrapper around Module for the RPC.
 // TODO(@distractedm1nd): These structs need to be autogenerated.
 type API struct {
 	Internal struct {
-		SamplingStats func(ctx context.Context) (das.SamplingStats, error) `perm:"read"`
-		WaitCatchUp   func(ctx context.Context) error                      `perm:"read"`
+		SamplingStats    func(ctx context.Context) (das.SamplingStats, error)                   `perm:"read"`
+		WaitCatchUp      func(ctx context.Context) error                                        `perm:"read"`
+		SamplingAuditLog func(ctx context.Context, from, to uint64) ([]das.SampleRecord, error) `perm:"read"`
 	}
 }
 
@@ -32,3 +36,7 @@ func (api *API) SamplingStats(ctx context.Context) (das.SamplingStats, error) {
 func (api *API) WaitCatchUp(ctx context.Context) error {
 	return api.Internal.WaitCatchUp(ctx)
 }
+
+func (api *API) SamplingAuditLog(ctx context.Context, from, to uint64) ([]das.SampleRecord, error) {
+	return api.Internal.SamplingAuditLog(ctx, from, to)
+}
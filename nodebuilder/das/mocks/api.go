@@ -35,6 +35,21 @@ func (m *MockModule) EXPECT() *MockModuleMockRecorder {
 	return m.recorder
 }
 
+// SamplingAuditLog mocks base method.
+func (m *MockModule) SamplingAuditLog(arg0 context.Context, arg1, arg2 uint64) ([]das.SampleRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SamplingAuditLog", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]das.SampleRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SamplingAuditLog indicates an expected call of SamplingAuditLog.
+func (mr *MockModuleMockRecorder) SamplingAuditLog(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SamplingAuditLog", reflect.TypeOf((*MockModule)(nil).SamplingAuditLog), arg0, arg1, arg2)
+}
+
 // SamplingStats mocks base method.
 func (m *MockModule) SamplingStats(arg0 context.Context) (das.SamplingStats, error) {
 	m.ctrl.T.Helper()
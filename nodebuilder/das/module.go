@@ -2,12 +2,16 @@ package das
 
 import (
 	"context"
+	"fmt"
 
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-node/das"
 	modfraud "github.com/celestiaorg/celestia-node/nodebuilder/fraud"
+	modheader "github.com/celestiaorg/celestia-node/nodebuilder/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/share/p2p/attestsub"
 )
 
 func ConstructModule(tp node.Type, cfg *Config) fx.Option {
@@ -17,28 +21,56 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 	if tp != node.Bridge {
 		err = cfg.Validate()
 	}
+	if err == nil && tp == node.Full && cfg.ShareAttestations {
+		err = fmt.Errorf("moddas misconfiguration: sampling attestations are only shared by light nodes")
+	}
 
 	baseComponents := fx.Options(
 		fx.Supply(*cfg),
 		fx.Error(err),
 		fx.Provide(
-			func(c Config) []das.Option {
+			func(c Config, path node.StorePath, network modp2p.Network) []das.Option {
 				return []das.Option{
 					das.WithSamplingRange(c.SamplingRange),
 					das.WithConcurrencyLimit(c.ConcurrencyLimit),
+					das.WithMinConcurrencyLimit(c.MinConcurrencyLimit),
+					das.WithTargetSampleLatency(c.TargetSampleLatency),
 					das.WithBackgroundStoreInterval(c.BackgroundStoreInterval),
 					das.WithSampleFrom(c.SampleFrom),
+					das.WithFirstSampleableHeight(modp2p.FirstSampleableHeightFor(network)),
 					das.WithSampleTimeout(c.SampleTimeout),
+					das.WithCheckpointDir(c.checkpointDir(path)),
+					das.WithAuditLogSize(c.AuditLogSize),
 				}
 			},
 		),
 	)
 
+	attestComponents := fx.Options()
+	if tp == node.Light && cfg.ShareAttestations {
+		attestComponents = fx.Options(
+			fx.Provide(fx.Annotate(
+				newAttestPubSub,
+				fx.OnStart(func(ctx context.Context, ps *attestsub.PubSub) error {
+					return ps.Start(ctx)
+				}),
+				fx.OnStop(func(ctx context.Context, ps *attestsub.PubSub) error {
+					return ps.Stop(ctx)
+				}),
+			)),
+			fx.Decorate(func(opts []das.Option, ps *attestsub.PubSub) []das.Option {
+				return append(opts, das.WithAttestations(ps))
+			}),
+		)
+	}
+
 	switch tp {
 	case node.Light, node.Full:
 		return fx.Module(
 			"daser",
 			baseComponents,
+			attestComponents,
+			fx.Provide(newRetrievalBudget),
 			fx.Provide(fx.Annotate(
 				newDASer,
 				fx.OnStart(func(ctx context.Context, breaker *modfraud.ServiceBreaker[*das.DASer]) error {
@@ -52,6 +84,9 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 			fx.Provide(func(das *das.DASer) Module {
 				return das
 			}),
+			fx.Provide(func(das *das.DASer) modheader.PruneGuard {
+				return pruneGuard{daser: das}
+			}),
 		)
 	case node.Bridge:
 		return fx.Module(
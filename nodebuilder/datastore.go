@@ -0,0 +1,350 @@
+package nodebuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/dgraph-io/badger/v2/options"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	dsbadger "github.com/ipfs/go-ds-badger2"
+	dsleveldb "github.com/ipfs/go-ds-leveldb"
+	dspebble "github.com/ipfs/go-ds-pebble"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+
+	"github.com/celestiaorg/celestia-node/libs/fslock"
+)
+
+// Datastore backends available by default.
+const (
+	BadgerBackend  = "badger"
+	PebbleBackend  = "pebble"
+	LevelDBBackend = "leveldb"
+)
+
+// migrationBatchSize is the amount of entries copied per batch by MigrateDatastore.
+const migrationBatchSize = 1024
+
+// DatastoreConfig selects the backend of the node's datastore and tunes it.
+// Switching backends of an existing store requires migrating its data with MigrateDatastore, as
+// every backend keeps its data under its own directory.
+type DatastoreConfig struct {
+	// Backend is the name of the backend, e.g. "badger", "pebble" or "leveldb".
+	Backend string
+	Badger  BadgerConfig
+	Pebble  PebbleConfig
+	LevelDB LevelDBConfig
+}
+
+// BadgerConfig tunes the badger backend.
+type BadgerConfig struct {
+	// ValueThreshold is the size in bytes, from which values are stored in the value log instead of
+	// the LSM tree.
+	ValueThreshold int
+	// MaxTableSize is the in memory and on disk size in bytes of the tables of the LSM tree. Bigger
+	// values constantly take more RAM.
+	MaxTableSize int64
+}
+
+// PebbleConfig tunes the pebble backend.
+type PebbleConfig struct {
+	// CacheSize is the size in bytes of the block cache.
+	CacheSize int64
+	// MemTableSize is the size in bytes of a memtable before it is flushed to disk.
+	MemTableSize int
+	// MaxOpenFiles is the soft limit of open files.
+	MaxOpenFiles int
+}
+
+// LevelDBConfig tunes the leveldb backend.
+type LevelDBConfig struct {
+	// BlockCacheCapacity is the size in bytes of the block cache.
+	BlockCacheCapacity int
+	// WriteBuffer is the size in bytes of a memtable before it is flushed to disk.
+	WriteBuffer int
+	// Compression enables snappy compression of the tables.
+	Compression bool
+}
+
+// DefaultDatastoreConfig returns the default datastore configuration.
+func DefaultDatastoreConfig() DatastoreConfig {
+	return DatastoreConfig{
+		Backend: BadgerBackend,
+		Badger: BadgerConfig{
+			// Badger sets ValueThreshold to 1K by default and this makes shares being stored in LSM tree
+			// instead of the value log, so we change the value to be lower than share size,
+			// so shares are store in value log. For value log and LSM definitions
+			ValueThreshold: 128,
+			MaxTableSize:   64 << 20,
+		},
+		Pebble: PebbleConfig{
+			CacheSize:    64 << 20,
+			MemTableSize: 64 << 20,
+			MaxOpenFiles: 1000,
+		},
+		LevelDB: LevelDBConfig{
+			BlockCacheCapacity: 64 << 20,
+			WriteBuffer:        16 << 20,
+			Compression:        true,
+		},
+	}
+}
+
+// Validate performs basic validation of the config. Zero values, e.g. of configs predating the
+// datastore section, fall back to the defaults.
+func (cfg *DatastoreConfig) Validate() error {
+	if _, err := datastoreBackend(cfg.backend()); err != nil {
+		return err
+	}
+	defaults := DefaultDatastoreConfig()
+	if cfg.Badger.ValueThreshold == 0 {
+		cfg.Badger.ValueThreshold = defaults.Badger.ValueThreshold
+	}
+	if cfg.Badger.MaxTableSize == 0 {
+		cfg.Badger.MaxTableSize = defaults.Badger.MaxTableSize
+	}
+	if cfg.Pebble.CacheSize == 0 {
+		cfg.Pebble.CacheSize = defaults.Pebble.CacheSize
+	}
+	if cfg.Badger.ValueThreshold < 0 || cfg.Badger.MaxTableSize < 0 {
+		return fmt.Errorf("node: invalid badger config: %+v", cfg.Badger)
+	}
+	if cfg.Pebble.CacheSize < 0 || cfg.Pebble.MemTableSize < 0 || cfg.Pebble.MaxOpenFiles < 0 {
+		return fmt.Errorf("node: invalid pebble config: %+v", cfg.Pebble)
+	}
+	if cfg.LevelDB.BlockCacheCapacity < 0 || cfg.LevelDB.WriteBuffer < 0 {
+		return fmt.Errorf("node: invalid leveldb config: %+v", cfg.LevelDB)
+	}
+	return nil
+}
+
+// backend returns the name of the configured backend. Configs predating the choice of backends
+// use badger.
+func (cfg *DatastoreConfig) backend() string {
+	if cfg.Backend == "" {
+		return BadgerBackend
+	}
+	return cfg.Backend
+}
+
+// DatastoreBackend opens a datastore under the given directory, tuned by the given config.
+type DatastoreBackend func(path string, cfg DatastoreConfig) (datastore.Batching, error)
+
+var (
+	backendsLk        sync.RWMutex
+	datastoreBackends = map[string]DatastoreBackend{
+		BadgerBackend:  openBadger,
+		PebbleBackend:  openPebble,
+		LevelDBBackend: openLevelDB,
+	}
+)
+
+// RegisterDatastoreBackend makes the backend available under the given name to DatastoreConfig.
+func RegisterDatastoreBackend(name string, backend DatastoreBackend) {
+	backendsLk.Lock()
+	defer backendsLk.Unlock()
+	datastoreBackends[name] = backend
+}
+
+// DatastoreBackends returns the names of the available backends.
+func DatastoreBackends() []string {
+	backendsLk.RLock()
+	defer backendsLk.RUnlock()
+
+	names := make([]string, 0, len(datastoreBackends))
+	for name := range datastoreBackends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func datastoreBackend(name string) (DatastoreBackend, error) {
+	backendsLk.RLock()
+	defer backendsLk.RUnlock()
+
+	backend, ok := datastoreBackends[name]
+	if !ok {
+		return nil, fmt.Errorf("node: unknown datastore backend %q, available: %v", name, DatastoreBackends())
+	}
+	return backend, nil
+}
+
+// openDatastore opens the datastore of the configured backend under the given store path.
+func openDatastore(base string, cfg DatastoreConfig) (datastore.Batching, error) {
+	name := cfg.backend()
+	backend, err := datastoreBackend(name)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := backend(datastorePath(base, name), cfg)
+	if err != nil {
+		return nil, fmt.Errorf("node: can't open %s Datastore: %w", name, err)
+	}
+	return ds, nil
+}
+
+// datastorePath returns the directory of the given backend. Badger keeps the data directory it
+// always used.
+func datastorePath(base, backend string) string {
+	if backend == BadgerBackend {
+		return dataPath(base)
+	}
+	return filepath.Join(base, "data-"+backend)
+}
+
+func openBadger(path string, cfg DatastoreConfig) (datastore.Batching, error) {
+	opts := dsbadger.DefaultOptions // this should be copied
+
+	opts.ValueThreshold = cfg.Badger.ValueThreshold
+	// We always write unique values to Badger transaction so there is no need to detect conflicts.
+	opts.DetectConflicts = false
+	// Use MemoryMap for better performance
+	opts.ValueLogLoadingMode = options.MemoryMap
+	opts.TableLoadingMode = options.MemoryMap
+	// Truncate set to true will truncate corrupted data on start if there is any.
+	// If we don't truncate, the node will refuse to start and will beg for recovering, etc.
+	// If we truncate, the node will start with any uncorrupted data and reliably sync again what was
+	// corrupted in most cases.
+	opts.Truncate = true
+	opts.MaxTableSize = cfg.Badger.MaxTableSize
+
+	return dsbadger.NewDatastore(path, &opts)
+}
+
+func openPebble(path string, cfg DatastoreConfig) (datastore.Batching, error) {
+	cache := pebble.NewCache(cfg.Pebble.CacheSize)
+	// the database keeps its own reference to the cache
+	defer cache.Unref()
+
+	opts := &pebble.Options{
+		Cache:        cache,
+		MemTableSize: cfg.Pebble.MemTableSize,
+		MaxOpenFiles: cfg.Pebble.MaxOpenFiles,
+	}
+	opts.EnsureDefaults()
+	return dspebble.NewDatastore(path, opts)
+}
+
+func openLevelDB(path string, cfg DatastoreConfig) (datastore.Batching, error) {
+	compression := opt.NoCompression
+	if cfg.LevelDB.Compression {
+		compression = opt.SnappyCompression
+	}
+	return dsleveldb.NewDatastore(path, &dsleveldb.Options{
+		BlockCacheCapacity: cfg.LevelDB.BlockCacheCapacity,
+		WriteBuffer:        cfg.LevelDB.WriteBuffer,
+		Compression:        compression,
+	})
+}
+
+// MigrateDatastore copies the data of the datastore under the given store path from its
+// configured backend into the given one and switches the config over to it. The data of the
+// previous backend is left in place and can be removed once the node runs fine on the new one.
+// The node must be stopped. It returns the amount of migrated entries.
+func MigrateDatastore(ctx context.Context, path, backend string) (_ int, err error) {
+	path, err = storePath(path)
+	if err != nil {
+		return 0, err
+	}
+
+	flock, err := fslock.Lock(lockPath(path))
+	if err != nil {
+		if err == fslock.ErrLocked {
+			err = ErrOpened
+		}
+		return 0, err
+	}
+	defer flock.Unlock() //nolint: errcheck
+
+	cfg, err := LoadConfig(configPath(path))
+	if err != nil {
+		return 0, fmt.Errorf("node: can't load Config: %w", err)
+	}
+	from := cfg.Datastore
+	if err = from.Validate(); err != nil {
+		return 0, err
+	}
+	if from.backend() == backend {
+		return 0, fmt.Errorf("node: datastore already uses %s backend", backend)
+	}
+	to := from
+	to.Backend = backend
+	if err = to.Validate(); err != nil {
+		return 0, err
+	}
+	if entries, _ := os.ReadDir(datastorePath(path, backend)); len(entries) != 0 {
+		return 0, fmt.Errorf("node: %s datastore is not empty: %s", backend, datastorePath(path, backend))
+	}
+
+	src, err := openDatastore(path, from)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = errors.Join(err, src.Close())
+	}()
+	dst, err := openDatastore(path, to)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = errors.Join(err, dst.Close())
+	}()
+
+	log.Infow("migrating datastore", "from", from.backend(), "to", backend)
+	copied, err := copyDatastore(ctx, src, dst)
+	if err != nil {
+		return copied, fmt.Errorf("node: migrating datastore: %w", err)
+	}
+	if err = dst.Sync(ctx, datastore.NewKey("/")); err != nil {
+		return copied, err
+	}
+
+	cfg.Datastore = to
+	if err = SaveConfig(configPath(path), cfg); err != nil {
+		return copied, fmt.Errorf("node: can't save Config: %w", err)
+	}
+	log.Infow("migrated datastore", "to", backend, "entries", copied)
+	return copied, nil
+}
+
+// copyDatastore copies all entries of the src datastore into the dst one in batches.
+func copyDatastore(ctx context.Context, src datastore.Read, dst datastore.Batching) (int, error) {
+	results, err := src.Query(ctx, query.Query{})
+	if err != nil {
+		return 0, err
+	}
+	defer results.Close()
+
+	var copied int
+	batch, err := dst.Batch(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for res := range results.Next() {
+		if res.Error != nil {
+			return copied, res.Error
+		}
+		if err = batch.Put(ctx, datastore.NewKey(res.Key), res.Value); err != nil {
+			return copied, err
+		}
+		copied++
+		if copied%migrationBatchSize != 0 {
+			continue
+		}
+		if err = batch.Commit(ctx); err != nil {
+			return copied, err
+		}
+		if batch, err = dst.Batch(ctx); err != nil {
+			return copied, err
+		}
+	}
+	return copied, batch.Commit(ctx)
+}
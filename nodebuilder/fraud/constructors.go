@@ -5,7 +5,6 @@ import (
 
 	"github.com/ipfs/go-datastore"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
-	"github.com/libp2p/go-libp2p/core/host"
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/go-fraud"
@@ -19,7 +18,7 @@ import (
 func newFraudService(syncerEnabled bool) func(
 	fx.Lifecycle,
 	*pubsub.PubSub,
-	host.Host,
+	p2p.ServingHost,
 	libhead.Store[*header.ExtendedHeader],
 	datastore.Batching,
 	p2p.Network,
@@ -27,7 +26,7 @@ func newFraudService(syncerEnabled bool) func(
 	return func(
 		lc fx.Lifecycle,
 		sub *pubsub.PubSub,
-		host host.Host,
+		host p2p.ServingHost,
 		hstore libhead.Store[*header.ExtendedHeader],
 		ds datastore.Batching,
 		network p2p.Network,
@@ -17,14 +17,26 @@ type Module interface {
 	Subscribe(context.Context, fraud.ProofType) (<-chan Proof, error)
 	// Get fetches fraud proofs from the disk by its type.
 	Get(context.Context, fraud.ProofType) ([]Proof, error)
+	// GetByHeight fetches fraud proofs of the given type against the block at the given height from
+	// the disk.
+	GetByHeight(ctx context.Context, proofType fraud.ProofType, height uint64) ([]Proof, error)
+	// List fetches all fraud proofs received and verified by the node from the disk, ordered by
+	// height.
+	List(context.Context) ([]Proof, error)
 }
 
 // API is a wrapper around Module for the RPC.
 // TODO(@distractedm1nd): These structs need to be autogenerated.
 type API struct {
 	Internal struct {
-		Subscribe func(context.Context, fraud.ProofType) (<-chan Proof, error) `perm:"public"`
-		Get       func(context.Context, fraud.ProofType) ([]Proof, error)      `perm:"public"`
+		Subscribe   func(context.Context, fraud.ProofType) (<-chan Proof, error) `perm:"public"`
+		Get         func(context.Context, fraud.ProofType) ([]Proof, error)      `perm:"public"`
+		GetByHeight func(
+			ctx context.Context,
+			proofType fraud.ProofType,
+			height uint64,
+		) ([]Proof, error) `perm:"public"`
+		List func(context.Context) ([]Proof, error) `perm:"public"`
 	}
 }
 
@@ -35,3 +47,11 @@ func (api *API) Subscribe(ctx context.Context, proofType fraud.ProofType) (<-cha
 func (api *API) Get(ctx context.Context, proofType fraud.ProofType) ([]Proof, error) {
 	return api.Internal.Get(ctx, proofType)
 }
+
+func (api *API) GetByHeight(ctx context.Context, proofType fraud.ProofType, height uint64) ([]Proof, error) {
+	return api.Internal.GetByHeight(ctx, proofType, height)
+}
+
+func (api *API) List(ctx context.Context) ([]Proof, error) {
+	return api.Internal.List(ctx)
+}
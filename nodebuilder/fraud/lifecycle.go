@@ -15,60 +15,71 @@ type service interface {
 	Stop(context.Context) error
 }
 
-// ServiceBreaker wraps any service with fraud proof subscription of a specific type.
-// If proof happens the service is Stopped automatically.
-// TODO(@Wondertan): Support multiple fraud types.
+// ServiceBreaker wraps any service with fraud proof subscriptions of specific types.
+// If proof of any of the types happens the service is Stopped automatically.
 type ServiceBreaker[S service] struct {
-	Service   S
-	FraudType fraud.ProofType
-	FraudServ fraud.Service
+	Service    S
+	FraudTypes []fraud.ProofType
+	FraudServ  fraud.Service
 
 	ctx    context.Context
 	cancel context.CancelFunc
-	sub    fraud.Subscription
+	subs   []fraud.Subscription
 }
 
 // Start starts the inner service if there are no fraud proofs stored.
 // Subscribes for fraud and stops the service whenever necessary.
 func (breaker *ServiceBreaker[S]) Start(ctx context.Context) error {
-	proofs, err := breaker.FraudServ.Get(ctx, breaker.FraudType)
-	switch err {
-	default:
-		return fmt.Errorf("getting proof(%s): %w", breaker.FraudType, err)
-	case nil:
-		return &fraud.ErrFraudExists{Proof: proofs}
-	case datastore.ErrNotFound:
+	for _, fraudType := range breaker.FraudTypes {
+		proofs, err := breaker.FraudServ.Get(ctx, fraudType)
+		switch err {
+		default:
+			return fmt.Errorf("getting proof(%s): %w", fraudType, err)
+		case nil:
+			return &fraud.ErrFraudExists{Proof: proofs}
+		case datastore.ErrNotFound:
+		}
 	}
 
-	err = breaker.Service.Start(ctx)
+	err := breaker.Service.Start(ctx)
 	if err != nil {
 		return err
 	}
 
-	breaker.sub, err = breaker.FraudServ.Subscribe(breaker.FraudType)
-	if err != nil {
-		return fmt.Errorf("subscribing for proof(%s): %w", breaker.FraudType, err)
-	}
-
 	breaker.ctx, breaker.cancel = context.WithCancel(context.Background())
-	go breaker.awaitProof()
+	breaker.subs = make([]fraud.Subscription, 0, len(breaker.FraudTypes))
+	for _, fraudType := range breaker.FraudTypes {
+		sub, err := breaker.FraudServ.Subscribe(fraudType)
+		if err != nil {
+			breaker.cancelSubs()
+			return fmt.Errorf("subscribing for proof(%s): %w", fraudType, err)
+		}
+		breaker.subs = append(breaker.subs, sub)
+		go breaker.awaitProof(sub)
+	}
 	return nil
 }
 
-// Stop stops the service and cancels subscription.
+// Stop stops the service and cancels subscriptions.
 func (breaker *ServiceBreaker[S]) Stop(ctx context.Context) error {
 	if breaker.ctx.Err() != nil {
 		// short circuit if the service was already stopped
 		return nil
 	}
 
-	breaker.sub.Cancel()
-	breaker.cancel()
+	breaker.cancelSubs()
 	return breaker.Service.Stop(ctx)
 }
 
-func (breaker *ServiceBreaker[S]) awaitProof() {
-	_, err := breaker.sub.Proof(breaker.ctx)
+func (breaker *ServiceBreaker[S]) cancelSubs() {
+	for _, sub := range breaker.subs {
+		sub.Cancel()
+	}
+	breaker.cancel()
+}
+
+func (breaker *ServiceBreaker[S]) awaitProof(sub fraud.Subscription) {
+	_, err := sub.Proof(breaker.ctx)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,95 @@
+package fraud
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+
+	"github.com/celestiaorg/go-fraud"
+)
+
+const (
+	proofTypeKey = "proof_type"
+	reasonKey    = "reason"
+)
+
+var meter = global.MeterProvider().Meter("module/fraud")
+
+// metrics of the fraud proofs received from the network.
+type metrics struct {
+	received     syncint64.Counter     // attributes: proof_type[string]
+	validated    syncint64.Counter     // attributes: proof_type[string]
+	rejected     syncint64.Counter     // attributes: proof_type[string], reason[string]
+	receiptDelay syncfloat64.Histogram // attributes: proof_type[string]
+}
+
+// WithMetrics enables the metrics of fraud proofs received, validated and rejected by the node.
+func WithMetrics(t *proofTracer) error {
+	received, err := meter.SyncInt64().Counter("fraud_proofs_received",
+		instrument.WithDescription("amount of fraud proofs received from the network"))
+	if err != nil {
+		return err
+	}
+
+	validated, err := meter.SyncInt64().Counter("fraud_proofs_validated",
+		instrument.WithDescription("amount of received fraud proofs which are valid"))
+	if err != nil {
+		return err
+	}
+
+	rejected, err := meter.SyncInt64().Counter("fraud_proofs_rejected",
+		instrument.WithDescription("amount of received fraud proofs which are rejected, by reason"))
+	if err != nil {
+		return err
+	}
+
+	receiptDelay, err := meter.SyncFloat64().Histogram("fraud_proof_receipt_delay_hist",
+		instrument.WithUnit(unit.Unit("s")),
+		instrument.WithDescription("time from the block of a valid fraud proof to its receipt"))
+	if err != nil {
+		return err
+	}
+
+	t.metrics = &metrics{
+		received:     received,
+		validated:    validated,
+		rejected:     rejected,
+		receiptDelay: receiptDelay,
+	}
+	return nil
+}
+
+func (m *metrics) observeReceived(ctx context.Context, proofType fraud.ProofType) {
+	if m == nil {
+		return
+	}
+	m.received.Add(ctx, 1, attribute.String(proofTypeKey, proofType.String()))
+}
+
+// observeValidated records the validated proof and the delay of its receipt, unless it is
+// negative.
+func (m *metrics) observeValidated(ctx context.Context, proofType fraud.ProofType, delay time.Duration) {
+	if m == nil {
+		return
+	}
+	m.validated.Add(ctx, 1, attribute.String(proofTypeKey, proofType.String()))
+	if delay >= 0 {
+		m.receiptDelay.Record(ctx, delay.Seconds(), attribute.String(proofTypeKey, proofType.String()))
+	}
+}
+
+func (m *metrics) observeRejected(ctx context.Context, proofType fraud.ProofType, reason string) {
+	if m == nil {
+		return
+	}
+	m.rejected.Add(ctx, 1,
+		attribute.String(proofTypeKey, proofType.String()),
+		attribute.String(reasonKey, reason),
+	)
+}
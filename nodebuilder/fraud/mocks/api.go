@@ -51,6 +51,36 @@ func (mr *MockModuleMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockModule)(nil).Get), arg0, arg1)
 }
 
+// GetByHeight mocks base method.
+func (m *MockModule) GetByHeight(arg0 context.Context, arg1 fraud0.ProofType, arg2 uint64) ([]fraud.Proof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHeight", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]fraud.Proof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHeight indicates an expected call of GetByHeight.
+func (mr *MockModuleMockRecorder) GetByHeight(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHeight", reflect.TypeOf((*MockModule)(nil).GetByHeight), arg0, arg1, arg2)
+}
+
+// List mocks base method.
+func (m *MockModule) List(arg0 context.Context) ([]fraud.Proof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]fraud.Proof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockModuleMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockModule)(nil).List), arg0)
+}
+
 // Subscribe mocks base method.
 func (m *MockModule) Subscribe(arg0 context.Context, arg1 fraud0.ProofType) (<-chan fraud.Proof, error) {
 	m.ctrl.T.Helper()
@@ -1,20 +1,44 @@
 package fraud
 
 import (
+	"context"
+
 	logging "github.com/ipfs/go-log/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/host"
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/go-fraud"
+	libhead "github.com/celestiaorg/go-header"
 
+	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 )
 
 var log = logging.Logger("module/fraud")
 
 func ConstructModule(tp node.Type) fx.Option {
-	baseComponent := fx.Provide(func(serv fraud.Service) fraud.Getter {
-		return serv
-	})
+	baseComponent := fx.Options(
+		fx.Provide(func(serv fraud.Service) fraud.Getter {
+			return serv
+		}),
+		fx.Provide(fx.Annotate(
+			func(
+				network p2p.Network,
+				store libhead.Store[*header.ExtendedHeader],
+				host host.Host,
+			) (*proofTracer, error) {
+				return newProofTracer(network.String(), store, host)
+			},
+			fx.OnStop(func(ctx context.Context, t *proofTracer) error {
+				return t.Stop(ctx)
+			}),
+		)),
+		fx.Provide(p2p.PubSubOpt(func(t *proofTracer) pubsub.Option {
+			return pubsub.WithRawTracer(t)
+		})),
+	)
 	switch tp {
 	case node.Light:
 		return fx.Module(
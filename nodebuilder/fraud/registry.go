@@ -0,0 +1,51 @@
+package fraud
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/celestiaorg/go-fraud"
+
+	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
+)
+
+var (
+	proofTypesLk sync.RWMutex
+	// proofTypes are the types of fraud proofs, which stop services relying on the chain.
+	proofTypes = []fraud.ProofType{
+		byzantine.BadEncoding,
+		byzantine.UnorderedNamespaces,
+	}
+)
+
+// RegisterProofType registers the type of the given proof for packages defining fraud conditions
+// beyond the ones of the node, e.g. rollup-specific ones. Proofs of the type are unmarshalled with
+// their UnmarshalBinary and verified with their Validate. Once registered, they are gossiped, synced
+// and stored by the fraud service, and stop services relying on the chain like the node's own
+// proofs.
+//
+// It must be called before the node is started, e.g. in init of the registering package.
+func RegisterProofType(proof fraud.Proof) error {
+	proofTypesLk.Lock()
+	defer proofTypesLk.Unlock()
+
+	for _, registered := range fraud.Registered() {
+		if registered == proof.Type() {
+			return fmt.Errorf("fraud: proof type %s is already registered", proof.Type())
+		}
+	}
+	fraud.Register(proof)
+	proofTypes = append(proofTypes, proof.Type())
+	return nil
+}
+
+// ProofTypes returns the types of fraud proofs stopping services relying on the chain, including
+// the registered ones.
+func ProofTypes() []fraud.ProofType {
+	proofTypesLk.RLock()
+	defer proofTypesLk.RUnlock()
+
+	types := make([]fraud.ProofType, len(proofTypes))
+	copy(types, proofTypes)
+	return types
+}
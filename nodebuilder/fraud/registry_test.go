@@ -0,0 +1,57 @@
+package fraud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/go-fraud"
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
+)
+
+func TestRegisterProofType(t *testing.T) {
+	require.NoError(t, RegisterProofType(&rollupProof{}))
+	require.Contains(t, fraud.Registered(), rollupProofType)
+	require.Contains(t, ProofTypes(), rollupProofType)
+
+	// registered proofs are unmarshalled with their own unmarshaller
+	proof, err := fraud.Unmarshal(rollupProofType, []byte{7})
+	require.NoError(t, err)
+	require.EqualValues(t, 7, proof.Height())
+
+	require.Error(t, RegisterProofType(&rollupProof{}))
+	require.Error(t, RegisterProofType(&byzantine.BadEncodingProof{}))
+}
+
+const rollupProofType fraud.ProofType = "rollup"
+
+type rollupProof struct {
+	height uint64
+}
+
+func (p *rollupProof) Type() fraud.ProofType {
+	return rollupProofType
+}
+
+func (p *rollupProof) HeaderHash() []byte {
+	return nil
+}
+
+func (p *rollupProof) Height() uint64 {
+	return p.height
+}
+
+func (p *rollupProof) Validate(libhead.Header) error {
+	return nil
+}
+
+func (p *rollupProof) MarshalBinary() ([]byte, error) {
+	return []byte{byte(p.height)}, nil
+}
+
+func (p *rollupProof) UnmarshalBinary(data []byte) error {
+	p.height = uint64(data[0])
+	return nil
+}
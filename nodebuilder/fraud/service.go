@@ -3,6 +3,11 @@ package fraud
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ipfs/go-datastore"
 
 	"github.com/celestiaorg/go-fraud"
 )
@@ -54,6 +59,41 @@ func (s *Service) Get(ctx context.Context, proofType fraud.ProofType) ([]Proof,
 	return proofs, nil
 }
 
+func (s *Service) GetByHeight(ctx context.Context, proofType fraud.ProofType, height uint64) ([]Proof, error) {
+	all, err := s.Get(ctx, proofType)
+	if err != nil {
+		return nil, err
+	}
+	proofs := make([]Proof, 0, 1)
+	for _, proof := range all {
+		if proof.Height() == height {
+			proofs = append(proofs, proof)
+		}
+	}
+	if len(proofs) == 0 {
+		return nil, fmt.Errorf("fraud: no %s proofs at height %d: %w", proofType, height, datastore.ErrNotFound)
+	}
+	return proofs, nil
+}
+
+func (s *Service) List(ctx context.Context) ([]Proof, error) {
+	var proofs []Proof
+	for _, proofType := range fraud.Registered() {
+		typed, err := s.Get(ctx, proofType)
+		switch {
+		case errors.Is(err, datastore.ErrNotFound):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("getting proofs(%s): %w", proofType, err)
+		}
+		proofs = append(proofs, typed...)
+	}
+	sort.SliceStable(proofs, func(i, j int) bool {
+		return proofs[i].Height() < proofs[j].Height()
+	})
+	return proofs, nil
+}
+
 // Proof embeds the fraud.Proof interface type to provide a concrete type for JSON serialization.
 type Proof struct {
 	fraud.Proof
@@ -0,0 +1,62 @@
+package fraud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/go-fraud"
+
+	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
+)
+
+func TestService_GetByHeightAndList(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	serv := &Service{Service: &storedProofs{
+		byzantine.BadEncoding: {
+			&byzantine.BadEncodingProof{BlockHeight: 1},
+			&byzantine.BadEncodingProof{BlockHeight: 5},
+		},
+		byzantine.UnorderedNamespaces: {
+			&byzantine.UnorderedNamespacesProof{BlockHeight: 3},
+		},
+	}}
+
+	proofs, err := serv.GetByHeight(ctx, byzantine.BadEncoding, 5)
+	require.NoError(t, err)
+	require.Len(t, proofs, 1)
+	require.EqualValues(t, 5, proofs[0].Height())
+
+	_, err = serv.GetByHeight(ctx, byzantine.BadEncoding, 3)
+	require.ErrorIs(t, err, datastore.ErrNotFound)
+
+	proofs, err = serv.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, proofs, 3)
+	for i, height := range []uint64{1, 3, 5} {
+		require.Equal(t, height, proofs[i].Height())
+	}
+}
+
+// storedProofs is a fraud.Service serving the stored proofs by their type.
+type storedProofs map[fraud.ProofType][]fraud.Proof
+
+func (s storedProofs) Get(_ context.Context, proofType fraud.ProofType) ([]fraud.Proof, error) {
+	proofs, ok := s[proofType]
+	if !ok {
+		return nil, datastore.ErrNotFound
+	}
+	return proofs, nil
+}
+
+func (s storedProofs) Subscribe(fraud.ProofType) (fraud.Subscription, error) {
+	panic("not implemented")
+}
+
+func (s storedProofs) Broadcast(context.Context, fraud.Proof) error {
+	panic("not implemented")
+}
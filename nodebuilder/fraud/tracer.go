@@ -0,0 +1,230 @@
+package fraud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/celestiaorg/go-fraud"
+	"github.com/celestiaorg/go-fraud/fraudserv"
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// ProofStatus is the outcome of the validation of a fraud proof received from the network.
+type ProofStatus string
+
+const (
+	// ProofValidated proofs were validated against their headers and stored.
+	ProofValidated ProofStatus = "validated"
+	// ProofRejected proofs were rejected or ignored by the validation.
+	ProofRejected ProofStatus = "rejected"
+)
+
+// Reasons of rejection of fraud proofs. Proofs rejected by pubsub before their validation have the
+// reason of pubsub instead, e.g. "blacklisted_peer".
+const (
+	// RejectMalformed proofs can't be unmarshalled or are of unknown types.
+	RejectMalformed = "malformed"
+	// RejectInvalid proofs fail the validation against their headers.
+	RejectInvalid = "invalid"
+	// RejectKnown proofs are already stored.
+	RejectKnown = "known"
+	// RejectUnverifiable proofs can't be validated, as their headers are not available.
+	RejectUnverifiable = "unverifiable"
+)
+
+// ProofEvent is emitted on the libp2p event bus of the node for every fraud proof received from
+// the network, once it is validated or rejected, so that alerting pipelines can react to it.
+type ProofEvent struct {
+	ProofType fraud.ProofType `json:"proof_type"`
+	// Height is the height of the block the proof is against. It is zero for malformed proofs.
+	Height uint64      `json:"height"`
+	From   peer.ID     `json:"from"`
+	Status ProofStatus `json:"status"`
+	// Reason is the reason of rejection of rejected proofs.
+	Reason     string    `json:"reason,omitempty"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// proofTracer traces the fraud proofs received from the network through the validation pipeline
+// of pubsub, as the fraud service validates them internally. It emits every traced proof as a
+// ProofEvent and records its metrics, if enabled.
+type proofTracer struct {
+	topics map[string]fraud.ProofType
+	store  libhead.Store[*header.ExtendedHeader]
+	// emitter is nil when the node has no host, e.g. in tests.
+	emitter event.Emitter
+	metrics *metrics
+
+	// received holds the time proofs entered the validation by their message IDs.
+	received sync.Map
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newProofTracer(
+	network string,
+	store libhead.Store[*header.ExtendedHeader],
+	host host.Host,
+) (*proofTracer, error) {
+	t := &proofTracer{
+		topics: make(map[string]fraud.ProofType),
+		store:  store,
+	}
+	t.ctx, t.cancel = context.WithCancel(context.Background())
+	for _, proofType := range fraud.Registered() {
+		t.topics[fraudserv.PubsubTopicID(proofType.String(), network)] = proofType
+	}
+	if host != nil {
+		var err error
+		t.emitter, err = host.EventBus().Emitter(new(ProofEvent))
+		if err != nil {
+			return nil, fmt.Errorf("creating fraud proof emitter: %w", err)
+		}
+	}
+	return t, nil
+}
+
+func (t *proofTracer) Stop(context.Context) error {
+	t.cancel()
+	if t.emitter == nil {
+		return nil
+	}
+	return t.emitter.Close()
+}
+
+// proofType returns the type of proofs of the topic of the message, if it is a fraud topic and the
+// message is received from the network.
+func (t *proofTracer) proofType(msg *pubsub.Message) (fraud.ProofType, bool) {
+	if msg.Local {
+		return "", false
+	}
+	proofType, ok := t.topics[msg.GetTopic()]
+	return proofType, ok
+}
+
+func (t *proofTracer) ValidateMessage(msg *pubsub.Message) {
+	proofType, ok := t.proofType(msg)
+	if !ok {
+		return
+	}
+	t.received.Store(msg.ID, time.Now())
+	t.metrics.observeReceived(t.ctx, proofType)
+}
+
+func (t *proofTracer) DeliverMessage(msg *pubsub.Message) {
+	proofType, ok := t.proofType(msg)
+	if !ok {
+		return
+	}
+	proof, ok := msg.ValidatorData.(fraud.Proof)
+	if !ok {
+		return
+	}
+
+	evt := ProofEvent{
+		ProofType:  proofType,
+		Height:     proof.Height(),
+		From:       msg.ReceivedFrom,
+		Status:     ProofValidated,
+		ReceivedAt: t.receivedAt(msg),
+	}
+	log.Warnw("received valid fraud proof", "type", proofType, "height", evt.Height, "from", evt.From)
+	// the header of the proof is looked up off the validation pipeline
+	go func() {
+		t.metrics.observeValidated(t.ctx, proofType, t.receiptDelay(evt))
+		t.emit(evt)
+	}()
+}
+
+func (t *proofTracer) RejectMessage(msg *pubsub.Message, reason string) {
+	proofType, ok := t.proofType(msg)
+	if !ok {
+		return
+	}
+
+	// the fraud service only attaches the proof once it is unmarshalled and not known yet
+	proof, unmarshalled := msg.ValidatorData.(fraud.Proof)
+	switch {
+	case reason == pubsub.RejectValidationFailed && unmarshalled:
+		reason = RejectInvalid
+	case reason == pubsub.RejectValidationFailed:
+		reason = RejectMalformed
+	case reason == pubsub.RejectValidationIgnored && unmarshalled:
+		reason = RejectUnverifiable
+	case reason == pubsub.RejectValidationIgnored:
+		reason = RejectKnown
+	default:
+		reason = strings.ReplaceAll(reason, " ", "_")
+	}
+
+	evt := ProofEvent{
+		ProofType:  proofType,
+		From:       msg.ReceivedFrom,
+		Status:     ProofRejected,
+		Reason:     reason,
+		ReceivedAt: t.receivedAt(msg),
+	}
+	if unmarshalled {
+		evt.Height = proof.Height()
+	}
+	t.metrics.observeRejected(t.ctx, proofType, reason)
+	go t.emit(evt)
+}
+
+// receivedAt returns the time the message entered the validation and forgets it.
+func (t *proofTracer) receivedAt(msg *pubsub.Message) time.Time {
+	received, ok := t.received.LoadAndDelete(msg.ID)
+	if !ok {
+		// the message was rejected before its validation
+		return time.Now()
+	}
+	return received.(time.Time)
+}
+
+// receiptDelay returns the time from the block the proof is against to the receipt of the proof.
+// It is negative if the header of the block can't be retrieved.
+func (t *proofTracer) receiptDelay(evt ProofEvent) time.Duration {
+	h, err := t.store.GetByHeight(t.ctx, evt.Height)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			log.Debugw("getting header of fraud proof", "height", evt.Height, "err", err)
+		}
+		return -1
+	}
+	return evt.ReceivedAt.Sub(h.Time())
+}
+
+func (t *proofTracer) emit(evt ProofEvent) {
+	if t.emitter == nil {
+		return
+	}
+	if err := t.emitter.Emit(evt); err != nil {
+		log.Errorw("emitting fraud proof event", "type", evt.ProofType, "height", evt.Height, "err", err)
+	}
+}
+
+func (t *proofTracer) AddPeer(peer.ID, protocol.ID)         {}
+func (t *proofTracer) RemovePeer(peer.ID)                   {}
+func (t *proofTracer) Join(string)                          {}
+func (t *proofTracer) Leave(string)                         {}
+func (t *proofTracer) Graft(peer.ID, string)                {}
+func (t *proofTracer) Prune(peer.ID, string)                {}
+func (t *proofTracer) DuplicateMessage(*pubsub.Message)     {}
+func (t *proofTracer) ThrottlePeer(peer.ID)                 {}
+func (t *proofTracer) RecvRPC(*pubsub.RPC)                  {}
+func (t *proofTracer) SendRPC(*pubsub.RPC, peer.ID)         {}
+func (t *proofTracer) DropRPC(*pubsub.RPC, peer.ID)         {}
+func (t *proofTracer) UndeliverableMessage(*pubsub.Message) {}
@@ -0,0 +1,110 @@
+package fraud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/go-fraud/fraudserv"
+	libheadtest "github.com/celestiaorg/go-header/headertest"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
+)
+
+func TestProofTracer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	store := libheadtest.NewStore[*header.ExtendedHeader](t, headertest.NewTestSuite(t, 3), 3)
+	net, err := mocknet.WithNPeers(2)
+	require.NoError(t, err)
+	host, from := net.Hosts()[0], net.Hosts()[1].ID()
+	sub, err := host.EventBus().Subscribe(new(ProofEvent))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sub.Close()
+	})
+
+	tracer, err := newProofTracer("private", store, host)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, tracer.Stop(ctx))
+	})
+
+	topic := fraudserv.PubsubTopicID(byzantine.BadEncoding.String(), "private")
+	newMsg := func(id string, proof *byzantine.BadEncodingProof) *pubsub.Message {
+		msg := &pubsub.Message{
+			Message:      &pb.Message{Topic: &topic},
+			ID:           id,
+			ReceivedFrom: from,
+		}
+		if proof != nil {
+			msg.ValidatorData = proof
+		}
+		return msg
+	}
+	next := func() ProofEvent {
+		select {
+		case evt := <-sub.Out():
+			return evt.(ProofEvent)
+		case <-ctx.Done():
+			t.Fatal("fraud proof event wasn't emitted")
+			return ProofEvent{}
+		}
+	}
+
+	// valid proofs
+	msg := newMsg("valid", &byzantine.BadEncodingProof{BlockHeight: 2})
+	tracer.ValidateMessage(msg)
+	tracer.DeliverMessage(msg)
+	evt := next()
+	assert.Equal(t, byzantine.BadEncoding, evt.ProofType)
+	assert.EqualValues(t, 2, evt.Height)
+	assert.Equal(t, from, evt.From)
+	assert.Equal(t, ProofValidated, evt.Status)
+	assert.Empty(t, evt.Reason)
+
+	// rejected proofs, by reason
+	tests := []struct {
+		msg    *pubsub.Message
+		reason string
+		want   string
+	}{
+		{newMsg("invalid", &byzantine.BadEncodingProof{BlockHeight: 3}), pubsub.RejectValidationFailed, RejectInvalid},
+		{newMsg("malformed", nil), pubsub.RejectValidationFailed, RejectMalformed},
+		{
+			newMsg("unverifiable", &byzantine.BadEncodingProof{BlockHeight: 9}),
+			pubsub.RejectValidationIgnored,
+			RejectUnverifiable,
+		},
+		{newMsg("known", nil), pubsub.RejectValidationIgnored, RejectKnown},
+		{newMsg("blacklisted", nil), pubsub.RejectBlacklstedPeer, "blacklisted_peer"},
+	}
+	for _, tt := range tests {
+		tracer.ValidateMessage(tt.msg)
+		tracer.RejectMessage(tt.msg, tt.reason)
+		evt = next()
+		assert.Equal(t, ProofRejected, evt.Status)
+		assert.Equal(t, tt.want, evt.Reason)
+	}
+
+	// messages of other topics and local proofs are not traced
+	other := "other"
+	tracer.RejectMessage(&pubsub.Message{Message: &pb.Message{Topic: &other}}, pubsub.RejectValidationFailed)
+	local := newMsg("local", &byzantine.BadEncodingProof{BlockHeight: 2})
+	local.Local = true
+	tracer.DeliverMessage(local)
+	select {
+	case evt := <-sub.Out():
+		t.Fatalf("unexpected event: %v", evt)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
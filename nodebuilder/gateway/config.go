@@ -4,13 +4,32 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/celestiaorg/celestia-node/api/gateway"
+	"github.com/celestiaorg/celestia-node/libs/httputil"
 	"github.com/celestiaorg/celestia-node/libs/utils"
 )
 
 type Config struct {
-	Address             string
-	Port                string
-	Enabled             bool
+	Address string
+	Port    string
+	Enabled bool
+	// MaxRangeSize is the maximum amount of heights that can be requested through
+	// a single range route.
+	MaxRangeSize uint64
+	// MaxSubscriptions is the maximum amount of event streams and WebSocket subscriptions a single
+	// client can have open at once.
+	MaxSubscriptions int
+	// AuthRequired rejects requests without a token signed by the node. Requests with tokens are
+	// always authenticated, so that namespace-scoped tokens can only access their namespaces.
+	AuthRequired bool
+	// ServeBlocks enables the /ipfs/{cid} endpoint, serving NMT nodes of the local blockstore as raw
+	// blocks, so that IPFS tooling can traverse DAH trees of the node.
+	ServeBlocks bool
+	// TLS configures TLS termination of the gateway.
+	TLS httputil.TLSConfig
+	// CORS configures the origins browsers are allowed to call the gateway from.
+	CORS httputil.CORSConfig
+
 	deprecatedEndpoints bool
 }
 
@@ -18,8 +37,11 @@ func DefaultConfig() Config {
 	return Config{
 		Address: "0.0.0.0",
 		// do NOT expose the same port as celestia-core by default so that both can run on the same machine
-		Port:    "26659",
-		Enabled: false,
+		Port:         "26659",
+		Enabled:      false,
+		MaxRangeSize: gateway.DefaultMaxRangeSize,
+
+		MaxSubscriptions: gateway.DefaultMaxSubscriptions,
 	}
 }
 
@@ -34,5 +56,18 @@ func (cfg *Config) Validate() error {
 	if err != nil {
 		return fmt.Errorf("gateway: invalid port: %s", err.Error())
 	}
+	if cfg.MaxRangeSize == 0 {
+		return fmt.Errorf("gateway: max range size must be positive")
+	}
+	if cfg.MaxSubscriptions < 0 {
+		return fmt.Errorf("gateway: max subscriptions can't be negative")
+	}
+	if cfg.MaxSubscriptions == 0 {
+		// configs written before the limit was introduced
+		cfg.MaxSubscriptions = gateway.DefaultMaxSubscriptions
+	}
+	if err = cfg.TLS.Validate(); err != nil {
+		return fmt.Errorf("gateway: %w", err)
+	}
 	return nil
 }
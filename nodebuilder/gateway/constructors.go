@@ -1,8 +1,16 @@
 package gateway
 
 import (
+	"github.com/cristalhq/jwt"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	"golang.org/x/crypto/acme/autocert"
+
 	"github.com/celestiaorg/celestia-node/api/gateway"
+	"github.com/celestiaorg/celestia-node/api/rpc"
 	"github.com/celestiaorg/celestia-node/das"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	"github.com/celestiaorg/celestia-node/libs/httputil"
+	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
 	"github.com/celestiaorg/celestia-node/nodebuilder/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/share"
 	"github.com/celestiaorg/celestia-node/nodebuilder/state"
@@ -14,14 +22,38 @@ func Handler(
 	state state.Module,
 	share share.Module,
 	header header.Module,
+	blob blob.Module,
 	daser *das.DASer,
+	bs blockstore.Blockstore,
+	signer jwt.Signer,
+	revocations *authtoken.Revocations,
+	rpcSrv *rpc.Server,
 	serv *gateway.Server,
 ) {
-	handler := gateway.NewHandler(state, share, header, daser)
+	opts := []gateway.HandlerOption{
+		gateway.WithMaxRangeSize(cfg.MaxRangeSize),
+		gateway.WithMaxSubscriptions(cfg.MaxSubscriptions),
+		gateway.WithAuth(signer, cfg.AuthRequired),
+		gateway.WithRevocations(revocations),
+		gateway.WithAllowedOrigins(cfg.CORS),
+		// modules disabled at runtime, the audit log and rate limits of the RPC apply to the gateway
+		gateway.WithGuards(rpcSrv),
+	}
+	if cfg.ServeBlocks {
+		opts = append(opts, gateway.WithBlockstore(bs))
+	}
+	handler := gateway.NewHandler(state, share, header, blob, daser, opts...)
 	handler.RegisterEndpoints(serv, cfg.deprecatedEndpoints)
 	handler.RegisterMiddleware(serv)
 }
 
-func server(cfg *Config) *gateway.Server {
-	return gateway.NewServer(cfg.Address, cfg.Port)
+func server(cfg *Config, acme *autocert.Manager) (*gateway.Server, error) {
+	srv := gateway.NewServer(cfg.Address, cfg.Port)
+	srv.SetCORS(cfg.CORS)
+	tlsCfg, err := httputil.NewTLSConfig(cfg.TLS, acme)
+	if err != nil {
+		return nil, err
+	}
+	srv.SetTLS(tlsCfg)
+	return srv, nil
 }
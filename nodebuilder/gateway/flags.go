@@ -10,6 +10,11 @@ var (
 	addrFlag            = "gateway.addr"
 	portFlag            = "gateway.port"
 	deprecatedEndpoints = "gateway.deprecated-endpoints"
+	serveBlocksFlag     = "gateway.serve-blocks"
+
+	tlsCertFlag    = "gateway.tls.cert"
+	tlsKeyFlag     = "gateway.tls.key"
+	corsOriginFlag = "gateway.cors.origins"
 )
 
 // Flags gives a set of hardcoded node/gateway package flags.
@@ -26,6 +31,11 @@ func Flags() *flag.FlagSet {
 		false,
 		"Enables deprecated endpoints on the gateway. These will be removed in the next release.",
 	)
+	flags.Bool(
+		serveBlocksFlag,
+		false,
+		"Enables the /ipfs/{cid} endpoint serving NMT nodes of the local blockstore as raw blocks",
+	)
 	flags.String(
 		addrFlag,
 		"",
@@ -36,6 +46,21 @@ func Flags() *flag.FlagSet {
 		"",
 		"Set a custom gateway port (default: 26659)",
 	)
+	flags.String(
+		tlsCertFlag,
+		"",
+		"Path to the PEM-encoded TLS certificate of the gateway. Enables TLS along with --gateway.tls.key",
+	)
+	flags.String(
+		tlsKeyFlag,
+		"",
+		"Path to the PEM-encoded key of the TLS certificate of the gateway",
+	)
+	flags.StringSlice(
+		corsOriginFlag,
+		nil,
+		"Origins browsers are allowed to call the gateway from, e.g. https://example.com or *",
+	)
 
 	return flags
 }
@@ -50,6 +75,10 @@ func ParseFlags(cmd *cobra.Command, cfg *Config) {
 	if cmd.Flags().Changed(deprecatedEndpoints) && err == nil {
 		cfg.deprecatedEndpoints = deprecatedEndpointsEnabled
 	}
+	serveBlocks, err := cmd.Flags().GetBool(serveBlocksFlag)
+	if cmd.Flags().Changed(serveBlocksFlag) && err == nil {
+		cfg.ServeBlocks = serveBlocks
+	}
 	addr, port := cmd.Flag(addrFlag), cmd.Flag(portFlag)
 	if !cfg.Enabled && (addr.Changed || port.Changed) {
 		log.Warn("custom address or port provided without enabling gateway, setting config values")
@@ -62,4 +91,13 @@ func ParseFlags(cmd *cobra.Command, cfg *Config) {
 	if portVal != "" {
 		cfg.Port = portVal
 	}
+	cert, key := cmd.Flag(tlsCertFlag).Value.String(), cmd.Flag(tlsKeyFlag).Value.String()
+	if cert != "" || key != "" {
+		cfg.TLS.Enabled = true
+		cfg.TLS.CertFile, cfg.TLS.KeyFile = cert, key
+	}
+	origins, err := cmd.Flags().GetStringSlice(corsOriginFlag)
+	if cmd.Flags().Changed(corsOriginFlag) && err == nil {
+		cfg.CORS.AllowedOrigins = origins
+	}
 }
@@ -3,10 +3,15 @@ package gateway
 import (
 	"context"
 
+	"github.com/cristalhq/jwt"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
 	logging "github.com/ipfs/go-log/v2"
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-node/api/gateway"
+	"github.com/celestiaorg/celestia-node/api/rpc"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	blobServ "github.com/celestiaorg/celestia-node/nodebuilder/blob"
 	headerServ "github.com/celestiaorg/celestia-node/nodebuilder/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	shareServ "github.com/celestiaorg/celestia-node/nodebuilder/share"
@@ -52,9 +57,14 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 				state stateServ.Module,
 				share shareServ.Module,
 				header headerServ.Module,
+				blob blobServ.Module,
+				bs blockstore.Blockstore,
+				signer jwt.Signer,
+				revocations *authtoken.Revocations,
+				rpcSrv *rpc.Server,
 				serv *gateway.Server,
 			) {
-				Handler(cfg, state, share, header, nil, serv)
+				Handler(cfg, state, share, header, blob, nil, bs, signer, revocations, rpcSrv, serv)
 			}),
 		)
 	default:
@@ -2,7 +2,9 @@ package header
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
@@ -25,14 +27,39 @@ type Config struct {
 	// Note: The trusted does *not* imply Headers are not verified, but trusted as reliable to fetch
 	// headers at any moment.
 	TrustedPeers []string
+	// TrustedPeersQuorum is the amount of trusted peers that must agree on the head of the network for
+	// it to be accepted. It is 0, or disabled, by default, in which case the highest head reported by
+	// any trusted peer is accepted.
+	TrustedPeersQuorum int
 
 	Store  store.Parameters
 	Syncer sync.Parameters
 
 	Server p2p_exchange.ServerParameters
 	Client p2p_exchange.ClientParameters `toml:",omitempty"`
+	// Throttle limits how fast headers are synced. It is disabled by default.
+	Throttle ThrottleConfig
+
+	// Pruning configures pruning of old headers from the store. Only light nodes may prune headers.
+	Pruning PruningConfig
+}
+
+// PruningConfig configures pruning of headers older than the retention period from the store, for
+// light nodes with constrained storage. Headers not sampled by the DASer yet are never pruned.
+type PruningConfig struct {
+	Enabled bool
+	// Retention is the period headers are kept for, counted from their time. It defaults to the
+	// sampling window, beyond which data is not retained by the network.
+	Retention time.Duration
+	// Interval is how often old headers are pruned.
+	Interval time.Duration
 }
 
+const (
+	defaultPruningRetention = 30 * 24 * time.Hour
+	defaultPruningInterval  = time.Hour
+)
+
 func DefaultConfig(tp node.Type) Config {
 	cfg := Config{
 		TrustedHash:  "",
@@ -40,6 +67,10 @@ func DefaultConfig(tp node.Type) Config {
 		Store:        store.DefaultParameters(),
 		Syncer:       sync.DefaultParameters(),
 		Server:       p2p_exchange.DefaultServerParameters(),
+		Pruning: PruningConfig{
+			Retention: defaultPruningRetention,
+			Interval:  defaultPruningInterval,
+		},
 	}
 
 	switch tp {
@@ -102,6 +133,24 @@ func (cfg *Config) Validate(tp node.Type) error {
 		return fmt.Errorf("module/header: misconfiguration of p2p exchange server: %w", err)
 	}
 
+	if cfg.TrustedPeersQuorum < 0 {
+		return errors.New("module/header: trusted peers quorum must not be negative")
+	}
+	if len(cfg.TrustedPeers) > 0 && cfg.TrustedPeersQuorum > len(cfg.TrustedPeers) {
+		return fmt.Errorf("module/header: trusted peers quorum of %d exceeds the amount of trusted peers: %d",
+			cfg.TrustedPeersQuorum, len(cfg.TrustedPeers))
+	}
+
+	err = cfg.Throttle.Validate()
+	if err != nil {
+		return fmt.Errorf("module/header: misconfiguration of throttle: %w", err)
+	}
+
+	err = cfg.Pruning.Validate(tp)
+	if err != nil {
+		return fmt.Errorf("module/header: misconfiguration of pruning: %w", err)
+	}
+
 	// we do not create a client for bridge nodes
 	if tp == node.Bridge {
 		return nil
@@ -114,3 +163,20 @@ func (cfg *Config) Validate(tp node.Type) error {
 
 	return nil
 }
+
+// Validate performs basic validation of the pruning config.
+func (cfg *PruningConfig) Validate(tp node.Type) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if tp != node.Light {
+		return fmt.Errorf("pruning is only supported by light nodes, not %s", tp)
+	}
+	if cfg.Retention <= 0 {
+		return errors.New("retention must be positive")
+	}
+	if cfg.Interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	return nil
+}
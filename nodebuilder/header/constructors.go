@@ -2,6 +2,7 @@ package header
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -18,7 +19,6 @@ import (
 	"github.com/celestiaorg/celestia-node/header"
 	modfraud "github.com/celestiaorg/celestia-node/nodebuilder/fraud"
 	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
-	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
 )
 
 // newP2PExchange constructs a new Exchange for headers.
@@ -39,7 +39,10 @@ func newP2PExchange(
 		ids[index] = peer.ID
 		host.Peerstore().AddAddrs(peer.ID, peer.Addrs, peerstore.PermanentAddrTTL)
 	}
-	exchange, err := p2p.NewExchange[*header.ExtendedHeader](host, ids, conngater,
+	// the peers serving the headers are tracked by the host, so the validatingExchange can penalize
+	// the ones serving mismatching headers
+	tracking := &trackingHost{Host: host, protocol: headerExchangeProtocol(network)}
+	exchange, err := p2p.NewExchange[*header.ExtendedHeader](tracking, ids, conngater,
 		p2p.WithParams(cfg.Client),
 		p2p.WithNetworkID[p2p.ClientParameters](network.String()),
 		p2p.WithChainID(network.String()),
@@ -55,8 +58,19 @@ func newP2PExchange(
 			return exchange.Stop(ctx)
 		},
 	})
-	return exchange, nil
 
+	var ex libhead.Exchange[*header.ExtendedHeader] = newValidatingExchange(exchange, host, conngater, ids)
+	if cfg.TrustedPeersQuorum != 0 {
+		if cfg.TrustedPeersQuorum > len(ids) {
+			return nil, fmt.Errorf("trusted peers quorum of %d exceeds the amount of trusted peers: %d",
+				cfg.TrustedPeersQuorum, len(ids))
+		}
+		ex = newQuorumExchange(ex, host, network, ids, cfg.TrustedPeersQuorum)
+	}
+	if cfg.Throttle.enabled() {
+		ex = newThrottledExchange(ex, cfg.Throttle)
+	}
+	return ex, nil
 }
 
 // newSyncer constructs new Syncer for headers.
@@ -76,9 +90,9 @@ func newSyncer(
 	}
 
 	return syncer, &modfraud.ServiceBreaker[*sync.Syncer[*header.ExtendedHeader]]{
-		Service:   syncer,
-		FraudType: byzantine.BadEncoding,
-		FraudServ: fservice,
+		Service:    syncer,
+		FraudTypes: modfraud.ProofTypes(),
+		FraudServ:  fservice,
 	}, nil
 }
 
@@ -86,12 +100,13 @@ func newSyncer(
 // NOTE: It is needed to ensure that Store is always initialized before Syncer is started.
 type InitStore libhead.Store[*header.ExtendedHeader]
 
-// newInitStore constructs an initialized store
+// newInitStore constructs an initialized store. Headers are appended through the lazyStore, so that
+// they are trimmed for the LazyGetter.
 func newInitStore(
 	lc fx.Lifecycle,
 	cfg Config,
 	net modp2p.Network,
-	s libhead.Store[*header.ExtendedHeader],
+	s *lazyStore,
 	ex libhead.Exchange[*header.ExtendedHeader],
 ) (InitStore, error) {
 	trustedHash, err := cfg.trustedHash(net)
@@ -101,7 +116,7 @@ func newInitStore(
 
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			return store.Init(ctx, s, ex, trustedHash)
+			return store.Init[*header.ExtendedHeader](ctx, s, ex, trustedHash)
 		},
 	})
 
@@ -0,0 +1,303 @@
+package header
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/host"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// recentHeadersSize is the amount of recently observed headers not yet in the store that
+// equivocations are detected against.
+const recentHeadersSize = 512
+
+// equivocationDetector detects equivocations among headers observed from gossip and exchange,
+// comparing them against stored and recently observed headers. Detected equivocations are
+// recorded in the datastore and emitted as events. Equivocations of the stored head, which
+// replace it, are emitted as events as well.
+//
+// To keep observation cheap, headers are only validated once they conflict with another one.
+type equivocationDetector struct {
+	store libhead.Store[*header.ExtendedHeader]
+	ds    datastore.Datastore
+	// emitters are nil when the node has no host, e.g. in tests.
+	emitter            event.Emitter
+	replacementEmitter event.Emitter
+
+	lk     sync.Mutex
+	recent *lru.Cache[uint64, *header.ExtendedHeader]
+}
+
+func newEquivocationDetector(
+	ds datastore.Batching,
+	store libhead.Store[*header.ExtendedHeader],
+	host host.Host,
+) (*equivocationDetector, error) {
+	recent, err := lru.New[uint64, *header.ExtendedHeader](recentHeadersSize)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &equivocationDetector{
+		store:  store,
+		ds:     namespace.Wrap(ds, datastore.NewKey("/header/equivocations")),
+		recent: recent,
+	}
+	if host != nil {
+		d.emitter, err = host.EventBus().Emitter(new(header.Equivocation))
+		if err != nil {
+			return nil, fmt.Errorf("creating equivocation emitter: %w", err)
+		}
+		d.replacementEmitter, err = host.EventBus().Emitter(new(header.HeadReplacement))
+		if err != nil {
+			return nil, fmt.Errorf("creating head replacement emitter: %w", err)
+		}
+	}
+	return d, nil
+}
+
+func (d *equivocationDetector) Stop(context.Context) error {
+	if d.emitter == nil {
+		return nil
+	}
+	return errors.Join(d.emitter.Close(), d.replacementEmitter.Close())
+}
+
+// observe checks the given header for equivocation.
+func (d *equivocationDetector) observe(ctx context.Context, h *header.ExtendedHeader) {
+	if h.IsZero() {
+		return
+	}
+	height := uint64(h.Height())
+
+	if d.store.HasAt(ctx, height) {
+		stored, err := d.store.GetByHeight(ctx, height)
+		if err != nil {
+			log.Debugw("getting stored header to check for equivocation", "height", height, "err", err)
+			return
+		}
+		// stored headers are already verified
+		if d.check(ctx, stored, h) && height == d.store.Height() {
+			d.replaceHead(ctx, stored, h)
+		}
+		return
+	}
+
+	d.lk.Lock()
+	first, ok := d.recent.Get(height)
+	if !ok || bytes.Equal(first.Hash(), h.Hash()) {
+		d.recent.Add(height, h)
+		d.lk.Unlock()
+		return
+	}
+	d.lk.Unlock()
+
+	if first.Validate() != nil {
+		// the invalid header is replaced, so that further headers are checked against the valid one
+		d.lk.Lock()
+		d.recent.Add(height, h)
+		d.lk.Unlock()
+		return
+	}
+	d.check(ctx, first, h)
+}
+
+// check records the equivocation of the given headers, if any. It reports whether a new
+// equivocation was recorded.
+func (d *equivocationDetector) check(ctx context.Context, first, second *header.ExtendedHeader) bool {
+	if bytes.Equal(first.Hash(), second.Hash()) {
+		return false
+	}
+
+	key := equivocationKey(uint64(second.Height()), second.Hash())
+	recorded, err := d.ds.Has(ctx, key)
+	if err != nil {
+		log.Errorw("checking recorded equivocations", "height", second.Height(), "err", err)
+		return false
+	}
+	if recorded {
+		return false
+	}
+
+	eq, err := header.NewEquivocation(first, second)
+	if err != nil {
+		// headers failing validation are not evidence of misbehavior
+		log.Debugw("conflicting header is not an equivocation", "height", second.Height(), "err", err)
+		return false
+	}
+
+	bin, err := json.Marshal(eq)
+	if err != nil {
+		log.Errorw("marshaling equivocation", "height", eq.Height, "err", err)
+		return false
+	}
+	err = d.ds.Put(ctx, key, bin)
+	if err != nil {
+		log.Errorw("recording equivocation", "height", eq.Height, "err", err)
+	}
+
+	log.Errorw("CRITICAL: equivocating headers detected, consensus may be compromised",
+		"height", eq.Height,
+		"first", first.Hash(),
+		"second", second.Hash(),
+	)
+	if d.emitter != nil {
+		if err := d.emitter.Emit(*eq); err != nil {
+			log.Errorw("emitting equivocation", "height", eq.Height, "err", err)
+		}
+	}
+	return true
+}
+
+// replaceHead emits the replacement of the stored head by the competing header, if the latter
+// extends the header the head extends, so that modules depending on the head roll back their state.
+func (d *equivocationDetector) replaceHead(ctx context.Context, head, competing *header.ExtendedHeader) {
+	height := uint64(head.Height())
+	if height <= 1 {
+		return
+	}
+	parent, err := d.store.GetByHeight(ctx, height-1)
+	if err != nil {
+		log.Errorw("getting parent of the head to verify its replacement", "height", height, "err", err)
+		return
+	}
+	r, err := header.NewHeadReplacement(parent, head, competing)
+	if err != nil {
+		log.Debugw("competing header does not replace the head", "height", height, "err", err)
+		return
+	}
+
+	log.Warnw("head replaced by a competing header, rolling back its state",
+		"height", height,
+		"replaced", head.Hash(),
+		"replacement", competing.Hash(),
+	)
+	if d.replacementEmitter != nil {
+		if err := d.replacementEmitter.Emit(*r); err != nil {
+			log.Errorw("emitting head replacement", "height", height, "err", err)
+		}
+	}
+}
+
+// equivocations lists all the recorded equivocations.
+func (d *equivocationDetector) equivocations(ctx context.Context) ([]*header.Equivocation, error) {
+	res, err := d.ds.Query(ctx, query.Query{})
+	if err != nil {
+		return nil, fmt.Errorf("querying equivocations: %w", err)
+	}
+	defer res.Close()
+
+	eqs := make([]*header.Equivocation, 0)
+	for entry := range res.Next() {
+		if entry.Error != nil {
+			return nil, fmt.Errorf("reading equivocations: %w", entry.Error)
+		}
+		eq := &header.Equivocation{}
+		err = json.Unmarshal(entry.Value, eq)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshaling equivocation: %w", err)
+		}
+		eqs = append(eqs, eq)
+	}
+	return eqs, nil
+}
+
+func equivocationKey(height uint64, hash libhead.Hash) datastore.Key {
+	return datastore.NewKey(strconv.FormatUint(height, 10)).ChildString(hash.String())
+}
+
+// subscriber wraps the Subscriber, so that every header received from gossip is observed before
+// being validated.
+func (d *equivocationDetector) subscriber(
+	sub libhead.Subscriber[*header.ExtendedHeader],
+) libhead.Subscriber[*header.ExtendedHeader] {
+	return &observingSubscriber{Subscriber: sub, detector: d}
+}
+
+// exchange wraps the Exchange, so that every header it returns is observed.
+func (d *equivocationDetector) exchange(
+	ex libhead.Exchange[*header.ExtendedHeader],
+) libhead.Exchange[*header.ExtendedHeader] {
+	return &observingExchange{Exchange: ex, detector: d}
+}
+
+type observingSubscriber struct {
+	libhead.Subscriber[*header.ExtendedHeader]
+	detector *equivocationDetector
+}
+
+func (s *observingSubscriber) AddValidator(
+	val func(context.Context, *header.ExtendedHeader) pubsub.ValidationResult,
+) error {
+	return s.Subscriber.AddValidator(func(ctx context.Context, h *header.ExtendedHeader) pubsub.ValidationResult {
+		s.detector.observe(ctx, h)
+		return val(ctx, h)
+	})
+}
+
+type observingExchange struct {
+	libhead.Exchange[*header.ExtendedHeader]
+	detector *equivocationDetector
+}
+
+func (ex *observingExchange) Head(ctx context.Context) (*header.ExtendedHeader, error) {
+	h, err := ex.Exchange.Head(ctx)
+	if err == nil {
+		ex.detector.observe(ctx, h)
+	}
+	return h, err
+}
+
+func (ex *observingExchange) Get(ctx context.Context, hash libhead.Hash) (*header.ExtendedHeader, error) {
+	h, err := ex.Exchange.Get(ctx, hash)
+	if err == nil {
+		ex.detector.observe(ctx, h)
+	}
+	return h, err
+}
+
+func (ex *observingExchange) GetByHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	h, err := ex.Exchange.GetByHeight(ctx, height)
+	if err == nil {
+		ex.detector.observe(ctx, h)
+	}
+	return h, err
+}
+
+func (ex *observingExchange) GetRangeByHeight(
+	ctx context.Context,
+	from, amount uint64,
+) ([]*header.ExtendedHeader, error) {
+	hs, err := ex.Exchange.GetRangeByHeight(ctx, from, amount)
+	for _, h := range hs {
+		ex.detector.observe(ctx, h)
+	}
+	return hs, err
+}
+
+func (ex *observingExchange) GetVerifiedRange(
+	ctx context.Context,
+	from *header.ExtendedHeader,
+	amount uint64,
+) ([]*header.ExtendedHeader, error) {
+	hs, err := ex.Exchange.GetVerifiedRange(ctx, from, amount)
+	for _, h := range hs {
+		ex.detector.observe(ctx, h)
+	}
+	return hs, err
+}
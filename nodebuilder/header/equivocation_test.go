@@ -0,0 +1,150 @@
+package header
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	libhead "github.com/celestiaorg/go-header"
+	libheadtest "github.com/celestiaorg/go-header/headertest"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+)
+
+func TestEquivocationDetector(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := headertest.NewTestSuite(t, 3)
+	store := libheadtest.NewStore[*header.ExtendedHeader](t, suite, 3)
+
+	net, err := mocknet.WithNPeers(1)
+	require.NoError(t, err)
+	host := net.Hosts()[0]
+	sub, err := host.EventBus().Subscribe(new(header.Equivocation))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sub.Close()
+	})
+
+	detector, err := newEquivocationDetector(ds_sync.MutexWrap(datastore.NewMapDatastore()), store, host)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, detector.Stop(ctx))
+	})
+
+	stored := suite.Head()
+	recent := suite.NextHeader()
+
+	// the same headers are not equivocations
+	detector.observe(ctx, stored)
+	detector.observe(ctx, recent)
+	eqs, err := detector.equivocations(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, eqs)
+
+	// conflicting, but invalid headers are not equivocations either
+	invalid := conflictingHeader(suite, stored)
+	invalid.Commit.Signatures[0].Signature[0] ^= 0xFF
+	detector.observe(ctx, invalid)
+	eqs, err = detector.equivocations(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, eqs)
+
+	// equivocations are detected against both stored and recently observed headers
+	for _, first := range []*header.ExtendedHeader{stored, recent} {
+		second := conflictingHeader(suite, first)
+		detector.observe(ctx, second)
+		// observing the same equivocation again is not reported twice
+		detector.observe(ctx, second)
+
+		select {
+		case evt := <-sub.Out():
+			eq := evt.(header.Equivocation)
+			assert.EqualValues(t, first.Height(), eq.Height)
+			assert.True(t, first.Equals(eq.First))
+			assert.True(t, second.Equals(eq.Second))
+		case <-ctx.Done():
+			t.Fatal("equivocation event wasn't emitted")
+		}
+	}
+
+	eqs, err = detector.equivocations(ctx)
+	require.NoError(t, err)
+	assert.Len(t, eqs, 2)
+	select {
+	case evt := <-sub.Out():
+		t.Fatalf("unexpected event: %v", evt)
+	default:
+	}
+}
+
+func TestEquivocationDetector_HeadReplacement(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := headertest.NewTestSuite(t, 3)
+	store := libheadtest.NewStore[*header.ExtendedHeader](t, suite, 3)
+
+	net, err := mocknet.WithNPeers(1)
+	require.NoError(t, err)
+	host := net.Hosts()[0]
+	sub, err := host.EventBus().Subscribe(new(header.HeadReplacement))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sub.Close()
+	})
+
+	detector, err := newEquivocationDetector(ds_sync.MutexWrap(datastore.NewMapDatastore()), store, host)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, detector.Stop(ctx))
+	})
+
+	head, err := store.Head(ctx)
+	require.NoError(t, err)
+	below, err := store.GetByHeight(ctx, uint64(head.Height())-1)
+	require.NoError(t, err)
+
+	// equivocations below the head do not replace it
+	detector.observe(ctx, conflictingHeader(suite, below))
+
+	replacement := conflictingHeader(suite, head)
+	detector.observe(ctx, replacement)
+	select {
+	case evt := <-sub.Out():
+		r := evt.(header.HeadReplacement)
+		assert.EqualValues(t, head.Height(), r.Height)
+		assert.True(t, head.Equals(r.Replaced))
+		assert.True(t, replacement.Equals(r.Replacement))
+	case <-ctx.Done():
+		t.Fatal("head replacement event wasn't emitted")
+	}
+
+	// the same replacement is not reported twice
+	detector.observe(ctx, replacement)
+	select {
+	case evt := <-sub.Out():
+		t.Fatalf("unexpected event: %v", evt)
+	default:
+	}
+}
+
+// conflictingHeader creates a valid header for the same height as the given one, but with a
+// different hash.
+func conflictingHeader(suite *headertest.TestSuite, h *header.ExtendedHeader) *header.ExtendedHeader {
+	rh := suite.GenRawHeader(h.Height(), h.LastHeader(), libhead.Hash(h.LastCommitHash), libhead.Hash(h.DataHash))
+	return &header.ExtendedHeader{
+		RawHeader:    *rh,
+		Commit:       suite.Commit(rh),
+		ValidatorSet: h.ValidatorSet,
+		DAH:          h.DAH,
+	}
+}
@@ -12,6 +12,7 @@ import (
 var (
 	headersTrustedHashFlag  = "headers.trusted-hash"
 	headersTrustedPeersFlag = "headers.trusted-peers"
+	headersQuorumFlag       = "headers.trusted-peers-quorum"
 )
 
 // Flags gives a set of hardcoded Header package flags.
@@ -40,6 +41,12 @@ func TrustedPeersFlags() *flag.FlagSet {
 		nil,
 		"Multiaddresses of a reliable peers to fetch headers from. (Format: multiformats.io/multiaddr)",
 	)
+	flags.Int(
+		headersQuorumFlag,
+		0,
+		"Amount of trusted peers that must agree on the head of the network before syncing it. "+
+			"Disabled when 0",
+	)
 	return flags
 }
 
@@ -61,6 +68,14 @@ func ParseTrustedPeerFlags(
 		}
 	}
 	cfg.TrustedPeers = append(cfg.TrustedPeers, tpeers...)
+
+	if cmd.Flags().Changed(headersQuorumFlag) {
+		quorum, err := cmd.Flags().GetInt(headersQuorumFlag)
+		if err != nil {
+			return err
+		}
+		cfg.TrustedPeersQuorum = quorum
+	}
 	return nil
 }
 
@@ -30,6 +30,13 @@ type Module interface {
 	// GetByHeight returns the ExtendedHeader at the given height if it is
 	// currently available.
 	GetByHeight(context.Context, uint64) (*header.ExtendedHeader, error)
+	// GetLazyByHeight returns the ExtendedHeader at the given height if it is currently available.
+	// Unless full is set, the header is read without the signatures of its commit and its validator
+	// set, which are only needed to verify it, so that it is cheaper to load from the store.
+	GetLazyByHeight(ctx context.Context, height uint64, full bool) (*header.ExtendedHeader, error)
+	// GetRangeByHeight returns the given range [from:to) of ExtendedHeaders from the node's header
+	// store in one call, if they are all currently available.
+	GetRangeByHeight(ctx context.Context, from, to uint64) ([]*header.ExtendedHeader, error)
 	// WaitForHeight blocks until the header at the given height has been processed
 	// by the store or context deadline is exceeded.
 	WaitForHeight(context.Context, uint64) (*header.ExtendedHeader, error)
@@ -43,6 +50,10 @@ type Module interface {
 
 	// Subscribe to recent ExtendedHeaders from the network.
 	Subscribe(ctx context.Context) (<-chan *header.ExtendedHeader, error)
+
+	// Equivocations returns evidence of all the equivocations detected by the node, i.e. pairs of
+	// valid ExtendedHeaders for the same height with different hashes observed from the network.
+	Equivocations(ctx context.Context) ([]*header.Equivocation, error)
 }
 
 // API is a wrapper around Module for the RPC.
@@ -59,12 +70,22 @@ type API struct {
 			*header.ExtendedHeader,
 			uint64,
 		) ([]*header.ExtendedHeader, error) `perm:"public"`
-		GetByHeight   func(context.Context, uint64) (*header.ExtendedHeader, error)    `perm:"public"`
+		GetByHeight     func(context.Context, uint64) (*header.ExtendedHeader, error) `perm:"public"`
+		GetLazyByHeight func(
+			ctx context.Context,
+			height uint64,
+			full bool,
+		) (*header.ExtendedHeader, error) `perm:"public"`
+		GetRangeByHeight func(
+			ctx context.Context,
+			from, to uint64,
+		) ([]*header.ExtendedHeader, error) `perm:"public"`
 		WaitForHeight func(context.Context, uint64) (*header.ExtendedHeader, error)    `perm:"read"`
 		SyncState     func(ctx context.Context) (sync.State, error)                    `perm:"read"`
 		SyncWait      func(ctx context.Context) error                                  `perm:"read"`
 		NetworkHead   func(ctx context.Context) (*header.ExtendedHeader, error)        `perm:"public"`
 		Subscribe     func(ctx context.Context) (<-chan *header.ExtendedHeader, error) `perm:"public"`
+		Equivocations func(ctx context.Context) ([]*header.Equivocation, error)        `perm:"read"`
 	}
 }
 
@@ -84,6 +105,14 @@ func (api *API) GetByHeight(ctx context.Context, u uint64) (*header.ExtendedHead
 	return api.Internal.GetByHeight(ctx, u)
 }
 
+func (api *API) GetLazyByHeight(ctx context.Context, height uint64, full bool) (*header.ExtendedHeader, error) {
+	return api.Internal.GetLazyByHeight(ctx, height, full)
+}
+
+func (api *API) GetRangeByHeight(ctx context.Context, from, to uint64) ([]*header.ExtendedHeader, error) {
+	return api.Internal.GetRangeByHeight(ctx, from, to)
+}
+
 func (api *API) WaitForHeight(ctx context.Context, u uint64) (*header.ExtendedHeader, error) {
 	return api.Internal.WaitForHeight(ctx, u)
 }
@@ -107,3 +136,7 @@ func (api *API) NetworkHead(ctx context.Context) (*header.ExtendedHeader, error)
 func (api *API) Subscribe(ctx context.Context) (<-chan *header.ExtendedHeader, error) {
 	return api.Internal.Subscribe(ctx)
 }
+
+func (api *API) Equivocations(ctx context.Context) ([]*header.Equivocation, error) {
+	return api.Internal.Equivocations(ctx)
+}
@@ -0,0 +1,93 @@
+package header
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/go-header/sync"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/watchdog"
+)
+
+// syncerProbe checks in with the watchdog on behalf of the syncer, which can't check in itself.
+// Reading the state of the syncer never blocks, so the probe checks the progress of the syncer
+// instead: it checks in only once the height of the synced head advanced since the previous tick.
+// As the network produces blocks well within the heartbeat interval, a head not advancing for more
+// than the allowed missed heartbeats means the syncer, or the network it syncs from, is stalled.
+type syncerProbe struct {
+	syncer *sync.Syncer[*header.ExtendedHeader]
+	hb     *watchdog.Heartbeat
+	// height is the height of the synced head seen on the last check-in
+	height uint64
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type syncerProbeParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Syncer    *sync.Syncer[*header.ExtendedHeader]
+	// Heartbeats is nil if the watchdog is disabled
+	Heartbeats *watchdog.Registry `optional:"true"`
+}
+
+// withSyncerProbe decorates the syncer with the syncerProbe, if the watchdog is enabled.
+func withSyncerProbe(params syncerProbeParams) *sync.Syncer[*header.ExtendedHeader] {
+	if params.Heartbeats == nil {
+		return params.Syncer
+	}
+	probe := &syncerProbe{syncer: params.Syncer}
+	params.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			probe.start(params.Heartbeats)
+			return nil
+		},
+		OnStop: probe.stop,
+	})
+	return params.Syncer
+}
+
+func (p *syncerProbe) start(heartbeats *watchdog.Registry) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	p.hb = heartbeats.Register("header", "header/syncer")
+	go p.run(ctx)
+}
+
+func (p *syncerProbe) run(ctx context.Context) {
+	defer close(p.done)
+	for {
+		select {
+		case <-p.hb.Tick():
+			p.probe()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// probe checks in if the synced head advanced.
+func (p *syncerProbe) probe() {
+	// the height is of the head of the store, which is left as is if reading it fails
+	state := p.syncer.State()
+	if state.Height > p.height {
+		p.height = state.Height
+		p.hb.Beat()
+	}
+}
+
+func (p *syncerProbe) stop(ctx context.Context) error {
+	p.cancel()
+	p.hb.Stop()
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
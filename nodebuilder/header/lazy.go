@@ -0,0 +1,114 @@
+package header
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/ipfs/go-datastore"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// trimmedCacheSize is the amount of recently read trimmed headers cached by the LazyGetter.
+const trimmedCacheSize = 256
+
+// trimmedPrefix is the prefix of keys of trimmed headers, indexed by their heights.
+var trimmedPrefix = datastore.NewKey("header_trimmed")
+
+// LazyGetter gets headers without the signatures of their commits and their validator sets, for
+// consumers only needing the DAH and the height, like the DASer. Headers not trimmed yet are got
+// whole from the store.
+type LazyGetter libhead.Getter[*header.ExtendedHeader]
+
+// lazyStore wraps the header store, so that every appended header is written trimmed under its
+// own key as well. The signatures of commits and validator sets are kept by the store only, which
+// loads them when the whole header is got.
+type lazyStore struct {
+	libhead.Store[*header.ExtendedHeader]
+
+	ds datastore.Batching
+	// cache holds recently read trimmed headers, as the caches of the store hold whole ones
+	cache *lru.Cache[uint64, *header.ExtendedHeader]
+}
+
+func newLazyStore(ds datastore.Batching, store libhead.Store[*header.ExtendedHeader]) (*lazyStore, error) {
+	cache, err := lru.New[uint64, *header.ExtendedHeader](trimmedCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return &lazyStore{
+		Store: store,
+		ds:    ds,
+		cache: cache,
+	}, nil
+}
+
+// Append appends the headers to the store and writes the appended ones trimmed.
+func (s *lazyStore) Append(ctx context.Context, headers ...*header.ExtendedHeader) error {
+	appendErr := s.Store.Append(ctx, headers...)
+	batch, err := s.ds.Batch(ctx)
+	if err != nil {
+		return errors.Join(appendErr, err)
+	}
+	for _, h := range headers {
+		if appendErr != nil {
+			// the store appends the headers preceding an invalid one. The ones it doesn't report as
+			// stored yet are not trimmed, as the LazyGetter gets them from the store anyway
+			if stored, err := s.Store.Has(ctx, h.Hash()); err != nil || !stored {
+				break
+			}
+		}
+		data, err := header.MarshalTrimmedExtendedHeader(h)
+		if err != nil {
+			return errors.Join(appendErr, err)
+		}
+		if err = batch.Put(ctx, trimmedKey(uint64(h.Height())), data); err != nil {
+			return errors.Join(appendErr, err)
+		}
+	}
+	return errors.Join(appendErr, batch.Commit(ctx))
+}
+
+// lazyGetter gets the trimmed headers written by the lazyStore.
+type lazyGetter lazyStore
+
+func (g *lazyGetter) GetByHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	if h, ok := g.cache.Get(height); ok {
+		return h, nil
+	}
+	data, err := g.ds.Get(ctx, trimmedKey(height))
+	if errors.Is(err, datastore.ErrNotFound) {
+		// headers appended before trimming or not appended yet are left to the store, which reads,
+		// waits for or reports them
+		return g.Store.GetByHeight(ctx, height)
+	}
+	if err != nil {
+		return nil, err
+	}
+	h, err := header.UnmarshalTrimmedExtendedHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	g.cache.Add(height, h)
+	return h, nil
+}
+
+func (g *lazyGetter) GetRangeByHeight(ctx context.Context, from, to uint64) ([]*header.ExtendedHeader, error) {
+	headers := make([]*header.ExtendedHeader, 0, to-from)
+	for height := from; height < to; height++ {
+		h, err := g.GetByHeight(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, h)
+	}
+	return headers, nil
+}
+
+func trimmedKey(height uint64) datastore.Key {
+	return trimmedPrefix.ChildString(strconv.FormatUint(height, 10))
+}
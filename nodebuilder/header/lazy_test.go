@@ -0,0 +1,75 @@
+package header
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header/headertest"
+)
+
+func TestLazyGetter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	suite := headertest.NewTestSuite(t, 3)
+	genesis := suite.Head()
+
+	inner, err := store.NewStoreWithHead(ctx, ds, genesis, store.WithWriteBatchSize(1))
+	require.NoError(t, err)
+	require.NoError(t, inner.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, inner.Stop(ctx))
+	})
+	s, err := newLazyStore(ds, inner)
+	require.NoError(t, err)
+	headers := suite.GenExtendedHeaders(5)
+	require.NoError(t, s.Append(ctx, headers...))
+
+	// appended headers are trimmed right away, while the head the store was initialized with is not
+	stored, err := ds.Has(ctx, trimmedKey(uint64(headers[0].Height())))
+	require.NoError(t, err)
+	assert.True(t, stored)
+	stored, err = ds.Has(ctx, trimmedKey(uint64(genesis.Height())))
+	require.NoError(t, err)
+	assert.False(t, stored)
+
+	getter := (*lazyGetter)(s)
+	trimmed, err := getter.GetRangeByHeight(ctx, 1, 7)
+	require.NoError(t, err)
+	require.Len(t, trimmed, 6)
+	// headers not trimmed are got whole from the store
+	assert.False(t, trimmed[0].IsTrimmed())
+	assert.Equal(t, genesis.Hash(), trimmed[0].Hash())
+	for i, h := range headers {
+		assert.True(t, trimmed[i+1].IsTrimmed())
+		assert.Equal(t, h.Hash(), trimmed[i+1].Hash())
+		assert.True(t, h.DAH.Equals(trimmed[i+1].DAH))
+		// trimmed headers are cached
+		cached, err := getter.GetByHeight(ctx, uint64(h.Height()))
+		require.NoError(t, err)
+		assert.Same(t, trimmed[i+1], cached)
+
+		// the store still gets whole headers, so that they can be verified
+		full, err := s.GetByHeight(ctx, uint64(h.Height()))
+		require.NoError(t, err)
+		assert.False(t, full.IsTrimmed())
+		assert.NoError(t, full.Validate())
+	}
+
+	// invalid headers are not trimmed
+	invalid := suite.GenExtendedHeaders(1)[0]
+	invalid.RawHeader.LastBlockID.Hash = nil
+	require.Error(t, s.Append(ctx, invalid))
+	stored, err = ds.Has(ctx, trimmedKey(uint64(invalid.Height())))
+	require.NoError(t, err)
+	assert.False(t, stored)
+}
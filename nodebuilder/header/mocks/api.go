@@ -37,6 +37,21 @@ func (m *MockModule) EXPECT() *MockModuleMockRecorder {
 	return m.recorder
 }
 
+// Equivocations mocks base method.
+func (m *MockModule) Equivocations(arg0 context.Context) ([]*header.Equivocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Equivocations", arg0)
+	ret0, _ := ret[0].([]*header.Equivocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Equivocations indicates an expected call of Equivocations.
+func (mr *MockModuleMockRecorder) Equivocations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Equivocations", reflect.TypeOf((*MockModule)(nil).Equivocations), arg0)
+}
+
 // GetByHash mocks base method.
 func (m *MockModule) GetByHash(arg0 context.Context, arg1 header0.Hash) (*header.ExtendedHeader, error) {
 	m.ctrl.T.Helper()
@@ -67,6 +82,36 @@ func (mr *MockModuleMockRecorder) GetByHeight(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHeight", reflect.TypeOf((*MockModule)(nil).GetByHeight), arg0, arg1)
 }
 
+// GetLazyByHeight mocks base method.
+func (m *MockModule) GetLazyByHeight(arg0 context.Context, arg1 uint64, arg2 bool) (*header.ExtendedHeader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLazyByHeight", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*header.ExtendedHeader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLazyByHeight indicates an expected call of GetLazyByHeight.
+func (mr *MockModuleMockRecorder) GetLazyByHeight(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLazyByHeight", reflect.TypeOf((*MockModule)(nil).GetLazyByHeight), arg0, arg1, arg2)
+}
+
+// GetRangeByHeight mocks base method.
+func (m *MockModule) GetRangeByHeight(arg0 context.Context, arg1, arg2 uint64) ([]*header.ExtendedHeader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRangeByHeight", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*header.ExtendedHeader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRangeByHeight indicates an expected call of GetRangeByHeight.
+func (mr *MockModuleMockRecorder) GetRangeByHeight(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRangeByHeight", reflect.TypeOf((*MockModule)(nil).GetRangeByHeight), arg0, arg1, arg2)
+}
+
 // GetVerifiedRangeByHeight mocks base method.
 func (m *MockModule) GetVerifiedRangeByHeight(arg0 context.Context, arg1 *header.ExtendedHeader, arg2 uint64) ([]*header.ExtendedHeader, error) {
 	m.ctrl.T.Helper()
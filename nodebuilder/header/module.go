@@ -6,7 +6,6 @@ import (
 	"github.com/ipfs/go-datastore"
 	logging "github.com/ipfs/go-log/v2"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
-	"github.com/libp2p/go-libp2p/core/host"
 	"go.uber.org/fx"
 
 	libhead "github.com/celestiaorg/go-header"
@@ -30,20 +29,29 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 		fx.Supply(*cfg),
 		fx.Error(cfgErr),
 		fx.Provide(newHeaderService),
+		storeComponents(tp, cfg),
+		fx.Provide(newLazyStore),
+		fx.Provide(func(store *lazyStore) LazyGetter {
+			return (*lazyGetter)(store)
+		}),
+		fx.Provide(newInitStore),
 		fx.Provide(fx.Annotate(
-			func(ds datastore.Batching) (libhead.Store[*header.ExtendedHeader], error) {
-				return store.NewStore[*header.ExtendedHeader](ds, store.WithParams(cfg.Store))
-			},
-			fx.OnStart(func(ctx context.Context, store libhead.Store[*header.ExtendedHeader]) error {
-				return store.Start(ctx)
-			}),
-			fx.OnStop(func(ctx context.Context, store libhead.Store[*header.ExtendedHeader]) error {
-				return store.Stop(ctx)
+			newEquivocationDetector,
+			fx.OnStop(func(ctx context.Context, detector *equivocationDetector) error {
+				return detector.Stop(ctx)
 			}),
 		)),
-		fx.Provide(newInitStore),
-		fx.Provide(func(subscriber *p2p.Subscriber[*header.ExtendedHeader]) libhead.Subscriber[*header.ExtendedHeader] {
-			return subscriber
+		fx.Provide(func(
+			subscriber *p2p.Subscriber[*header.ExtendedHeader],
+			detector *equivocationDetector,
+		) libhead.Subscriber[*header.ExtendedHeader] {
+			return detector.subscriber(subscriber)
+		}),
+		fx.Decorate(func(
+			ex libhead.Exchange[*header.ExtendedHeader],
+			detector *equivocationDetector,
+		) libhead.Exchange[*header.ExtendedHeader] {
+			return detector.exchange(ex)
 		}),
 		fx.Provide(fx.Annotate(
 			newSyncer,
@@ -60,6 +68,7 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 				return breaker.Stop(ctx)
 			}),
 		)),
+		fx.Decorate(withSyncerProbe),
 		fx.Provide(fx.Annotate(
 			func(ps *pubsub.PubSub, network modp2p.Network) *p2p.Subscriber[*header.ExtendedHeader] {
 				return p2p.NewSubscriber[*header.ExtendedHeader](ps, header.MsgID, network.String())
@@ -73,7 +82,7 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 		)),
 		fx.Provide(fx.Annotate(
 			func(
-				host host.Host,
+				host modp2p.ServingHost,
 				store libhead.Store[*header.ExtendedHeader],
 				network modp2p.Network,
 			) (*p2p.ExchangeServer[*header.ExtendedHeader], error) {
@@ -111,3 +120,61 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 		panic("invalid node type")
 	}
 }
+
+// storeComponents provides the header store, which prunes old headers on light nodes if enabled.
+func storeComponents(tp node.Type, cfg *Config) fx.Option {
+	newStore := func(ds datastore.Batching) (libhead.Store[*header.ExtendedHeader], error) {
+		return store.NewStore[*header.ExtendedHeader](ds, store.WithParams(cfg.Store))
+	}
+	if tp != node.Light || !cfg.Pruning.Enabled {
+		return fx.Provide(fx.Annotate(
+			newStore,
+			fx.OnStart(func(ctx context.Context, store libhead.Store[*header.ExtendedHeader]) error {
+				return store.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, store libhead.Store[*header.ExtendedHeader]) error {
+				return store.Stop(ctx)
+			}),
+		))
+	}
+
+	return fx.Options(
+		fx.Provide(fx.Annotate(
+			func(ds datastore.Batching) (*prunedStore, error) {
+				store, err := newStore(ds)
+				if err != nil {
+					return nil, err
+				}
+				return newPrunedStore(ds, store), nil
+			},
+			fx.OnStart(func(ctx context.Context, store *prunedStore) error {
+				return store.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, store *prunedStore) error {
+				return store.Stop(ctx)
+			}),
+		)),
+		fx.Provide(func(store *prunedStore) libhead.Store[*header.ExtendedHeader] {
+			return store
+		}),
+		fx.Invoke(func(*pruner) {}),
+		fx.Provide(fx.Annotate(
+			func(store *prunedStore, params prunerParams) *pruner {
+				return newPruner(cfg.Pruning, store, params.Guard)
+			},
+			fx.OnStart(func(ctx context.Context, p *pruner) error {
+				return p.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, p *pruner) error {
+				return p.Stop(ctx)
+			}),
+		)),
+	)
+}
+
+type prunerParams struct {
+	fx.In
+
+	// Guard keeps headers not sampled by the DASer yet
+	Guard PruneGuard `optional:"true"`
+}
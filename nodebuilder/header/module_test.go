@@ -8,6 +8,7 @@ import (
 	"github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/p2p/net/conngater"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/fx"
@@ -97,6 +98,9 @@ func TestConstructModule_ExchangeParams(t *testing.T) {
 		fx.Supply(modp2p.Private),
 		fx.Supply(modp2p.Bootstrappers{}),
 		fx.Provide(libp2p.New),
+		fx.Provide(func(h host.Host) modp2p.ServingHost {
+			return h
+		}),
 		fx.Provide(func() datastore.Batching {
 			return datastore.NewMapDatastore()
 		}),
@@ -106,7 +110,7 @@ func TestConstructModule_ExchangeParams(t *testing.T) {
 		}),
 		fx.Invoke(
 			func(e libhead.Exchange[*header.ExtendedHeader], server *p2p.ExchangeServer[*header.ExtendedHeader]) {
-				ex := e.(*p2p.Exchange[*header.ExtendedHeader])
+				ex := unwrapExchange(e).(*p2p.Exchange[*header.ExtendedHeader])
 				exchange = ex
 				exchangeServer = server
 			}),
@@ -14,7 +14,7 @@ func WithMetrics(
 	ex libhead.Exchange[*header.ExtendedHeader],
 	sync *sync.Syncer[*header.ExtendedHeader],
 ) error {
-	if p2pex, ok := ex.(*p2p.Exchange[*header.ExtendedHeader]); ok {
+	if p2pex, ok := unwrapExchange(ex).(*p2p.Exchange[*header.ExtendedHeader]); ok {
 		if err := p2pex.InitMetrics(); err != nil {
 			return err
 		}
@@ -26,3 +26,19 @@ func WithMetrics(
 
 	return libhead.WithMetrics[*header.ExtendedHeader](store)
 }
+
+// wrappedExchange is implemented by the Exchanges wrapping another one.
+type wrappedExchange interface {
+	unwrap() libhead.Exchange[*header.ExtendedHeader]
+}
+
+// unwrapExchange returns the innermost Exchange wrapped by the given one.
+func unwrapExchange(ex libhead.Exchange[*header.ExtendedHeader]) libhead.Exchange[*header.ExtendedHeader] {
+	for {
+		wrapped, ok := ex.(wrappedExchange)
+		if !ok {
+			return ex
+		}
+		ex = wrapped.unwrap()
+	}
+}
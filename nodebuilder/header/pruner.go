@@ -0,0 +1,341 @@
+package header
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// ErrPruned is returned for requests of headers pruned from the store.
+var ErrPruned = errors.New("header: pruned")
+
+var (
+	// prunedHeightKey holds the height headers are pruned up to.
+	prunedHeightKey = datastore.NewKey("header_pruner/pruned")
+	// storePrefix is the prefix of keys of the go-header store. Headers are kept under their hashes
+	// and indexed by their heights within it.
+	storePrefix = datastore.NewKey("headers")
+)
+
+const (
+	// pruneBatchSize is the amount of headers removed at once.
+	pruneBatchSize = 1000
+	// pruneTimeout bounds a single pruning round.
+	pruneTimeout = time.Minute
+)
+
+// PruneGuard keeps headers still referenced by other components from being pruned.
+type PruneGuard interface {
+	// PrunableHeight returns the height headers up to which are not referenced anymore.
+	PrunableHeight(context.Context) (uint64, error)
+}
+
+// prunedStore wraps the header store, so that pruned headers are reported with ErrPruned instead
+// of not being found. The caches of the store can't be evicted from outside of it, so pruned headers
+// cached before being pruned are guarded against until they age out of the caches.
+type prunedStore struct {
+	libhead.Store[*header.ExtendedHeader]
+
+	ds datastore.Batching
+	// pruned is the height headers are pruned up to
+	pruned atomic.Uint64
+}
+
+func newPrunedStore(ds datastore.Batching, store libhead.Store[*header.ExtendedHeader]) *prunedStore {
+	return &prunedStore{
+		Store: store,
+		ds:    ds,
+	}
+}
+
+func (s *prunedStore) Start(ctx context.Context) error {
+	b, err := s.ds.Get(ctx, prunedHeightKey)
+	switch {
+	case errors.Is(err, datastore.ErrNotFound):
+	case err != nil:
+		return fmt.Errorf("header/pruner: loading pruned height: %w", err)
+	default:
+		s.pruned.Store(binary.BigEndian.Uint64(b))
+	}
+	return s.Store.Start(ctx)
+}
+
+// Get guards headers by their hashes, as the store keeps recently read headers cached, so that
+// pruned ones may still be found.
+func (s *prunedStore) Get(ctx context.Context, hash libhead.Hash) (*header.ExtendedHeader, error) {
+	h, err := s.Store.Get(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkPruned(uint64(h.Height())); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (s *prunedStore) Has(ctx context.Context, hash libhead.Hash) (bool, error) {
+	_, err := s.Get(ctx, hash)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, libhead.ErrNotFound), errors.Is(err, ErrPruned):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (s *prunedStore) GetByHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	if err := s.checkPruned(height); err != nil {
+		return nil, err
+	}
+	return s.Store.GetByHeight(ctx, height)
+}
+
+func (s *prunedStore) GetRangeByHeight(ctx context.Context, from, to uint64) ([]*header.ExtendedHeader, error) {
+	if err := s.checkPruned(from); err != nil {
+		return nil, err
+	}
+	return s.Store.GetRangeByHeight(ctx, from, to)
+}
+
+func (s *prunedStore) GetVerifiedRange(
+	ctx context.Context,
+	from *header.ExtendedHeader,
+	to uint64,
+) ([]*header.ExtendedHeader, error) {
+	if err := s.checkPruned(uint64(from.Height()) + 1); err != nil {
+		return nil, err
+	}
+	return s.Store.GetVerifiedRange(ctx, from, to)
+}
+
+func (s *prunedStore) HasAt(ctx context.Context, height uint64) bool {
+	return s.checkPruned(height) == nil && s.Store.HasAt(ctx, height)
+}
+
+func (s *prunedStore) checkPruned(height uint64) error {
+	if pruned := s.pruned.Load(); height <= pruned {
+		return fmt.Errorf("%w: height %d, pruned up to %d", ErrPruned, height, pruned)
+	}
+	return nil
+}
+
+// pruner periodically removes headers older than the retention period from the store. Headers not
+// yet released by the PruneGuard and the head of the store are never removed.
+type pruner struct {
+	store *prunedStore
+	guard PruneGuard
+
+	retention time.Duration
+	interval  time.Duration
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newPruner(cfg PruningConfig, store *prunedStore, guard PruneGuard) *pruner {
+	return &pruner{
+		store:     store,
+		guard:     guard,
+		retention: cfg.Retention,
+		interval:  cfg.Interval,
+		done:      make(chan struct{}),
+	}
+}
+
+func (p *pruner) Start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	go p.run(ctx)
+	return nil
+}
+
+func (p *pruner) Stop(ctx context.Context) error {
+	p.cancel()
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *pruner) run(ctx context.Context) {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			pruneCtx, cancel := context.WithTimeout(ctx, pruneTimeout)
+			err := p.prune(pruneCtx)
+			cancel()
+			if err != nil && ctx.Err() == nil {
+				log.Errorw("pruning headers", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// prune removes headers older than the retention period, lowest first, up to the height released
+// by the guard.
+func (p *pruner) prune(ctx context.Context) error {
+	head, err := p.store.Head(ctx)
+	if err != nil {
+		return err
+	}
+	// the head is kept, as the store and the syncer rely on it
+	limit := uint64(head.Height()) - 1
+	if p.guard != nil {
+		prunable, err := p.guard.PrunableHeight(ctx)
+		if err != nil {
+			return fmt.Errorf("getting prunable height: %w", err)
+		}
+		if prunable < limit {
+			limit = prunable
+		}
+	}
+
+	cutoff := time.Now().Add(-p.retention)
+	from := p.store.pruned.Load() + 1
+	if from == 1 {
+		// nothing was pruned yet, so start from the lowest stored header, which is not the genesis
+		// one for stores initialized from a trusted hash
+		from, err = p.tail(ctx, uint64(head.Height()))
+		if err != nil {
+			return err
+		}
+	}
+	for from <= limit {
+		to := from + pruneBatchSize - 1
+		if to > limit {
+			to = limit
+		}
+
+		pruned, err := p.pruneRange(ctx, from, to, cutoff)
+		if err != nil {
+			return err
+		}
+		if pruned != to {
+			break
+		}
+		from = to + 1
+	}
+	return nil
+}
+
+// tail returns the height of the lowest stored header. As headers are stored contiguously up to
+// the given head, it is searched for by bisection.
+func (p *pruner) tail(ctx context.Context, head uint64) (uint64, error) {
+	var searchErr error
+	tail := sort.Search(int(head), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		stored, err := p.store.ds.Has(ctx, storePrefix.ChildString(strconv.FormatUint(uint64(i)+1, 10)))
+		if err != nil {
+			searchErr = err
+		}
+		return stored
+	})
+	if searchErr != nil {
+		return 0, fmt.Errorf("searching for the lowest stored header: %w", searchErr)
+	}
+	return uint64(tail) + 1, nil
+}
+
+// pruneRange removes headers in the given range older than the cutoff and returns the height
+// headers are pruned up to afterwards.
+func (p *pruner) pruneRange(ctx context.Context, from, to uint64, cutoff time.Time) (uint64, error) {
+	batch, err := p.store.ds.Batch(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	pruned := from - 1
+	for height := from; height <= to; height++ {
+		heightKey := storePrefix.ChildString(strconv.FormatUint(height, 10))
+		// headers pending to be written by the store are not removed, as they would be written
+		// afterwards
+		flushed, err := p.store.ds.Has(ctx, heightKey)
+		if err != nil {
+			return 0, err
+		}
+		if !flushed {
+			break
+		}
+
+		h, err := p.readHeader(ctx, heightKey)
+		if err != nil {
+			return 0, fmt.Errorf("getting header at height %d: %w", height, err)
+		}
+		if h.Time().After(cutoff) {
+			break
+		}
+
+		err = batch.Delete(ctx, storePrefix.ChildString(h.Hash().String()))
+		if err != nil {
+			return 0, err
+		}
+		err = batch.Delete(ctx, heightKey)
+		if err != nil {
+			return 0, err
+		}
+		err = batch.Delete(ctx, trimmedKey(height))
+		if err != nil {
+			return 0, err
+		}
+		pruned = height
+	}
+	if pruned < from {
+		return pruned, nil
+	}
+
+	// the pruned height is committed with the removals, so that it never lags behind them
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, pruned)
+	if err = batch.Put(ctx, prunedHeightKey, b); err != nil {
+		return 0, err
+	}
+	// the pruned height is advanced before the removals, so that readers never miss removed headers
+	p.store.pruned.Store(pruned)
+	if err = batch.Commit(ctx); err != nil {
+		return 0, err
+	}
+	log.Debugw("pruned headers", "from", from, "to", pruned)
+	return pruned, nil
+}
+
+// readHeader reads the header indexed under the given height key from the datastore. Headers are
+// not read through the store, so that the headers about to be pruned don't take the place of the
+// ones in its caches.
+func (p *pruner) readHeader(ctx context.Context, heightKey datastore.Key) (*header.ExtendedHeader, error) {
+	hash, err := p.store.ds.Get(ctx, heightKey)
+	if err != nil {
+		return nil, err
+	}
+	b, err := p.store.ds.Get(ctx, storePrefix.ChildString(libhead.Hash(hash).String()))
+	if err != nil {
+		return nil, err
+	}
+	h := new(header.ExtendedHeader)
+	if err = h.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
@@ -0,0 +1,106 @@
+package header
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/go-header/store"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+)
+
+func TestPruner(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	suite := headertest.NewTestSuite(t, 3)
+	genesis := suite.Head()
+	// headers up to the height of 6 are older than the retention period
+	old := suite.GenExtendedHeaders(5)
+	retained := time.Now()
+	time.Sleep(time.Millisecond * 100)
+	recent := suite.GenExtendedHeaders(5)
+
+	pstore := newTestPrunedStore(ctx, t, ds, genesis)
+	require.NoError(t, pstore.Append(ctx, old...))
+	require.NoError(t, pstore.Append(ctx, recent...))
+	require.Eventually(t, func() bool {
+		flushed, err := ds.Has(ctx, storePrefix.ChildString("11"))
+		return err == nil && flushed
+	}, time.Second, time.Millisecond*10)
+
+	// the header is cached by the store before being pruned
+	_, err := pstore.Get(ctx, old[0].Hash())
+	require.NoError(t, err)
+
+	guard := &fixedGuard{height: 4}
+	p := newPruner(PruningConfig{Retention: time.Since(retained)}, pstore, guard)
+
+	// headers not released by the guard are kept
+	require.NoError(t, p.prune(ctx))
+	_, err = pstore.GetByHeight(ctx, 4)
+	require.ErrorIs(t, err, ErrPruned)
+	// pruned headers are not served by their hashes either
+	_, err = pstore.Get(ctx, old[0].Hash())
+	require.ErrorIs(t, err, ErrPruned)
+	has, err := pstore.Has(ctx, old[0].Hash())
+	require.NoError(t, err)
+	assert.False(t, has)
+	_, err = pstore.GetByHeight(ctx, 5)
+	require.NoError(t, err)
+
+	// recent headers are kept
+	guard.height = 10
+	require.NoError(t, p.prune(ctx))
+	_, err = pstore.GetRangeByHeight(ctx, 6, 8)
+	require.ErrorIs(t, err, ErrPruned)
+	assert.False(t, pstore.HasAt(ctx, 6))
+	assert.True(t, pstore.HasAt(ctx, 7))
+	h, err := pstore.GetByHeight(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, recent[0].Hash(), h.Hash())
+
+	stored, err := ds.Has(ctx, storePrefix.ChildString(old[4].Hash().String()))
+	require.NoError(t, err)
+	assert.False(t, stored)
+
+	// the pruned height survives restarts
+	require.NoError(t, pstore.Stop(ctx))
+	inner, err := store.NewStore[*header.ExtendedHeader](ds)
+	require.NoError(t, err)
+	pstore = newPrunedStore(ds, inner)
+	require.NoError(t, pstore.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, pstore.Stop(ctx))
+	})
+	assert.EqualValues(t, 6, pstore.pruned.Load())
+}
+
+func newTestPrunedStore(
+	ctx context.Context,
+	t *testing.T,
+	ds datastore.Batching,
+	head *header.ExtendedHeader,
+) *prunedStore {
+	inner, err := store.NewStoreWithHead(ctx, ds, head, store.WithWriteBatchSize(1))
+	require.NoError(t, err)
+	pstore := newPrunedStore(ds, inner)
+	require.NoError(t, pstore.Start(ctx))
+	return pstore
+}
+
+type fixedGuard struct {
+	height uint64
+}
+
+func (g *fixedGuard) PrunableHeight(context.Context) (uint64, error) {
+	return g.height, nil
+}
@@ -0,0 +1,228 @@
+package header
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	libhead "github.com/celestiaorg/go-header"
+	p2p_pb "github.com/celestiaorg/go-header/p2p/pb"
+	"github.com/celestiaorg/go-libp2p-messenger/serde"
+
+	"github.com/celestiaorg/celestia-node/header"
+	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+// ErrNoQuorum is returned when not enough trusted peers agree on the head of the network.
+var ErrNoQuorum = errors.New("header: no quorum of trusted peers on the head")
+
+// peerGetter requests headers from a single trusted peer.
+type peerGetter interface {
+	Head(context.Context) (*header.ExtendedHeader, error)
+	GetByHeight(context.Context, uint64) (*header.ExtendedHeader, error)
+}
+
+// trustedPeer requests headers from a single trusted peer over the header exchange protocol. The
+// Exchange only requests its trusted peers together, so the quorumExchange asks each of them
+// through a trustedPeer, sharing the host and the protocol with the Exchange.
+type trustedPeer struct {
+	host    host.Host
+	id      peer.ID
+	network modp2p.Network
+}
+
+func (p *trustedPeer) Head(ctx context.Context) (*header.ExtendedHeader, error) {
+	// the origin of 0 requests the head of the peer
+	return p.request(ctx, 0)
+}
+
+func (p *trustedPeer) GetByHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	if height == 0 {
+		return nil, errors.New("header: height must be greater than 0")
+	}
+	return p.request(ctx, height)
+}
+
+func (p *trustedPeer) request(ctx context.Context, origin uint64) (*header.ExtendedHeader, error) {
+	stream, err := p.host.NewStream(ctx, p.id, headerExchangeProtocol(p.network))
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close() //nolint:errcheck
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = stream.SetDeadline(deadline); err != nil {
+			log.Debugw("setting deadline on header request", "peer", p.id, "err", err)
+		}
+	}
+
+	req := &p2p_pb.HeaderRequest{
+		Data:   &p2p_pb.HeaderRequest_Origin{Origin: origin},
+		Amount: 1,
+	}
+	if _, err = serde.Write(stream, req); err != nil {
+		stream.Reset() //nolint:errcheck
+		return nil, err
+	}
+	if err = stream.CloseWrite(); err != nil {
+		stream.Reset() //nolint:errcheck
+		return nil, err
+	}
+
+	var resp p2p_pb.HeaderResponse
+	if _, err = serde.Read(stream, &resp); err != nil {
+		stream.Reset() //nolint:errcheck
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case p2p_pb.StatusCode_OK:
+	case p2p_pb.StatusCode_NOT_FOUND:
+		return nil, libhead.ErrNotFound
+	default:
+		return nil, fmt.Errorf("header: unknown status code %d", resp.StatusCode)
+	}
+
+	h, err := header.UnmarshalExtendedHeader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(h.ChainID(), p.network.String()) {
+		return nil, fmt.Errorf("header: header of chain %s from trusted peer of chain %s", h.ChainID(), p.network)
+	}
+	return h, nil
+}
+
+// headerExchangeProtocol returns the protocol of the header exchange of the given network, as
+// served by go-header.
+func headerExchangeProtocol(network modp2p.Network) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/%s/header-ex/v0.0.3", network))
+}
+
+// quorumExchange wraps the Exchange, so that the head of the network is only accepted once a quorum
+// of trusted peers agree on it. The Exchange otherwise takes the highest head reported by any
+// trusted peer, which lets a single compromised peer eclipse a fresh node, as the syncer trusts the
+// head of the network without verification when it has no recent subjective head.
+type quorumExchange struct {
+	libhead.Exchange[*header.ExtendedHeader]
+
+	peers  map[peer.ID]peerGetter
+	quorum int
+}
+
+func (ex *quorumExchange) unwrap() libhead.Exchange[*header.ExtendedHeader] {
+	return ex.Exchange
+}
+
+// newQuorumExchange wraps the given Exchange, so that the head of the network is only accepted once
+// the quorum of the given trusted peers agree on it.
+func newQuorumExchange(
+	ex libhead.Exchange[*header.ExtendedHeader],
+	host host.Host,
+	network modp2p.Network,
+	ids []peer.ID,
+	quorum int,
+) *quorumExchange {
+	peers := make(map[peer.ID]peerGetter, len(ids))
+	for _, id := range ids {
+		peers[id] = &trustedPeer{host: host, id: id, network: network}
+	}
+	return &quorumExchange{Exchange: ex, peers: peers, quorum: quorum}
+}
+
+// Head requests heads from all the trusted peers and returns the header at the highest height
+// reached by a quorum of them, as long as a quorum of them agree on it.
+func (ex *quorumExchange) Head(ctx context.Context) (*header.ExtendedHeader, error) {
+	headsCtx := ctx
+	if deadline, ok := ctx.Deadline(); ok {
+		// peers ahead are asked for the header at the agreed height afterwards, so give them half
+		// of the caller's deadline
+		var cancel context.CancelFunc
+		headsCtx, cancel = context.WithDeadline(ctx, time.Now().Add(time.Until(deadline)/2))
+		defer cancel()
+	}
+
+	heads := collect(headsCtx, ex.peers, peerGetter.Head)
+	if len(heads) < ex.quorum {
+		return nil, fmt.Errorf("%w: %d of %d trusted peers responded, %d required",
+			ErrNoQuorum, len(heads), len(ex.peers), ex.quorum)
+	}
+
+	// the highest height reached by a quorum of the peers
+	heights := make([]uint64, 0, len(heads))
+	for _, h := range heads {
+		heights = append(heights, uint64(h.Height()))
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+	height := heights[ex.quorum-1]
+
+	// peers with heads behind the height can't vote, and peers ahead of it are asked for the header
+	// at the height
+	votes := make(map[peer.ID]*header.ExtendedHeader, len(heads))
+	ahead := make(map[peer.ID]peerGetter)
+	for id, h := range heads {
+		switch {
+		case uint64(h.Height()) == height:
+			votes[id] = h
+		case uint64(h.Height()) > height:
+			ahead[id] = ex.peers[id]
+		}
+	}
+	atHeight := collect(ctx, ahead, func(getter peerGetter, ctx context.Context) (*header.ExtendedHeader, error) {
+		return getter.GetByHeight(ctx, height)
+	})
+	for id, h := range atHeight {
+		if uint64(h.Height()) == height {
+			votes[id] = h
+		}
+	}
+
+	counts := make(map[string]int, len(votes))
+	for _, h := range votes {
+		hash := h.Hash().String()
+		counts[hash]++
+		if counts[hash] >= ex.quorum {
+			log.Debugw("trusted peers agreed on the head", "height", height, "hash", hash,
+				"votes", counts[hash], "quorum", ex.quorum)
+			return h, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: trusted peers disagree on the header at height %d", ErrNoQuorum, height)
+}
+
+// collect requests headers from the given peers in parallel and returns the successfully received
+// ones.
+func collect(
+	ctx context.Context,
+	peers map[peer.ID]peerGetter,
+	request func(peerGetter, context.Context) (*header.ExtendedHeader, error),
+) map[peer.ID]*header.ExtendedHeader {
+	type response struct {
+		id peer.ID
+		h  *header.ExtendedHeader
+	}
+	respCh := make(chan response, len(peers))
+	for id, getter := range peers {
+		go func(id peer.ID, getter peerGetter) {
+			h, err := request(getter, ctx)
+			if err != nil {
+				log.Debugw("requesting header from trusted peer", "peer", id, "err", err)
+			}
+			respCh <- response{id: id, h: h}
+		}(id, getter)
+	}
+
+	headers := make(map[peer.ID]*header.ExtendedHeader, len(peers))
+	for range peers {
+		resp := <-respCh
+		if resp.h != nil && !resp.h.IsZero() {
+			headers[resp.id] = resp.h
+		}
+	}
+	return headers
+}
@@ -0,0 +1,145 @@
+package header
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/go-header/p2p"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+func TestQuorumExchange_Head(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	honest := headertest.NewTestSuite(t, 3).GenExtendedHeaders(10)
+	forged := headertest.NewTestSuite(t, 3).GenExtendedHeaders(20)
+
+	newExchange := func(quorum int, peers ...*fakePeer) *quorumExchange {
+		ex := &quorumExchange{peers: make(map[peer.ID]peerGetter), quorum: quorum}
+		for i, p := range peers {
+			ex.peers[peer.ID(rune('a'+i))] = p
+		}
+		return ex
+	}
+
+	t.Run("Agreement", func(t *testing.T) {
+		// the peer ahead is asked for the header at the height reached by the quorum
+		ex := newExchange(2,
+			&fakePeer{headers: honest[:8]},
+			&fakePeer{headers: honest[:9]},
+			&fakePeer{headers: honest[:10]},
+		)
+		h, err := ex.Head(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, honest[8].Hash(), h.Hash())
+	})
+
+	t.Run("SingleCompromisedPeer", func(t *testing.T) {
+		ex := newExchange(2,
+			&fakePeer{headers: honest},
+			&fakePeer{headers: honest},
+			&fakePeer{headers: forged},
+		)
+		h, err := ex.Head(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, honest[9].Hash(), h.Hash())
+	})
+
+	t.Run("Disagreement", func(t *testing.T) {
+		ex := newExchange(2,
+			&fakePeer{headers: honest},
+			&fakePeer{headers: forged},
+		)
+		_, err := ex.Head(ctx)
+		require.ErrorIs(t, err, ErrNoQuorum)
+	})
+
+	t.Run("Unresponsive", func(t *testing.T) {
+		ex := newExchange(2,
+			&fakePeer{headers: honest},
+			&fakePeer{},
+		)
+		_, err := ex.Head(ctx)
+		require.ErrorIs(t, err, ErrNoQuorum)
+	})
+}
+
+// fakePeer serves the given contiguous headers.
+type fakePeer struct {
+	headers []*header.ExtendedHeader
+}
+
+func (p *fakePeer) Head(context.Context) (*header.ExtendedHeader, error) {
+	if len(p.headers) == 0 {
+		return nil, libhead.ErrNotFound
+	}
+	return p.headers[len(p.headers)-1], nil
+}
+
+func (p *fakePeer) GetByHeight(_ context.Context, height uint64) (*header.ExtendedHeader, error) {
+	for _, h := range p.headers {
+		if uint64(h.Height()) == height {
+			return h, nil
+		}
+	}
+	return nil, libhead.ErrNotFound
+}
+
+func TestTrustedPeer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	client, server := net.Hosts()[0], net.Hosts()[1]
+
+	store := headertest.NewStore(t)
+	head, err := store.Head(ctx)
+	require.NoError(t, err)
+	network := modp2p.Network(head.ChainID())
+
+	srv, err := p2p.NewExchangeServer[*header.ExtendedHeader](server, store,
+		p2p.WithNetworkID[p2p.ServerParameters](network.String()),
+	)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, srv.Stop(context.Background()))
+	})
+
+	tp := &trustedPeer{host: client, id: server.ID(), network: network}
+	h, err := tp.Head(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, head.Hash(), h.Hash())
+
+	h, err = tp.GetByHeight(ctx, 5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, h.Height())
+
+	_, err = tp.GetByHeight(ctx, uint64(head.Height())+1)
+	require.Error(t, err)
+
+	// headers of other chains are refused
+	tp.network = "other"
+	srv2, err := p2p.NewExchangeServer[*header.ExtendedHeader](server, store,
+		p2p.WithNetworkID[p2p.ServerParameters]("other"),
+	)
+	require.NoError(t, err)
+	require.NoError(t, srv2.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, srv2.Stop(context.Background()))
+	})
+	_, err = tp.Head(ctx)
+	require.Error(t, err)
+}
@@ -21,6 +21,9 @@ type Service struct {
 	sub       libhead.Subscriber[*header.ExtendedHeader]
 	p2pServer *p2p.ExchangeServer[*header.ExtendedHeader]
 	store     libhead.Store[*header.ExtendedHeader]
+	// lazy gets headers without the signatures of their commits and their validator sets
+	lazy     LazyGetter
+	detector *equivocationDetector
 }
 
 // syncer bare minimum Syncer interface for testing
@@ -38,6 +41,8 @@ func newHeaderService(
 	p2pServer *p2p.ExchangeServer[*header.ExtendedHeader],
 	ex libhead.Exchange[*header.ExtendedHeader],
 	store libhead.Store[*header.ExtendedHeader],
+	lazy LazyGetter,
+	detector *equivocationDetector,
 ) Module {
 	return &Service{
 		syncer:    syncer,
@@ -45,6 +50,8 @@ func newHeaderService(
 		p2pServer: p2pServer,
 		ex:        ex,
 		store:     store,
+		lazy:      lazy,
+		detector:  detector,
 	}
 }
 
@@ -61,6 +68,21 @@ func (s *Service) GetVerifiedRangeByHeight(
 }
 
 func (s *Service) GetByHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	return s.getByHeight(ctx, height, s.store)
+}
+
+func (s *Service) GetLazyByHeight(ctx context.Context, height uint64, full bool) (*header.ExtendedHeader, error) {
+	if full || s.lazy == nil {
+		return s.getByHeight(ctx, height, s.store)
+	}
+	return s.getByHeight(ctx, height, s.lazy)
+}
+
+func (s *Service) getByHeight(
+	ctx context.Context,
+	height uint64,
+	getter libhead.Getter[*header.ExtendedHeader],
+) (*header.ExtendedHeader, error) {
 	head, err := s.syncer.Head(ctx)
 	switch {
 	case err != nil:
@@ -85,8 +107,29 @@ func (s *Service) GetByHeight(ctx context.Context, height uint64) (*header.Exten
 		return nil, fmt.Errorf("header: syncing in progress: "+
 			"localHeadHeight: %d, requestedHeight: %d", head.Height(), height)
 	default:
-		return s.store.GetByHeight(ctx, height)
+		return getter.GetByHeight(ctx, height)
+	}
+}
+
+func (s *Service) GetRangeByHeight(ctx context.Context, from, to uint64) ([]*header.ExtendedHeader, error) {
+	switch {
+	case from == 0 || from >= to:
+		return nil, fmt.Errorf("header: invalid range: from %d, to %d", from, to)
+	case to-from > libhead.MaxRangeRequestSize:
+		return nil, fmt.Errorf("header: range of %d headers exceeds the maximum of %d",
+			to-from, libhead.MaxRangeRequestSize)
+	}
+
+	// the store waits for missing headers, so the range has to be available already
+	head, err := s.store.Head(ctx)
+	if err != nil {
+		return nil, err
 	}
+	if uint64(head.Height()) < to-1 {
+		return nil, fmt.Errorf("header: syncing in progress: "+
+			"localHeadHeight: %d, requestedHeight: %d", head.Height(), to-1)
+	}
+	return s.store.GetRangeByHeight(ctx, from, to)
 }
 
 func (s *Service) WaitForHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
@@ -138,3 +181,7 @@ func (s *Service) Subscribe(ctx context.Context) (<-chan *header.ExtendedHeader,
 	}()
 	return headerCh, nil
 }
+
+func (s *Service) Equivocations(ctx context.Context) ([]*header.Equivocation, error) {
+	return s.detector.equivocations(ctx)
+}
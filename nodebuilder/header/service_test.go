@@ -6,11 +6,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	libhead "github.com/celestiaorg/go-header"
 	"github.com/celestiaorg/go-header/sync"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
 )
 
 func TestGetByHeightHandlesError(t *testing.T) {
@@ -39,3 +41,25 @@ func (d *errorSyncer[H]) State() sync.State {
 func (d *errorSyncer[H]) SyncWait(context.Context) error {
 	return fmt.Errorf("dummy error")
 }
+
+func TestGetRangeByHeight(t *testing.T) {
+	ctx := context.Background()
+	serv := Service{
+		store: headertest.NewStore(t),
+	}
+
+	headers, err := serv.GetRangeByHeight(ctx, 2, 6)
+	require.NoError(t, err)
+	require.Len(t, headers, 4)
+	for i, h := range headers {
+		assert.EqualValues(t, 2+i, h.Height())
+	}
+
+	// invalid, oversized and not yet available ranges are rejected
+	_, err = serv.GetRangeByHeight(ctx, 6, 6)
+	require.Error(t, err)
+	_, err = serv.GetRangeByHeight(ctx, 1, 2+libhead.MaxRangeRequestSize)
+	require.Error(t, err)
+	_, err = serv.GetRangeByHeight(ctx, 5, 20)
+	require.Error(t, err)
+}
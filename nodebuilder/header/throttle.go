@@ -0,0 +1,205 @@
+package header
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+const (
+	// minThrottleBatch is the amount of headers adaptive throttling shrinks batches down to.
+	minThrottleBatch = 16
+	// maxThrottleBackoff caps the pause between batches while verification lags behind.
+	maxThrottleBackoff = time.Second * 10
+)
+
+// ThrottleConfig limits how fast headers are synced, so that low-power devices, e.g. phones or
+// Raspberry Pis, aren't overwhelmed while catching up with the network.
+type ThrottleConfig struct {
+	// BatchSize is the maximum amount of headers requested and verified at once. It is 0, or
+	// bounded by the range the syncer requests, by default.
+	BatchSize uint64
+	// RateLimit is the maximum amount of headers synced per second. It is 0, or unlimited, by
+	// default.
+	RateLimit float64
+	// Adaptive shrinks batches and pauses between them, whenever verifying headers takes longer than
+	// retrieving them. Batches and pauses are restored as verification catches up.
+	Adaptive bool
+}
+
+func (cfg *ThrottleConfig) enabled() bool {
+	return cfg.BatchSize != 0 || cfg.RateLimit != 0 || cfg.Adaptive
+}
+
+// Validate performs basic validation of the throttle config.
+func (cfg *ThrottleConfig) Validate() error {
+	if cfg.BatchSize > libhead.MaxRangeRequestSize {
+		return fmt.Errorf("batch size of %d exceeds the maximum of %d", cfg.BatchSize, libhead.MaxRangeRequestSize)
+	}
+	if cfg.RateLimit < 0 {
+		return errors.New("rate limit must not be negative")
+	}
+	return nil
+}
+
+// throttledExchange wraps the Exchange, so that verified ranges requested by the syncer are
+// retrieved in batches, which are limited in size and rate and adapted to the pace of
+// verification.
+type throttledExchange struct {
+	libhead.Exchange[*header.ExtendedHeader]
+
+	maxBatch  uint64
+	rateLimit float64
+	adaptive  bool
+
+	lk        sync.Mutex
+	batchSize uint64
+	backoff   time.Duration
+}
+
+func (ex *throttledExchange) unwrap() libhead.Exchange[*header.ExtendedHeader] {
+	return ex.Exchange
+}
+
+func newThrottledExchange(
+	ex libhead.Exchange[*header.ExtendedHeader],
+	cfg ThrottleConfig,
+) *throttledExchange {
+	maxBatch := cfg.BatchSize
+	if maxBatch == 0 {
+		maxBatch = libhead.MaxRangeRequestSize
+	}
+	return &throttledExchange{
+		Exchange:  ex,
+		maxBatch:  maxBatch,
+		rateLimit: cfg.RateLimit,
+		adaptive:  cfg.Adaptive,
+		batchSize: maxBatch,
+	}
+}
+
+// GetVerifiedRange requests the range in batches. Every batch is retrieved first and verified
+// afterwards, so that the time spent on each is known. Batches failing verification are
+// re-requested through the wrapped Exchange, which penalizes the peers serving invalid headers.
+func (ex *throttledExchange) GetVerifiedRange(
+	ctx context.Context,
+	from *header.ExtendedHeader,
+	amount uint64,
+) ([]*header.ExtendedHeader, error) {
+	headers := make([]*header.ExtendedHeader, 0, amount)
+	for amount > 0 {
+		size, backoff := ex.next()
+		if size > amount {
+			size = amount
+		}
+		if err := sleep(ctx, backoff); err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		batch, err := ex.Exchange.GetRangeByHeight(ctx, uint64(from.Height())+1, size)
+		if err != nil {
+			return nil, err
+		}
+		retrieved := time.Now()
+		if err = verifyRange(from, batch); err != nil {
+			log.Warnw("invalid headers range, re-requesting", "from", from.Height()+1, "amount", size, "err", err)
+			batch, err = ex.Exchange.GetVerifiedRange(ctx, from, size)
+			if err != nil {
+				return nil, err
+			}
+		}
+		ex.adapt(retrieved.Sub(start), time.Since(retrieved))
+
+		if ex.rateLimit > 0 {
+			// the batch takes at least as long as the rate limit allows for its headers
+			limit := time.Duration(float64(len(batch)) / ex.rateLimit * float64(time.Second))
+			if err = sleep(ctx, limit-time.Since(start)); err != nil {
+				return nil, err
+			}
+		}
+
+		headers = append(headers, batch...)
+		from = batch[len(batch)-1]
+		amount -= uint64(len(batch))
+	}
+	return headers, nil
+}
+
+// next returns the size of the next batch and the pause before requesting it.
+func (ex *throttledExchange) next() (uint64, time.Duration) {
+	ex.lk.Lock()
+	defer ex.lk.Unlock()
+	return ex.batchSize, ex.backoff
+}
+
+// adapt shrinks batches and extends pauses between them while verification lags behind retrieval,
+// and gradually restores them otherwise.
+func (ex *throttledExchange) adapt(retrieval, verification time.Duration) {
+	if !ex.adaptive {
+		return
+	}
+
+	ex.lk.Lock()
+	defer ex.lk.Unlock()
+	if verification > retrieval {
+		ex.batchSize /= 2
+		if ex.batchSize < minThrottleBatch {
+			ex.batchSize = minThrottleBatch
+		}
+		lag := verification - retrieval
+		ex.backoff *= 2
+		if ex.backoff < lag {
+			ex.backoff = lag
+		}
+		if ex.backoff > maxThrottleBackoff {
+			ex.backoff = maxThrottleBackoff
+		}
+		log.Debugw("header verification lags behind, throttling",
+			"lag", lag, "batch_size", ex.batchSize, "backoff", ex.backoff)
+	} else {
+		ex.batchSize += minThrottleBatch
+		ex.backoff /= 2
+	}
+	if ex.batchSize > ex.maxBatch {
+		ex.batchSize = ex.maxBatch
+	}
+}
+
+// verifyRange verifies the range of headers is adjacent to the trusted header and valid against it.
+func verifyRange(trusted *header.ExtendedHeader, headers []*header.ExtendedHeader) error {
+	if len(headers) == 0 {
+		return libhead.ErrNotFound
+	}
+	for _, untrusted := range headers {
+		if untrusted.Height() != trusted.Height()+1 {
+			return fmt.Errorf("non-adjacent header: expected height %d, got %d",
+				trusted.Height()+1, untrusted.Height())
+		}
+		if err := trusted.Verify(untrusted); err != nil {
+			return err
+		}
+		trusted = untrusted
+	}
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
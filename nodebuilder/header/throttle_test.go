@@ -0,0 +1,98 @@
+package header
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+)
+
+func TestThrottledExchange(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	suite := headertest.NewTestSuite(t, 3)
+	from := suite.Head()
+	headers := suite.GenExtendedHeaders(150)
+
+	t.Run("Batches", func(t *testing.T) {
+		fake := &fakeExchange{headers: headers}
+		ex := newThrottledExchange(fake, ThrottleConfig{BatchSize: 30})
+
+		got, err := ex.GetVerifiedRange(ctx, from, 100)
+		require.NoError(t, err)
+		assert.Equal(t, headers[:100], got)
+		assert.Equal(t, []uint64{30, 30, 30, 10}, fake.requested)
+	})
+
+	t.Run("RateLimit", func(t *testing.T) {
+		ex := newThrottledExchange(&fakeExchange{headers: headers}, ThrottleConfig{BatchSize: 10, RateLimit: 200})
+
+		start := time.Now()
+		_, err := ex.GetVerifiedRange(ctx, from, 40)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), time.Millisecond*200)
+	})
+
+	t.Run("Adaptive", func(t *testing.T) {
+		// headers are retrieved instantly, so verification always lags behind
+		fake := &fakeExchange{headers: headers}
+		ex := newThrottledExchange(fake, ThrottleConfig{BatchSize: 64, Adaptive: true})
+
+		_, err := ex.GetVerifiedRange(ctx, from, 150)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{64, 32, minThrottleBatch}, fake.requested[:3])
+		assert.Greater(t, ex.backoff, time.Duration(0))
+	})
+
+	t.Run("InvalidRange", func(t *testing.T) {
+		// the range is served by other peers once it fails verification
+		forgedSuite := headertest.NewTestSuite(t, 3)
+		forgedSuite.Head()
+		forged := forgedSuite.GenExtendedHeaders(10)
+		fake := &fakeExchange{headers: forged, verified: headers}
+		ex := newThrottledExchange(fake, ThrottleConfig{BatchSize: 10})
+
+		got, err := ex.GetVerifiedRange(ctx, from, 10)
+		require.NoError(t, err)
+		assert.Equal(t, headers[:10], got)
+	})
+}
+
+// fakeExchange serves the given contiguous headers, and the verified ones for verified ranges.
+type fakeExchange struct {
+	libhead.Exchange[*header.ExtendedHeader]
+
+	headers   []*header.ExtendedHeader
+	verified  []*header.ExtendedHeader
+	requested []uint64
+}
+
+func (ex *fakeExchange) GetRangeByHeight(_ context.Context, from, amount uint64) ([]*header.ExtendedHeader, error) {
+	ex.requested = append(ex.requested, amount)
+	return headersRange(ex.headers, from, amount)
+}
+
+func (ex *fakeExchange) GetVerifiedRange(
+	_ context.Context,
+	from *header.ExtendedHeader,
+	amount uint64,
+) ([]*header.ExtendedHeader, error) {
+	return headersRange(ex.verified, uint64(from.Height())+1, amount)
+}
+
+func headersRange(headers []*header.ExtendedHeader, from, amount uint64) ([]*header.ExtendedHeader, error) {
+	for i, h := range headers {
+		if uint64(h.Height()) == from && i+int(amount) <= len(headers) {
+			return headers[i : i+int(amount)], nil
+		}
+	}
+	return nil, libhead.ErrNotFound
+}
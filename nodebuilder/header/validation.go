@@ -0,0 +1,323 @@
+package header
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+
+	libhead "github.com/celestiaorg/go-header"
+	p2p_pb "github.com/celestiaorg/go-header/p2p/pb"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// responseValidationRetries is the amount of times a request is repeated, if the returned headers
+// don't match it.
+var responseValidationRetries = 2
+
+// errInvalidResponse is returned when the headers returned by the network don't match the request.
+var errInvalidResponse = errors.New("header: response doesn't match request")
+
+// validatingExchange wraps the Exchange, so that returned headers are checked strictly against the
+// request before they are accepted: single headers must have the requested height or hash, and
+// ranges must cover exactly the requested heights and chain by hashes. Mismatching responses, e.g.
+// shifted or partial ranges served by faulty peers, are requested again, instead of failing
+// verification later in the syncer, and the peers serving them are penalized.
+//
+// The Exchange doesn't expose the peers serving the responses, so they are tracked by the host of
+// the Exchange, wrapped by a trackingHost.
+type validatingExchange struct {
+	libhead.Exchange[*header.ExtendedHeader]
+
+	// penalize is called for every peer serving a mismatching response, if set
+	penalize func(peer.ID, error)
+}
+
+// newValidatingExchange wraps the given Exchange, running over a trackingHost, so that the peers
+// serving mismatching responses are blocked. Trusted peers are only reported, as blocking them could
+// cut the node off the network.
+func newValidatingExchange(
+	ex libhead.Exchange[*header.ExtendedHeader],
+	host host.Host,
+	conngater *conngater.BasicConnectionGater,
+	trusted []peer.ID,
+) *validatingExchange {
+	return &validatingExchange{
+		Exchange: ex,
+		penalize: func(id peer.ID, reason error) {
+			for _, trustedID := range trusted {
+				if id == trustedID {
+					log.Warnw("trusted peer served mismatching headers", "peer", id, "reason", reason)
+					return
+				}
+			}
+			if err := conngater.BlockPeer(id); err != nil {
+				log.Errorw("blocking peer", "peer", id, "err", err)
+			}
+			if err := host.Network().ClosePeer(id); err != nil {
+				log.Errorw("closing connection with peer", "peer", id, "err", err)
+			}
+			log.Warnw("blocked peer serving mismatching headers", "peer", id, "reason", reason)
+		},
+	}
+}
+
+func (ex *validatingExchange) unwrap() libhead.Exchange[*header.ExtendedHeader] {
+	return ex.Exchange
+}
+
+func (ex *validatingExchange) Get(ctx context.Context, hash libhead.Hash) (*header.ExtendedHeader, error) {
+	return requestValid(ctx, ex.penalize, func(ctx context.Context) (*header.ExtendedHeader, error) {
+		return ex.Exchange.Get(ctx, hash)
+	}, func(h *header.ExtendedHeader) error {
+		if !bytes.Equal(h.Hash(), hash) {
+			return fmt.Errorf("requested hash %s, got %s", hash, h.Hash())
+		}
+		return nil
+	})
+}
+
+func (ex *validatingExchange) GetByHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	return requestValid(ctx, ex.penalize, func(ctx context.Context) (*header.ExtendedHeader, error) {
+		return ex.Exchange.GetByHeight(ctx, height)
+	}, func(h *header.ExtendedHeader) error {
+		if uint64(h.Height()) != height {
+			return fmt.Errorf("requested height %d, got %d", height, h.Height())
+		}
+		return nil
+	})
+}
+
+func (ex *validatingExchange) GetRangeByHeight(
+	ctx context.Context,
+	from, amount uint64,
+) ([]*header.ExtendedHeader, error) {
+	return requestValid(ctx, ex.penalize, func(ctx context.Context) ([]*header.ExtendedHeader, error) {
+		return ex.Exchange.GetRangeByHeight(ctx, from, amount)
+	}, func(headers []*header.ExtendedHeader) error {
+		return validateRange(nil, from, amount, headers)
+	})
+}
+
+func (ex *validatingExchange) GetVerifiedRange(
+	ctx context.Context,
+	from *header.ExtendedHeader,
+	amount uint64,
+) ([]*header.ExtendedHeader, error) {
+	return requestValid(ctx, ex.penalize, func(ctx context.Context) ([]*header.ExtendedHeader, error) {
+		return ex.Exchange.GetVerifiedRange(ctx, from, amount)
+	}, func(headers []*header.ExtendedHeader) error {
+		return validateRange(from, uint64(from.Height())+1, amount, headers)
+	})
+}
+
+// requestValid performs the request until the response passes validation, or the retries run out.
+// The peers serving mismatching responses are penalized, if penalize is set.
+func requestValid[T any](
+	ctx context.Context,
+	penalize func(peer.ID, error),
+	request func(context.Context) (T, error),
+	validate func(T) error,
+) (T, error) {
+	var zero T
+	for attempt := 0; ; attempt++ {
+		tracker := &servingTracker{}
+		resp, err := request(context.WithValue(ctx, servingTrackerKey{}, tracker))
+		if err != nil {
+			return zero, err
+		}
+		err = validate(resp)
+		if err == nil {
+			return resp, nil
+		}
+		if penalize != nil {
+			for _, id := range tracker.blame(err) {
+				penalize(id, err)
+			}
+		}
+		if attempt == responseValidationRetries || ctx.Err() != nil {
+			return zero, fmt.Errorf("%w: %w", errInvalidResponse, err)
+		}
+		log.Warnw("response doesn't match request, re-requesting", "attempt", attempt, "err", err)
+	}
+}
+
+// rangeMismatchError is returned when a range stops matching the request at the given height.
+type rangeMismatchError struct {
+	height uint64
+	reason string
+}
+
+func (e *rangeMismatchError) Error() string {
+	return e.reason
+}
+
+// validateRange checks that the headers are exactly the requested range, and that they chain by
+// hashes, starting from the given header, if any.
+func validateRange(prev *header.ExtendedHeader, from, amount uint64, headers []*header.ExtendedHeader) error {
+	for i, h := range headers {
+		height := from + uint64(i)
+		if uint64(h.Height()) != height {
+			return &rangeMismatchError{
+				height: height,
+				reason: fmt.Sprintf("expected height %d at position %d of range, got %d", height, i, h.Height()),
+			}
+		}
+		if prev != nil && !bytes.Equal(h.LastHeader(), prev.Hash()) {
+			return &rangeMismatchError{
+				height: height,
+				reason: fmt.Sprintf("header at height %d doesn't chain to the previous one", height),
+			}
+		}
+		prev = h
+	}
+	if uint64(len(headers)) != amount {
+		return &rangeMismatchError{
+			height: from + uint64(len(headers)),
+			reason: fmt.Sprintf("requested %d headers from height %d, got %d", amount, from, len(headers)),
+		}
+	}
+	return nil
+}
+
+// servingTrackerKey is the context key of the servingTracker of a request to the Exchange.
+type servingTrackerKey struct{}
+
+// servingTracker tracks the peers serving the header requests the Exchange makes for a single
+// request, along with the headers they serve.
+type servingTracker struct {
+	lk     sync.Mutex
+	served []*servedRange
+}
+
+// servedRange is the range of headers a peer served for a single header request.
+type servedRange struct {
+	peer peer.ID
+	// from is the height the headers are requested from, which is zero for requests by hash
+	from uint64
+	// amount is the amount of headers served
+	amount uint64
+}
+
+// blame returns the peers accountable for the mismatch of the response. Mismatches of ranges are
+// blamed on the peers serving the height the range stops matching at, while mismatches of single
+// headers are blamed on any peer serving a header, as the Exchange takes the first one served.
+func (t *servingTracker) blame(err error) []peer.ID {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	var mismatch *rangeMismatchError
+	isRange := errors.As(err, &mismatch)
+
+	var ids []peer.ID
+	for _, r := range t.served {
+		if r.amount == 0 {
+			continue
+		}
+		if isRange && (mismatch.height < r.from || mismatch.height >= r.from+r.amount) {
+			continue
+		}
+		ids = append(ids, r.peer)
+	}
+	return ids
+}
+
+// trackingHost wraps the host of the Exchange, so that the peers serving the header requests made
+// for a request with a servingTracker are tracked by it.
+type trackingHost struct {
+	host.Host
+
+	protocol protocol.ID
+}
+
+func (h *trackingHost) NewStream(ctx context.Context, id peer.ID, pids ...protocol.ID) (network.Stream, error) {
+	s, err := h.Host.NewStream(ctx, id, pids...)
+	if err != nil {
+		return nil, err
+	}
+	tracker, ok := ctx.Value(servingTrackerKey{}).(*servingTracker)
+	if !ok || s.Protocol() != h.protocol {
+		return s, nil
+	}
+	served := &servedRange{peer: id}
+	tracker.lk.Lock()
+	tracker.served = append(tracker.served, served)
+	tracker.lk.Unlock()
+	return &trackedStream{Stream: s, tracker: tracker, served: served}, nil
+}
+
+// trackedStream reads the header request written to the stream and counts the headers read from
+// it. Requests and responses are length-prefixed protobufs, as written by the Exchange.
+type trackedStream struct {
+	network.Stream
+
+	tracker *servingTracker
+	served  *servedRange
+
+	// length is the length of the response being read, decoded from its prefix up to the shift
+	length uint64
+	shift  uint
+	// body is the response being read, or nil while its length prefix is read
+	body []byte
+}
+
+func (s *trackedStream) Write(p []byte) (int, error) {
+	// the Exchange writes a request at once
+	length, n := binary.Uvarint(p)
+	if n > 0 && uint64(len(p)-n) >= length {
+		var req p2p_pb.HeaderRequest
+		if err := req.Unmarshal(p[n : n+int(length)]); err == nil {
+			s.tracker.lk.Lock()
+			s.served.from = req.GetOrigin()
+			s.tracker.lk.Unlock()
+		}
+	}
+	return s.Stream.Write(p)
+}
+
+func (s *trackedStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	s.readResponses(p[:n])
+	return n, err
+}
+
+// readResponses decodes the responses read so far, counting the served headers.
+func (s *trackedStream) readResponses(p []byte) {
+	for len(p) > 0 {
+		if s.body == nil {
+			b := p[0]
+			p = p[1:]
+			s.length |= uint64(b&0x7f) << s.shift
+			if b&0x80 != 0 {
+				s.shift += 7
+				continue
+			}
+			s.body, s.shift = []byte{}, 0
+		}
+
+		n := len(p)
+		if left := s.length - uint64(len(s.body)); uint64(n) > left {
+			n = int(left)
+		}
+		s.body, p = append(s.body, p[:n]...), p[n:]
+		if uint64(len(s.body)) < s.length {
+			continue
+		}
+
+		var resp p2p_pb.HeaderResponse
+		if err := resp.Unmarshal(s.body); err == nil && resp.StatusCode == p2p_pb.StatusCode_OK {
+			s.tracker.lk.Lock()
+			s.served.amount++
+			s.tracker.lk.Unlock()
+		}
+		s.body, s.length = nil, 0
+	}
+}
@@ -0,0 +1,185 @@
+package header
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/go-header/p2p"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+func TestValidatingExchange(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	suite := headertest.NewTestSuite(t, 3)
+	from := suite.Head()
+	headers := suite.GenExtendedHeaders(20)
+
+	t.Run("Valid", func(t *testing.T) {
+		fake := &faultyExchange{headers: headers}
+		ex := &validatingExchange{Exchange: fake}
+
+		got, err := ex.GetVerifiedRange(ctx, from, 10)
+		require.NoError(t, err)
+		assert.Equal(t, headers[:10], got)
+		assert.Equal(t, 1, fake.requests)
+	})
+
+	t.Run("Shifted", func(t *testing.T) {
+		fake := &faultyExchange{headers: headers, faulty: 1, shift: 1}
+		ex := &validatingExchange{Exchange: fake}
+
+		got, err := ex.GetRangeByHeight(ctx, uint64(headers[0].Height()), 10)
+		require.NoError(t, err)
+		assert.Equal(t, headers[:10], got)
+		assert.Equal(t, 2, fake.requests)
+	})
+
+	t.Run("Partial", func(t *testing.T) {
+		fake := &faultyExchange{headers: headers, faulty: responseValidationRetries + 1, drop: 1}
+		ex := &validatingExchange{Exchange: fake}
+
+		_, err := ex.GetVerifiedRange(ctx, from, 10)
+		require.ErrorIs(t, err, errInvalidResponse)
+		assert.Equal(t, responseValidationRetries+1, fake.requests)
+	})
+
+	t.Run("Unchained", func(t *testing.T) {
+		// the range has the requested heights, but belongs to another chain
+		forgedSuite := headertest.NewTestSuite(t, 3)
+		forgedSuite.Head()
+		forged := forgedSuite.GenExtendedHeaders(10)
+		ex := &validatingExchange{Exchange: &faultyExchange{headers: forged}}
+
+		_, err := ex.GetVerifiedRange(ctx, from, 10)
+		require.ErrorIs(t, err, errInvalidResponse)
+	})
+}
+
+func TestValidatingExchange_PenalizesServingPeer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	client, server := net.Hosts()[0], net.Hosts()[1]
+
+	store := headertest.NewStore(t)
+	head, err := store.Head(ctx)
+	require.NoError(t, err)
+	network := modp2p.Network(head.ChainID())
+
+	// the server serves every range shifted by one header
+	srv, err := p2p.NewExchangeServer[*header.ExtendedHeader](server, &shiftedStore{Store: store},
+		p2p.WithNetworkID[p2p.ServerParameters](network.String()),
+	)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, srv.Stop(context.Background()))
+	})
+
+	gater, err := conngater.NewBasicConnectionGater(sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	tracking := &trackingHost{Host: client, protocol: headerExchangeProtocol(network)}
+	exchange, err := p2p.NewExchange[*header.ExtendedHeader](tracking, []peer.ID{server.ID()}, gater,
+		p2p.WithNetworkID[p2p.ClientParameters](network.String()),
+		p2p.WithChainID(network.String()),
+	)
+	require.NoError(t, err)
+	require.NoError(t, exchange.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, exchange.Stop(context.Background()))
+	})
+
+	var penalized []peer.ID
+	ex := &validatingExchange{
+		Exchange: exchange,
+		penalize: func(id peer.ID, _ error) {
+			penalized = append(penalized, id)
+		},
+	}
+
+	_, err = ex.GetByHeight(ctx, 2)
+	require.ErrorIs(t, err, errInvalidResponse)
+	assert.Len(t, penalized, responseValidationRetries+1)
+	assert.Subset(t, []peer.ID{server.ID()}, penalized)
+
+	penalized = nil
+	_, err = ex.GetRangeByHeight(ctx, 2, 5)
+	require.ErrorIs(t, err, errInvalidResponse)
+	assert.Len(t, penalized, responseValidationRetries+1)
+	assert.Subset(t, []peer.ID{server.ID()}, penalized)
+}
+
+func TestServingTracker_Blame(t *testing.T) {
+	tracker := &servingTracker{served: []*servedRange{
+		{peer: "full", from: 1, amount: 5},
+		// partially served the range requested
+		{peer: "partial", from: 6, amount: 2},
+		{peer: "remaining", from: 8, amount: 3},
+		{peer: "failed", from: 6, amount: 0},
+	}}
+
+	assert.Equal(t, []peer.ID{"partial"}, tracker.blame(&rangeMismatchError{height: 7}))
+	assert.Equal(t, []peer.ID{"remaining"}, tracker.blame(&rangeMismatchError{height: 8}))
+	assert.Empty(t, tracker.blame(&rangeMismatchError{height: 11}))
+	// mismatching single headers are blamed on any peer serving a header
+	assert.Equal(t, []peer.ID{"full", "partial", "remaining"}, tracker.blame(errors.New("mismatch")))
+}
+
+// shiftedStore serves the headers one height above the requested ones.
+type shiftedStore struct {
+	libhead.Store[*header.ExtendedHeader]
+}
+
+func (s *shiftedStore) GetRangeByHeight(ctx context.Context, from, to uint64) ([]*header.ExtendedHeader, error) {
+	return s.Store.GetRangeByHeight(ctx, from+1, to+1)
+}
+
+// faultyExchange serves the given contiguous headers, shifting or dropping headers of the first
+// faulty responses.
+type faultyExchange struct {
+	libhead.Exchange[*header.ExtendedHeader]
+
+	headers  []*header.ExtendedHeader
+	faulty   int
+	shift    uint64
+	drop     int
+	requests int
+}
+
+func (ex *faultyExchange) GetRangeByHeight(_ context.Context, from, amount uint64) ([]*header.ExtendedHeader, error) {
+	ex.requests++
+	if ex.requests > ex.faulty {
+		return headersRange(ex.headers, from, amount)
+	}
+	headers, err := headersRange(ex.headers, from+ex.shift, amount)
+	if err != nil {
+		return nil, err
+	}
+	return headers[:len(headers)-ex.drop], nil
+}
+
+func (ex *faultyExchange) GetVerifiedRange(
+	ctx context.Context,
+	from *header.ExtendedHeader,
+	amount uint64,
+) ([]*header.ExtendedHeader, error) {
+	return ex.GetRangeByHeight(ctx, uint64(from.Height())+1, amount)
+}
@@ -92,6 +92,15 @@ func Reset(path string, tp node.Type) error {
 	if err != nil {
 		return err
 	}
+	for _, backend := range DatastoreBackends() {
+		if backend == BadgerBackend {
+			continue
+		}
+		err = os.RemoveAll(datastorePath(path, backend))
+		if err != nil {
+			return err
+		}
+	}
 
 	// light nodes don't have dagstore paths
 	if tp == node.Light {
@@ -183,8 +192,11 @@ func initDir(path string) error {
 
 // generateKeys will construct a keyring from the given keystore path and check
 // if account keys already exist. If not, it will generate a new account key and
-// store it.
+// store it. No keys are generated for the read-only state mode.
 func generateKeys(cfg Config, ksPath string) error {
+	if cfg.State.ReadOnly {
+		return nil
+	}
 	encConf := encoding.MakeConfig(app.ModuleEncodingRegisters...)
 
 	if cfg.State.KeyringBackend == keyring.BackendTest {
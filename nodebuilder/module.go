@@ -2,10 +2,12 @@ package nodebuilder
 
 import (
 	"context"
+	"path/filepath"
 
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-node/libs/fxutil"
+	"github.com/celestiaorg/celestia-node/libs/httputil"
 	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
 	"github.com/celestiaorg/celestia-node/nodebuilder/core"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
@@ -23,16 +25,17 @@ func ConstructModule(tp node.Type, network p2p.Network, cfg *Config, store Store
 	log.Infow("Accessing keyring...")
 	ks, err := store.Keystore()
 	if err != nil {
-		fx.Error(err)
+		return fx.Error(err)
 	}
 	signer, err := state.KeyringSigner(cfg.State, ks, network)
 	if err != nil {
-		fx.Error(err)
+		return fx.Error(err)
 	}
 
 	baseComponents := fx.Options(
 		fx.Supply(tp),
 		fx.Supply(network),
+		fx.Supply(node.BuildInfo{}),
 		fx.Provide(p2p.BootstrappersFor),
 		fx.Provide(func(lc fx.Lifecycle) context.Context {
 			return fxutil.WithLifecycle(context.Background(), lc)
@@ -43,6 +46,9 @@ func ConstructModule(tp node.Type, network p2p.Network, cfg *Config, store Store
 		fx.Provide(store.Keystore),
 		fx.Supply(node.StorePath(store.Path())),
 		fx.Supply(signer),
+		fx.Provide(newConfigReloader(cfg, store)),
+		// the servers of the node share a single ACME account and certificate cache
+		fx.Supply(httputil.NewACMEManager(filepath.Join(store.Path(), "acme"), cfg.RPC.TLS, cfg.Gateway.TLS)),
 		// modules provided by the node
 		p2p.ConstructModule(tp, &cfg.P2P),
 		state.ConstructModule(tp, &cfg.State),
@@ -53,8 +59,8 @@ func ConstructModule(tp node.Type, network p2p.Network, cfg *Config, store Store
 		core.ConstructModule(tp, &cfg.Core),
 		das.ConstructModule(tp, &cfg.DASer),
 		fraud.ConstructModule(tp),
-		blob.ConstructModule(),
-		node.ConstructModule(tp),
+		blob.ConstructModule(tp, &cfg.Blob),
+		node.ConstructModule(tp, &cfg.Node),
 	)
 
 	return fx.Module(
@@ -2,25 +2,50 @@ package node
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/cristalhq/jwt"
 	"github.com/filecoin-project/go-jsonrpc/auth"
 	logging "github.com/ipfs/go-log/v2"
 
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/libs/audit"
 	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	"github.com/celestiaorg/celestia-node/libs/watchdog"
 )
 
 const APIVersion = "v0.2.1"
 
 type module struct {
-	tp     Type
-	signer jwt.Signer
+	tp          Type
+	signer      jwt.Signer
+	revocations *authtoken.Revocations
+	reload      ConfigReloader
+	modules     RPCModules
+	audit       AuditLog
+	heartbeats  *watchdog.Registry
 }
 
-func newModule(tp Type, signer jwt.Signer) Module {
+func newModule(
+	tp Type,
+	signer jwt.Signer,
+	revocations *authtoken.Revocations,
+	reload ConfigReloader,
+	modules RPCModules,
+	audit AuditLog,
+	heartbeats *watchdog.Registry,
+) Module {
 	return &module{
-		tp:     tp,
-		signer: signer,
+		tp:          tp,
+		signer:      signer,
+		revocations: revocations,
+		reload:      reload,
+		modules:     modules,
+		audit:       audit,
+		heartbeats:  heartbeats,
 	}
 }
 
@@ -42,10 +67,111 @@ func (m *module) LogLevelSet(_ context.Context, name, level string) error {
 	return logging.SetLogLevel(name, level)
 }
 
+// ConfigReloader applies changes of runtime-safe parameters of the stored config to the running
+// node.
+type ConfigReloader func(context.Context) (*ReloadReport, error)
+
+// ReloadReport describes the outcome of a config reload.
+type ReloadReport struct {
+	// Applied lists the parameters whose changes were applied to the running node.
+	Applied []string `json:"applied"`
+	// Rejected maps parameters or config sections whose changes were not applied onto the reason.
+	Rejected map[string]string `json:"rejected"`
+}
+
+func (m *module) ConfigReload(ctx context.Context) (*ReloadReport, error) {
+	return m.reload(ctx)
+}
+
+// RPCModules toggles modules served by the RPC server of the running node.
+type RPCModules interface {
+	Modules() map[string]bool
+	SetModuleEnabled(module string, enabled bool) error
+}
+
+func (m *module) RPCModules(context.Context) (map[string]bool, error) {
+	return m.modules.Modules(), nil
+}
+
+func (m *module) RPCModuleSet(_ context.Context, module string, enabled bool) error {
+	return m.modules.SetModuleEnabled(module, enabled)
+}
+
+// AuditLog provides the audit log of the RPC server of the running node.
+type AuditLog interface {
+	AuditEntries(amount int) ([]audit.Entry, error)
+}
+
+func (m *module) AuditLog(_ context.Context, amount int) ([]audit.Entry, error) {
+	return m.audit.AuditEntries(amount)
+}
+
+func (m *module) Health(context.Context) (*Health, error) {
+	return newHealth(m.heartbeats.Status()), nil
+}
+
 func (m *module) AuthVerify(_ context.Context, token string) ([]auth.Permission, error) {
-	return authtoken.ExtractSignedPermissions(m.signer, token)
+	payload, err := authtoken.VerifyPayload(m.signer, m.revocations, token)
+	if err != nil {
+		return nil, err
+	}
+	return payload.Allow, nil
 }
 
 func (m *module) AuthNew(_ context.Context, permissions []auth.Permission) (string, error) {
 	return authtoken.NewSignedJWT(m.signer, permissions)
 }
+
+func (m *module) AuthNewScoped(
+	_ context.Context,
+	permissions []auth.Permission,
+	namespaces []namespace.ID,
+) (string, error) {
+	if len(namespaces) == 0 {
+		return "", fmt.Errorf("node: scoped token requires at least one namespace")
+	}
+	return authtoken.NewSignedJWT(m.signer, permissions, namespaces...)
+}
+
+// TokenScope restricts the methods and namespaces a token grants its permissions on.
+type TokenScope struct {
+	// Methods are the modules, e.g. "blob", or methods of modules, e.g. "blob.Submit", the token can
+	// invoke. The token can invoke any method, if empty.
+	Methods []string `json:"methods,omitempty"`
+	// Namespaces are the namespaces the token can read and submit blobs of. The token is not
+	// restricted to any namespace, if empty.
+	Namespaces []namespace.ID `json:"namespaces,omitempty"`
+}
+
+func (m *module) AuthNewWithScope(
+	_ context.Context,
+	permissions []auth.Permission,
+	scope TokenScope,
+) (string, error) {
+	modules := m.modules.Modules()
+	for _, method := range scope.Methods {
+		if err := perms.ValidateMethod(method); err != nil {
+			return "", fmt.Errorf("node: invalid method %s: %w", method, err)
+		}
+		module, _, _ := strings.Cut(method, ".")
+		if _, ok := modules[module]; !ok {
+			return "", fmt.Errorf("node: unknown module %s", module)
+		}
+	}
+	return authtoken.NewSignedPayloadJWT(m.signer, &perms.JWTPayload{
+		Allow:      permissions,
+		Namespaces: scope.Namespaces,
+		Methods:    scope.Methods,
+	})
+}
+
+func (m *module) AuthRevoke(ctx context.Context, token string) error {
+	payload, err := authtoken.ExtractSignedPayload(m.signer, token)
+	if err != nil {
+		return err
+	}
+	if payload.ID == "" {
+		return fmt.Errorf("node: token has no ID and can only be revoked by rotating the JWT secret")
+	}
+	return m.revocations.Revoke(ctx, payload.ID)
+}
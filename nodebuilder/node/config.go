@@ -3,6 +3,8 @@ package node
 import (
 	"fmt"
 	"time"
+
+	logging "github.com/ipfs/go-log/v2"
 )
 
 var defaultLifecycleTimeout = time.Minute * 2
@@ -10,6 +12,13 @@ var defaultLifecycleTimeout = time.Minute * 2
 type Config struct {
 	StartupTimeout  time.Duration
 	ShutdownTimeout time.Duration
+	// LogLevels sets log levels of the given components, e.g. {"share/getters" = "debug"}.
+	// It can be changed without restarting the node.
+	LogLevels map[string]string `toml:",omitempty"`
+	// SelfTest checks on startup whether the environment can sustain the node.
+	SelfTest SelfTestConfig
+	// Watchdog flags long-running loops of the node that got stuck.
+	Watchdog WatchdogConfig
 }
 
 // DefaultConfig returns the default node configuration for a given node type.
@@ -24,6 +33,8 @@ func DefaultConfig(tp Type) Config {
 	return Config{
 		StartupTimeout:  timeout,
 		ShutdownTimeout: timeout,
+		SelfTest:        DefaultSelfTestConfig(tp),
+		Watchdog:        DefaultWatchdogConfig(),
 	}
 }
 
@@ -34,5 +45,23 @@ func (c *Config) Validate() error {
 	if c.ShutdownTimeout == 0 {
 		return fmt.Errorf("invalid shutdown timeout: %v", c.ShutdownTimeout)
 	}
+	for name, level := range c.LogLevels {
+		if _, err := logging.LevelFromString(level); err != nil {
+			return fmt.Errorf("invalid log level of %s: %w", name, err)
+		}
+	}
+	if err := c.Watchdog.Validate(); err != nil {
+		return err
+	}
+	return c.SelfTest.Validate()
+}
+
+// ApplyLogLevels sets the configured log levels.
+func (c *Config) ApplyLogLevels() error {
+	for name, level := range c.LogLevels {
+		if err := logging.SetLogLevel(name, level); err != nil {
+			return fmt.Errorf("setting log level of %s: %w", name, err)
+		}
+	}
 	return nil
 }
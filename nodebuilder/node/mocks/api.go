@@ -8,7 +8,9 @@ import (
 	context "context"
 	reflect "reflect"
 
+	audit "github.com/celestiaorg/celestia-node/libs/audit"
 	node "github.com/celestiaorg/celestia-node/nodebuilder/node"
+	namespace "github.com/celestiaorg/nmt/namespace"
 	auth "github.com/filecoin-project/go-jsonrpc/auth"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -36,6 +38,21 @@ func (m *MockModule) EXPECT() *MockModuleMockRecorder {
 	return m.recorder
 }
 
+// AuditLog mocks base method.
+func (m *MockModule) AuditLog(arg0 context.Context, arg1 int) ([]audit.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditLog", arg0, arg1)
+	ret0, _ := ret[0].([]audit.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuditLog indicates an expected call of AuditLog.
+func (mr *MockModuleMockRecorder) AuditLog(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditLog", reflect.TypeOf((*MockModule)(nil).AuditLog), arg0, arg1)
+}
+
 // AuthNew mocks base method.
 func (m *MockModule) AuthNew(arg0 context.Context, arg1 []auth.Permission) (string, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +68,50 @@ func (mr *MockModuleMockRecorder) AuthNew(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthNew", reflect.TypeOf((*MockModule)(nil).AuthNew), arg0, arg1)
 }
 
+// AuthNewScoped mocks base method.
+func (m *MockModule) AuthNewScoped(arg0 context.Context, arg1 []auth.Permission, arg2 []namespace.ID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthNewScoped", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthNewScoped indicates an expected call of AuthNewScoped.
+func (mr *MockModuleMockRecorder) AuthNewScoped(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthNewScoped", reflect.TypeOf((*MockModule)(nil).AuthNewScoped), arg0, arg1, arg2)
+}
+
+// AuthNewWithScope mocks base method.
+func (m *MockModule) AuthNewWithScope(arg0 context.Context, arg1 []auth.Permission, arg2 node.TokenScope) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthNewWithScope", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthNewWithScope indicates an expected call of AuthNewWithScope.
+func (mr *MockModuleMockRecorder) AuthNewWithScope(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthNewWithScope", reflect.TypeOf((*MockModule)(nil).AuthNewWithScope), arg0, arg1, arg2)
+}
+
+// AuthRevoke mocks base method.
+func (m *MockModule) AuthRevoke(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthRevoke", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthRevoke indicates an expected call of AuthRevoke.
+func (mr *MockModuleMockRecorder) AuthRevoke(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthRevoke", reflect.TypeOf((*MockModule)(nil).AuthRevoke), arg0, arg1)
+}
+
 // AuthVerify mocks base method.
 func (m *MockModule) AuthVerify(arg0 context.Context, arg1 string) ([]auth.Permission, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +127,36 @@ func (mr *MockModuleMockRecorder) AuthVerify(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthVerify", reflect.TypeOf((*MockModule)(nil).AuthVerify), arg0, arg1)
 }
 
+// ConfigReload mocks base method.
+func (m *MockModule) ConfigReload(arg0 context.Context) (*node.ReloadReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigReload", arg0)
+	ret0, _ := ret[0].(*node.ReloadReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfigReload indicates an expected call of ConfigReload.
+func (mr *MockModuleMockRecorder) ConfigReload(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigReload", reflect.TypeOf((*MockModule)(nil).ConfigReload), arg0)
+}
+
+// Health mocks base method.
+func (m *MockModule) Health(arg0 context.Context) (*node.Health, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health", arg0)
+	ret0, _ := ret[0].(*node.Health)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Health indicates an expected call of Health.
+func (mr *MockModuleMockRecorder) Health(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockModule)(nil).Health), arg0)
+}
+
 // Info mocks base method.
 func (m *MockModule) Info(arg0 context.Context) (node.Info, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +185,32 @@ func (mr *MockModuleMockRecorder) LogLevelSet(arg0, arg1, arg2 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogLevelSet", reflect.TypeOf((*MockModule)(nil).LogLevelSet), arg0, arg1, arg2)
 }
+
+// RPCModuleSet mocks base method.
+func (m *MockModule) RPCModuleSet(arg0 context.Context, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RPCModuleSet", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RPCModuleSet indicates an expected call of RPCModuleSet.
+func (mr *MockModuleMockRecorder) RPCModuleSet(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPCModuleSet", reflect.TypeOf((*MockModule)(nil).RPCModuleSet), arg0, arg1, arg2)
+}
+
+// RPCModules mocks base method.
+func (m *MockModule) RPCModules(arg0 context.Context) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RPCModules", arg0)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RPCModules indicates an expected call of RPCModules.
+func (mr *MockModuleMockRecorder) RPCModules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPCModules", reflect.TypeOf((*MockModule)(nil).RPCModules), arg0)
+}
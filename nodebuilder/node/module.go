@@ -1,16 +1,70 @@
 package node
 
 import (
+	"context"
+
 	"github.com/cristalhq/jwt"
+	"github.com/ipfs/go-datastore"
 	"go.uber.org/fx"
+
+	"github.com/celestiaorg/go-header/sync"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	"github.com/celestiaorg/celestia-node/libs/watchdog"
 )
 
-func ConstructModule(tp Type) fx.Option {
+func ConstructModule(tp Type, cfg *Config) fx.Option {
+	selfTestOpt := fx.Options()
+	if cfg.SelfTest.Enabled {
+		selfTestOpt = fx.Invoke(func(
+			lc fx.Lifecycle,
+			path StorePath,
+			ds datastore.Batching,
+			syncer *sync.Syncer[*header.ExtendedHeader],
+		) {
+			st := &selfTest{cfg: cfg.SelfTest, tp: tp, path: path, ds: ds, syncer: syncer}
+			// the hook is appended after the hooks of the dependencies, so the syncer is started
+			lc.Append(fx.Hook{OnStart: st.Run})
+		})
+	}
+
 	return fx.Module(
 		"node",
-		fx.Provide(func(secret jwt.Signer) Module {
-			return newModule(tp, secret)
+		fx.Error(cfg.SelfTest.Validate()),
+		fx.Error(cfg.Watchdog.Validate()),
+		fx.Provide(func(
+			secret jwt.Signer,
+			revocations *authtoken.Revocations,
+			reload ConfigReloader,
+			modules RPCModules,
+			audit AuditLog,
+			heartbeats *watchdog.Registry,
+		) Module {
+			return newModule(tp, secret, revocations, reload, modules, audit, heartbeats)
 		}),
 		fx.Provide(secret),
+		fx.Provide(func(ds datastore.Batching) (*authtoken.Revocations, error) {
+			return authtoken.NewRevocations(context.Background(), ds)
+		}),
+		fx.Invoke(func() error {
+			return cfg.ApplyLogLevels()
+		}),
+		fx.Provide(func() *watchdog.Registry {
+			return newHeartbeats(cfg.Watchdog)
+		}),
+		fx.Provide(fx.Annotate(
+			func(heartbeats *watchdog.Registry) *watchdog.Watchdog {
+				return watchdog.NewWatchdog(heartbeats, cfg.Watchdog.Restart)
+			},
+			fx.OnStart(func(ctx context.Context, w *watchdog.Watchdog) error {
+				return w.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, w *watchdog.Watchdog) error {
+				return w.Stop(ctx)
+			}),
+		)),
+		fx.Invoke(func(*watchdog.Watchdog) {}),
+		selfTestOpt,
 	)
 }
@@ -4,6 +4,10 @@ import (
 	"context"
 
 	"github.com/filecoin-project/go-jsonrpc/auth"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/libs/audit"
 )
 
 // Module defines the API related to interacting with the "administrative"
@@ -17,20 +21,61 @@ type Module interface {
 	// LogLevelSet sets the given component log level to the given level.
 	LogLevelSet(ctx context.Context, name, level string) error
 
+	// ConfigReload applies changes of runtime-safe parameters of the stored config to the running
+	// node and reports which changes were applied and which require a restart.
+	ConfigReload(ctx context.Context) (*ReloadReport, error)
+
+	// RPCModules returns the modules served over RPC mapped onto whether they are enabled.
+	RPCModules(ctx context.Context) (map[string]bool, error)
+	// RPCModuleSet enables or disables the given module served over RPC. Methods of a disabled
+	// module fail until it is enabled again.
+	RPCModuleSet(ctx context.Context, module string, enabled bool) error
+	// AuditLog returns up to the given amount of the latest authenticated write and admin calls
+	// served over RPC, or all of them if the amount is not positive.
+	AuditLog(ctx context.Context, amount int) ([]audit.Entry, error)
+
+	// Health reports the liveness of the long-running loops of the node, flagging the stuck ones.
+	Health(ctx context.Context) (*Health, error)
+
 	// AuthVerify returns the permissions assigned to the given token.
 	AuthVerify(ctx context.Context, token string) ([]auth.Permission, error)
 	// AuthNew signs and returns a new token with the given permissions.
 	AuthNew(ctx context.Context, perms []auth.Permission) (string, error)
+	// AuthNewScoped signs and returns a new token with the given permissions, which only allows
+	// reading and submitting blobs of the given namespaces.
+	AuthNewScoped(ctx context.Context, perms []auth.Permission, namespaces []namespace.ID) (string, error)
+	// AuthNewWithScope signs and returns a new token with the given permissions, which are only
+	// granted on the methods and namespaces of the given scope.
+	AuthNewWithScope(ctx context.Context, perms []auth.Permission, scope TokenScope) (string, error)
+	// AuthRevoke revokes the given token, so that it is rejected from then on. Tokens signed before
+	// revocation was supported can't be revoked.
+	AuthRevoke(ctx context.Context, token string) error
 }
 
 var _ Module = (*API)(nil)
 
 type API struct {
 	Internal struct {
-		Info        func(context.Context) (Info, error)                                `perm:"admin"`
-		LogLevelSet func(ctx context.Context, name, level string) error                `perm:"admin"`
-		AuthVerify  func(ctx context.Context, token string) ([]auth.Permission, error) `perm:"admin"`
-		AuthNew     func(ctx context.Context, perms []auth.Permission) (string, error) `perm:"admin"`
+		Info          func(context.Context) (Info, error)                                `perm:"admin"`
+		LogLevelSet   func(ctx context.Context, name, level string) error                `perm:"admin"`
+		ConfigReload  func(ctx context.Context) (*ReloadReport, error)                   `perm:"admin"`
+		RPCModules    func(ctx context.Context) (map[string]bool, error)                 `perm:"admin"`
+		RPCModuleSet  func(ctx context.Context, module string, enabled bool) error       `perm:"admin"`
+		AuditLog      func(ctx context.Context, amount int) ([]audit.Entry, error)       `perm:"admin"`
+		Health        func(ctx context.Context) (*Health, error)                         `perm:"admin"`
+		AuthVerify    func(ctx context.Context, token string) ([]auth.Permission, error) `perm:"admin"`
+		AuthNew       func(ctx context.Context, perms []auth.Permission) (string, error) `perm:"admin"`
+		AuthNewScoped func(
+			ctx context.Context,
+			perms []auth.Permission,
+			namespaces []namespace.ID,
+		) (string, error) `perm:"admin"`
+		AuthNewWithScope func(
+			ctx context.Context,
+			perms []auth.Permission,
+			scope TokenScope,
+		) (string, error) `perm:"admin"`
+		AuthRevoke func(ctx context.Context, token string) error `perm:"admin"`
 	}
 }
 
@@ -42,6 +87,26 @@ func (api *API) LogLevelSet(ctx context.Context, name, level string) error {
 	return api.Internal.LogLevelSet(ctx, name, level)
 }
 
+func (api *API) ConfigReload(ctx context.Context) (*ReloadReport, error) {
+	return api.Internal.ConfigReload(ctx)
+}
+
+func (api *API) RPCModules(ctx context.Context) (map[string]bool, error) {
+	return api.Internal.RPCModules(ctx)
+}
+
+func (api *API) RPCModuleSet(ctx context.Context, module string, enabled bool) error {
+	return api.Internal.RPCModuleSet(ctx, module, enabled)
+}
+
+func (api *API) AuditLog(ctx context.Context, amount int) ([]audit.Entry, error) {
+	return api.Internal.AuditLog(ctx, amount)
+}
+
+func (api *API) Health(ctx context.Context) (*Health, error) {
+	return api.Internal.Health(ctx)
+}
+
 func (api *API) AuthVerify(ctx context.Context, token string) ([]auth.Permission, error) {
 	return api.Internal.AuthVerify(ctx, token)
 }
@@ -49,3 +114,23 @@ func (api *API) AuthVerify(ctx context.Context, token string) ([]auth.Permission
 func (api *API) AuthNew(ctx context.Context, perms []auth.Permission) (string, error) {
 	return api.Internal.AuthNew(ctx, perms)
 }
+
+func (api *API) AuthNewScoped(
+	ctx context.Context,
+	perms []auth.Permission,
+	namespaces []namespace.ID,
+) (string, error) {
+	return api.Internal.AuthNewScoped(ctx, perms, namespaces)
+}
+
+func (api *API) AuthNewWithScope(
+	ctx context.Context,
+	perms []auth.Permission,
+	scope TokenScope,
+) (string, error) {
+	return api.Internal.AuthNewWithScope(ctx, perms, scope)
+}
+
+func (api *API) AuthRevoke(ctx context.Context, token string) error {
+	return api.Internal.AuthRevoke(ctx, token)
+}
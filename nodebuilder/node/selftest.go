@@ -0,0 +1,205 @@
+package node
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+var log = logging.Logger("module/node")
+
+var (
+	// selfTestWrites is the amount of synced writes the write latency is averaged over.
+	selfTestWrites = 8
+	// selfTestWriteSize is the size of every synced write.
+	selfTestWriteSize = 64 << 10
+	// selfTestHeadTimeout is the time the network head has to be retrieved for the clock check.
+	selfTestHeadTimeout = 10 * time.Second
+)
+
+var selfTestKey = datastore.NewKey("/node/selftest")
+
+// ErrSelfTest is returned when the node refuses to start, as the environment failed the self-test.
+var ErrSelfTest = errors.New("node: self-test failed")
+
+// SelfTestConfig configures the self-test of the environment, which runs when the node starts.
+type SelfTestConfig struct {
+	// Enabled runs the self-test when the node starts.
+	Enabled bool
+	// Enforce makes the node refuse to start, if the environment fails the self-test. Otherwise,
+	// failures are only logged.
+	Enforce bool
+	// MaxWriteLatency is the maximum average latency of synced writes to the datastore and the
+	// directory of CAR files.
+	MaxWriteLatency time.Duration
+	// MaxClockDrift is the maximum amount the system clock may lag behind timestamps of headers.
+	MaxClockDrift time.Duration
+}
+
+// DefaultSelfTestConfig returns the default self-test configuration for the given node type. The
+// maximum write latency reflects the amount of data the node type stores.
+func DefaultSelfTestConfig(tp Type) SelfTestConfig {
+	cfg := SelfTestConfig{
+		MaxWriteLatency: 50 * time.Millisecond,
+		MaxClockDrift:   10 * time.Second,
+	}
+	switch tp {
+	case Bridge:
+		cfg.MaxWriteLatency = 20 * time.Millisecond
+	case Light:
+		cfg.MaxWriteLatency = 200 * time.Millisecond
+	}
+	return cfg
+}
+
+func (cfg *SelfTestConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.MaxWriteLatency <= 0 {
+		return fmt.Errorf("invalid self-test max write latency: %v", cfg.MaxWriteLatency)
+	}
+	if cfg.MaxClockDrift <= 0 {
+		return fmt.Errorf("invalid self-test max clock drift: %v", cfg.MaxClockDrift)
+	}
+	return nil
+}
+
+// networkHead provides the head of the network, i.e. the header syncer.
+type networkHead interface {
+	Head(context.Context) (*header.ExtendedHeader, error)
+}
+
+// selfTest checks whether the environment can sustain the node.
+type selfTest struct {
+	cfg    SelfTestConfig
+	tp     Type
+	path   StorePath
+	ds     datastore.Datastore
+	syncer networkHead
+}
+
+// Run runs all the checks and reports their failures. Failures are returned only if the self-test
+// is enforced.
+func (st *selfTest) Run(ctx context.Context) error {
+	var failures []error
+	latency, err := st.datastoreWriteLatency(ctx)
+	if err == nil && latency > st.cfg.MaxWriteLatency {
+		err = fmt.Errorf("datastore write latency %v exceeds %v", latency, st.cfg.MaxWriteLatency)
+	}
+	failures = append(failures, err)
+	log.Infow("self-test: datastore write latency", "latency", latency)
+
+	if st.tp != Light {
+		latency, err = st.carWriteLatency()
+		if err == nil && latency > st.cfg.MaxWriteLatency {
+			err = fmt.Errorf("CAR directory write latency %v exceeds %v", latency, st.cfg.MaxWriteLatency)
+		}
+		failures = append(failures, err)
+		log.Infow("self-test: CAR directory write latency", "latency", latency)
+	}
+
+	failures = append(failures, st.checkClock(ctx))
+
+	err = errors.Join(failures...)
+	if err == nil {
+		log.Info("self-test passed")
+		return nil
+	}
+	if st.cfg.Enforce {
+		return fmt.Errorf("%w: %w", ErrSelfTest, err)
+	}
+	log.Warnw("self-test failed, the node may fall behind", "err", err)
+	return nil
+}
+
+// datastoreWriteLatency returns the average latency of synced writes to the datastore.
+func (st *selfTest) datastoreWriteLatency(ctx context.Context) (time.Duration, error) {
+	value := make([]byte, selfTestWriteSize)
+	defer func() {
+		if err := st.ds.Delete(ctx, selfTestKey); err != nil {
+			log.Warnw("self-test: cleaning up datastore", "err", err)
+		}
+	}()
+
+	start := time.Now()
+	for i := 0; i < selfTestWrites; i++ {
+		if _, err := rand.Read(value); err != nil {
+			return 0, err
+		}
+		if err := st.ds.Put(ctx, selfTestKey, value); err != nil {
+			return 0, fmt.Errorf("writing to datastore: %w", err)
+		}
+		if err := st.ds.Sync(ctx, selfTestKey); err != nil {
+			return 0, fmt.Errorf("syncing datastore: %w", err)
+		}
+	}
+	return time.Since(start) / time.Duration(selfTestWrites), nil
+}
+
+// carWriteLatency returns the average latency of synced writes to the directory of CAR files.
+func (st *selfTest) carWriteLatency() (time.Duration, error) {
+	dir := filepath.Join(string(st.path), "blocks")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+	f, err := os.CreateTemp(dir, ".selftest-*")
+	if err != nil {
+		return 0, fmt.Errorf("creating file in CAR directory: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+		if err := os.Remove(f.Name()); err != nil {
+			log.Warnw("self-test: cleaning up CAR directory", "err", err)
+		}
+	}()
+
+	value := make([]byte, selfTestWriteSize)
+	start := time.Now()
+	for i := 0; i < selfTestWrites; i++ {
+		if _, err = rand.Read(value); err != nil {
+			return 0, err
+		}
+		if _, err = f.Write(value); err != nil {
+			return 0, fmt.Errorf("writing to CAR directory: %w", err)
+		}
+		if err = f.Sync(); err != nil {
+			return 0, fmt.Errorf("syncing CAR directory: %w", err)
+		}
+	}
+	return time.Since(start) / time.Duration(selfTestWrites), nil
+}
+
+// checkClock compares the system clock against the timestamp of the network head. A head from the
+// future means the clock lags behind, which makes the node reject valid headers. An old head is
+// only logged, as a clock running ahead can't be told apart from a halted network.
+func (st *selfTest) checkClock(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, selfTestHeadTimeout)
+	defer cancel()
+	head, err := st.syncer.Head(ctx)
+	if err != nil {
+		return fmt.Errorf("getting network head for clock check: %w", err)
+	}
+
+	drift := time.Until(head.Time())
+	log.Infow("self-test: clock drift to network head", "height", head.Height(), "drift", drift)
+	if drift > st.cfg.MaxClockDrift {
+		return fmt.Errorf("system clock lags behind the network head at height %d by %v", head.Height(), drift)
+	}
+	if -drift > st.cfg.MaxClockDrift+time.Minute {
+		log.Warnw("self-test: network head is old, system clock may run ahead",
+			"height", head.Height(),
+			"age", -drift,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/headertest"
+)
+
+func TestSelfTest_WriteLatency(t *testing.T) {
+	ctx := context.Background()
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+	st := &selfTest{
+		cfg:  DefaultSelfTestConfig(Full),
+		tp:   Full,
+		path: StorePath(t.TempDir()),
+		ds:   ds,
+	}
+
+	latency, err := st.datastoreWriteLatency(ctx)
+	require.NoError(t, err)
+	require.Positive(t, latency)
+	// the written data is cleaned up
+	has, err := ds.Has(ctx, selfTestKey)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	latency, err = st.carWriteLatency()
+	require.NoError(t, err)
+	require.Positive(t, latency)
+	entries, err := os.ReadDir(filepath.Join(string(st.path), "blocks"))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestSelfTest_CheckClock(t *testing.T) {
+	ctx := context.Background()
+	head := headertest.RandExtendedHeader(t)
+	st := &selfTest{
+		cfg:    DefaultSelfTestConfig(Light),
+		tp:     Light,
+		syncer: &fixedHead{head: head},
+	}
+
+	// the clock is in sync with the network head
+	head.RawHeader.Time = time.Now()
+	require.NoError(t, st.checkClock(ctx))
+
+	// the clock lags behind the network head, which is from the future
+	head.RawHeader.Time = time.Now().Add(st.cfg.MaxClockDrift + time.Minute)
+	require.Error(t, st.checkClock(ctx))
+
+	// an old head may come from a halted network, so it is only logged
+	head.RawHeader.Time = time.Now().Add(-time.Hour)
+	require.NoError(t, st.checkClock(ctx))
+
+	// the network head is not known
+	st.syncer = &fixedHead{err: errors.New("no head")}
+	require.Error(t, st.checkClock(ctx))
+}
+
+type fixedHead struct {
+	head *header.ExtendedHeader
+	err  error
+}
+
+func (f *fixedHead) Head(context.Context) (*header.ExtendedHeader, error) {
+	return f.head, f.err
+}
@@ -0,0 +1,71 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/libs/watchdog"
+)
+
+// WatchdogConfig configures the watchdog of long-running loops of the node, e.g. the syncer, the
+// DASer, discovery and the core listener.
+type WatchdogConfig struct {
+	// Enabled makes the loops check in every heartbeat interval and flags the loops that stop.
+	Enabled bool
+	// HeartbeatInterval is the interval the loops check in at.
+	HeartbeatInterval time.Duration
+	// MaxMissedHeartbeats is the amount of heartbeats a loop may miss in a row, before it is
+	// considered stuck.
+	MaxMissedHeartbeats int
+	// Restart makes the node restart modules with stuck loops, if the module supports restarts.
+	Restart bool
+}
+
+// DefaultWatchdogConfig returns the default watchdog configuration.
+func DefaultWatchdogConfig() WatchdogConfig {
+	return WatchdogConfig{
+		Enabled:             true,
+		HeartbeatInterval:   30 * time.Second,
+		MaxMissedHeartbeats: 3,
+	}
+}
+
+func (cfg *WatchdogConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.HeartbeatInterval <= 0 {
+		return fmt.Errorf("invalid watchdog heartbeat interval: %v", cfg.HeartbeatInterval)
+	}
+	if cfg.MaxMissedHeartbeats <= 0 {
+		return fmt.Errorf("invalid watchdog max missed heartbeats: %d", cfg.MaxMissedHeartbeats)
+	}
+	return nil
+}
+
+// newHeartbeats returns the registry of heartbeats of the loops, or nil if the watchdog is
+// disabled.
+func newHeartbeats(cfg WatchdogConfig) *watchdog.Registry {
+	if !cfg.Enabled {
+		return nil
+	}
+	return watchdog.NewRegistry(cfg.HeartbeatInterval, cfg.MaxMissedHeartbeats)
+}
+
+// Health describes the liveness of the long-running loops of the node.
+type Health struct {
+	// Healthy is false if any of the loops is stuck.
+	Healthy bool `json:"healthy"`
+	// Loops are the loops checking in with the watchdog. Empty if the watchdog is disabled.
+	Loops []watchdog.Status `json:"loops"`
+}
+
+func newHealth(loops []watchdog.Status) *Health {
+	health := &Health{Healthy: true, Loops: loops}
+	for _, loop := range loops {
+		if loop.Stuck {
+			health.Healthy = false
+		}
+	}
+	return health
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
@@ -81,7 +82,6 @@ func TestLifecycle_WithMetrics(t *testing.T) {
 						otlpmetrichttp.WithInsecure(),
 					},
 					tt.tp,
-					node.BuildInfo{},
 				),
 			)
 			require.NotNil(t, node)
@@ -165,3 +165,29 @@ func TestEmptyBlockExists(t *testing.T) {
 	}
 
 }
+
+func TestNode_Health(t *testing.T) {
+	nd := TestNode(t, node.Full)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, nd.Start(ctx))
+	defer func() {
+		require.NoError(t, nd.Stop(ctx))
+	}()
+
+	// loops check in once their goroutines are running
+	require.Eventually(t, func() bool {
+		health, err := nd.AdminServ.Health(ctx)
+		require.NoError(t, err)
+		require.True(t, health.Healthy)
+
+		loops := make(map[string]string)
+		for _, loop := range health.Loops {
+			loops[loop.Name] = loop.Module
+		}
+		return loops["header/syncer"] == "header" &&
+			loops["das/sampling-coordinator"] == "das" &&
+			loops["share/discovery/disconnects"] == "share"
+	}, 5*time.Second, 10*time.Millisecond)
+}
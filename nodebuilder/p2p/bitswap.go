@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-datastore"
 	blockstore "github.com/ipfs/go-ipfs-blockstore"
 	exchange "github.com/ipfs/go-ipfs-exchange-interface"
@@ -11,6 +12,7 @@ import (
 	"github.com/ipfs/go-libipfs/bitswap/network"
 	routinghelpers "github.com/libp2p/go-libp2p-routing-helpers"
 	hst "github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"go.uber.org/fx"
 
@@ -29,15 +31,24 @@ const (
 // dataExchange provides a constructor for IPFS block's DataExchange over BitSwap.
 func dataExchange(params bitSwapParams) exchange.Interface {
 	prefix := protocol.ID(fmt.Sprintf("/celestia/%s", params.Net))
-	return bitswap.New(
-		params.Ctx,
-		network.NewFromIpfsHost(params.Host, &routinghelpers.Null{}, network.Prefix(prefix)),
-		params.Bs,
+	opts := []bitswap.Option{
 		bitswap.ProvideEnabled(false),
 		// NOTE: These below ar required for our protocol to work reliably.
 		// See https://github.com/celestiaorg/celestia-node/issues/732
 		bitswap.SetSendDontHaves(false),
 		bitswap.SetSimulateDontHavesOnTimeout(false),
+	}
+	if params.Cfg.OutboundOnly {
+		// the protocol is kept for the blocks sent back to the node, but none is served
+		opts = append(opts, bitswap.WithPeerBlockRequestFilter(func(peer.ID, cid.Cid) bool {
+			return false
+		}))
+	}
+	return bitswap.New(
+		params.Ctx,
+		network.NewFromIpfsHost(params.Host, &routinghelpers.Null{}, network.Prefix(prefix)),
+		params.Bs,
+		opts...,
 	)
 }
 
@@ -68,6 +79,7 @@ type bitSwapParams struct {
 
 	Ctx  context.Context
 	Net  Network
+	Cfg  Config
 	Host hst.Host
 	Bs   blockstore.Blockstore
 }
@@ -34,6 +34,16 @@ type Config struct {
 
 	// Allowlist for IPColocation PubSub parameter, a list of string CIDRs
 	IPColocationWhitelist []string
+
+	// MDNS enables discovery of peers of the same network in the local network over mDNS, so that
+	// local devnets and integration environments find each other without bootstrappers or a DHT.
+	// It is off by default and is not meant for public networks.
+	MDNS bool
+	// OutboundOnly makes the node only dial out to peers, without listening for inbound connections,
+	// advertising its addresses or serving other peers. It is meant for light nodes in restrictive or
+	// privacy-sensitive environments, which still sample and get data through their outbound
+	// connections.
+	OutboundOnly bool
 }
 
 // DefaultConfig returns default configuration for P2P subsystem.
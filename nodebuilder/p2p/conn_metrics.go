@@ -0,0 +1,287 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	hst "github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-libp2p/p2p/net/swarm"
+	ma "github.com/multiformats/go-multiaddr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.uber.org/fx"
+)
+
+const (
+	directionKey = "direction"
+
+	dialErrorClassKey                     = "class"
+	dialErrorTimeout       dialErrorClass = "timeout"
+	dialErrorRefused       dialErrorClass = "refused"
+	dialErrorResourceLimit dialErrorClass = "resource_limit"
+	dialErrorGater         dialErrorClass = "gater"
+	dialErrorBackoff       dialErrorClass = "backoff"
+	dialErrorOther         dialErrorClass = "other"
+	dialErrorCanceled      dialErrorClass = "canceled"
+
+	churnEventKey = "event"
+)
+
+// connChurnWindow is the window the churn of connections is reported for.
+var connChurnWindow = time.Minute
+
+// churn events index the churn counts of connMetrics.
+const (
+	churnOpened = iota
+	churnClosed
+)
+
+var meter = global.MeterProvider().Meter("p2p")
+
+type dialErrorClass string
+
+// connMetrics tracks the churn of connections and the failures of dials, so that flapping
+// connectivity can be told apart from slow discovery.
+type connMetrics struct {
+	opened     syncint64.Counter // attributes: direction[string]
+	closed     syncint64.Counter // attributes: direction[string]
+	dialFailed syncint64.Counter // attributes: class[string]
+
+	// churn counts the connections opened and closed in the current window, by direction, and
+	// lastChurn holds the counts of the previous full window, which are reported.
+	churn     [2][3]atomic.Int64 // [event][direction]
+	lastChurn [2][3]atomic.Int64
+}
+
+// WithConnectionMetrics registers the metrics of connection churn and dial failures on the host.
+func WithConnectionMetrics(lc fx.Lifecycle, h hst.Host) (hst.Host, error) {
+	m, err := initConnMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			h.Network().Notify(m)
+			go m.rotate(ctx)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			h.Network().StopNotify(m)
+			cancel()
+			return nil
+		},
+	})
+	return &metricsHost{Host: h, metrics: m}, nil
+}
+
+func initConnMetrics() (*connMetrics, error) {
+	opened, err := meter.SyncInt64().Counter("p2p_connections_opened",
+		instrument.WithDescription("amount of opened connections"))
+	if err != nil {
+		return nil, err
+	}
+
+	closed, err := meter.SyncInt64().Counter("p2p_connections_closed",
+		instrument.WithDescription("amount of closed connections"))
+	if err != nil {
+		return nil, err
+	}
+
+	dialFailed, err := meter.SyncInt64().Counter("p2p_dial_failures",
+		instrument.WithDescription("amount of failed dials by error class"))
+	if err != nil {
+		return nil, err
+	}
+
+	churn, err := meter.AsyncInt64().Gauge("p2p_connection_churn_per_minute",
+		instrument.WithDescription("amount of connections opened and closed in the last minute"))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &connMetrics{opened: opened, closed: closed, dialFailed: dialFailed}
+	err = meter.RegisterCallback(
+		[]instrument.Asynchronous{churn},
+		func(ctx context.Context) {
+			for event, name := range [...]string{churnOpened: "opened", churnClosed: "closed"} {
+				for dir := range m.lastChurn[event] {
+					churn.Observe(ctx, m.lastChurn[event][dir].Load(),
+						attribute.String(churnEventKey, name),
+						attribute.String(directionKey, network.Direction(dir).String()),
+					)
+				}
+			}
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// rotate moves the churn of the current window to the reported one every window, until the context
+// is canceled.
+func (m *connMetrics) rotate(ctx context.Context) {
+	ticker := time.NewTicker(connChurnWindow)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for event := range m.churn {
+				for dir := range m.churn[event] {
+					m.lastChurn[event][dir].Store(m.churn[event][dir].Swap(0))
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (m *connMetrics) Connected(_ network.Network, conn network.Conn) {
+	dir := conn.Stat().Direction
+	m.churn[churnOpened][dir].Add(1)
+	m.opened.Add(context.Background(), 1, attribute.String(directionKey, dir.String()))
+}
+
+func (m *connMetrics) Disconnected(_ network.Network, conn network.Conn) {
+	dir := conn.Stat().Direction
+	m.churn[churnClosed][dir].Add(1)
+	m.closed.Add(context.Background(), 1, attribute.String(directionKey, dir.String()))
+}
+
+func (m *connMetrics) Listen(network.Network, ma.Multiaddr)      {}
+func (m *connMetrics) ListenClose(network.Network, ma.Multiaddr) {}
+
+// observeDial records the failure of the dial, if any.
+func (m *connMetrics) observeDial(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+	class := classifyDialError(err)
+	if class == dialErrorCanceled && ctx.Err() != nil {
+		// dials canceled by the dialer are not failures
+		return
+	}
+	m.dialFailed.Add(context.Background(), 1, attribute.String(dialErrorClassKey, string(class)))
+}
+
+// observeStreamDial records the failure of opening a stream as a failed dial, if the peer had to be
+// dialed for it and is still not connected. Failures of streams over established connections, e.g.
+// unsupported protocols, are not dial failures.
+func (m *connMetrics) observeStreamDial(
+	ctx context.Context,
+	net network.Network,
+	p peer.ID,
+	dialed bool,
+	err error,
+) {
+	if !dialed || err == nil || net.Connectedness(p) == network.Connected {
+		return
+	}
+	m.observeDial(ctx, err)
+}
+
+// classifyDialError returns the class of the dial error. Errors of dials to every address of the
+// peer are considered, as the swarm reports the cause of the dial separately from them.
+func classifyDialError(err error) dialErrorClass {
+	errs := []error{err}
+	var dialErr *swarm.DialError
+	if errors.As(err, &dialErr) {
+		for _, transportErr := range dialErr.DialErrors {
+			errs = append(errs, transportErr.Cause)
+		}
+	}
+
+	classes := []struct {
+		class dialErrorClass
+		is    func(error) bool
+	}{
+		{dialErrorGater, func(err error) bool {
+			return errors.Is(err, swarm.ErrGaterDisallowedConnection)
+		}},
+		{dialErrorResourceLimit, func(err error) bool {
+			return errors.Is(err, network.ErrResourceLimitExceeded)
+		}},
+		{dialErrorBackoff, func(err error) bool {
+			return errors.Is(err, swarm.ErrDialBackoff)
+		}},
+		{dialErrorRefused, func(err error) bool {
+			// not every transport wraps the syscall error
+			return errors.Is(err, syscall.ECONNREFUSED) || strings.Contains(err.Error(), "connection refused")
+		}},
+		{dialErrorTimeout, func(err error) bool {
+			var timeout interface{ Timeout() bool }
+			return errors.Is(err, swarm.ErrDialTimeout) ||
+				errors.Is(err, context.DeadlineExceeded) ||
+				(errors.As(err, &timeout) && timeout.Timeout())
+		}},
+		{dialErrorCanceled, func(err error) bool {
+			return errors.Is(err, context.Canceled)
+		}},
+	}
+	for _, c := range classes {
+		for _, err := range errs {
+			if c.is(err) {
+				return c.class
+			}
+		}
+	}
+	return dialErrorOther
+}
+
+// metricsHost records the failures of dials performed by the host, either directly or by opening
+// streams to peers it isn't connected to.
+type metricsHost struct {
+	hst.Host
+
+	metrics *connMetrics
+}
+
+func (h *metricsHost) Connect(ctx context.Context, pi peer.AddrInfo) error {
+	err := h.Host.Connect(ctx, pi)
+	h.metrics.observeDial(ctx, err)
+	return err
+}
+
+func (h *metricsHost) NewStream(ctx context.Context, p peer.ID, pids ...protocol.ID) (network.Stream, error) {
+	dialed := h.Host.Network().Connectedness(p) != network.Connected
+	s, err := h.Host.NewStream(ctx, p, pids...)
+	h.metrics.observeStreamDial(ctx, h.Host.Network(), p, dialed, err)
+	return s, err
+}
+
+func (h *metricsHost) Network() network.Network {
+	return &metricsNetwork{Network: h.Host.Network(), metrics: h.metrics}
+}
+
+// metricsNetwork records the failures of dials performed by the network of the host.
+type metricsNetwork struct {
+	network.Network
+
+	metrics *connMetrics
+}
+
+func (n *metricsNetwork) DialPeer(ctx context.Context, p peer.ID) (network.Conn, error) {
+	conn, err := n.Network.DialPeer(ctx, p)
+	n.metrics.observeDial(ctx, err)
+	return conn, err
+}
+
+func (n *metricsNetwork) NewStream(ctx context.Context, p peer.ID) (network.Stream, error) {
+	dialed := n.Network.Connectedness(p) != network.Connected
+	s, err := n.Network.NewStream(ctx, p)
+	n.metrics.observeStreamDial(ctx, n.Network, p, dialed, err)
+	return s, err
+}
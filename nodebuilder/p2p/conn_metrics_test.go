@@ -0,0 +1,90 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/libp2p/go-libp2p/p2p/net/swarm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+)
+
+func TestClassifyDialError(t *testing.T) {
+	dialErr := func(causes ...error) error {
+		err := &swarm.DialError{}
+		for _, cause := range causes {
+			err.DialErrors = append(err.DialErrors, swarm.TransportError{Cause: cause})
+		}
+		return err
+	}
+
+	tests := []struct {
+		err   error
+		class dialErrorClass
+	}{
+		{swarm.ErrDialBackoff, dialErrorBackoff},
+		{&swarm.DialError{Cause: swarm.ErrGaterDisallowedConnection}, dialErrorGater},
+		{dialErr(fmt.Errorf("dial: %w", syscall.ECONNREFUSED)), dialErrorRefused},
+		{dialErr(fmt.Errorf("quic: connection refused")), dialErrorRefused},
+		{dialErr(fmt.Errorf("reserving: %w", network.ErrResourceLimitExceeded)), dialErrorResourceLimit},
+		{dialErr(context.DeadlineExceeded), dialErrorTimeout},
+		{&swarm.DialError{Cause: swarm.ErrDialTimeout}, dialErrorTimeout},
+		{context.Canceled, dialErrorCanceled},
+		{dialErr(fmt.Errorf("handshake failed")), dialErrorOther},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.class, classifyDialError(tt.err), tt.err.Error())
+	}
+}
+
+// dialFailures counts the failed dials recorded.
+type dialFailures struct {
+	syncint64.Counter
+	count atomic.Int64
+}
+
+func (d *dialFailures) Add(_ context.Context, incr int64, _ ...attribute.KeyValue) {
+	d.count.Add(incr)
+}
+
+func TestMetricsHost_DialFailures(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	hosts := net.Hosts()
+	unlinked, err := net.GenPeer()
+	require.NoError(t, err)
+	noop, err := metric.NewNoopMeterProvider().Meter("test").SyncInt64().Counter("dial_failures")
+	require.NoError(t, err)
+	failures := &dialFailures{Counter: noop}
+	h := &metricsHost{Host: hosts[0], metrics: &connMetrics{dialFailed: failures}}
+
+	// streams failing over established connections are not dial failures
+	_, err = h.NewStream(ctx, hosts[1].ID(), "/unsupported")
+	require.Error(t, err)
+	assert.Zero(t, failures.count.Load())
+
+	// streams to peers that can't be dialed are
+	_, err = h.NewStream(ctx, unlinked.ID(), "/unsupported")
+	require.Error(t, err)
+	assert.EqualValues(t, 1, failures.count.Load())
+
+	_, err = h.Network().DialPeer(ctx, unlinked.ID())
+	require.Error(t, err)
+	assert.EqualValues(t, 2, failures.count.Load())
+
+	_, err = h.Network().NewStream(ctx, unlinked.ID())
+	require.Error(t, err)
+	assert.EqualValues(t, 3, failures.count.Load())
+}
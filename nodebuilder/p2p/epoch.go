@@ -0,0 +1,17 @@
+package p2p
+
+// ProtocolEpochFor reports the epoch of shrex protocols for a given network. Epochs of unknown
+// networks, e.g. custom ones, are the initial epoch.
+func ProtocolEpochFor(net Network) uint {
+	return protocolEpochs[net]
+}
+
+// NOTE: Every time a fork breaking shrex protocols is scheduled on a network, its epoch has to be
+// bumped here, so that peers on both sides of the fork fail fast with a wrong epoch instead of
+// opaque decoding errors.
+var protocolEpochs = map[Network]uint{
+	Arabica:        0,
+	Mocha:          0,
+	BlockspaceRace: 0,
+	Private:        0,
+}
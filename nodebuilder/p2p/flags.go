@@ -3,6 +3,7 @@ package p2p
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/multiformats/go-multiaddr"
@@ -14,8 +15,10 @@ import (
 const EnvCustomNetwork = "CELESTIA_CUSTOM"
 
 const (
-	networkFlag = "p2p.network"
-	mutualFlag  = "p2p.mutual"
+	networkFlag  = "p2p.network"
+	mutualFlag   = "p2p.mutual"
+	mdnsFlag     = "p2p.mdns"
+	outboundFlag = "p2p.outbound-only"
 )
 
 // Flags gives a set of p2p flags.
@@ -37,6 +40,19 @@ Peers must bidirectionally point to each other. (Format: multiformats.io/multiad
 			listProvidedNetworks()+
 			". Must be passed on both init and start to take effect.",
 	)
+	flags.Bool(
+		mdnsFlag,
+		false,
+		"Enables discovery of peers in the local network over mDNS. Meant for local devnets and CI, "+
+			"not for public networks.",
+	)
+
+	flags.Bool(
+		outboundFlag,
+		false,
+		"Makes the light node only dial out to peers, without listening for inbound connections, "+
+			"advertising its addresses or serving other peers.",
+	)
 
 	return flags
 }
@@ -61,6 +77,22 @@ func ParseFlags(
 	if len(mutualPeers) != 0 {
 		cfg.MutualPeers = mutualPeers
 	}
+
+	mdnsEnabled, err := cmd.Flags().GetBool(mdnsFlag)
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed(mdnsFlag) {
+		cfg.MDNS = mdnsEnabled
+	}
+
+	outboundOnly, err := cmd.Flags().GetBool(outboundFlag)
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed(outboundFlag) {
+		cfg.OutboundOnly = outboundOnly
+	}
 	return nil
 }
 
@@ -90,7 +122,8 @@ func ParseNetwork(cmd *cobra.Command) (Network, error) {
 func parseNetworkFromEnv() (Network, error) {
 	var network Network
 	// check if custom network option set
-	// format: CELESTIA_CUSTOM=<netID>:<genesisHash>:<bootstrapPeerList>
+	// format:
+	// CELESTIA_CUSTOM=<netID>:<genesisHash>:<bootstrapPeerList>:<firstSampleableHeight>:<targetConfidence>
 	if custom, ok := os.LookupEnv(EnvCustomNetwork); ok {
 		fmt.Print("\n\nWARNING: Celestia custom network specified. Only use this option if the node is " +
 			"freshly created and initialized.\n**DO NOT** run a custom network over an already-existing node " +
@@ -109,7 +142,7 @@ func parseNetworkFromEnv() (Network, error) {
 			genesisList[network] = strings.ToUpper(genHash)
 		}
 		// check if bootstrappers were provided and register
-		if len(params) == 3 {
+		if len(params) >= 3 && params[2] != "" {
 			bootstrappers := params[2]
 			// validate bootstrappers
 			bs := strings.Split(bootstrappers, ",")
@@ -119,6 +152,22 @@ func parseNetworkFromEnv() (Network, error) {
 			}
 			bootstrapList[Network(netID)] = bs
 		}
+		// check if the first sampleable height was provided and register it
+		if len(params) >= 4 && params[3] != "" {
+			height, err := strconv.ParseUint(params[3], 10, 64)
+			if err != nil || height == 0 {
+				return DefaultNetwork, fmt.Errorf("params: env %s: invalid first sampleable height", EnvCustomNetwork)
+			}
+			firstSampleableHeights[network] = height
+		}
+		// check if the target confidence was provided and register it
+		if len(params) == 5 {
+			confidence, err := strconv.ParseFloat(params[4], 64)
+			if err != nil || confidence <= 0 || confidence >= 1 {
+				return DefaultNetwork, fmt.Errorf("params: env %s: invalid target confidence", EnvCustomNetwork)
+			}
+			targetConfidences[network] = confidence
+		}
 	}
 	return network, nil
 }
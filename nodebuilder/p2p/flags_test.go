@@ -7,6 +7,8 @@ import (
 	flag "github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 )
 
 // TestParseNetwork_matchesByAlias checks to ensure flag parsing
@@ -47,6 +49,46 @@ func TestParseNetwork_parsesFromEnv(t *testing.T) {
 	assert.Equal(t, Network("testing"), net)
 }
 
+// TestParseNetwork_firstSampleableHeightFromEnv checks that custom networks register their first
+// sampleable height.
+func TestParseNetwork_firstSampleableHeightFromEnv(t *testing.T) {
+	cmd := createCmdWithNetworkFlag()
+
+	t.Setenv(EnvCustomNetwork, "sampleable:hash::100")
+	t.Cleanup(func() {
+		delete(firstSampleableHeights, "sampleable")
+	})
+
+	net, err := ParseNetwork(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, Network("sampleable"), net)
+	assert.EqualValues(t, 100, FirstSampleableHeightFor(net))
+
+	t.Setenv(EnvCustomNetwork, "sampleable:hash::0")
+	_, err = ParseNetwork(cmd)
+	assert.Error(t, err)
+}
+
+// TestParseNetwork_targetConfidenceFromEnv checks that custom networks opt in to sampling for a
+// target confidence.
+func TestParseNetwork_targetConfidenceFromEnv(t *testing.T) {
+	cmd := createCmdWithNetworkFlag()
+
+	t.Setenv(EnvCustomNetwork, "confident:hash:::0.999")
+	t.Cleanup(func() {
+		delete(targetConfidences, "confident")
+	})
+
+	net, err := ParseNetwork(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, 0.999, TargetConfidenceFor(net))
+	assert.EqualValues(t, 1, FirstSampleableHeightFor(net))
+
+	t.Setenv(EnvCustomNetwork, "confident:hash:::1")
+	_, err = ParseNetwork(cmd)
+	assert.Error(t, err)
+}
+
 func TestParsedNetwork_invalidNetwork(t *testing.T) {
 	cmd := createCmdWithNetworkFlag()
 
@@ -69,3 +111,33 @@ func createCmdWithNetworkFlag() *cobra.Command {
 	cmd.Flags().AddFlagSet(flags)
 	return cmd
 }
+
+// TestParseFlags_MDNS checks to ensure mDNS discovery stays disabled unless enabled through the
+// flag.
+func TestParseFlags_MDNS(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().AddFlagSet(Flags())
+
+	cfg := DefaultConfig(node.Light)
+	require.NoError(t, ParseFlags(cmd, &cfg))
+	assert.False(t, cfg.MDNS)
+
+	require.NoError(t, cmd.Flags().Set(mdnsFlag, "true"))
+	require.NoError(t, ParseFlags(cmd, &cfg))
+	assert.True(t, cfg.MDNS)
+}
+
+// TestParseFlags_OutboundOnly checks to ensure the outbound-only mode stays disabled unless
+// enabled through the flag.
+func TestParseFlags_OutboundOnly(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().AddFlagSet(Flags())
+
+	cfg := DefaultConfig(node.Light)
+	require.NoError(t, ParseFlags(cmd, &cfg))
+	assert.False(t, cfg.OutboundOnly)
+
+	require.NoError(t, cmd.Flags().Set(outboundFlag, "true"))
+	require.NoError(t, ParseFlags(cmd, &cfg))
+	assert.True(t, cfg.OutboundOnly)
+}
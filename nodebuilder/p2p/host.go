@@ -2,7 +2,6 @@ package p2p
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/libp2p/go-libp2p"
 	p2pconfig "github.com/libp2p/go-libp2p/config"
@@ -37,8 +36,7 @@ func host(params hostParams) (HostBase, error) {
 		libp2p.Peerstore(params.PStore),
 		libp2p.ConnectionManager(params.ConnMngr),
 		libp2p.ConnectionGater(params.ConnGater),
-		libp2p.UserAgent(fmt.Sprintf("celestia-%s", params.Net)),
-		libp2p.NATPortMap(), // enables upnp
+		libp2p.UserAgent(newUserAgent(params.Net, params.Tp, params.BuildInfo).String()),
 		libp2p.DisableRelay(),
 		libp2p.BandwidthReporter(params.Bandwidth),
 		libp2p.ResourceManager(params.ResourceManager),
@@ -48,6 +46,11 @@ func host(params hostParams) (HostBase, error) {
 		libp2p.DefaultMuxers,
 	}
 
+	// nodes in the outbound-only mode don't open ports for inbound connections
+	if !params.Cfg.OutboundOnly {
+		opts = append(opts, libp2p.NATPortMap()) // enables upnp
+	}
+
 	if params.Registry != nil {
 		opts = append(opts, libp2p.PrometheusRegisterer(params.Registry))
 	} else {
@@ -77,6 +80,7 @@ type hostParams struct {
 	fx.In
 
 	Net             Network
+	Cfg             Config
 	Lc              fx.Lifecycle
 	ID              peer.ID
 	Key             crypto.PrivKey
@@ -88,5 +92,6 @@ type hostParams struct {
 	ResourceManager network.ResourceManager
 	Registry        prometheus.Registerer `optional:"true"`
 
-	Tp node.Type
+	Tp        node.Type
+	BuildInfo node.BuildInfo
 }
@@ -0,0 +1,60 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	hst "github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+	"go.uber.org/fx"
+)
+
+// mdnsConnectTimeout is the timeout for connecting to a peer found in the local network.
+const mdnsConnectTimeout = time.Second * 10
+
+// mdnsDiscovery starts discovering peers of the same network in the local network over mDNS, if
+// enabled in the config. Found peers are connected to right away, so that local devnets and
+// integration environments don't need bootstrappers or a DHT to find each other.
+func mdnsDiscovery(lc fx.Lifecycle, cfg Config, net Network, host hst.Host) {
+	if !cfg.MDNS {
+		return
+	}
+	if net == Arabica || net == Mocha || net == BlockspaceRace {
+		log.Warnw("mDNS discovery is enabled on a public network, "+
+			"exposing the node to peers in the local network", "network", net)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// the service name is scoped to the network, so that nodes of other networks are not found
+	srv := mdns.NewMdnsService(host, fmt.Sprintf("_celestia-%s._udp", net), &mdnsNotifee{ctx: ctx, host: host})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			return srv.Start()
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return srv.Close()
+		},
+	})
+}
+
+// mdnsNotifee connects to the peers found over mDNS.
+type mdnsNotifee struct {
+	ctx  context.Context
+	host hst.Host
+}
+
+func (n *mdnsNotifee) HandlePeerFound(info peer.AddrInfo) {
+	go func() {
+		ctx, cancel := context.WithTimeout(n.ctx, mdnsConnectTimeout)
+		defer cancel()
+
+		if err := n.host.Connect(ctx, info); err != nil {
+			log.Debugw("connecting to peer found over mDNS", "peer", info.ID, "err", err)
+			return
+		}
+		log.Debugw("connected to peer found over mDNS", "peer", info.ID)
+	}()
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	p2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	gomock "github.com/golang/mock/gomock"
 	metrics "github.com/libp2p/go-libp2p/core/metrics"
 	network "github.com/libp2p/go-libp2p/core/network"
@@ -216,6 +217,21 @@ func (mr *MockModuleMockRecorder) PeerInfo(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerInfo", reflect.TypeOf((*MockModule)(nil).PeerInfo), arg0, arg1)
 }
 
+// PeerVersions mocks base method.
+func (m *MockModule) PeerVersions(arg0 context.Context) ([]p2p.PeerVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerVersions", arg0)
+	ret0, _ := ret[0].([]p2p.PeerVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PeerVersions indicates an expected call of PeerVersions.
+func (mr *MockModuleMockRecorder) PeerVersions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerVersions", reflect.TypeOf((*MockModule)(nil).PeerVersions), arg0)
+}
+
 // Peers mocks base method.
 func (m *MockModule) Peers(arg0 context.Context) ([]peer.ID, error) {
 	m.ctrl.T.Helper()
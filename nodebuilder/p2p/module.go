@@ -14,6 +14,15 @@ var log = logging.Logger("module/p2p")
 func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 	// sanitize config values before constructing module
 	cfgErr := cfg.Validate()
+	if cfgErr == nil {
+		cfgErr = cfg.validateOutboundOnly(tp)
+	}
+
+	listen, announce := cfg.ListenAddresses, cfg.AnnounceAddresses
+	if cfg.OutboundOnly {
+		// no addresses to be dialed at are listened on or announced
+		listen, announce = nil, nil
+	}
 
 	baseComponents := fx.Options(
 		fx.Supply(*cfg),
@@ -25,15 +34,17 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 		fx.Provide(connectionGater),
 		fx.Provide(host),
 		fx.Provide(routedHost),
+		fx.Provide(servingHost),
 		fx.Provide(pubSub),
 		fx.Provide(dataExchange),
 		fx.Provide(blockService),
 		fx.Provide(peerRouting),
 		fx.Provide(contentRouting),
-		fx.Provide(addrsFactory(cfg.AnnounceAddresses, cfg.NoAnnounceAddresses)),
+		fx.Provide(addrsFactory(announce, cfg.NoAnnounceAddresses)),
 		fx.Provide(metrics.NewBandwidthCounter),
 		fx.Provide(newModule),
-		fx.Invoke(Listen(cfg.ListenAddresses)),
+		fx.Invoke(Listen(listen)),
+		fx.Invoke(mdnsDiscovery),
 		fx.Provide(resourceManager),
 		fx.Provide(resourceManagerOpt(allowList)),
 	)
@@ -6,6 +6,10 @@ import (
 
 	"github.com/ipfs/go-datastore"
 	ds_sync "github.com/ipfs/go-datastore/sync"
+	hst "github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxtest"
 
@@ -15,16 +19,21 @@ import (
 
 func testModule(tp node.Type) fx.Option {
 	cfg := DefaultConfig(tp)
+	return testModuleWithConfig(tp, &cfg)
+}
+
+func testModuleWithConfig(tp node.Type, cfg *Config) fx.Option {
 	// TODO(@Wondertan): Most of these can be deduplicated
 	//  by moving Store into the modnode and introducing there a TestModNode module
 	//  that testers would import
 	return fx.Options(
 		fx.NopLogger,
-		ConstructModule(tp, &cfg),
+		ConstructModule(tp, cfg),
 		fx.Provide(context.Background),
 		fx.Supply(Private),
 		fx.Supply(Bootstrappers{}),
 		fx.Supply(tp),
+		fx.Supply(node.BuildInfo{}),
 		fx.Provide(keystore.NewMapKeystore),
 		fx.Supply(fx.Annotate(ds_sync.MutexWrap(datastore.NewMapDatastore()), fx.As(new(datastore.Batching)))),
 	)
@@ -65,3 +74,35 @@ func TestModuleBuild_WithMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestModuleBuild_OutboundOnly(t *testing.T) {
+	cfg := DefaultConfig(node.Light)
+	cfg.OutboundOnly = true
+
+	var (
+		h       hst.Host
+		serving ServingHost
+	)
+	app := fxtest.New(t,
+		testModuleWithConfig(node.Light, &cfg),
+		fx.Populate(&h, &serving),
+	)
+	app.RequireStart()
+	defer app.RequireStop()
+
+	// nothing is listened on or advertised
+	assert.Empty(t, h.Network().ListenAddresses())
+	assert.Empty(t, h.Addrs())
+
+	// the protocols of serving services are not registered
+	const pid = protocol.ID("/test/serving")
+	serving.SetStreamHandler(pid, func(network.Stream) {})
+	assert.NotContains(t, h.Mux().Protocols(), pid)
+
+	for _, tp := range []node.Type{node.Full, node.Bridge} {
+		cfg := DefaultConfig(tp)
+		cfg.OutboundOnly = true
+		app := fx.New(testModuleWithConfig(tp, &cfg))
+		assert.Error(t, app.Err(), tp.String())
+	}
+}
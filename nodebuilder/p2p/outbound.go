@@ -0,0 +1,58 @@
+package p2p
+
+import (
+	"fmt"
+
+	hst "github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+// ServingHost is the Host of the services serving other peers, like the header exchange server or
+// the fraud proof service. In the outbound-only mode, the stream handlers registered by those
+// services are dropped, so that the node serves nothing, while the services keep making requests.
+type ServingHost hst.Host
+
+// servingHost provides the ServingHost over the Host.
+func servingHost(cfg Config, h hst.Host) ServingHost {
+	if !cfg.OutboundOnly {
+		return h
+	}
+	return &nonServingHost{Host: h}
+}
+
+// nonServingHost is the Host ignoring the registration of stream handlers.
+type nonServingHost struct {
+	hst.Host
+}
+
+func (h *nonServingHost) SetStreamHandler(pid protocol.ID, _ network.StreamHandler) {
+	log.Debugw("outbound-only mode: not serving", "protocol", pid)
+}
+
+func (h *nonServingHost) SetStreamHandlerMatch(pid protocol.ID, _ func(protocol.ID) bool, _ network.StreamHandler) {
+	log.Debugw("outbound-only mode: not serving", "protocol", pid)
+}
+
+func (h *nonServingHost) RemoveStreamHandler(protocol.ID) {}
+
+// validateOutboundOnly checks that the outbound-only mode is used by light nodes only, as the other
+// node types exist to serve, and that it isn't combined with options advertising the node.
+func (cfg *Config) validateOutboundOnly(tp node.Type) error {
+	if !cfg.OutboundOnly {
+		return nil
+	}
+	switch {
+	case tp != node.Light:
+		return fmt.Errorf("p2p: outbound-only mode is supported by light nodes only, not %s", tp)
+	case isBootstrapper():
+		return fmt.Errorf("p2p: bootstrappers can't run in outbound-only mode")
+	case cfg.MDNS:
+		return fmt.Errorf("p2p: mDNS discovery advertises the node and can't be used in outbound-only mode")
+	case cfg.PeerExchange:
+		return fmt.Errorf("p2p: peer exchange can't be used in outbound-only mode")
+	}
+	return nil
+}
@@ -77,6 +77,9 @@ type Module interface {
 	// PubSubPeers returns the peer IDs of the peers joined on
 	// the given topic.
 	PubSubPeers(ctx context.Context, topic string) ([]peer.ID, error)
+
+	// PeerVersions summarizes the builds connected peers advertise in their user agents.
+	PeerVersions(context.Context) ([]PeerVersion, error)
 }
 
 // module contains all components necessary to access information and
@@ -188,6 +191,21 @@ func (m *module) PubSubPeers(_ context.Context, topic string) ([]peer.ID, error)
 	return m.ps.ListPeers(topic), nil
 }
 
+func (m *module) PeerVersions(context.Context) ([]PeerVersion, error) {
+	peers := m.host.Network().Peers()
+	agents := make([]string, 0, len(peers))
+	for _, p := range peers {
+		agent, err := m.host.Peerstore().Get(p, "AgentVersion")
+		if err != nil {
+			// identify has not finished yet or failed
+			agents = append(agents, "")
+			continue
+		}
+		agents = append(agents, agent.(string))
+	}
+	return summarizeVersions(agents), nil
+}
+
 // API is a wrapper around Module for the RPC.
 // TODO(@distractedm1nd): These structs need to be autogenerated.
 //
@@ -212,6 +230,7 @@ type API struct {
 		BandwidthForProtocol func(ctx context.Context, proto protocol.ID) (metrics.Stats, error)  `perm:"admin"`
 		ResourceState        func(context.Context) (rcmgr.ResourceManagerStat, error)             `perm:"admin"`
 		PubSubPeers          func(ctx context.Context, topic string) ([]peer.ID, error)           `perm:"admin"`
+		PeerVersions         func(context.Context) ([]PeerVersion, error)                         `perm:"admin"`
 	}
 }
 
@@ -286,3 +305,7 @@ func (api *API) ResourceState(ctx context.Context) (rcmgr.ResourceManagerStat, e
 func (api *API) PubSubPeers(ctx context.Context, topic string) ([]peer.ID, error) {
 	return api.Internal.PubSubPeers(ctx, topic)
 }
+
+func (api *API) PeerVersions(ctx context.Context) ([]PeerVersion, error) {
+	return api.Internal.PeerVersions(ctx)
+}
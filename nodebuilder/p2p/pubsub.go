@@ -85,6 +85,7 @@ func pubSub(cfg Config, params pubSubParams) (*pubsub.PubSub, error) {
 		// floodsub(because gossipsub supports floodsub protocol by default).
 		pubsub.WithGossipSubProtocols([]protocol.ID{pubsub.GossipSubID_v11}, pubsub.GossipSubDefaultFeatures),
 	}
+	opts = append(opts, params.Opts...)
 
 	return pubsub.NewGossipSub(
 		params.Ctx,
@@ -105,6 +106,17 @@ type pubSubParams struct {
 	Host          hst.Host
 	Bootstrappers Bootstrappers
 	Network       Network
+	// Opts are the options of other modules, e.g. tracers of their topics.
+	Opts []pubsub.Option `group:"pubsub-opts"`
+}
+
+// PubSubOpt provides the constructor of a PubSub option, so that modules can extend PubSub
+// without depending on its construction.
+func PubSubOpt(opt any) fx.Annotated {
+	return fx.Annotated{
+		Group:  "pubsub-opts",
+		Target: opt,
+	}
 }
 
 func topicScoreParams(network Network) map[string]*pubsub.TopicScoreParams {
@@ -42,6 +42,13 @@ func peerRouting(cfg Config, tp node.Type, params routingParams) (routing.PeerRo
 		)
 	}
 
+	if cfg.OutboundOnly {
+		// clients neither serve the DHT nor get advertised in its routing tables
+		opts = append(opts,
+			dht.Mode(dht.ModeClient),
+		)
+	}
+
 	d, err := dht.New(params.Ctx, params.Host, opts...)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,29 @@
+package p2p
+
+// FirstSampleableHeightFor reports the first height the given network published data at. Heights
+// below it are not sampled.
+func FirstSampleableHeightFor(net Network) uint64 {
+	if height, ok := firstSampleableHeights[net]; ok {
+		return height
+	}
+	return 1
+}
+
+// firstSampleableHeights are the first heights networks published data at. Custom networks
+// register theirs through EnvCustomNetwork.
+// NOTE: Every long-running network that started publishing data after its genesis has to be added
+// here. None did so far.
+var firstSampleableHeights = map[Network]uint64{}
+
+// TargetConfidenceFor reports the confidence of blocks being available, which light nodes of the
+// given network sample for, trading security for bandwidth. Zero means a fixed amount of shares is
+// sampled.
+func TargetConfidenceFor(net Network) float64 {
+	return targetConfidences[net]
+}
+
+// targetConfidences are the confidences light nodes of networks opting in sample for by default.
+// Custom networks register theirs through EnvCustomNetwork.
+// NOTE: Long-running networks keep sampling the default amount of shares, as any confidence
+// reached with fewer samples from small squares weakens their security.
+var targetConfidences = map[Network]float64{}
@@ -0,0 +1,90 @@
+package p2p
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+const (
+	userAgentPrefix = "celestia-node"
+	unknownVersion  = "unknown"
+)
+
+// UserAgent describes the build of a node advertised over the libp2p identify protocol.
+type UserAgent struct {
+	Network         string `json:"network"`
+	NodeType        string `json:"node_type"`
+	SemanticVersion string `json:"semantic_version"`
+	LastCommit      string `json:"last_commit"`
+}
+
+func newUserAgent(net Network, tp node.Type, info node.BuildInfo) UserAgent {
+	ua := UserAgent{
+		Network:         net.String(),
+		NodeType:        strings.ToLower(tp.String()),
+		SemanticVersion: info.SemanticVersion,
+		LastCommit:      info.LastCommit,
+	}
+	if ua.SemanticVersion == "" {
+		ua.SemanticVersion = unknownVersion
+	}
+	if ua.LastCommit == "" {
+		ua.LastCommit = unknownVersion
+	}
+	return ua
+}
+
+// String formats the UserAgent as
+// "celestia-node/<network>/<node type>/<semantic version>/<last commit>".
+func (ua UserAgent) String() string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", userAgentPrefix, ua.Network, ua.NodeType, ua.SemanticVersion, ua.LastCommit)
+}
+
+// ParseUserAgent parses the user agent advertised by a celestia-node peer. It reports false for
+// user agents of other software and of older celestia-node versions, which only advertised the
+// network.
+func ParseUserAgent(s string) (UserAgent, bool) {
+	parts := strings.Split(s, "/")
+	// network name may contain slashes in theory, so split the rest from the end
+	if len(parts) < 5 || parts[0] != userAgentPrefix {
+		return UserAgent{}, false
+	}
+	n := len(parts)
+	return UserAgent{
+		Network:         strings.Join(parts[1:n-3], "/"),
+		NodeType:        parts[n-3],
+		SemanticVersion: parts[n-2],
+		LastCommit:      parts[n-1],
+	}, true
+}
+
+// PeerVersion is the amount of connected peers running the same build.
+type PeerVersion struct {
+	// UserAgent is empty for peers not advertising their build.
+	UserAgent
+	Peers int `json:"peers"`
+}
+
+// summarizeVersions counts the given user agents by the builds they advertise, most common first.
+func summarizeVersions(agents []string) []PeerVersion {
+	counts := make(map[UserAgent]int)
+	for _, agent := range agents {
+		ua, _ := ParseUserAgent(agent)
+		counts[ua]++
+	}
+
+	versions := make([]PeerVersion, 0, len(counts))
+	for ua, peers := range counts {
+		versions = append(versions, PeerVersion{UserAgent: ua, Peers: peers})
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		if versions[i].Peers != versions[j].Peers {
+			return versions[i].Peers > versions[j].Peers
+		}
+		return versions[i].String() < versions[j].String()
+	})
+	return versions
+}
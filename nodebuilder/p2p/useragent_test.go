@@ -0,0 +1,44 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestUserAgent(t *testing.T) {
+	ua := newUserAgent("mocha", node.Light, node.BuildInfo{SemanticVersion: "v0.11.0", LastCommit: "abcdef"})
+	assert.Equal(t, "celestia-node/mocha/light/v0.11.0/abcdef", ua.String())
+
+	parsed, ok := ParseUserAgent(ua.String())
+	require.True(t, ok)
+	assert.Equal(t, ua, parsed)
+
+	// build info is not always available, e.g. in tests
+	ua = newUserAgent(Private, node.Full, node.BuildInfo{})
+	assert.Equal(t, "celestia-node/private/full/unknown/unknown", ua.String())
+
+	for _, agent := range []string{"", "celestia-mocha", "go-ipfs/0.19.0/", "celestia-node/mocha/light"} {
+		_, ok = ParseUserAgent(agent)
+		assert.False(t, ok, agent)
+	}
+}
+
+func TestSummarizeVersions(t *testing.T) {
+	versions := summarizeVersions([]string{
+		"celestia-node/mocha/light/v0.11.0/abcdef",
+		"celestia-node/mocha/full/v0.10.0/123456",
+		"celestia-node/mocha/light/v0.11.0/abcdef",
+		"celestia-mocha",
+	})
+	require.Len(t, versions, 3)
+	assert.Equal(t, PeerVersion{
+		UserAgent: UserAgent{Network: "mocha", NodeType: "light", SemanticVersion: "v0.11.0", LastCommit: "abcdef"},
+		Peers:     2,
+	}, versions[0])
+	// peers not advertising their build are summarized under the empty UserAgent
+	assert.Contains(t, versions, PeerVersion{Peers: 1})
+}
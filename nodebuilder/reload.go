@@ -0,0 +1,258 @@
+package nodebuilder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/getters"
+	disc "github.com/celestiaorg/celestia-node/share/p2p/discovery"
+	"github.com/celestiaorg/celestia-node/share/p2p/shrexeds"
+	"github.com/celestiaorg/celestia-node/share/p2p/shrexnd"
+)
+
+// reloadable is a config parameter that can be changed without restarting the node.
+type reloadable struct {
+	name string
+	// copy sets the parameter of dst to the value of src.
+	copy func(dst, src *Config)
+	// apply applies the parameter of the given config to the running node.
+	apply func(*Config) error
+}
+
+// reloadComponents are the components of the running node reloadable parameters are applied to.
+// They are optional, as not every node type runs all of them.
+type reloadComponents struct {
+	fx.In
+
+	EDSServer   *shrexeds.Server     `optional:"true"`
+	NDServer    *shrexnd.Server      `optional:"true"`
+	EDSClient   *shrexeds.Client     `optional:"true"`
+	NDClient    *shrexnd.Client      `optional:"true"`
+	ShrexGetter *getters.ShrexGetter `optional:"true"`
+	Discovery   *disc.Discovery      `optional:"true"`
+}
+
+// configReloader applies changes of reloadable parameters of the stored config to the running node.
+type configReloader struct {
+	load   func() (*Config, error)
+	params []reloadable
+
+	lk sync.Mutex
+	// current is the config the running node uses.
+	current *Config
+}
+
+func newConfigReloader(cfg *Config, store Store) func(reloadComponents) (node.ConfigReloader, error) {
+	return func(comps reloadComponents) (node.ConfigReloader, error) {
+		current, err := cloneConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		r := &configReloader{
+			load:    store.Config,
+			params:  reloadableParams(comps),
+			current: current,
+		}
+		return r.reload, nil
+	}
+}
+
+// reloadableParams lists all the parameters that can be changed without restarting the node.
+func reloadableParams(comps reloadComponents) []reloadable {
+	return []reloadable{
+		{
+			name: "Node.LogLevels",
+			copy: func(dst, src *Config) { dst.Node.LogLevels = src.Node.LogLevels },
+			apply: func(cfg *Config) error {
+				if err := cfg.Node.Validate(); err != nil {
+					return err
+				}
+				return cfg.Node.ApplyLogLevels()
+			},
+		},
+		{
+			name: "Share.ShrExEDSParams.ConcurrencyLimit",
+			copy: func(dst, src *Config) {
+				dst.Share.ShrExEDSParams.ConcurrencyLimit = src.Share.ShrExEDSParams.ConcurrencyLimit
+			},
+			apply: func(cfg *Config) error {
+				if err := cfg.Share.ShrExEDSParams.Validate(); err != nil {
+					return err
+				}
+				if comps.EDSServer != nil {
+					comps.EDSServer.SetConcurrencyLimit(cfg.Share.ShrExEDSParams.ConcurrencyLimit)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Share.ShrExNDParams.ConcurrencyLimit",
+			copy: func(dst, src *Config) {
+				dst.Share.ShrExNDParams.ConcurrencyLimit = src.Share.ShrExNDParams.ConcurrencyLimit
+			},
+			apply: func(cfg *Config) error {
+				if err := cfg.Share.ShrExNDParams.Validate(); err != nil {
+					return err
+				}
+				if comps.NDServer != nil {
+					comps.NDServer.SetConcurrencyLimit(cfg.Share.ShrExNDParams.ConcurrencyLimit)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Share.ShrExGetterTimeout",
+			copy: func(dst, src *Config) { dst.Share.ShrExGetterTimeout = src.Share.ShrExGetterTimeout },
+			apply: func(cfg *Config) error {
+				if cfg.Share.ShrExGetterTimeout < 0 {
+					return fmt.Errorf("shrex getter timeout can't be negative")
+				}
+				if comps.ShrexGetter != nil {
+					comps.ShrexGetter.SetMinRequestTimeout(cfg.Share.ShrExGetterTimeout)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Share.RetrievalDeadlines",
+			copy: func(dst, src *Config) {
+				dst.Share.RetrievalDeadlines = append(share.DeadlineProfiles(nil), src.Share.RetrievalDeadlines...)
+			},
+			apply: func(cfg *Config) error {
+				profiles := cfg.Share.RetrievalDeadlineProfiles()
+				if err := profiles.Validate(); err != nil {
+					return err
+				}
+				if comps.ShrexGetter != nil {
+					comps.ShrexGetter.SetDeadlineProfiles(profiles)
+				}
+				if comps.NDClient != nil {
+					comps.NDClient.SetDeadlineProfiles(profiles)
+				}
+				if comps.EDSClient != nil {
+					comps.EDSClient.SetDeadlineProfiles(profiles)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Share.Discovery.PeersLimit",
+			copy: func(dst, src *Config) { dst.Share.Discovery.PeersLimit = src.Share.Discovery.PeersLimit },
+			apply: func(cfg *Config) error {
+				if comps.Discovery != nil {
+					return comps.Discovery.SetPeersLimit(cfg.Share.Discovery.PeersLimit)
+				}
+				return nil
+			},
+		},
+	}
+}
+
+// reload loads the stored config and applies changes of its reloadable parameters one by one.
+// Invalid changes are rejected, as well as changes of any other parameters, which require a
+// restart. The config the running node uses is only updated with the applied changes.
+//
+// NOTE: Config overrides passed on start through flags are not persisted, so reloading reports
+// them as changes requiring a restart.
+func (r *configReloader) reload(context.Context) (*node.ReloadReport, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	stored, err := r.load()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	report := &node.ReloadReport{
+		Applied:  []string{},
+		Rejected: make(map[string]string),
+	}
+	for _, param := range r.params {
+		changed, err := r.changedParam(param, stored)
+		if err != nil {
+			return nil, err
+		}
+		if !changed {
+			continue
+		}
+
+		err = param.apply(stored)
+		if err != nil {
+			report.Rejected[param.name] = err.Error()
+			continue
+		}
+		param.copy(r.current, stored)
+		report.Applied = append(report.Applied, param.name)
+	}
+
+	// changes of the remaining parameters are detected per config section, with the reloadable
+	// parameters excluded from comparison
+	rest, err := cloneConfig(stored)
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range r.params {
+		param.copy(rest, r.current)
+	}
+	currentVal, restVal := reflect.ValueOf(r.current).Elem(), reflect.ValueOf(rest).Elem()
+	for i := 0; i < currentVal.NumField(); i++ {
+		equal, err := tomlEqual(currentVal.Field(i).Interface(), restVal.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		if !equal {
+			report.Rejected[currentVal.Type().Field(i).Name] = "changes require a restart"
+		}
+	}
+
+	log.Infow("reloaded config", "applied", report.Applied, "rejected", report.Rejected)
+	return report, nil
+}
+
+// changedParam checks whether the given parameter of the stored config differs from the current one.
+func (r *configReloader) changedParam(param reloadable, stored *Config) (bool, error) {
+	probe, err := cloneConfig(r.current)
+	if err != nil {
+		return false, err
+	}
+	param.copy(probe, stored)
+	equal, err := tomlEqual(r.current, probe)
+	return !equal, err
+}
+
+// cloneConfig deeply copies the given Config.
+func cloneConfig(cfg *Config) (*Config, error) {
+	buf := &bytes.Buffer{}
+	err := cfg.Encode(buf)
+	if err != nil {
+		return nil, fmt.Errorf("encoding config: %w", err)
+	}
+	clone := &Config{}
+	err = clone.Decode(buf)
+	if err != nil {
+		return nil, fmt.Errorf("decoding config: %w", err)
+	}
+	return clone, nil
+}
+
+// tomlEqual compares TOML encodings of the given values, so that only the persisted parameters are
+// compared.
+func tomlEqual(a, b any) (bool, error) {
+	bufA, bufB := &bytes.Buffer{}, &bytes.Buffer{}
+	if err := toml.NewEncoder(bufA).Encode(a); err != nil {
+		return false, fmt.Errorf("encoding config: %w", err)
+	}
+	if err := toml.NewEncoder(bufB).Encode(b); err != nil {
+		return false, fmt.Errorf("encoding config: %w", err)
+	}
+	return bytes.Equal(bufA.Bytes(), bufB.Bytes()), nil
+}
@@ -0,0 +1,72 @@
+package nodebuilder
+
+import (
+	"context"
+	"testing"
+
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	disc "github.com/celestiaorg/celestia-node/share/p2p/discovery"
+	"github.com/celestiaorg/celestia-node/share/p2p/shrexnd"
+)
+
+func TestConfigReload(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.Logger("test/reload")
+
+	// the config is reloaded from disk
+	cfg := DefaultConfig(node.Full)
+	dir := t.TempDir()
+	require.NoError(t, Init(*cfg, dir, node.Full))
+	store, err := OpenStore(dir, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+
+	ndServer, err := shrexnd.NewServer(cfg.Share.ShrExNDParams, nil, nil, nil)
+	require.NoError(t, err)
+	comps := reloadComponents{
+		NDServer:  ndServer,
+		Discovery: disc.NewDiscovery(nil, nil, disc.WithPeersLimit(cfg.Share.Discovery.PeersLimit)),
+	}
+	reload, err := newConfigReloader(cfg, store)(comps)
+	require.NoError(t, err)
+
+	// nothing changed
+	report, err := reload(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, report.Applied)
+	assert.Empty(t, report.Rejected)
+
+	updated, err := cloneConfig(cfg)
+	require.NoError(t, err)
+	updated.Node.LogLevels = map[string]string{"test/reload": "debug"}
+	updated.Share.ShrExNDParams.ConcurrencyLimit = 1
+	updated.Share.Discovery.PeersLimit = 0
+	updated.Core.IP = "127.0.0.2"
+	require.NoError(t, store.PutConfig(updated))
+
+	report, err = reload(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Node.LogLevels", "Share.ShrExNDParams.ConcurrencyLimit"}, report.Applied)
+	assert.Len(t, report.Rejected, 2)
+	assert.Contains(t, report.Rejected, "Share.Discovery.PeersLimit")
+	assert.Contains(t, report.Rejected, "Core")
+	assert.True(t, logger.Desugar().Core().Enabled(zapcore.DebugLevel))
+
+	// applied changes are not reported again, while rejected ones are
+	updated.Share.Discovery.PeersLimit = 10
+	updated.Share.ShrExNDParams.ConcurrencyLimit = 0
+	require.NoError(t, store.PutConfig(updated))
+	report, err = reload(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Share.Discovery.PeersLimit"}, report.Applied)
+	assert.Len(t, report.Rejected, 2)
+	assert.Contains(t, report.Rejected, "Share.ShrExNDParams.ConcurrencyLimit")
+	assert.Contains(t, report.Rejected, "Core")
+}
@@ -3,13 +3,90 @@ package rpc
 import (
 	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/celestiaorg/celestia-node/api/rpc"
+	"github.com/celestiaorg/celestia-node/libs/httputil"
 	"github.com/celestiaorg/celestia-node/libs/utils"
 )
 
 type Config struct {
 	Address string
 	Port    string
+	// Debug enables debug methods of the RPC.
+	Debug bool
+	// Audit configures the log of authenticated write and admin calls.
+	Audit AuditConfig
+	// GRPC configures the gRPC server mirroring the share, header, blob and das modules.
+	GRPC GRPCConfig
+	// RateLimit configures the rate limits of the clients of the RPC.
+	RateLimit RateLimitConfig
+	// Subscriptions limits the subscriptions of the clients of the RPC, e.g. to headers or blobs.
+	Subscriptions SubscriptionsConfig
+	// Batch limits the batches of requests clients send in one round trip, e.g. for ranges of
+	// headers.
+	Batch BatchConfig
+	// TLS configures TLS termination of the RPC.
+	TLS httputil.TLSConfig
+	// CORS configures the origins browsers are allowed to call the RPC from.
+	CORS httputil.CORSConfig
+	// TrustedProxies are the IPs or CIDR ranges of reverse proxies trusted to report the IPs of the
+	// clients they forward in the X-Forwarded-For header, so that rate limits tell the clients apart.
+	TrustedProxies []string `toml:",omitempty"`
+}
+
+// BatchConfig limits the JSON-RPC batches of the clients of the RPC.
+type BatchConfig struct {
+	// MaxSize is the maximum amount of requests in a batch. Zero means no limit.
+	MaxSize int
+	// Concurrency is the maximum amount of requests of a batch served at once. Zero means they are
+	// served one by one.
+	Concurrency int
+}
+
+// SubscriptionsConfig limits the subscriptions of the clients of the RPC, which are told apart by
+// their auth tokens, or by their IPs otherwise.
+type SubscriptionsConfig struct {
+	// MaxPerClient is the maximum amount of active subscriptions of a client. Zero means no limit.
+	MaxPerClient int
+	// StallTimeout is the time after which subscriptions whose consumers stopped reading are closed.
+	// Zero means they are never closed.
+	StallTimeout time.Duration
+}
+
+// RateLimitConfig configures the token bucket rate limits of the clients of the RPC, which are told
+// apart by their auth tokens, or by their IPs otherwise.
+type RateLimitConfig struct {
+	Enabled bool
+	// RequestsPerSecond and Burst limit the calls of a client to methods without limits of their own.
+	RequestsPerSecond float64
+	Burst             int
+	// Methods limits the calls of a client to expensive methods, keyed by "module.Method". Each of
+	// them is limited separately from the other methods.
+	Methods map[string]MethodRateLimit
+}
+
+// MethodRateLimit limits the calls of a client to a method.
+type MethodRateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// GRPCConfig configures the gRPC server, which listens on the address of the RPC and is served
+// over TLS as configured for the RPC.
+type GRPCConfig struct {
+	Enabled bool
+	Port    string
+}
+
+// AuditConfig configures the audit log of the RPC, which is stored under the node's store.
+type AuditConfig struct {
+	// Enabled makes the RPC record authenticated calls of write and admin methods.
+	Enabled bool
+	// MaxSize is the size in bytes, after which the audit log is rotated.
+	MaxSize int64
+	// MaxFiles is the amount of rotated audit logs kept.
+	MaxFiles int
 }
 
 func DefaultConfig() Config {
@@ -17,6 +94,30 @@ func DefaultConfig() Config {
 		Address: "0.0.0.0",
 		// do NOT expose the same port as celestia-core by default so that both can run on the same machine
 		Port: "26658",
+		Audit: AuditConfig{
+			MaxSize:  10 << 20,
+			MaxFiles: 5,
+		},
+		GRPC: GRPCConfig{
+			Port: "26661",
+		},
+		RateLimit: RateLimitConfig{
+			RequestsPerSecond: 50,
+			Burst:             100,
+			Methods: map[string]MethodRateLimit{
+				"share.GetEDS":               {RequestsPerSecond: 2, Burst: 4},
+				"share.GetEDSBytes":          {RequestsPerSecond: 2, Burst: 4},
+				"share.GetSharesByNamespace": {RequestsPerSecond: 10, Burst: 20},
+			},
+		},
+		Subscriptions: SubscriptionsConfig{
+			MaxPerClient: 32,
+			StallTimeout: time.Minute,
+		},
+		Batch: BatchConfig{
+			MaxSize:     200,
+			Concurrency: 16,
+		},
 	}
 }
 
@@ -31,5 +132,64 @@ func (cfg *Config) Validate() error {
 	if err != nil {
 		return fmt.Errorf("service/rpc: invalid port: %s", err.Error())
 	}
+	if cfg.GRPC.Enabled {
+		_, err = strconv.Atoi(cfg.GRPC.Port)
+		if err != nil {
+			return fmt.Errorf("service/rpc: invalid gRPC port: %s", err.Error())
+		}
+	}
+	if cfg.Subscriptions.MaxPerClient < 0 || cfg.Subscriptions.StallTimeout < 0 {
+		return fmt.Errorf("service/rpc: invalid subscription limits: max %d per client, stall timeout %s",
+			cfg.Subscriptions.MaxPerClient, cfg.Subscriptions.StallTimeout)
+	}
+	if cfg.Batch.MaxSize < 0 || cfg.Batch.Concurrency < 0 {
+		return fmt.Errorf("service/rpc: invalid batch limits: max size %d, concurrency %d",
+			cfg.Batch.MaxSize, cfg.Batch.Concurrency)
+	}
+	if cfg.RateLimit.Enabled {
+		if err = cfg.RateLimit.Validate(); err != nil {
+			return err
+		}
+	}
+	if err = cfg.TLS.Validate(); err != nil {
+		return fmt.Errorf("service/rpc: %w", err)
+	}
+	if _, err = httputil.ParseTrustedProxies(cfg.TrustedProxies); err != nil {
+		return fmt.Errorf("service/rpc: %w", err)
+	}
+	if cfg.Audit.Enabled {
+		if cfg.Audit.MaxSize <= 0 {
+			return fmt.Errorf("service/rpc: invalid audit log max size: %d", cfg.Audit.MaxSize)
+		}
+		if cfg.Audit.MaxFiles < 0 {
+			return fmt.Errorf("service/rpc: invalid audit log max files: %d", cfg.Audit.MaxFiles)
+		}
+	}
+	return nil
+}
+
+func (cfg *RateLimitConfig) Validate() error {
+	if cfg.RequestsPerSecond <= 0 || cfg.Burst <= 0 {
+		return fmt.Errorf("service/rpc: invalid rate limit: %v requests per second, burst %d",
+			cfg.RequestsPerSecond, cfg.Burst)
+	}
+	for method, limit := range cfg.Methods {
+		if limit.RequestsPerSecond <= 0 || limit.Burst <= 0 {
+			return fmt.Errorf("service/rpc: invalid rate limit of %s: %v requests per second, burst %d",
+				method, limit.RequestsPerSecond, limit.Burst)
+		}
+	}
 	return nil
 }
+
+// limits returns the rate limits of the RPC server.
+func (cfg *RateLimitConfig) limits() rpc.RateLimits {
+	limits := rpc.RateLimits{
+		Default: rpc.Limit{Rate: cfg.RequestsPerSecond, Burst: cfg.Burst},
+		Methods: make(map[string]rpc.Limit, len(cfg.Methods)),
+	}
+	for method, limit := range cfg.Methods {
+		limits.Methods[method] = rpc.Limit{Rate: limit.RequestsPerSecond, Burst: limit.Burst}
+	}
+	return limits
+}
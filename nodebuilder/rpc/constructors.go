@@ -1,9 +1,18 @@
 package rpc
 
 import (
+	"context"
+	"path/filepath"
+
 	"github.com/cristalhq/jwt"
+	"go.uber.org/fx"
+	"golang.org/x/crypto/acme/autocert"
 
+	"github.com/celestiaorg/celestia-node/api/grpc"
 	"github.com/celestiaorg/celestia-node/api/rpc"
+	"github.com/celestiaorg/celestia-node/libs/audit"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	"github.com/celestiaorg/celestia-node/libs/httputil"
 	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
 	"github.com/celestiaorg/celestia-node/nodebuilder/fraud"
@@ -36,6 +45,81 @@ func registerEndpoints(
 	serv.RegisterAuthedService("blob", blobMod, &blob.API{})
 }
 
-func server(cfg *Config, auth jwt.Signer) *rpc.Server {
-	return rpc.NewServer(cfg.Address, cfg.Port, auth)
+// registerGRPCServices registers the given services on the gRPC server.
+func registerGRPCServices(
+	headerMod header.Module,
+	shareMod share.Module,
+	blobMod blob.Module,
+	daserMod das.Module,
+	serv *grpc.Server,
+) {
+	serv.RegisterServices(headerMod, shareMod, blobMod, daserMod)
+}
+
+func grpcServer(
+	cfg *Config,
+	auth jwt.Signer,
+	revocations *authtoken.Revocations,
+	rpcSrv *rpc.Server,
+	acme *autocert.Manager,
+) (*grpc.Server, error) {
+	srv := grpc.NewServer(cfg.Address, cfg.GRPC.Port, auth)
+	srv.SetRevocations(revocations)
+	// calls over gRPC are guarded the same way as calls over the JSON-RPC
+	srv.SetGuards(rpcSrv)
+	// the gRPC server listens on the address of the RPC, so it serves the same certificates
+	tlsCfg, err := httputil.NewTLSConfig(cfg.TLS, acme)
+	if err != nil {
+		return nil, err
+	}
+	srv.SetTLS(tlsCfg)
+	return srv, nil
+}
+
+func server(
+	lc fx.Lifecycle,
+	cfg *Config,
+	auth jwt.Signer,
+	revocations *authtoken.Revocations,
+	path node.StorePath,
+	acme *autocert.Manager,
+) (*rpc.Server, error) {
+	srv := rpc.NewServer(cfg.Address, cfg.Port, auth)
+	srv.SetDebug(cfg.Debug)
+	srv.SetRevocations(revocations)
+	srv.SetSubscriptionLimits(rpc.SubscriptionLimits{
+		MaxPerClient: cfg.Subscriptions.MaxPerClient,
+		StallTimeout: cfg.Subscriptions.StallTimeout,
+	})
+	srv.SetBatchLimits(rpc.BatchLimits{
+		MaxSize:     cfg.Batch.MaxSize,
+		Concurrency: cfg.Batch.Concurrency,
+	})
+	if cfg.RateLimit.Enabled {
+		srv.SetRateLimits(cfg.RateLimit.limits())
+	}
+	proxies, err := httputil.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	srv.SetTrustedProxies(proxies)
+	srv.SetCORS(cfg.CORS)
+	tlsCfg, err := httputil.NewTLSConfig(cfg.TLS, acme)
+	if err != nil {
+		return nil, err
+	}
+	srv.SetTLS(tlsCfg)
+	if cfg.Audit.Enabled {
+		auditLog, err := audit.Open(filepath.Join(string(path), "audit"), cfg.Audit.MaxSize, cfg.Audit.MaxFiles)
+		if err != nil {
+			return nil, err
+		}
+		lc.Append(fx.Hook{
+			OnStop: func(context.Context) error {
+				return auditLog.Close()
+			},
+		})
+		srv.SetAuditLog(auditLog)
+	}
+	return srv, nil
 }
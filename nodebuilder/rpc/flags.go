@@ -6,8 +6,18 @@ import (
 )
 
 var (
-	addrFlag = "rpc.addr"
-	portFlag = "rpc.port"
+	addrFlag  = "rpc.addr"
+	portFlag  = "rpc.port"
+	debugFlag = "rpc.debug"
+
+	grpcFlag     = "rpc.grpc"
+	grpcPortFlag = "rpc.grpc.port"
+
+	rateLimitFlag = "rpc.rate-limit"
+
+	tlsCertFlag    = "rpc.tls.cert"
+	tlsKeyFlag     = "rpc.tls.key"
+	corsOriginFlag = "rpc.cors.origins"
 )
 
 // Flags gives a set of hardcoded node/rpc package flags.
@@ -24,6 +34,44 @@ func Flags() *flag.FlagSet {
 		"",
 		"Set a custom RPC port (default: 26658)",
 	)
+	flags.Bool(
+		debugFlag,
+		false,
+		"Enables debug methods of the RPC, e.g. share retrievals along with traces of the getters tried",
+	)
+
+	flags.Bool(
+		grpcFlag,
+		false,
+		"Enables the gRPC server mirroring the share, header, blob and das modules",
+	)
+	flags.String(
+		grpcPortFlag,
+		"",
+		"Set a custom gRPC port (default: 26661)",
+	)
+
+	flags.Bool(
+		rateLimitFlag,
+		false,
+		"Enables the rate limits of RPC clients configured in the config, e.g. of share.GetEDS",
+	)
+
+	flags.String(
+		tlsCertFlag,
+		"",
+		"Path to the PEM-encoded TLS certificate of the RPC. Enables TLS along with --rpc.tls.key",
+	)
+	flags.String(
+		tlsKeyFlag,
+		"",
+		"Path to the PEM-encoded key of the TLS certificate of the RPC",
+	)
+	flags.StringSlice(
+		corsOriginFlag,
+		nil,
+		"Origins browsers are allowed to call the RPC from, e.g. https://example.com or *",
+	)
 
 	return flags
 }
@@ -38,4 +86,29 @@ func ParseFlags(cmd *cobra.Command, cfg *Config) {
 	if port != "" {
 		cfg.Port = port
 	}
+	debug, err := cmd.Flags().GetBool(debugFlag)
+	if cmd.Flags().Changed(debugFlag) && err == nil {
+		cfg.Debug = debug
+	}
+	enableGRPC, err := cmd.Flags().GetBool(grpcFlag)
+	if cmd.Flags().Changed(grpcFlag) && err == nil {
+		cfg.GRPC.Enabled = enableGRPC
+	}
+	grpcPort := cmd.Flag(grpcPortFlag).Value.String()
+	if grpcPort != "" {
+		cfg.GRPC.Port = grpcPort
+	}
+	rateLimit, err := cmd.Flags().GetBool(rateLimitFlag)
+	if cmd.Flags().Changed(rateLimitFlag) && err == nil {
+		cfg.RateLimit.Enabled = rateLimit
+	}
+	cert, key := cmd.Flag(tlsCertFlag).Value.String(), cmd.Flag(tlsKeyFlag).Value.String()
+	if cert != "" || key != "" {
+		cfg.TLS.Enabled = true
+		cfg.TLS.CertFile, cfg.TLS.KeyFile = cert, key
+	}
+	origins, err := cmd.Flags().GetStringSlice(corsOriginFlag)
+	if cmd.Flags().Changed(corsOriginFlag) && err == nil {
+		cfg.CORS.AllowedOrigins = origins
+	}
 }
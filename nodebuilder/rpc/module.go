@@ -5,6 +5,7 @@ import (
 
 	"go.uber.org/fx"
 
+	"github.com/celestiaorg/celestia-node/api/grpc"
 	"github.com/celestiaorg/celestia-node/api/rpc"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 )
@@ -25,13 +26,36 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 				return server.Stop(ctx)
 			}),
 		)),
+		fx.Provide(func(server *rpc.Server) node.RPCModules {
+			return server
+		}),
+		fx.Provide(func(server *rpc.Server) node.AuditLog {
+			return server
+		}),
 	)
 
+	grpcComponents := fx.Options()
+	if cfg.GRPC.Enabled {
+		grpcComponents = fx.Options(
+			fx.Provide(fx.Annotate(
+				grpcServer,
+				fx.OnStart(func(ctx context.Context, server *grpc.Server) error {
+					return server.Start(ctx)
+				}),
+				fx.OnStop(func(ctx context.Context, server *grpc.Server) error {
+					return server.Stop(ctx)
+				}),
+			)),
+			fx.Invoke(registerGRPCServices),
+		)
+	}
+
 	switch tp {
 	case node.Light, node.Full, node.Bridge:
 		return fx.Module(
 			"rpc",
 			baseComponents,
+			grpcComponents,
 			fx.Invoke(registerEndpoints),
 		)
 	default:
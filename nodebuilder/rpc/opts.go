@@ -0,0 +1,10 @@
+package rpc
+
+import (
+	"github.com/celestiaorg/celestia-node/api/rpc"
+)
+
+// WithMetrics enables the metrics of the RPC server.
+func WithMetrics(srv *rpc.Server) error {
+	return srv.WithMetrics()
+}
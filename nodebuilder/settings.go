@@ -17,9 +17,11 @@ import (
 	"github.com/celestiaorg/go-fraud"
 
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
+	modfraud "github.com/celestiaorg/celestia-node/nodebuilder/fraud"
 	modheader "github.com/celestiaorg/celestia-node/nodebuilder/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	modrpc "github.com/celestiaorg/celestia-node/nodebuilder/rpc"
 	"github.com/celestiaorg/celestia-node/nodebuilder/share"
 	"github.com/celestiaorg/celestia-node/state"
 )
@@ -36,6 +38,12 @@ func WithBootstrappers(peers p2p.Bootstrappers) fx.Option {
 	return fx.Replace(peers)
 }
 
+// WithBuildInfo sets the build information of the node, advertised to peers and reported in
+// metrics.
+func WithBuildInfo(info node.BuildInfo) fx.Option {
+	return fx.Replace(info)
+}
+
 // WithPyroscope enables pyroscope profiling for the node.
 func WithPyroscope(endpoint string, nodeType node.Type) fx.Option {
 	return fx.Options(
@@ -62,16 +70,19 @@ func WithPyroscope(endpoint string, nodeType node.Type) fx.Option {
 }
 
 // WithMetrics enables metrics exporting for the node.
-func WithMetrics(metricOpts []otlpmetrichttp.Option, nodeType node.Type, buildInfo node.BuildInfo) fx.Option {
+func WithMetrics(metricOpts []otlpmetrichttp.Option, nodeType node.Type) fx.Option {
 	baseComponents := fx.Options(
 		fx.Supply(metricOpts),
-		fx.Supply(buildInfo),
 		fx.Invoke(initializeMetrics),
 		fx.Invoke(state.WithMetrics),
 		fx.Invoke(fraud.WithMetrics),
+		fx.Invoke(modfraud.WithMetrics),
 		fx.Invoke(node.WithMetrics),
 		fx.Invoke(modheader.WithMetrics),
+		fx.Invoke(modrpc.WithMetrics),
 		fx.Invoke(share.WithDiscoveryMetrics),
+		fx.Decorate(share.WithNamespaceMetrics),
+		fx.Decorate(p2p.WithConnectionMetrics),
 	)
 
 	samplingMetrics := fx.Options(
@@ -79,6 +90,7 @@ func WithMetrics(metricOpts []otlpmetrichttp.Option, nodeType node.Type, buildIn
 		fx.Invoke(share.WithPeerManagerMetrics),
 		fx.Invoke(share.WithShrexClientMetrics),
 		fx.Invoke(share.WithShrexGetterMetrics),
+		fx.Invoke(share.WithCascadeMetrics),
 	)
 
 	var opts fx.Option
@@ -87,17 +99,20 @@ func WithMetrics(metricOpts []otlpmetrichttp.Option, nodeType node.Type, buildIn
 		opts = fx.Options(
 			baseComponents,
 			fx.Invoke(share.WithShrexServerMetrics),
+			fx.Invoke(share.WithStoreMetrics),
 			samplingMetrics,
 		)
 	case node.Light:
 		opts = fx.Options(
 			baseComponents,
+			fx.Invoke(share.WithCacheAvailabilityMetrics),
 			samplingMetrics,
 		)
 	case node.Bridge:
 		opts = fx.Options(
 			baseComponents,
 			fx.Invoke(share.WithShrexServerMetrics),
+			fx.Invoke(share.WithStoreMetrics),
 		)
 	default:
 		panic("invalid node type")
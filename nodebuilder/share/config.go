@@ -1,16 +1,28 @@
 package share
 
 import (
+	"encoding/hex"
 	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/celestiaorg/nmt/namespace"
 
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/availability/light"
+	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/p2p/discovery"
 	"github.com/celestiaorg/celestia-node/share/p2p/peers"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexeds"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexnd"
 )
 
+const defaultRemoteStorageCacheSize = 32
+
 // TODO: some params are pointers and other are not, Let's fix this.
 type Config struct {
 	UseShareExchange bool
@@ -18,20 +30,81 @@ type Config struct {
 	ShrExEDSParams *shrexeds.Parameters
 	// ShrExNDParams sets shrexnd client and server configuration parameters
 	ShrExNDParams *shrexnd.Parameters
+	// ShrExNDRedirectThreshold is the amount of shares a namespace has to span for shrexnd server to
+	// redirect clients to shrexeds instead of serving it. Zero disables redirection.
+	ShrExNDRedirectThreshold int
+	// ShrExGetterTimeout is the minimal timeout given to a single peer to serve a request of the shrex
+	// getter. Zero picks the timeout from RetrievalDeadlines by the size of the requested square. It
+	// can be changed without restarting the node.
+	ShrExGetterTimeout time.Duration
+	// RetrievalDeadlines maps ranges of square sizes onto the timeouts given to a single peer to serve
+	// a retrieval of the square, so big squares aren't failed prematurely. Profiles are ordered by
+	// their max square size and the last one, with a max square size of zero, applies to all bigger
+	// squares. Empty sets the default profiles. It can be changed without restarting the node.
+	RetrievalDeadlines share.DeadlineProfiles `toml:",omitempty"`
+	// ShrExSubAllowUnsigned makes the node accept shrexsub notifications of peers not signing their
+	// messages, for networks with relaxed gossip signing, instead of ignoring them. Signed
+	// notifications are verified regardless.
+	ShrExSubAllowUnsigned bool
 	// PeerManagerParams sets peer-manager configuration parameters
 	PeerManagerParams peers.Parameters
 
 	LightAvailability light.Parameters `toml:",omitempty"`
 	Discovery         discovery.Parameters
+
+	// RemoteStorage configures bridge and full nodes to keep EDS CAR files in an S3-compatible object
+	// storage instead of the local node store.
+	RemoteStorage RemoteStorageConfig
+	// MigrateCARs makes bridge and full nodes rewrite stored CAR files into the current layout in the
+	// background, while serving them as usual.
+	MigrateCARs bool
+	// MaxStoreSize is the total size in bytes of CAR files bridge and full nodes keep. Once it is
+	// exceeded, the least accessed EDSes are pruned first. Zero keeps all EDSes.
+	MaxStoreSize int64 `toml:",omitempty"`
+	// EDSCacheSize is the total size in bytes of recently accessed EDSes bridge and full nodes keep
+	// decoded in memory. Zero sets the default size, while a negative size disables the cache.
+	EDSCacheSize int64 `toml:",omitempty"`
+	// MetricsNamespaces is the allowlist of hex-encoded namespaces retrievals of shares are counted
+	// for, if metrics are enabled. Namespaces out of the allowlist are not counted, so that the amount
+	// of metric labels stays bounded.
+	MetricsNamespaces []string `toml:",omitempty"`
+	// PinnedPeers maps hex-encoded namespaces onto multiaddrs of their preferred providers, e.g. full
+	// nodes of the rollup using the namespace. The shrex getter requests shares of the namespaces
+	// from their pinned providers first, before falling back to other peers.
+	PinnedPeers map[string][]string `toml:",omitempty"`
+}
+
+// RemoteStorageConfig configures an S3-compatible object storage for EDS CAR files.
+// DAGStore indexes are always kept locally.
+type RemoteStorageConfig struct {
+	Enabled bool
+	S3      eds.S3Config
+	// CacheSize is the amount of recently read CAR files cached locally.
+	CacheSize int
+}
+
+// Validate performs basic validation of the config.
+func (cfg *RemoteStorageConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.CacheSize < 0 {
+		return fmt.Errorf("remote storage: cache size can't be negative")
+	}
+	return cfg.S3.Validate()
 }
 
 func DefaultConfig(tp node.Type) Config {
 	cfg := Config{
-		Discovery:         discovery.DefaultParameters(),
-		ShrExEDSParams:    shrexeds.DefaultParameters(),
-		ShrExNDParams:     shrexnd.DefaultParameters(),
-		UseShareExchange:  true,
-		PeerManagerParams: peers.DefaultParameters(),
+		Discovery:          discovery.DefaultParameters(),
+		ShrExEDSParams:     shrexeds.DefaultParameters(),
+		ShrExNDParams:      shrexnd.DefaultParameters(),
+		UseShareExchange:   true,
+		RetrievalDeadlines: share.DefaultDeadlineProfiles(),
+		PeerManagerParams:  peers.DefaultParameters(),
+		RemoteStorage: RemoteStorageConfig{
+			CacheSize: defaultRemoteStorageCacheSize,
+		},
 	}
 
 	if tp == node.Light {
@@ -41,6 +114,15 @@ func DefaultConfig(tp node.Type) Config {
 	return cfg
 }
 
+// validateOutboundOnly checks that the config doesn't enable serving peer exchange, while the node
+// runs in the outbound-only mode of p2p.
+func (cfg *Config) validateOutboundOnly(p2pCfg modp2p.Config) error {
+	if p2pCfg.OutboundOnly && cfg.Discovery.PeerExchange {
+		return fmt.Errorf("nodebuilder/share: peer exchange can't be used in outbound-only mode")
+	}
+	return nil
+}
+
 // Validate performs basic validation of the config.
 func (cfg *Config) Validate(tp node.Type) error {
 	if tp == node.Light {
@@ -57,6 +139,18 @@ func (cfg *Config) Validate(tp node.Type) error {
 		return fmt.Errorf("nodebuilder/share: %w", err)
 	}
 
+	if cfg.ShrExGetterTimeout < 0 {
+		return fmt.Errorf("nodebuilder/share: shrex getter timeout can't be negative")
+	}
+
+	if err := cfg.RetrievalDeadlineProfiles().Validate(); err != nil {
+		return fmt.Errorf("nodebuilder/share: %w", err)
+	}
+
+	if cfg.ShrExNDRedirectThreshold < 0 {
+		return fmt.Errorf("nodebuilder/share: shrexnd redirect threshold can't be negative")
+	}
+
 	if err := cfg.ShrExEDSParams.Validate(); err != nil {
 		return fmt.Errorf("nodebuilder/share: %w", err)
 	}
@@ -65,5 +159,79 @@ func (cfg *Config) Validate(tp node.Type) error {
 		return fmt.Errorf("nodebuilder/share: %w", err)
 	}
 
+	if cfg.MaxStoreSize < 0 {
+		return fmt.Errorf("nodebuilder/share: max store size can't be negative")
+	}
+
+	if err := cfg.RemoteStorage.Validate(); err != nil {
+		return fmt.Errorf("nodebuilder/share: %w", err)
+	}
+
+	if _, err := cfg.metricsNamespaces(); err != nil {
+		return fmt.Errorf("nodebuilder/share: %w", err)
+	}
+
+	if _, err := cfg.pinnedPeers(); err != nil {
+		return fmt.Errorf("nodebuilder/share: %w", err)
+	}
+
 	return nil
 }
+
+// RetrievalDeadlineProfiles returns the configured deadline profiles of retrievals. Configs
+// predating the profiles use the default ones.
+func (cfg *Config) RetrievalDeadlineProfiles() share.DeadlineProfiles {
+	if len(cfg.RetrievalDeadlines) == 0 {
+		return share.DefaultDeadlineProfiles()
+	}
+	return cfg.RetrievalDeadlines
+}
+
+// metricsNamespaces decodes the allowlist of namespaces retrievals of shares are counted for.
+func (cfg *Config) metricsNamespaces() ([]namespace.ID, error) {
+	nIDs := make([]namespace.ID, 0, len(cfg.MetricsNamespaces))
+	for _, ns := range cfg.MetricsNamespaces {
+		nID, err := decodeNamespace(ns)
+		if err != nil {
+			return nil, fmt.Errorf("metrics %w", err)
+		}
+		nIDs = append(nIDs, nID)
+	}
+	return nIDs, nil
+}
+
+// pinnedPeers decodes the pinned providers of namespaces, keyed by the raw namespace ID.
+func (cfg *Config) pinnedPeers() (map[string][]peer.AddrInfo, error) {
+	pinned := make(map[string][]peer.AddrInfo, len(cfg.PinnedPeers))
+	for ns, addrs := range cfg.PinnedPeers {
+		nID, err := decodeNamespace(ns)
+		if err != nil {
+			return nil, fmt.Errorf("pinned %w", err)
+		}
+		infos := make([]peer.AddrInfo, 0, len(addrs))
+		for _, addr := range addrs {
+			ma, err := multiaddr.NewMultiaddr(addr)
+			if err != nil {
+				return nil, fmt.Errorf("pinned peer %s of namespace %s: %w", addr, ns, err)
+			}
+			info, err := peer.AddrInfoFromP2pAddr(ma)
+			if err != nil {
+				return nil, fmt.Errorf("pinned peer %s of namespace %s: %w", addr, ns, err)
+			}
+			infos = append(infos, *info)
+		}
+		pinned[string(nID)] = infos
+	}
+	return pinned, nil
+}
+
+func decodeNamespace(ns string) (namespace.ID, error) {
+	nID, err := hex.DecodeString(ns)
+	if err != nil {
+		return nil, fmt.Errorf("namespace %s is not hex-encoded: %w", ns, err)
+	}
+	if len(nID) != share.NamespaceSize {
+		return nil, fmt.Errorf("namespace %s must be %d bytes long", ns, share.NamespaceSize)
+	}
+	return nID, nil
+}
@@ -3,49 +3,157 @@ package share
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/filecoin-project/dagstore"
+	"github.com/ipfs/go-blockservice"
 	"github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
 	"github.com/libp2p/go-libp2p/core/routing"
 	routingdisc "github.com/libp2p/go-libp2p/p2p/discovery/routing"
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/nmt/namespace"
 
+	"github.com/celestiaorg/celestia-node/libs/budget"
+	"github.com/celestiaorg/celestia-node/libs/watchdog"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/availability/cache"
 	"github.com/celestiaorg/celestia-node/share/availability/light"
 	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/getters"
 	disc "github.com/celestiaorg/celestia-node/share/p2p/discovery"
+	"github.com/celestiaorg/celestia-node/share/p2p/peers"
+	"github.com/celestiaorg/celestia-node/share/p2p/shrexeds"
 )
 
-func newDiscovery(cfg Config) func(routing.ContentRouting, host.Host) *disc.Discovery {
+func newDiscovery(cfg Config) func(
+	routing.ContentRouting,
+	host.Host,
+	modp2p.ServingHost,
+	*watchdog.Registry,
+	*watchdog.Watchdog,
+	modp2p.Network,
+) *disc.Discovery {
 	return func(
 		r routing.ContentRouting,
 		h host.Host,
+		servingHost modp2p.ServingHost,
+		heartbeats *watchdog.Registry,
+		wd *watchdog.Watchdog,
+		network modp2p.Network,
 	) *disc.Discovery {
-		return disc.NewDiscovery(
+		d := disc.NewDiscovery(
 			h,
 			routingdisc.NewRoutingDiscovery(r),
 			disc.WithPeersLimit(cfg.Discovery.PeersLimit),
 			disc.WithAdvertiseInterval(cfg.Discovery.AdvertiseInterval),
+			disc.WithPeerExchange(cfg.Discovery.PeerExchange, cfg.Discovery.PeerExchangeInterval),
 		)
+		d.WithServingHost(servingHost)
+		d.WithHeartbeats(heartbeats)
+		// peers shared over peer exchange are only added once they are seen to serve EDSes
+		d.WithExchangedPeersProtocol(shrexeds.ProtocolID(network.String(), modp2p.ProtocolEpochFor(network)))
+		wd.SetRestarter("share", d.Restart)
+		return d
 	}
 }
 
+// pinnedPeersTag is the ConnManager tag protecting connections to pinned providers.
+const pinnedPeersTag = "share-pinned"
+
+// pinnedConnectTimeout limits connecting to a pinned provider on start.
+const pinnedConnectTimeout = time.Minute
+
+// pinPeers pins the providers of namespaces configured in PinnedPeers on the peer manager. Pinned
+// providers are connected to on start and their connections are protected from being trimmed.
+func pinPeers(cfg Config) func(fx.Lifecycle, host.Host, *peers.Manager) error {
+	return func(lc fx.Lifecycle, h host.Host, manager *peers.Manager) error {
+		pinned, err := cfg.pinnedPeers()
+		if err != nil {
+			return err
+		}
+		// providers may be pinned for multiple namespaces
+		providers := make(map[peer.ID]peer.AddrInfo)
+		for nID, infos := range pinned {
+			ids := make([]peer.ID, len(infos))
+			for i, info := range infos {
+				ids[i] = info.ID
+				h.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.PermanentAddrTTL)
+				h.ConnManager().Protect(info.ID, pinnedPeersTag)
+				providers[info.ID] = info
+			}
+			manager.PinPeers(namespace.ID(nID), ids...)
+		}
+		if len(providers) == 0 {
+			return nil
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				for _, info := range providers {
+					go connectPinned(ctx, h, info)
+				}
+				return nil
+			},
+			OnStop: func(context.Context) error {
+				cancel()
+				return nil
+			},
+		})
+		return nil
+	}
+}
+
+// connectPinned connects to the pinned provider, so that it is requested without dialing it first.
+func connectPinned(ctx context.Context, h host.Host, info peer.AddrInfo) {
+	ctx, cancel := context.WithTimeout(ctx, pinnedConnectTimeout)
+	defer cancel()
+
+	if err := h.Connect(ctx, info); err != nil {
+		log.Warnw("connecting to pinned provider", "peer", info.ID, "err", err)
+		return
+	}
+	log.Debugw("connected to pinned provider", "peer", info.ID)
+}
+
 // cacheAvailability wraps light availability with a cache for result sampling.
-func cacheAvailability(lc fx.Lifecycle, ds datastore.Batching, avail *light.ShareAvailability) share.Availability {
+func cacheAvailability(lc fx.Lifecycle, ds datastore.Batching, avail *light.ShareAvailability) *cache.ShareAvailability {
 	ca := cache.NewShareAvailability(avail, ds)
 	lc.Append(fx.Hook{
-		OnStop: ca.Close,
+		OnStart: ca.Start,
+		OnStop:  ca.Close,
 	})
 	return ca
 }
 
-func newModule(getter share.Getter, avail share.Availability) Module {
-	return &module{getter, avail}
+type moduleParams struct {
+	fx.In
+
+	Getter       share.Getter
+	Availability share.Availability
+	// IPLDGetter reconstructs data squares on full and light nodes
+	IPLDGetter *getters.IPLDGetter `optional:"true"`
+	// Budget is shared with sampling on full and light nodes, if enabled
+	Budget *budget.Budget `optional:"true"`
+}
+
+func newModule(params moduleParams) Module {
+	getter := params.Getter
+	if params.Budget != nil {
+		getter = getters.NewBudgetedGetter(getter, params.Budget)
+	}
+	return &module{
+		Getter:       getter,
+		Availability: params.Availability,
+		ipld:         params.IPLDGetter,
+	}
 }
 
 // ensureEmptyCARExists adds an empty EDS to the provided EDS store.
@@ -60,11 +168,18 @@ func ensureEmptyCARExists(ctx context.Context, store *eds.Store) error {
 	return err
 }
 
-func lightGetter(
+// ipldGetter plans retrieval of squares over bitswap by the parts of them peers served over shrex.
+func ipldGetter(bServ blockservice.BlockService, peerKnowledge *eds.PeerKnowledge) *getters.IPLDGetter {
+	getter := getters.NewIPLDGetter(bServ)
+	getter.SetPeerKnowledge(peerKnowledge)
+	return getter
+}
+
+func lightCascade(
 	shrexGetter *getters.ShrexGetter,
 	ipldGetter *getters.IPLDGetter,
 	cfg Config,
-) share.Getter {
+) *getters.CascadeGetter {
 	var cascade []share.Getter
 	if cfg.UseShareExchange {
 		cascade = append(cascade, shrexGetter)
@@ -73,13 +188,13 @@ func lightGetter(
 	return getters.NewCascadeGetter(cascade)
 }
 
-func fullGetter(
+func fullCascade(
 	store *eds.Store,
 	storeGetter *getters.StoreGetter,
 	shrexGetter *getters.ShrexGetter,
 	ipldGetter *getters.IPLDGetter,
 	cfg Config,
-) share.Getter {
+) *getters.CascadeGetter {
 	var cascade []share.Getter
 	cascade = append(cascade, storeGetter)
 	if cfg.UseShareExchange {
@@ -88,3 +203,51 @@ func fullGetter(
 	cascade = append(cascade, getters.NewTeeGetter(ipldGetter, store))
 	return getters.NewCascadeGetter(cascade)
 }
+
+// fullGetter makes concurrent requests of a missing EDS, e.g. by sampling and RPC, share a single
+// retrieval over the cascade.
+func fullGetter(cascade *getters.CascadeGetter) share.Getter {
+	return getters.NewCoalescingGetter(cascade)
+}
+
+// newStore constructs the EDS Store, keeping CAR files in the remote storage if it's enabled.
+func newStore(cfg *Config) func(node.StorePath, datastore.Batching) (*eds.Store, error) {
+	return func(path node.StorePath, ds datastore.Batching) (*eds.Store, error) {
+		var opts []eds.Option
+		if cfg.RemoteStorage.Enabled {
+			storage, err := eds.NewS3Storage(cfg.RemoteStorage.S3)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, eds.WithCARStorage(storage, cfg.RemoteStorage.CacheSize))
+		}
+		if cfg.MigrateCARs {
+			opts = append(opts, eds.WithMigration(eds.CanonicalMigration))
+		}
+		if cfg.MaxStoreSize > 0 {
+			opts = append(opts, eds.WithMaxSize(cfg.MaxStoreSize))
+		}
+		switch {
+		case cfg.EDSCacheSize > 0:
+			opts = append(opts, eds.WithEDSCacheSize(cfg.EDSCacheSize))
+		case cfg.EDSCacheSize < 0:
+			opts = append(opts, eds.WithEDSCacheSize(0))
+		}
+		return eds.NewStore(string(path), ds, opts...)
+	}
+}
+
+// targetConfidence returns the configured target confidence of light availability, or the one of
+// the network, if neither the confidence nor the amount of samples are configured.
+func targetConfidence(params light.Parameters, network modp2p.Network) float64 {
+	if params.TargetConfidence == 0 && params.SampleAmount == light.DefaultSampleAmount {
+		confidence := modp2p.TargetConfidenceFor(network)
+		if confidence != 0 {
+			log.Infow("sampling for the target confidence of the network, "+
+				"configure TargetConfidence or SampleAmount to override it",
+				"network", network, "confidence", confidence)
+		}
+		return confidence
+	}
+	return params.TargetConfidence
+}
@@ -0,0 +1,101 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/go-fraud"
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
+)
+
+// fraudInvalidator invalidates cached availability of blocks proven to be badly encoded and
+// triggers their sampling again, as earlier conclusions about their availability can't be trusted.
+type fraudInvalidator struct {
+	avail   share.Availability
+	headers libhead.Store[*header.ExtendedHeader]
+	fraud   fraud.Subscriber
+
+	sub    fraud.Subscription
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newFraudInvalidator(
+	avail share.Availability,
+	headers libhead.Store[*header.ExtendedHeader],
+	fraudServ fraud.Service,
+) *fraudInvalidator {
+	return &fraudInvalidator{
+		avail:   avail,
+		headers: headers,
+		fraud:   fraudServ,
+	}
+}
+
+func (fi *fraudInvalidator) Start(context.Context) error {
+	sub, err := fi.fraud.Subscribe(byzantine.BadEncoding)
+	if err != nil {
+		return fmt.Errorf("subscribing for proof(%s): %w", byzantine.BadEncoding, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	fi.sub, fi.cancel = sub, cancel
+	fi.done = make(chan struct{})
+	go fi.run(ctx)
+	return nil
+}
+
+func (fi *fraudInvalidator) Stop(ctx context.Context) error {
+	fi.cancel()
+	fi.sub.Cancel()
+	select {
+	case <-fi.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (fi *fraudInvalidator) run(ctx context.Context) {
+	defer close(fi.done)
+	for {
+		proof, err := fi.sub.Proof(ctx)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Errorw("receiving fraud proof", "err", err)
+			}
+			return
+		}
+
+		err = fi.invalidate(ctx, proof.Height())
+		if err != nil && ctx.Err() == nil {
+			log.Errorw("invalidating availability", "height", proof.Height(), "err", err)
+		}
+	}
+}
+
+// invalidate evicts cached availability of the block at the given height and samples it again.
+func (fi *fraudInvalidator) invalidate(ctx context.Context, height uint64) error {
+	h, err := fi.headers.GetByHeight(ctx, height)
+	if err != nil {
+		return fmt.Errorf("getting header: %w", err)
+	}
+
+	ctx = share.WithHeight(ctx, height)
+	err = fi.avail.Invalidate(ctx, h.DAH)
+	if err != nil {
+		return err
+	}
+	log.Infow("invalidated availability after fraud proof", "height", height)
+
+	err = fi.avail.SharesAvailable(ctx, h.DAH)
+	if err != nil {
+		return fmt.Errorf("sampling: %w", err)
+	}
+	return nil
+}
@@ -9,7 +9,9 @@ import (
 	reflect "reflect"
 
 	da "github.com/celestiaorg/celestia-app/pkg/da"
+	share0 "github.com/celestiaorg/celestia-node/nodebuilder/share"
 	share "github.com/celestiaorg/celestia-node/share"
+	eds "github.com/celestiaorg/celestia-node/share/eds"
 	namespace "github.com/celestiaorg/nmt/namespace"
 	rsmt2d "github.com/celestiaorg/rsmt2d"
 	gomock "github.com/golang/mock/gomock"
@@ -53,6 +55,36 @@ func (mr *MockModuleMockRecorder) GetEDS(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEDS", reflect.TypeOf((*MockModule)(nil).GetEDS), arg0, arg1)
 }
 
+// GetEDSBytes mocks base method.
+func (m *MockModule) GetEDSBytes(arg0 context.Context, arg1 *da.DataAvailabilityHeader) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEDSBytes", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEDSBytes indicates an expected call of GetEDSBytes.
+func (mr *MockModuleMockRecorder) GetEDSBytes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEDSBytes", reflect.TypeOf((*MockModule)(nil).GetEDSBytes), arg0, arg1)
+}
+
+// GetEDSWithTrace mocks base method.
+func (m *MockModule) GetEDSWithTrace(arg0 context.Context, arg1 *da.DataAvailabilityHeader) (*share0.TracedEDS, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEDSWithTrace", arg0, arg1)
+	ret0, _ := ret[0].(*share0.TracedEDS)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEDSWithTrace indicates an expected call of GetEDSWithTrace.
+func (mr *MockModuleMockRecorder) GetEDSWithTrace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEDSWithTrace", reflect.TypeOf((*MockModule)(nil).GetEDSWithTrace), arg0, arg1)
+}
+
 // GetShare mocks base method.
 func (m *MockModule) GetShare(arg0 context.Context, arg1 *da.DataAvailabilityHeader, arg2, arg3 int) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -68,6 +100,21 @@ func (mr *MockModuleMockRecorder) GetShare(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShare", reflect.TypeOf((*MockModule)(nil).GetShare), arg0, arg1, arg2, arg3)
 }
 
+// GetShareWithTrace mocks base method.
+func (m *MockModule) GetShareWithTrace(arg0 context.Context, arg1 *da.DataAvailabilityHeader, arg2, arg3 int) (*share0.TracedShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShareWithTrace", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*share0.TracedShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShareWithTrace indicates an expected call of GetShareWithTrace.
+func (mr *MockModuleMockRecorder) GetShareWithTrace(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareWithTrace", reflect.TypeOf((*MockModule)(nil).GetShareWithTrace), arg0, arg1, arg2, arg3)
+}
+
 // GetSharesByNamespace mocks base method.
 func (m *MockModule) GetSharesByNamespace(arg0 context.Context, arg1 *da.DataAvailabilityHeader, arg2 namespace.ID) (share.NamespacedShares, error) {
 	m.ctrl.T.Helper()
@@ -83,6 +130,21 @@ func (mr *MockModuleMockRecorder) GetSharesByNamespace(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharesByNamespace", reflect.TypeOf((*MockModule)(nil).GetSharesByNamespace), arg0, arg1, arg2)
 }
 
+// GetSharesByNamespaceWithTrace mocks base method.
+func (m *MockModule) GetSharesByNamespaceWithTrace(arg0 context.Context, arg1 *da.DataAvailabilityHeader, arg2 namespace.ID) (*share0.TracedNamespacedShares, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharesByNamespaceWithTrace", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*share0.TracedNamespacedShares)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharesByNamespaceWithTrace indicates an expected call of GetSharesByNamespaceWithTrace.
+func (mr *MockModuleMockRecorder) GetSharesByNamespaceWithTrace(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharesByNamespaceWithTrace", reflect.TypeOf((*MockModule)(nil).GetSharesByNamespaceWithTrace), arg0, arg1, arg2)
+}
+
 // ProbabilityOfAvailability mocks base method.
 func (m *MockModule) ProbabilityOfAvailability(arg0 context.Context) float64 {
 	m.ctrl.T.Helper()
@@ -97,6 +159,21 @@ func (mr *MockModuleMockRecorder) ProbabilityOfAvailability(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProbabilityOfAvailability", reflect.TypeOf((*MockModule)(nil).ProbabilityOfAvailability), arg0)
 }
 
+// ReconstructionProgress mocks base method.
+func (m *MockModule) ReconstructionProgress(arg0 context.Context) ([]eds.ReconstructionProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconstructionProgress", arg0)
+	ret0, _ := ret[0].([]eds.ReconstructionProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconstructionProgress indicates an expected call of ReconstructionProgress.
+func (mr *MockModuleMockRecorder) ReconstructionProgress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconstructionProgress", reflect.TypeOf((*MockModule)(nil).ReconstructionProgress), arg0)
+}
+
 // SharesAvailable mocks base method.
 func (m *MockModule) SharesAvailable(arg0 context.Context, arg1 *da.DataAvailabilityHeader) error {
 	m.ctrl.T.Helper()
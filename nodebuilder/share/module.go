@@ -3,7 +3,7 @@ package share
 import (
 	"context"
 
-	"github.com/ipfs/go-datastore"
+	logging "github.com/ipfs/go-log/v2"
 	"github.com/libp2p/go-libp2p/core/host"
 	"go.uber.org/fx"
 
@@ -11,6 +11,7 @@ import (
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/availability/cache"
 	"github.com/celestiaorg/celestia-node/share/availability/full"
 	"github.com/celestiaorg/celestia-node/share/availability/light"
 	"github.com/celestiaorg/celestia-node/share/eds"
@@ -22,6 +23,8 @@ import (
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexsub"
 )
 
+var log = logging.Logger("module/share")
+
 func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option {
 	// sanitize config values before constructing module
 	cfgErr := cfg.Validate(tp)
@@ -29,7 +32,11 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 	baseComponents := fx.Options(
 		fx.Supply(*cfg),
 		fx.Error(cfgErr),
+		fx.Invoke(cfg.validateOutboundOnly),
 		fx.Options(options...),
+		fx.Decorate(func(avail share.Availability, network modp2p.Network) share.Availability {
+			return newSampleableAvailability(avail, modp2p.FirstSampleableHeightFor(network))
+		}),
 		fx.Provide(newModule),
 		fx.Invoke(func(disc *disc.Discovery) {}),
 		fx.Provide(fx.Annotate(
@@ -43,9 +50,23 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 		)),
 		fx.Provide(
 			func(ctx context.Context, h host.Host, network modp2p.Network) (*shrexsub.PubSub, error) {
-				return shrexsub.NewPubSub(ctx, h, network.String())
+				var opts []shrexsub.Option
+				if cfg.ShrExSubAllowUnsigned {
+					opts = append(opts, shrexsub.WithUnsignedNotifications())
+				}
+				return shrexsub.NewPubSub(ctx, h, network.String(), opts...)
 			},
 		),
+		fx.Invoke(func(*fraudInvalidator) {}),
+		fx.Provide(fx.Annotate(
+			newFraudInvalidator,
+			fx.OnStart(func(ctx context.Context, fi *fraudInvalidator) error {
+				return fi.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, fi *fraudInvalidator) error {
+				return fi.Stop(ctx)
+			}),
+		)),
 	)
 
 	bridgeAndFullComponents := fx.Options(
@@ -54,6 +75,7 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 		fx.Provide(fx.Annotate(
 			func(host host.Host, store *eds.Store, network modp2p.Network) (*shrexeds.Server, error) {
 				cfg.ShrExEDSParams.WithNetworkID(network.String())
+				cfg.ShrExEDSParams.WithProtocolEpoch(modp2p.ProtocolEpochFor(network))
 				return shrexeds.NewServer(cfg.ShrExEDSParams, host, store)
 			},
 			fx.OnStart(func(ctx context.Context, server *shrexeds.Server) error {
@@ -71,7 +93,10 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 				network modp2p.Network,
 			) (*shrexnd.Server, error) {
 				cfg.ShrExNDParams.WithNetworkID(network.String())
-				return shrexnd.NewServer(cfg.ShrExNDParams, host, store, getter)
+				cfg.ShrExNDParams.WithProtocolEpoch(modp2p.ProtocolEpochFor(network))
+				return shrexnd.NewServer(cfg.ShrExNDParams, host, store, getter,
+					shrexnd.WithRedirectToEDSThreshold(cfg.ShrExNDRedirectThreshold),
+				)
 			},
 			fx.OnStart(func(ctx context.Context, server *shrexnd.Server) error {
 				return server.Start(ctx)
@@ -81,9 +106,7 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 			}),
 		)),
 		fx.Provide(fx.Annotate(
-			func(path node.StorePath, ds datastore.Batching) (*eds.Store, error) {
-				return eds.NewStore(string(path), ds)
-			},
+			newStore(cfg),
 			fx.OnStart(func(ctx context.Context, store *eds.Store) error {
 				err := store.Start(ctx)
 				if err != nil {
@@ -117,20 +140,45 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 			return cfg.PeerManagerParams
 		}),
 		fx.Provide(peers.NewManager),
+		fx.Provide(eds.NewPeerKnowledge),
+		fx.Invoke(pinPeers(*cfg)),
 		fx.Provide(
 			func(host host.Host, network modp2p.Network) (*shrexnd.Client, error) {
 				cfg.ShrExNDParams.WithNetworkID(network.String())
-				return shrexnd.NewClient(cfg.ShrExNDParams, host)
+				cfg.ShrExNDParams.WithProtocolEpoch(modp2p.ProtocolEpochFor(network))
+				client, err := shrexnd.NewClient(cfg.ShrExNDParams, host)
+				if err != nil {
+					return nil, err
+				}
+				client.SetDeadlineProfiles(cfg.RetrievalDeadlineProfiles())
+				return client, nil
 			},
 		),
 		fx.Provide(
 			func(host host.Host, network modp2p.Network) (*shrexeds.Client, error) {
 				cfg.ShrExEDSParams.WithNetworkID(network.String())
-				return shrexeds.NewClient(cfg.ShrExEDSParams, host)
+				cfg.ShrExEDSParams.WithProtocolEpoch(modp2p.ProtocolEpochFor(network))
+				client, err := shrexeds.NewClient(cfg.ShrExEDSParams, host)
+				if err != nil {
+					return nil, err
+				}
+				client.SetDeadlineProfiles(cfg.RetrievalDeadlineProfiles())
+				return client, nil
 			},
 		),
 		fx.Provide(fx.Annotate(
-			getters.NewShrexGetter,
+			func(
+				edsClient *shrexeds.Client,
+				ndClient *shrexnd.Client,
+				peerManager *peers.Manager,
+				peerKnowledge *eds.PeerKnowledge,
+			) *getters.ShrexGetter {
+				getter := getters.NewShrexGetter(edsClient, ndClient, peerManager)
+				getter.SetPeerKnowledge(peerKnowledge)
+				getter.SetMinRequestTimeout(cfg.ShrExGetterTimeout)
+				getter.SetDeadlineProfiles(cfg.RetrievalDeadlineProfiles())
+				return getter
+			},
 			fx.OnStart(func(ctx context.Context, getter *getters.ShrexGetter) error {
 				return getter.Start(ctx)
 			}),
@@ -163,22 +211,27 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 			baseComponents,
 			bridgeAndFullComponents,
 			shrexGetterComponents,
-			fx.Provide(getters.NewIPLDGetter),
+			fx.Provide(ipldGetter),
+			fx.Provide(fullCascade),
 			fx.Provide(fullGetter),
 		)
 	case node.Light:
 		return fx.Module(
 			"share",
 			baseComponents,
-			fx.Provide(func() []light.Option {
+			fx.Provide(func(network modp2p.Network) []light.Option {
 				return []light.Option{
 					light.WithSampleAmount(cfg.LightAvailability.SampleAmount),
+					light.WithTargetConfidence(targetConfidence(cfg.LightAvailability, network)),
 				}
 			}),
 			shrexGetterComponents,
 			fx.Invoke(share.EnsureEmptySquareExists),
-			fx.Provide(getters.NewIPLDGetter),
-			fx.Provide(lightGetter),
+			fx.Provide(ipldGetter),
+			fx.Provide(lightCascade),
+			fxutil.ProvideAs(func(cascade *getters.CascadeGetter) share.Getter {
+				return cascade
+			}),
 			// shrexsub broadcaster stub for daser
 			fx.Provide(func() shrexsub.BroadcastFn {
 				return func(context.Context, shrexsub.Notification) error {
@@ -186,9 +239,23 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 				}
 			}),
 			fx.Provide(light.NewShareAvailability),
+			fx.Invoke(func(lc fx.Lifecycle, avail *light.ShareAvailability) {
+				if cfg.LightAvailability.CoordinationSocket == "" {
+					return
+				}
+				coordinator := light.NewCoordinator(cfg.LightAvailability.CoordinationSocket)
+				lc.Append(fx.Hook{
+					OnStart: coordinator.Start,
+					OnStop:  coordinator.Stop,
+				})
+				avail.WithCoordinator(coordinator)
+			}),
 			// cacheAvailability's lifecycle continues to use a fx hook,
 			// since the LC requires a cacheAvailability but the constructor returns a share.Availability
 			fx.Provide(cacheAvailability),
+			fx.Provide(func(ca *cache.ShareAvailability) share.Availability {
+				return ca
+			}),
 		)
 	default:
 		panic("invalid node type")
@@ -1,6 +1,9 @@
 package share
 
 import (
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/availability/cache"
+	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/getters"
 	disc "github.com/celestiaorg/celestia-node/share/p2p/discovery"
 	"github.com/celestiaorg/celestia-node/share/p2p/peers"
@@ -41,3 +44,31 @@ func WithShrexServerMetrics(edsServer *shrexeds.Server, ndServer *shrexnd.Server
 func WithShrexGetterMetrics(sg *getters.ShrexGetter) error {
 	return sg.WithMetrics()
 }
+
+// WithCascadeMetrics is a utility function to turn on metrics of the getters of the cascade and
+// that is expected to be "invoked" by the fx lifecycle.
+func WithCascadeMetrics(cg *getters.CascadeGetter) error {
+	return cg.WithMetrics()
+}
+
+// WithStoreMetrics is a utility function to turn on EDS store metrics and that is expected to be
+// "invoked" by the fx lifecycle.
+func WithStoreMetrics(s *eds.Store) error {
+	return s.WithMetrics()
+}
+
+// WithCacheAvailabilityMetrics is a utility function to turn on metrics of the sampling results
+// cache and that is expected to be "invoked" by the fx lifecycle.
+func WithCacheAvailabilityMetrics(ca *cache.ShareAvailability) error {
+	return ca.WithMetrics()
+}
+
+// WithNamespaceMetrics is a utility function to count retrievals of shares of the namespaces
+// allowlisted in the config and that is expected to be "decorated" by the fx lifecycle.
+func WithNamespaceMetrics(getter share.Getter, cfg Config) (share.Getter, error) {
+	nIDs, err := cfg.metricsNamespaces()
+	if err != nil || len(nIDs) == 0 {
+		return getter, err
+	}
+	return getters.NewNamespaceMetricsGetter(getter, nIDs)
+}
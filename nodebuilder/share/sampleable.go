@@ -0,0 +1,30 @@
+package share
+
+import (
+	"context"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// sampleableAvailability skips validation of availability for heights the network did not
+// publish data at.
+type sampleableAvailability struct {
+	share.Availability
+	firstHeight uint64
+}
+
+func newSampleableAvailability(avail share.Availability, firstHeight uint64) share.Availability {
+	if firstHeight <= 1 {
+		return avail
+	}
+	return &sampleableAvailability{Availability: avail, firstHeight: firstHeight}
+}
+
+// SharesAvailable validates availability with the wrapped Availability, unless the context tells
+// the Root belongs to a height below the first sampleable one.
+func (sa *sampleableAvailability) SharesAvailable(ctx context.Context, root *share.Root) error {
+	if height, ok := share.HeightFromContext(ctx); ok && height < sa.firstHeight {
+		return nil
+	}
+	return sa.Availability.SharesAvailable(ctx, root)
+}
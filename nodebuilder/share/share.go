@@ -2,11 +2,14 @@ package share
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/celestiaorg/nmt/namespace"
 	"github.com/celestiaorg/rsmt2d"
 
 	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/getters"
 )
 
 var _ Module = (*API)(nil)
@@ -38,9 +41,53 @@ type Module interface {
 	GetShare(ctx context.Context, dah *share.Root, row, col int) (share.Share, error)
 	// GetEDS gets the full EDS identified by the given root.
 	GetEDS(ctx context.Context, root *share.Root) (*rsmt2d.ExtendedDataSquare, error)
+	// GetEDSBytes gets the full EDS identified by the given root in the canonical byte encoding of
+	// eds.ToBytes, which is several times more compact than the JSON encoding of GetEDS.
+	GetEDSBytes(ctx context.Context, root *share.Root) ([]byte, error)
 	// GetSharesByNamespace gets all shares from an EDS within the given namespace.
 	// Shares are returned in a row-by-row order if the namespace spans multiple rows.
 	GetSharesByNamespace(ctx context.Context, root *share.Root, namespace namespace.ID) (share.NamespacedShares, error)
+	// ReconstructionProgress reports the progress of the ongoing reconstructions of data squares
+	// from the network, ordered by height.
+	ReconstructionProgress(context.Context) ([]eds.ReconstructionProgress, error)
+
+	// GetShareWithTrace gets a Share like GetShare does, along with the trace of the getters tried
+	// to retrieve it. Only available in RPC debug mode.
+	//
+	// The *WithTrace methods return the trace of the attempts made so far along with the error on
+	// failure. The error describes the trace as well, as results of failed calls don't reach RPC
+	// clients.
+	GetShareWithTrace(ctx context.Context, dah *share.Root, row, col int) (*TracedShare, error)
+	// GetEDSWithTrace gets the full EDS like GetEDS does, along with the trace of the getters tried
+	// to retrieve it. Only available in RPC debug mode.
+	GetEDSWithTrace(ctx context.Context, root *share.Root) (*TracedEDS, error)
+	// GetSharesByNamespaceWithTrace gets all shares within the given namespace like
+	// GetSharesByNamespace does, along with the trace of the getters tried to retrieve them. Only
+	// available in RPC debug mode.
+	GetSharesByNamespaceWithTrace(
+		ctx context.Context,
+		root *share.Root,
+		namespace namespace.ID,
+	) (*TracedNamespacedShares, error)
+}
+
+// TracedShare is a Share along with the trace of the getters tried to retrieve it.
+type TracedShare struct {
+	Share share.Share
+	Trace *getters.CascadeTrace
+}
+
+// TracedEDS is an EDS along with the trace of the getters tried to retrieve it.
+type TracedEDS struct {
+	EDS   *rsmt2d.ExtendedDataSquare
+	Trace *getters.CascadeTrace
+}
+
+// TracedNamespacedShares are NamespacedShares along with the trace of the getters tried to
+// retrieve them.
+type TracedNamespacedShares struct {
+	Shares share.NamespacedShares
+	Trace  *getters.CascadeTrace
 }
 
 // API is a wrapper around Module for the RPC.
@@ -58,11 +105,30 @@ type API struct {
 			ctx context.Context,
 			root *share.Root,
 		) (*rsmt2d.ExtendedDataSquare, error) `perm:"public"`
+		GetEDSBytes func(
+			ctx context.Context,
+			root *share.Root,
+		) ([]byte, error) `perm:"public"`
 		GetSharesByNamespace func(
 			ctx context.Context,
 			root *share.Root,
 			namespace namespace.ID,
 		) (share.NamespacedShares, error) `perm:"public"`
+		ReconstructionProgress func(context.Context) ([]eds.ReconstructionProgress, error) `perm:"read"`
+		GetShareWithTrace      func(
+			ctx context.Context,
+			dah *share.Root,
+			row, col int,
+		) (*TracedShare, error) `perm:"read" debug:"true"`
+		GetEDSWithTrace func(
+			ctx context.Context,
+			root *share.Root,
+		) (*TracedEDS, error) `perm:"read" debug:"true"`
+		GetSharesByNamespaceWithTrace func(
+			ctx context.Context,
+			root *share.Root,
+			namespace namespace.ID,
+		) (*TracedNamespacedShares, error) `perm:"read" debug:"true"`
 	}
 }
 
@@ -82,6 +148,10 @@ func (api *API) GetEDS(ctx context.Context, root *share.Root) (*rsmt2d.ExtendedD
 	return api.Internal.GetEDS(ctx, root)
 }
 
+func (api *API) GetEDSBytes(ctx context.Context, root *share.Root) ([]byte, error) {
+	return api.Internal.GetEDSBytes(ctx, root)
+}
+
 func (api *API) GetSharesByNamespace(
 	ctx context.Context,
 	root *share.Root,
@@ -90,11 +160,84 @@ func (api *API) GetSharesByNamespace(
 	return api.Internal.GetSharesByNamespace(ctx, root, namespace)
 }
 
+func (api *API) ReconstructionProgress(ctx context.Context) ([]eds.ReconstructionProgress, error) {
+	return api.Internal.ReconstructionProgress(ctx)
+}
+
+func (api *API) GetShareWithTrace(ctx context.Context, dah *share.Root, row, col int) (*TracedShare, error) {
+	return api.Internal.GetShareWithTrace(ctx, dah, row, col)
+}
+
+func (api *API) GetEDSWithTrace(ctx context.Context, root *share.Root) (*TracedEDS, error) {
+	return api.Internal.GetEDSWithTrace(ctx, root)
+}
+
+func (api *API) GetSharesByNamespaceWithTrace(
+	ctx context.Context,
+	root *share.Root,
+	namespace namespace.ID,
+) (*TracedNamespacedShares, error) {
+	return api.Internal.GetSharesByNamespaceWithTrace(ctx, root, namespace)
+}
+
 type module struct {
 	share.Getter
 	share.Availability
+
+	ipld *getters.IPLDGetter
 }
 
 func (m module) SharesAvailable(ctx context.Context, root *share.Root) error {
 	return m.Availability.SharesAvailable(ctx, root)
 }
+
+func (m module) ReconstructionProgress(context.Context) ([]eds.ReconstructionProgress, error) {
+	if m.ipld == nil {
+		return []eds.ReconstructionProgress{}, nil
+	}
+	return m.ipld.ReconstructionProgress(), nil
+}
+
+func (m module) GetEDSBytes(ctx context.Context, root *share.Root) ([]byte, error) {
+	square, err := m.Getter.GetEDS(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	return eds.ToBytes(square)
+}
+
+func (m module) GetShareWithTrace(ctx context.Context, dah *share.Root, row, col int) (*TracedShare, error) {
+	ctx, trace := getters.WithCascadeTrace(ctx)
+	shr, err := m.Getter.GetShare(ctx, dah, row, col)
+	if err != nil {
+		return &TracedShare{Trace: trace}, withTrace(err, trace)
+	}
+	return &TracedShare{Share: shr, Trace: trace}, nil
+}
+
+func (m module) GetEDSWithTrace(ctx context.Context, root *share.Root) (*TracedEDS, error) {
+	ctx, trace := getters.WithCascadeTrace(ctx)
+	square, err := m.Getter.GetEDS(ctx, root)
+	if err != nil {
+		return &TracedEDS{Trace: trace}, withTrace(err, trace)
+	}
+	return &TracedEDS{EDS: square, Trace: trace}, nil
+}
+
+func (m module) GetSharesByNamespaceWithTrace(
+	ctx context.Context,
+	root *share.Root,
+	namespace namespace.ID,
+) (*TracedNamespacedShares, error) {
+	ctx, trace := getters.WithCascadeTrace(ctx)
+	shares, err := m.Getter.GetSharesByNamespace(ctx, root, namespace)
+	if err != nil {
+		return &TracedNamespacedShares{Trace: trace}, withTrace(err, trace)
+	}
+	return &TracedNamespacedShares{Shares: shares, Trace: trace}, nil
+}
+
+// withTrace appends the description of the trace to the error.
+func withTrace(err error, trace *getters.CascadeTrace) error {
+	return fmt.Errorf("%w; %s", err, trace)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/ipfs/go-datastore"
 	ds_sync "github.com/ipfs/go-datastore/sync"
 	"github.com/stretchr/testify/assert"
@@ -11,8 +12,14 @@ import (
 
 	"github.com/celestiaorg/celestia-app/pkg/da"
 
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/availability/light"
+	availMock "github.com/celestiaorg/celestia-node/share/availability/mocks"
 	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/getters"
+	"github.com/celestiaorg/celestia-node/share/mocks"
 )
 
 func Test_EmptyCARExists(t *testing.T) {
@@ -43,3 +50,61 @@ func Test_EmptyCARExists(t *testing.T) {
 	assert.Equal(t, eds.Flattened(), emptyEds.Flattened())
 	assert.NoError(t, err)
 }
+
+func Test_SampleableAvailability(t *testing.T) {
+	ctx := context.Background()
+	mock := availMock.NewMockAvailability(gomock.NewController(t))
+	avail := newSampleableAvailability(mock, 10)
+	dah := da.MinDataAvailabilityHeader()
+
+	// heights below the first sampleable one are not sampled
+	assert.NoError(t, avail.SharesAvailable(share.WithHeight(ctx, 9), &dah))
+
+	mock.EXPECT().SharesAvailable(gomock.Any(), &dah).Return(share.ErrNotAvailable).Times(2)
+	assert.ErrorIs(t, avail.SharesAvailable(share.WithHeight(ctx, 10), &dah), share.ErrNotAvailable)
+	// the height is unknown
+	assert.ErrorIs(t, avail.SharesAvailable(ctx, &dah), share.ErrNotAvailable)
+}
+
+func Test_GetEDSWithTraceErr(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	failing := mocks.NewMockGetter(ctrl)
+	failing.EXPECT().GetEDS(gomock.Any(), gomock.Any()).Return(nil, share.ErrNotFound)
+	mod := module{Getter: getters.NewCascadeGetter([]share.Getter{failing})}
+
+	dah := da.MinDataAvailabilityHeader()
+	traced, err := mod.GetEDSWithTrace(ctx, &dah)
+	require.ErrorIs(t, err, share.ErrNotFound)
+	// the trace of the failed attempts is returned along with the error
+	require.NotNil(t, traced)
+	require.Len(t, traced.Trace.Attempts, 1)
+	assert.Equal(t, share.ErrNotFound.Error(), traced.Trace.Attempts[0].Error)
+	assert.Contains(t, err.Error(), "mocks.MockGetter")
+}
+
+func Test_ValidateOutboundOnly(t *testing.T) {
+	cfg := DefaultConfig(node.Light)
+	p2pCfg := modp2p.DefaultConfig(node.Light)
+	p2pCfg.OutboundOnly = true
+	assert.NoError(t, cfg.validateOutboundOnly(p2pCfg))
+
+	// peer exchange is served to other nodes
+	cfg.Discovery.PeerExchange = true
+	assert.Error(t, cfg.validateOutboundOnly(p2pCfg))
+
+	p2pCfg.OutboundOnly = false
+	assert.NoError(t, cfg.validateOutboundOnly(p2pCfg))
+}
+
+func Test_targetConfidence(t *testing.T) {
+	params := light.DefaultParameters()
+	// networks not opting in sample the default amount of shares
+	assert.Zero(t, targetConfidence(params, modp2p.Mocha))
+	assert.Zero(t, targetConfidence(params, modp2p.Private))
+
+	params.SampleAmount = 20
+	assert.Zero(t, targetConfidence(params, modp2p.Mocha))
+	params.TargetConfidence = 0.999
+	assert.Equal(t, 0.999, targetConfidence(params, modp2p.Mocha))
+}
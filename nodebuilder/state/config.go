@@ -1,6 +1,11 @@
 package state
 
-import "github.com/cosmos/cosmos-sdk/crypto/keyring"
+import (
+	"errors"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+)
 
 var defaultKeyringBackend = keyring.BackendTest
 
@@ -9,16 +14,82 @@ var defaultKeyringBackend = keyring.BackendTest
 type Config struct {
 	KeyringAccName string
 	KeyringBackend string
+	// ReadOnly starts the state module without keys, serving only queries, e.g. of balances and
+	// delegations of given addresses. It is also used when no account name is configured and the
+	// default key is missing from the keyring.
+	ReadOnly bool
+	// MinBalance is the balance in utia, which submissions must leave on the paying account. It is
+	// 0, or disabled, by default.
+	MinBalance uint64
+	// Resubmission configures resubmission of blob transactions stuck in the mempool.
+	Resubmission ResubmissionConfig
+	// RemoteSigner configures signing of transactions by a remote signer.
+	RemoteSigner RemoteSignerConfig
+}
+
+// RemoteSignerConfig configures signing of transactions by a remote signer, e.g. one started with
+// `cel-key remote-signer`, so that the keys don't need to be held on the node's machine. The token
+// of the signer is read from the CELESTIA_REMOTE_SIGNER_TOKEN environment variable.
+type RemoteSignerConfig struct {
+	// Address is the JSON-RPC address of the signer, either unix:///path/to/socket or an https://
+	// URL. Signing is local, if it is empty.
+	Address string
+	// CAFile is the path of the PEM-encoded certificate authority the TLS certificate of the signer
+	// is verified with, e.g. if it is self-signed. The system's roots are used, if it is empty.
+	CAFile string `toml:",omitempty"`
+	// Accounts are the names of the remote keys in addition to the signing account, e.g. to submit
+	// blobs from.
+	Accounts []string
+}
+
+// ResubmissionConfig configures the submit mode, in which blob transactions are not awaited in a
+// block by the core node, but monitored for inclusion and resubmitted with escalated fees after the
+// given amount of blocks.
+type ResubmissionConfig struct {
+	Enabled bool
+	// Blocks is the amount of blocks to wait for the inclusion of a transaction.
+	Blocks uint64
+	// FeeMultiplier escalates the fee of every resubmission.
+	FeeMultiplier float64
+	// MaxAttempts is the maximum amount of times a transaction is submitted.
+	MaxAttempts int
 }
 
 func DefaultConfig() Config {
 	return Config{
 		KeyringAccName: "",
 		KeyringBackend: defaultKeyringBackend,
+		Resubmission: ResubmissionConfig{
+			Blocks:        3,
+			FeeMultiplier: 1.5,
+			MaxAttempts:   3,
+		},
 	}
 }
 
 // Validate performs basic validation of the config.
 func (cfg *Config) Validate() error {
+	if cfg.ReadOnly && (cfg.KeyringAccName != "" || cfg.RemoteSigner.Address != "") {
+		return errors.New("module/state: read-only mode conflicts with the configured keys")
+	}
+	if cfg.RemoteSigner.Address == "" && len(cfg.RemoteSigner.Accounts) != 0 {
+		return errors.New("module/state: remote signer accounts require the remote signer address")
+	}
+	if addr := cfg.RemoteSigner.Address; addr != "" &&
+		!strings.HasPrefix(addr, "unix://") && !strings.HasPrefix(addr, "https://") {
+		return errors.New("module/state: remote signer address must be a unix socket or an https URL")
+	}
+	if !cfg.Resubmission.Enabled {
+		return nil
+	}
+	if cfg.Resubmission.Blocks == 0 {
+		return errors.New("module/state: resubmission blocks must be positive")
+	}
+	if cfg.Resubmission.FeeMultiplier <= 1 {
+		return errors.New("module/state: resubmission fee multiplier must be greater than 1")
+	}
+	if cfg.Resubmission.MaxAttempts <= 0 {
+		return errors.New("module/state: resubmission max attempts must be positive")
+	}
 	return nil
 }
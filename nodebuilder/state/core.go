@@ -1,6 +1,8 @@
 package state
 
 import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
 	libfraud "github.com/celestiaorg/go-fraud"
 	"github.com/celestiaorg/go-header/sync"
@@ -8,23 +10,38 @@ import (
 	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/core"
 	modfraud "github.com/celestiaorg/celestia-node/nodebuilder/fraud"
-	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
 	"github.com/celestiaorg/celestia-node/state"
 )
 
 // coreAccessor constructs a new instance of state.Module over
 // a celestia-core connection.
 func coreAccessor(
+	cfg Config,
 	corecfg core.Config,
 	signer *apptypes.KeyringSigner,
 	sync *sync.Syncer[*header.ExtendedHeader],
 	fraudServ libfraud.Service,
 ) (*state.CoreAccessor, *modfraud.ServiceBreaker[*state.CoreAccessor]) {
-	ca := state.NewCoreAccessor(signer, sync, corecfg.IP, corecfg.RPCPort, corecfg.GRPCPort)
+	opts := []state.Option{state.WithMinBalance(sdk.NewIntFromUint64(cfg.MinBalance))}
+	if cfg.Resubmission.Enabled {
+		opts = append(opts, state.WithResubmission(
+			cfg.Resubmission.Blocks,
+			cfg.Resubmission.FeeMultiplier,
+			cfg.Resubmission.MaxAttempts,
+		))
+	}
+	for _, endpoint := range corecfg.FailoverEndpoints {
+		opts = append(opts, state.WithFailoverEndpoints(state.Endpoint{
+			IP:       endpoint.IP,
+			RPCPort:  endpoint.RPCPort,
+			GRPCPort: endpoint.GRPCPort,
+		}))
+	}
+	ca := state.NewCoreAccessor(signer, sync, corecfg.IP, corecfg.RPCPort, corecfg.GRPCPort, opts...)
 
 	return ca, &modfraud.ServiceBreaker[*state.CoreAccessor]{
-		Service:   ca,
-		FraudType: byzantine.BadEncoding,
-		FraudServ: fraudServ,
+		Service:    ca,
+		FraudTypes: modfraud.ProofTypes(),
+		FraudServ:  fraudServ,
 	}
 }
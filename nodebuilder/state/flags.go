@@ -10,6 +10,7 @@ import (
 var (
 	keyringAccNameFlag = "keyring.accname"
 	keyringBackendFlag = "keyring.backend"
+	readOnlyFlag       = "state.readonly"
 )
 
 // Flags gives a set of hardcoded State flags.
@@ -20,6 +21,7 @@ func Flags() *flag.FlagSet {
 		"given string.")
 	flags.String(keyringBackendFlag, defaultKeyringBackend, fmt.Sprintf("Directs node's keyring signer to use the given "+
 		"backend. Default is %s.", defaultKeyringBackend))
+	flags.Bool(readOnlyFlag, false, "Starts the state module without keys, serving only queries.")
 
 	return flags
 }
@@ -32,4 +34,8 @@ func ParseFlags(cmd *cobra.Command, cfg *Config) {
 	}
 
 	cfg.KeyringBackend = cmd.Flag(keyringBackendFlag).Value.String()
+
+	if cmd.Flag(readOnlyFlag).Changed {
+		cfg.ReadOnly = cmd.Flag(readOnlyFlag).Value.String() == "true"
+	}
 }
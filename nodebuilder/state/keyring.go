@@ -1,21 +1,48 @@
 package state
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+	"time"
+
 	kr "github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
 
 	"github.com/celestiaorg/celestia-node/libs/keystore"
+	"github.com/celestiaorg/celestia-node/libs/remotesigner"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 )
 
 const DefaultAccountName = "my_celes_key"
 
-// KeyringSigner constructs a new keyring signer.
+// RemoteSignerTokenEnv is the environment variable holding the token of the remote signer.
+const RemoteSignerTokenEnv = "CELESTIA_REMOTE_SIGNER_TOKEN"
+
+// remoteSignerTimeout is the time the remote signer has to serve the public keys of the accounts.
+var remoteSignerTimeout = 30 * time.Second
+
+// KeyringSigner constructs a new keyring signer. No signer is returned in the read-only mode, which
+// is also used when no account name is configured and the default key is missing.
 // NOTE: we construct keyring signer before constructing node for easier UX
 // as having keyring-backend set to `file` prompts user for password.
 func KeyringSigner(cfg Config, ks keystore.Keystore, net p2p.Network) (*apptypes.KeyringSigner, error) {
+	if cfg.ReadOnly {
+		log.Infow("starting state module in read-only mode")
+		return nil, nil
+	}
 	ring := ks.Keyring()
+	if cfg.RemoteSigner.Address != "" {
+		remote, err := remoteKeyring(cfg, ring)
+		if err != nil {
+			return nil, err
+		}
+		ring = remote
+	}
 	var info *kr.Record
 	// if custom keyringAccName provided, find key for that name
 	if cfg.KeyringAccName != "" {
@@ -28,6 +55,11 @@ func KeyringSigner(cfg Config, ks keystore.Keystore, net p2p.Network) (*apptypes
 	} else {
 		// use default key
 		keyInfo, err := ring.Key(DefaultAccountName)
+		if errors.Is(err, sdkerrors.ErrKeyNotFound) && cfg.RemoteSigner.Address == "" {
+			log.Warnw("no key in keyring, starting state module in read-only mode",
+				"name", DefaultAccountName, "path", ks.Path())
+			return nil, nil
+		}
 		if err != nil {
 			log.Errorw("could not access key in keyring", "name", DefaultAccountName)
 			return nil, err
@@ -42,3 +74,37 @@ func KeyringSigner(cfg Config, ks keystore.Keystore, net p2p.Network) (*apptypes
 
 	return signer, nil
 }
+
+// remoteKeyring wraps the keyring, so that the signing account and the configured remote accounts
+// are signed with by the remote signer.
+func remoteKeyring(cfg Config, ring kr.Keyring) (kr.Keyring, error) {
+	name := cfg.KeyringAccName
+	if name == "" {
+		name = DefaultAccountName
+	}
+	names := append([]string{name}, cfg.RemoteSigner.Accounts...)
+
+	var tlsCfg *tls.Config
+	if cfg.RemoteSigner.CAFile != "" {
+		ca, err := os.ReadFile(cfg.RemoteSigner.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(ca) {
+			return nil, errors.New("module/state: no certificates in the remote signer CA file")
+		}
+		tlsCfg = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), remoteSignerTimeout)
+	defer cancel()
+	token := os.Getenv(RemoteSignerTokenEnv)
+	remote, err := remotesigner.NewKeyring(ctx, ring, cfg.RemoteSigner.Address, token, tlsCfg, names...)
+	if err != nil {
+		log.Errorw("failed to connect to remote signer", "address", cfg.RemoteSigner.Address)
+		return nil, err
+	}
+	log.Infow("signing with remote signer", "address", cfg.RemoteSigner.Address, "accounts", names)
+	return remote, nil
+}
@@ -55,6 +55,21 @@ func (mr *MockModuleMockRecorder) AccountAddress(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountAddress", reflect.TypeOf((*MockModule)(nil).AccountAddress), arg0)
 }
 
+// Accounts mocks base method.
+func (m *MockModule) Accounts(arg0 context.Context) ([]state.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accounts", arg0)
+	ret0, _ := ret[0].([]state.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accounts indicates an expected call of Accounts.
+func (mr *MockModuleMockRecorder) Accounts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accounts", reflect.TypeOf((*MockModule)(nil).Accounts), arg0)
+}
+
 // Balance mocks base method.
 func (m *MockModule) Balance(arg0 context.Context) (*types.Coin, error) {
 	m.ctrl.T.Helper()
@@ -204,6 +219,21 @@ func (mr *MockModuleMockRecorder) SubmitPayForBlob(arg0, arg1, arg2, arg3 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitPayForBlob", reflect.TypeOf((*MockModule)(nil).SubmitPayForBlob), arg0, arg1, arg2, arg3)
 }
 
+// SubmitPayForBlobFrom mocks base method.
+func (m *MockModule) SubmitPayForBlobFrom(arg0 context.Context, arg1 string, arg2 math.Int, arg3 uint64, arg4 []*blob.Blob) (*types.TxResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitPayForBlobFrom", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*types.TxResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitPayForBlobFrom indicates an expected call of SubmitPayForBlobFrom.
+func (mr *MockModuleMockRecorder) SubmitPayForBlobFrom(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitPayForBlobFrom", reflect.TypeOf((*MockModule)(nil).SubmitPayForBlobFrom), arg0, arg1, arg2, arg3, arg4)
+}
+
 // SubmitTx mocks base method.
 func (m *MockModule) SubmitTx(arg0 context.Context, arg1 types1.Tx) (*types.TxResponse, error) {
 	m.ctrl.T.Helper()
@@ -234,6 +264,21 @@ func (mr *MockModuleMockRecorder) Transfer(arg0, arg1, arg2, arg3, arg4 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockModule)(nil).Transfer), arg0, arg1, arg2, arg3, arg4)
 }
 
+// TransferFrom mocks base method.
+func (m *MockModule) TransferFrom(arg0 context.Context, arg1 string, arg2 types.AccAddress, arg3, arg4 math.Int, arg5 uint64) (*types.TxResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferFrom", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*types.TxResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferFrom indicates an expected call of TransferFrom.
+func (mr *MockModuleMockRecorder) TransferFrom(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferFrom", reflect.TypeOf((*MockModule)(nil).TransferFrom), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // Undelegate mocks base method.
 func (m *MockModule) Undelegate(arg0 context.Context, arg1 types.ValAddress, arg2, arg3 math.Int, arg4 uint64) (*types.TxResponse, error) {
 	m.ctrl.T.Helper()
@@ -22,6 +22,9 @@ type Module interface {
 
 	// AccountAddress retrieves the address of the node's account/signer
 	AccountAddress(ctx context.Context) (state.Address, error)
+	// Accounts lists the accounts in the node's keyring, which can be selected to pay for
+	// submissions.
+	Accounts(ctx context.Context) ([]state.Account, error)
 	// Balance retrieves the Celestia coin balance for the node's account/signer
 	// and verifies it against the corresponding block's AppHash.
 	Balance(ctx context.Context) (*state.Balance, error)
@@ -38,6 +41,11 @@ type Module interface {
 	Transfer(
 		ctx context.Context, to state.AccAddress, amount, fee state.Int, gasLimit uint64,
 	) (*state.TxResponse, error)
+	// TransferFrom sends the given amount of coins from the given account of the node's keyring to
+	// the given account address.
+	TransferFrom(
+		ctx context.Context, account string, to state.AccAddress, amount, fee state.Int, gasLimit uint64,
+	) (*state.TxResponse, error)
 	// SubmitTx submits the given transaction/message to the
 	// Celestia network and blocks until the tx is included in
 	// a block.
@@ -49,6 +57,15 @@ type Module interface {
 		gasLim uint64,
 		blobs []*blob.Blob,
 	) (*state.TxResponse, error)
+	// SubmitPayForBlobFrom builds, signs and submits a PayForBlob transaction paid by the given
+	// account of the node's keyring.
+	SubmitPayForBlobFrom(
+		ctx context.Context,
+		account string,
+		fee state.Int,
+		gasLim uint64,
+		blobs []*blob.Blob,
+	) (*state.TxResponse, error)
 
 	// CancelUnbondingDelegation cancels a user's pending undelegation from a validator.
 	CancelUnbondingDelegation(
@@ -100,6 +117,7 @@ type Module interface {
 type API struct {
 	Internal struct {
 		AccountAddress    func(ctx context.Context) (state.Address, error)                      `perm:"read"`
+		Accounts          func(ctx context.Context) ([]state.Account, error)                    `perm:"read"`
 		IsStopped         func(ctx context.Context) bool                                        `perm:"public"`
 		Balance           func(ctx context.Context) (*state.Balance, error)                     `perm:"read"`
 		BalanceForAddress func(ctx context.Context, addr state.Address) (*state.Balance, error) `perm:"public"`
@@ -110,6 +128,14 @@ type API struct {
 			fee state.Int,
 			gasLimit uint64,
 		) (*state.TxResponse, error) `perm:"write"`
+		TransferFrom func(
+			ctx context.Context,
+			account string,
+			to state.AccAddress,
+			amount,
+			fee state.Int,
+			gasLimit uint64,
+		) (*state.TxResponse, error) `perm:"write"`
 		SubmitTx         func(ctx context.Context, tx state.Tx) (*state.TxResponse, error) `perm:"write"`
 		SubmitPayForBlob func(
 			ctx context.Context,
@@ -117,6 +143,13 @@ type API struct {
 			gasLim uint64,
 			blobs []*blob.Blob,
 		) (*state.TxResponse, error) `perm:"write"`
+		SubmitPayForBlobFrom func(
+			ctx context.Context,
+			account string,
+			fee state.Int,
+			gasLim uint64,
+			blobs []*blob.Blob,
+		) (*state.TxResponse, error) `perm:"write"`
 		CancelUnbondingDelegation func(
 			ctx context.Context,
 			valAddr state.ValAddress,
@@ -167,6 +200,10 @@ func (api *API) AccountAddress(ctx context.Context) (state.Address, error) {
 	return api.Internal.AccountAddress(ctx)
 }
 
+func (api *API) Accounts(ctx context.Context) ([]state.Account, error) {
+	return api.Internal.Accounts(ctx)
+}
+
 func (api *API) IsStopped(ctx context.Context) bool {
 	return api.Internal.IsStopped(ctx)
 }
@@ -185,6 +222,17 @@ func (api *API) Transfer(
 	return api.Internal.Transfer(ctx, to, amount, fee, gasLimit)
 }
 
+func (api *API) TransferFrom(
+	ctx context.Context,
+	account string,
+	to state.AccAddress,
+	amount,
+	fee state.Int,
+	gasLimit uint64,
+) (*state.TxResponse, error) {
+	return api.Internal.TransferFrom(ctx, account, to, amount, fee, gasLimit)
+}
+
 func (api *API) SubmitTx(ctx context.Context, tx state.Tx) (*state.TxResponse, error) {
 	return api.Internal.SubmitTx(ctx, tx)
 }
@@ -198,6 +246,16 @@ func (api *API) SubmitPayForBlob(
 	return api.Internal.SubmitPayForBlob(ctx, fee, gasLim, blobs)
 }
 
+func (api *API) SubmitPayForBlobFrom(
+	ctx context.Context,
+	account string,
+	fee state.Int,
+	gasLim uint64,
+	blobs []*blob.Blob,
+) (*state.TxResponse, error) {
+	return api.Internal.SubmitPayForBlobFrom(ctx, account, fee, gasLim, blobs)
+}
+
 func (api *API) CancelUnbondingDelegation(
 	ctx context.Context,
 	valAddr state.ValAddress,
@@ -1,9 +1,11 @@
 package nodebuilder
 
 import (
+	"context"
 	"strconv"
 	"testing"
 
+	"github.com/ipfs/go-datastore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -50,3 +52,73 @@ func TestRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestMigrateDatastore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	err := Init(*DefaultConfig(node.Light), dir, node.Light)
+	require.NoError(t, err)
+
+	put := func(ds datastore.Batching, n int) {
+		for i := 0; i < n; i++ {
+			err := ds.Put(ctx, datastore.NewKey(strconv.Itoa(i)), []byte{byte(i)})
+			require.NoError(t, err)
+		}
+	}
+	check := func(ds datastore.Batching, n int) {
+		for i := 0; i < n; i++ {
+			val, err := ds.Get(ctx, datastore.NewKey(strconv.Itoa(i)))
+			require.NoError(t, err)
+			assert.Equal(t, []byte{byte(i)}, val)
+		}
+	}
+
+	// spans several batches
+	const entries = migrationBatchSize + 10
+	store, err := OpenStore(dir, nil)
+	require.NoError(t, err)
+	ds, err := store.Datastore()
+	require.NoError(t, err)
+	put(ds, entries)
+	require.NoError(t, store.Close())
+
+	for _, backend := range []string{PebbleBackend, LevelDBBackend} {
+		migrated, err := MigrateDatastore(ctx, dir, backend)
+		require.NoError(t, err)
+		assert.Equal(t, entries, migrated)
+
+		store, err := OpenStore(dir, nil)
+		require.NoError(t, err)
+		cfg, err := store.Config()
+		require.NoError(t, err)
+		assert.Equal(t, backend, cfg.Datastore.Backend)
+		ds, err := store.Datastore()
+		require.NoError(t, err)
+		check(ds, entries)
+		require.NoError(t, store.Close())
+	}
+
+	// previous backends keep their data, so it isn't overwritten
+	_, err = MigrateDatastore(ctx, dir, BadgerBackend)
+	require.Error(t, err)
+	_, err = MigrateDatastore(ctx, dir, "unknown")
+	require.Error(t, err)
+}
+
+// TestDatastore_ConfigWithoutSection tests that configs predating the datastore section, which
+// decode its values as zeros, still open a writable datastore.
+func TestDatastore_ConfigWithoutSection(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	cfg := DefaultConfig(node.Light)
+	cfg.Datastore = DatastoreConfig{}
+	err := Init(*cfg, dir, node.Light)
+	require.NoError(t, err)
+
+	store, err := OpenStore(dir, nil)
+	require.NoError(t, err)
+	ds, err := store.Datastore()
+	require.NoError(t, err)
+	require.NoError(t, ds.Put(ctx, datastore.NewKey("key"), []byte("value")))
+	require.NoError(t, store.Close())
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/nodebuilder/tests/swamp"
 )
 
@@ -34,6 +35,9 @@ Light node:
 7. Wait until LN has sampled height 20
 8. Wait for LN DASer to catch up to network head
 
+Outbound-only light node:
+4-8. Same as for the light node, with the LN in the outbound-only mode
+
 Full node:
 4. Create a Full Node (FN) with bridge as a trusted peer
 5. Start a FN with a defined connection to the BN
@@ -81,6 +85,25 @@ func TestSyncAgainstBridge_NonEmptyChain(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("outbound-only light sync against bridge", func(t *testing.T) {
+		// create a light node which serves nothing to its peers
+		cfg := sw.DefaultTestConfig(node.Light)
+		cfg.P2P.OutboundOnly = true
+		light := sw.NewNodeWithConfig(node.Light, cfg)
+		err = light.Start(ctx)
+		require.NoError(t, err)
+		h, err = light.HeaderServ.WaitForHeight(ctx, numBlocks)
+		require.NoError(t, err)
+		assert.EqualValues(t, h.Commit.BlockID.Hash, sw.GetCoreBlockHashByHeight(ctx, numBlocks))
+
+		// the light node still samples through its outbound connections
+		err = light.ShareServ.SharesAvailable(ctx, h.DAH)
+		assert.NoError(t, err)
+
+		err = light.DASer.WaitCatchUp(ctx)
+		require.NoError(t, err)
+	})
+
 	t.Run("full sync against bridge", func(t *testing.T) {
 		// create a full node with bridge node as its bootstrapper
 		full := sw.NewFullNode()
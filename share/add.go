@@ -15,7 +15,8 @@ import (
 )
 
 // AddShares erasures and extends shares to blockservice.BlockService using the provided
-// ipld.NodeAdder.
+// ipld.NodeAdder. Trees of rows and columns are built concurrently, adding their nodes through the
+// striped ipld.NmtNodeAdder.
 func AddShares(
 	ctx context.Context,
 	shares []Share,
@@ -0,0 +1,57 @@
+package share
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mdutils "github.com/ipfs/go-merkledag/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+func TestAddShares_AddsEveryNodeOnce(t *testing.T) {
+	const size = 16
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	bServ := mdutils.Bserv()
+
+	eds, err := AddShares(ctx, RandShares(t, size*size), bServ)
+	require.NoError(t, err)
+
+	keys, err := bServ.Blockstore().AllKeysChan(ctx)
+	require.NoError(t, err)
+	var count int
+	for range keys {
+		count++
+	}
+
+	width := int(eds.Width())
+	// leaves are shared by rows and columns, while every tree has width-1 inner nodes
+	assert.Equal(t, width*width+2*width*(width-1), count)
+
+	// all shares are retrievable from the stored trees
+	for i, root := range eds.RowRoots() {
+		for j := 0; j < width; j++ {
+			shr, err := GetShare(ctx, bServ, ipld.MustCidFromNamespacedSha256(root), j, width)
+			require.NoError(t, err)
+			assert.Equal(t, eds.GetCell(uint(i), uint(j)), shr)
+		}
+	}
+}
+
+func BenchmarkAddShares(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	b.Cleanup(cancel)
+
+	shares := RandShares(b, 128*128)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := AddShares(ctx, shares, mdutils.Bserv())
+		require.NoError(b, err)
+	}
+}
@@ -3,6 +3,7 @@ package share
 import (
 	"context"
 	"errors"
+	"time"
 
 	da "github.com/celestiaorg/celestia-app/pkg/da"
 )
@@ -23,4 +24,75 @@ type Availability interface {
 	// being available based on the number of samples collected.
 	// TODO(@Wondertan): Merge with SharesAvailable method, eventually
 	ProbabilityOfAvailability(context.Context) float64
+	// Invalidate evicts any cached positive result of SharesAvailable for the given Root, so that
+	// the next call to SharesAvailable validates availability anew. It is used when previous
+	// conclusions about the Root are proven wrong, e.g. by a fraud proof or a replaced head. Only
+	// cached results are dropped, while stored data is kept to be served to peers.
+	Invalidate(context.Context, *Root) error
+}
+
+// SampleResult describes a single share sampled by an Availability.
+type SampleResult struct {
+	Row, Col int
+	// Source is the name of the Getter the share was retrieved by. It is empty if unknown.
+	Source  string
+	Latency time.Duration
+	Err     error
+}
+
+type sampleObserverKey struct{}
+
+// WithSampleObserver returns a context making Availability implementations report every share they
+// sample to the given observer. The observer may be called concurrently.
+func WithSampleObserver(ctx context.Context, observer func(SampleResult)) context.Context {
+	return context.WithValue(ctx, sampleObserverKey{}, observer)
+}
+
+// ObserveSample reports the sampled share to the observer stored in the context, if any.
+func ObserveSample(ctx context.Context, res SampleResult) {
+	if observer, ok := ctx.Value(sampleObserverKey{}).(func(SampleResult)); ok {
+		observer(res)
+	}
+}
+
+type confidenceObserverKey struct{}
+
+// WithConfidenceObserver returns a context making Availability implementations report the effective
+// confidence of the Root being available, achieved by SharesAvailable, to the given observer.
+func WithConfidenceObserver(ctx context.Context, observer func(float64)) context.Context {
+	return context.WithValue(ctx, confidenceObserverKey{}, observer)
+}
+
+// ObserveConfidence reports the achieved confidence to the observer stored in the context, if any.
+func ObserveConfidence(ctx context.Context, confidence float64) {
+	if observer, ok := ctx.Value(confidenceObserverKey{}).(func(float64)); ok {
+		observer(confidence)
+	}
+}
+
+type heightKey struct{}
+
+// WithHeight returns a context telling Availability the height of the header the Root belongs to.
+func WithHeight(ctx context.Context, height uint64) context.Context {
+	return context.WithValue(ctx, heightKey{}, height)
+}
+
+// HeightFromContext returns the height of the header set with WithHeight, if any.
+func HeightFromContext(ctx context.Context) (uint64, bool) {
+	height, ok := ctx.Value(heightKey{}).(uint64)
+	return height, ok
+}
+
+type recheckKey struct{}
+
+// WithRecheck returns a context making Availability implementations validate availability of the
+// Root anew, bypassing cached results without evicting them.
+func WithRecheck(ctx context.Context) context.Context {
+	return context.WithValue(ctx, recheckKey{}, true)
+}
+
+// RecheckFromContext reports whether availability must be validated anew, as set with WithRecheck.
+func RecheckFromContext(ctx context.Context) bool {
+	recheck, _ := ctx.Value(recheckKey{}).(bool)
+	return recheck
 }
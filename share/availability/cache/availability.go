@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"sync"
+	"time"
 
 	"github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/autobatch"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/celestiaorg/celestia-app/pkg/da"
 
+	libcache "github.com/celestiaorg/celestia-node/libs/cache"
 	"github.com/celestiaorg/celestia-node/share"
 )
 
@@ -21,18 +23,33 @@ var (
 
 	cacheAvailabilityPrefix = datastore.NewKey("sampling_result")
 	writeBatchSize          = 2048
+	// resultsCacheSize is the amount of the latest sampling results kept in memory
+	resultsCacheSize = 4096
+	// flushInterval is the period of flushing queued writes to disk, so that sampling results
+	// survive a crash
+	flushInterval = time.Minute
 )
 
 // ShareAvailability wraps a given share.Availability (whether it's light or full)
 // and stores the results of a successful sampling routine over a given Root's hash
 // to disk.
+//
+// Results are keyed by the DataHash rather than the height, so that the same data square is
+// sampled only once, even if it is committed to by multiple headers, e.g. after a re-org. Sampling
+// of the same DataHash by concurrent callers is done once as well.
 type ShareAvailability struct {
 	avail share.Availability
 
+	// results keeps the latest sampling results in memory and deduplicates sampling in progress
+	results *libcache.Cache[string, struct{}]
+
 	// TODO(@Wondertan): Once we come to parallelized DASer, this lock becomes a contention point
 	//  Related to #483
 	dsLk sync.RWMutex
 	ds   *autobatch.Datastore
+
+	cancel context.CancelFunc
+	done   chan struct{}
 }
 
 // NewShareAvailability wraps the given share.Availability with an additional datastore
@@ -45,17 +62,44 @@ func NewShareAvailability(
 	autoDS := autobatch.NewAutoBatching(ds, writeBatchSize)
 
 	return &ShareAvailability{
-		avail: avail,
-		ds:    autoDS,
+		avail:   avail,
+		results: libcache.New[string, struct{}](resultsCacheSize),
+		ds:      autoDS,
 	}
 }
 
-// SharesAvailable will store, upon success, the hash of the given Root to disk.
+// Start starts periodic flushing of queued writes to disk.
+func (ca *ShareAvailability) Start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	ca.cancel = cancel
+	ca.done = make(chan struct{})
+	go ca.flushLoop(ctx)
+	return nil
+}
+
+// WithMetrics turns on metric collection of the sampling results cache.
+func (ca *ShareAvailability) WithMetrics() error {
+	return ca.results.WithMetrics("sampling_results")
+}
+
+// SharesAvailable will store, upon success, the hash of the given Root to disk. Cached results are
+// bypassed, but kept, if the context is created with share.WithRecheck.
 func (ca *ShareAvailability) SharesAvailable(ctx context.Context, root *share.Root) error {
 	// short-circuit if the given root is minimum DAH of an empty data square
 	if isMinRoot(root) {
 		return nil
 	}
+	if share.RecheckFromContext(ctx) {
+		return ca.avail.SharesAvailable(ctx, root)
+	}
+
+	_, err := ca.results.GetOrLoad(ctx, root.String(), func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, ca.sharesAvailable(ctx, root)
+	})
+	return err
+}
+
+func (ca *ShareAvailability) sharesAvailable(ctx context.Context, root *share.Root) error {
 	// do not sample over Root that has already been sampled
 	key := rootKey(root)
 
@@ -84,11 +128,57 @@ func (ca *ShareAvailability) ProbabilityOfAvailability(ctx context.Context) floa
 	return ca.avail.ProbabilityOfAvailability(ctx)
 }
 
-// Close flushes all queued writes to disk.
+// Invalidate removes the cached sampling result of the given Root, so that it is sampled again on
+// the next SharesAvailable call, and invalidates the wrapped Availability.
+func (ca *ShareAvailability) Invalidate(ctx context.Context, root *share.Root) error {
+	ca.results.Remove(root.String())
+
+	ca.dsLk.Lock()
+	err := ca.ds.Delete(ctx, rootKey(root))
+	ca.dsLk.Unlock()
+	if err != nil {
+		return err
+	}
+	return ca.avail.Invalidate(ctx, root)
+}
+
+// Close stops periodic flushing and flushes all queued writes to disk.
 func (ca *ShareAvailability) Close(ctx context.Context) error {
+	if ca.cancel != nil {
+		ca.cancel()
+		select {
+		case <-ca.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return ca.flush(ctx)
+}
+
+func (ca *ShareAvailability) flushLoop(ctx context.Context) {
+	defer close(ca.done)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := ca.flush(ctx); err != nil && ctx.Err() == nil {
+				log.Errorw("flushing sampling results to disk", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (ca *ShareAvailability) flush(ctx context.Context) error {
+	ca.dsLk.Lock()
+	defer ca.dsLk.Unlock()
 	return ca.ds.Flush(ctx)
 }
 
+// rootKey keys sampling results by the DataHash of the Root.
 func rootKey(root *share.Root) datastore.Key {
 	return datastore.NewKey(root.String())
 }
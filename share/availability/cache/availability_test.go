@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -103,6 +104,100 @@ func TestCacheAvailability_NoDuplicateSampling(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestCacheAvailability_ConcurrentSampling tests to ensure that concurrent
+// requests for the same Root run a single sampling routine.
+func TestCacheAvailability_ConcurrentSampling(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	root := availability_test.RandFillBS(t, 16, mdutils.Bserv())
+	avail := &blockingAvailability{release: make(chan struct{})}
+	ca := NewShareAvailability(avail, sync.MutexWrap(datastore.NewMapDatastore()))
+
+	const callers = 10
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			errs <- ca.SharesAvailable(ctx, root)
+		}()
+	}
+
+	// let all callers join the sampling in progress
+	time.Sleep(time.Millisecond * 100)
+	close(avail.release)
+	for i := 0; i < callers; i++ {
+		require.NoError(t, <-errs)
+	}
+	assert.EqualValues(t, 1, avail.calls.Load())
+}
+
+// TestCacheAvailability_Restart tests to ensure that sampling results
+// survive a restart.
+func TestCacheAvailability_Restart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	root := availability_test.RandFillBS(t, 16, mdutils.Bserv())
+	ds := sync.MutexWrap(datastore.NewMapDatastore())
+	ca := NewShareAvailability(&dummyAvailability{}, ds)
+	require.NoError(t, ca.Start(ctx))
+	require.NoError(t, ca.SharesAvailable(ctx, root))
+	require.NoError(t, ca.Close(ctx))
+
+	// any sampling routine after the restart is reported as an error
+	ca = NewShareAvailability(&dummyAvailability{counter: 1}, ds)
+	require.NoError(t, ca.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, ca.Close(context.Background()))
+	})
+	require.NoError(t, ca.SharesAvailable(ctx, root))
+}
+
+// TestCacheAvailability_Invalidate tests to ensure that an invalidated Root
+// is sampled again.
+func TestCacheAvailability_Invalidate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// create root to cache
+	root := availability_test.RandFillBS(t, 16, mdutils.Bserv())
+	// wrap dummyAvailability with a datastore
+	ds := sync.MutexWrap(datastore.NewMapDatastore())
+	ca := NewShareAvailability(&dummyAvailability{counter: 0}, ds)
+	// sample the root
+	err := ca.SharesAvailable(ctx, root)
+	require.NoError(t, err)
+
+	err = ca.Invalidate(ctx, root)
+	require.NoError(t, err)
+	// ensure cached result was evicted
+	exists, err := ca.ds.Has(ctx, rootKey(root))
+	require.NoError(t, err)
+	assert.False(t, exists)
+	// sampling routine over the same root runs again, which dummyAvailability reports as an error
+	err = ca.SharesAvailable(ctx, root)
+	require.Error(t, err)
+}
+
+// TestCacheAvailability_Recheck tests to ensure that a recheck samples the Root
+// again, but keeps the cached result.
+func TestCacheAvailability_Recheck(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	root := availability_test.RandFillBS(t, 16, mdutils.Bserv())
+	ds := sync.MutexWrap(datastore.NewMapDatastore())
+	ca := NewShareAvailability(&dummyAvailability{counter: 0}, ds)
+	require.NoError(t, ca.SharesAvailable(ctx, root))
+
+	// sampling routine over the same root runs again, which dummyAvailability reports as an error
+	require.Error(t, ca.SharesAvailable(share.WithRecheck(ctx), root))
+	// ensure cached result was kept
+	exists, err := ca.ds.Has(ctx, rootKey(root))
+	require.NoError(t, err)
+	assert.True(t, exists)
+	require.NoError(t, ca.SharesAvailable(ctx, root))
+}
+
 // TestCacheAvailability_MinRoot tests to make sure `SharesAvailable` will
 // short circuit if the given root is a minimum DataAvailabilityHeader (minRoot).
 func TestCacheAvailability_MinRoot(t *testing.T) {
@@ -136,3 +231,23 @@ func (da *dummyAvailability) SharesAvailable(_ context.Context, root *share.Root
 func (da *dummyAvailability) ProbabilityOfAvailability(context.Context) float64 {
 	return 0
 }
+
+func (da *dummyAvailability) Invalidate(context.Context, *share.Root) error {
+	return nil
+}
+
+type blockingAvailability struct {
+	dummyAvailability
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (ba *blockingAvailability) SharesAvailable(ctx context.Context, _ *share.Root) error {
+	ba.calls.Add(1)
+	select {
+	case <-ba.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -66,6 +66,7 @@ func (fa *ShareAvailability) SharesAvailable(ctx context.Context, root *share.Ro
 	// a hack to avoid loading the whole EDS in mem if we store it already.
 	if fa.store != nil {
 		if ok, _ := fa.store.Has(ctx, root.Hash()); ok {
+			share.ObserveConfidence(ctx, 1)
 			return nil
 		}
 	}
@@ -80,9 +81,17 @@ func (fa *ShareAvailability) SharesAvailable(ctx context.Context, root *share.Ro
 
 		return err
 	}
-	return err
+	share.ObserveConfidence(ctx, 1)
+	return nil
 }
 
 func (fa *ShareAvailability) ProbabilityOfAvailability(context.Context) float64 {
 	return 1
 }
+
+// Invalidate is a no-op, as full ShareAvailability does not cache sampling results. Stored EDSes
+// are never removed, as they are verified against their roots and still serve peers, e.g. to prove
+// a block is badly encoded.
+func (fa *ShareAvailability) Invalidate(context.Context, *share.Root) error {
+	return nil
+}
@@ -4,9 +4,16 @@ import (
 	"context"
 	"testing"
 
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/celestiaorg/celestia-app/pkg/da"
+
+	"github.com/celestiaorg/celestia-node/share"
 	availability_test "github.com/celestiaorg/celestia-node/share/availability/test"
+	"github.com/celestiaorg/celestia-node/share/eds"
 )
 
 func TestShareAvailableOverMocknet_Full(t *testing.T) {
@@ -32,3 +39,28 @@ func TestSharesAvailable_Full(t *testing.T) {
 	err := avail.SharesAvailable(ctx, dah)
 	assert.NoError(t, err)
 }
+
+// TestInvalidate_KeepsStoredEDS ensures invalidation, e.g. of a replaced head, keeps the stored EDS,
+// as it is still served to peers.
+func TestInvalidate_KeepsStoredEDS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store, err := eds.NewStore(t.TempDir(), ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	require.NoError(t, store.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop(ctx))
+	})
+
+	square := share.RandEDS(t, 4)
+	dah := da.NewDataAvailabilityHeader(square)
+	require.NoError(t, store.Put(ctx, dah.Hash(), square))
+
+	avail := NewShareAvailability(store, nil, nil)
+	require.NoError(t, avail.Invalidate(ctx, &dah))
+
+	has, err := store.Has(ctx, dah.Hash())
+	require.NoError(t, err)
+	assert.True(t, has)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"time"
 
 	ipldFormat "github.com/ipfs/go-ipld-format"
 	logging "github.com/ipfs/go-log/v2"
@@ -21,6 +22,8 @@ var log = logging.Logger("share/light")
 type ShareAvailability struct {
 	getter share.Getter
 	params Parameters
+	// coordinator partitions the samples with co-located nodes, if set.
+	coordinator *Coordinator
 }
 
 // NewShareAvailability creates a new light Availability.
@@ -34,11 +37,18 @@ func NewShareAvailability(
 		opt(&params)
 	}
 
-	return &ShareAvailability{getter, params}
+	return &ShareAvailability{getter: getter, params: params}
+}
+
+// WithCoordinator makes the ShareAvailability partition its samples with the light nodes
+// coordinating through the given Coordinator.
+func (la *ShareAvailability) WithCoordinator(c *Coordinator) {
+	la.coordinator = c
 }
 
 // SharesAvailable randomly samples `params.SampleAmount` amount of Shares committed to the given
-// Root. This way SharesAvailable subjectively verifies that Shares are available.
+// Root, or the amount reaching `params.TargetConfidence` for the Root's square size, if set. This
+// way SharesAvailable subjectively verifies that Shares are available.
 func (la *ShareAvailability) SharesAvailable(ctx context.Context, dah *share.Root) error {
 	log.Debugw("Validate availability", "root", dah.String())
 	// We assume the caller of this method has already performed basic validation on the
@@ -48,10 +58,14 @@ func (la *ShareAvailability) SharesAvailable(ctx context.Context, dah *share.Roo
 			"err", err)
 		panic(err)
 	}
-	samples, err := SampleSquare(len(dah.RowRoots), int(la.params.SampleAmount))
+	squareWidth := len(dah.RowRoots)
+	samples, err := SampleSquare(squareWidth, la.sampleAmount(squareWidth))
 	if err != nil {
 		return err
 	}
+	if la.coordinator != nil {
+		samples = la.coordinator.Partition(ctx, dah.Hash(), squareWidth, samples)
+	}
 
 	// indicate to the share.Getter that a blockservice session should be created. This
 	// functionality is optional and must be supported by the used share.Getter.
@@ -62,10 +76,19 @@ func (la *ShareAvailability) SharesAvailable(ctx context.Context, dah *share.Roo
 	for _, s := range samples {
 		go func(s Sample) {
 			log.Debugw("fetching share", "root", dah.String(), "row", s.Row, "col", s.Col)
-			_, err := la.getter.GetShare(ctx, dah, s.Row, s.Col)
+			start := time.Now()
+			getCtx := getters.WithSourceRecorder(ctx)
+			_, err := la.getter.GetShare(getCtx, dah, s.Row, s.Col)
 			if err != nil {
 				log.Debugw("error fetching share", "root", dah.String(), "row", s.Row, "col", s.Col)
 			}
+			share.ObserveSample(ctx, share.SampleResult{
+				Row:     s.Row,
+				Col:     s.Col,
+				Source:  getters.Source(getCtx),
+				Latency: time.Since(start),
+				Err:     err,
+			})
 			// we don't really care about Share bodies at this point
 			// it also means we now saved the Share in local storage
 			select {
@@ -95,14 +118,31 @@ func (la *ShareAvailability) SharesAvailable(ctx context.Context, dah *share.Roo
 		}
 	}
 
+	share.ObserveConfidence(ctx, share.SamplingConfidence(squareWidth/2, len(samples)))
+	return nil
+}
+
+// sampleAmount returns the amount of shares to sample from the square of the given width.
+func (la *ShareAvailability) sampleAmount(squareWidth int) int {
+	if la.params.TargetConfidence > 0 {
+		return share.SamplesForConfidence(squareWidth/2, la.params.TargetConfidence)
+	}
+	return int(la.params.SampleAmount)
+}
+
+// Invalidate is a no-op, as light ShareAvailability does not cache sampling results.
+func (la *ShareAvailability) Invalidate(context.Context, *share.Root) error {
 	return nil
 }
 
 // ProbabilityOfAvailability calculates the probability that the
 // data square is available based on the amount of samples collected
-// (params.SampleAmount).
+// (params.SampleAmount), or returns params.TargetConfidence, if set.
 //
 // Formula: 1 - (0.75 ** amount of samples)
 func (la *ShareAvailability) ProbabilityOfAvailability(context.Context) float64 {
+	if la.params.TargetConfidence > 0 {
+		return la.params.TargetConfidence
+	}
 	return 1 - math.Pow(0.75, float64(la.params.SampleAmount))
 }
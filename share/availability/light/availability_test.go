@@ -94,7 +94,7 @@ func TestService_GetSharesByNamespace(t *testing.T) {
 			shares, err := getter.GetSharesByNamespace(context.Background(), root, randNID)
 			require.NoError(t, err)
 			require.NoError(t, shares.Verify(root, randNID))
-			flattened := shares.Flatten()
+			flattened, _ := shares.Flatten()
 			assert.Len(t, flattened, tt.expectedShareCount)
 			for _, value := range flattened {
 				assert.Equal(t, randNID, []byte(share.ID(value)))
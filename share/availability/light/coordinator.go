@@ -0,0 +1,303 @@
+package light
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	// coordinationTimeout is the time the coordinating node has to partition the samples.
+	coordinationTimeout = time.Second
+	// maxCoordinatedRoots is the amount of recent roots the claimed samples are kept for.
+	maxCoordinatedRoots = 64
+	// replacementAttempts is the amount of random samples tried to replace a claimed one.
+	replacementAttempts = 32
+)
+
+// Coordinator partitions samples among light nodes running on the same host, so that they don't
+// sample identical shares of the same square. Every node samples the same amount of unique shares
+// as without coordination, so its own confidence is kept, while samples claimed by other nodes are
+// replaced with unclaimed ones, increasing the aggregate coverage of the square.
+//
+// Nodes coordinate over a Unix socket, which is served by the first of them, and taken over by
+// another one, if the serving node stops. Samples are not coordinated, if the socket is unreachable.
+//
+// NOTE: The serving node chooses the samples of the others, so all the nodes sharing the socket
+// must trust each other.
+type Coordinator struct {
+	path string
+
+	lk sync.Mutex
+	// claims are served over the listener, if the node serves the socket.
+	ln     net.Listener
+	claims *sampleClaims
+	// cancel closes the connections of the other nodes to the socket.
+	cancel context.CancelFunc
+	// conn is the connection to the node serving the socket, otherwise.
+	conn *coordinationConn
+}
+
+// coordinationConn is a connection to the node serving the socket. Requests over it are serialized
+// by its own lock, so that the Coordinator isn't locked during IO.
+type coordinationConn struct {
+	lk   sync.Mutex
+	conn net.Conn
+	enc  *json.Encoder
+	dec  *json.Decoder
+}
+
+// coordinationRequest is the request to partition the samples of the square of the root.
+type coordinationRequest struct {
+	Root    string   `json:"root"`
+	Width   int      `json:"width"`
+	Samples []Sample `json:"samples"`
+}
+
+type coordinationResponse struct {
+	Samples []Sample `json:"samples"`
+}
+
+// NewCoordinator creates a new Coordinator over the Unix socket at the given path.
+func NewCoordinator(path string) *Coordinator {
+	return &Coordinator{path: path}
+}
+
+func (c *Coordinator) Start(context.Context) error {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	return c.connect()
+}
+
+func (c *Coordinator) Stop(context.Context) error {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	c.disconnect()
+	if c.ln != nil {
+		c.cancel()
+		err := c.ln.Close()
+		c.ln, c.claims, c.cancel = nil, nil, nil
+		return err
+	}
+	return nil
+}
+
+// Partition returns the samples to take from the square of the given root and width, with the ones
+// claimed by other nodes replaced. The given samples are returned, if coordination fails.
+func (c *Coordinator) Partition(ctx context.Context, root []byte, width int, samples []Sample) []Sample {
+	req := &coordinationRequest{Root: hex.EncodeToString(root), Width: width, Samples: samples}
+
+	c.lk.Lock()
+	if c.claims == nil && c.conn == nil {
+		// the serving node may have stopped, so take over the socket, if needed
+		if err := c.connect(); err != nil {
+			c.lk.Unlock()
+			log.Debugw("sampling uncoordinated", "socket", c.path, "err", err)
+			return samples
+		}
+	}
+	claims, conn := c.claims, c.conn
+	c.lk.Unlock()
+
+	if claims != nil {
+		return claims.partition(req)
+	}
+	resp, err := conn.request(ctx, req)
+	if err != nil {
+		log.Warnw("sampling uncoordinated", "socket", c.path, "err", err)
+		c.lk.Lock()
+		if c.conn == conn {
+			c.disconnect()
+		}
+		c.lk.Unlock()
+		return samples
+	}
+	return resp
+}
+
+func (cc *coordinationConn) request(ctx context.Context, req *coordinationRequest) ([]Sample, error) {
+	cc.lk.Lock()
+	defer cc.lk.Unlock()
+
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > coordinationTimeout {
+		deadline = time.Now().Add(coordinationTimeout)
+	}
+	if err := cc.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	if err := cc.enc.Encode(req); err != nil {
+		return nil, err
+	}
+	var resp coordinationResponse
+	if err := cc.dec.Decode(&resp); err != nil {
+		return nil, err
+	}
+	if err := validatePartition(req, resp.Samples); err != nil {
+		return nil, err
+	}
+	return resp.Samples, nil
+}
+
+// connect connects to the node serving the socket, or serves the socket, if no node does. It must
+// be called with the lock held.
+func (c *Coordinator) connect() error {
+	conn, err := net.Dial("unix", c.path)
+	if err == nil {
+		c.conn = &coordinationConn{conn: conn, enc: json.NewEncoder(conn), dec: json.NewDecoder(conn)}
+		return nil
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		// the socket was left by a node which didn't stop gracefully
+		if err = os.Remove(c.path); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	ln, err := net.Listen("unix", c.path)
+	if err != nil {
+		return fmt.Errorf("serving coordination socket: %w", err)
+	}
+	// the serving node chooses the samples of the others, so only the user running it may connect
+	if err = os.Chmod(c.path, 0o600); err != nil {
+		_ = ln.Close()
+		return fmt.Errorf("restricting coordination socket: %w", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c.ln, c.claims, c.cancel = ln, newSampleClaims(), cancel
+	go serve(ctx, ln, c.claims)
+	log.Infow("serving sampling coordination", "socket", c.path)
+	return nil
+}
+
+// disconnect closes the connection to the node serving the socket. It must be called with the
+// lock held.
+func (c *Coordinator) disconnect() {
+	if c.conn != nil {
+		_ = c.conn.conn.Close()
+		c.conn = nil
+	}
+}
+
+// serve partitions the samples of the nodes connecting to the socket, until the context is
+// canceled.
+func serve(ctx context.Context, ln net.Listener, claims *sampleClaims) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			go func() {
+				<-ctx.Done()
+				_ = conn.Close()
+			}()
+
+			enc, dec := json.NewEncoder(conn), json.NewDecoder(conn)
+			for {
+				var req coordinationRequest
+				if err := dec.Decode(&req); err != nil {
+					return
+				}
+				if req.Width <= 0 {
+					return
+				}
+				if err := enc.Encode(&coordinationResponse{Samples: claims.partition(&req)}); err != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+// validatePartition checks that the partitioned samples are as many unique samples of the square as
+// requested.
+func validatePartition(req *coordinationRequest, samples []Sample) error {
+	if len(samples) != len(req.Samples) {
+		return fmt.Errorf("requested %d samples, got %d", len(req.Samples), len(samples))
+	}
+	seen := make(map[Sample]struct{}, len(samples))
+	for _, s := range samples {
+		if s.Row < 0 || s.Row >= req.Width || s.Col < 0 || s.Col >= req.Width {
+			return fmt.Errorf("sample %v out of square of width %d", s, req.Width)
+		}
+		if _, ok := seen[s]; ok {
+			return fmt.Errorf("duplicate sample %v", s)
+		}
+		seen[s] = struct{}{}
+	}
+	return nil
+}
+
+// sampleClaims tracks the samples claimed by the nodes for the recent roots.
+type sampleClaims struct {
+	lk     sync.Mutex
+	claims map[string]map[Sample]struct{}
+	// roots are ordered from the oldest, to evict the claims of old roots.
+	roots []string
+}
+
+func newSampleClaims() *sampleClaims {
+	return &sampleClaims{claims: make(map[string]map[Sample]struct{})}
+}
+
+// partition claims the requested samples, replacing the ones already claimed with random unclaimed
+// ones. Claimed samples are kept, if no unclaimed one is found.
+func (sc *sampleClaims) partition(req *coordinationRequest) []Sample {
+	sc.lk.Lock()
+	defer sc.lk.Unlock()
+	claimed, ok := sc.claims[req.Root]
+	if !ok {
+		claimed = make(map[Sample]struct{})
+		sc.claims[req.Root] = claimed
+		sc.roots = append(sc.roots, req.Root)
+		if len(sc.roots) > maxCoordinatedRoots {
+			delete(sc.claims, sc.roots[0])
+			sc.roots = sc.roots[1:]
+		}
+	}
+
+	samples := make([]Sample, len(req.Samples))
+	var duplicates []int
+	for i, s := range req.Samples {
+		if _, ok := claimed[s]; ok {
+			duplicates = append(duplicates, i)
+			continue
+		}
+		claimed[s] = struct{}{}
+		samples[i] = s
+	}
+
+	requested := make(map[Sample]struct{}, len(req.Samples))
+	for _, s := range req.Samples {
+		requested[s] = struct{}{}
+	}
+	for _, i := range duplicates {
+		samples[i] = req.Samples[i]
+		if len(claimed) >= req.Width*req.Width {
+			continue
+		}
+		for attempt := 0; attempt < replacementAttempts; attempt++ {
+			s := Sample{Row: randInt(req.Width), Col: randInt(req.Width)}
+			_, isClaimed := claimed[s]
+			_, isRequested := requested[s]
+			if !isClaimed && !isRequested {
+				claimed[s] = struct{}{}
+				samples[i] = s
+				break
+			}
+		}
+	}
+	return samples
+}
@@ -0,0 +1,67 @@
+package light
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoordinator(t *testing.T) {
+	ctx := context.Background()
+	// socket paths are limited in length, so the temporary directory of the test may not fit
+	dir, err := os.MkdirTemp("", "coord")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "sampling.sock")
+
+	serving, joining := NewCoordinator(path), NewCoordinator(path)
+	require.NoError(t, serving.Start(ctx))
+	require.NoError(t, joining.Start(ctx))
+	t.Cleanup(func() {
+		_ = joining.Stop(ctx)
+	})
+	require.NotNil(t, serving.claims)
+	require.Nil(t, joining.claims)
+	// only the user running the nodes may connect to the socket
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	root, width := []byte("root"), 8
+	samples, err := SampleSquare(width, 16)
+	require.NoError(t, err)
+
+	// the first node takes its samples, and the second gets unclaimed ones instead
+	first := serving.Partition(ctx, root, width, samples)
+	assert.ElementsMatch(t, samples, first)
+	second := joining.Partition(ctx, root, width, samples)
+	require.NoError(t, validatePartition(&coordinationRequest{Width: width, Samples: samples}, second))
+	for _, s := range second {
+		assert.NotContains(t, first, s)
+	}
+
+	// other roots are not affected
+	assert.ElementsMatch(t, samples, joining.Partition(ctx, []byte("other"), width, samples))
+
+	// the socket is taken over once the serving node stops
+	require.NoError(t, serving.Stop(ctx))
+	assert.ElementsMatch(t, samples, joining.Partition(ctx, root, width, samples))
+	assert.ElementsMatch(t, samples, joining.Partition(ctx, root, width, samples))
+	assert.NotNil(t, joining.claims)
+}
+
+func TestSampleClaims_FullSquare(t *testing.T) {
+	claims := newSampleClaims()
+	width := 2
+	samples, err := SampleSquare(width, width*width)
+	require.NoError(t, err)
+
+	req := &coordinationRequest{Root: "root", Width: width, Samples: samples}
+	assert.ElementsMatch(t, samples, claims.partition(req))
+	// every sample is claimed, so the claimed ones are kept
+	assert.ElementsMatch(t, samples, claims.partition(req))
+}
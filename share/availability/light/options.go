@@ -14,6 +14,14 @@ var (
 // availability implementation
 type Parameters struct {
 	SampleAmount uint // The minimum required amount of samples to perform
+	// TargetConfidence is the confidence of a block being available, e.g. 0.999999, the amount of
+	// samples is derived from for every block according to its square size. It overrides
+	// SampleAmount. Zero disables it, though light nodes of networks opting in sample for the target
+	// confidence of their network, if neither it nor SampleAmount is configured.
+	TargetConfidence float64
+	// CoordinationSocket is the path of the Unix socket light nodes running on the same host
+	// partition their samples over, so that they don't sample identical shares. Empty disables it.
+	CoordinationSocket string
 }
 
 // Option is a function that configures light availability Parameters
@@ -38,6 +46,15 @@ func (p *Parameters) Validate() error {
 		)
 	}
 
+	if p.TargetConfidence < 0 || p.TargetConfidence >= 1 {
+		return fmt.Errorf(
+			"light availability: invalid option: value %s was %v, where it should be %s",
+			"TargetConfidence",
+			p.TargetConfidence, // current value
+			"in [0, 1)",        // what the value should be
+		)
+	}
+
 	return nil
 }
 
@@ -48,3 +65,11 @@ func WithSampleAmount(sampleAmount uint) Option {
 		p.SampleAmount = sampleAmount
 	}
 }
+
+// WithTargetConfidence is a functional option that the Availability interface
+// implementers use to set the TargetConfidence configuration param
+func WithTargetConfidence(confidence float64) Option {
+	return func(p *Parameters) {
+		p.TargetConfidence = confidence
+	}
+}
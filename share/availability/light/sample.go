@@ -37,7 +37,7 @@ func newSquareSampler(squareWidth int, expectedSamples int) *squareSampler {
 // generateSample randomly picks unique point on a 2D spaces.
 func (ss *squareSampler) generateSample(num int) error {
 	if num > ss.squareWidth*ss.squareWidth {
-		num = ss.squareWidth
+		num = ss.squareWidth * ss.squareWidth
 	}
 
 	done := 0
@@ -36,6 +36,20 @@ func (m *MockAvailability) EXPECT() *MockAvailabilityMockRecorder {
 	return m.recorder
 }
 
+// Invalidate mocks base method.
+func (m *MockAvailability) Invalidate(arg0 context.Context, arg1 *da.DataAvailabilityHeader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invalidate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockAvailabilityMockRecorder) Invalidate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockAvailability)(nil).Invalidate), arg0, arg1)
+}
+
 // ProbabilityOfAvailability mocks base method.
 func (m *MockAvailability) ProbabilityOfAvailability(arg0 context.Context) float64 {
 	m.ctrl.T.Helper()
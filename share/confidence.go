@@ -0,0 +1,45 @@
+package share
+
+// SamplingConfidence returns the probability of detecting an unavailable block by sampling the
+// given amount of unique shares from its extended square, for the original square of the given
+// size.
+//
+// The block is considered unavailable, if it can't be reconstructed, which requires at least
+// (k+1)^2 shares of the (2k)^2 extended square to be withheld. The confidence is then the
+// probability of hitting at least one withheld share:
+//
+//	1 - C(N-W, samples) / C(N, samples), where N = (2k)^2 and W = (k+1)^2
+func SamplingConfidence(squareSize, samples int) float64 {
+	total, withheld := extendedSquare(squareSize)
+	if samples > total-withheld {
+		return 1
+	}
+
+	missed := 1.0
+	for i := 0; i < samples; i++ {
+		missed *= float64(total-withheld-i) / float64(total-i)
+	}
+	return 1 - missed
+}
+
+// SamplesForConfidence returns the minimal amount of unique shares to sample from the extended
+// square, for the original square of the given size, to reach the target confidence of the block
+// being available.
+func SamplesForConfidence(squareSize int, confidence float64) int {
+	total, withheld := extendedSquare(squareSize)
+	missed := 1.0
+	for samples := 0; samples <= total-withheld; samples++ {
+		if 1-missed >= confidence {
+			return samples
+		}
+		missed *= float64(total-withheld-samples) / float64(total-samples)
+	}
+	// sampling one share more than could ever be available always detects unavailability
+	return total - withheld + 1
+}
+
+// extendedSquare returns the amount of shares in the extended square for the original square of
+// the given size, and the minimal amount of them withheld to make it unrecoverable.
+func extendedSquare(squareSize int) (total, withheld int) {
+	return 4 * squareSize * squareSize, (squareSize + 1) * (squareSize + 1)
+}
@@ -0,0 +1,37 @@
+package share
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamplingConfidence(t *testing.T) {
+	// a single sample hits one of the (k+1)^2 = 9 withheld shares of the (2k)^2 = 16 ones with the
+	// probability of 9/16, and two samples miss them with the probability of 7/16 * 6/15
+	assert.InDelta(t, 9.0/16, SamplingConfidence(2, 1), 1e-9)
+	assert.InDelta(t, 1-7.0/16*6.0/15, SamplingConfidence(2, 2), 1e-9)
+	// sampling more shares than can be available always detects unavailability
+	assert.Equal(t, 1.0, SamplingConfidence(2, 8))
+	assert.Equal(t, 0.0, SamplingConfidence(2, 0))
+
+	// the larger the square, the closer it gets to sampling with replacement with the probability of
+	// about 1/4 to hit a withheld share
+	assert.InDelta(t, 1-math.Pow(0.75, 16), SamplingConfidence(256, 16), 1e-3)
+}
+
+func TestSamplesForConfidence(t *testing.T) {
+	for _, size := range []int{1, 2, 8, 64, 256} {
+		for _, target := range []float64{0.5, 0.99, 0.999999} {
+			amount := SamplesForConfidence(size, target)
+			assert.GreaterOrEqual(t, SamplingConfidence(size, amount), target)
+			if amount > 0 {
+				assert.Less(t, SamplingConfidence(size, amount-1), target)
+			}
+		}
+	}
+
+	// sampling with replacement with the probability of 3/4 to miss takes 49 samples for 99.9999%
+	assert.LessOrEqual(t, SamplesForConfidence(256, 0.999999), 49)
+}
@@ -0,0 +1,95 @@
+package share
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// DeadlineProfile is the default retrieval timeout of squares up to a size.
+type DeadlineProfile struct {
+	// MaxSquareSize is the largest width of the original data square the profile applies to. Zero
+	// applies the profile to all squares bigger than the ones of the other profiles.
+	MaxSquareSize int
+	// Timeout is the time given to a single peer to serve a retrieval of the square.
+	Timeout time.Duration
+}
+
+// DeadlineProfiles maps ranges of square sizes onto their default retrieval timeouts, so that big
+// squares are given the time they take to be served, while retrievals of small squares fail fast.
+type DeadlineProfiles []DeadlineProfile
+
+// DefaultDeadlineProfiles returns the default retrieval timeouts by square size.
+func DefaultDeadlineProfiles() DeadlineProfiles {
+	return DeadlineProfiles{
+		{MaxSquareSize: 16, Timeout: 10 * time.Second},
+		{MaxSquareSize: 64, Timeout: 30 * time.Second},
+		// based on the time taken by healthy peers to serve EDSes of blocks of size 128
+		{MaxSquareSize: 128, Timeout: time.Minute},
+		{MaxSquareSize: 0, Timeout: 2 * time.Minute},
+	}
+}
+
+// Timeout returns the retrieval timeout of squares of the given width. Squares bigger than the ones
+// of all profiles get the timeout of the last profile.
+func (p DeadlineProfiles) Timeout(squareSize int) time.Duration {
+	var timeout time.Duration
+	for _, profile := range p {
+		timeout = profile.Timeout
+		if profile.MaxSquareSize == 0 || squareSize <= profile.MaxSquareSize {
+			break
+		}
+	}
+	return timeout
+}
+
+// TimeoutFor returns the retrieval timeout of the square committed to by the given root.
+func (p DeadlineProfiles) TimeoutFor(root *Root) time.Duration {
+	return p.Timeout(len(root.RowRoots) / 2)
+}
+
+// TimeoutFromContext returns the retrieval timeout of the square of the size set with
+// WithSquareSize. Squares of unknown size get the timeout of the last profile.
+func (p DeadlineProfiles) TimeoutFromContext(ctx context.Context) time.Duration {
+	if size, ok := SquareSizeFromContext(ctx); ok {
+		return p.Timeout(size)
+	}
+	return p.Timeout(math.MaxInt)
+}
+
+type squareSizeKey struct{}
+
+// WithSquareSize returns a context telling retrievals the width of the original data square they
+// retrieve, for retrievals identifying squares by their DataHash only, e.g. over shrex/eds.
+func WithSquareSize(ctx context.Context, size int) context.Context {
+	return context.WithValue(ctx, squareSizeKey{}, size)
+}
+
+// SquareSizeFromContext returns the width of the original data square set with WithSquareSize, if
+// any.
+func SquareSizeFromContext(ctx context.Context) (int, bool) {
+	size, ok := ctx.Value(squareSizeKey{}).(int)
+	return size, ok
+}
+
+// Validate checks that the profiles are ordered by square size, have positive timeouts and that
+// only the last one applies to all bigger squares.
+func (p DeadlineProfiles) Validate() error {
+	if len(p) == 0 {
+		return fmt.Errorf("share: deadline profiles are empty")
+	}
+	for i, profile := range p {
+		if profile.Timeout <= 0 {
+			return fmt.Errorf("share: deadline profile %d: timeout must be positive", i)
+		}
+		last := i == len(p)-1
+		switch {
+		case profile.MaxSquareSize < 0, profile.MaxSquareSize == 0 && !last:
+			return fmt.Errorf("share: deadline profile %d: invalid max square size %d", i, profile.MaxSquareSize)
+		case i > 0 && profile.MaxSquareSize != 0 && profile.MaxSquareSize <= p[i-1].MaxSquareSize:
+			return fmt.Errorf("share: deadline profiles must be ordered by max square size")
+		}
+	}
+	return nil
+}
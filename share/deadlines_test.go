@@ -0,0 +1,65 @@
+package share
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadlineProfiles_Timeout(t *testing.T) {
+	profiles := DefaultDeadlineProfiles()
+
+	tests := []struct {
+		squareSize int
+		timeout    time.Duration
+	}{
+		{1, 10 * time.Second},
+		{16, 10 * time.Second},
+		{32, 30 * time.Second},
+		{128, time.Minute},
+		{256, 2 * time.Minute},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.timeout, profiles.Timeout(tt.squareSize), "square size %d", tt.squareSize)
+	}
+
+	// squares bigger than the ones of all profiles get the timeout of the last one
+	bounded := DeadlineProfiles{{MaxSquareSize: 16, Timeout: time.Second}, {MaxSquareSize: 64, Timeout: time.Minute}}
+	assert.Equal(t, time.Minute, bounded.Timeout(128))
+}
+
+func TestDeadlineProfiles_TimeoutFromContext(t *testing.T) {
+	profiles := DefaultDeadlineProfiles()
+
+	ctx := WithSquareSize(context.Background(), 32)
+	assert.Equal(t, 30*time.Second, profiles.TimeoutFromContext(ctx))
+	// squares of unknown size get the timeout of the biggest ones
+	assert.Equal(t, 2*time.Minute, profiles.TimeoutFromContext(context.Background()))
+}
+
+func TestDeadlineProfiles_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		profiles DeadlineProfiles
+		valid    bool
+	}{
+		{"default", DefaultDeadlineProfiles(), true},
+		{"empty", DeadlineProfiles{}, false},
+		{"zero timeout", DeadlineProfiles{{MaxSquareSize: 16}}, false},
+		{"unordered", DeadlineProfiles{{64, time.Second}, {16, time.Second}}, false},
+		{"catch-all not last", DeadlineProfiles{{0, time.Second}, {16, time.Second}}, false},
+		{"negative size", DeadlineProfiles{{-1, time.Second}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.profiles.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+		})
+	}
+}
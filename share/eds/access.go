@@ -0,0 +1,145 @@
+package eds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+)
+
+// ShardAccess holds access statistics of an EDS stored in the Store.
+type ShardAccess struct {
+	// LastAccess is the last time the EDS was read, or the time it was put if it was never read.
+	LastAccess time.Time `json:"last_access"`
+	// Count is the amount of times the EDS was read.
+	Count uint64 `json:"count"`
+}
+
+// accessTracker tracks ShardAccess statistics per shard and persists them in the datastore,
+// so they survive restarts.
+type accessTracker struct {
+	ds ds.Batching
+
+	lk    sync.Mutex
+	stats map[string]*ShardAccess
+	dirty map[string]struct{}
+}
+
+func newAccessTracker(dts ds.Batching) *accessTracker {
+	return &accessTracker{
+		ds:    namespace.Wrap(dts, ds.NewKey("/eds/access")),
+		stats: make(map[string]*ShardAccess),
+		dirty: make(map[string]struct{}),
+	}
+}
+
+// load reads all the persisted statistics from the datastore.
+func (t *accessTracker) load(ctx context.Context) error {
+	res, err := t.ds.Query(ctx, query.Query{})
+	if err != nil {
+		return fmt.Errorf("querying access stats: %w", err)
+	}
+	defer res.Close()
+
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	for entry := range res.Next() {
+		if entry.Error != nil {
+			return fmt.Errorf("reading access stats: %w", entry.Error)
+		}
+
+		var stat ShardAccess
+		if err := json.Unmarshal(entry.Value, &stat); err != nil {
+			return fmt.Errorf("unmarshalling access stats of %s: %w", entry.Key, err)
+		}
+		t.stats[ds.RawKey(entry.Key).BaseNamespace()] = &stat
+	}
+	return nil
+}
+
+// flush persists all the statistics updated since the last flush.
+func (t *accessTracker) flush(ctx context.Context) error {
+	t.lk.Lock()
+	batch, err := t.ds.Batch(ctx)
+	if err != nil {
+		t.lk.Unlock()
+		return fmt.Errorf("creating ds batch: %w", err)
+	}
+	for key := range t.dirty {
+		stat, ok := t.stats[key]
+		if !ok {
+			err = batch.Delete(ctx, ds.NewKey(key))
+		} else {
+			var bs []byte
+			bs, err = json.Marshal(stat)
+			if err == nil {
+				err = batch.Put(ctx, ds.NewKey(key), bs)
+			}
+		}
+		if err != nil {
+			t.lk.Unlock()
+			return fmt.Errorf("writing access stats of %s: %w", key, err)
+		}
+	}
+	t.dirty = make(map[string]struct{})
+	t.lk.Unlock()
+
+	return batch.Commit(ctx)
+}
+
+// init starts tracking the shard with the given key, if it isn't tracked yet.
+func (t *accessTracker) init(key string) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	if _, ok := t.stats[key]; ok {
+		return
+	}
+	t.stats[key] = &ShardAccess{LastAccess: time.Now()}
+	t.dirty[key] = struct{}{}
+}
+
+// record registers a read of the shard with the given key.
+func (t *accessTracker) record(key string) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	stat, ok := t.stats[key]
+	if !ok {
+		stat = &ShardAccess{}
+		t.stats[key] = stat
+	}
+	stat.LastAccess = time.Now()
+	stat.Count++
+	t.dirty[key] = struct{}{}
+}
+
+// get returns the statistics of the shard with the given key.
+func (t *accessTracker) get(key string) ShardAccess {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	if stat, ok := t.stats[key]; ok {
+		return *stat
+	}
+	return ShardAccess{}
+}
+
+// restore sets the statistics of the shard with the given key, e.g. when the shard is registered
+// again.
+func (t *accessTracker) restore(key string, stat ShardAccess) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	t.stats[key] = &stat
+	t.dirty[key] = struct{}{}
+}
+
+// remove stops tracking the shard with the given key.
+func (t *accessTracker) remove(key string) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	delete(t.stats, key)
+	t.dirty[key] = struct{}{}
+}
@@ -1,14 +1,14 @@
 package eds
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"reflect"
-	"sync"
 
 	"github.com/filecoin-project/dagstore"
 	"github.com/filecoin-project/dagstore/shard"
-	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/celestiaorg/celestia-node/libs/cache"
 )
 
 var (
@@ -25,95 +25,80 @@ type accessorWithBlockstore struct {
 }
 
 type blockstoreCache struct {
-	// stripedLocks prevents simultaneous RW access to the blockstore cache for a shard. Instead
-	// of using only one lock or one lock per key, we stripe the shard keys across 256 locks. 256 is
-	// chosen because it 0-255 is the range of values we get looking at the last byte of the key.
-	stripedLocks [256]sync.Mutex
 	// caches the blockstore for a given shard for shard read affinity i.e.
 	// further reads will likely be from the same shard. Maps (shard key -> blockstore).
-	cache *lru.Cache
+	// Concurrent reads of a shard missing in the cache acquire it only once.
+	cache *cache.Cache[shard.Key, *accessorWithBlockstore]
 }
 
 func newBlockstoreCache(cacheSize int) (*blockstoreCache, error) {
+	if cacheSize <= 0 {
+		return nil, fmt.Errorf("failed to instantiate blockstore cache: invalid size %d", cacheSize)
+	}
 	// instantiate the blockstore cache
-	bslru, err := lru.NewWithEvict(cacheSize, func(_ interface{}, val interface{}) {
+	bscache := cache.NewWithEvict(cacheSize, func(_ shard.Key, abs *accessorWithBlockstore) {
 		// ensure we close the blockstore for a shard when it's evicted so dagstore can gc it.
-		abs, ok := val.(*accessorWithBlockstore)
-		if !ok {
-			panic(fmt.Sprintf(
-				"casting value from cache to accessorWithBlockstore: %s",
-				reflect.TypeOf(val),
-			))
-		}
-
 		if err := abs.sa.Close(); err != nil {
 			log.Errorf("couldn't close accessor after cache eviction: %s", err)
 		}
 	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to instantiate blockstore cache: %w", err)
-	}
-	return &blockstoreCache{cache: bslru}, nil
+	return &blockstoreCache{cache: bscache}, nil
 }
 
 // Get retrieves the blockstore for a given shard key from the cache. If the blockstore is not in
 // the cache, it returns an errCacheMiss
 func (bc *blockstoreCache) Get(shardContainingCid shard.Key) (*accessorWithBlockstore, error) {
-	lk := &bc.stripedLocks[shardKeyToStriped(shardContainingCid)]
-	lk.Lock()
-	defer lk.Unlock()
-
-	return bc.unsafeGet(shardContainingCid)
-}
-
-func (bc *blockstoreCache) unsafeGet(shardContainingCid shard.Key) (*accessorWithBlockstore, error) {
 	// We've already ensured that the given shard has the cid/multihash we are looking for.
-	val, ok := bc.cache.Get(shardContainingCid)
+	accessor, ok := bc.cache.Get(shardContainingCid)
 	if !ok {
 		return nil, errCacheMiss
 	}
-
-	accessor, ok := val.(*accessorWithBlockstore)
-	if !ok {
-		panic(fmt.Sprintf(
-			"casting value from cache to accessorWithBlockstore: %s",
-			reflect.TypeOf(val),
-		))
-	}
 	return accessor, nil
 }
 
+// GetOrLoad retrieves the blockstore for a given shard key from the cache. If the blockstore is
+// not in the cache, the shard accessor is acquired with the given function and added to the cache.
+func (bc *blockstoreCache) GetOrLoad(
+	ctx context.Context,
+	shardContainingCid shard.Key,
+	load func(context.Context, shard.Key) (*dagstore.ShardAccessor, error),
+) (*accessorWithBlockstore, error) {
+	return bc.cache.GetOrLoad(ctx, shardContainingCid, func(ctx context.Context) (*accessorWithBlockstore, error) {
+		accessor, err := load(ctx, shardContainingCid)
+		if err != nil {
+			return nil, err
+		}
+		return newAccessorWithBlockstore(accessor)
+	})
+}
+
 // Add adds a blockstore for a given shard key to the cache.
 func (bc *blockstoreCache) Add(
 	shardContainingCid shard.Key,
 	accessor *dagstore.ShardAccessor,
 ) (*accessorWithBlockstore, error) {
-	lk := &bc.stripedLocks[shardKeyToStriped(shardContainingCid)]
-	lk.Lock()
-	defer lk.Unlock()
+	newAccessor, err := newAccessorWithBlockstore(accessor)
+	if err != nil {
+		return nil, err
+	}
+	bc.cache.Add(shardContainingCid, newAccessor)
+	return newAccessor, nil
+}
 
-	return bc.unsafeAdd(shardContainingCid, accessor)
+// Remove removes the blockstore for a given shard key from the cache, closing the underlying
+// accessor.
+func (bc *blockstoreCache) Remove(shardContainingCid shard.Key) {
+	bc.cache.Remove(shardContainingCid)
 }
 
-func (bc *blockstoreCache) unsafeAdd(
-	shardContainingCid shard.Key,
-	accessor *dagstore.ShardAccessor,
-) (*accessorWithBlockstore, error) {
+func newAccessorWithBlockstore(accessor *dagstore.ShardAccessor) (*accessorWithBlockstore, error) {
 	blockStore, err := accessor.Blockstore()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get blockstore from accessor: %w", err)
 	}
 
-	newAccessor := &accessorWithBlockstore{
+	return &accessorWithBlockstore{
 		bs: blockStore,
 		sa: accessor,
-	}
-	bc.cache.Add(shardContainingCid, newAccessor)
-	return newAccessor, nil
-}
-
-// shardKeyToStriped returns the index of the lock to use for a given shard key. We use the last
-// byte of the shard key as the pseudo-random index.
-func shardKeyToStriped(sk shard.Key) byte {
-	return sk.String()[len(sk.String())-1]
+	}, nil
 }
@@ -125,5 +125,5 @@ func (bs *blockstore) getReadOnlyBlockstore(ctx context.Context, cid cid.Cid) (d
 	if err != nil {
 		return nil, fmt.Errorf("failed to get accessor for shard %s: %w", shardKey, err)
 	}
-	return accessor.bs, nil
+	return bs.store.carBlockstore(shardKey, accessor.bs), nil
 }
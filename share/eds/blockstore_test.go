@@ -1,6 +1,7 @@
 package eds
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"testing"
@@ -15,7 +16,8 @@ import (
 )
 
 // TestBlockstore_Operations tests Has, Get, and GetSize on the top level eds.Store blockstore.
-// It verifies that these operations are valid and successful on all blocks stored in a CAR file.
+// It verifies that these operations are valid and successful on all blocks of an EDS, including the
+// parity shares and inner nodes missing in its CAR file.
 func TestBlockstore_Operations(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
@@ -29,9 +31,10 @@ func TestBlockstore_Operations(t *testing.T) {
 	err = edsStore.Put(ctx, dah.Hash(), eds)
 	require.NoError(t, err)
 
-	r, err := edsStore.GetCAR(ctx, dah.Hash())
+	buf := new(bytes.Buffer)
+	err = WriteEDS(ctx, eds, buf)
 	require.NoError(t, err)
-	carReader, err := car.NewCarReader(r)
+	carReader, err := car.NewCarReader(buf)
 	require.NoError(t, err)
 
 	topLevelBS := edsStore.Blockstore()
@@ -243,11 +243,88 @@ func (m *BadEncoding) GetAxis() Axis {
 	return Axis_ROW
 }
 
+type UnorderedNamespaces struct {
+	HeaderHash []byte   `protobuf:"bytes,1,opt,name=HeaderHash,proto3" json:"HeaderHash,omitempty"`
+	Height     uint64   `protobuf:"varint,2,opt,name=Height,proto3" json:"Height,omitempty"`
+	Shares     [][]byte `protobuf:"bytes,3,rep,name=Shares,proto3" json:"Shares,omitempty"`
+	Index      uint32   `protobuf:"varint,4,opt,name=Index,proto3" json:"Index,omitempty"`
+	Axis       Axis     `protobuf:"varint,5,opt,name=Axis,proto3,enum=share.eds.byzantine.pb.Axis" json:"Axis,omitempty"`
+}
+
+func (m *UnorderedNamespaces) Reset()         { *m = UnorderedNamespaces{} }
+func (m *UnorderedNamespaces) String() string { return proto.CompactTextString(m) }
+func (*UnorderedNamespaces) ProtoMessage()    {}
+func (*UnorderedNamespaces) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d28ce8f160a920d1, []int{3}
+}
+func (m *UnorderedNamespaces) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UnorderedNamespaces) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UnorderedNamespaces.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UnorderedNamespaces) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnorderedNamespaces.Merge(m, src)
+}
+func (m *UnorderedNamespaces) XXX_Size() int {
+	return m.Size()
+}
+func (m *UnorderedNamespaces) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnorderedNamespaces.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnorderedNamespaces proto.InternalMessageInfo
+
+func (m *UnorderedNamespaces) GetHeaderHash() []byte {
+	if m != nil {
+		return m.HeaderHash
+	}
+	return nil
+}
+
+func (m *UnorderedNamespaces) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *UnorderedNamespaces) GetShares() [][]byte {
+	if m != nil {
+		return m.Shares
+	}
+	return nil
+}
+
+func (m *UnorderedNamespaces) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *UnorderedNamespaces) GetAxis() Axis {
+	if m != nil {
+		return m.Axis
+	}
+	return Axis_ROW
+}
+
 func init() {
 	proto.RegisterEnum("share.eds.byzantine.pb.Axis", Axis_name, Axis_value)
 	proto.RegisterType((*MerkleProof)(nil), "share.eds.byzantine.pb.MerkleProof")
 	proto.RegisterType((*Share)(nil), "share.eds.byzantine.pb.Share")
 	proto.RegisterType((*BadEncoding)(nil), "share.eds.byzantine.pb.BadEncoding")
+	proto.RegisterType((*UnorderedNamespaces)(nil), "share.eds.byzantine.pb.UnorderedNamespaces")
 }
 
 func init() {
@@ -255,29 +332,31 @@ func init() {
 }
 
 var fileDescriptor_d28ce8f160a920d1 = []byte{
-	// 347 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0x41, 0x4b, 0xf3, 0x30,
-	0x1c, 0xc6, 0x9b, 0xb7, 0xed, 0xde, 0xf7, 0xfd, 0x77, 0xca, 0x08, 0x32, 0x02, 0x6a, 0x28, 0xf5,
-	0x52, 0x3c, 0xb4, 0x32, 0xf1, 0xe0, 0xd1, 0xa9, 0x30, 0x41, 0x99, 0x44, 0xd0, 0xa3, 0xa4, 0x26,
-	0x5b, 0x8b, 0x23, 0x1d, 0x4d, 0x0f, 0xd3, 0x4f, 0xe1, 0x87, 0xf2, 0xe0, 0x71, 0x47, 0x8f, 0xb2,
-	0x7d, 0x11, 0x49, 0x3a, 0x64, 0x07, 0x77, 0xfb, 0x3f, 0x0f, 0x4f, 0xf2, 0xfc, 0xfe, 0x09, 0x44,
-	0x3a, 0xe7, 0x95, 0x4c, 0xa5, 0xd0, 0x69, 0xf6, 0xf2, 0xca, 0x55, 0x5d, 0x28, 0x99, 0x4e, 0xb3,
-	0xd4, 0xda, 0xc9, 0xb4, 0x2a, 0xeb, 0x12, 0x77, 0x1b, 0x21, 0x85, 0x4e, 0x7e, 0x32, 0xc9, 0x34,
-	0x8b, 0x72, 0x08, 0x6e, 0x64, 0xf5, 0x3c, 0x91, 0xb7, 0x55, 0x59, 0x8e, 0xf0, 0x0e, 0xf8, 0xba,
-	0xe6, 0x55, 0x4d, 0x50, 0x88, 0x62, 0x97, 0x35, 0x02, 0x77, 0xc0, 0x95, 0x4a, 0x90, 0x3f, 0xd6,
-	0x33, 0xa3, 0xc9, 0xa9, 0x52, 0x48, 0x4d, 0xdc, 0xd0, 0x8d, 0xdb, 0xac, 0x11, 0x78, 0x17, 0xfe,
-	0x4f, 0x24, 0x1f, 0x3d, 0xe6, 0x5c, 0xe7, 0xc4, 0x0b, 0x51, 0xdc, 0x66, 0xff, 0x8c, 0x31, 0xe0,
-	0x3a, 0x8f, 0xee, 0xc1, 0xbf, 0x33, 0x0c, 0x18, 0x83, 0x77, 0xc1, 0x6b, 0x6e, 0x2b, 0xda, 0xcc,
-	0xce, 0xf8, 0x14, 0x7c, 0x0b, 0x60, 0x3b, 0x82, 0xde, 0x41, 0xf2, 0x3b, 0x6e, 0xb2, 0xc6, 0xca,
-	0x9a, 0x13, 0xd1, 0x3b, 0x82, 0xa0, 0xcf, 0xc5, 0xa5, 0x7a, 0x2a, 0x45, 0xa1, 0xc6, 0x98, 0x02,
-	0x0c, 0x24, 0x17, 0xb2, 0x32, 0xad, 0xab, 0x92, 0x35, 0x07, 0x77, 0xa1, 0x35, 0x90, 0xc5, 0x38,
-	0xaf, 0x6d, 0x97, 0xc7, 0x56, 0x0a, 0x9f, 0x40, 0xcb, 0xf2, 0x35, 0x3b, 0x05, 0xbd, 0xfd, 0x4d,
-	0x0c, 0x36, 0xc5, 0x56, 0x61, 0xf3, 0x12, 0x57, 0x4a, 0xc8, 0x99, 0xdd, 0x77, 0x8b, 0x35, 0x02,
-	0x1f, 0x81, 0x77, 0x36, 0x2b, 0x34, 0xf1, 0x43, 0x14, 0x6f, 0xf7, 0xf6, 0x36, 0x5d, 0xc5, 0x67,
-	0x85, 0x66, 0x36, 0x79, 0x48, 0xc0, 0x33, 0x0a, 0xff, 0x05, 0x97, 0x0d, 0x1f, 0x3a, 0x8e, 0x19,
-	0xce, 0x87, 0xd7, 0x1d, 0xd4, 0x27, 0x1f, 0x0b, 0x8a, 0xe6, 0x0b, 0x8a, 0xbe, 0x16, 0x14, 0xbd,
-	0x2d, 0xa9, 0x33, 0x5f, 0x52, 0xe7, 0x73, 0x49, 0x9d, 0xac, 0x65, 0xff, 0xf6, 0xf8, 0x3b, 0x00,
-	0x00, 0xff, 0xff, 0x0f, 0xa3, 0x91, 0xb4, 0x01, 0x02, 0x00, 0x00,
+	// 378 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x92, 0x41, 0x8b, 0xd3, 0x40,
+	0x1c, 0xc5, 0x33, 0x26, 0xa9, 0xfa, 0x4f, 0x94, 0x32, 0x4a, 0x09, 0xa8, 0x21, 0xc4, 0x4b, 0xf0,
+	0x90, 0x48, 0xc5, 0x83, 0x47, 0xab, 0x42, 0x05, 0xb5, 0x32, 0xa2, 0x1e, 0x65, 0xd2, 0xf9, 0xb7,
+	0x09, 0xd6, 0x49, 0x98, 0xc9, 0xa1, 0xfa, 0x29, 0xfc, 0x22, 0x7e, 0x0b, 0x0f, 0x7b, 0xec, 0x71,
+	0x8f, 0x4b, 0xfb, 0x45, 0x96, 0x99, 0x94, 0xa5, 0x0b, 0xdb, 0xcb, 0xb2, 0xb7, 0xff, 0x7b, 0xbc,
+	0xe4, 0xfd, 0x5e, 0x08, 0xa4, 0xba, 0xe2, 0x0a, 0x0b, 0x14, 0xba, 0x28, 0x7f, 0xff, 0xe1, 0xb2,
+	0xab, 0x25, 0x16, 0x6d, 0x59, 0x58, 0x3b, 0x6f, 0x55, 0xd3, 0x35, 0x74, 0xd4, 0x0b, 0x14, 0x3a,
+	0xbf, 0xc8, 0xe4, 0x6d, 0x99, 0x56, 0x10, 0x7c, 0x44, 0xf5, 0x73, 0x85, 0x9f, 0x55, 0xd3, 0x2c,
+	0xe8, 0x43, 0xf0, 0x75, 0xc7, 0x55, 0x17, 0x91, 0x84, 0x64, 0x2e, 0xeb, 0x05, 0x1d, 0x82, 0x8b,
+	0x52, 0x44, 0xb7, 0xac, 0x67, 0x4e, 0x93, 0x93, 0x8d, 0x40, 0x1d, 0xb9, 0x89, 0x9b, 0x85, 0xac,
+	0x17, 0xf4, 0x11, 0xdc, 0x5d, 0x21, 0x5f, 0xfc, 0xa8, 0xb8, 0xae, 0x22, 0x2f, 0x21, 0x59, 0xc8,
+	0xee, 0x18, 0x63, 0xca, 0x75, 0x95, 0x7e, 0x03, 0xff, 0x8b, 0x61, 0xa0, 0x14, 0xbc, 0xb7, 0xbc,
+	0xe3, 0xb6, 0x22, 0x64, 0xf6, 0xa6, 0xaf, 0xc0, 0xb7, 0x00, 0xb6, 0x23, 0x18, 0x3f, 0xcd, 0xaf,
+	0xc6, 0xcd, 0x0f, 0x58, 0x59, 0xff, 0x44, 0xfa, 0x9f, 0x40, 0x30, 0xe1, 0xe2, 0x9d, 0x9c, 0x37,
+	0xa2, 0x96, 0x4b, 0x1a, 0x03, 0x4c, 0x91, 0x0b, 0x54, 0xa6, 0x75, 0x5f, 0x72, 0xe0, 0xd0, 0x11,
+	0x0c, 0xa6, 0x58, 0x2f, 0xab, 0xce, 0x76, 0x79, 0x6c, 0xaf, 0xe8, 0x4b, 0x18, 0x58, 0xbe, 0x7e,
+	0x53, 0x30, 0x7e, 0x72, 0x8c, 0xc1, 0xa6, 0xd8, 0x3e, 0x6c, 0xbe, 0xc4, 0x7b, 0x29, 0x70, 0x6d,
+	0xf7, 0xde, 0x63, 0xbd, 0xa0, 0xcf, 0xc1, 0x7b, 0xbd, 0xae, 0x75, 0xe4, 0x27, 0x24, 0xbb, 0x3f,
+	0x7e, 0x7c, 0xec, 0x55, 0x7c, 0x5d, 0x6b, 0x66, 0x93, 0xe9, 0x3f, 0x02, 0x0f, 0xbe, 0xca, 0x46,
+	0x09, 0x54, 0x28, 0x3e, 0xf1, 0x5f, 0xa8, 0x5b, 0x3e, 0x47, 0x7d, 0xed, 0x39, 0xa3, 0x4b, 0x73,
+	0xc2, 0x9b, 0xe6, 0x7d, 0x16, 0x81, 0x67, 0x14, 0xbd, 0x0d, 0x2e, 0x9b, 0x7d, 0x1f, 0x3a, 0xe6,
+	0x78, 0x33, 0xfb, 0x30, 0x24, 0x93, 0xe8, 0x64, 0x1b, 0x93, 0xcd, 0x36, 0x26, 0x67, 0xdb, 0x98,
+	0xfc, 0xdd, 0xc5, 0xce, 0x66, 0x17, 0x3b, 0xa7, 0xbb, 0xd8, 0x29, 0x07, 0xf6, 0x5f, 0x7c, 0x71,
+	0x3e, 0x00, 0xe4, 0x5e, 0xce, 0x31, 0xb1, 0x02, 0x00, 0x00,
 }
 
 func (m *MerkleProof) Marshal() (dAtA []byte, err error) {
@@ -430,6 +509,60 @@ func (m *BadEncoding) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *UnorderedNamespaces) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UnorderedNamespaces) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UnorderedNamespaces) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Axis != 0 {
+		i = encodeVarintShare(dAtA, i, uint64(m.Axis))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Index != 0 {
+		i = encodeVarintShare(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Shares) > 0 {
+		for iNdEx := len(m.Shares) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Shares[iNdEx])
+			copy(dAtA[i:], m.Shares[iNdEx])
+			i = encodeVarintShare(dAtA, i, uint64(len(m.Shares[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Height != 0 {
+		i = encodeVarintShare(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.HeaderHash) > 0 {
+		i -= len(m.HeaderHash)
+		copy(dAtA[i:], m.HeaderHash)
+		i = encodeVarintShare(dAtA, i, uint64(len(m.HeaderHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintShare(dAtA []byte, offset int, v uint64) int {
 	offset -= sovShare(v)
 	base := offset
@@ -511,6 +644,34 @@ func (m *BadEncoding) Size() (n int) {
 	return n
 }
 
+func (m *UnorderedNamespaces) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.HeaderHash)
+	if l > 0 {
+		n += 1 + l + sovShare(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovShare(uint64(m.Height))
+	}
+	if len(m.Shares) > 0 {
+		for _, b := range m.Shares {
+			l = len(b)
+			n += 1 + l + sovShare(uint64(l))
+		}
+	}
+	if m.Index != 0 {
+		n += 1 + sovShare(uint64(m.Index))
+	}
+	if m.Axis != 0 {
+		n += 1 + sovShare(uint64(m.Axis))
+	}
+	return n
+}
+
 func sovShare(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -966,6 +1127,179 @@ func (m *BadEncoding) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *UnorderedNamespaces) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowShare
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UnorderedNamespaces: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UnorderedNamespaces: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeaderHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShare
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShare
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShare
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HeaderHash = append(m.HeaderHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.HeaderHash == nil {
+				m.HeaderHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShare
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shares", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShare
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthShare
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShare
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Shares = append(m.Shares, make([]byte, postIndex-iNdEx))
+			copy(m.Shares[len(m.Shares)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShare
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Axis", wireType)
+			}
+			m.Axis = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShare
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Axis |= Axis(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipShare(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthShare
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipShare(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,283 @@
+package byzantine
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/minio/sha256-simd"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	appns "github.com/celestiaorg/celestia-app/pkg/namespace"
+	"github.com/celestiaorg/go-fraud"
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/bufpool"
+	"github.com/celestiaorg/celestia-node/share"
+	pb "github.com/celestiaorg/celestia-node/share/eds/byzantine/pb"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+const (
+	UnorderedNamespaces fraud.ProofType = "unorderednamespaces"
+)
+
+func init() {
+	fraud.Register(&UnorderedNamespacesProof{})
+}
+
+// ErrUnorderedNamespaces is thrown when a row or col of the original data square is committed to
+// by its DAH root while its shares are not sorted by namespace.
+//
+// Such an axis can't be rebuilt by honest NMTs, so it is reported as ErrByzantineData by rsmt2d,
+// while a Bad Encoding Fraud Proof for it would never pass validation.
+type ErrUnorderedNamespaces struct {
+	Index uint32
+	// Shares are the shares of the axis within the original data square.
+	Shares [][]byte
+	Axis   rsmt2d.Axis
+}
+
+func (e *ErrUnorderedNamespaces) Error() string {
+	return fmt.Sprintf("unordered namespaces error(Axis:%v, Index:%v)", e.Axis, e.Index)
+}
+
+// FindUnorderedNamespaces checks whether the given shares of the row or col at the given index are
+// not sorted by namespace, while still being committed to by the respective root of the given DAH.
+// It returns nil if the shares of the original data square are incomplete, sorted or are not
+// committed to by the DAH.
+func FindUnorderedNamespaces(
+	dah *da.DataAvailabilityHeader,
+	axis rsmt2d.Axis,
+	index uint,
+	shares [][]byte,
+) *ErrUnorderedNamespaces {
+	odsWidth := len(dah.RowRoots) / 2
+	// only the axes of the original data square contain namespaces other than the parity one
+	if int(index) >= odsWidth || len(shares) < odsWidth {
+		return nil
+	}
+	shares = shares[:odsWidth]
+	for _, shr := range shares {
+		if shr == nil {
+			return nil
+		}
+	}
+	if !namespacesUnordered(shares) {
+		return nil
+	}
+
+	root, err := unorderedRoot(shares, index)
+	if err != nil || !bytes.Equal(root, axisRoot(dah, axis, index)) {
+		return nil
+	}
+	return &ErrUnorderedNamespaces{
+		Index:  uint32(index),
+		Shares: shares,
+		Axis:   axis,
+	}
+}
+
+// UnorderedNamespacesProof proves that a row or col of the original data square committed to by a
+// block is not sorted by namespace. It carries all shares of the axis within the original data
+// square, so that the axis can be extended and its root recomputed.
+type UnorderedNamespacesProof struct {
+	headerHash  []byte
+	BlockHeight uint64
+	// Shares contains all shares of the row or col within the original data square.
+	Shares [][]byte
+	// Index represents the row/col index with unordered namespaces.
+	Index uint32
+	// Axis represents the axis with unordered namespaces.
+	Axis rsmt2d.Axis
+}
+
+// CreateUnorderedNamespacesProof creates a new Unordered Namespaces Fraud Proof that should be
+// propagated through network.
+func CreateUnorderedNamespacesProof(
+	hash []byte,
+	height uint64,
+	errUnordered *ErrUnorderedNamespaces,
+) fraud.Proof {
+	return &UnorderedNamespacesProof{
+		headerHash:  hash,
+		BlockHeight: height,
+		Shares:      errUnordered.Shares,
+		Index:       errUnordered.Index,
+		Axis:        errUnordered.Axis,
+	}
+}
+
+// Type returns type of fraud proof.
+func (p *UnorderedNamespacesProof) Type() fraud.ProofType {
+	return UnorderedNamespaces
+}
+
+// HeaderHash returns block hash.
+func (p *UnorderedNamespacesProof) HeaderHash() []byte {
+	return p.headerHash
+}
+
+// Height returns block height.
+func (p *UnorderedNamespacesProof) Height() uint64 {
+	return p.BlockHeight
+}
+
+// MarshalBinary converts UnorderedNamespacesProof to binary.
+func (p *UnorderedNamespacesProof) MarshalBinary() ([]byte, error) {
+	proof := pb.UnorderedNamespaces{
+		HeaderHash: p.headerHash,
+		Height:     p.BlockHeight,
+		Shares:     p.Shares,
+		Index:      p.Index,
+		Axis:       pb.Axis(p.Axis),
+	}
+	return proof.Marshal()
+}
+
+// UnmarshalBinary converts binary to UnorderedNamespacesProof.
+func (p *UnorderedNamespacesProof) UnmarshalBinary(data []byte) error {
+	in := pb.UnorderedNamespaces{}
+	if err := in.Unmarshal(data); err != nil {
+		return err
+	}
+	*p = UnorderedNamespacesProof{
+		headerHash:  in.HeaderHash,
+		BlockHeight: in.Height,
+		Shares:      in.Shares,
+		Index:       in.Index,
+		Axis:        rsmt2d.Axis(in.Axis),
+	}
+	return nil
+}
+
+// Validate ensures that fraud proof is correct.
+// Validate checks that the shares are not sorted by namespace, extends them, recomputes the root of
+// the row or col and compares it with block's Merkle Root.
+func (p *UnorderedNamespacesProof) Validate(hdr libhead.Header) error {
+	header, ok := hdr.(*header.ExtendedHeader)
+	if !ok {
+		panic(fmt.Sprintf("invalid header type: expected %T, got %T", header, hdr))
+	}
+	if header.Height() != int64(p.BlockHeight) {
+		return errors.New("fraud: incorrect block height")
+	}
+	if p.Axis != rsmt2d.Row && p.Axis != rsmt2d.Col {
+		return fmt.Errorf("fraud: invalid proof: unknown axis %d", p.Axis)
+	}
+
+	odsWidth := len(header.DAH.RowRoots) / 2
+	if int(p.Index) >= odsWidth {
+		return fmt.Errorf("fraud: invalid proof: index out of original data square (%d >= %d)", p.Index, odsWidth)
+	}
+	if len(p.Shares) != odsWidth {
+		return fmt.Errorf("fraud: invalid proof: incorrect number of shares %d != %d", len(p.Shares), odsWidth)
+	}
+	for index, shr := range p.Shares {
+		if len(shr) != share.Size {
+			return fmt.Errorf("fraud: invalid proof: incorrect share size at index %d", index)
+		}
+	}
+	if !namespacesUnordered(p.Shares) {
+		return errors.New("fraud: invalid proof: shares are sorted by namespace")
+	}
+
+	root, err := unorderedRoot(p.Shares, uint(p.Index))
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(root, axisRoot(header.DAH, p.Axis, uint(p.Index))) {
+		return errors.New("fraud: invalid proof: recomputed Merkle root doesn't match the DAH's row/column root")
+	}
+	return nil
+}
+
+// namespacesUnordered reports whether the shares are not sorted by namespace.
+func namespacesUnordered(shares [][]byte) bool {
+	for i := 1; i < len(shares); i++ {
+		if share.ID(shares[i]).Less(share.ID(shares[i-1])) {
+			return true
+		}
+	}
+	return false
+}
+
+func axisRoot(dah *da.DataAvailabilityHeader, axis rsmt2d.Axis, index uint) []byte {
+	if axis == rsmt2d.Col {
+		return dah.ColumnRoots[index]
+	}
+	return dah.RowRoots[index]
+}
+
+// unorderedRoot extends the given shares of an axis of the original data square and computes the
+// root of the axis the way an NMT would, but without requiring namespaces of siblings to be ordered,
+// as NMTs refuse to hash unordered namespaces.
+func unorderedRoot(shares [][]byte, axisIndex uint) ([]byte, error) {
+	parity, err := share.DefaultRSMT2DCodec().Encode(shares)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher := nmt.NewNmtHasher(sha256.New(), share.NamespaceSize, ipld.NMTIgnoreMaxNamespace)
+	odsWidth := len(shares)
+	leaves := make([][]byte, 0, 2*odsWidth)
+	leaves = append(leaves, shares...)
+	leaves = append(leaves, parity...)
+
+	// every leaf is namespaced in the same buffer, as the hasher doesn't keep it
+	buf := bufpool.Get(share.NamespaceSize + share.Size)
+	defer buf.Release()
+	nodes := make([][]byte, 0, len(leaves))
+	for i, shr := range leaves {
+		// mirrors wrapper.ErasuredNamespacedMerkleTree, which commits to the parity namespace for all
+		// shares outside the original data square
+		ns := appns.ParitySharesNamespace.Bytes()
+		if i < odsWidth && int(axisIndex) < odsWidth {
+			ns = share.ID(shr)
+		}
+		leaf, err := hasher.HashLeaf(append(append(buf.Bytes[:0], ns...), shr...))
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, leaf)
+	}
+
+	// the amount of leaves is always a power of two, so the tree is perfectly balanced
+	for len(nodes) > 1 {
+		for i := 0; i < len(nodes)/2; i++ {
+			nodes[i] = hashUnorderedNode(nodes[2*i], nodes[2*i+1])
+		}
+		nodes = nodes[:len(nodes)/2]
+	}
+	return nodes[0], nil
+}
+
+// hashUnorderedNode computes the namespaced hash of the node with the given children like
+// nmt.Hasher.HashNode does, but merges the namespace ranges of the children regardless of their
+// order.
+func hashUnorderedNode(left, right []byte) []byte {
+	leftMin, leftMax := nmt.MinNamespace(left, share.NamespaceSize), nmt.MaxNamespace(left, share.NamespaceSize)
+	rightMin, rightMax := nmt.MinNamespace(right, share.NamespaceSize), nmt.MaxNamespace(right, share.NamespaceSize)
+
+	minNs, maxNs := leftMin, leftMax
+	if bytes.Compare(rightMin, minNs) < 0 {
+		minNs = rightMin
+	}
+	// the parity namespace of the right child is ignored, as in NMTs ignoring the max namespace
+	if !bytes.Equal(rightMin, appns.ParitySharesNamespace.Bytes()) && bytes.Compare(rightMax, maxNs) > 0 {
+		maxNs = rightMax
+	}
+
+	h := sha256.New()
+	h.Write([]byte{nmt.NodePrefix})
+	h.Write(left)
+	h.Write(right)
+
+	res := make([]byte, 0, 2*share.NamespaceSize+h.Size())
+	res = append(res, minNs...)
+	res = append(res, maxNs...)
+	return h.Sum(res)
+}
@@ -0,0 +1,89 @@
+package byzantine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	core "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// TestUnorderedRoot asserts that roots computed without ordering requirements match the roots of
+// honest NMTs.
+func TestUnorderedRoot(t *testing.T) {
+	odsWidth := 4
+	eds, dah := randEDS(t, odsWidth)
+
+	for i := uint(0); i < uint(2*odsWidth); i++ {
+		root, err := unorderedRoot(eds.Row(i)[:odsWidth], i)
+		require.NoError(t, err)
+		require.Equal(t, dah.RowRoots[i], root)
+
+		root, err = unorderedRoot(eds.Col(i)[:odsWidth], i)
+		require.NoError(t, err)
+		require.Equal(t, dah.ColumnRoots[i], root)
+	}
+}
+
+func TestUnorderedNamespacesProof(t *testing.T) {
+	odsWidth := 4
+	eds, dah := randEDS(t, odsWidth)
+
+	row := uint(1)
+	shares := eds.Row(row)[:odsWidth]
+	require.Nil(t, FindUnorderedNamespaces(&dah, rsmt2d.Row, row, shares))
+
+	// commit to the row with its first and last shares swapped
+	unordered := make([][]byte, odsWidth)
+	copy(unordered, shares)
+	unordered[0], unordered[odsWidth-1] = unordered[odsWidth-1], unordered[0]
+	root, err := unorderedRoot(unordered, row)
+	require.NoError(t, err)
+	dah.RowRoots[row] = root
+
+	errUnordered := FindUnorderedNamespaces(&dah, rsmt2d.Row, row, unordered)
+	require.NotNil(t, errUnordered)
+	require.Nil(t, FindUnorderedNamespaces(&dah, rsmt2d.Col, row, unordered))
+
+	h := &header.ExtendedHeader{
+		RawHeader: core.Header{
+			Height: 420,
+		},
+		DAH: &dah,
+		Commit: &core.Commit{
+			BlockID: core.BlockID{
+				Hash: []byte("made up hash"),
+			},
+		},
+	}
+
+	proof := CreateUnorderedNamespacesProof(h.Hash(), uint64(h.Height()), errUnordered)
+	require.NoError(t, proof.Validate(h))
+
+	bin, err := proof.MarshalBinary()
+	require.NoError(t, err)
+	decoded := &UnorderedNamespacesProof{}
+	require.NoError(t, decoded.UnmarshalBinary(bin))
+	require.Equal(t, proof, decoded)
+	require.NoError(t, decoded.Validate(h))
+
+	// shares sorted by namespace prove nothing
+	decoded.Shares = shares
+	require.Error(t, decoded.Validate(h))
+}
+
+func randEDS(t *testing.T, odsWidth int) (*rsmt2d.ExtendedDataSquare, da.DataAvailabilityHeader) {
+	eds, err := rsmt2d.ComputeExtendedDataSquare(
+		share.RandShares(t, odsWidth*odsWidth),
+		share.DefaultRSMT2DCodec(),
+		wrapper.NewConstructor(uint64(odsWidth)),
+	)
+	require.NoError(t, err)
+	return eds, da.NewDataAvailabilityHeader(eds)
+}
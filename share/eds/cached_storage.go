@@ -0,0 +1,131 @@
+package eds
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/filecoin-project/dagstore/mount"
+
+	"github.com/celestiaorg/celestia-node/libs/cache"
+)
+
+const (
+	cachePath = "/cache/"
+	// partialSuffix marks CAR files that are being fetched into the cache.
+	partialSuffix = ".partial"
+)
+
+// cachedStorage is a read-through cache of recently accessed CAR files over a CARStorage. Cached
+// CAR files are kept in a local directory, so they can be read without hitting the underlying
+// storage.
+type cachedStorage struct {
+	storage CARStorage
+	local   *fileStorage
+	// cache tracks keys of CAR files kept in the local directory and deduplicates concurrent
+	// fetches of the same CAR file.
+	cache *cache.Cache[string, struct{}]
+}
+
+func newCachedStorage(storage CARStorage, dir string, size int) (*cachedStorage, error) {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("creating CAR cache directory: %w", err)
+	}
+
+	if size <= 0 {
+		return nil, fmt.Errorf("creating CAR cache: invalid size %d", size)
+	}
+
+	local := newFileStorage(dir)
+	carCache := cache.NewWithEvict(size, func(key string, _ struct{}) {
+		if err := local.Remove(context.Background(), key); err != nil {
+			log.Errorw("removing evicted CAR file from cache", "key", key, "err", err)
+		}
+	})
+
+	// pick up CAR files cached before restart
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading CAR cache directory: %w", err)
+	}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), partialSuffix) {
+			_ = local.Remove(context.Background(), entry.Name())
+			continue
+		}
+		carCache.Add(entry.Name(), struct{}{})
+	}
+
+	return &cachedStorage{
+		storage: storage,
+		local:   local,
+		cache:   carCache,
+	}, nil
+}
+
+func (cs *cachedStorage) Put(ctx context.Context, key string, r io.Reader) error {
+	return cs.storage.Put(ctx, key, r)
+}
+
+func (cs *cachedStorage) Get(ctx context.Context, key string) (mount.Reader, error) {
+	if _, ok := cs.cache.Get(key); ok {
+		r, err := cs.local.Get(ctx, key)
+		if err == nil {
+			return r, nil
+		}
+		log.Warnw("reading cached CAR file, falling back to storage", "key", key, "err", err)
+		cs.cache.Remove(key)
+	}
+
+	_, err := cs.cache.GetOrLoad(ctx, key, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, cs.fetch(ctx, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cs.local.Get(ctx, key)
+}
+
+// fetch copies the CAR file stored under the given key from the underlying storage into the cache.
+func (cs *cachedStorage) fetch(ctx context.Context, key string) error {
+	r, err := cs.storage.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	// write into a temporary file first, so that partially fetched CAR files are never read
+	tmpKey := key + partialSuffix
+	err = cs.local.Put(ctx, tmpKey, r)
+	if err != nil {
+		_ = cs.local.Remove(ctx, tmpKey)
+		return fmt.Errorf("caching CAR file: %w", err)
+	}
+	err = os.Rename(cs.local.dir+tmpKey, cs.local.dir+key)
+	if err != nil {
+		return fmt.Errorf("caching CAR file: %w", err)
+	}
+	return nil
+}
+
+func (cs *cachedStorage) Size(ctx context.Context, key string) (int64, error) {
+	if _, ok := cs.cache.Get(key); ok {
+		size, err := cs.local.Size(ctx, key)
+		if err == nil {
+			return size, nil
+		}
+	}
+	return cs.storage.Size(ctx, key)
+}
+
+func (cs *cachedStorage) Remove(ctx context.Context, key string) error {
+	cs.cache.Remove(key)
+	return cs.storage.Remove(ctx, key)
+}
+
+func (cs *cachedStorage) Keys(ctx context.Context) ([]string, error) {
+	return cs.storage.Keys(ctx)
+}
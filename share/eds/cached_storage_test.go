@@ -0,0 +1,67 @@
+package eds
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedStorage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	remoteDir, cacheDir := t.TempDir()+"/", t.TempDir()+"/"
+	storage, err := newCachedStorage(newFileStorage(remoteDir), cacheDir, 1)
+	require.NoError(t, err)
+
+	for _, key := range []string{"first", "second"} {
+		err = storage.Put(ctx, key, strings.NewReader(key))
+		require.NoError(t, err)
+		// CAR files are only cached once read
+		_, err = os.Stat(cacheDir + key)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	}
+
+	r, err := storage.Get(ctx, "first")
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "first", string(data))
+	_, err = os.Stat(cacheDir + "first")
+	require.NoError(t, err)
+
+	// cached CAR file is read even if it's gone from the underlying storage
+	err = os.Remove(remoteDir + "first")
+	require.NoError(t, err)
+	r, err = storage.Get(ctx, "first")
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	// reading another CAR file evicts the least recently read one
+	r, err = storage.Get(ctx, "second")
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	_, err = os.Stat(cacheDir + "first")
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = storage.Get(ctx, "first")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// cache is restored after restart
+	storage, err = newCachedStorage(newFileStorage(remoteDir), cacheDir, 1)
+	require.NoError(t, err)
+	_, ok := storage.cache.Get("second")
+	assert.True(t, ok)
+
+	err = storage.Remove(ctx, "second")
+	require.NoError(t, err)
+	_, err = os.Stat(cacheDir + "second")
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = os.Stat(remoteDir + "second")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
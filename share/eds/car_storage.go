@@ -0,0 +1,140 @@
+package eds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/filecoin-project/dagstore/mount"
+)
+
+// carStorageScheme is the scheme under which storageMount is registered on the mount registry.
+const carStorageScheme = "car"
+
+// CARStorage stores CAR files of EDSes keyed by the string representation of their DataHash.
+// The Store keeps DAGStore indexes locally regardless of the CARStorage used, so the storage is
+// only accessed when EDS data is read.
+type CARStorage interface {
+	// Put stores the CAR file read from r under the given key.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get returns a reader of the CAR file stored under the given key.
+	// It returns ErrNotFound if there is no such file.
+	Get(ctx context.Context, key string) (mount.Reader, error)
+	// Size returns the size of the CAR file stored under the given key in bytes.
+	// It returns ErrNotFound if there is no such file.
+	Size(ctx context.Context, key string) (int64, error)
+	// Remove removes the CAR file stored under the given key.
+	Remove(ctx context.Context, key string) error
+	// Keys returns the keys of all the CAR files stored.
+	Keys(ctx context.Context) ([]string, error)
+}
+
+// fileStorage is the default CARStorage keeping CAR files in a local directory.
+type fileStorage struct {
+	dir string
+}
+
+func newFileStorage(dir string) *fileStorage {
+	return &fileStorage{dir: dir}
+}
+
+func (fs *fileStorage) Put(_ context.Context, key string, r io.Reader) error {
+	f, err := os.OpenFile(fs.dir+key, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
+
+func (fs *fileStorage) Get(_ context.Context, key string) (mount.Reader, error) {
+	f, err := os.Open(fs.dir + key)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (fs *fileStorage) Size(_ context.Context, key string) (int64, error) {
+	stat, err := os.Stat(fs.dir + key)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, ErrNotFound
+		}
+		return 0, err
+	}
+	return stat.Size(), nil
+}
+
+func (fs *fileStorage) Remove(_ context.Context, key string) error {
+	return os.Remove(fs.dir + key)
+}
+
+func (fs *fileStorage) Keys(context.Context) ([]string, error) {
+	entries, err := os.ReadDir(fs.dir)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			keys = append(keys, entry.Name())
+		}
+	}
+	return keys, nil
+}
+
+// storageMount is a DAGStore mount of a CAR file kept in a CARStorage.
+type storageMount struct {
+	// Storage is exported, as the mount registry only carries over exported fields of the template
+	// mount to new instances.
+	Storage CARStorage
+	Key     string
+}
+
+var _ mount.Mount = (*storageMount)(nil)
+
+func (m *storageMount) Fetch(ctx context.Context) (mount.Reader, error) {
+	return m.Storage.Get(ctx, m.Key)
+}
+
+func (m *storageMount) Info() mount.Info {
+	return mount.Info{
+		Kind:             mount.KindRemote,
+		AccessRandom:     true,
+		AccessSeek:       true,
+		AccessSequential: true,
+	}
+}
+
+func (m *storageMount) Stat(ctx context.Context) (mount.Stat, error) {
+	size, err := m.Storage.Size(ctx, m.Key)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return mount.Stat{}, nil
+	case err != nil:
+		return mount.Stat{}, err
+	}
+	return mount.Stat{Exists: true, Size: size, Ready: true}, nil
+}
+
+func (m *storageMount) Serialize() *url.URL {
+	return &url.URL{Host: m.Key}
+}
+
+func (m *storageMount) Deserialize(u *url.URL) error {
+	if u.Host == "" {
+		return fmt.Errorf("invalid key")
+	}
+	m.Key = u.Host
+	return nil
+}
+
+func (m *storageMount) Close() error {
+	return nil
+}
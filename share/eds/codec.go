@@ -0,0 +1,143 @@
+package eds
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// codecVersion is the version of the byte serialization of EDS written by Encode.
+const codecVersion byte = 1
+
+// codecHeaderSize is the size of the header preceding the shares: the version and the ODS width.
+const codecHeaderSize = 1 + 4
+
+// ErrInvalidEncoding is returned when decoding bytes which are not a valid serialization of EDS.
+var ErrInvalidEncoding = errors.New("eds: invalid encoding")
+
+// Encode writes the canonical byte serialization of the EDS to the writer. Only the original data
+// square (ODS) is written, as parity shares are recomputed when decoding. The serialization
+// consists of:
+//   - the version of the serialization (1 byte),
+//   - the width of the ODS (4 bytes, big endian),
+//   - the shares of the ODS in row-major order (share.Size bytes each).
+func Encode(w io.Writer, eds *rsmt2d.ExtendedDataSquare) error {
+	odsWidth := eds.Width() / 2
+	header := make([]byte, codecHeaderSize)
+	header[0] = codecVersion
+	binary.BigEndian.PutUint32(header[1:], uint32(odsWidth))
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(header); err != nil {
+		return fmt.Errorf("eds: writing header: %w", err)
+	}
+	for i := uint(0); i < odsWidth; i++ {
+		for _, shr := range eds.Row(i)[:odsWidth] {
+			if len(shr) != share.Size {
+				return fmt.Errorf("eds: share of size %d, expected %d", len(shr), share.Size)
+			}
+			if _, err := bw.Write(shr); err != nil {
+				return fmt.Errorf("eds: writing share: %w", err)
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// Decode reads the byte serialization of an EDS written by Encode from the reader, recomputes the
+// EDS from its ODS and verifies it against the given DataHash. It never reads past the end of the
+// serialization.
+func Decode(r io.Reader, root share.DataHash) (*rsmt2d.ExtendedDataSquare, error) {
+	eds, err := decode(r)
+	if err != nil {
+		return nil, err
+	}
+	if err = VerifyEDS(eds, root); err != nil {
+		return nil, err
+	}
+	return eds, nil
+}
+
+// decode reads the byte serialization of an EDS like Decode, without verifying it against a
+// DataHash. The roots of the EDS are computed, so that an ODS they can't be computed for, e.g. with
+// unordered namespaces, is rejected.
+func decode(r io.Reader) (*rsmt2d.ExtendedDataSquare, error) {
+	header := make([]byte, codecHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: reading header: %w", ErrInvalidEncoding, err)
+	}
+	if header[0] != codecVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, header[0])
+	}
+	odsWidth := binary.BigEndian.Uint32(header[1:])
+	if odsWidth == 0 || odsWidth > uint32(share.MaxSquareSize) || odsWidth&(odsWidth-1) != 0 {
+		return nil, fmt.Errorf("%w: invalid ODS width %d", ErrInvalidEncoding, odsWidth)
+	}
+
+	shares := make([][]byte, odsWidth*odsWidth)
+	for i := range shares {
+		shares[i] = make([]byte, share.Size)
+		if _, err := io.ReadFull(r, shares[i]); err != nil {
+			return nil, fmt.Errorf("%w: reading share %d: %w", ErrInvalidEncoding, i, err)
+		}
+	}
+
+	eds, err := rsmt2d.ComputeExtendedDataSquare(
+		shares,
+		share.DefaultRSMT2DCodec(),
+		wrapper.NewConstructor(uint64(odsWidth)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: computing eds: %w", ErrInvalidEncoding, err)
+	}
+	// rsmt2d leaves the roots empty, if it fails to compute them
+	if uint(len(eds.RowRoots())) != eds.Width() || uint(len(eds.ColRoots())) != eds.Width() {
+		return nil, fmt.Errorf("%w: computing roots of the eds", ErrInvalidEncoding)
+	}
+	return eds, nil
+}
+
+// ToBytes returns the byte serialization of the EDS written by Encode.
+func ToBytes(eds *rsmt2d.ExtendedDataSquare) ([]byte, error) {
+	odsWidth := int(eds.Width() / 2)
+	buf := bytes.NewBuffer(make([]byte, 0, codecHeaderSize+odsWidth*odsWidth*share.Size))
+	if err := Encode(buf, eds); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// FromBytes decodes the EDS from its byte serialization and verifies it against the given
+// DataHash. Unlike Decode, it rejects trailing bytes.
+func FromBytes(data []byte, root share.DataHash) (*rsmt2d.ExtendedDataSquare, error) {
+	eds, err := fromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	if err = VerifyEDS(eds, root); err != nil {
+		return nil, err
+	}
+	return eds, nil
+}
+
+// fromBytes decodes the EDS from its byte serialization like FromBytes, without verifying it
+// against a DataHash.
+func fromBytes(data []byte) (*rsmt2d.ExtendedDataSquare, error) {
+	r := bytes.NewReader(data)
+	eds, err := decode(r)
+	if err != nil {
+		return nil, err
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrInvalidEncoding, r.Len())
+	}
+	return eds, nil
+}
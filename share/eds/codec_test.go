@@ -0,0 +1,81 @@
+package eds
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestCodec(t *testing.T) {
+	eds := share.RandEDS(t, 4)
+	dah := da.NewDataAvailabilityHeader(eds)
+	root := dah.Hash()
+
+	data, err := ToBytes(eds)
+	require.NoError(t, err)
+	require.Len(t, data, codecHeaderSize+4*4*share.Size)
+
+	decoded, err := FromBytes(data, root)
+	require.NoError(t, err)
+	assert.Equal(t, eds.RowRoots(), decoded.RowRoots())
+
+	// streaming decode stops at the end of the serialization
+	buf := bytes.NewBuffer(append(data, 0xff))
+	decoded, err = Decode(buf, root)
+	require.NoError(t, err)
+	assert.Equal(t, eds.RowRoots(), decoded.RowRoots())
+	assert.Equal(t, 1, buf.Len())
+
+	_, err = FromBytes(append(data, 0xff), root)
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+	_, err = FromBytes(data[:len(data)-1], root)
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+
+	unknown := append([]byte{}, data...)
+	unknown[0] = codecVersion + 1
+	_, err = FromBytes(unknown, root)
+	assert.ErrorIs(t, err, ErrInvalidEncoding)
+
+	// the EDS of another DataHash is rejected
+	other := da.NewDataAvailabilityHeader(share.RandEDS(t, 4))
+	_, err = FromBytes(data, other.Hash())
+	assert.Error(t, err)
+
+	// an ODS with unordered namespaces doesn't match the DataHash
+	unordered := append([]byte{}, data...)
+	first, second := codecHeaderSize, codecHeaderSize+share.Size
+	copy(unordered[first:first+share.NamespaceSize], bytes.Repeat([]byte{0xff}, share.NamespaceSize))
+	copy(unordered[second:second+share.NamespaceSize], bytes.Repeat([]byte{0x01}, share.NamespaceSize))
+	_, err = FromBytes(unordered, root)
+	assert.Error(t, err)
+}
+
+func FuzzFromBytes(f *testing.F) {
+	for _, width := range []int{1, 2, 4} {
+		data, err := ToBytes(share.RandEDS(f, width))
+		require.NoError(f, err)
+		f.Add(data)
+	}
+	f.Add([]byte{})
+	f.Add([]byte{codecVersion, 0, 0, 0, 3})
+	f.Add([]byte{codecVersion, 0xff, 0xff, 0xff, 0xff})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		eds, err := fromBytes(data)
+		if err != nil {
+			require.ErrorIs(t, err, ErrInvalidEncoding)
+			return
+		}
+
+		// anything decoded is encoded back to the same bytes
+		encoded, err := ToBytes(eds)
+		require.NoError(t, err)
+		require.Equal(t, data, encoded)
+	})
+}
@@ -18,13 +18,13 @@ import (
 	"github.com/ipld/go-car/util"
 	"github.com/minio/sha256-simd"
 
-	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	"github.com/celestiaorg/celestia-app/pkg/da"
 	"github.com/celestiaorg/celestia-app/pkg/namespace"
 	"github.com/celestiaorg/celestia-app/pkg/wrapper"
 	"github.com/celestiaorg/nmt"
 	"github.com/celestiaorg/rsmt2d"
 
+	"github.com/celestiaorg/celestia-node/libs/bufpool"
 	"github.com/celestiaorg/celestia-node/libs/utils"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/ipld"
@@ -132,26 +132,46 @@ func (w *writingSession) writeHeader() error {
 	}, w.w)
 }
 
-// writeQuadrants reorders the shares to quadrant order and writes them to the CARv1 file.
+// writeQuadrants writes the shares to the CARv1 file in quadrant row-by-row order, prepending
+// the respective namespace to the shares.
+// e.g. [ Q1 R1 | Q1 R2 | Q1 R3 | Q1 R4 | Q2 R1 | Q2 R2 .... ]
 func (w *writingSession) writeQuadrants() error {
-	shares := quadrantOrder(w.eds)
-	for _, share := range shares {
-		leaf, err := w.hasher.HashLeaf(share)
-		if err != nil {
-			return fmt.Errorf("hashing share: %w", err)
-		}
-		cid, err := ipld.CidFromNamespacedSha256(leaf)
-		if err != nil {
-			return fmt.Errorf("getting cid from share: %w", err)
-		}
-		err = util.LdWrite(w.w, cid.Bytes(), share)
-		if err != nil {
-			return fmt.Errorf("writing share to file: %w", err)
+	// every share is namespaced in the same buffer, as it isn't needed after being written
+	buf := bufpool.Get(ipld.NamespaceSize + share.Size)
+	defer buf.Release()
+
+	quadrantWidth := w.eds.Width() / 2
+	for quadrant := 0; quadrant < 4; quadrant++ {
+		rowOffset, colOffset := quadrantOffsets(quadrant, quadrantWidth)
+		for i := uint(0); i < quadrantWidth; i++ {
+			for j := uint(0); j < quadrantWidth; j++ {
+				shr := appendNamespace(buf.Bytes[:0], quadrant, w.eds.GetCell(rowOffset+i, colOffset+j))
+				if err := writeLeaf(w.w, w.hasher, shr); err != nil {
+					return err
+				}
+			}
 		}
 	}
 	return nil
 }
 
+// writeLeaf writes the namespaced share to the CARv1 file as a leaf of NMTs.
+func writeLeaf(w io.Writer, hasher *nmt.Hasher, shr []byte) error {
+	leaf, err := hasher.HashLeaf(shr)
+	if err != nil {
+		return fmt.Errorf("hashing share: %w", err)
+	}
+	cid, err := ipld.CidFromNamespacedSha256(leaf)
+	if err != nil {
+		return fmt.Errorf("getting cid from share: %w", err)
+	}
+	err = util.LdWrite(w, cid.Bytes(), shr)
+	if err != nil {
+		return fmt.Errorf("writing share: %w", err)
+	}
+	return nil
+}
+
 // writeProofs iterates over the in-memory blockstore's keys and writes all inner nodes to the
 // CARv1 file.
 func (w *writingSession) writeProofs(ctx context.Context) error {
@@ -185,25 +205,40 @@ func (w *writingSession) writeProofs(ctx context.Context) error {
 	return nil
 }
 
-// quadrantOrder reorders the shares in the EDS to quadrant row-by-row order, prepending the
-// respective namespace to the shares.
-// e.g. [ Q1 R1 | Q1 R2 | Q1 R3 | Q1 R4 | Q2 R1 | Q2 R2 .... ]
-func quadrantOrder(eds *rsmt2d.ExtendedDataSquare) [][]byte {
-	size := eds.Width() * eds.Width()
-	shares := make([][]byte, size)
-
-	quadrantWidth := int(eds.Width() / 2)
-	quadrantSize := quadrantWidth * quadrantWidth
-	for i := 0; i < quadrantWidth; i++ {
-		for j := 0; j < quadrantWidth; j++ {
-			cells := getQuadrantCells(eds, uint(i), uint(j))
-			innerOffset := i*quadrantWidth + j
-			for quadrant := 0; quadrant < 4; quadrant++ {
-				shares[(quadrant*quadrantSize)+innerOffset] = prependNamespace(quadrant, cells[quadrant])
+// writeODS writes the CARv1 header and the first quadrant of the EDS to w. The output is the same
+// as the one of ODSReader over the CAR file of the EDS.
+func writeODS(eds *rsmt2d.ExtendedDataSquare, w io.Writer) error {
+	rootCids, err := rootsToCids(eds)
+	if err != nil {
+		return fmt.Errorf("getting root cids: %w", err)
+	}
+	err = car.WriteHeader(&car.CarHeader{
+		Roots:   rootCids,
+		Version: 1,
+	}, w)
+	if err != nil {
+		return fmt.Errorf("writing carv1 header: %w", err)
+	}
+
+	hasher := nmt.NewNmtHasher(sha256.New(), ipld.NamespaceSize, ipld.NMTIgnoreMaxNamespace)
+	buf := bufpool.Get(ipld.NamespaceSize + share.Size)
+	defer buf.Release()
+	// shares are read by rows, as GetCell returns empty cells of squares repaired from scratch
+	odsWidth := eds.Width() / 2
+	for i := uint(0); i < odsWidth; i++ {
+		row := eds.Row(i)
+		for j := uint(0); j < odsWidth; j++ {
+			if err := writeLeaf(w, hasher, appendNamespace(buf.Bytes[:0], 0, row[j])); err != nil {
+				return err
 			}
 		}
 	}
-	return shares
+	return nil
+}
+
+// quadrantOffsets returns the coordinates of the first cell of the given EDS quadrant.
+func quadrantOffsets(quadrant int, quadrantWidth uint) (row, col uint) {
+	return uint(quadrant/2) * quadrantWidth, uint(quadrant%2) * quadrantWidth
 }
 
 // getQuadrantCells returns the cell of each EDS quadrant with the passed inner-quadrant coordinates
@@ -217,15 +252,14 @@ func getQuadrantCells(eds *rsmt2d.ExtendedDataSquare, i, j uint) [][]byte {
 	return cells
 }
 
-// prependNamespace adds the namespace to the passed share if in the first quadrant,
-// otherwise it adds the ParitySharesNamespace to the beginning.
-func prependNamespace(quadrant int, share []byte) []byte {
-	namespacedShare := make([]byte, 0, appconsts.NamespaceSize+appconsts.ShareSize)
+// appendNamespace appends the share to the given buffer, prefixed with its namespace if in the
+// first quadrant, otherwise with the ParitySharesNamespace.
+func appendNamespace(buf []byte, quadrant int, share []byte) []byte {
 	switch quadrant {
 	case 0:
-		return append(append(namespacedShare, share[:ipld.NamespaceSize]...), share...)
+		return append(append(buf, share[:ipld.NamespaceSize]...), share...)
 	case 1, 2, 3:
-		return append(append(namespacedShare, namespace.ParitySharesNamespace.Bytes()...), share...)
+		return append(append(buf, namespace.ParitySharesNamespace.Bytes()...), share...)
 	default:
 		panic("invalid quadrant")
 	}
@@ -255,6 +289,42 @@ func ReadEDS(ctx context.Context, r io.Reader, root share.DataHash) (eds *rsmt2d
 		utils.SetStatusAndEnd(span, err)
 	}()
 
+	eds, err = readEDS(r)
+	if err != nil {
+		return nil, err
+	}
+	if err = VerifyEDS(eds, root); err != nil {
+		return nil, err
+	}
+	return eds, nil
+}
+
+// ReadUnverifiedEDS reads the first EDS quadrant (1/4) from an io.Reader CAR file, like ReadEDS,
+// but skips validation of the EDS against the DataRoot. The caller is responsible for validating
+// the EDS with VerifyEDS.
+func ReadUnverifiedEDS(ctx context.Context, r io.Reader) (eds *rsmt2d.ExtendedDataSquare, err error) {
+	_, span := tracer.Start(ctx, "read-unverified-eds")
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	return readEDS(r)
+}
+
+// VerifyEDS checks that the EDS is committed to by the given DataRoot.
+func VerifyEDS(eds *rsmt2d.ExtendedDataSquare, root share.DataHash) error {
+	newDah := da.NewDataAvailabilityHeader(eds)
+	if !bytes.Equal(newDah.Hash(), root) {
+		return fmt.Errorf(
+			"share: content integrity mismatch: imported root %s doesn't match expected root %s",
+			newDah.Hash(),
+			root,
+		)
+	}
+	return nil
+}
+
+func readEDS(r io.Reader) (*rsmt2d.ExtendedDataSquare, error) {
 	carReader, err := car.NewCarReader(r)
 	if err != nil {
 		return nil, fmt.Errorf("share: reading car file: %w", err)
@@ -276,7 +346,7 @@ func ReadEDS(ctx context.Context, r io.Reader, root share.DataHash) (eds *rsmt2d
 		shares[i] = block.RawData()[ipld.NamespaceSize:]
 	}
 
-	eds, err = rsmt2d.ComputeExtendedDataSquare(
+	eds, err := rsmt2d.ComputeExtendedDataSquare(
 		shares,
 		share.DefaultRSMT2DCodec(),
 		wrapper.NewConstructor(uint64(odsWidth)),
@@ -284,15 +354,6 @@ func ReadEDS(ctx context.Context, r io.Reader, root share.DataHash) (eds *rsmt2d
 	if err != nil {
 		return nil, fmt.Errorf("share: computing eds: %w", err)
 	}
-
-	newDah := da.NewDataAvailabilityHeader(eds)
-	if !bytes.Equal(newDah.Hash(), root) {
-		return nil, fmt.Errorf(
-			"share: content integrity mismatch: imported root %s doesn't match expected root %s",
-			newDah.Hash(),
-			root,
-		)
-	}
 	return eds, nil
 }
 
@@ -0,0 +1,133 @@
+package eds
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// defaultEDSCacheSize is the default maximum total size of EDSes cached in memory by the Store.
+const defaultEDSCacheSize = 128 << 20 // 128 MiB
+
+// edsCache is an LRU cache of decoded EDSes keyed by DataHash, which evicts least recently used
+// EDSes once the total size of cached EDSes exceeds its capacity. Cached EDSes are never handed out
+// to be modified: the cache keeps its own copies of added EDSes and returns shared EDSes for
+// reading only, which have to be copied with copyEDS before being given to callers of the Store.
+type edsCache struct {
+	lk sync.Mutex
+	// capacity is the maximum total size of cached EDSes in bytes.
+	capacity int64
+	// size is the current total size of cached EDSes in bytes.
+	size int64
+	// order keeps cached entries from the most to the least recently used.
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type edsCacheEntry struct {
+	key    string
+	square *rsmt2d.ExtendedDataSquare
+	size   int64
+}
+
+func newEDSCache(capacity int64) *edsCache {
+	return &edsCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// get returns the cached EDS with the given key, if any. The EDS is shared and must not be
+// modified.
+func (c *edsCache) get(key string) (*rsmt2d.ExtendedDataSquare, bool) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*edsCacheEntry).square, true
+}
+
+// add caches a copy of the given EDS, evicting least recently used EDSes if needed. EDSes larger
+// than the capacity of the cache are not cached.
+func (c *edsCache) add(key string, square *rsmt2d.ExtendedDataSquare) error {
+	size := edsSize(square)
+	if size > c.capacity {
+		return nil
+	}
+	if _, ok := c.get(key); ok {
+		return nil
+	}
+
+	square, err := copyEDS(square)
+	if err != nil {
+		return err
+	}
+
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&edsCacheEntry{key: key, square: square, size: size})
+	c.size += size
+	for c.size > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+	return nil
+}
+
+// remove evicts the EDS with the given key from the cache.
+func (c *edsCache) remove(key string) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.removeElement(elem)
+	}
+}
+
+func (c *edsCache) removeElement(elem *list.Element) {
+	entry := c.order.Remove(elem).(*edsCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
+
+// copyEDS returns a deep copy of the shares of the EDS. Roots of the copy are computed lazily, once
+// they are requested.
+func copyEDS(square *rsmt2d.ExtendedDataSquare) (*rsmt2d.ExtendedDataSquare, error) {
+	shares := square.Flattened()
+	copies := make([][]byte, len(shares))
+	for i, sh := range shares {
+		copies[i] = make([]byte, len(sh))
+		copy(copies[i], sh)
+	}
+
+	cp, err := rsmt2d.ImportExtendedDataSquare(
+		copies,
+		share.DefaultRSMT2DCodec(),
+		wrapper.NewConstructor(uint64(square.Width()/2)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("copying EDS: %w", err)
+	}
+	return cp, nil
+}
+
+// edsSize estimates the amount of memory taken by the shares of the EDS.
+func edsSize(square *rsmt2d.ExtendedDataSquare) int64 {
+	width := int64(square.Width())
+	return width * width * share.Size
+}
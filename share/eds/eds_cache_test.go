@@ -0,0 +1,86 @@
+package eds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEDSCache(t *testing.T) {
+	first, _ := randomEDS(t)
+	second, _ := randomEDS(t)
+	size := edsSize(first)
+
+	// fits one EDS only
+	cache := newEDSCache(size)
+	require.NoError(t, cache.add("first", first))
+	require.NoError(t, cache.add("second", second))
+
+	_, ok := cache.get("first")
+	assert.False(t, ok)
+	got, ok := cache.get("second")
+	require.True(t, ok)
+	// the cache keeps its own copy
+	assert.NotSame(t, second, got)
+	assert.Equal(t, second.Flattened(), got.Flattened())
+	assert.Equal(t, size, cache.size)
+
+	// fits two EDSes, so the least recently used one is evicted on the third add
+	cache = newEDSCache(2 * size)
+	require.NoError(t, cache.add("first", first))
+	require.NoError(t, cache.add("second", second))
+	_, ok = cache.get("first")
+	require.True(t, ok)
+	require.NoError(t, cache.add("third", second))
+	_, ok = cache.get("first")
+	assert.True(t, ok)
+	_, ok = cache.get("second")
+	assert.False(t, ok)
+
+	cache.remove("first")
+	_, ok = cache.get("first")
+	assert.False(t, ok)
+	assert.Equal(t, size, cache.size)
+
+	// EDSes exceeding the capacity are never cached
+	cache = newEDSCache(size - 1)
+	require.NoError(t, cache.add("first", first))
+	_, ok = cache.get("first")
+	assert.False(t, ok)
+}
+
+func TestStore_GetCached(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	eds, dah := randomEDS(t)
+	err = edsStore.Put(ctx, dah.Hash(), eds)
+	require.NoError(t, err)
+	edsStore.edsCache.remove(dah.String())
+
+	// first read decodes the CAR file and caches the EDS
+	first, err := edsStore.Get(ctx, dah.Hash())
+	require.NoError(t, err)
+	_, ok := edsStore.edsCache.get(dah.String())
+	require.True(t, ok)
+
+	// cached EDSes are served as copies, so callers modifying them don't corrupt the cache
+	first.Flattened()[0][0]++
+	second, err := edsStore.Get(ctx, dah.Hash())
+	require.NoError(t, err)
+	assert.NotSame(t, first, second)
+	assert.Equal(t, eds.Flattened(), second.Flattened())
+	assert.Equal(t, dah.RowRoots, second.RowRoots())
+
+	err = edsStore.Remove(ctx, dah.Hash())
+	require.NoError(t, err)
+	_, err = edsStore.Get(ctx, dah.Hash())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -1,6 +1,7 @@
 package eds
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"embed"
@@ -12,6 +13,8 @@ import (
 	ds "github.com/ipfs/go-datastore"
 	bstore "github.com/ipfs/go-ipfs-blockstore"
 	carv1 "github.com/ipld/go-car"
+	"github.com/ipld/go-car/util"
+	"github.com/minio/sha256-simd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/libs/rand"
@@ -19,6 +22,7 @@ import (
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	"github.com/celestiaorg/celestia-app/pkg/da"
 	"github.com/celestiaorg/celestia-app/pkg/namespace"
+	"github.com/celestiaorg/nmt"
 	"github.com/celestiaorg/rsmt2d"
 
 	"github.com/celestiaorg/celestia-node/share"
@@ -31,7 +35,7 @@ var exampleRoot string
 //go:embed "testdata/example.car"
 var f embed.FS
 
-func TestQuadrantOrder(t *testing.T) {
+func TestWriteQuadrants(t *testing.T) {
 	testCases := []struct {
 		name       string
 		squareSize int
@@ -55,22 +59,29 @@ func TestQuadrantOrder(t *testing.T) {
 			eds, err := rsmt2d.ComputeExtendedDataSquare(shares, share.DefaultRSMT2DCodec(), rsmt2d.NewDefaultTree)
 			require.NoError(t, err)
 
-			res := quadrantOrder(eds)
-			for _, s := range res {
-				require.Len(t, s, testShareSize+namespace.NamespaceSize)
+			var buf bytes.Buffer
+			w := &writingSession{
+				eds:    eds,
+				hasher: nmt.NewNmtHasher(sha256.New(), ipld.NamespaceSize, ipld.NMTIgnoreMaxNamespace),
+				w:      &buf,
 			}
+			require.NoError(t, w.writeQuadrants())
 
+			reader := bufio.NewReader(&buf)
 			for q := 0; q < 4; q++ {
 				for i := 0; i < tc.squareSize; i++ {
 					for j := 0; j < tc.squareSize; j++ {
-						resIndex := q*tc.squareSize*tc.squareSize + i*tc.squareSize + j
 						edsRow := q/2*tc.squareSize + i
 						edsCol := (q%2)*tc.squareSize + j
 
-						assert.Equal(t, res[resIndex], prependNamespace(q, eds.Row(uint(edsRow))[edsCol]))
+						_, leaf, err := util.ReadNode(reader)
+						require.NoError(t, err)
+						require.Len(t, leaf, testShareSize+namespace.NamespaceSize)
+						require.Equal(t, appendNamespace(nil, q, eds.Row(uint(edsRow))[edsCol]), leaf)
 					}
 				}
 			}
+			assert.Zero(t, buf.Len())
 		})
 	}
 }
@@ -129,11 +140,17 @@ func TestWriteEDSInQuadrantOrder(t *testing.T) {
 	reader, err := carv1.NewCarReader(f)
 	require.NoError(t, err, "error creating car reader")
 
-	shares := quadrantOrder(eds)
-	for i := 0; i < len(shares); i++ {
-		block, err := reader.Next()
-		require.NoError(t, err, "error getting block")
-		require.Equal(t, block.RawData(), shares[i])
+	quadrantWidth := eds.Width() / 2
+	for quadrant := 0; quadrant < 4; quadrant++ {
+		rowOffset, colOffset := quadrantOffsets(quadrant, quadrantWidth)
+		for i := uint(0); i < quadrantWidth; i++ {
+			for j := uint(0); j < quadrantWidth; j++ {
+				block, err := reader.Next()
+				require.NoError(t, err, "error getting block")
+				shr := appendNamespace(nil, quadrant, eds.GetCell(rowOffset+i, colOffset+j))
+				require.Equal(t, block.RawData(), shr)
+			}
+		}
 	}
 }
 
@@ -0,0 +1,161 @@
+package eds
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/filecoin-project/dagstore"
+	"github.com/filecoin-project/dagstore/shard"
+	"github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	bstore "github.com/ipfs/go-ipfs-blockstore"
+	format "github.com/ipfs/go-ipld-format"
+	blocks "github.com/ipfs/go-libipfs/blocks"
+	"github.com/multiformats/go-multihash"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// defaultExtendedCacheSize is the default amount of re-extended EDSes the Store keeps in memory.
+// Every re-extended EDS holds all of its shares and NMT nodes, so the cache is kept small.
+const defaultExtendedCacheSize = 4
+
+// extendingBlockstore serves blocks of the CAR file of an EDS. As CAR files only store the ODS,
+// parity shares and NMT nodes missing in the CAR file are served by re-extending the EDS.
+//
+// NOTE: AllKeysChan only lists blocks stored in the CAR file.
+type extendingBlockstore struct {
+	dagstore.ReadBlockstore
+
+	store *Store
+	key   string
+}
+
+func (bs *extendingBlockstore) Has(ctx context.Context, cid cid.Cid) (bool, error) {
+	has, err := bs.ReadBlockstore.Has(ctx, cid)
+	if err != nil || has {
+		return has, err
+	}
+
+	extended, err := bs.store.extendedBlockstore(ctx, bs.key)
+	if err != nil {
+		return false, err
+	}
+	return extended.Has(ctx, cid)
+}
+
+func (bs *extendingBlockstore) Get(ctx context.Context, cid cid.Cid) (blocks.Block, error) {
+	block, err := bs.ReadBlockstore.Get(ctx, cid)
+	if !format.IsNotFound(err) {
+		return block, err
+	}
+
+	extended, err := bs.store.extendedBlockstore(ctx, bs.key)
+	if err != nil {
+		return nil, err
+	}
+	return extended.Get(ctx, cid)
+}
+
+func (bs *extendingBlockstore) GetSize(ctx context.Context, cid cid.Cid) (int, error) {
+	size, err := bs.ReadBlockstore.GetSize(ctx, cid)
+	if !format.IsNotFound(err) {
+		return size, err
+	}
+
+	extended, err := bs.store.extendedBlockstore(ctx, bs.key)
+	if err != nil {
+		return 0, err
+	}
+	return extended.GetSize(ctx, cid)
+}
+
+// carBlockstore wraps the blockstore of the CAR file stored under the given key, so that it
+// serves all blocks of the EDS.
+func (s *Store) carBlockstore(key shard.Key, bs dagstore.ReadBlockstore) dagstore.ReadBlockstore {
+	return &extendingBlockstore{
+		ReadBlockstore: bs,
+		store:          s,
+		key:            key.String(),
+	}
+}
+
+// extendedBlockstore returns an in-memory blockstore with all shares and NMT nodes of the EDS
+// stored under the given key. Recently re-extended EDSes are served from memory, while concurrent
+// requests for the same EDS re-extend it only once.
+func (s *Store) extendedBlockstore(ctx context.Context, key string) (bstore.Blockstore, error) {
+	bs, err := s.extended.GetOrLoad(ctx, key, func(ctx context.Context) (bstore.Blockstore, error) {
+		root, err := hex.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("decoding shard key: %w", err)
+		}
+		eds, err := s.Get(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+		return extendEDS(ctx, eds)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("eds/store: re-extending EDS: %w", err)
+	}
+	return bs, nil
+}
+
+// indexExtended adds parity shares and NMT nodes of the EDS stored under the given key to the
+// top-level index, as the DAGStore only indexes blocks stored in the CAR file. If the EDS is not
+// given, it is read from the Store.
+func (s *Store) indexExtended(ctx context.Context, key string, eds *rsmt2d.ExtendedDataSquare) error {
+	var (
+		bs  bstore.Blockstore
+		err error
+	)
+	if eds != nil {
+		bs, err = extendEDS(ctx, eds)
+		if err == nil {
+			s.extended.Add(key, bs)
+		}
+	} else {
+		bs, err = s.extendedBlockstore(ctx, key)
+	}
+	if err != nil {
+		return err
+	}
+	keys, err := bs.AllKeysChan(ctx)
+	if err != nil {
+		return fmt.Errorf("listing extended blocks: %w", err)
+	}
+
+	var mhs multihashes
+	for k := range keys {
+		mhs = append(mhs, k.Hash())
+	}
+	return s.topIdx.AddMultihashesForShard(ctx, mhs, shard.KeyFromString(key))
+}
+
+// extendEDS imports all shares of the EDS together with its NMT nodes into an in-memory
+// blockstore.
+func extendEDS(ctx context.Context, eds *rsmt2d.ExtendedDataSquare) (bstore.Blockstore, error) {
+	store := bstore.NewBlockstore(dssync.MutexWrap(ds.NewMapDatastore()))
+	_, err := share.ImportShares(ctx, share.ExtractEDS(eds), blockservice.New(store, nil))
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// multihashes implements index.MultihashIterator over a slice of multihashes.
+type multihashes []multihash.Multihash
+
+func (mhs multihashes) ForEach(f func(mh multihash.Multihash) error) error {
+	for _, mh := range mhs {
+		if err := f(mh); err != nil {
+			return err
+		}
+	}
+	return nil
+}
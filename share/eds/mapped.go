@@ -0,0 +1,360 @@
+package eds
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	carv1 "github.com/ipld/go-car"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// ErrMappingUnsupported is returned by Store.MappedODS if the CAR file of the EDS can't be
+// memory-mapped, as it is not kept on the local disk or the platform doesn't support mappings.
+// Shares are to be read through the blockstore instead.
+var ErrMappingUnsupported = errors.New("eds/store: memory-mapping CAR file is unsupported")
+
+// localFiler is implemented by CARStorages keeping CAR files on the local disk.
+type localFiler interface {
+	// localPath returns the path of the CAR file stored under the given key, if it is kept on the
+	// local disk.
+	localPath(key string) (string, bool)
+}
+
+func (fs *fileStorage) localPath(key string) (string, bool) {
+	return fs.dir + key, true
+}
+
+func (cs *cachedStorage) localPath(key string) (string, bool) {
+	if _, ok := cs.cache.Get(key); !ok {
+		return "", false
+	}
+	return cs.local.localPath(key)
+}
+
+// mappingCacheSize is the amount of idle mappings kept, so that consecutive reads of the same EDS,
+// e.g. of its shares one by one, don't map its CAR file every time.
+const mappingCacheSize = 16
+
+// MappedODS gives read-only access to the shares of the original data square of an EDS straight
+// from its memory-mapped CAR file, without copying them through the blockstore. Shares of the ODS
+// are the first leaves of every stored CAR file, whether only the ODS is stored or all quadrants
+// of the EDS are.
+//
+// Shares returned by MappedODS reference the mapping and are valid only until the MappedODS is
+// released. They must neither be modified nor accessed after Release, which must not be called
+// concurrently with reading them. The mapping survives removal of the CAR file by the Store.
+type MappedODS struct {
+	*odsMapping
+
+	mappings *mappings
+	released atomic.Bool
+}
+
+// odsMapping is a memory-mapped CAR file, shared by the MappedODSes of its EDS.
+type odsMapping struct {
+	key  string
+	data []byte
+	dah  *share.Root
+	// width of the ODS
+	width int
+	// offset of the first leaf record within the CAR file
+	offset int
+	// size of every leaf record, made of its length, CID and namespaced share
+	recordSize int
+
+	// refs is the amount of unreleased MappedODSes of the mapping, guarded by the lock of mappings.
+	refs int
+	// evicted is set once the mapping is to be unmapped as soon as it is not referenced anymore.
+	evicted bool
+}
+
+// mappings shares the mapped CAR files between the readers of their EDSes. Mappings are refcounted
+// and unmapped once they are neither referenced nor among the latest idle ones.
+type mappings struct {
+	lk     sync.Mutex
+	mapped map[string]*odsMapping
+	// idle are the keys of unreferenced mappings, the least recently released first
+	idle []string
+}
+
+func newMappings() *mappings {
+	return &mappings{mapped: make(map[string]*odsMapping)}
+}
+
+// acquire references the mapping of the given key, mapping it with the given func if it is not
+// mapped yet.
+func (ms *mappings) acquire(key string, mapODS func() (*odsMapping, error)) (*MappedODS, error) {
+	ms.lk.Lock()
+	m, ok := ms.mapped[key]
+	if ok {
+		ms.ref(m)
+		ms.lk.Unlock()
+		return &MappedODS{odsMapping: m, mappings: ms}, nil
+	}
+	ms.lk.Unlock()
+
+	// CAR files are mapped without the lock, not to block reads of other EDSes
+	mapped, err := mapODS()
+	if err != nil {
+		return nil, err
+	}
+	mapped.key = key
+
+	ms.lk.Lock()
+	defer ms.lk.Unlock()
+	if m, ok = ms.mapped[key]; ok {
+		// the CAR file was mapped concurrently
+		mapped.unmap()
+	} else {
+		m = mapped
+		ms.mapped[key] = m
+	}
+	ms.ref(m)
+	return &MappedODS{odsMapping: m, mappings: ms}, nil
+}
+
+func (ms *mappings) ref(m *odsMapping) {
+	if m.refs == 0 {
+		for i, key := range ms.idle {
+			if key == m.key {
+				ms.idle = append(ms.idle[:i], ms.idle[i+1:]...)
+				break
+			}
+		}
+	}
+	m.refs++
+}
+
+// release drops a reference of the mapping, keeping it mapped while idle, unless it is evicted or
+// there are too many idle mappings.
+func (ms *mappings) release(m *odsMapping) {
+	ms.lk.Lock()
+	defer ms.lk.Unlock()
+	m.refs--
+	if m.refs > 0 {
+		return
+	}
+	if m.evicted {
+		m.unmap()
+		return
+	}
+
+	ms.idle = append(ms.idle, m.key)
+	if len(ms.idle) > mappingCacheSize {
+		oldest := ms.mapped[ms.idle[0]]
+		ms.idle = ms.idle[1:]
+		delete(ms.mapped, oldest.key)
+		oldest.unmap()
+	}
+}
+
+// evict forgets the mapping of the given key, unmapping it once it is not referenced anymore.
+func (ms *mappings) evict(key string) {
+	ms.lk.Lock()
+	defer ms.lk.Unlock()
+	m, ok := ms.mapped[key]
+	if !ok {
+		return
+	}
+	delete(ms.mapped, key)
+	if m.refs > 0 {
+		m.evicted = true
+		return
+	}
+	for i, idle := range ms.idle {
+		if idle == key {
+			ms.idle = append(ms.idle[:i], ms.idle[i+1:]...)
+			break
+		}
+	}
+	m.unmap()
+}
+
+// close evicts all mappings.
+func (ms *mappings) close() {
+	ms.lk.Lock()
+	keys := make([]string, 0, len(ms.mapped))
+	for key := range ms.mapped {
+		keys = append(keys, key)
+	}
+	ms.lk.Unlock()
+	for _, key := range keys {
+		ms.evict(key)
+	}
+}
+
+// MappedODS memory-maps the CAR file of the EDS with the given root, giving read-only access to
+// the shares of its ODS. It returns ErrNotFound if the EDS is not stored and ErrMappingUnsupported
+// if its CAR file can't be mapped. The returned MappedODS must be released once its shares are not
+// used anymore. Concurrent and consecutive readers of the same EDS share its mapping.
+func (s *Store) MappedODS(ctx context.Context, root share.DataHash) (m *MappedODS, err error) {
+	ctx, span := tracer.Start(ctx, "store/mapped-ods", trace.WithAttributes(
+		share.TraceAttributes(ctx, root, nil)...,
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	has, err := s.Has(ctx, root)
+	if err != nil {
+		return nil, fmt.Errorf("eds/store: failed to check if root exists: %w", err)
+	}
+	if !has {
+		return nil, ErrNotFound
+	}
+
+	key := root.String()
+	filer, ok := s.carStorage.(localFiler)
+	if !ok {
+		return nil, ErrMappingUnsupported
+	}
+	path, ok := filer.localPath(key)
+	if !ok {
+		return nil, ErrMappingUnsupported
+	}
+
+	m, err = s.mapped.acquire(key, func() (*odsMapping, error) {
+		m, err := mapODS(path)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(m.dah.Hash(), root) {
+			m.unmap()
+			return nil, fmt.Errorf("eds/store: content integrity mismatch from CAR for root %x", root)
+		}
+		return m, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.access.record(key)
+	return m, nil
+}
+
+// mapODS memory-maps the CAR file at the given path.
+func mapODS(path string) (*odsMapping, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	// the mapping stays valid after the file is closed
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if stat.Size() == 0 {
+		return nil, fmt.Errorf("eds/store: empty CAR file %s", path)
+	}
+	data, err := mmapFile(f, int(stat.Size()))
+	if err != nil {
+		if errors.Is(err, ErrMappingUnsupported) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("eds/store: mapping CAR file: %w", err)
+	}
+
+	m, err := newODSMapping(data)
+	if err != nil {
+		_ = munmap(data)
+		return nil, fmt.Errorf("eds/store: invalid CAR file %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// newODSMapping locates the leaves of the ODS within the mapped CAR file.
+func newODSMapping(data []byte) (*odsMapping, error) {
+	headerLen, n := binary.Uvarint(data)
+	if n <= 0 || headerLen > uint64(len(data)-n) {
+		return nil, errors.New("malformed header length")
+	}
+	carHeader, err := carv1.ReadHeader(bufio.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	if len(carHeader.Roots) == 0 || len(carHeader.Roots)%4 != 0 {
+		return nil, fmt.Errorf("unexpected amount of roots: %d", len(carHeader.Roots))
+	}
+
+	offset := n + int(headerLen)
+	recordLen, n := binary.Uvarint(data[offset:])
+	if n <= 0 || recordLen < share.NamespaceSize+share.Size || recordLen > uint64(len(data)) {
+		return nil, errors.New("malformed leaf length")
+	}
+
+	m := &odsMapping{
+		data:       data,
+		dah:        dahFromCARHeader(carHeader),
+		width:      len(carHeader.Roots) / 4,
+		offset:     offset,
+		recordSize: n + int(recordLen),
+	}
+	if len(data) < offset+m.width*m.width*m.recordSize {
+		return nil, fmt.Errorf("truncated: %d bytes", len(data))
+	}
+	return m, nil
+}
+
+// unmap unmaps the CAR file.
+func (m *odsMapping) unmap() {
+	if err := munmap(m.data); err != nil {
+		log.Errorw("unmapping CAR file", "err", err)
+	}
+	m.data = nil
+}
+
+// Width returns the width of the ODS.
+func (m *MappedODS) Width() int {
+	return m.width
+}
+
+// DAH returns the DataAvailabilityHeader of the EDS read from the header of its CAR file.
+func (m *MappedODS) DAH() *share.Root {
+	return m.dah
+}
+
+// Share returns the share of the ODS at the given coordinates. The share references the mapping
+// and is read-only.
+func (m *MappedODS) Share(row, col int) share.Share {
+	if m.released.Load() {
+		panic("eds: share of MappedODS read after release")
+	}
+	if row < 0 || row >= m.width || col < 0 || col >= m.width {
+		panic(fmt.Sprintf("eds: share (%d, %d) is outside the ODS of width %d", row, col, m.width))
+	}
+	end := m.offset + (row*m.width+col+1)*m.recordSize
+	// the capacity is capped, so that appending to the share never writes to the mapping
+	return m.data[end-share.Size : end : end]
+}
+
+// Row returns the shares of the given row of the ODS, referencing the mapping.
+func (m *MappedODS) Row(row int) []share.Share {
+	shares := make([]share.Share, m.width)
+	for col := range shares {
+		shares[col] = m.Share(row, col)
+	}
+	return shares
+}
+
+// Release releases the mapping of the CAR file, which is unmapped once it is not used anymore. It
+// is a no-op when called again.
+func (m *MappedODS) Release() {
+	if m.released.Swap(true) {
+		return
+	}
+	m.mappings.release(m.odsMapping)
+}
@@ -0,0 +1,87 @@
+package eds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_MappedODS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	eds, dah := randomEDS(t)
+	err = edsStore.Put(ctx, dah.Hash(), eds)
+	require.NoError(t, err)
+
+	m, err := edsStore.MappedODS(ctx, dah.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, dah.Hash(), m.DAH().Hash())
+
+	width := int(eds.Width() / 2)
+	require.Equal(t, width, m.Width())
+	for i := 0; i < width; i++ {
+		row := m.Row(i)
+		for j := 0; j < width; j++ {
+			assert.Equal(t, eds.GetCell(uint(i), uint(j)), m.Share(i, j))
+			assert.Equal(t, m.Share(i, j), row[j])
+		}
+	}
+	assert.Panics(t, func() { m.Share(width, 0) })
+
+	// the mapping outlives the CAR file
+	shr := append([]byte(nil), m.Share(0, 0)...)
+	err = edsStore.Remove(ctx, dah.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, shr, m.Share(0, 0))
+
+	m.Release()
+	m.Release()
+	assert.Panics(t, func() { m.Share(0, 0) })
+
+	_, err = edsStore.MappedODS(ctx, dah.Hash())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStore_MappedODS_Shared(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	eds, dah := randomEDS(t)
+	err = edsStore.Put(ctx, dah.Hash(), eds)
+	require.NoError(t, err)
+
+	// concurrent readers share the mapping
+	first, err := edsStore.MappedODS(ctx, dah.Hash())
+	require.NoError(t, err)
+	second, err := edsStore.MappedODS(ctx, dah.Hash())
+	require.NoError(t, err)
+	assert.Same(t, first.odsMapping, second.odsMapping)
+
+	// which is kept idle once released, for consecutive readers
+	first.Release()
+	second.Release()
+	third, err := edsStore.MappedODS(ctx, dah.Hash())
+	require.NoError(t, err)
+	assert.Same(t, first.odsMapping, third.odsMapping)
+	assert.Equal(t, eds.GetCell(0, 0), third.Share(0, 0))
+	third.Release()
+
+	// until the EDS is removed
+	err = edsStore.Remove(ctx, dah.Hash())
+	require.NoError(t, err)
+	assert.Empty(t, edsStore.mapped.mapped)
+	assert.Empty(t, edsStore.mapped.idle)
+}
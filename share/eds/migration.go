@@ -0,0 +1,333 @@
+package eds
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// migratingSuffix is appended to keys of CAR files being migrated while they are written.
+const migratingSuffix = ".migrating"
+
+// Migration rewrites CAR files of the Store into a new layout.
+type Migration interface {
+	// Name uniquely identifies the Migration. The Store tracks progress of the Migration under it,
+	// so that an interrupted Migration resumes where it stopped.
+	Name() string
+	// Migrate reads the CAR file of the EDS with the given DataHash from r and writes it in the new
+	// layout into w. The new layout must start with the CARv1 header and the ODS, as the Store
+	// re-extends the EDS from them to serve shares and NMT nodes missing in the CAR file.
+	Migrate(ctx context.Context, root share.DataHash, r io.Reader, w io.Writer) error
+}
+
+// CanonicalMigration rewrites CAR files into the layout written by Store.Put, which keeps only the
+// ODS. As the EDS is recomputed from its ODS and verified against the DataHash, it also normalizes
+// CAR files written by older versions of the Store, e.g. the ones written by WriteEDS.
+//
+// Its Name changes along with the layout, so that CAR files migrated into a previous layout are
+// rewritten again.
+var CanonicalMigration Migration = canonicalMigration{}
+
+type canonicalMigration struct{}
+
+func (canonicalMigration) Name() string {
+	return "canonical-ods"
+}
+
+func (canonicalMigration) Migrate(ctx context.Context, root share.DataHash, r io.Reader, w io.Writer) error {
+	eds, err := ReadEDS(ctx, r, root)
+	if err != nil {
+		return err
+	}
+	return writeODS(eds, w)
+}
+
+// MigrationProgress reports progress of a Migration.
+type MigrationProgress struct {
+	// Total is the amount of EDSes registered on the Store.
+	Total int
+	// Migrated is the amount of EDSes already rewritten by the Migration.
+	Migrated int
+	// Failed is the amount of EDSes the last run of the Migration failed to rewrite.
+	Failed int
+}
+
+// Done checks whether all EDSes of the Store are migrated.
+func (p MigrationProgress) Done() bool {
+	return p.Migrated == p.Total
+}
+
+// Migrate rewrites CAR files of all EDSes registered on the Store using the given Migration.
+// EDSes are rewritten one by one, so the Store keeps serving reads during the Migration. Each
+// EDS is only unavailable for the short time its rewritten CAR file replaces the old one.
+// EDSes migrated by previous runs are skipped, while EDSes that fail to migrate are logged and
+// retried on the next run.
+//
+// NOTE: EDSes put while the Migration runs are written in the layout of Store.Put.
+func (s *Store) Migrate(ctx context.Context, m Migration) (progress MigrationProgress, err error) {
+	ctx, span := tracer.Start(ctx, "store/migrate", trace.WithAttributes(
+		attribute.String("migration", m.Name()),
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	for key, info := range s.dgstr.AllShardsInfo() {
+		if info.Error != nil {
+			continue
+		}
+		progress.Total++
+
+		doneKey := migrationKey(key.String(), m)
+		migrated, err := s.migrations.Has(ctx, doneKey)
+		if err != nil {
+			return progress, fmt.Errorf("eds/store: checking migration progress: %w", err)
+		}
+		if migrated {
+			progress.Migrated++
+			continue
+		}
+
+		err = s.migrateCAR(ctx, m, key.String())
+		if errors.Is(err, ErrNotFound) {
+			progress.Total--
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return progress, ctx.Err()
+			}
+			log.Warnw("migrating EDS", "migration", m.Name(), "key", key, "err", err)
+			progress.Failed++
+			continue
+		}
+		err = s.migrations.Put(ctx, doneKey, []byte{})
+		if err != nil {
+			return progress, fmt.Errorf("eds/store: recording migration progress: %w", err)
+		}
+		progress.Migrated++
+	}
+
+	log.Infow("migration finished",
+		"migration", m.Name(),
+		"migrated", progress.Migrated,
+		"failed", progress.Failed,
+		"total", progress.Total,
+	)
+	return progress, nil
+}
+
+// MigrationProgress returns progress of the given Migration.
+func (s *Store) MigrationProgress(ctx context.Context, m Migration) (MigrationProgress, error) {
+	var progress MigrationProgress
+	for key, info := range s.dgstr.AllShardsInfo() {
+		if info.Error != nil {
+			continue
+		}
+		progress.Total++
+
+		migrated, err := s.migrations.Has(ctx, migrationKey(key.String(), m))
+		if err != nil {
+			return progress, fmt.Errorf("eds/store: checking migration progress: %w", err)
+		}
+		if migrated {
+			progress.Migrated++
+		}
+	}
+	return progress, nil
+}
+
+// migrationKey returns the datastore key marking the EDS with the given key as migrated by the
+// Migration.
+func migrationKey(key string, m Migration) ds.Key {
+	return ds.NewKey(key).ChildString(m.Name())
+}
+
+// forgetMigrations drops the migration progress of the EDS with the given key, as the EDS may be
+// put again later in a different layout.
+func (s *Store) forgetMigrations(ctx context.Context, key string) error {
+	res, err := s.migrations.Query(ctx, query.Query{Prefix: ds.NewKey(key).String(), KeysOnly: true})
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	for entry := range res.Next() {
+		if entry.Error != nil {
+			return entry.Error
+		}
+		err = s.migrations.Delete(ctx, ds.NewKey(entry.Key))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateCAR rewrites the CAR file stored under the given key into a temporary file first, so that
+// the EDS keeps being served from the old one. Then the old file is swapped with the new one and
+// the shard is registered again to rebuild its index.
+func (s *Store) migrateCAR(ctx context.Context, m Migration, key string) error {
+	root, err := hex.DecodeString(key)
+	if err != nil {
+		return fmt.Errorf("decoding shard key: %w", err)
+	}
+
+	unlock := s.lockWrite(root)
+	defer unlock()
+
+	// the EDS might have been removed since the shards were listed
+	has, err := s.Has(ctx, root)
+	if err != nil {
+		return err
+	}
+	if !has {
+		return ErrNotFound
+	}
+
+	release, err := s.acquireWriter(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	tmpKey := key + migratingSuffix
+	err = s.rewriteCAR(ctx, m, root, key, tmpKey)
+	if err != nil {
+		if rerr := s.carStorage.Remove(ctx, tmpKey); rerr != nil && !errors.Is(rerr, ErrNotFound) {
+			log.Warnw("removing partially migrated CAR file", "key", tmpKey, "err", rerr)
+		}
+		return fmt.Errorf("rewriting CAR file: %w", err)
+	}
+
+	access := s.access.get(key)
+	err = s.destroyShard(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	err = s.replaceCAR(ctx, tmpKey, key)
+	if err != nil {
+		return fmt.Errorf("replacing CAR file, migrated copy is kept under %s: %w", tmpKey, err)
+	}
+	err = s.registerShard(ctx, key, nil)
+	if err != nil {
+		return fmt.Errorf("registering migrated CAR file: %w", err)
+	}
+	s.access.restore(key, access)
+
+	if _, ok := s.carStorage.(carRenamer); ok {
+		// the migrated copy became the CAR file itself
+		return nil
+	}
+	err = s.carStorage.Remove(ctx, tmpKey)
+	if err != nil {
+		log.Warnw("removing migrated copy of CAR file", "key", tmpKey, "err", err)
+	}
+	return nil
+}
+
+// carRenamer is implemented by CARStorages able to replace CAR files atomically.
+type carRenamer interface {
+	// rename durably replaces the CAR file stored under to with the one stored under from.
+	rename(from, to string) error
+}
+
+// rename syncs the CAR file to disk before renaming it, and the directory afterwards, so that a
+// crash leaves either the old or the new CAR file in place, but never a truncated one.
+func (fs *fileStorage) rename(from, to string) error {
+	f, err := os.Open(fs.dir + from)
+	if err != nil {
+		return err
+	}
+	err = f.Sync()
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(fs.dir+from, fs.dir+to)
+	if err != nil {
+		return err
+	}
+	dir, err := os.Open(fs.dir)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+// replaceCAR replaces the CAR file stored under key with the migrated one stored under tmpKey.
+// CARStorages unable to rename CAR files get the migrated one copied, which is atomic for object
+// stores, as objects are replaced once their upload completes.
+func (s *Store) replaceCAR(ctx context.Context, tmpKey, key string) error {
+	if renamer, ok := s.carStorage.(carRenamer); ok {
+		return renamer.rename(tmpKey, key)
+	}
+
+	// from this point the old CAR file is gone, so the migrated one is only removed once it is
+	// registered under the original key
+	err := s.carStorage.Remove(ctx, key)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("removing old CAR file: %w", err)
+	}
+	return s.copyCAR(ctx, tmpKey, key)
+}
+
+// rewriteCAR writes the CAR file stored under key rewritten by the Migration under tmpKey.
+func (s *Store) rewriteCAR(ctx context.Context, m Migration, root share.DataHash, key, tmpKey string) error {
+	r, err := s.carStorage.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(m.Migrate(ctx, root, r, pw))
+	}()
+	err = s.carStorage.Put(ctx, tmpKey, pr)
+	// unblock the migration in case storing failed before the whole CAR file was read
+	pr.CloseWithError(err)
+	return err
+}
+
+func (s *Store) copyCAR(ctx context.Context, from, to string) error {
+	r, err := s.carStorage.Get(ctx, from)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return s.carStorage.Put(ctx, to, r)
+}
+
+// migrate runs the Migration configured on the Store in the background.
+func (s *Store) migrate(ctx context.Context) {
+	progress, err := s.Migrate(ctx, s.migration)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Errorw("running migration", "migration", s.migration.Name(), "err", err)
+		}
+		return
+	}
+	if !progress.Done() {
+		log.Warnw("migration incomplete, failed EDSes are retried on restart",
+			"migration", s.migration.Name(),
+			"failed", progress.Failed,
+		)
+	}
+}
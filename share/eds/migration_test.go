@@ -0,0 +1,170 @@
+package eds
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+func TestStore_Migrate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	roots := make([]share.DataHash, 3)
+	for i := range roots {
+		eds, dah := randomEDS(t)
+		err = edsStore.Put(ctx, dah.Hash(), eds)
+		require.NoError(t, err)
+		roots[i] = dah.Hash()
+	}
+
+	t.Run("Failed", func(t *testing.T) {
+		m := &testMigration{name: "failing", err: errors.New("failed")}
+		progress, err := edsStore.Migrate(ctx, m)
+		require.NoError(t, err)
+		assert.Equal(t, MigrationProgress{Total: 3, Failed: 3}, progress)
+
+		// EDSes stay untouched
+		for _, root := range roots {
+			_, err = edsStore.carStorage.Size(ctx, root.String()+migratingSuffix)
+			assert.ErrorIs(t, err, ErrNotFound)
+			_, err = edsStore.Get(ctx, root)
+			require.NoError(t, err)
+		}
+	})
+
+	m := &testMigration{name: "test"}
+	t.Run("Migrate", func(t *testing.T) {
+		progress, err := edsStore.Migrate(ctx, m)
+		require.NoError(t, err)
+		assert.True(t, progress.Done())
+		assert.Equal(t, MigrationProgress{Total: 3, Migrated: 3}, progress)
+		assert.EqualValues(t, 3, m.migrated.Load())
+
+		for _, root := range roots {
+			dah, err := edsStore.GetDAH(ctx, root)
+			require.NoError(t, err)
+			bs, err := edsStore.CARBlockstore(ctx, root)
+			require.NoError(t, err)
+			has, err := bs.Has(ctx, ipld.MustCidFromNamespacedSha256(dah.RowRoots[0]))
+			require.NoError(t, err)
+			assert.True(t, has)
+
+			_, err = edsStore.carStorage.Size(ctx, root.String()+migratingSuffix)
+			assert.ErrorIs(t, err, ErrNotFound)
+		}
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		eds, dah := randomEDS(t)
+		err = edsStore.Put(ctx, dah.Hash(), eds)
+		require.NoError(t, err)
+
+		progress, err := edsStore.MigrationProgress(ctx, m)
+		require.NoError(t, err)
+		assert.Equal(t, MigrationProgress{Total: 4, Migrated: 3}, progress)
+
+		// only the new EDS is migrated
+		progress, err = edsStore.Migrate(ctx, m)
+		require.NoError(t, err)
+		assert.Equal(t, MigrationProgress{Total: 4, Migrated: 4}, progress)
+		assert.EqualValues(t, 4, m.migrated.Load())
+
+		// removed EDS is migrated again once put back
+		err = edsStore.Remove(ctx, dah.Hash())
+		require.NoError(t, err)
+		err = edsStore.Put(ctx, dah.Hash(), eds)
+		require.NoError(t, err)
+		progress, err = edsStore.MigrationProgress(ctx, m)
+		require.NoError(t, err)
+		assert.Equal(t, MigrationProgress{Total: 4, Migrated: 3}, progress)
+	})
+}
+
+func TestStore_StopWaitsForMigration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	dir, ds := t.TempDir(), ds_sync.MutexWrap(datastore.NewMapDatastore())
+	edsStore, err := NewStore(dir, ds)
+	require.NoError(t, err)
+	require.NoError(t, edsStore.Start(ctx))
+	eds, dah := randomEDS(t)
+	require.NoError(t, edsStore.Put(ctx, dah.Hash(), eds))
+	require.NoError(t, edsStore.Stop(ctx))
+
+	// the Store is restarted with the Migration, so that it runs over the put EDS
+	m := &blockingMigration{started: make(chan struct{})}
+	edsStore, err = NewStore(dir, ds, WithMigration(m))
+	require.NoError(t, err)
+	require.NoError(t, edsStore.Start(ctx))
+	select {
+	case <-m.started:
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	require.NoError(t, edsStore.Stop(ctx))
+	assert.True(t, m.exited.Load())
+	// the interrupted migration leaves the CAR file untouched
+	_, err = edsStore.carStorage.Size(ctx, dah.String()+migratingSuffix)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+// testMigration rewrites CAR files with CanonicalMigration, unless err is set.
+type testMigration struct {
+	name     string
+	err      error
+	migrated atomic.Int64
+}
+
+func (m *testMigration) Name() string {
+	return m.name
+}
+
+// blockingMigration blocks migrating until its context is canceled.
+type blockingMigration struct {
+	started chan struct{}
+	exited  atomic.Bool
+}
+
+func (m *blockingMigration) Name() string {
+	return "blocking"
+}
+
+func (m *blockingMigration) Migrate(ctx context.Context, _ share.DataHash, _ io.Reader, _ io.Writer) error {
+	close(m.started)
+	<-ctx.Done()
+	// give Stop the chance to proceed, if it doesn't wait for the migration
+	time.Sleep(time.Millisecond * 50)
+	m.exited.Store(true)
+	return ctx.Err()
+}
+
+func (m *testMigration) Migrate(ctx context.Context, root share.DataHash, r io.Reader, w io.Writer) error {
+	if m.err != nil {
+		return m.err
+	}
+	err := CanonicalMigration.Migrate(ctx, root, r, w)
+	if err == nil {
+		m.migrated.Add(1)
+	}
+	return err
+}
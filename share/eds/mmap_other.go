@@ -0,0 +1,13 @@
+//go:build !darwin && !freebsd && !linux
+
+package eds
+
+import "os"
+
+func mmapFile(*os.File, int) ([]byte, error) {
+	return nil, ErrMappingUnsupported
+}
+
+func munmap([]byte) error {
+	return nil
+}
@@ -89,8 +89,8 @@ func (r *bufferedODSReader) readLeaf() error {
 		return fmt.Errorf("malformed car; header `length`: %v is bigger than %v", l, util.MaxAllowedSectionSize)
 	}
 
-	buf := make([]byte, 8)
-	n := binary.PutUvarint(buf, l)
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], l)
 	r.buf.Write(buf[:n])
 
 	_, err = r.buf.ReadFrom(io.LimitReader(r.carReader, int64(l)))
@@ -92,3 +92,32 @@ func TestODSReaderReconstruction(t *testing.T) {
 	require.Equal(t, eds.RowRoots(), loaded.RowRoots())
 	require.Equal(t, eds.ColRoots(), loaded.ColRoots())
 }
+
+// TestStore_GetODS ensures that the ODS served from memory is the same as the one read from the CAR
+// file.
+func TestStore_GetODS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	eds, dah := randomEDS(t)
+	err = edsStore.Put(ctx, dah.Hash(), eds)
+	require.NoError(t, err)
+
+	// EDS is cached on Put
+	r, err := edsStore.GetODS(ctx, dah.Hash())
+	require.NoError(t, err)
+	fromMemory, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	edsStore.edsCache.remove(dah.String())
+	r, err = edsStore.GetODS(ctx, dah.Hash())
+	require.NoError(t, err)
+	fromCAR, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, fromCAR, fromMemory)
+}
@@ -0,0 +1,45 @@
+package eds
+
+// Option is the functional option that is applied to the Store instance.
+type Option func(*Store)
+
+// WithCARStorage makes the Store keep CAR files in the given CARStorage instead of the local blocks
+// directory. If cacheSize is positive, up to cacheSize recently read CAR files are cached locally.
+func WithCARStorage(storage CARStorage, cacheSize int) Option {
+	return func(s *Store) {
+		s.carStorage = storage
+		s.carCacheSize = cacheSize
+	}
+}
+
+// WithEDSCacheSize sets the maximum total size in bytes of decoded EDSes the Store keeps in memory.
+// Size of 0 disables the cache.
+func WithEDSCacheSize(size int64) Option {
+	return func(s *Store) {
+		s.edsCacheSize = size
+	}
+}
+
+// WithMaxSize makes the Store prune least accessed EDSes with PruneLeastAccessed on every garbage
+// collection, once the total size of stored CAR files exceeds the given size in bytes. Size of 0
+// disables pruning.
+func WithMaxSize(size int64) Option {
+	return func(s *Store) {
+		s.maxSize = size
+	}
+}
+
+// WithWriteConcurrency sets the maximum amount of EDSes the Store writes in parallel.
+func WithWriteConcurrency(n int) Option {
+	return func(s *Store) {
+		s.writeConcurrency = n
+	}
+}
+
+// WithMigration makes the Store run the given Migration in the background once it is started.
+// See Store.Migrate for details.
+func WithMigration(m Migration) Option {
+	return func(s *Store) {
+		s.migration = m
+	}
+}
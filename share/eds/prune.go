@@ -0,0 +1,91 @@
+package eds
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// ShardInfo describes an EDS registered on the Store.
+type ShardInfo struct {
+	DataHash share.DataHash
+	// Size is the size of the stored CAR file in bytes.
+	Size int64
+	// Access holds access statistics of the EDS.
+	Access ShardAccess
+	// Err is set if the shard is in an errored state.
+	Err error
+}
+
+// List returns information about all EDSes registered on the Store.
+func (s *Store) List(ctx context.Context) ([]ShardInfo, error) {
+	shards := s.dgstr.AllShardsInfo()
+	infos := make([]ShardInfo, 0, len(shards))
+	for key, shardInfo := range shards {
+		root, err := hex.DecodeString(key.String())
+		if err != nil {
+			return nil, fmt.Errorf("eds/store: decoding shard key %s: %w", key, err)
+		}
+
+		info := ShardInfo{
+			DataHash: root,
+			Access:   s.access.get(key.String()),
+			Err:      shardInfo.Error,
+		}
+		info.Size, err = s.carStorage.Size(ctx, key.String())
+		if err != nil && info.Err == nil {
+			info.Err = err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// PruneLeastAccessed removes EDSes from the Store, least recently accessed first, until the total
+// size of stored CAR files doesn't exceed maxSize. Among EDSes last accessed at the same time, the
+// least frequently accessed ones are removed first. The empty EDS is never removed.
+// It returns the DataHashes of removed EDSes.
+func (s *Store) PruneLeastAccessed(ctx context.Context, maxSize int64) ([]share.DataHash, error) {
+	infos, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, info := range infos {
+		total += info.Size
+	}
+	if total <= maxSize {
+		return nil, nil
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		ai, aj := infos[i].Access, infos[j].Access
+		if !ai.LastAccess.Equal(aj.LastAccess) {
+			return ai.LastAccess.Before(aj.LastAccess)
+		}
+		return ai.Count < aj.Count
+	})
+
+	var pruned []share.DataHash
+	for _, info := range infos {
+		if total <= maxSize {
+			break
+		}
+		if info.DataHash.IsEmptyRoot() {
+			continue
+		}
+
+		err = s.Remove(ctx, info.DataHash)
+		if err != nil {
+			return pruned, fmt.Errorf("eds/store: pruning %s: %w", info.DataHash, err)
+		}
+		total -= info.Size
+		pruned = append(pruned, info.DataHash)
+	}
+	log.Infow("pruned least accessed EDSes", "amount", len(pruned), "size", total)
+	return pruned, nil
+}
@@ -0,0 +1,168 @@
+package eds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestStore_List(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	eds, dah := randomEDS(t)
+	err = edsStore.Put(ctx, dah.Hash(), eds)
+	require.NoError(t, err)
+
+	infos, err := edsStore.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, share.DataHash(dah.Hash()), infos[0].DataHash)
+	assert.Positive(t, infos[0].Size)
+	assert.NoError(t, infos[0].Err)
+	assert.Zero(t, infos[0].Access.Count)
+	assert.False(t, infos[0].Access.LastAccess.IsZero())
+
+	for i := 0; i < 3; i++ {
+		_, err = edsStore.GetDAH(ctx, dah.Hash())
+		require.NoError(t, err)
+	}
+
+	infos, err = edsStore.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.EqualValues(t, 3, infos[0].Access.Count)
+}
+
+func TestStore_PruneLeastAccessed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	emptyEDS := share.EmptyExtendedDataSquare()
+	emptyDAH := da.NewDataAvailabilityHeader(emptyEDS)
+	err = edsStore.Put(ctx, emptyDAH.Hash(), emptyEDS)
+	require.NoError(t, err)
+
+	roots := make([]share.DataHash, 3)
+	for i := range roots {
+		eds, dah := randomEDS(t)
+		err = edsStore.Put(ctx, dah.Hash(), eds)
+		require.NoError(t, err)
+		roots[i] = dah.Hash()
+		// ensure access times are distinguishable
+		time.Sleep(time.Millisecond)
+	}
+
+	// access the first EDS, making the second one the least recently accessed
+	_, err = edsStore.GetDAH(ctx, roots[0])
+	require.NoError(t, err)
+
+	infos, err := edsStore.List(ctx)
+	require.NoError(t, err)
+	var total, size int64
+	for _, info := range infos {
+		total += info.Size
+		if info.DataHash.IsEmptyRoot() {
+			continue
+		}
+		size = info.Size
+	}
+
+	// nothing to prune
+	pruned, err := edsStore.PruneLeastAccessed(ctx, total)
+	require.NoError(t, err)
+	assert.Empty(t, pruned)
+
+	pruned, err = edsStore.PruneLeastAccessed(ctx, total-size)
+	require.NoError(t, err)
+	assert.Equal(t, []share.DataHash{roots[1]}, pruned)
+
+	pruned, err = edsStore.PruneLeastAccessed(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []share.DataHash{roots[2], roots[0]}, pruned)
+
+	// empty EDS is never pruned
+	has, err := edsStore.Has(ctx, emptyDAH.Hash())
+	require.NoError(t, err)
+	assert.True(t, has)
+}
+
+// TestStore_GCPrunes verifies that EDSes beyond the max size of the store are pruned by the GC
+// periodically.
+func TestStore_GCPrunes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	edsStore, err := NewStore(t.TempDir(), ds, WithMaxSize(1))
+	require.NoError(t, err)
+	edsStore.gcInterval = time.Second
+
+	// kicks off the gc goroutine
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	eds, dah := randomEDS(t)
+	err = edsStore.Put(ctx, dah.Hash(), eds)
+	require.NoError(t, err)
+
+	// wait for gc to run, retry three times
+	var has bool
+	for i := 0; i < 3; i++ {
+		time.Sleep(edsStore.gcInterval)
+		has, err = edsStore.Has(ctx, dah.Hash())
+		require.NoError(t, err)
+		if !has {
+			break
+		}
+	}
+	assert.False(t, has)
+}
+
+func TestStore_AccessPersistence(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	edsStore, err := NewStore(dir, ds)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	eds, dah := randomEDS(t)
+	err = edsStore.Put(ctx, dah.Hash(), eds)
+	require.NoError(t, err)
+	_, err = edsStore.GetDAH(ctx, dah.Hash())
+	require.NoError(t, err)
+
+	err = edsStore.Stop(ctx)
+	require.NoError(t, err)
+
+	edsStore, err = NewStore(dir, ds)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	stat := edsStore.access.get(share.DataHash(dah.Hash()).String())
+	assert.EqualValues(t, 1, stat.Count)
+}
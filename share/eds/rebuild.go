@@ -0,0 +1,155 @@
+package eds
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/filecoin-project/dagstore/shard"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// RebuildProgress reports progress of Store.RebuildIndexes.
+type RebuildProgress struct {
+	// Total is the amount of CAR files found in the Store.
+	Total int
+	// Rebuilt is the amount of EDSes which indexes are rebuilt.
+	Rebuilt int
+	// Failed is the amount of EDSes which indexes failed to be rebuilt.
+	Failed int
+}
+
+// Done checks whether indexes of all EDSes of the Store are rebuilt.
+func (p RebuildProgress) Done() bool {
+	return p.Rebuilt == p.Total
+}
+
+// RebuildIndexes rebuilds indexes of all CAR files kept in the Store's CARStorage, using the given
+// amount of workers in parallel. Every CAR file is registered on the DAGStore again, which rebuilds
+// its CID index and the top-level index mapping CIDs onto EDSes. It recovers the Store from lost or
+// corrupted indexes without fetching EDSes from the network again, including the ones which
+// DAGStore registration was lost.
+//
+// EDSes that fail to be rebuilt are logged and counted as failed. The given report function, if
+// any, is called with the progress after every EDS.
+func (s *Store) RebuildIndexes(
+	ctx context.Context,
+	workers int,
+	report func(RebuildProgress),
+) (progress RebuildProgress, err error) {
+	ctx, span := tracer.Start(ctx, "store/rebuild-indexes", trace.WithAttributes(
+		attribute.Int("workers", workers),
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	if workers <= 0 {
+		return progress, fmt.Errorf("eds/store: rebuild workers must be positive, got %d", workers)
+	}
+
+	keys, err := s.carStorage.Keys(ctx)
+	if err != nil {
+		return progress, fmt.Errorf("eds/store: listing CAR files: %w", err)
+	}
+	roots := make([]share.DataHash, 0, len(keys))
+	for _, key := range keys {
+		root, err := hex.DecodeString(key)
+		if err != nil || share.DataHash(root).Validate() != nil {
+			// e.g. partially migrated CAR files
+			continue
+		}
+		roots = append(roots, root)
+	}
+	progress.Total = len(roots)
+
+	var (
+		wg     sync.WaitGroup
+		lk     sync.Mutex
+		rootCh = make(chan share.DataHash)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for root := range rootCh {
+				err := s.rebuildShard(ctx, root)
+				if err != nil && ctx.Err() == nil {
+					log.Warnw("rebuilding EDS indexes", "hash", root.String(), "err", err)
+				}
+
+				lk.Lock()
+				if err != nil {
+					progress.Failed++
+				} else {
+					progress.Rebuilt++
+				}
+				if report != nil {
+					report(progress)
+				}
+				lk.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, root := range roots {
+		select {
+		case rootCh <- root:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(rootCh)
+	wg.Wait()
+	if ctx.Err() != nil {
+		return progress, ctx.Err()
+	}
+
+	log.Infow("indexes rebuilt",
+		"rebuilt", progress.Rebuilt,
+		"failed", progress.Failed,
+		"total", progress.Total,
+	)
+	return progress, nil
+}
+
+// rebuildShard unregisters the EDS with the given root from the DAGStore, if it's registered, and
+// registers it again from its CAR file, indexing it from scratch.
+func (s *Store) rebuildShard(ctx context.Context, root share.DataHash) error {
+	unlock := s.lockWrite(root)
+	defer unlock()
+
+	release, err := s.acquireWriter(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	key := root.String()
+	access := s.access.get(key)
+	if _, err := s.dgstr.GetShardInfo(shard.KeyFromString(key)); err == nil {
+		err = s.destroyShard(ctx, key)
+		// the index being rebuilt might be lost already
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	err = s.registerShard(ctx, key, nil)
+	if err != nil {
+		return err
+	}
+	// keep access statistics of the EDS, unless they were lost along with the indexes
+	if !access.LastAccess.IsZero() {
+		s.access.restore(key, access)
+	}
+	return nil
+}
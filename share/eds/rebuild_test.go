@@ -0,0 +1,70 @@
+package eds
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+func TestStore_RebuildIndexes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	edsStore, err := NewStore(dir, ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	roots := make([]share.Root, 3)
+	for i := range roots {
+		eds, dah := randomEDS(t)
+		err = edsStore.Put(ctx, dah.Hash(), eds)
+		require.NoError(t, err)
+		roots[i] = dah
+	}
+	err = edsStore.Stop(ctx)
+	require.NoError(t, err)
+
+	// lose both the DAGStore registrations and the indexes, keeping only CAR files
+	err = os.RemoveAll(dir + indexPath)
+	require.NoError(t, err)
+	edsStore, err = NewStore(dir, ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = edsStore.Stop(ctx) })
+
+	has, err := edsStore.Has(ctx, roots[0].Hash())
+	require.NoError(t, err)
+	require.False(t, has)
+
+	var reports int
+	progress, err := edsStore.RebuildIndexes(ctx, 2, func(RebuildProgress) { reports++ })
+	require.NoError(t, err)
+	assert.Equal(t, RebuildProgress{Total: 3, Rebuilt: 3}, progress)
+	assert.Equal(t, 3, reports)
+
+	for _, dah := range roots {
+		_, err = edsStore.Get(ctx, dah.Hash())
+		require.NoError(t, err)
+		has, err := edsStore.Blockstore().Has(ctx, ipld.MustCidFromNamespacedSha256(dah.RowRoots[0]))
+		require.NoError(t, err)
+		assert.True(t, has)
+	}
+
+	// indexes of registered EDSes are rebuilt as well
+	progress, err = edsStore.RebuildIndexes(ctx, 2, nil)
+	require.NoError(t, err)
+	assert.True(t, progress.Done())
+	_, err = edsStore.Get(ctx, roots[0].Hash())
+	require.NoError(t, err)
+}
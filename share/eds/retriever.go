@@ -3,6 +3,7 @@ package eds
 import (
 	"context"
 	"errors"
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -40,22 +41,40 @@ var (
 //	 ---- ----
 //
 // Retriever randomly picks one of the data square quadrants and tries to request them one by one
-// until it is able to reconstruct the whole square.
+// until it is able to reconstruct the whole square. It plans requests across rows and columns by the
+// PeerKnowledge learned from shrex responses and by the shares already retrieved, requesting the
+// quadrant served by the most known peers, then the one that misses the most shares, and skipping
+// roots whose shares in the quadrant are all retrieved.
 type Retriever struct {
 	bServ blockservice.BlockService
+	peers *PeerKnowledge
+
+	// sessions tracks ongoing retrievals to report their progress
+	sessionsLk sync.Mutex
+	sessions   map[*retrievalSession]struct{}
 }
 
 // NewRetriever creates a new instance of the Retriever over IPLD BlockService and rmst2d.Codec
 func NewRetriever(bServ blockservice.BlockService) *Retriever {
-	return &Retriever{bServ: bServ}
+	return &Retriever{
+		bServ:    bServ,
+		sessions: make(map[*retrievalSession]struct{}),
+	}
+}
+
+// SetPeerKnowledge sets the knowledge about peers serving data squares to plan quadrant requests by.
+// It must be called before the Retriever is used.
+func (r *Retriever) SetPeerKnowledge(peers *PeerKnowledge) {
+	r.peers = peers
 }
 
 // Retrieve retrieves all the data committed to DataAvailabilityHeader.
 //
 // If not available locally, it aims to request from the network only one quadrant (1/4) of the
 // data square and reconstructs the other three quadrants (3/4). If the requested quadrant is not
-// available within RetrieveQuadrantTimeout, it starts requesting another quadrant until either the
-// data is reconstructed, context is canceled or ErrByzantine is generated.
+// available within RetrieveQuadrantTimeout, or its retrieval stalls for RetrieveStallTimeout even
+// after its missing shares are requested from other peers, it starts requesting another quadrant
+// until either the data is reconstructed, context is canceled or ErrByzantine is generated.
 func (r *Retriever) Retrieve(ctx context.Context, dah *da.DataAvailabilityHeader) (*rsmt2d.ExtendedDataSquare, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel() // cancels all the ongoing requests if reconstruction succeeds early
@@ -73,6 +92,8 @@ func (r *Retriever) Retrieve(ctx context.Context, dah *da.DataAvailabilityHeader
 		return nil, err
 	}
 	defer ses.Close()
+	r.track(ses)
+	defer r.untrack(ses)
 
 	// wait for a signal to start reconstruction
 	// try until either success or context or bad data
@@ -88,6 +109,10 @@ func (r *Retriever) Retrieve(ctx context.Context, dah *da.DataAvailabilityHeader
 			var errByz *rsmt2d.ErrByzantineData
 			if errors.As(err, &errByz) {
 				span.RecordError(err)
+				// unordered namespaces can't be proven with BEFP, as honest NMTs can't rebuild such axis
+				if errUnordered := ses.unorderedNamespaces(errByz); errUnordered != nil {
+					return nil, errUnordered
+				}
 				return nil, byzantine.NewErrByzantine(ctx, r.bServ, dah, errByz)
 			}
 
@@ -103,19 +128,44 @@ func (r *Retriever) Retrieve(ctx context.Context, dah *da.DataAvailabilityHeader
 // quadrant request retries. Also, provides an API
 // to reconstruct the block once enough shares are fetched.
 type retrievalSession struct {
-	dah  *da.DataAvailabilityHeader
-	bget blockservice.BlockGetter
+	dah   *da.DataAvailabilityHeader
+	bServ blockservice.BlockService
+	peers *PeerKnowledge
+	bget  *squareSession
+	// reassigned are the sessions requesting the shares of stalled quadrants from other peers
+	reassigned   []*squareSession
+	reassignedLk sync.Mutex
 
 	// TODO(@Wondertan): Extract into a separate data structure
 	// https://github.com/celestiaorg/rsmt2d/issues/135
 	squareQuadrants  []*quadrant
 	squareCellsLks   [][]sync.Mutex
+	squareCellsSet   []atomic.Bool
 	squareCellsCount uint32
 	squareSig        chan struct{}
 	squareDn         chan struct{}
 	squareLk         sync.RWMutex
 	square           *rsmt2d.ExtendedDataSquare
 
+	// squareCells references the written shares, as the accessors of the square copy them and
+	// can't tell the shares not written
+	squareCells [][]byte
+	// rowCellsCount and colCellsCount are the amounts of shares written per row and column
+	rowCellsCount []atomic.Int32
+	colCellsCount []atomic.Int32
+	// axesDecoding is the amount of rows and columns being decoded
+	axesDecoding atomic.Int32
+	// axesDecoded is the amount of rows and columns decoded
+	axesDecoded atomic.Int32
+	// decodeSem bounds the amount of rows and columns decoded at once
+	decodeSem chan struct{}
+
+	// height of the header the square is committed to, if known
+	height             uint64
+	started            time.Time
+	quadrantsRequested atomic.Int32
+	quadrantsStalled   atomic.Int32
+
 	span trace.Span
 }
 
@@ -134,14 +184,23 @@ func (r *Retriever) newSession(ctx context.Context, dah *da.DataAvailabilityHead
 
 	ses := &retrievalSession{
 		dah:             dah,
-		bget:            blockservice.NewSession(ctx, r.bServ),
+		bServ:           r.bServ,
+		peers:           r.peers,
+		bget:            newSquareSession(ctx, r.bServ, dah),
 		squareQuadrants: newQuadrants(dah),
 		squareCellsLks:  make([][]sync.Mutex, size),
+		squareCellsSet:  make([]atomic.Bool, size*size),
+		squareCells:     make([][]byte, size*size),
+		rowCellsCount:   make([]atomic.Int32, size),
+		colCellsCount:   make([]atomic.Int32, size),
+		decodeSem:       make(chan struct{}, runtime.GOMAXPROCS(0)),
 		squareSig:       make(chan struct{}, 1),
 		squareDn:        make(chan struct{}),
 		square:          square,
+		started:         time.Now(),
 		span:            trace.SpanFromContext(ctx),
 	}
+	ses.height, _ = share.HeightFromContext(ctx)
 	for i := range ses.squareCellsLks {
 		ses.squareCellsLks[i] = make([]sync.Mutex, size)
 	}
@@ -176,9 +235,28 @@ func (rs *retrievalSession) Reconstruct(ctx context.Context) (*rsmt2d.ExtendedDa
 	}
 	log.Infow("data square reconstructed", "data_hash", rs.dah.String(), "size", len(rs.dah.RowRoots))
 	close(rs.squareDn)
+	// the shares still wanted are not needed anymore
+	rs.closeSessions()
 	return rs.square, nil
 }
 
+// unorderedNamespaces checks whether the axis rsmt2d failed to rebuild is committed to by the DAH
+// with shares unordered by namespace.
+func (rs *retrievalSession) unorderedNamespaces(errByz *rsmt2d.ErrByzantineData) *byzantine.ErrUnorderedNamespaces {
+	shares := errByz.Shares
+	if shares == nil {
+		// the axis failed the sanity check before repairing, so it is complete in the square
+		rs.squareLk.RLock()
+		if errByz.Axis == rsmt2d.Row {
+			shares = rs.square.Row(errByz.Index)
+		} else {
+			shares = rs.square.Col(errByz.Index)
+		}
+		rs.squareLk.RUnlock()
+	}
+	return byzantine.FindUnorderedNamespaces(rs.dah, errByz.Axis, errByz.Index, shares)
+}
+
 // isReconstructed report true whether the square attached to the session
 // is already reconstructed.
 func (rs *retrievalSession) isReconstructed() bool {
@@ -193,59 +271,193 @@ func (rs *retrievalSession) isReconstructed() bool {
 
 func (rs *retrievalSession) Close() error {
 	defer rs.span.End()
+	rs.closeSessions()
 	return nil
 }
 
+// newBitswapSession starts a new session for requesting the shares of a stalled quadrant. Bitswap
+// sessions stick to the peers that served them, so a new one asks other peers than the ones the
+// stalled request waits for.
+func (rs *retrievalSession) newBitswapSession(ctx context.Context) *squareSession {
+	ses := newSquareSession(ctx, rs.bServ, rs.dah)
+	rs.reassignedLk.Lock()
+	rs.reassigned = append(rs.reassigned, ses)
+	rs.reassignedLk.Unlock()
+	return ses
+}
+
+// closeSessions closes all the sessions requesting shares of the square.
+func (rs *retrievalSession) closeSessions() {
+	rs.bget.Close()
+	rs.reassignedLk.Lock()
+	defer rs.reassignedLk.Unlock()
+	for _, ses := range rs.reassigned {
+		ses.Close()
+	}
+}
+
+// prefetched returns the amount of prefetched nodes requested by all the sessions of the square.
+func (rs *retrievalSession) prefetched() int64 {
+	prefetched := rs.bget.prefetched.Load()
+	rs.reassignedLk.Lock()
+	defer rs.reassignedLk.Unlock()
+	for _, ses := range rs.reassigned {
+		prefetched += ses.prefetched.Load()
+	}
+	return prefetched
+}
+
 // request kicks off quadrants requests.
-// It instantly requests a quadrant and periodically requests more
-// until either context is canceled or we are out of quadrants.
+// It instantly requests a quadrant and requests more once the previous one times out, is retrieved
+// without being enough to reconstruct the square, or stalls even after being reassigned, until
+// either context is canceled or we are out of quadrants.
 func (rs *retrievalSession) request(ctx context.Context) {
-	t := time.NewTicker(RetrieveQuadrantTimeout)
-	defer t.Stop()
-	for retry := 0; retry < len(rs.squareQuadrants); retry++ {
-		q := rs.squareQuadrants[retry]
-		log.Debugw("requesting quadrant",
-			"axis", q.source,
-			"x", q.x,
-			"y", q.y,
-			"size", len(q.roots),
+	quadrants := rs.squareQuadrants
+	for len(quadrants) > 0 {
+		var (
+			q     *quadrant
+			roots []int
 		)
-		rs.span.AddEvent("requesting quadrant", trace.WithAttributes(
-			attribute.Int("axis", int(q.source)),
-			attribute.Int("x", q.x),
-			attribute.Int("y", q.y),
-			attribute.Int("size", len(q.roots)),
-		))
-		rs.doRequest(ctx, q)
+		q, roots, quadrants = planQuadrant(quadrants, rs.isCellSet, rs.servedBy)
+		if q == nil {
+			// all the shares are retrieved already
+			return
+		}
+		rs.quadrantsRequested.Add(1)
+		if err := rs.requestQuadrant(ctx, q, roots); err != nil {
+			return
+		}
+	}
+}
+
+// quadrantResult is the outcome of waiting for a requested quadrant.
+type quadrantResult int
+
+const (
+	// quadrantTimedOut means the quadrant was not retrieved within RetrieveQuadrantTimeout.
+	quadrantTimedOut quadrantResult = iota
+	// quadrantStalled means none of the missing shares of the quadrant were retrieved within
+	// RetrieveStallTimeout.
+	quadrantStalled
+	// quadrantRetrieved means all the shares of the quadrant were retrieved.
+	quadrantRetrieved
+)
+
+// requestQuadrant requests the given roots of the quadrant and waits for the request to end. A
+// stalled request is reassigned once: the missing shares of the quadrant are requested through a
+// new bitswap session, which asks other peers than the ones the stalled request waits for.
+func (rs *retrievalSession) requestQuadrant(ctx context.Context, q *quadrant, roots []int) error {
+	quadrantTimeout, stallTimeout := RetrieveQuadrantTimeout, RetrieveStallTimeout
+	rs.traceQuadrant(log.Debugw, "requesting quadrant", q, attribute.Int("roots", len(roots)))
+	rs.doRequest(ctx, rs.bget, q, roots)
+
+	reassigned := false
+	for {
+		result, err := rs.await(ctx, q, quadrantTimeout, stallTimeout)
+		if err != nil {
+			return err
+		}
+
+		switch result {
+		case quadrantTimedOut:
+			rs.traceQuadrant(log.Warnw, "quadrant request timed out, requesting another quadrant", q,
+				attribute.String("timeout", quadrantTimeout.String()))
+		case quadrantRetrieved:
+			rs.traceQuadrant(log.Debugw, "quadrant retrieved, requesting another quadrant", q)
+		case quadrantStalled:
+			rs.quadrantsStalled.Add(1)
+			if reassigned {
+				rs.traceQuadrant(log.Warnw, "reassigned quadrant request stalled, requesting another quadrant", q,
+					attribute.String("timeout", stallTimeout.String()))
+				return nil
+			}
+			reassigned = true
+			roots, _ = q.missingRoots(rs.isCellSet)
+			rs.traceQuadrant(log.Warnw, "quadrant request stalled, requesting missing shares from other peers", q,
+				attribute.String("timeout", stallTimeout.String()),
+				attribute.Int("roots", len(roots)),
+			)
+			rs.doRequest(ctx, rs.newBitswapSession(ctx), q, roots)
+			continue
+		}
+		return nil
+	}
+}
+
+// traceQuadrant logs the event of the quadrant request with the given logging function and records
+// it into the span of the session.
+func (rs *retrievalSession) traceQuadrant(
+	logFn func(string, ...interface{}),
+	event string,
+	q *quadrant,
+	attrs ...attribute.KeyValue,
+) {
+	attrs = append(attrs,
+		attribute.Int("axis", int(q.source)),
+		attribute.Int("x", q.x),
+		attribute.Int("y", q.y),
+		attribute.Int("size", len(q.roots)),
+	)
+	keyvals := make([]interface{}, 0, len(attrs)*2)
+	for _, attr := range attrs {
+		keyvals = append(keyvals, string(attr.Key), attr.Value.Emit())
+	}
+	logFn(event, keyvals...)
+	rs.span.AddEvent(event, trace.WithAttributes(attrs...))
+}
+
+// await waits for the requested quadrant to either time out, stall or be retrieved, whatever comes
+// first. Retrieval of the quadrant stalls, if none of its missing shares are retrieved within the
+// stall timeout.
+func (rs *retrievalSession) await(
+	ctx context.Context,
+	q *quadrant,
+	quadrantTimeout, stallTimeout time.Duration,
+) (quadrantResult, error) {
+	timeout := time.NewTimer(quadrantTimeout)
+	defer timeout.Stop()
+	stall := time.NewTicker(stallTimeout)
+	defer stall.Stop()
+
+	_, missing := q.missingRoots(rs.isCellSet)
+	for {
 		select {
-		case <-t.C:
+		case <-timeout.C:
+			return quadrantTimedOut, nil
+		case <-stall.C:
+			_, nowMissing := q.missingRoots(rs.isCellSet)
+			if nowMissing == 0 {
+				// the quadrant is retrieved, but wasn't enough to reconstruct the square
+				return quadrantRetrieved, nil
+			}
+			if nowMissing == missing {
+				return quadrantStalled, nil
+			}
+			missing = nowMissing
 		case <-ctx.Done():
-			return
+			return 0, ctx.Err()
 		}
-		log.Warnw("quadrant request timeout",
-			"timeout", RetrieveQuadrantTimeout.String(),
-			"axis", q.source,
-			"x", q.x,
-			"y", q.y,
-			"size", len(q.roots),
-		)
-		rs.span.AddEvent("quadrant request timeout", trace.WithAttributes(
-			attribute.Int("axis", int(q.source)),
-			attribute.Int("x", q.x),
-			attribute.Int("y", q.y),
-			attribute.Int("size", len(q.roots)),
-		))
 	}
 }
 
-// doRequest requests the given quadrant by requesting halves of axis(Row or Col) using GetShares
-// and fills shares into rs.square slice.
-func (rs *retrievalSession) doRequest(ctx context.Context, q *quadrant) {
+// servedBy returns the amount of peers known to serve all the shares of the quadrant.
+func (rs *retrievalSession) servedBy(q *quadrant) int {
+	return rs.peers.servedBy(rs.dah.Hash(), q)
+}
+
+// isCellSet reports whether the share at the given position is retrieved.
+func (rs *retrievalSession) isCellSet(x, y int) bool {
+	return rs.squareCellsSet[x*len(rs.dah.RowRoots)+y].Load()
+}
+
+// doRequest requests the given roots of the quadrant through the given session by requesting halves
+// of axis(Row or Col) using GetShares and fills shares into rs.square slice.
+func (rs *retrievalSession) doRequest(ctx context.Context, bget *squareSession, q *quadrant, roots []int) {
 	size := len(q.roots)
-	for i, root := range q.roots {
+	for _, i := range roots {
 		go func(i int, root cid.Cid) {
 			// get the root node
-			nd, err := ipld.GetNode(ctx, rs.bget, root)
+			nd, err := ipld.GetNode(ctx, bget, root)
 			if err != nil {
 				rs.span.RecordError(err, trace.WithAttributes(
 					attribute.String("requesting-root", root.String()),
@@ -256,7 +468,7 @@ func (rs *retrievalSession) doRequest(ctx context.Context, q *quadrant) {
 			// and go get shares of left or the right side of the whole col/row axis
 			// the left or the right side of the tree represent some portion of the quadrant
 			// which we put into the rs.square share-by-share by calculating shares' indexes using q.index
-			share.GetShares(ctx, rs.bget, nd.Links()[q.x].Cid, size, func(j int, share share.Share) {
+			share.GetShares(ctx, bget, nd.Links()[q.x].Cid, size, func(j int, share share.Share) {
 				// NOTE: Each share can appear twice here, for a Row and Col, respectively.
 				// These shares are always equal, and we allow only the first one to be written
 				// in the square.
@@ -265,41 +477,66 @@ func (rs *retrievalSession) doRequest(ctx context.Context, q *quadrant) {
 				//
 				// calc position of the share
 				x, y := q.pos(i, j)
-				// try to lock the share
-				ok := rs.squareCellsLks[x][y].TryLock()
-				if !ok {
-					// if already locked and written - do nothing
-					return
-				}
-				// The R lock here is *not* to protect rs.square from multiple
-				// concurrent shares writes but to avoid races between share writes and
-				// repairing attempts.
-				// Shares are written atomically in their own slice slots and these "writes" do
-				// not need synchronization!
-				rs.squareLk.RLock()
-				defer rs.squareLk.RUnlock()
-				// the routine could be blocked above for some time during which the square
-				// might be reconstructed, if so don't write anything and return
-				if rs.isReconstructed() {
-					return
-				}
-				if rs.square.GetCell(uint(x), uint(y)) != nil {
-					return
-				}
-				rs.square.SetCell(uint(x), uint(y), share)
-				// if we have >= 1/4 of the square we can start trying to Reconstruct
-				// TODO(@Wondertan): This is not an ideal way to know when to start
-				//  reconstruction and can cause idle reconstruction tries in some cases,
-				//  but it is totally fine for the happy case and for now.
-				//  The earlier we correctly know that we have the full square - the earlier
-				//  we cancel ongoing requests - the less data is being wastedly transferred.
-				if atomic.AddUint32(&rs.squareCellsCount, 1) >= uint32(size*size) {
-					select {
-					case rs.squareSig <- struct{}{}:
-					default:
-					}
-				}
+				rs.setCell(ctx, x, y, share)
 			})
-		}(i, root)
+		}(i, q.roots[i])
+	}
+}
+
+// setCell writes the retrieved or decoded share into the square, unless the share at the position
+// is already written. Rows and columns crossing half of their shares written are decoded in the
+// background.
+func (rs *retrievalSession) setCell(ctx context.Context, x, y int, share share.Share) {
+	// try to lock the share
+	ok := rs.squareCellsLks[x][y].TryLock()
+	if !ok {
+		// if already locked and written - do nothing
+		return
+	}
+	// The R lock here is *not* to protect rs.square from multiple
+	// concurrent shares writes but to avoid races between share writes and
+	// repairing attempts.
+	// Shares are written atomically in their own slice slots and these "writes" do
+	// not need synchronization!
+	rs.squareLk.RLock()
+	defer rs.squareLk.RUnlock()
+	// the routine could be blocked above for some time during which the square
+	// might be reconstructed, if so don't write anything and return
+	if rs.isReconstructed() {
+		return
+	}
+	if rs.square.GetCell(uint(x), uint(y)) != nil {
+		return
+	}
+	width := len(rs.dah.RowRoots)
+	rs.square.SetCell(uint(x), uint(y), share)
+	rs.squareCells[x*width+y] = share
+	rs.squareCellsSet[x*width+y].Store(true)
+	// the axes are decoded once, when they cross half of their shares written
+	if rs.rowCellsCount[x].Add(1) == int32(width/2) {
+		rs.decodeAxis(ctx, rsmt2d.Row, x)
+	}
+	if rs.colCellsCount[y].Add(1) == int32(width/2) {
+		rs.decodeAxis(ctx, rsmt2d.Col, y)
+	}
+	atomic.AddUint32(&rs.squareCellsCount, 1)
+	rs.signal()
+}
+
+// signal signals that reconstruction can be attempted, once 1/4 of the square is written and no
+// rows or columns are being decoded, so that decoding finishes before repairing the square.
+func (rs *retrievalSession) signal() {
+	// TODO(@Wondertan): This is not an ideal way to know when to start
+	//  reconstruction and can cause idle reconstruction tries in some cases,
+	//  but it is totally fine for the happy case and for now.
+	//  The earlier we correctly know that we have the full square - the earlier
+	//  we cancel ongoing requests - the less data is being wastedly transferred.
+	odsWidth := len(rs.dah.RowRoots) / 2
+	if atomic.LoadUint32(&rs.squareCellsCount) < uint32(odsWidth*odsWidth) || rs.axesDecoding.Load() > 0 {
+		return
+	}
+	select {
+	case rs.squareSig <- struct{}{}:
+	default:
 	}
 }
@@ -0,0 +1,118 @@
+package eds
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/minio/sha256-simd"
+
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/libs/bufpool"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+var errAxisRootMismatch = errors.New("decoded axis doesn't match its root")
+
+// decodeAxis decodes the given row or column in the background, once half of its shares are
+// written, and writes the decoded shares into the square. This way erasure decoding overlaps with
+// retrieval of the rest of the square, instead of starting once enough of the square is retrieved,
+// and shares decoded for rows make columns cross the threshold and vice versa.
+//
+// Axes that fail decoding or don't match their roots are left to the repair of the whole square,
+// which detects byzantine data.
+func (rs *retrievalSession) decodeAxis(ctx context.Context, axis rsmt2d.Axis, idx int) {
+	rs.axesDecoding.Add(1)
+	go func() {
+		defer func() {
+			rs.axesDecoding.Add(-1)
+			// reconstruction might have been held off by the decoding
+			rs.signal()
+		}()
+
+		select {
+		case rs.decodeSem <- struct{}{}:
+			defer func() { <-rs.decodeSem }()
+		case <-ctx.Done():
+			return
+		}
+		if rs.isReconstructed() {
+			return
+		}
+
+		shares, err := rs.decode(axis, idx)
+		if err != nil {
+			log.Debugw("decoding axis", "axis", axis, "index", idx, "err", err)
+			return
+		}
+		rs.axesDecoded.Add(1)
+		for i, shr := range shares {
+			x, y := axisPos(axis, idx, i)
+			rs.setCell(ctx, x, y, shr)
+		}
+	}()
+}
+
+// decode decodes the given row or column from the shares written so far and checks it against
+// its root.
+func (rs *retrievalSession) decode(axis rsmt2d.Axis, idx int) ([]share.Share, error) {
+	width := len(rs.dah.RowRoots)
+	shares := make([][]byte, width)
+	for i := range shares {
+		x, y := axisPos(axis, idx, i)
+		if rs.squareCellsSet[x*width+y].Load() {
+			shares[i] = rs.squareCells[x*width+y]
+		}
+	}
+
+	shares, err := share.DefaultRSMT2DCodec().Decode(shares)
+	if err != nil {
+		return nil, err
+	}
+
+	// the tree keeps the pushed leaves until it is dropped, so they are namespaced in a borrowed
+	// buffer given back once the root is computed
+	leafSize := share.NamespaceSize + share.Size
+	buf := bufpool.Get(width * leafSize)
+	defer buf.Release()
+	tree := nmt.New(
+		sha256.New(),
+		nmt.NamespaceIDSize(share.NamespaceSize),
+		nmt.IgnoreMaxNamespace(ipld.NMTIgnoreMaxNamespace),
+	)
+	for i, shr := range shares {
+		x, y := axisPos(axis, idx, i)
+		quadrant := 0
+		if x >= width/2 || y >= width/2 {
+			quadrant = 1
+		}
+		leaf := appendNamespace(buf.Bytes[i*leafSize:i*leafSize:(i+1)*leafSize], quadrant, shr)
+		if err := tree.Push(leaf); err != nil {
+			return nil, err
+		}
+	}
+	root, err := tree.Root()
+	if err != nil {
+		return nil, err
+	}
+	expected := rs.dah.RowRoots[idx]
+	if axis == rsmt2d.Col {
+		expected = rs.dah.ColumnRoots[idx]
+	}
+	if !bytes.Equal(root, expected) {
+		return nil, errAxisRootMismatch
+	}
+	return shares, nil
+}
+
+// axisPos returns the position in the square of the share with the given index in the row or
+// column.
+func axisPos(axis rsmt2d.Axis, idx, i int) (x, y int) {
+	if axis == rsmt2d.Row {
+		return idx, i
+	}
+	return i, idx
+}
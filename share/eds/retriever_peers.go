@@ -0,0 +1,149 @@
+package eds
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/libs/cache"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// peerKnowledgeSize is the amount of data squares PeerKnowledge keeps track of.
+const peerKnowledgeSize = 256
+
+// PeerKnowledge tracks which parts of data squares peers are known to serve, as learned from shrex
+// responses. Retriever uses it to plan quadrant requests towards the parts of the square served by
+// the most peers. A nil PeerKnowledge knows nothing.
+type PeerKnowledge struct {
+	squares *cache.Cache[string, *squarePeers]
+}
+
+// squarePeers is the knowledge about peers serving a single data square.
+type squarePeers struct {
+	lk sync.Mutex
+	// peers maps the peers to the rows of the square they served, nil for the whole square
+	peers map[peer.ID]map[int]struct{}
+}
+
+// NewPeerKnowledge creates a new PeerKnowledge.
+func NewPeerKnowledge() *PeerKnowledge {
+	return &PeerKnowledge{
+		squares: cache.New[string, *squarePeers](peerKnowledgeSize),
+	}
+}
+
+// ObserveSquare records that the peer served the whole square of the given DataHash, e.g. over
+// shrex/eds.
+func (pk *PeerKnowledge) ObserveSquare(hash share.DataHash, id peer.ID) {
+	if pk == nil {
+		return
+	}
+	sp := pk.square(hash)
+	sp.lk.Lock()
+	defer sp.lk.Unlock()
+	sp.peers[id] = nil
+}
+
+// ObserveRows records that the peer served the given rows of the square of the given DataHash, e.g.
+// over shrex/nd.
+func (pk *PeerKnowledge) ObserveRows(hash share.DataHash, id peer.ID, rows ...int) {
+	if pk == nil || len(rows) == 0 {
+		return
+	}
+	sp := pk.square(hash)
+	sp.lk.Lock()
+	defer sp.lk.Unlock()
+	served, ok := sp.peers[id]
+	if ok && served == nil {
+		// the peer is known to serve the whole square already
+		return
+	}
+	if served == nil {
+		served = make(map[int]struct{}, len(rows))
+		sp.peers[id] = served
+	}
+	for _, row := range rows {
+		served[row] = struct{}{}
+	}
+}
+
+// ObserveMissing records that the peer does not serve the square of the given DataHash.
+func (pk *PeerKnowledge) ObserveMissing(hash share.DataHash, id peer.ID) {
+	if pk == nil {
+		return
+	}
+	sp, ok := pk.squares.Get(hash.String())
+	if !ok {
+		return
+	}
+	sp.lk.Lock()
+	defer sp.lk.Unlock()
+	delete(sp.peers, id)
+}
+
+// servedBy returns the amount of peers known to serve all the shares of the quadrant of the square
+// of the given DataHash.
+func (pk *PeerKnowledge) servedBy(hash share.DataHash, q *quadrant) int {
+	if pk == nil {
+		return 0
+	}
+	sp, ok := pk.squares.Get(hash.String())
+	if !ok {
+		return 0
+	}
+	sp.lk.Lock()
+	defer sp.lk.Unlock()
+
+	var count int
+	for _, rows := range sp.peers {
+		if rows == nil || servesQuadrant(rows, q) {
+			count++
+		}
+	}
+	return count
+}
+
+// servingPeers returns the peers known to serve any shares of the square of the given DataHash.
+func (pk *PeerKnowledge) servingPeers(hash share.DataHash) []peer.ID {
+	if pk == nil {
+		return nil
+	}
+	sp, ok := pk.squares.Get(hash.String())
+	if !ok {
+		return nil
+	}
+	sp.lk.Lock()
+	defer sp.lk.Unlock()
+
+	peers := make([]peer.ID, 0, len(sp.peers))
+	for id := range sp.peers {
+		peers = append(peers, id)
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+	return peers
+}
+
+func (pk *PeerKnowledge) square(hash share.DataHash) *squarePeers {
+	return pk.squares.GetOrAdd(hash.String(), func() *squarePeers {
+		return &squarePeers{peers: make(map[peer.ID]map[int]struct{})}
+	})
+}
+
+// servesQuadrant reports whether the given served rows cover all the shares of the quadrant. Only
+// quadrants requested by rows can be covered, as a column can't be served by a subset of rows.
+func servesQuadrant(rows map[int]struct{}, q *quadrant) bool {
+	if q.source != rsmt2d.Row {
+		return false
+	}
+	for i := range q.roots {
+		row, _ := q.pos(i, 0)
+		if _, ok := rows[row]; !ok {
+			return false
+		}
+	}
+	return true
+}
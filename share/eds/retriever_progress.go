@@ -0,0 +1,86 @@
+package eds
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// ReconstructionProgress describes the progress of an ongoing retrieval of a data square by the
+// Retriever.
+type ReconstructionProgress struct {
+	// Height is the height of the header the data square is committed to. It is zero if unknown.
+	Height uint64 `json:"height,omitempty"`
+	// DataHash is the DataHash of the data square.
+	DataHash share.DataHash `json:"data_hash"`
+	// SharesObtained is the amount of shares retrieved or decoded so far.
+	SharesObtained int `json:"shares_obtained"`
+	// SharesNeeded is the amount of shares needed to attempt reconstruction of the data square.
+	SharesNeeded int `json:"shares_needed"`
+	// QuadrantsRequested is the amount of quadrants requested so far.
+	QuadrantsRequested int `json:"quadrants_requested"`
+	// QuadrantsStalled is the amount of times retrieval of a requested quadrant stalled. The first
+	// stall of a quadrant reassigns its missing shares to other peers, the second one requests
+	// another quadrant early.
+	QuadrantsStalled int `json:"quadrants_stalled"`
+	// AxesDecoded is the amount of rows and columns decoded as soon as half of their shares were
+	// obtained.
+	AxesDecoded int `json:"axes_decoded"`
+	// ActivePeers are the peers known to serve shares of the data square, which quadrant requests are
+	// planned towards. Peers serving shares over bitswap only aren't known.
+	ActivePeers []peer.ID `json:"active_peers"`
+	// NodesPrefetched is the amount of NMT nodes wanted ahead of the traversal of the data square.
+	NodesPrefetched int `json:"nodes_prefetched"`
+	// Started is the time the retrieval started at.
+	Started time.Time `json:"started"`
+}
+
+// Progress returns the progress of all the ongoing retrievals ordered by height.
+func (r *Retriever) Progress() []ReconstructionProgress {
+	r.sessionsLk.Lock()
+	progress := make([]ReconstructionProgress, 0, len(r.sessions))
+	for ses := range r.sessions {
+		progress = append(progress, ses.progress())
+	}
+	r.sessionsLk.Unlock()
+
+	sort.Slice(progress, func(i, j int) bool {
+		if progress[i].Height != progress[j].Height {
+			return progress[i].Height < progress[j].Height
+		}
+		return progress[i].Started.Before(progress[j].Started)
+	})
+	return progress
+}
+
+func (r *Retriever) track(ses *retrievalSession) {
+	r.sessionsLk.Lock()
+	defer r.sessionsLk.Unlock()
+	r.sessions[ses] = struct{}{}
+}
+
+func (r *Retriever) untrack(ses *retrievalSession) {
+	r.sessionsLk.Lock()
+	defer r.sessionsLk.Unlock()
+	delete(r.sessions, ses)
+}
+
+func (rs *retrievalSession) progress() ReconstructionProgress {
+	odsWidth := len(rs.dah.RowRoots) / 2
+	return ReconstructionProgress{
+		Height:             rs.height,
+		DataHash:           rs.dah.Hash(),
+		SharesObtained:     int(atomic.LoadUint32(&rs.squareCellsCount)),
+		SharesNeeded:       odsWidth * odsWidth,
+		QuadrantsRequested: int(rs.quadrantsRequested.Load()),
+		QuadrantsStalled:   int(rs.quadrantsStalled.Load()),
+		AxesDecoded:        int(rs.axesDecoded.Load()),
+		ActivePeers:        rs.peers.servingPeers(rs.dah.Hash()),
+		NodesPrefetched:    int(rs.prefetched()),
+		Started:            rs.started,
+	}
+}
@@ -30,6 +30,13 @@ const (
 // - We have 4 quadrants from two sources(rows, cols) which equals to 8 in total.
 var RetrieveQuadrantTimeout = blockTime / numQuadrants * 2
 
+// RetrieveStallTimeout defines how long a requested quadrant may make no progress before Retriever
+// considers its request stalled. The missing shares of a stalled quadrant are requested from other
+// peers once, and if the quadrant stalls again, Retriever starts retrieving another quadrant without
+// waiting for the RetrieveQuadrantTimeout. It bounds retrieval latency by the speed of the peers serving shares,
+// rather than by the slowest of them.
+var RetrieveStallTimeout = RetrieveQuadrantTimeout / 4
+
 type quadrant struct {
 	// slice of roots to get shares from
 	roots []cid.Cid
@@ -87,6 +94,58 @@ func newQuadrants(dah *da.DataAvailabilityHeader) []*quadrant {
 	return quadrants
 }
 
+// planQuadrant picks the quadrant to request next out of the given ones and returns it along with
+// indexes of its roots to request and the rest of the quadrants. It prefers the quadrant served by the
+// most known peers, so that requests go to the parts of the square peers are known to have, and
+// then the quadrant missing the most shares, so that a partially retrieved quadrant of one axis is
+// complemented by quadrants of the other axis rather than by requesting the same shares again. Roots
+// whose shares in the quadrant are all retrieved are not requested. Ties keep the order of the given
+// quadrants. It returns nil quadrant if none of them misses any shares.
+func planQuadrant(
+	quadrants []*quadrant,
+	isSet func(x, y int) bool,
+	servedBy func(*quadrant) int,
+) (next *quadrant, roots []int, rest []*quadrant) {
+	best, bestServed, bestMissing := -1, 0, 0
+	for i, q := range quadrants {
+		qRoots, missing := q.missingRoots(isSet)
+		if missing == 0 {
+			continue
+		}
+		served := servedBy(q)
+		if best == -1 || served > bestServed || (served == bestServed && missing > bestMissing) {
+			best, bestServed, bestMissing, roots = i, served, missing, qRoots
+		}
+	}
+	if best == -1 {
+		return nil, nil, nil
+	}
+
+	rest = make([]*quadrant, 0, len(quadrants)-1)
+	rest = append(rest, quadrants[:best]...)
+	rest = append(rest, quadrants[best+1:]...)
+	return quadrants[best], roots, rest
+}
+
+// missingRoots returns indexes of the quadrant roots which miss any of their shares in the quadrant,
+// along with the amount of missing shares.
+func (q *quadrant) missingRoots(isSet func(x, y int) bool) (roots []int, missing int) {
+	size := len(q.roots)
+	for i := 0; i < size; i++ {
+		var rootMissing int
+		for j := 0; j < size; j++ {
+			if !isSet(q.pos(i, j)) {
+				rootMissing++
+			}
+		}
+		if rootMissing > 0 {
+			roots = append(roots, i)
+			missing += rootMissing
+		}
+	}
+	return roots, missing
+}
+
 // pos calculates position of a share in a data square.
 func (q *quadrant) pos(rootIdx, cellIdx int) (int, int) {
 	cellIdx += len(q.roots) * q.x
@@ -3,11 +3,13 @@ package eds
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/ipfs/go-blockservice"
 	mdutils "github.com/ipfs/go-merkledag/test"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -154,3 +156,153 @@ func generateByzantineError(
 	_, err = NewRetriever(bServ).Retrieve(ctx, faultHeader.DAH)
 	return faultHeader, err
 }
+
+func TestPlanQuadrant(t *testing.T) {
+	const odsSize = 4
+	shares := share.RandShares(t, odsSize*odsSize)
+	eds, err := rsmt2d.ComputeExtendedDataSquare(shares, share.DefaultRSMT2DCodec(), wrapper.NewConstructor(odsSize))
+	require.NoError(t, err)
+	dah := da.NewDataAvailabilityHeader(eds)
+
+	// everything but the half of the first row is retrieved
+	isSet := func(x, y int) bool {
+		return x != 0 || y >= odsSize
+	}
+
+	noPeers := func(*quadrant) int { return 0 }
+	q, roots, rest := planQuadrant(newQuadrants(&dah), isSet, noPeers)
+	require.NotNil(t, q)
+	assert.Len(t, rest, numQuadrants*2-1)
+	// only the quadrants covering the missing shares are planned
+	assert.Equal(t, 0, q.x)
+	assert.Equal(t, 0, q.y)
+	switch q.source {
+	case rsmt2d.Row:
+		assert.Equal(t, []int{0}, roots)
+	case rsmt2d.Col:
+		assert.Equal(t, []int{0, 1, 2, 3}, roots)
+	}
+
+	q, _, _ = planQuadrant(rest, func(int, int) bool { return true }, noPeers)
+	assert.Nil(t, q)
+
+	// quadrants served by known peers are preferred over the ones missing more shares
+	peers := NewPeerKnowledge()
+	peers.ObserveRows(dah.Hash(), "peer", odsSize, odsSize+1, odsSize+2, odsSize+3)
+	servedBy := func(q *quadrant) int { return peers.servedBy(dah.Hash(), q) }
+	// everything but the half of the last row and the second quadrant of the square is retrieved
+	isSet = func(x, y int) bool {
+		return (x != odsSize*2-1 || y >= odsSize) && (x >= odsSize || y < odsSize)
+	}
+	q, _, _ = planQuadrant(newQuadrants(&dah), isSet, servedBy)
+	require.NotNil(t, q)
+	assert.Equal(t, rsmt2d.Row, q.source)
+	assert.Equal(t, 1, q.y)
+
+	// peers not serving the square anymore are forgotten
+	peers.ObserveMissing(dah.Hash(), "peer")
+	q, _, _ = planQuadrant(newQuadrants(&dah), isSet, servedBy)
+	require.NotNil(t, q)
+	assert.Zero(t, servedBy(q))
+	assert.False(t, q.source == rsmt2d.Row && q.y == 1)
+}
+
+func TestRetriever_DecodeAxes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	// the square is not available, so only the shares written below are in the square
+	r := NewRetriever(mdutils.Bserv())
+	eds := share.RandEDS(t, 4)
+	dah := da.NewDataAvailabilityHeader(eds)
+	ses, err := r.newSession(ctx, &dah)
+	require.NoError(t, err)
+	defer ses.Close()
+
+	// the first quadrant makes the first half of rows and columns decodable, and these make the
+	// rest of the square decodable
+	width := int(eds.Width())
+	for x := 0; x < width/2; x++ {
+		for y := 0; y < width/2; y++ {
+			ses.setCell(ctx, x, y, eds.GetCell(uint(x), uint(y)))
+		}
+	}
+	require.Eventually(t, func() bool {
+		return atomic.LoadUint32(&ses.squareCellsCount) == uint32(width*width) && ses.axesDecoding.Load() == 0
+	}, time.Second*5, time.Millisecond*10)
+	assert.NotZero(t, ses.progress().AxesDecoded)
+	assert.Equal(t, width*width, ses.progress().SharesObtained)
+
+	square, err := ses.Reconstruct(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, eds.Flattened(), square.Flattened())
+}
+
+func TestRetriever_Progress(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	// the square is not available, so its retrieval never completes
+	r := NewRetriever(mdutils.Bserv())
+	dah := da.NewDataAvailabilityHeader(share.RandEDS(t, 4))
+
+	peers := NewPeerKnowledge()
+	peers.ObserveSquare(dah.Hash(), "b")
+	peers.ObserveRows(dah.Hash(), "a", 0, 1)
+	peers.ObserveMissing(dah.Hash(), "c")
+	r.SetPeerKnowledge(peers)
+
+	retrieveCtx, cancelRetrieve := context.WithCancel(share.WithHeight(ctx, 42))
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := r.Retrieve(retrieveCtx, &dah)
+		errCh <- err
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(r.Progress()) == 1
+	}, time.Second, time.Millisecond*10)
+	progress := r.Progress()[0]
+	assert.EqualValues(t, 42, progress.Height)
+	assert.Equal(t, share.DataHash(dah.Hash()), progress.DataHash)
+	assert.Equal(t, 0, progress.SharesObtained)
+	assert.Equal(t, 16, progress.SharesNeeded)
+	assert.Equal(t, 1, progress.QuadrantsRequested)
+	assert.Equal(t, []peer.ID{"a", "b"}, progress.ActivePeers)
+
+	cancelRetrieve()
+	require.ErrorIs(t, <-errCh, context.Canceled)
+	assert.Empty(t, r.Progress())
+}
+
+func TestRetriever_Stall(t *testing.T) {
+	stallTimeout := RetrieveStallTimeout
+	RetrieveStallTimeout = time.Millisecond * 50
+	t.Cleanup(func() { RetrieveStallTimeout = stallTimeout })
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	// the square is not available, so requests of its quadrants never progress
+	r := NewRetriever(mdutils.Bserv())
+	dah := da.NewDataAvailabilityHeader(share.RandEDS(t, 4))
+
+	retrieveCtx, cancelRetrieve := context.WithCancel(ctx)
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := r.Retrieve(retrieveCtx, &dah)
+		errCh <- err
+	}()
+
+	// stalled quadrants are reassigned once and then replaced without waiting for the quadrant timeout
+	require.Eventually(t, func() bool {
+		progress := r.Progress()
+		return len(progress) == 1 && progress[0].QuadrantsRequested >= 2
+	}, RetrieveQuadrantTimeout/2, time.Millisecond*10)
+	progress := r.Progress()[0]
+	// every quadrant requested before the current one stalled twice
+	assert.GreaterOrEqual(t, progress.QuadrantsStalled, 2*(progress.QuadrantsRequested-1))
+	assert.LessOrEqual(t, progress.QuadrantsStalled, 2*progress.QuadrantsRequested)
+
+	cancelRetrieve()
+	require.ErrorIs(t, <-errCh, context.Canceled)
+}
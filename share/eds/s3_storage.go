@@ -0,0 +1,266 @@
+package eds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/filecoin-project/dagstore/mount"
+)
+
+// S3Config configures an S3Storage.
+// Credentials are resolved using the default AWS credential chain, e.g. from the
+// AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables.
+type S3Config struct {
+	// Endpoint is the URL of an S3-compatible service. AWS is used if empty.
+	Endpoint string
+	// Region is the region of the bucket.
+	Region string
+	// Bucket is the name of the bucket to keep CAR files in.
+	Bucket string
+	// Prefix is prepended to the keys of all the objects written to the bucket.
+	Prefix string
+	// UsePathStyle makes requests address the bucket in the URL path instead of the host name.
+	// Most S3-compatible services, like MinIO, require it.
+	UsePathStyle bool
+}
+
+// Validate performs basic validation of the config.
+func (cfg *S3Config) Validate() error {
+	if cfg.Bucket == "" {
+		return fmt.Errorf("eds/s3: bucket must be set")
+	}
+	if cfg.Region == "" {
+		return fmt.Errorf("eds/s3: region must be set")
+	}
+	return nil
+}
+
+// S3Storage is a CARStorage keeping CAR files in an S3-compatible object storage.
+type S3Storage struct {
+	client   *s3.S3
+	uploader *s3manager.Uploader
+
+	bucket string
+	prefix string
+}
+
+// NewS3Storage creates a new S3Storage with the given config.
+func NewS3Storage(cfg S3Config) (*S3Storage, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	awsCfg := aws.NewConfig().
+		WithRegion(cfg.Region).
+		WithS3ForcePathStyle(cfg.UsePathStyle)
+	if cfg.Endpoint != "" {
+		awsCfg = awsCfg.WithEndpoint(cfg.Endpoint)
+	}
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return nil, fmt.Errorf("eds/s3: creating session: %w", err)
+	}
+
+	client := s3.New(sess)
+	return &S3Storage{
+		client:   client,
+		uploader: s3manager.NewUploaderWithClient(client),
+		bucket:   cfg.Bucket,
+		prefix:   cfg.Prefix,
+	}, nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader) error {
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+		Body:   r,
+	})
+	if err != nil {
+		return fmt.Errorf("eds/s3: uploading %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) (mount.Reader, error) {
+	size, err := s.Size(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	// the reader outlives the request it's fetched for, as dagstore keeps mounts open in its cache, so
+	// its range requests are bound to the lifetime of the reader instead
+	readerCtx, cancel := context.WithCancel(context.Background())
+	return &s3Reader{
+		ctx:     readerCtx,
+		cancel:  cancel,
+		storage: s,
+		key:     s.prefix + key,
+		size:    size,
+	}, nil
+}
+
+func (s *S3Storage) Size(ctx context.Context, key string) (int64, error) {
+	out, err := s.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return 0, ErrNotFound
+		}
+		return 0, fmt.Errorf("eds/s3: getting size of %s: %w", key, err)
+	}
+	return aws.Int64Value(out.ContentLength), nil
+}
+
+func (s *S3Storage) Remove(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+	})
+	if err != nil {
+		return fmt.Errorf("eds/s3: removing %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *S3Storage) Keys(ctx context.Context) ([]string, error) {
+	var keys []string
+	err := s.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.prefix),
+	}, func(out *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range out.Contents {
+			keys = append(keys, strings.TrimPrefix(aws.StringValue(obj.Key), s.prefix))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("eds/s3: listing objects: %w", err)
+	}
+	return keys, nil
+}
+
+// getRange returns the body of the object starting at the given offset. If length is positive,
+// only the given amount of bytes is requested.
+func (s *S3Storage) getRange(ctx context.Context, key string, off, length int64) (io.ReadCloser, error) {
+	rng := fmt.Sprintf("bytes=%d-", off)
+	if length > 0 {
+		rng += fmt.Sprint(off + length - 1)
+	}
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Range:  aws.String(rng),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("eds/s3: reading %s: %w", key, err)
+	}
+	return out.Body, nil
+}
+
+func isNotFound(err error) bool {
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusNotFound {
+		return true
+	}
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey
+}
+
+// s3Reader provides random access to an S3 object using range requests. Sequential reads are
+// served from a single streamed response, which is reopened only when the reader is seeked.
+type s3Reader struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	storage *S3Storage
+	key     string
+	size    int64
+
+	offset int64
+	body   io.ReadCloser
+}
+
+func (r *s3Reader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		body, err := r.storage.getRange(r.ctx, r.key, r.offset, 0)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+	}
+
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	if errors.Is(err, io.EOF) && r.offset < r.size {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (r *s3Reader) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	length := int64(len(p))
+	if off+length > r.size {
+		length = r.size - off
+	}
+
+	body, err := r.storage.getRange(r.ctx, r.key, off, length)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	n, err := io.ReadFull(body, p[:length])
+	if err == nil && int(length) < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (r *s3Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, fmt.Errorf("eds/s3: invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("eds/s3: negative offset %d", offset)
+	}
+
+	if offset != r.offset && r.body != nil {
+		_ = r.body.Close()
+		r.body = nil
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+func (r *s3Reader) Close() error {
+	defer r.cancel()
+	if r.body == nil {
+		return nil
+	}
+	return r.body.Close()
+}
@@ -0,0 +1,195 @@
+package eds
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3Storage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	storage := newTestS3Storage(t)
+
+	data := []byte("some car file data")
+	err := storage.Put(ctx, "key", strings.NewReader(string(data)))
+	require.NoError(t, err)
+
+	size, err := storage.Size(ctx, "key")
+	require.NoError(t, err)
+	assert.EqualValues(t, len(data), size)
+
+	r, err := storage.Get(ctx, "key")
+	require.NoError(t, err)
+	t.Cleanup(func() { r.Close() })
+
+	buf := make([]byte, 4)
+	n, err := r.ReadAt(buf, 5)
+	require.NoError(t, err)
+	assert.Equal(t, data[5:5+n], buf)
+
+	_, err = r.Seek(10, io.SeekStart)
+	require.NoError(t, err)
+	rest, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, data[10:], rest)
+
+	keys, err := storage.Keys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"key"}, keys)
+
+	err = storage.Remove(ctx, "key")
+	require.NoError(t, err)
+	_, err = storage.Get(ctx, "key")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+// TestS3Storage_ReaderOutlivesContext verifies that the reader is usable after the context it was
+// fetched with is done, as dagstore keeps mounts open beyond the request that opened them.
+func TestS3Storage_ReaderOutlivesContext(t *testing.T) {
+	storage := newTestS3Storage(t)
+
+	data := []byte("some car file data")
+	err := storage.Put(context.Background(), "key", strings.NewReader(string(data)))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r, err := storage.Get(ctx, "key")
+	require.NoError(t, err)
+	cancel()
+
+	buf := make([]byte, 4)
+	_, err = r.ReadAt(buf, 5)
+	require.NoError(t, err)
+	assert.Equal(t, data[5:9], buf)
+
+	err = r.Close()
+	require.NoError(t, err)
+	_, err = r.ReadAt(buf, 5)
+	assert.Error(t, err)
+}
+
+func TestStore_S3Storage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := NewStore(t.TempDir(), ds_sync.MutexWrap(datastore.NewMapDatastore()),
+		WithCARStorage(newTestS3Storage(t), 1))
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	eds, dah := randomEDS(t)
+	err = edsStore.Put(ctx, dah.Hash(), eds)
+	require.NoError(t, err)
+
+	retrievedEDS, err := edsStore.Get(ctx, dah.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, eds.Flattened(), retrievedEDS.Flattened())
+
+	err = edsStore.Remove(ctx, dah.Hash())
+	require.NoError(t, err)
+	_, err = edsStore.Get(ctx, dah.Hash())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func newTestS3Storage(t *testing.T) *S3Storage {
+	t.Helper()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	srv := httptest.NewServer(newFakeS3())
+	t.Cleanup(srv.Close)
+
+	storage, err := NewS3Storage(S3Config{
+		Endpoint:     srv.URL,
+		Region:       "us-east-1",
+		Bucket:       "bucket",
+		Prefix:       "eds/",
+		UsePathStyle: true,
+	})
+	require.NoError(t, err)
+	return storage
+}
+
+// fakeS3 is an in-memory server implementing the subset of S3 API used by S3Storage.
+type fakeS3 struct {
+	lk      sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: make(map[string][]byte)}
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		s.objects[r.URL.Path] = data
+	case http.MethodDelete:
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet, http.MethodHead:
+		if r.URL.Query().Get("list-type") == "2" {
+			s.list(w, r)
+			return
+		}
+		data, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				fmt.Fprint(w, "<Error><Code>NoSuchKey</Code></Error>")
+			}
+			return
+		}
+		if rng := r.Header.Get("Range"); rng != "" {
+			var from, to int
+			n, _ := fmt.Sscanf(rng, "bytes=%d-%d", &from, &to)
+			if n < 2 || to >= len(data) {
+				to = len(data) - 1
+			}
+			data = data[from : to+1]
+			w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+			w.WriteHeader(http.StatusPartialContent)
+		} else {
+			w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// list responds with all the objects of the bucket having the requested prefix.
+func (s *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Path + "/" + r.URL.Query().Get("prefix")
+	fmt.Fprint(w, "<ListBucketResult><IsTruncated>false</IsTruncated>")
+	for path := range s.objects {
+		if strings.HasPrefix(path, prefix) {
+			fmt.Fprintf(w, "<Contents><Key>%s</Key></Contents>", strings.TrimPrefix(path, r.URL.Path+"/"))
+		}
+	}
+	fmt.Fprint(w, "</ListBucketResult>")
+}
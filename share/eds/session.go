@@ -0,0 +1,206 @@
+package eds
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-cid"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+var _ blockservice.BlockGetter = (*squareSession)(nil)
+
+// PrefetchTimeout defines how long the traversal waits for a prefetched node before requesting it
+// itself. Nodes are prefetched as soon as their parent is retrieved, so a prefetch not delivered by
+// then is likely lost, e.g. as the node got stored by another session in the meantime.
+var PrefetchTimeout = time.Millisecond * 250
+
+// maxInflightPrefetches limits the prefetches of a session not delivered yet. Every prefetch wants
+// two nodes and the session sends only a limited amount of wants at once, so unbounded prefetching
+// would queue the wants of the traversal itself behind the prefetched ones.
+const maxInflightPrefetches = 8
+
+// squareSession is the Bitswap session of the retrieval of a single data square. It follows the
+// traversal of the NMTs of the square, which always requests both children of an inner node, by
+// prefetching the children of every requested inner node in one want, instead of wanting them one
+// by one. The children of roots are not prefetched, as the Retriever requests only one half of
+// every root. The session is closed, canceling all its wants, as soon as the square is
+// reconstructed.
+type squareSession struct {
+	bServ blockservice.BlockService
+	ses   *blockservice.Session
+	// roots of the square, which children are not prefetched
+	roots map[cid.Cid]struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	lk        sync.Mutex
+	prefetchs map[cid.Cid]*prefetch
+
+	// inflight is the amount of prefetches not delivered yet
+	inflight atomic.Int64
+	// prefetched is the amount of prefetched nodes requested by the traversal afterwards
+	prefetched atomic.Int64
+}
+
+// prefetch is a node wanted ahead of the traversal.
+type prefetch struct {
+	done  chan struct{}
+	block blocks.Block
+}
+
+func newSquareSession(
+	ctx context.Context,
+	bServ blockservice.BlockService,
+	dah *da.DataAvailabilityHeader,
+) *squareSession {
+	ctx, cancel := context.WithCancel(ctx)
+	roots := make(map[cid.Cid]struct{}, len(dah.RowRoots)+len(dah.ColumnRoots))
+	for _, axisRoots := range [][][]byte{dah.RowRoots, dah.ColumnRoots} {
+		for _, root := range axisRoots {
+			roots[ipld.MustCidFromNamespacedSha256(root)] = struct{}{}
+		}
+	}
+	return &squareSession{
+		bServ:     bServ,
+		ses:       blockservice.NewSession(ctx, bServ),
+		roots:     roots,
+		ctx:       ctx,
+		cancel:    cancel,
+		prefetchs: make(map[cid.Cid]*prefetch),
+	}
+}
+
+// GetBlock gets the node of the given CID, waiting for it if it is prefetched already, and
+// prefetches its children.
+func (s *squareSession) GetBlock(ctx context.Context, id cid.Cid) (blocks.Block, error) {
+	blk, prefetched, ok := s.awaitPrefetch(ctx, id)
+	if ok {
+		s.prefetched.Add(1)
+		s.prefetchChildren(blk)
+		return blk, nil
+	}
+
+	// the session limits its live wants, so a prefetch not delivered in time may be queued behind
+	// other prefetches, and the node is requested outside of the session instead
+	getter := blockservice.BlockGetter(s.ses)
+	if prefetched {
+		getter = s.bServ
+	}
+	blk, err := getter.GetBlock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.prefetchChildren(blk)
+	return blk, nil
+}
+
+// GetBlocks gets the nodes of the given CIDs without prefetching.
+func (s *squareSession) GetBlocks(ctx context.Context, ids []cid.Cid) <-chan blocks.Block {
+	return s.ses.GetBlocks(ctx, ids)
+}
+
+// Close cancels all the wants of the session.
+func (s *squareSession) Close() {
+	s.cancel()
+	s.lk.Lock()
+	s.prefetchs = nil
+	s.lk.Unlock()
+}
+
+// awaitPrefetch waits for the prefetch of the given CID, if any, for up to PrefetchTimeout. It
+// reports whether the node was prefetched and whether the prefetch delivered it. Otherwise, the
+// node has to be requested.
+func (s *squareSession) awaitPrefetch(ctx context.Context, id cid.Cid) (_ blocks.Block, prefetched, ok bool) {
+	s.lk.Lock()
+	p, ok := s.prefetchs[id]
+	// every prefetched node is awaited once, as the traversal requests it once
+	delete(s.prefetchs, id)
+	s.lk.Unlock()
+	if !ok {
+		return nil, false, false
+	}
+
+	timer := time.NewTimer(PrefetchTimeout)
+	defer timer.Stop()
+	select {
+	case <-p.done:
+		return p.block, true, p.block != nil
+	case <-timer.C:
+		log.Debugw("prefetch timed out, requesting the node", "cid", id)
+		return nil, true, false
+	case <-ctx.Done():
+		return nil, true, false
+	}
+}
+
+// prefetchChildren wants both children of the given inner node in one go.
+func (s *squareSession) prefetchChildren(blk blocks.Block) {
+	data := blk.RawData()
+	if len(data) != 2*ipld.NmtHashSize {
+		// leaves have no children
+		return
+	}
+	if _, ok := s.roots[blk.Cid()]; ok {
+		return
+	}
+
+	children := []cid.Cid{
+		ipld.MustCidFromNamespacedSha256(data[:ipld.NmtHashSize]),
+		ipld.MustCidFromNamespacedSha256(data[ipld.NmtHashSize:]),
+	}
+	if s.inflight.Add(1) > maxInflightPrefetches {
+		// the children are requested by the traversal instead
+		s.inflight.Add(-1)
+		return
+	}
+
+	pending := make(map[cid.Cid]*prefetch, len(children))
+	s.lk.Lock()
+	if s.prefetchs == nil {
+		// the session is closed
+		s.lk.Unlock()
+		s.inflight.Add(-1)
+		return
+	}
+	for _, id := range children {
+		if _, ok := s.prefetchs[id]; ok {
+			continue
+		}
+		p := &prefetch{done: make(chan struct{})}
+		s.prefetchs[id] = p
+		pending[id] = p
+	}
+	s.lk.Unlock()
+	if len(pending) == 0 {
+		s.inflight.Add(-1)
+		return
+	}
+
+	ids := make([]cid.Cid, 0, len(pending))
+	for id := range pending {
+		ids = append(ids, id)
+	}
+	go func() {
+		for blk := range s.ses.GetBlocks(s.ctx, ids) {
+			if p, ok := pending[blk.Cid()]; ok {
+				p.block = blk
+				close(p.done)
+				delete(pending, blk.Cid())
+			}
+		}
+		// nodes which were not retrieved are requested by the traversal again
+		for _, p := range pending {
+			close(p.done)
+		}
+		s.inflight.Add(-1)
+	}()
+}
@@ -0,0 +1,50 @@
+package eds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mdutils "github.com/ipfs/go-merkledag/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+func TestSquareSession(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	const size = 8
+	bServ := mdutils.Bserv()
+	in, err := share.AddShares(ctx, share.RandShares(t, size*size), bServ)
+	require.NoError(t, err)
+	dah := da.NewDataAvailabilityHeader(in)
+
+	ses := newSquareSession(ctx, bServ, &dah)
+	defer ses.Close()
+
+	// traverse the left half of the first row, as the Retriever does
+	root, err := ipld.GetNode(ctx, ses, ipld.MustCidFromNamespacedSha256(dah.RowRoots[0]))
+	require.NoError(t, err)
+	// children of roots are not prefetched
+	assert.Empty(t, ses.prefetchs)
+
+	got := make([]share.Share, size)
+	share.GetShares(ctx, ses, root.Links()[0].Cid, size, func(i int, sh share.Share) {
+		got[i] = sh
+	})
+	assert.Equal(t, in.Row(0)[:size], got)
+	// every node but the half's root is prefetched by its parent: 2+4+8 nodes of the half's subtree
+	assert.EqualValues(t, 2*size-2, ses.prefetched.Load())
+
+	// closed sessions stop prefetching
+	ses.Close()
+	_, err = ses.GetBlock(ctx, root.Links()[1].Cid)
+	require.NoError(t, err) // the node is stored locally
+	assert.Nil(t, ses.prefetchs)
+}
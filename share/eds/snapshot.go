@@ -0,0 +1,791 @@
+package eds
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	"github.com/filecoin-project/dagstore"
+	"github.com/filecoin-project/dagstore/shard"
+	carv2 "github.com/ipld/go-car/v2"
+	carindex "github.com/ipld/go-car/v2/index"
+	"github.com/minio/sha256-simd"
+	"github.com/multiformats/go-multihash"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	libhead "github.com/celestiaorg/go-header"
+
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+const (
+	// snapshotVersion is the version of the snapshot archive format. Version 2 adds checksums of
+	// CAR files to the manifest, version 3 archives DAGStore indexes of CAR files and version 4
+	// moves the checksum of every CAR file into an entry following it.
+	snapshotVersion = 4
+	// snapshotManifestName is the name of the archive entry holding the SnapshotManifest. It is
+	// always the first entry of the archive.
+	snapshotManifestName = "manifest.json"
+	// snapshotCARExt is the extension of archive entries holding CAR files, named by their DataHash.
+	snapshotCARExt = ".car"
+	// snapshotIndexExt is the extension of archive entries holding DAGStore indexes of CAR files,
+	// named by their DataHash. Each index precedes the CAR file it indexes.
+	snapshotIndexExt = ".index"
+	// snapshotShardExt is the extension of archive entries holding the SnapshotShard of CAR files,
+	// named by their DataHash. Since version 4, each follows the CAR file it describes, as checksums
+	// are computed while CAR files are archived.
+	snapshotShardExt = ".shard"
+)
+
+// SnapshotManifest describes the contents of a snapshot archive.
+type SnapshotManifest struct {
+	Version int `json:"version"`
+	// From and To define the inclusive height range the snapshot covers.
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+	// DataHashes index the EDSes of the snapshot by height, starting from From. Multiple heights may
+	// point to the same EDS, which is archived only once.
+	DataHashes []share.DataHash `json:"data_hashes"`
+	// Shards describe the CAR files of the snapshot in the order they are archived in. Snapshots of
+	// version 1 have none. Since version 4, each is archived after its CAR file instead of in the
+	// manifest entry.
+	Shards []SnapshotShard `json:"shards,omitempty"`
+}
+
+// SnapshotShard describes a CAR file archived in a snapshot, so that it can be verified on its own
+// without hashing the whole archive.
+type SnapshotShard struct {
+	DataHash share.DataHash `json:"data_hash"`
+	// Size is the size of the CAR file in bytes.
+	Size int64 `json:"size"`
+	// SHA256 is the hex encoded SHA-256 checksum of the CAR file.
+	SHA256 string `json:"sha256"`
+}
+
+// DataHash returns the DataHash of the EDS at the given height, if the snapshot covers it.
+func (m *SnapshotManifest) DataHash(height uint64) (share.DataHash, bool) {
+	if height < m.From || height > m.To {
+		return nil, false
+	}
+	return m.DataHashes[height-m.From], true
+}
+
+// shardIndex indexes the Shards of the manifest by their DataHashes.
+func (m *SnapshotManifest) shardIndex() map[string]SnapshotShard {
+	idx := make(map[string]SnapshotShard, len(m.Shards))
+	for _, shard := range m.Shards {
+		idx[shard.DataHash.String()] = shard
+	}
+	return idx
+}
+
+// ExportSnapshot writes CAR files of all EDSes in the inclusive height range [from; to] into w as
+// a single tar archive, which can be imported into a Store of another node using ImportSnapshot.
+// Heights are mapped onto DataHashes using the given HeaderGetter. Exporting fails if any EDS of
+// the range is not stored.
+//
+// The size and the checksum of every CAR file are computed while it is archived and are written
+// into the entry following it, so every CAR file is read once. The DAGStore index of every CAR
+// file is archived along with it.
+func (s *Store) ExportSnapshot(
+	ctx context.Context,
+	w io.Writer,
+	from, to uint64,
+	getter HeaderGetter,
+) (manifest *SnapshotManifest, err error) {
+	ctx, span := tracer.Start(ctx, "store/export-snapshot", trace.WithAttributes(
+		attribute.Int64("from", int64(from)),
+		attribute.Int64("to", int64(to)),
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	if from == 0 || from > to {
+		return nil, fmt.Errorf("eds/store: invalid range [%d; %d]", from, to)
+	}
+
+	manifest = &SnapshotManifest{
+		Version:    snapshotVersion,
+		From:       from,
+		To:         to,
+		DataHashes: make([]share.DataHash, 0, to-from+1),
+	}
+	for height := from; height <= to; height++ {
+		h, err := getter(ctx, height)
+		if err != nil {
+			return nil, fmt.Errorf("eds/store: getting header at height %d: %w", height, err)
+		}
+		manifest.DataHashes = append(manifest.DataHashes, h.DAH.Hash())
+	}
+
+	tw := tar.NewWriter(w)
+	rawManifest, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("eds/store: marshaling snapshot manifest: %w", err)
+	}
+	err = writeTarEntry(tw, snapshotManifestName, int64(len(rawManifest)), bytes.NewReader(rawManifest))
+	if err != nil {
+		return nil, fmt.Errorf("eds/store: writing snapshot manifest: %w", err)
+	}
+
+	// multiple heights can point to the same EDS, e.g. the empty one, so archive each only once
+	exported := make(map[string]struct{})
+	for _, root := range manifest.DataHashes {
+		key := root.String()
+		if _, ok := exported[key]; ok {
+			continue
+		}
+		exported[key] = struct{}{}
+
+		// the size of the CAR file precedes it in the archive
+		size, err := s.carStorage.Size(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("eds/store: getting size of EDS %s: %w", key, err)
+		}
+		err = s.exportIndex(tw, root)
+		if err != nil {
+			return nil, fmt.Errorf("eds/store: exporting index of EDS %s: %w", key, err)
+		}
+		shard, err := s.exportCAR(ctx, tw, root, size)
+		if err != nil {
+			return nil, fmt.Errorf("eds/store: exporting EDS %s: %w", key, err)
+		}
+		rawShard, err := json.Marshal(shard)
+		if err != nil {
+			return nil, fmt.Errorf("eds/store: marshaling shard of EDS %s: %w", key, err)
+		}
+		err = writeTarEntry(tw, key+snapshotShardExt, int64(len(rawShard)), bytes.NewReader(rawShard))
+		if err != nil {
+			return nil, fmt.Errorf("eds/store: writing shard of EDS %s: %w", key, err)
+		}
+		manifest.Shards = append(manifest.Shards, shard)
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return nil, fmt.Errorf("eds/store: finalizing snapshot: %w", err)
+	}
+	log.Infow("exported snapshot", "from", from, "to", to, "edses", len(exported))
+	return manifest, nil
+}
+
+// exportCAR writes the stored CAR file of the EDS of the given size into the archive and describes
+// it as a SnapshotShard.
+func (s *Store) exportCAR(
+	ctx context.Context,
+	tw *tar.Writer,
+	root share.DataHash,
+	size int64,
+) (SnapshotShard, error) {
+	key := root.String()
+	r, err := s.carStorage.Get(ctx, key)
+	if err != nil {
+		return SnapshotShard{}, err
+	}
+	defer r.Close()
+
+	// the size is fixed by the tar header, so a CAR file that grew since fails writing and one that
+	// shrunk fails the size check
+	cr := newChecksumReader(r)
+	err = writeTarEntry(tw, key+snapshotCARExt, size, cr)
+	if err != nil {
+		return SnapshotShard{}, err
+	}
+	if cr.size != size {
+		return SnapshotShard{}, fmt.Errorf("CAR file of %d bytes, expected %d", cr.size, size)
+	}
+	return cr.shard(root), nil
+}
+
+// exportIndex writes the DAGStore index of the stored CAR file of the EDS into the archive.
+func (s *Store) exportIndex(tw *tar.Writer, root share.DataHash) error {
+	key := root.String()
+	idx, err := s.carIdx.GetFullIndex(shard.KeyFromString(key))
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err = carindex.WriteTo(idx, buf); err != nil {
+		return err
+	}
+	return writeTarEntry(tw, key+snapshotIndexExt, int64(buf.Len()), buf)
+}
+
+func writeTarEntry(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0600,
+		Size: size,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, r)
+	return err
+}
+
+// ImportSnapshot reads a snapshot archive written by ExportSnapshot and registers its EDSes on the
+// Store. Every CAR file is fully verified against its DataHash before it is registered, so an
+// archive from an untrusted source can't bring corrupted data into the Store. CAR files are also
+// checked against their archived checksums and indexes before they are registered, if the archive
+// has them. Indexes are generated while CAR files are verified, so the DAGStore doesn't read CAR
+// files again to index them.
+//
+// EDSes that are already stored are skipped, so an interrupted import can be resumed by importing
+// the same archive again. Stored EDSes are skipped without reading them, if r is an io.Seeker, e.g.
+// an archive file.
+//
+// The DataHashes of the manifest are validated against the headers of their heights, if the given
+// HeaderGetter is not nil, so that stored squares always match their headers. Heights which headers
+// are not found are not validated, as headers of the imported heights may be synced afterwards.
+//
+// It returns the manifest of the imported snapshot.
+func (s *Store) ImportSnapshot(
+	ctx context.Context,
+	r io.Reader,
+	getter HeaderGetter,
+) (manifest *SnapshotManifest, err error) {
+	ctx, span := tracer.Start(ctx, "store/import-snapshot")
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	tr := tar.NewReader(r)
+	manifest, err = readSnapshotManifest(tr)
+	if err != nil {
+		return nil, fmt.Errorf("eds/store: %w", err)
+	}
+	span.SetAttributes(
+		attribute.Int64("from", int64(manifest.From)),
+		attribute.Int64("to", int64(manifest.To)),
+	)
+	if getter != nil {
+		if err = validateManifest(ctx, manifest, getter); err != nil {
+			return nil, fmt.Errorf("eds/store: %w", err)
+		}
+	}
+
+	pending := make(map[string]share.DataHash, len(manifest.DataHashes))
+	for _, root := range manifest.DataHashes {
+		pending[root.String()] = root
+	}
+	edses := len(pending)
+	// EDSes which CAR files are skipped, as they are already stored
+	skipped := make(map[string]struct{})
+
+	// indexes precede the CAR files they index
+	indexes := make(map[string]carindex.Index)
+	archived := manifest.shardIndex()
+	if manifest.Version >= 4 {
+		manifest.Shards = make([]SnapshotShard, 0, len(pending))
+	}
+	var imported int
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("eds/store: reading snapshot: %w", err)
+		}
+
+		if key := strings.TrimSuffix(hdr.Name, snapshotShardExt); key != hdr.Name && manifest.Version >= 4 {
+			// shards of imported CAR files are read along with them, so only the shards of skipped
+			// ones are left
+			if _, ok := skipped[key]; !ok {
+				return nil, fmt.Errorf("eds/store: unexpected snapshot entry %s", hdr.Name)
+			}
+			delete(skipped, key)
+			shard, err := readSnapshotShard(tr, key)
+			if err != nil {
+				return nil, fmt.Errorf("eds/store: %w", err)
+			}
+			manifest.Shards = append(manifest.Shards, shard)
+			continue
+		}
+		if key := strings.TrimSuffix(hdr.Name, snapshotIndexExt); key != hdr.Name {
+			if _, ok := pending[key]; !ok {
+				return nil, fmt.Errorf("eds/store: unexpected snapshot entry %s", hdr.Name)
+			}
+			indexes[key], err = carindex.ReadFrom(tr)
+			if err != nil {
+				return nil, fmt.Errorf("eds/store: reading index of %s: %w", key, err)
+			}
+			continue
+		}
+
+		key := strings.TrimSuffix(hdr.Name, snapshotCARExt)
+		root, ok := pending[key]
+		if !ok {
+			return nil, fmt.Errorf("eds/store: unexpected snapshot entry %s", hdr.Name)
+		}
+		delete(pending, key)
+		idx := indexes[key]
+		delete(indexes, key)
+
+		// the shard of the CAR file is archived in the manifest before version 4 and in the entry
+		// following the CAR file since
+		archivedShard := func() (*SnapshotShard, error) {
+			if manifest.Version < 4 {
+				if sh, ok := archived[key]; ok {
+					return &sh, nil
+				}
+				return nil, nil
+			}
+			hdr, err := tr.Next()
+			if err != nil {
+				return nil, fmt.Errorf("reading shard: %w", err)
+			}
+			if hdr.Name != key+snapshotShardExt {
+				return nil, fmt.Errorf("%w: CAR file has no checksum", ErrCorrupted)
+			}
+			sh, err := readSnapshotShard(tr, key)
+			if err != nil {
+				return nil, err
+			}
+			manifest.Shards = append(manifest.Shards, sh)
+			return &sh, nil
+		}
+		err = s.importCAR(ctx, root, archivedShard, idx, tr)
+		if errors.Is(err, dagstore.ErrShardExists) {
+			skipped[key] = struct{}{}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("eds/store: importing %s: %w", key, err)
+		}
+		imported++
+	}
+
+	if len(pending) != 0 {
+		return nil, fmt.Errorf("eds/store: snapshot is missing %d EDSes", len(pending))
+	}
+	if manifest.Version >= 4 && len(manifest.Shards) != edses {
+		return nil, fmt.Errorf("eds/store: snapshot is missing checksums of CAR files")
+	}
+	log.Infow("imported snapshot", "from", manifest.From, "to", manifest.To, "edses", imported)
+	return manifest, nil
+}
+
+// readSnapshotShard reads the SnapshotShard of the CAR file of the EDS with the given key.
+func readSnapshotShard(r io.Reader, key string) (SnapshotShard, error) {
+	var shard SnapshotShard
+	err := json.NewDecoder(r).Decode(&shard)
+	if err != nil {
+		return SnapshotShard{}, fmt.Errorf("decoding shard of %s: %w", key, err)
+	}
+	if shard.DataHash.String() != key {
+		return SnapshotShard{}, fmt.Errorf("%w: shard of %s describes %s", ErrCorrupted, key, shard.DataHash)
+	}
+	return shard, nil
+}
+
+// validateManifest ensures the DataHashes of the manifest are the ones the headers of their heights
+// commit to. Heights which headers are not found are skipped.
+func validateManifest(ctx context.Context, manifest *SnapshotManifest, getter HeaderGetter) error {
+	for height := manifest.From; height <= manifest.To; height++ {
+		h, err := getter(ctx, height)
+		if errors.Is(err, libhead.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("getting header at height %d: %w", height, err)
+		}
+		root, _ := manifest.DataHash(height)
+		if !bytes.Equal(h.DAH.Hash(), h.DataHash) || !bytes.Equal(root, h.DataHash) {
+			return fmt.Errorf("data hash %s of height %d doesn't match its header: %X", root, height, h.DataHash)
+		}
+	}
+	return nil
+}
+
+// importCAR verifies and indexes the CAR file read from r while writing it to CARStorage, and
+// registers it if it is valid. The CAR file is also checked against its archived index, if given,
+// and against the SnapshotShard returned by archived, if any. The SnapshotShard is requested once the
+// CAR file is read, as it may follow the CAR file in the archive. Like Put, it returns
+// dagstore.ErrShardExists if the EDS is already stored.
+func (s *Store) importCAR(
+	ctx context.Context,
+	root share.DataHash,
+	archived func() (*SnapshotShard, error),
+	archivedIdx carindex.Index,
+	r io.Reader,
+) error {
+	unlock := s.lockWrite(root)
+	defer unlock()
+
+	has, err := s.Has(ctx, root)
+	if err != nil {
+		return fmt.Errorf("checking if EDS exists: %w", err)
+	}
+	if has {
+		return dagstore.ErrShardExists
+	}
+
+	release, err := s.acquireWriter(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	key := root.String()
+	cr := newChecksumReader(r)
+	r = cr
+	pr, pw := io.Pipe()
+	ipr, ipw := io.Pipe()
+	verified := make(chan error, 1)
+	go func() {
+		err := verifyCAR(bufio.NewReader(io.TeeReader(r, io.MultiWriter(pw, ipw))), root)
+		if err != nil {
+			err = fmt.Errorf("%w: %s", ErrCorrupted, err)
+		}
+		verified <- err
+		pw.CloseWithError(err)
+		ipw.CloseWithError(err)
+	}()
+	type indexResult struct {
+		idx carindex.Index
+		err error
+	}
+	indexed := make(chan indexResult, 1)
+	go func() {
+		// the same options the DAGStore indexes CAR files with
+		idx, err := carv2.GenerateIndex(
+			newStreamSeeker(ipr),
+			carv2.ZeroLengthSectionAsEOF(true),
+			carv2.StoreIdentityCIDs(true),
+		)
+		// unblock the verifier in case indexing failed before the whole CAR file was read
+		ipr.CloseWithError(err)
+		indexed <- indexResult{idx: idx, err: err}
+	}()
+
+	err = s.carStorage.Put(ctx, key, pr)
+	// unblock the verifier in case storing failed before the whole CAR file was read
+	pr.CloseWithError(err)
+	if verr := <-verified; err == nil {
+		err = verr
+	}
+	res := <-indexed
+	if err == nil && res.err != nil {
+		err = fmt.Errorf("indexing CAR file: %w", res.err)
+	}
+	if err == nil {
+		var sh *SnapshotShard
+		sh, err = archived()
+		if err == nil && sh != nil {
+			err = cr.verify(*sh)
+		}
+	}
+	if err == nil && archivedIdx != nil {
+		err = verifyIndex(res.idx, archivedIdx)
+	}
+	if err == nil {
+		// the DAGStore doesn't index shards which index already exists on registration
+		err = s.carIdx.AddFullIndex(shard.KeyFromString(key), res.idx)
+	}
+	if err != nil {
+		if rerr := s.carStorage.Remove(ctx, key); rerr != nil && !errors.Is(rerr, ErrNotFound) {
+			log.Warnw("removing partially imported CAR file", "key", key, "err", rerr)
+		}
+		return err
+	}
+
+	err = s.registerShard(ctx, key, nil)
+	if err != nil {
+		return err
+	}
+	// the DAGStore populates the inverted index only when it indexes shards itself
+	err = s.addIndexToInverted(ctx, key, res.idx)
+	if err != nil {
+		if derr := s.destroyShard(ctx, key); derr != nil {
+			log.Warnw("destroying partially indexed shard", "key", key, "err", derr)
+		}
+		return err
+	}
+	return nil
+}
+
+// verifyIndex checks the archived index of a CAR file matches the index generated from it.
+func verifyIndex(generated, archived carindex.Index) error {
+	var generatedBuf, archivedBuf bytes.Buffer
+	if _, err := carindex.WriteTo(generated, &generatedBuf); err != nil {
+		return fmt.Errorf("encoding index: %w", err)
+	}
+	if _, err := carindex.WriteTo(archived, &archivedBuf); err != nil {
+		return fmt.Errorf("encoding archived index: %w", err)
+	}
+	if !bytes.Equal(generatedBuf.Bytes(), archivedBuf.Bytes()) {
+		return fmt.Errorf("%w: archived index doesn't match the CAR file", ErrCorrupted)
+	}
+	return nil
+}
+
+// addIndexToInverted adds all the multihashes of the CAR file index to the inverted index.
+func (s *Store) addIndexToInverted(ctx context.Context, key string, idx carindex.Index) error {
+	iterable, ok := idx.(carindex.IterableIndex)
+	if !ok {
+		return fmt.Errorf("index of %s is not iterable", key)
+	}
+
+	var mhs multihashes
+	err := iterable.ForEach(func(mh multihash.Multihash, _ uint64) error {
+		mhs = append(mhs, mh)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("iterating index of %s: %w", key, err)
+	}
+	return s.topIdx.AddMultihashesForShard(ctx, mhs, shard.KeyFromString(key))
+}
+
+// readSnapshotManifest reads and validates the manifest from the first entry of the archive.
+func readSnapshotManifest(tr *tar.Reader) (*SnapshotManifest, error) {
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot manifest: %w", err)
+	}
+	if hdr.Name != snapshotManifestName {
+		return nil, fmt.Errorf("snapshot manifest expected, got %s", hdr.Name)
+	}
+	manifest := &SnapshotManifest{}
+	err = json.NewDecoder(tr).Decode(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("decoding snapshot manifest: %w", err)
+	}
+	if manifest.Version < 1 || manifest.Version > snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", manifest.Version)
+	}
+	if manifest.From == 0 || manifest.From > manifest.To ||
+		uint64(len(manifest.DataHashes)) != manifest.To-manifest.From+1 {
+		return nil, fmt.Errorf("malformed snapshot manifest")
+	}
+	return manifest, nil
+}
+
+// SnapshotVerifyResult summarizes the outcome of VerifySnapshot.
+type SnapshotVerifyResult struct {
+	// Checked is the amount of CAR files that were checked.
+	Checked int
+	// Missing contains the EDSes which CAR files are not in the archive.
+	Missing []share.DataHash
+	// Corrupted contains the EDSes which CAR files don't match their checksums.
+	Corrupted []CorruptedShard
+}
+
+// CorruptedShard describes a CAR file of a snapshot that failed verification.
+type CorruptedShard struct {
+	DataHash share.DataHash
+	Err      error
+}
+
+// VerifySnapshot checks CAR files of a snapshot archive written by ExportSnapshot against their
+// archived checksums, without importing them. Only the EDSes with the given DataHashes are checked,
+// if any are given, and the rest of the archive is skipped without reading it, if r is an
+// io.Seeker. Snapshots of version 1 have no checksums and can't be verified.
+//
+// Unlike ImportSnapshot, it doesn't check the CAR files against their DataHashes, so it only
+// detects archives corrupted after they were exported.
+func VerifySnapshot(
+	ctx context.Context,
+	r io.Reader,
+	roots ...share.DataHash,
+) (manifest *SnapshotManifest, res *SnapshotVerifyResult, err error) {
+	_, span := tracer.Start(ctx, "store/verify-snapshot", trace.WithAttributes(
+		attribute.Int("roots", len(roots)),
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	tr := tar.NewReader(r)
+	manifest, err = readSnapshotManifest(tr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("eds: %w", err)
+	}
+	if manifest.Version < 2 {
+		return nil, nil, fmt.Errorf("eds: snapshot of version %d has no checksums", manifest.Version)
+	}
+
+	inSnapshot := make(map[string]struct{}, len(manifest.DataHashes))
+	for _, root := range manifest.DataHashes {
+		inSnapshot[root.String()] = struct{}{}
+	}
+	if len(roots) == 0 {
+		roots = manifest.DataHashes
+	}
+	pending := make(map[string]share.DataHash, len(roots))
+	for _, root := range roots {
+		if _, ok := inSnapshot[root.String()]; !ok {
+			return nil, nil, fmt.Errorf("eds: EDS %s is not in the snapshot", root.String())
+		}
+		pending[root.String()] = root
+	}
+
+	// checksums of archives of version 4 follow their CAR files, which are checked against them
+	// once they are read
+	archived := manifest.shardIndex()
+	if manifest.Version >= 4 {
+		manifest.Shards = make([]SnapshotShard, 0, len(inSnapshot))
+	}
+	type unverifiedCAR struct {
+		root share.DataHash
+		cr   *checksumReader
+	}
+	unverified := make(map[string]unverifiedCAR)
+	res = &SnapshotVerifyResult{}
+	corrupted := func(root share.DataHash, err error) {
+		res.Corrupted = append(res.Corrupted, CorruptedShard{DataHash: root, Err: err})
+	}
+	for len(pending) != 0 || len(unverified) != 0 {
+		if err = ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("eds: reading snapshot: %w", err)
+		}
+
+		if key := strings.TrimSuffix(hdr.Name, snapshotShardExt); key != hdr.Name && manifest.Version >= 4 {
+			shard, err := readSnapshotShard(tr, key)
+			if err != nil {
+				return nil, nil, fmt.Errorf("eds: %w", err)
+			}
+			manifest.Shards = append(manifest.Shards, shard)
+			if car, ok := unverified[key]; ok {
+				delete(unverified, key)
+				if err = car.cr.verify(shard); err != nil {
+					corrupted(car.root, err)
+				}
+			}
+			continue
+		}
+
+		key := strings.TrimSuffix(hdr.Name, snapshotCARExt)
+		root, ok := pending[key]
+		if !ok {
+			continue
+		}
+		delete(pending, key)
+
+		res.Checked++
+		cr := newChecksumReader(tr)
+		if _, err = io.Copy(io.Discard, cr); err != nil {
+			corrupted(root, err)
+			continue
+		}
+		shard, ok := archived[key]
+		if !ok {
+			unverified[key] = unverifiedCAR{root: root, cr: cr}
+			continue
+		}
+		if err = cr.verify(shard); err != nil {
+			corrupted(root, err)
+		}
+	}
+
+	for _, root := range pending {
+		res.Missing = append(res.Missing, root)
+	}
+	for _, car := range unverified {
+		corrupted(car.root, fmt.Errorf("%w: CAR file has no checksum", ErrCorrupted))
+	}
+	return manifest, res, nil
+}
+
+// streamSeeker makes a stream seekable forward, so that CAR files can be indexed while they are
+// streamed. Indexing of streams that are not io.Seekers is broken, as it doesn't account for the
+// CAR header in offsets of sections.
+type streamSeeker struct {
+	r      *bufio.Reader
+	offset int64
+}
+
+func newStreamSeeker(r io.Reader) *streamSeeker {
+	return &streamSeeker{r: bufio.NewReader(r)}
+}
+
+func (s *streamSeeker) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	s.offset += int64(n)
+	return n, err
+}
+
+func (s *streamSeeker) ReadByte() (byte, error) {
+	b, err := s.r.ReadByte()
+	if err == nil {
+		s.offset++
+	}
+	return b, err
+}
+
+// Seek discards the stream up to the given offset. Seeking backwards is not supported.
+func (s *streamSeeker) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += s.offset
+	default:
+		return s.offset, fmt.Errorf("unsupported whence %d", whence)
+	}
+	if offset < s.offset {
+		return s.offset, fmt.Errorf("can't seek backwards to %d from %d", offset, s.offset)
+	}
+
+	n, err := s.r.Discard(int(offset - s.offset))
+	s.offset += int64(n)
+	return s.offset, err
+}
+
+// checksumReader computes the size and the SHA-256 checksum of the data read through it.
+type checksumReader struct {
+	r    io.Reader
+	hash hash.Hash
+	size int64
+}
+
+func newChecksumReader(r io.Reader) *checksumReader {
+	return &checksumReader{r: r, hash: sha256.New()}
+}
+
+func (cr *checksumReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.hash.Write(p[:n])
+	cr.size += int64(n)
+	return n, err
+}
+
+// shard describes the data read so far as the SnapshotShard of the EDS.
+func (cr *checksumReader) shard(root share.DataHash) SnapshotShard {
+	return SnapshotShard{
+		DataHash: root,
+		Size:     cr.size,
+		SHA256:   hex.EncodeToString(cr.hash.Sum(nil)),
+	}
+}
+
+// verify checks the data read so far matches the shard.
+func (cr *checksumReader) verify(shard SnapshotShard) error {
+	if cr.size != shard.Size {
+		return fmt.Errorf("%w: size %d, expected %d", ErrCorrupted, cr.size, shard.Size)
+	}
+	if sum := hex.EncodeToString(cr.hash.Sum(nil)); sum != shard.SHA256 {
+		return fmt.Errorf("%w: checksum %s, expected %s", ErrCorrupted, sum, shard.SHA256)
+	}
+	return nil
+}
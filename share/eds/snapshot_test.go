@@ -0,0 +1,359 @@
+package eds
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ipld/go-car"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestStore_Snapshot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	src, err := newStore(t)
+	require.NoError(t, err)
+	err = src.Start(ctx)
+	require.NoError(t, err)
+
+	// heights 1-3 are stored, heights 4 and 5 are empty, height 6 is missing
+	dahs := make(map[uint64]*share.Root)
+	edses := make(map[uint64]*rsmt2d.ExtendedDataSquare)
+	for height := uint64(1); height <= 3; height++ {
+		eds, dah := randomEDS(t)
+		err = src.Put(ctx, dah.Hash(), eds)
+		require.NoError(t, err)
+		dahs[height], edses[height] = &dah, eds
+	}
+	emptyEDS := share.EmptyExtendedDataSquare()
+	emptyDAH := da.NewDataAvailabilityHeader(emptyEDS)
+	err = src.Put(ctx, emptyDAH.Hash(), emptyEDS)
+	require.NoError(t, err)
+	dahs[4], dahs[5] = &emptyDAH, &emptyDAH
+	_, missingDAH := randomEDS(t)
+	dahs[6] = &missingDAH
+
+	getter := func(_ context.Context, height uint64) (*header.ExtendedHeader, error) {
+		dah, ok := dahs[height]
+		if !ok {
+			return nil, fmt.Errorf("no header at height %d", height)
+		}
+		return &header.ExtendedHeader{RawHeader: core.Header{DataHash: dah.Hash()}, DAH: dah}, nil
+	}
+
+	t.Run("ExportImport", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		manifest, err := src.ExportSnapshot(ctx, buf, 1, 5, getter)
+		require.NoError(t, err)
+		assert.Len(t, manifest.DataHashes, 5)
+
+		dst, err := newStore(t)
+		require.NoError(t, err)
+		err = dst.Start(ctx)
+		require.NoError(t, err)
+
+		imported, err := dst.ImportSnapshot(ctx, buf, getter)
+		require.NoError(t, err)
+		assert.Equal(t, manifest, imported)
+
+		for height := uint64(1); height <= 5; height++ {
+			root, ok := imported.DataHash(height)
+			require.True(t, ok)
+			assert.Equal(t, share.DataHash(dahs[height].Hash()), root)
+
+			eds, err := dst.Get(ctx, root)
+			require.NoError(t, err)
+			if expected, ok := edses[height]; ok {
+				assert.Equal(t, expected.Flattened(), eds.Flattened())
+			}
+		}
+		_, ok := imported.DataHash(6)
+		assert.False(t, ok)
+
+		// blocks of imported EDSes are found in the inverted index
+		r, err := src.GetCAR(ctx, dahs[1].Hash())
+		require.NoError(t, err)
+		carReader, err := car.NewCarReader(r)
+		require.NoError(t, err)
+		block, err := carReader.Next()
+		require.NoError(t, err)
+		has, err := dst.Blockstore().Has(ctx, block.Cid())
+		require.NoError(t, err)
+		assert.True(t, has)
+
+		// the empty EDS is archived once
+		require.Len(t, manifest.Shards, 4)
+		for _, shard := range manifest.Shards {
+			size, err := src.carStorage.Size(ctx, shard.DataHash.String())
+			require.NoError(t, err)
+			assert.Equal(t, size, shard.Size)
+		}
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		_, err := src.ExportSnapshot(ctx, buf, 1, 3, getter)
+		require.NoError(t, err)
+
+		dst, err := newStore(t)
+		require.NoError(t, err)
+		err = dst.Start(ctx)
+		require.NoError(t, err)
+		// the first EDS was imported before the import was interrupted
+		err = dst.Put(ctx, dahs[1].Hash(), edses[1])
+		require.NoError(t, err)
+
+		_, err = dst.ImportSnapshot(ctx, bytes.NewReader(buf.Bytes()), nil)
+		require.NoError(t, err)
+		for height := uint64(1); height <= 3; height++ {
+			has, err := dst.Has(ctx, dahs[height].Hash())
+			require.NoError(t, err)
+			assert.True(t, has)
+		}
+	})
+
+	t.Run("ValidateHeaders", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		_, err := src.ExportSnapshot(ctx, buf, 1, 3, getter)
+		require.NoError(t, err)
+
+		dst, err := newStore(t)
+		require.NoError(t, err)
+		err = dst.Start(ctx)
+		require.NoError(t, err)
+
+		// squares not committed to by the headers of their heights are not imported
+		swapped := func(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+			if height == 2 {
+				height = 3
+			}
+			return getter(ctx, height)
+		}
+		_, err = dst.ImportSnapshot(ctx, bytes.NewReader(buf.Bytes()), swapped)
+		require.Error(t, err)
+		has, err := dst.Has(ctx, dahs[1].Hash())
+		require.NoError(t, err)
+		assert.False(t, has)
+
+		// heights which headers are not synced yet are not validated
+		unsynced := func(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+			if height == 3 {
+				return nil, libhead.ErrNotFound
+			}
+			return getter(ctx, height)
+		}
+		_, err = dst.ImportSnapshot(ctx, bytes.NewReader(buf.Bytes()), unsynced)
+		require.NoError(t, err)
+		has, err = dst.Has(ctx, dahs[3].Hash())
+		require.NoError(t, err)
+		assert.True(t, has)
+	})
+
+	t.Run("ImportVersion1", func(t *testing.T) {
+		// archives of version 1 have neither checksums nor indexes
+		manifest := &SnapshotManifest{Version: 1, From: 1, To: 3}
+		for height := uint64(1); height <= 3; height++ {
+			manifest.DataHashes = append(manifest.DataHashes, dahs[height].Hash())
+		}
+		rawManifest, err := json.Marshal(manifest)
+		require.NoError(t, err)
+
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		err = writeTarEntry(tw, snapshotManifestName, int64(len(rawManifest)), bytes.NewReader(rawManifest))
+		require.NoError(t, err)
+		for _, root := range manifest.DataHashes {
+			size, err := src.carStorage.Size(ctx, root.String())
+			require.NoError(t, err)
+			r, err := src.carStorage.Get(ctx, root.String())
+			require.NoError(t, err)
+			err = writeTarEntry(tw, root.String()+snapshotCARExt, size, r)
+			require.NoError(t, err)
+			require.NoError(t, r.Close())
+		}
+		require.NoError(t, tw.Close())
+
+		dst, err := newStore(t)
+		require.NoError(t, err)
+		err = dst.Start(ctx)
+		require.NoError(t, err)
+
+		imported, err := dst.ImportSnapshot(ctx, bytes.NewReader(buf.Bytes()), getter)
+		require.NoError(t, err)
+		assert.Equal(t, manifest, imported)
+		for height := uint64(1); height <= 3; height++ {
+			eds, err := dst.Get(ctx, dahs[height].Hash())
+			require.NoError(t, err)
+			assert.Equal(t, edses[height].Flattened(), eds.Flattened())
+		}
+
+		_, _, err = VerifySnapshot(ctx, bytes.NewReader(buf.Bytes()))
+		assert.Error(t, err)
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		manifest, err := src.ExportSnapshot(ctx, buf, 1, 5, getter)
+		require.NoError(t, err)
+
+		_, res, err := VerifySnapshot(ctx, bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, &SnapshotVerifyResult{Checked: len(manifest.Shards)}, res)
+
+		// only the requested EDS is checked
+		_, res, err = VerifySnapshot(ctx, bytes.NewReader(buf.Bytes()), dahs[2].Hash())
+		require.NoError(t, err)
+		assert.Equal(t, &SnapshotVerifyResult{Checked: 1}, res)
+
+		_, _, err = VerifySnapshot(ctx, bytes.NewReader(buf.Bytes()), missingDAH.Hash())
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, err := src.ExportSnapshot(ctx, &bytes.Buffer{}, 5, 6, getter)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("InvalidRange", func(t *testing.T) {
+		_, err := src.ExportSnapshot(ctx, &bytes.Buffer{}, 3, 1, getter)
+		assert.Error(t, err)
+	})
+
+	t.Run("Corrupted", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		_, err := src.ExportSnapshot(ctx, buf, 1, 1, getter)
+		require.NoError(t, err)
+
+		data := buf.Bytes()
+		odsWidth := edses[1].Width() / 2
+		lastShare := edses[1].GetCell(odsWidth-1, odsWidth-1)
+		idx := bytes.Index(data, lastShare)
+		require.Positive(t, idx)
+		data[idx] ^= 0xFF
+
+		dst, err := newStore(t)
+		require.NoError(t, err)
+		err = dst.Start(ctx)
+		require.NoError(t, err)
+
+		_, err = dst.ImportSnapshot(ctx, bytes.NewReader(data), nil)
+		assert.ErrorIs(t, err, ErrCorrupted)
+
+		_, res, err := VerifySnapshot(ctx, bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, 1, res.Checked)
+		assert.Empty(t, res.Missing)
+		require.Len(t, res.Corrupted, 1)
+		assert.Equal(t, share.DataHash(dahs[1].Hash()), res.Corrupted[0].DataHash)
+		assert.ErrorIs(t, res.Corrupted[0].Err, ErrCorrupted)
+
+		has, err := dst.Has(ctx, dahs[1].Hash())
+		require.NoError(t, err)
+		assert.False(t, has)
+		_, err = dst.carStorage.Size(ctx, share.DataHash(dahs[1].Hash()).String())
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("CorruptedChecksum", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		_, err := src.ExportSnapshot(ctx, buf, 1, 1, getter)
+		require.NoError(t, err)
+
+		// rewrite the archive with the checksum of the CAR file replaced
+		corrupted := &bytes.Buffer{}
+		tr, tw := tar.NewReader(buf), tar.NewWriter(corrupted)
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			data, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			if strings.HasSuffix(hdr.Name, snapshotShardExt) {
+				var shard SnapshotShard
+				require.NoError(t, json.Unmarshal(data, &shard))
+				shard.SHA256 = strings.Repeat("0", len(shard.SHA256))
+				data, err = json.Marshal(shard)
+				require.NoError(t, err)
+				hdr.Size = int64(len(data))
+			}
+			require.NoError(t, tw.WriteHeader(hdr))
+			_, err = tw.Write(data)
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+
+		dst, err := newStore(t)
+		require.NoError(t, err)
+		err = dst.Start(ctx)
+		require.NoError(t, err)
+
+		// the CAR file is valid according to its DataHash, but it is not registered, as it doesn't
+		// match its checksum
+		_, err = dst.ImportSnapshot(ctx, bytes.NewReader(corrupted.Bytes()), nil)
+		assert.ErrorIs(t, err, ErrCorrupted)
+		has, err := dst.Has(ctx, dahs[1].Hash())
+		require.NoError(t, err)
+		assert.False(t, has)
+
+		_, res, err := VerifySnapshot(ctx, bytes.NewReader(corrupted.Bytes()))
+		require.NoError(t, err)
+		require.Len(t, res.Corrupted, 1)
+		assert.ErrorIs(t, res.Corrupted[0].Err, ErrCorrupted)
+	})
+
+	t.Run("CorruptedIndex", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		_, err := src.ExportSnapshot(ctx, buf, 1, 1, getter)
+		require.NoError(t, err)
+
+		// rewrite the archive with the last byte of the index flipped
+		corrupted := &bytes.Buffer{}
+		tr, tw := tar.NewReader(buf), tar.NewWriter(corrupted)
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			data, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			if strings.HasSuffix(hdr.Name, snapshotIndexExt) {
+				data[len(data)-1] ^= 0xFF
+			}
+			require.NoError(t, tw.WriteHeader(hdr))
+			_, err = tw.Write(data)
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+
+		dst, err := newStore(t)
+		require.NoError(t, err)
+		err = dst.Start(ctx)
+		require.NoError(t, err)
+
+		_, err = dst.ImportSnapshot(ctx, corrupted, nil)
+		assert.ErrorIs(t, err, ErrCorrupted)
+		has, err := dst.Has(ctx, dahs[1].Hash())
+		require.NoError(t, err)
+		assert.False(t, has)
+	})
+}
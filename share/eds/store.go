@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/filecoin-project/dagstore/mount"
 	"github.com/filecoin-project/dagstore/shard"
 	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
 	bstore "github.com/ipfs/go-ipfs-blockstore"
 	carv1 "github.com/ipld/go-car"
 	"go.opentelemetry.io/otel/attribute"
@@ -24,6 +26,7 @@ import (
 	"github.com/celestiaorg/rsmt2d"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/cache"
 	"github.com/celestiaorg/celestia-node/libs/utils"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/ipld"
@@ -35,6 +38,8 @@ const (
 	transientsPath = "/transients/"
 
 	defaultGCInterval = time.Hour
+	// defaultWriteConcurrency is the default amount of EDSes the Store writes in parallel.
+	defaultWriteConcurrency = 8
 )
 
 var ErrNotFound = errors.New("eds not found in store")
@@ -43,14 +48,45 @@ var ErrNotFound = errors.New("eds not found in store")
 // every share and/or Merkle proof over every registered CARv1 file. The EDSStore provides a custom
 // blockstore interface implementation to achieve access. The main use-case is randomized sampling
 // over the whole chain of EDS block data and getting data by namespace.
+//
+// CARv1 files only store the ODS, while parity shares and Merkle proofs are recomputed on demand.
 type Store struct {
 	cancel context.CancelFunc
 
 	dgstr  *dagstore.DAGStore
 	mounts *mount.Registry
 
-	cache *blockstoreCache
-	bs    bstore.Blockstore
+	cache  *blockstoreCache
+	bs     bstore.Blockstore
+	access *accessTracker
+	// maxSize is the total size of CAR files least accessed EDSes are pruned beyond, if positive
+	maxSize int64
+
+	carStorage   CARStorage
+	carCacheSize int
+
+	// edsCache keeps recently accessed EDSes decoded in memory.
+	edsCache     *edsCache
+	edsCacheSize int64
+	// mapped shares the memory-mapped CAR files of EDSes between their readers.
+	mapped *mappings
+	// extended keeps blockstores of recently re-extended EDSes, serving parity shares and NMT nodes
+	// missing in CAR files. Concurrent re-extensions of the same EDS are deduplicated.
+	extended *cache.Cache[string, bstore.Blockstore]
+
+	// writeLocks serialize writes and removals of the same EDS, while EDSes of different heights
+	// are written in parallel.
+	writeLocks [256]sync.Mutex
+	// writers bounds the amount of EDSes written in parallel.
+	writers          chan struct{}
+	writeConcurrency int
+
+	// migrations tracks progress of Migrations, while migration is the Migration run in the
+	// background once the Store is started.
+	migrations datastore.Datastore
+	migration  Migration
+	// migrated is closed once the Migration run in the background exits
+	migrated chan struct{}
 
 	topIdx index.Inverted
 	carIdx index.FullIndexRepo
@@ -62,17 +98,57 @@ type Store struct {
 }
 
 // NewStore creates a new EDS Store under the given basepath and datastore.
-func NewStore(basepath string, ds datastore.Batching) (*Store, error) {
+func NewStore(basepath string, ds datastore.Batching, opts ...Option) (*Store, error) {
+	store := &Store{
+		basepath:     basepath,
+		gcInterval:   defaultGCInterval,
+		access:       newAccessTracker(ds),
+		migrations:   namespace.Wrap(ds, datastore.NewKey("/eds/migrations")),
+		edsCacheSize: defaultEDSCacheSize,
+
+		writeConcurrency: defaultWriteConcurrency,
+	}
+	for _, opt := range opts {
+		opt(store)
+	}
+	if store.maxSize < 0 {
+		return nil, fmt.Errorf("eds/store: max size can't be negative, got %d", store.maxSize)
+	}
+	if store.writeConcurrency <= 0 {
+		return nil, fmt.Errorf("eds/store: write concurrency must be positive, got %d", store.writeConcurrency)
+	}
+	store.edsCache = newEDSCache(store.edsCacheSize)
+	store.writers = make(chan struct{}, store.writeConcurrency)
+
+	store.extended = cache.New[string, bstore.Blockstore](defaultExtendedCacheSize)
+	store.mapped = newMappings()
+
 	err := setupPath(basepath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup eds.Store directories: %w", err)
 	}
 
+	switch {
+	case store.carStorage == nil:
+		store.carStorage = newFileStorage(basepath + blocksPath)
+	case store.carCacheSize > 0:
+		store.carStorage, err = newCachedStorage(store.carStorage, basepath+cachePath, store.carCacheSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	r := mount.NewRegistry()
+	// CAR files registered before CARStorage was introduced are mounted directly from the
+	// filesystem
 	err = r.Register("fs", &mount.FileMount{Path: basepath + blocksPath})
 	if err != nil {
 		return nil, fmt.Errorf("failed to register FS mount on the registry: %w", err)
 	}
+	err = r.Register(carStorageScheme, &storageMount{Storage: store.carStorage})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register CAR storage mount on the registry: %w", err)
+	}
 
 	fsRepo, err := index.NewFSRepo(basepath + indexPath)
 	if err != nil {
@@ -93,29 +169,45 @@ func NewStore(basepath string, ds datastore.Batching) (*Store, error) {
 		return nil, fmt.Errorf("failed to create DAGStore: %w", err)
 	}
 
-	cache, err := newBlockstoreCache(defaultCacheSize)
+	bsCache, err := newBlockstoreCache(defaultCacheSize)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create blockstore cache: %w", err)
 	}
 
-	store := &Store{
-		basepath:   basepath,
-		dgstr:      dagStore,
-		topIdx:     invertedRepo,
-		carIdx:     fsRepo,
-		gcInterval: defaultGCInterval,
-		mounts:     r,
-		cache:      cache,
-	}
-	store.bs = newBlockstore(store, cache)
+	store.dgstr = dagStore
+	store.topIdx = invertedRepo
+	store.carIdx = fsRepo
+	store.mounts = r
+	store.cache = bsCache
+	store.bs = newBlockstore(store, bsCache)
 	return store, nil
 }
 
+// WithMetrics turns on metric collection in the caches of the Store.
+func (s *Store) WithMetrics() error {
+	err := s.cache.cache.WithMetrics("eds_store_accessors")
+	if err != nil {
+		return err
+	}
+	err = s.extended.WithMetrics("eds_store_extended")
+	if err != nil {
+		return err
+	}
+	if cs, ok := s.carStorage.(*cachedStorage); ok {
+		return cs.cache.WithMetrics("eds_store_car_files")
+	}
+	return nil
+}
+
 func (s *Store) Start(ctx context.Context) error {
 	err := s.dgstr.Start(ctx)
 	if err != nil {
 		return err
 	}
+	err = s.access.load(ctx)
+	if err != nil {
+		return err
+	}
 	// start Store only if DagStore succeeds
 	ctx, cancel := context.WithCancel(context.Background())
 	s.cancel = cancel
@@ -124,12 +216,31 @@ func (s *Store) Start(ctx context.Context) error {
 		Shards: make(map[shard.Key]error),
 	})
 	go s.gc(ctx)
+	if s.migration != nil {
+		s.migrated = make(chan struct{})
+		go func() {
+			defer close(s.migrated)
+			s.migrate(ctx)
+		}()
+	}
 	return nil
 }
 
-// Stop stops the underlying DAGStore.
-func (s *Store) Stop(context.Context) error {
-	defer s.cancel()
+// Stop stops the underlying DAGStore, once the Migration run in the background exits.
+func (s *Store) Stop(ctx context.Context) error {
+	defer s.mapped.close()
+	s.cancel()
+	// the Migration rewrites shards of the DAGStore, so it must exit before the DAGStore is closed
+	if s.migrated != nil {
+		select {
+		case <-s.migrated:
+		case <-ctx.Done():
+			return fmt.Errorf("eds/store: waiting for the migration to exit: %w", ctx.Err())
+		}
+	}
+	if err := s.access.flush(ctx); err != nil {
+		log.Errorw("flushing access stats", "err", err)
+	}
 	return s.dgstr.Close()
 }
 
@@ -141,6 +252,14 @@ func (s *Store) gc(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
+			if err := s.access.flush(ctx); err != nil {
+				log.Errorw("flushing access stats", "err", err)
+			}
+			if s.maxSize > 0 {
+				if _, err := s.PruneLeastAccessed(ctx, s.maxSize); err != nil {
+					log.Errorw("pruning least accessed EDSes", "err", err)
+				}
+			}
 			res, err := s.dgstr.GC(ctx)
 			if err != nil {
 				log.Errorf("garbage collecting dagstore: %v", err)
@@ -155,9 +274,17 @@ func (s *Store) gc(ctx context.Context) {
 // Put stores the given data square with DataRoot's hash as a key.
 //
 // The square is verified on the Exchange level, and Put only stores the square, trusting it.
-// The resulting file stores all the shares and NMT Merkle Proofs of the EDS.
-// Additionally, the file gets indexed s.t. store.Blockstore can access them.
+// The resulting file stores only the ODS of the EDS, cutting its size 4 times. Still, all the
+// shares and NMT Merkle Proofs of the EDS get indexed s.t. store.Blockstore can access them by
+// re-extending the EDS.
+//
+// Put is safe for concurrent use. Puts of different EDSes run in parallel, bounded by the write
+// concurrency of the Store, while concurrent Puts of the same EDS are serialized, so that only
+// the first one writes it and the others return dagstore.ErrShardExists.
 func (s *Store) Put(ctx context.Context, root share.DataHash, square *rsmt2d.ExtendedDataSquare) (err error) {
+	unlock := s.lockWrite(root)
+	defer unlock()
+
 	// if root already exists, short-circuit
 	has, err := s.Has(ctx, root)
 	if err != nil {
@@ -168,28 +295,73 @@ func (s *Store) Put(ctx context.Context, root share.DataHash, square *rsmt2d.Ext
 	}
 
 	ctx, span := tracer.Start(ctx, "store/put", trace.WithAttributes(
-		attribute.String("root", root.String()),
-		attribute.Int("width", int(square.Width())),
+		share.TraceAttributes(ctx, root, nil,
+			attribute.Int("width", int(square.Width())),
+		)...,
 	))
 	defer func() {
 		utils.SetStatusAndEnd(span, err)
 	}()
 
-	key := root.String()
-	f, err := os.OpenFile(s.basepath+blocksPath+key, os.O_CREATE|os.O_WRONLY, 0600)
+	release, err := s.acquireWriter(ctx)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
+	defer release()
+
+	key := root.String()
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeODS(square, pw))
+	}()
+	err = s.carStorage.Put(ctx, key, pr)
+	// unblock the writer in case storing failed before the whole EDS was read
+	pr.CloseWithError(err)
+	if err != nil {
+		return fmt.Errorf("failed to write EDS to storage: %w", err)
+	}
 
-	err = WriteEDS(ctx, square, f)
+	err = s.registerShard(ctx, key, square)
 	if err != nil {
-		return fmt.Errorf("failed to write EDS to file: %w", err)
+		return err
+	}
+	if err := s.edsCache.add(key, square); err != nil {
+		log.Warnw("caching EDS", "key", key, "err", err)
+	}
+	return nil
+}
+
+// lockWrite locks writes and removals of the EDS with the given root. It returns a function
+// releasing the lock.
+func (s *Store) lockWrite(root share.DataHash) func() {
+	var stripe byte
+	if len(root) > 0 {
+		stripe = root[len(root)-1]
+	}
+	lk := &s.writeLocks[stripe]
+	lk.Lock()
+	return lk.Unlock
+}
+
+// acquireWriter blocks until the amount of EDSes being written is below the write concurrency of
+// the Store. It returns a function releasing the acquired writer slot.
+func (s *Store) acquireWriter(ctx context.Context) (func(), error) {
+	select {
+	case s.writers <- struct{}{}:
+		return func() { <-s.writers }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
+}
 
+// registerShard registers the CAR file stored under the given key in CARStorage on the DAGStore
+// and indexes the blocks of the EDS missing in the CAR file. The EDS is read from the CAR file
+// unless given.
+func (s *Store) registerShard(ctx context.Context, key string, square *rsmt2d.ExtendedDataSquare) error {
 	ch := make(chan dagstore.ShardResult, 1)
-	err = s.dgstr.RegisterShard(ctx, shard.KeyFromString(key), &mount.FileMount{
-		Path: s.basepath + blocksPath + key,
+	err := s.dgstr.RegisterShard(ctx, shard.KeyFromString(key), &storageMount{
+		Storage: s.carStorage,
+		Key:     key,
 	}, ch, dagstore.RegisterOpts{})
 	if err != nil {
 		return fmt.Errorf("failed to initiate shard registration: %w", err)
@@ -202,8 +374,18 @@ func (s *Store) Put(ctx context.Context, root share.DataHash, square *rsmt2d.Ext
 		if result.Error != nil {
 			return fmt.Errorf("failed to register shard: %w", result.Error)
 		}
-		return nil
 	}
+
+	s.access.init(key)
+	err = s.indexExtended(ctx, key, square)
+	if err != nil {
+		// do not keep the EDS partially indexed, so that it can be put again
+		if derr := s.destroyShard(ctx, key); derr != nil {
+			log.Warnw("destroying partially indexed shard", "key", key, "err", derr)
+		}
+		return fmt.Errorf("failed to index extended EDS: %w", err)
+	}
+	return nil
 }
 
 // GetCAR takes a DataRoot and returns a buffered reader to the respective EDS serialized as a
@@ -214,7 +396,7 @@ func (s *Store) Put(ctx context.Context, root share.DataHash, square *rsmt2d.Ext
 // The shard is cached in the Store, so subsequent calls to GetCAR with the same root will use the
 // same reader. The cache is responsible for closing the underlying reader.
 func (s *Store) GetCAR(ctx context.Context, root share.DataHash) (io.Reader, error) {
-	ctx, span := tracer.Start(ctx, "store/get-car", trace.WithAttributes(attribute.String("root", root.String())))
+	ctx, span := tracer.Start(ctx, "store/get-car", trace.WithAttributes(share.TraceAttributes(ctx, root, nil)...))
 	defer span.End()
 
 	key := root.String()
@@ -246,12 +428,12 @@ func (s *Store) CARBlockstore(
 	if err != nil {
 		return nil, fmt.Errorf("eds/store: failed to get accessor: %w", err)
 	}
-	return accessor.bs, nil
+	return s.carBlockstore(key, accessor.bs), nil
 }
 
 // GetDAH returns the DataAvailabilityHeader for the EDS identified by DataHash.
 func (s *Store) GetDAH(ctx context.Context, root share.DataHash) (*share.Root, error) {
-	ctx, span := tracer.Start(ctx, "store/get-dah", trace.WithAttributes(attribute.String("root", root.String())))
+	ctx, span := tracer.Start(ctx, "store/get-dah", trace.WithAttributes(share.TraceAttributes(ctx, root, nil)...))
 	defer span.End()
 
 	key := shard.KeyFromString(root.String())
@@ -307,37 +489,52 @@ func (s *Store) getAccessor(ctx context.Context, key shard.Key) (*dagstore.Shard
 }
 
 func (s *Store) getCachedAccessor(ctx context.Context, key shard.Key) (*accessorWithBlockstore, error) {
-	lk := &s.cache.stripedLocks[shardKeyToStriped(key)]
-	lk.Lock()
-	defer lk.Unlock()
-
-	accessor, err := s.cache.unsafeGet(key)
-	if err != nil && err != errCacheMiss {
-		log.Errorf("unexpected error while reading key from bs cache %s: %s", key, err)
-	}
-	if accessor != nil {
-		return accessor, nil
-	}
-
-	// wasn't found in cache, so acquire it and add to cache
-	shardAccessor, err := s.getAccessor(ctx, key)
+	// acquire the shard only if it isn't cached yet
+	accessor, err := s.cache.GetOrLoad(ctx, key, s.getAccessor)
 	if err != nil {
 		return nil, err
 	}
-	return s.cache.unsafeAdd(key, shardAccessor)
+	s.access.record(key.String())
+	return accessor, nil
 }
 
 // Remove removes EDS from Store by the given share.Root hash and cleans up all
 // the indexing.
 func (s *Store) Remove(ctx context.Context, root share.DataHash) (err error) {
-	ctx, span := tracer.Start(ctx, "store/remove", trace.WithAttributes(attribute.String("root", root.String())))
+	ctx, span := tracer.Start(ctx, "store/remove", trace.WithAttributes(share.TraceAttributes(ctx, root, nil)...))
 	defer func() {
 		utils.SetStatusAndEnd(span, err)
 	}()
 
+	unlock := s.lockWrite(root)
+	defer unlock()
+
 	key := root.String()
+	err = s.destroyShard(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	err = s.carStorage.Remove(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to remove CAR file: %w", err)
+	}
+	s.mapped.evict(key)
+
+	err = s.forgetMigrations(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to drop migration progress: %w", err)
+	}
+	return nil
+}
+
+// destroyShard unregisters the shard from the DAGStore and drops its index.
+func (s *Store) destroyShard(ctx context.Context, key string) error {
+	// cached accessors hold a reference to the shard, which prevents its destruction
+	s.cache.Remove(shard.KeyFromString(key))
+
 	ch := make(chan dagstore.ShardResult, 1)
-	err = s.dgstr.DestroyShard(ctx, shard.KeyFromString(key), ch, dagstore.DestroyOpts{})
+	err := s.dgstr.DestroyShard(ctx, shard.KeyFromString(key), ch, dagstore.DestroyOpts{})
 	if err != nil {
 		return fmt.Errorf("failed to initiate shard destruction: %w", err)
 	}
@@ -351,6 +548,10 @@ func (s *Store) Remove(ctx context.Context, root share.DataHash) (err error) {
 		return ctx.Err()
 	}
 
+	s.access.remove(key)
+	s.edsCache.remove(key)
+	s.extended.Remove(key)
+
 	dropped, err := s.carIdx.DropFullIndex(shard.KeyFromString(key))
 	if !dropped {
 		log.Warnf("failed to drop index for %s", key)
@@ -358,24 +559,26 @@ func (s *Store) Remove(ctx context.Context, root share.DataHash) (err error) {
 	if err != nil {
 		return fmt.Errorf("failed to drop index for %s: %w", key, err)
 	}
-
-	err = os.Remove(s.basepath + blocksPath + key)
-	if err != nil {
-		return fmt.Errorf("failed to remove CAR file: %w", err)
-	}
 	return nil
 }
 
 // Get reads EDS out of Store by given DataRoot.
 //
 // It reads only one quadrant(1/4) of the EDS and verifies the integrity of the stored data by
-// recomputing it.
+// recomputing it. Recently accessed EDSes are served from copies of the EDSes kept in memory.
 func (s *Store) Get(ctx context.Context, root share.DataHash) (eds *rsmt2d.ExtendedDataSquare, err error) {
-	ctx, span := tracer.Start(ctx, "store/get", trace.WithAttributes(attribute.String("root", root.String())))
+	ctx, span := tracer.Start(ctx, "store/get", trace.WithAttributes(share.TraceAttributes(ctx, root, nil)...))
 	defer func() {
 		utils.SetStatusAndEnd(span, err)
 	}()
 
+	key := root.String()
+	if eds, ok := s.edsCache.get(key); ok {
+		span.AddEvent("eds cache hit")
+		s.access.record(key)
+		return copyEDS(eds)
+	}
+
 	f, err := s.GetCAR(ctx, root)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get CAR file: %w", err)
@@ -384,12 +587,40 @@ func (s *Store) Get(ctx context.Context, root share.DataHash) (eds *rsmt2d.Exten
 	if err != nil {
 		return nil, fmt.Errorf("failed to read EDS from CAR file: %w", err)
 	}
+	if err := s.edsCache.add(key, eds); err != nil {
+		log.Warnw("caching EDS", "key", key, "err", err)
+	}
 	return eds, nil
 }
 
+// GetODS returns a reader of the CARv1 header and the first quadrant(ODS) of the EDS identified by
+// the given DataHash. If the EDS is kept in memory, the ODS is served without reading the CAR file.
+func (s *Store) GetODS(ctx context.Context, root share.DataHash) (io.Reader, error) {
+	ctx, span := tracer.Start(ctx, "store/get-ods", trace.WithAttributes(share.TraceAttributes(ctx, root, nil)...))
+	defer span.End()
+
+	key := root.String()
+	if eds, ok := s.edsCache.get(key); ok {
+		span.AddEvent("eds cache hit")
+		s.access.record(key)
+		buf := new(bytes.Buffer)
+		err := writeODS(eds, buf)
+		if err != nil {
+			return nil, fmt.Errorf("eds/store: writing ODS: %w", err)
+		}
+		return buf, nil
+	}
+
+	f, err := s.GetCAR(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	return ODSReader(f)
+}
+
 // Has checks if EDS exists by the given share.Root hash.
 func (s *Store) Has(ctx context.Context, root share.DataHash) (bool, error) {
-	_, span := tracer.Start(ctx, "store/has", trace.WithAttributes(attribute.String("root", root.String())))
+	_, span := tracer.Start(ctx, "store/has", trace.WithAttributes(share.TraceAttributes(ctx, root, nil)...))
 	defer span.End()
 
 	key := root.String()
@@ -417,5 +648,9 @@ func setupPath(basepath string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create index directory: %w", err)
 	}
+	err = os.MkdirAll(basepath+quarantinePath, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create quarantine directory: %w", err)
+	}
 	return nil
 }
@@ -2,10 +2,15 @@ package eds
 
 import (
 	"context"
+	"errors"
+	"io"
 	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/filecoin-project/dagstore"
 	"github.com/filecoin-project/dagstore/shard"
 	"github.com/ipfs/go-datastore"
 	ds_sync "github.com/ipfs/go-datastore/sync"
@@ -60,7 +65,7 @@ func TestEDSStore(t *testing.T) {
 	})
 
 	// GetCAR ensures that the reader returned from GetCAR is capable of reading the CAR header and
-	// ODS.
+	// ODS, which is the only data stored in the CAR file.
 	t.Run("GetCAR", func(t *testing.T) {
 		eds, dah := randomEDS(t)
 
@@ -80,6 +85,8 @@ func TestEDSStore(t *testing.T) {
 				assert.Equal(t, original, block.RawData()[share.NamespaceSize:])
 			}
 		}
+		_, err = carReader.Next()
+		assert.ErrorIs(t, err, io.EOF)
 	})
 
 	t.Run("item not exist", func(t *testing.T) {
@@ -155,6 +162,59 @@ func TestEDSStore(t *testing.T) {
 	})
 }
 
+// TestEDSStore_ConcurrentPut verifies that EDSes can be put in parallel, and that concurrent Puts
+// of the same EDS store it only once.
+func TestEDSStore_ConcurrentPut(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	const (
+		amount     = 8
+		duplicates = 3
+	)
+	edses := make([]*rsmt2d.ExtendedDataSquare, amount)
+	dahs := make([]share.Root, amount)
+	for i := range edses {
+		edses[i], dahs[i] = randomEDS(t)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		stored  atomic.Int32
+		existed atomic.Int32
+	)
+	for i := 0; i < amount*duplicates; i++ {
+		i := i % amount
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := edsStore.Put(ctx, dahs[i].Hash(), edses[i])
+			switch {
+			case err == nil:
+				stored.Add(1)
+			case errors.Is(err, dagstore.ErrShardExists):
+				existed.Add(1)
+			default:
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, amount, stored.Load())
+	assert.EqualValues(t, amount*(duplicates-1), existed.Load())
+	for i := range edses {
+		eds, err := edsStore.Get(ctx, dahs[i].Hash())
+		require.NoError(t, err)
+		assert.Equal(t, edses[i].Flattened(), eds.Flattened())
+	}
+}
+
 // TestEDSStore_GC verifies that unused transient shards are collected by the GC periodically.
 func TestEDSStore_GC(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
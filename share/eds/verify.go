@@ -0,0 +1,283 @@
+package eds
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ipld/go-car"
+	"github.com/minio/sha256-simd"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+const quarantinePath = "/quarantine/"
+
+// ErrCorrupted is returned when the stored CAR file of an EDS does not match its DataHash.
+var ErrCorrupted = errors.New("eds corrupted")
+
+// HeaderGetter retrieves the ExtendedHeader at the given height.
+// It is used by the Store to map heights onto the DataHashes it is keyed by.
+type HeaderGetter func(context.Context, uint64) (*header.ExtendedHeader, error)
+
+// ValidateAgainstHeader ensures the roots recomputed from the given EDS match the DAH of the given
+// header, and that the DAH is the one the header commits to. It returns share.ErrRootMismatch with
+// the first differing row or col if the roots differ.
+func ValidateAgainstHeader(eds *rsmt2d.ExtendedDataSquare, h *header.ExtendedHeader) error {
+	if !bytes.Equal(h.DAH.Hash(), h.DataHash) {
+		return fmt.Errorf("eds: header at height %d commits to data hash %X, but its DAH hashes to %X",
+			h.Height(), h.DataHash, h.DAH.Hash())
+	}
+
+	err := share.ValidateAgainstRoot(eds, h.DAH)
+	if errMismatch, ok := err.(*share.ErrRootMismatch); ok {
+		errMismatch.Height = uint64(h.Height())
+	}
+	return err
+}
+
+// VerifyResult summarizes the outcome of Store.Verify.
+type VerifyResult struct {
+	// Checked is the amount of unique EDSes that were verified.
+	Checked int
+	// Missing contains heights that have no EDS stored.
+	Missing []uint64
+	// Corrupted contains heights which EDS failed verification.
+	Corrupted []CorruptedEDS
+}
+
+// CorruptedEDS describes a stored EDS that failed verification.
+type CorruptedEDS struct {
+	Height   uint64
+	DataHash share.DataHash
+	Err      error
+	// Quarantined reports whether the corrupted CAR file was moved out of the Store.
+	Quarantined bool
+}
+
+// Verify re-reads CAR files stored for the inclusive height range [from; to], checking that every
+// block matches its CID and that the recomputed row and column roots match the DataHash.
+// Heights are mapped onto DataHashes using the given HeaderGetter.
+//
+// If quarantine is set, corrupted CAR files are unregistered from the Store and moved to a
+// separate quarantine directory, so they are no longer served and can be fetched again.
+func (s *Store) Verify(
+	ctx context.Context,
+	from, to uint64,
+	getter HeaderGetter,
+	quarantine bool,
+) (res *VerifyResult, err error) {
+	ctx, span := tracer.Start(ctx, "store/verify", trace.WithAttributes(
+		attribute.Int64("from", int64(from)),
+		attribute.Int64("to", int64(to)),
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	if from == 0 || from > to {
+		return nil, fmt.Errorf("eds/store: invalid range [%d; %d]", from, to)
+	}
+
+	res = &VerifyResult{}
+	// multiple heights can point to the same EDS, e.g. the empty one, so verify each only once
+	verified := make(map[string]struct{})
+	for height := from; height <= to; height++ {
+		h, err := getter(ctx, height)
+		if err != nil {
+			return res, fmt.Errorf("eds/store: getting header at height %d: %w", height, err)
+		}
+
+		root := share.DataHash(h.DAH.Hash())
+		key := root.String()
+		if _, ok := verified[key]; ok {
+			continue
+		}
+		verified[key] = struct{}{}
+
+		err = s.verify(ctx, root)
+		switch {
+		case err == nil:
+			res.Checked++
+		case errors.Is(err, ErrNotFound):
+			res.Missing = append(res.Missing, height)
+		case errors.Is(err, ErrCorrupted):
+			res.Checked++
+			log.Errorw("corrupted EDS found", "height", height, "hash", key, "err", err)
+			corrupted := CorruptedEDS{Height: height, DataHash: root, Err: err}
+			if quarantine {
+				if err := s.quarantine(ctx, root); err != nil {
+					return res, fmt.Errorf("eds/store: quarantining EDS at height %d: %w", height, err)
+				}
+				corrupted.Quarantined = true
+			}
+			res.Corrupted = append(res.Corrupted, corrupted)
+		default:
+			return res, fmt.Errorf("eds/store: verifying EDS at height %d: %w", height, err)
+		}
+
+		if ctx.Err() != nil {
+			return res, ctx.Err()
+		}
+	}
+	return res, nil
+}
+
+// verify checks the integrity of the CAR file stored for the given root.
+func (s *Store) verify(ctx context.Context, root share.DataHash) error {
+	f, err := s.carStorage.Get(ctx, root.String())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = verifyCAR(bufio.NewReader(f), root)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrCorrupted, err)
+	}
+	return nil
+}
+
+// verifyCAR reads the whole CARv1 file, either written by Store.Put or by WriteEDS, and ensures
+// that:
+//   - the DAH stored in the CAR header matches the given root
+//   - every leaf and inner node block matches its CID
+//   - the EDS recomputed from the ODS matches the DAH and the stored parity shares, if any
+//   - there are no redundant blocks.
+func verifyCAR(r io.Reader, root share.DataHash) error {
+	carReader, err := car.NewCarReader(r)
+	if err != nil {
+		return fmt.Errorf("reading car header: %w", err)
+	}
+
+	dah := dahFromCARHeader(carReader.Header)
+	if !bytes.Equal(dah.Hash(), root) {
+		return fmt.Errorf("car header roots don't match root %s", root)
+	}
+
+	odsWidth := len(carReader.Header.Roots) / 4
+	if odsWidth == 0 {
+		return ErrEmptySquare
+	}
+	width := odsWidth * 2
+	hasher := nmt.NewNmtHasher(sha256.New(), ipld.NamespaceSize, ipld.NMTIgnoreMaxNamespace)
+
+	// leaves are stored in quadrant order, see writeQuadrants, while CAR files written by Store.Put
+	// end after the first quadrant
+	leaves := make([][]byte, width*width)
+	for i := range leaves {
+		block, err := carReader.Next()
+		if i == odsWidth*odsWidth && errors.Is(err, io.EOF) {
+			leaves = leaves[:i]
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading leaf %d: %w", i, err)
+		}
+		hash, err := hasher.HashLeaf(block.RawData())
+		if err != nil {
+			return fmt.Errorf("hashing leaf %d: %w", i, err)
+		}
+		if !bytes.Equal(hash, ipld.NamespacedSha256FromCID(block.Cid())) {
+			return fmt.Errorf("leaf %d doesn't match its cid %s", i, block.Cid())
+		}
+		leaves[i] = block.RawData()[ipld.NamespaceSize:]
+	}
+
+	quadrantSize := odsWidth * odsWidth
+	eds, err := rsmt2d.ComputeExtendedDataSquare(
+		leaves[:quadrantSize],
+		share.DefaultRSMT2DCodec(),
+		wrapper.NewConstructor(uint64(odsWidth)),
+	)
+	if err != nil {
+		return fmt.Errorf("computing eds: %w", err)
+	}
+	recomputed := da.NewDataAvailabilityHeader(eds)
+	if !bytes.Equal(recomputed.Hash(), root) {
+		return fmt.Errorf("recomputed roots don't match root %s", root)
+	}
+	if len(leaves) == quadrantSize {
+		return nil
+	}
+	for i := 0; i < odsWidth; i++ {
+		for j := 0; j < odsWidth; j++ {
+			cells := getQuadrantCells(eds, uint(i), uint(j))
+			for quadrant := 1; quadrant < 4; quadrant++ {
+				if !bytes.Equal(cells[quadrant], leaves[quadrant*quadrantSize+i*odsWidth+j]) {
+					return fmt.Errorf("parity share (%d, %d) in quadrant %d doesn't match recomputed one",
+						i, j, quadrant)
+				}
+			}
+		}
+	}
+
+	// identical subtrees are stored only once, so the amount of inner nodes is only bounded
+	maxInnerNodes := innerNodeBatchSize(width*width, odsWidth)
+	for i := 0; ; i++ {
+		block, err := carReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading inner node %d: %w", i, err)
+		}
+		if i >= maxInnerNodes {
+			return fmt.Errorf("unexpected block %s after inner nodes", block.Cid())
+		}
+
+		node := block.RawData()
+		if len(node) != ipld.NmtHashSize*2 {
+			return fmt.Errorf("inner node %s has invalid size %d", block.Cid(), len(node))
+		}
+		hash, err := hasher.HashNode(node[:ipld.NmtHashSize], node[ipld.NmtHashSize:])
+		if err != nil {
+			return fmt.Errorf("hashing inner node %s: %w", block.Cid(), err)
+		}
+		if !bytes.Equal(hash, ipld.NamespacedSha256FromCID(block.Cid())) {
+			return fmt.Errorf("inner node doesn't match its cid %s", block.Cid())
+		}
+	}
+}
+
+// quarantine unregisters the EDS from the Store and moves its CAR file to the local quarantine
+// directory for further inspection.
+func (s *Store) quarantine(ctx context.Context, root share.DataHash) error {
+	unlock := s.lockWrite(root)
+	defer unlock()
+
+	key := root.String()
+	err := s.destroyShard(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	r, err := s.carStorage.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to read CAR file: %w", err)
+	}
+	defer r.Close()
+
+	err = newFileStorage(s.basepath+quarantinePath).Put(ctx, key, r)
+	if err != nil {
+		return fmt.Errorf("failed to move CAR file to quarantine: %w", err)
+	}
+	err = s.carStorage.Remove(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to remove CAR file: %w", err)
+	}
+	return s.forgetMigrations(ctx, key)
+}
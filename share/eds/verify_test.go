@@ -0,0 +1,173 @@
+package eds
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestStore_Verify(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	// heights 1-3 are stored, height 4 is empty, height 5 is missing
+	dahs := make(map[uint64]*share.Root)
+	for height := uint64(1); height <= 3; height++ {
+		eds, dah := randomEDS(t)
+		err = edsStore.Put(ctx, dah.Hash(), eds)
+		require.NoError(t, err)
+		dahs[height] = &dah
+	}
+	emptyEDS := share.EmptyExtendedDataSquare()
+	emptyDAH := da.NewDataAvailabilityHeader(emptyEDS)
+	err = edsStore.Put(ctx, emptyDAH.Hash(), emptyEDS)
+	require.NoError(t, err)
+	dahs[4] = &emptyDAH
+	_, missingDAH := randomEDS(t)
+	dahs[5] = &missingDAH
+
+	getter := func(_ context.Context, height uint64) (*header.ExtendedHeader, error) {
+		dah, ok := dahs[height]
+		if !ok {
+			return nil, fmt.Errorf("no header at height %d", height)
+		}
+		return &header.ExtendedHeader{DAH: dah}, nil
+	}
+
+	t.Run("Healthy", func(t *testing.T) {
+		res, err := edsStore.Verify(ctx, 1, 5, getter, false)
+		require.NoError(t, err)
+		assert.Equal(t, 4, res.Checked)
+		assert.Equal(t, []uint64{5}, res.Missing)
+		assert.Empty(t, res.Corrupted)
+	})
+
+	t.Run("InvalidRange", func(t *testing.T) {
+		_, err := edsStore.Verify(ctx, 3, 1, getter, false)
+		require.Error(t, err)
+	})
+
+	t.Run("Corrupted", func(t *testing.T) {
+		root := share.DataHash(dahs[2].Hash())
+		path := edsStore.basepath + blocksPath + root.String()
+		corruptLastByte(t, path)
+
+		res, err := edsStore.Verify(ctx, 1, 3, getter, false)
+		require.NoError(t, err)
+		require.Len(t, res.Corrupted, 1)
+		assert.EqualValues(t, 2, res.Corrupted[0].Height)
+		assert.ErrorIs(t, res.Corrupted[0].Err, ErrCorrupted)
+		assert.False(t, res.Corrupted[0].Quarantined)
+
+		res, err = edsStore.Verify(ctx, 2, 2, getter, true)
+		require.NoError(t, err)
+		require.Len(t, res.Corrupted, 1)
+		assert.True(t, res.Corrupted[0].Quarantined)
+
+		has, err := edsStore.Has(ctx, root)
+		require.NoError(t, err)
+		assert.False(t, has)
+		_, err = os.Stat(path)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+		_, err = os.Stat(edsStore.basepath + quarantinePath + root.String())
+		assert.NoError(t, err)
+
+		// once quarantined, the EDS is reported as missing
+		res, err = edsStore.Verify(ctx, 2, 2, getter, true)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{2}, res.Missing)
+	})
+}
+
+func TestVerifyCAR_CorruptedLeaf(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	edsStore, err := newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	eds, dah := randomEDS(t)
+	err = edsStore.Put(ctx, dah.Hash(), eds)
+	require.NoError(t, err)
+
+	root := share.DataHash(dah.Hash())
+	path := edsStore.basepath + blocksPath + root.String()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	// flip a byte of the very last share of the ODS, which is the last block of the CAR file
+	odsWidth := eds.Width() / 2
+	lastShare := eds.GetCell(odsWidth-1, odsWidth-1)
+	idx := bytes.Index(data, lastShare)
+	require.Positive(t, idx)
+	data[idx] ^= 0xFF
+	err = os.WriteFile(path, data, 0600)
+	require.NoError(t, err)
+
+	err = edsStore.verify(ctx, root)
+	assert.ErrorIs(t, err, ErrCorrupted)
+}
+
+func TestVerifyCAR_FullLayout(t *testing.T) {
+	eds, dah := randomEDS(t)
+	buf := new(bytes.Buffer)
+	err := WriteEDS(context.Background(), eds, buf)
+	require.NoError(t, err)
+
+	err = verifyCAR(buf, dah.Hash())
+	assert.NoError(t, err)
+}
+
+func corruptLastByte(t *testing.T, path string) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	data[len(data)-1] ^= 0xFF
+	err = os.WriteFile(path, data, 0600)
+	require.NoError(t, err)
+}
+
+func TestValidateAgainstHeader(t *testing.T) {
+	eds := share.RandEDS(t, 4)
+	dah := da.NewDataAvailabilityHeader(eds)
+	h := &header.ExtendedHeader{
+		RawHeader: core.Header{
+			Height:   5,
+			DataHash: dah.Hash(),
+		},
+		DAH: &dah,
+	}
+	require.NoError(t, ValidateAgainstHeader(eds, h))
+
+	// the square of another block differs starting from the first row
+	err := ValidateAgainstHeader(share.RandEDS(t, 4), h)
+	var errMismatch *share.ErrRootMismatch
+	require.ErrorAs(t, err, &errMismatch)
+	require.Equal(t, &share.ErrRootMismatch{Height: 5, Axis: rsmt2d.Row, Index: 0}, errMismatch)
+
+	// a DAH with a differing column is not committed to by the header
+	colRoots := make([][]byte, len(dah.ColumnRoots))
+	copy(colRoots, dah.ColumnRoots)
+	colRoots[2] = dah.RowRoots[2]
+	other := &da.DataAvailabilityHeader{RowRoots: dah.RowRoots, ColumnRoots: colRoots}
+	require.Error(t, ValidateAgainstHeader(eds, &header.ExtendedHeader{RawHeader: h.RawHeader, DAH: other}))
+}
@@ -2,10 +2,13 @@ package share
 
 import (
 	"context"
+	"encoding/hex"
 
 	"github.com/ipfs/go-blockservice"
 	"github.com/ipfs/go-cid"
 	format "github.com/ipfs/go-ipld-format"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/celestiaorg/nmt"
 	"github.com/celestiaorg/nmt/namespace"
@@ -52,7 +55,14 @@ func GetSharesByNamespace(
 	nID namespace.ID,
 	maxShares int,
 ) ([]Share, *nmt.Proof, error) {
-	ctx, span := tracer.Start(ctx, "get-shares-by-namespace")
+	attrs := []attribute.KeyValue{
+		attribute.String(NamespaceAttribute, hex.EncodeToString(nID)),
+		attribute.Stringer(RowRootAttribute, root),
+	}
+	if height, ok := HeightFromContext(ctx); ok {
+		attrs = append(attrs, attribute.Int64(HeightAttribute, int64(height)))
+	}
+	ctx, span := tracer.Start(ctx, "get-shares-by-namespace", trace.WithAttributes(attrs...))
 	defer span.End()
 
 	data := ipld.NewNamespaceData(maxShares, nID, ipld.WithLeaves(), ipld.WithProofs())
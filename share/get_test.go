@@ -8,15 +8,18 @@ import (
 	mrand "math/rand"
 	"sort"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
+	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-blockservice"
 	"github.com/ipfs/go-cid"
 	ds "github.com/ipfs/go-datastore"
 	dssync "github.com/ipfs/go-datastore/sync"
 	blockstore "github.com/ipfs/go-ipfs-blockstore"
 	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	format "github.com/ipfs/go-ipld-format"
 	mdutils "github.com/ipfs/go-merkledag/test"
 	"github.com/minio/sha256-simd"
 	"github.com/stretchr/testify/assert"
@@ -316,6 +319,63 @@ func TestCollectLeavesByNamespace_MultipleRowsContainingSameNamespaceId(t *testi
 	}
 }
 
+func TestVisitor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	bServ := mdutils.Bserv()
+
+	shares := RandShares(t, 16)
+	eds, err := AddShares(ctx, shares, bServ)
+	require.NoError(t, err)
+	rcid := ipld.MustCidFromNamespacedSha256(eds.RowRoots()[0])
+	width := int(eds.Width())
+
+	// the whole tree is visited
+	v := &countingVisitor{}
+	ipld.VisitLeaves(ctx, bServ, rcid, width, v)
+	assert.Equal(t, width-1, v.inner)
+	assert.Len(t, v.leaves, width)
+	assert.Empty(t, v.proofs)
+
+	// only the namespace path is visited, along with its proof
+	nid := shares[1][:NamespaceSize]
+	v = &countingVisitor{}
+	data := ipld.NewNamespaceData(width, nid, ipld.WithProofs(), ipld.WithVisitor(v))
+	require.NoError(t, data.CollectLeavesByNamespace(ctx, bServ, rcid))
+	assert.Less(t, v.inner, width-1)
+	require.Len(t, v.leaves, 1)
+	assert.Equal(t, shares[1], v.leaves[1].RawData()[NamespaceSize:])
+	assert.ElementsMatch(t, data.Proof().Nodes(), v.proofs)
+}
+
+type countingVisitor struct {
+	lk     sync.Mutex
+	inner  int
+	leaves map[int]format.Node
+	proofs [][]byte
+}
+
+func (v *countingVisitor) OnInnerNode(int, int, format.Node) {
+	v.lk.Lock()
+	defer v.lk.Unlock()
+	v.inner++
+}
+
+func (v *countingVisitor) OnLeaf(pos int, nd format.Node) {
+	v.lk.Lock()
+	defer v.lk.Unlock()
+	if v.leaves == nil {
+		v.leaves = make(map[int]format.Node)
+	}
+	v.leaves[pos] = nd
+}
+
+func (v *countingVisitor) OnProofNode(_, _ int, hash []byte) {
+	v.lk.Lock()
+	defer v.lk.Unlock()
+	v.proofs = append(v.proofs, hash)
+}
+
 func TestGetSharesWithProofsByNamespace(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	t.Cleanup(cancel)
@@ -399,6 +459,96 @@ func TestGetSharesWithProofsByNamespace(t *testing.T) {
 	}
 }
 
+func FuzzGetSharesByNamespace(f *testing.F) {
+	f.Add(int64(1), uint8(1), uint8(0), false)
+	f.Add(int64(2), uint8(2), uint8(5), true)
+	f.Add(int64(3), uint8(3), uint8(42), false)
+	f.Add(int64(4), uint8(3), uint8(7), true)
+
+	f.Fuzz(func(t *testing.T, seed int64, width, pick uint8, absent bool) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		bServ := mdutils.Bserv()
+		rand := mrand.New(mrand.NewSource(seed))
+
+		// generate shares from a small pool of namespaces, so that namespaces span several shares
+		odsWidth := 1 << (width % 4)
+		shares := make([]Share, odsWidth*odsWidth)
+		nIDs := make([]namespace.ID, 1+rand.Intn(len(shares)))
+		for i := range nIDs {
+			nIDs[i] = make([]byte, NamespaceSize)
+			rand.Read(nIDs[i])
+		}
+		for i := range shares {
+			shares[i] = make([]byte, Size)
+			copy(shares[i], nIDs[rand.Intn(len(nIDs))])
+			rand.Read(shares[i][NamespaceSize:])
+		}
+		sort.Slice(shares, func(i, j int) bool { return bytes.Compare(shares[i], shares[j]) < 0 })
+
+		nID := namespace.ID(shares[int(pick)%len(shares)][:NamespaceSize])
+		if absent {
+			nID = make([]byte, NamespaceSize)
+			rand.Read(nID)
+		}
+
+		eds, err := AddShares(ctx, shares, bServ)
+		require.NoError(t, err)
+
+		for i, row := range eds.RowRoots() {
+			rcid := ipld.MustCidFromNamespacedSha256(row)
+			rowShares, proof, err := GetSharesByNamespace(ctx, bServ, rcid, nID, len(eds.RowRoots()))
+			if ipld.NamespaceIsOutsideRange(row, row, nID) {
+				require.ErrorIs(t, err, ipld.ErrNamespaceOutsideRange)
+				continue
+			}
+			require.NoError(t, err)
+			require.NotNil(t, proof)
+
+			var expected []Share
+			for _, sh := range eds.Row(uint(i))[:odsWidth] {
+				if bytes.HasPrefix(sh, nID) {
+					expected = append(expected, sh)
+				}
+			}
+			if len(expected) == 0 {
+				// absence proof
+				require.True(t, proof.VerifyNamespace(sha256.New(), nID, nil, row))
+				continue
+			}
+
+			require.Equal(t, expected, rowShares)
+			require.True(t, proof.VerifyInclusion(sha256.New(), nID, rowShares, row))
+		}
+	})
+}
+
+func BenchmarkGetSharesByNamespace(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	b.Cleanup(cancel)
+	bServ := mdutils.Bserv()
+
+	shares := RandShares(b, 64*64)
+	nID := namespace.ID(shares[len(shares)/2][:NamespaceSize])
+	eds, err := AddShares(ctx, shares, bServ)
+	require.NoError(b, err)
+
+	var rcid cid.Cid
+	for _, row := range eds.RowRoots() {
+		if !ipld.NamespaceIsOutsideRange(row, row, nID) {
+			rcid = ipld.MustCidFromNamespacedSha256(row)
+			break
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, err := GetSharesByNamespace(ctx, bServ, rcid, nID, len(eds.RowRoots()))
+		require.NoError(b, err)
+	}
+}
+
 func TestBatchSize(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -495,3 +645,44 @@ func randomNids(total int) ([]namespace.ID, error) {
 	sort.Slice(namespaces, func(i, j int) bool { return bytes.Compare(namespaces[i], namespaces[j]) < 0 })
 	return namespaces, nil
 }
+
+// TestCollectProofsWithoutLeaves tests that leaves are not retrieved when only proofs are collected.
+func TestCollectProofsWithoutLeaves(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	bServ := mdutils.Bserv()
+
+	shares := RandShares(t, 16)
+	sort.Slice(shares, func(i, j int) bool { return bytes.Compare(shares[i], shares[j]) < 0 })
+	eds, err := AddShares(ctx, shares, bServ)
+	require.NoError(t, err)
+	rcid := ipld.MustCidFromNamespacedSha256(eds.RowRoots()[0])
+	width := int(eds.Width())
+
+	present := shares[1][:NamespaceSize]
+	absent := append(present[:len(present)-1:len(present)-1], present[len(present)-1]+1)
+	for _, nid := range []namespace.ID{present, absent} {
+		expected := ipld.NewNamespaceData(width, nid, ipld.WithLeaves(), ipld.WithProofs())
+		require.NoError(t, expected.CollectLeavesByNamespace(ctx, bServ, rcid))
+
+		data := ipld.NewNamespaceData(width, nid, ipld.WithProofs())
+		require.NoError(t, data.CollectLeavesByNamespace(ctx, innerNodesGetter{bServ}, rcid))
+		assert.Equal(t, expected.Proof(), data.Proof())
+	}
+}
+
+// innerNodesGetter fails to get leaves.
+type innerNodesGetter struct {
+	blockservice.BlockGetter
+}
+
+func (g innerNodesGetter) GetBlock(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	b, err := g.BlockGetter.GetBlock(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.RawData()) != 2*ipld.NmtHashSize {
+		return nil, errors.New("leaf retrieved")
+	}
+	return b, nil
+}
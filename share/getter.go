@@ -2,16 +2,15 @@ package share
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
-	"github.com/minio/sha256-simd"
-
 	"github.com/celestiaorg/nmt"
 	"github.com/celestiaorg/nmt/namespace"
 	"github.com/celestiaorg/rsmt2d"
 
-	"github.com/celestiaorg/celestia-node/share/ipld"
+	"github.com/celestiaorg/celestia-node/libs/encoding"
 )
 
 var (
@@ -41,13 +40,64 @@ type Getter interface {
 // NamespacedShares represents all shares with proofs within a specific namespace of an EDS.
 type NamespacedShares []NamespacedRow
 
-// Flatten returns the concatenated slice of all NamespacedRow shares.
-func (ns NamespacedShares) Flatten() []Share {
+// Index is the position of a share within NamespacedShares.
+type Index struct {
+	// Row is the index of the NamespacedRow the share belongs to.
+	Row int
+	// Col is the index of the share within its row of the EDS. If the row has no proof, it is
+	// relative to the first share of the row.
+	Col int
+}
+
+// Flatten returns the concatenated slice of all NamespacedRow shares together with their indices,
+// so that the rows can be rebuilt with FromRows.
+func (ns NamespacedShares) Flatten() ([]Share, []Index) {
 	shares := make([]Share, 0)
-	for _, row := range ns {
-		shares = append(shares, row.Shares...)
+	idxs := make([]Index, 0)
+	for i, row := range ns {
+		start := 0
+		if row.Proof != nil {
+			start = row.Proof.Start()
+		}
+		for j, sh := range row.Shares {
+			shares = append(shares, sh)
+			idxs = append(idxs, Index{Row: i, Col: start + j})
+		}
+	}
+	return shares, idxs
+}
+
+// FromRows rebuilds NamespacedShares from the flat shares and their indices returned by Flatten,
+// attaching to every row its proof. Rows without any shares, e.g. proving absence of the
+// namespace, are rebuilt from their proofs.
+func FromRows(shares []Share, idxs []Index, proofs []*nmt.Proof) (NamespacedShares, error) {
+	if len(shares) != len(idxs) {
+		return nil, fmt.Errorf("share: amount of shares and indices differs: %d != %d", len(shares), len(idxs))
+	}
+
+	ns := make(NamespacedShares, len(proofs))
+	for i, proof := range proofs {
+		ns[i].Shares, ns[i].Proof = make([]Share, 0), proof
+	}
+	for i, idx := range idxs {
+		if idx.Row < 0 || idx.Row >= len(ns) {
+			return nil, fmt.Errorf("share: row %d of share %d is out of %d rows", idx.Row, i, len(ns))
+		}
+		if i > 0 && idx.Row < idxs[i-1].Row {
+			return nil, fmt.Errorf("share: shares are not ordered by rows at share %d", i)
+		}
+
+		row := &ns[idx.Row]
+		start := 0
+		if row.Proof != nil {
+			start = row.Proof.Start()
+		}
+		if idx.Col != start+len(row.Shares) {
+			return nil, fmt.Errorf("share: share %d at column %d breaks the range of row %d", i, idx.Col, idx.Row)
+		}
+		row.Shares = append(row.Shares, shares[i])
 	}
-	return shares
+	return ns, nil
 }
 
 // NamespacedRow represents all shares with proofs within a specific namespace of a single EDS row.
@@ -56,41 +106,36 @@ type NamespacedRow struct {
 	Proof  *nmt.Proof
 }
 
-// Verify validates NamespacedShares by checking every row with nmt inclusion proof.
-func (ns NamespacedShares) Verify(root *Root, nID namespace.ID) error {
-	originalRoots := make([][]byte, 0)
-	for _, row := range root.RowRoots {
-		if !ipld.NamespaceIsOutsideRange(row, row, nID) {
-			originalRoots = append(originalRoots, row)
-		}
+type jsonNamespacedRow struct {
+	Shares []encoding.Base64
+	Proof  *nmt.Proof
+}
+
+func (row NamespacedRow) MarshalJSON() ([]byte, error) {
+	shares := make([]encoding.Base64, len(row.Shares))
+	for i, sh := range row.Shares {
+		shares[i] = sh
 	}
+	return json.Marshal(jsonNamespacedRow{Shares: shares, Proof: row.Proof})
+}
 
-	if len(originalRoots) != len(ns) {
-		return fmt.Errorf("amount of rows differs between root and namespace shares: expected %d, got %d",
-			len(originalRoots), len(ns))
+func (row *NamespacedRow) UnmarshalJSON(data []byte) error {
+	var jsonRow jsonNamespacedRow
+	err := json.Unmarshal(data, &jsonRow)
+	if err != nil {
+		return err
 	}
 
-	for i, row := range ns {
-		// verify row data against row hash from original root
-		if !row.verify(originalRoots[i], nID) {
-			return fmt.Errorf("row verification failed: row %d doesn't match original root: %s", i, root.String())
-		}
+	row.Shares = make([]Share, len(jsonRow.Shares))
+	for i, sh := range jsonRow.Shares {
+		row.Shares[i] = sh
 	}
+	row.Proof = jsonRow.Proof
 	return nil
 }
 
-// verify validates the row using nmt inclusion proof.
-func (row *NamespacedRow) verify(rowRoot []byte, nID namespace.ID) bool {
-	// construct nmt leaves from shares by prepending namespace
-	leaves := make([][]byte, 0, len(row.Shares))
-	for _, sh := range row.Shares {
-		leaves = append(leaves, append(sh[:NamespaceSize], sh...))
-	}
-
-	// verify namespace
-	return row.Proof.VerifyNamespace(
-		sha256.New(),
-		nID,
-		leaves,
-		rowRoot)
+// Verify validates NamespacedShares by checking every row with nmt inclusion proof.
+// See VerifyNamespacedShares.
+func (ns NamespacedShares) Verify(root *Root, nID namespace.ID) error {
+	return VerifyNamespacedShares(root, nID, ns)
 }
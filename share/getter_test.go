@@ -0,0 +1,48 @@
+package share
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/nmt"
+)
+
+func TestNamespacedShares_FlattenFromRows(t *testing.T) {
+	proof := func(start, end int) *nmt.Proof {
+		p := nmt.NewInclusionProof(start, end, nil, true)
+		return &p
+	}
+	ns := NamespacedShares{
+		{Shares: []Share{{1}, {2}}, Proof: proof(2, 4)},
+		{Shares: []Share{}, Proof: proof(0, 0)},
+		{Shares: []Share{{3}, {4}, {5}}, Proof: proof(0, 3)},
+	}
+
+	shares, idxs := ns.Flatten()
+	assert.Equal(t, []Share{{1}, {2}, {3}, {4}, {5}}, shares)
+	assert.Equal(t, []Index{{0, 2}, {0, 3}, {2, 0}, {2, 1}, {2, 2}}, idxs)
+
+	proofs := make([]*nmt.Proof, len(ns))
+	for i, row := range ns {
+		proofs[i] = row.Proof
+	}
+	rebuilt, err := FromRows(shares, idxs, proofs)
+	require.NoError(t, err)
+	for i := range ns {
+		assert.Equal(t, ns[i].Proof, rebuilt[i].Proof)
+		// shares are rebuilt in their order within the rows
+		assert.Equal(t, ns[i].Shares, rebuilt[i].Shares)
+	}
+
+	// indices must match the rows
+	_, err = FromRows(shares, idxs[:4], proofs)
+	require.Error(t, err)
+	_, err = FromRows(shares, idxs, proofs[:2])
+	require.Error(t, err)
+	gap := append([]Index{}, idxs...)
+	gap[1].Col++
+	_, err = FromRows(shares, gap, proofs)
+	require.Error(t, err)
+}
@@ -0,0 +1,60 @@
+package getters
+
+import (
+	"context"
+
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/libs/budget"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var _ share.Getter = (*BudgetedGetter)(nil)
+
+// BudgetedGetter wraps a getter, so that retrievals of user requests take their tokens from the
+// retrieval budget shared with sampling, as interactive ones.
+type BudgetedGetter struct {
+	share.Getter
+
+	budget *budget.Budget
+}
+
+// NewBudgetedGetter creates a new BudgetedGetter taking tokens from the given Budget.
+func NewBudgetedGetter(getter share.Getter, budget *budget.Budget) *BudgetedGetter {
+	return &BudgetedGetter{
+		Getter: getter,
+		budget: budget,
+	}
+}
+
+func (bg *BudgetedGetter) GetShare(ctx context.Context, root *share.Root, row, col int) (share.Share, error) {
+	release, err := bg.budget.Acquire(ctx, budget.Interactive)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return bg.Getter.GetShare(ctx, root, row, col)
+}
+
+func (bg *BudgetedGetter) GetEDS(ctx context.Context, root *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
+	release, err := bg.budget.Acquire(ctx, budget.Interactive)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return bg.Getter.GetEDS(ctx, root)
+}
+
+func (bg *BudgetedGetter) GetSharesByNamespace(
+	ctx context.Context,
+	root *share.Root,
+	id namespace.ID,
+) (share.NamespacedShares, error) {
+	release, err := bg.budget.Acquire(ctx, budget.Interactive)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return bg.Getter.GetSharesByNamespace(ctx, root, id)
+}
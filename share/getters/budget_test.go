@@ -0,0 +1,37 @@
+package getters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/libs/budget"
+)
+
+func TestBudgetedGetter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	getter, root := TestGetter(t)
+	b := budget.New(1)
+	bg := NewBudgetedGetter(getter, b)
+
+	eds, err := bg.GetEDS(ctx, root)
+	require.NoError(t, err)
+
+	// retrievals wait for a token while the budget is used up
+	release, err := b.Acquire(ctx, budget.Background)
+	require.NoError(t, err)
+	waitCtx, waitCancel := context.WithTimeout(ctx, time.Millisecond*50)
+	defer waitCancel()
+	_, err = bg.GetShare(waitCtx, root, 0, 0)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	sh, err := bg.GetShare(ctx, root, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, eds.GetCell(0, 0), sh)
+}
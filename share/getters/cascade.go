@@ -2,8 +2,8 @@ package getters
 
 import (
 	"context"
-	"encoding/hex"
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -20,9 +20,12 @@ var _ share.Getter = (*CascadeGetter)(nil)
 // CascadeGetter implements custom share.Getter that composes multiple Getter implementations in
 // "cascading" order.
 //
-// See cascade func for details on cascading.
+// See cascade func for details on cascading. Attempts of getters are recorded into the trace of the
+// request context, if any. See WithCascadeTrace.
 type CascadeGetter struct {
 	getters []share.Getter
+
+	metrics *cascadeMetrics
 }
 
 // NewCascadeGetter instantiates a new CascadeGetter from given share.Getters with given interval.
@@ -35,9 +38,10 @@ func NewCascadeGetter(getters []share.Getter) *CascadeGetter {
 // GetShare gets a share from any of registered share.Getters in cascading order.
 func (cg *CascadeGetter) GetShare(ctx context.Context, root *share.Root, row, col int) (share.Share, error) {
 	ctx, span := tracer.Start(ctx, "cascade/get-share", trace.WithAttributes(
-		attribute.String("root", root.String()),
-		attribute.Int("row", row),
-		attribute.Int("col", col),
+		share.TraceAttributes(ctx, root.Hash(), nil,
+			attribute.Int("row", row),
+			attribute.Int("col", col),
+		)...,
 	))
 	defer span.End()
 
@@ -45,13 +49,15 @@ func (cg *CascadeGetter) GetShare(ctx context.Context, root *share.Root, row, co
 		return get.GetShare(ctx, root, row, col)
 	}
 
-	return cascadeGetters(ctx, cg.getters, get)
+	shr, err := cascadeGetters(ctx, cg.getters, observe(cg.metrics, methodGetShare, get))
+	cg.observeServedByNone(ctx, methodGetShare, err)
+	return shr, err
 }
 
 // GetEDS gets a full EDS from any of registered share.Getters in cascading order.
 func (cg *CascadeGetter) GetEDS(ctx context.Context, root *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
 	ctx, span := tracer.Start(ctx, "cascade/get-eds", trace.WithAttributes(
-		attribute.String("root", root.String()),
+		share.TraceAttributes(ctx, root.Hash(), nil)...,
 	))
 	defer span.End()
 
@@ -59,7 +65,9 @@ func (cg *CascadeGetter) GetEDS(ctx context.Context, root *share.Root) (*rsmt2d.
 		return get.GetEDS(ctx, root)
 	}
 
-	return cascadeGetters(ctx, cg.getters, get)
+	eds, err := cascadeGetters(ctx, cg.getters, observe(cg.metrics, methodGetEDS, get))
+	cg.observeServedByNone(ctx, methodGetEDS, err)
+	return eds, err
 }
 
 // GetSharesByNamespace gets NamespacedShares from any of registered share.Getters in cascading
@@ -70,8 +78,7 @@ func (cg *CascadeGetter) GetSharesByNamespace(
 	id namespace.ID,
 ) (share.NamespacedShares, error) {
 	ctx, span := tracer.Start(ctx, "cascade/get-shares-by-namespace", trace.WithAttributes(
-		attribute.String("root", root.String()),
-		attribute.String("nid", hex.EncodeToString(id)),
+		share.TraceAttributes(ctx, root.Hash(), id)...,
 	))
 	defer span.End()
 
@@ -79,7 +86,16 @@ func (cg *CascadeGetter) GetSharesByNamespace(
 		return get.GetSharesByNamespace(ctx, root, id)
 	}
 
-	return cascadeGetters(ctx, cg.getters, get)
+	shares, err := cascadeGetters(ctx, cg.getters, observe(cg.metrics, methodGetSharesByNamespace, get))
+	cg.observeServedByNone(ctx, methodGetSharesByNamespace, err)
+	return shares, err
+}
+
+// observeServedByNone records the request as served by none of the getters, if all of them failed.
+func (cg *CascadeGetter) observeServedByNone(ctx context.Context, method string, err error) {
+	if err != nil && !errors.Is(err, share.ErrNamespaceNotFound) {
+		cg.metrics.observeServed(ctx, servedByNone, method)
+	}
 }
 
 // cascade implements a cascading retry algorithm for getting a value from multiple sources.
@@ -113,6 +129,9 @@ func cascadeGetters[V any](
 		}
 	}()
 
+	cascadeTrace := cascadeTraceFrom(ctx)
+	// collects the peers attempted by all the getters
+	retrievalErr := newRetrievalError()
 	for i, getter := range getters {
 		log.Debugf("cascade: launching getter #%d", i)
 		span.AddEvent("getter launched", trace.WithAttributes(attribute.Int("getter_idx", i)))
@@ -120,7 +139,9 @@ func cascadeGetters[V any](
 		// we split the timeout between left getters
 		// once async cascadegetter is implemented, we can remove this
 		getCtx, cancel := ctxWithSplitTimeout(ctx, len(getters)-i, 0)
+		start := time.Now()
 		val, getErr := get(getCtx, getter)
+		cascadeTrace.record(getter, time.Since(start), getErr)
 		cancel()
 		if getErr == nil || errors.Is(getErr, share.ErrNamespaceNotFound) {
 			return val, getErr
@@ -130,11 +151,14 @@ func cascadeGetters[V any](
 			continue
 		}
 
-		err = errors.Join(err, getErr)
+		err = errors.Join(err, retrievalErr.merge(getErr))
 		span.RecordError(getErr, trace.WithAttributes(attribute.Int("getter_idx", i)))
 		if ctx.Err() != nil {
-			return zero, err
+			return zero, retrievalErr.withErr(err)
 		}
 	}
-	return zero, err
+	if err == nil {
+		return zero, nil
+	}
+	return zero, retrievalErr.withErr(err)
 }
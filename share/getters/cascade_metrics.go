@@ -0,0 +1,160 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+const (
+	getterLabel = "getter"
+	methodLabel = "method"
+	resultLabel = "result"
+
+	methodGetShare             = "get_share"
+	methodGetEDS               = "get_eds"
+	methodGetSharesByNamespace = "get_shares_by_namespace"
+
+	resultSuccess = "success"
+	// servedByNone labels requests no getter of the cascade served
+	servedByNone = "none"
+)
+
+type cascadeMetrics struct {
+	attemptTime syncfloat64.Histogram // attributes: getter, method, result
+	attempts    syncint64.Counter     // attributes: getter, method, result
+	served      syncint64.Counter     // attributes: getter, method
+}
+
+// WithMetrics turns on metrics of the getters of the cascade: the latency and the result of every
+// attempt of a getter, and the getter that served each request.
+func (cg *CascadeGetter) WithMetrics() error {
+	return cg.withMetrics(gettersMeter)
+}
+
+func (cg *CascadeGetter) withMetrics(meter metric.Meter) error {
+	attemptTime, err := meter.SyncFloat64().Histogram(
+		"getters_cascade_attempt_time_hist",
+		instrument.WithUnit(unit.Unit("s")),
+		instrument.WithDescription("Duration of attempts of getters of the cascade in seconds"),
+	)
+	if err != nil {
+		return err
+	}
+
+	attempts, err := meter.SyncInt64().Counter(
+		"getters_cascade_attempts",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("Number of attempts of getters of the cascade"),
+	)
+	if err != nil {
+		return err
+	}
+
+	served, err := meter.SyncInt64().Counter(
+		"getters_cascade_served",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("Number of requests served by getters of the cascade"),
+	)
+	if err != nil {
+		return err
+	}
+
+	cg.metrics = &cascadeMetrics{
+		attemptTime: attemptTime,
+		attempts:    attempts,
+		served:      served,
+	}
+	return nil
+}
+
+// observe wraps the get func of a cascade to record the attempts of getters and the getter that
+// served the request into the metrics.
+func observe[V any](
+	m *cascadeMetrics,
+	method string,
+	get func(context.Context, share.Getter) (V, error),
+) func(context.Context, share.Getter) (V, error) {
+	if m == nil {
+		return get
+	}
+	return func(ctx context.Context, getter share.Getter) (V, error) {
+		start := time.Now()
+		val, err := get(ctx, getter)
+		m.observeAttempt(ctx, getterName(getter), method, time.Since(start), err)
+		return val, err
+	}
+}
+
+func (m *cascadeMetrics) observeAttempt(
+	ctx context.Context,
+	getter, method string,
+	dur time.Duration,
+	err error,
+) {
+	// getters that don't support the method don't make attempts
+	if errors.Is(err, errOperationNotSupported) {
+		return
+	}
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+
+	result := resultSuccess
+	// the namespace not being found is a valid response, ending the cascade
+	if err != nil && !errors.Is(err, share.ErrNamespaceNotFound) {
+		result = string(classifyError(err))
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String(getterLabel, getter),
+		attribute.String(methodLabel, method),
+		attribute.String(resultLabel, result),
+	}
+	m.attemptTime.Record(ctx, dur.Seconds(), attrs...)
+	m.attempts.Add(ctx, 1, attrs...)
+	if result == resultSuccess {
+		m.observeServed(ctx, getter, method)
+	}
+}
+
+// observeServed records the getter that served a request, or servedByNone.
+func (m *cascadeMetrics) observeServed(ctx context.Context, getter, method string) {
+	if m == nil {
+		return
+	}
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+	m.served.Add(ctx, 1,
+		attribute.String(getterLabel, getter),
+		attribute.String(methodLabel, method),
+	)
+}
+
+// getterName returns the metric label of the getter. Getters storing retrieved data, i.e.
+// TeeGetters, are named after the getters they wrap.
+func getterName(getter share.Getter) string {
+	switch g := getter.(type) {
+	case *StoreGetter:
+		return "store"
+	case *ShrexGetter:
+		return "shrex"
+	case *IPLDGetter:
+		return "ipld"
+	case *TeeGetter:
+		return getterName(g.getter)
+	default:
+		return strings.TrimPrefix(fmt.Sprintf("%T", getter), "*")
+	}
+}
@@ -8,6 +8,11 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/unit"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	"github.com/celestiaorg/rsmt2d"
 
@@ -115,4 +120,88 @@ func TestCascade(t *testing.T) {
 		_, err := cascadeGetters(ctx, getters, get)
 		assert.NoError(t, err)
 	})
+
+	t.Run("Trace", func(t *testing.T) {
+		ctx, trace := WithCascadeTrace(ctx)
+		getters := []share.Getter{immediateFailGetter, timeoutGetter, successGetter, immediateFailGetter}
+		_, err := cascadeGetters(ctx, getters, get)
+		assert.NoError(t, err)
+
+		require.Len(t, trace.Attempts, 3)
+		assert.Equal(t, "mocks.MockGetter", trace.Attempts[0].Getter)
+		assert.Equal(t, "second getter fails immediately", trace.Attempts[0].Error)
+		assert.Equal(t, context.DeadlineExceeded.Error(), trace.Attempts[1].Error)
+		assert.Empty(t, trace.Attempts[2].Error)
+	})
+}
+
+func TestCascadeGetter_Metrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	failGetter := mocks.NewMockGetter(ctrl)
+	failGetter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).Return(nil, share.ErrNotFound).Times(2)
+	successGetter := mocks.NewMockGetter(ctrl)
+	successGetter.EXPECT().GetEDS(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")
+
+	getter := NewCascadeGetter([]share.Getter{failGetter, successGetter})
+	err := getter.withMetrics(meter)
+	require.NoError(t, err)
+
+	_, err = getter.GetEDS(ctx, &share.Root{})
+	assert.NoError(t, err)
+
+	getter = NewCascadeGetter([]share.Getter{failGetter})
+	err = getter.withMetrics(meter)
+	require.NoError(t, err)
+	_, err = getter.GetEDS(ctx, &share.Root{})
+	assert.ErrorIs(t, err, share.ErrNotFound)
+
+	data, err := reader.Collect(ctx)
+	require.NoError(t, err)
+	require.Len(t, data.ScopeMetrics, 1)
+	metrics := make(map[string]metricdata.Metrics)
+	for _, m := range data.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+	// sums returns the values of the data points of the counter by their attributes
+	sums := func(name string) map[string]int64 {
+		sum, ok := metrics[name].Data.(metricdata.Sum[int64])
+		require.True(t, ok, name)
+		values := make(map[string]int64)
+		for _, dp := range sum.DataPoints {
+			values[dp.Attributes.Encoded(attribute.DefaultEncoder())] = dp.Value
+		}
+		return values
+	}
+
+	assert.Equal(t, map[string]int64{
+		"getter=mocks.MockGetter,method=get_eds,result=not_found": 2,
+		"getter=mocks.MockGetter,method=get_eds,result=success":   1,
+	}, sums("getters_cascade_attempts"))
+	assert.Equal(t, map[string]int64{
+		"getter=mocks.MockGetter,method=get_eds": 1,
+		"getter=none,method=get_eds":             1,
+	}, sums("getters_cascade_served"))
+
+	attemptTime := metrics["getters_cascade_attempt_time_hist"]
+	assert.Equal(t, unit.Unit("s"), attemptTime.Unit)
+	hist, ok := attemptTime.Data.(metricdata.Histogram)
+	require.True(t, ok)
+	var attempts uint64
+	for _, dp := range hist.DataPoints {
+		attempts += dp.Count
+	}
+	assert.EqualValues(t, 3, attempts)
+}
+
+func Test_getterName(t *testing.T) {
+	assert.Equal(t, "store", getterName(&StoreGetter{}))
+	assert.Equal(t, "shrex", getterName(NewTeeGetter(&ShrexGetter{}, nil)))
+	assert.Equal(t, "ipld", getterName(NewTeeGetter(&IPLDGetter{}, nil)))
+	assert.Equal(t, "mocks.MockGetter", getterName(mocks.NewMockGetter(gomock.NewController(t))))
 }
@@ -0,0 +1,77 @@
+package getters
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+type cascadeTraceKey struct{}
+
+// CascadeTrace records the decision path of a CascadeGetter for a single request: which getters
+// were tried, in what order, how long each attempt took and why it failed, if it did.
+type CascadeTrace struct {
+	lk sync.Mutex
+	// Attempts are the attempts of getters in the order they were made.
+	Attempts []CascadeAttempt
+}
+
+// CascadeAttempt is a single attempt of a getter within a cascade.
+type CascadeAttempt struct {
+	// Getter is the type name of the getter, e.g. getters.StoreGetter.
+	Getter   string
+	Duration time.Duration
+	// Error is empty if the getter succeeded.
+	Error string `json:",omitempty"`
+}
+
+// WithCascadeTrace returns a context making CascadeGetters record their attempts into the returned
+// trace.
+func WithCascadeTrace(ctx context.Context) (context.Context, *CascadeTrace) {
+	trace := &CascadeTrace{}
+	return context.WithValue(ctx, cascadeTraceKey{}, trace), trace
+}
+
+// cascadeTraceFrom returns the trace attached to the context, if any.
+func cascadeTraceFrom(ctx context.Context) *CascadeTrace {
+	trace, _ := ctx.Value(cascadeTraceKey{}).(*CascadeTrace)
+	return trace
+}
+
+func (t *CascadeTrace) record(getter share.Getter, dur time.Duration, err error) {
+	if t == nil {
+		return
+	}
+
+	attempt := CascadeAttempt{
+		Getter:   strings.TrimPrefix(fmt.Sprintf("%T", getter), "*"),
+		Duration: dur,
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	t.Attempts = append(t.Attempts, attempt)
+}
+
+// String describes the attempts of the trace in order, e.g. for errors returned over the RPC.
+func (t *CascadeTrace) String() string {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	attempts := make([]string, len(t.Attempts))
+	for i, attempt := range t.Attempts {
+		result := "ok"
+		if attempt.Error != "" {
+			result = attempt.Error
+		}
+		attempts[i] = fmt.Sprintf("%s (%s): %s", attempt.Getter, attempt.Duration, result)
+	}
+	return "cascade trace: [" + strings.Join(attempts, "; ") + "]"
+}
@@ -0,0 +1,54 @@
+package getters
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/libs/cache"
+	"github.com/celestiaorg/celestia-node/libs/utils"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var _ share.Getter = (*CoalescingGetter)(nil)
+
+// CoalescingGetter wraps a getter, so that concurrent requests of the same EDS are served by a
+// single retrieval, which result, and the verification of it, is shared among all the waiters.
+//
+// The retrieval runs with the context of the request which started it. Every waiter stops waiting
+// once its own context is done, and if the retrieving request leaves, one of the remaining waiters
+// retrieves the EDS again.
+type CoalescingGetter struct {
+	share.Getter
+
+	// inflight coalesces retrievals by root, without keeping retrieved EDSes
+	inflight *cache.Cache[string, *rsmt2d.ExtendedDataSquare]
+}
+
+// NewCoalescingGetter creates a new CoalescingGetter.
+func NewCoalescingGetter(getter share.Getter) *CoalescingGetter {
+	return &CoalescingGetter{
+		Getter:   getter,
+		inflight: cache.New[string, *rsmt2d.ExtendedDataSquare](0),
+	}
+}
+
+func (cg *CoalescingGetter) GetEDS(ctx context.Context, root *share.Root) (eds *rsmt2d.ExtendedDataSquare, err error) {
+	ctx, span := tracer.Start(ctx, "coalescing/get-eds", trace.WithAttributes(
+		share.TraceAttributes(ctx, root.Hash(), nil)...,
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	coalesced := true
+	eds, err = cg.inflight.Do(ctx, root.String(), func(ctx context.Context) (*rsmt2d.ExtendedDataSquare, error) {
+		coalesced = false
+		return cg.Getter.GetEDS(ctx, root)
+	})
+	span.SetAttributes(attribute.Bool("coalesced", coalesced))
+	return eds, err
+}
@@ -0,0 +1,155 @@
+package getters
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestCoalescingGetter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	square, root := randomEDS(t)
+
+	t.Run("shares retrieval among waiters", func(t *testing.T) {
+		bg := newBlockingGetter(square)
+		cg := NewCoalescingGetter(bg)
+
+		var wg sync.WaitGroup
+		results := make([]*rsmt2d.ExtendedDataSquare, 5)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				eds, err := cg.GetEDS(ctx, &root)
+				assert.NoError(t, err)
+				results[i] = eds
+			}(i)
+		}
+		require.Eventually(t, func() bool { return bg.calls.Load() == 1 }, time.Second, time.Millisecond)
+		// let the other waiters join the retrieval
+		time.Sleep(time.Millisecond * 50)
+		close(bg.release)
+		wg.Wait()
+
+		assert.EqualValues(t, 1, bg.calls.Load())
+		for _, eds := range results {
+			assert.Same(t, square, eds)
+		}
+	})
+
+	t.Run("waiters leave on their own cancellation", func(t *testing.T) {
+		bg := newBlockingGetter(square)
+		cg := NewCoalescingGetter(bg)
+
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := cg.GetEDS(ctx, &root)
+			errCh <- err
+		}()
+		require.Eventually(t, func() bool { return bg.calls.Load() == 1 }, time.Second, time.Millisecond)
+
+		leaving, leave := context.WithCancel(ctx)
+		leftCh := make(chan error, 1)
+		go func() {
+			_, err := cg.GetEDS(leaving, &root)
+			leftCh <- err
+		}()
+		time.Sleep(time.Millisecond * 50)
+
+		leave()
+		assert.ErrorIs(t, <-leftCh, context.Canceled)
+		// the retrieval continues for the retrieving waiter
+		close(bg.release)
+		assert.NoError(t, <-errCh)
+		assert.EqualValues(t, 1, bg.calls.Load())
+	})
+
+	t.Run("remaining waiter retrieves again once the retrieving one leaves", func(t *testing.T) {
+		bg := newBlockingGetter(square)
+		cg := NewCoalescingGetter(bg)
+
+		leaving, leave := context.WithCancel(ctx)
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := cg.GetEDS(leaving, &root)
+			errCh <- err
+		}()
+		require.Eventually(t, func() bool { return bg.calls.Load() == 1 }, time.Second, time.Millisecond)
+
+		result := make(chan *rsmt2d.ExtendedDataSquare, 1)
+		go func() {
+			eds, err := cg.GetEDS(ctx, &root)
+			assert.NoError(t, err)
+			result <- eds
+		}()
+		time.Sleep(time.Millisecond * 50)
+
+		leave()
+		assert.ErrorIs(t, <-errCh, context.Canceled)
+		<-bg.canceled
+		close(bg.release)
+		assert.Same(t, square, <-result)
+		assert.EqualValues(t, 2, bg.calls.Load())
+	})
+
+	t.Run("retrieval is canceled once no waiter is left", func(t *testing.T) {
+		bg := newBlockingGetter(square)
+		cg := NewCoalescingGetter(bg)
+
+		leaving, leave := context.WithCancel(ctx)
+		leave()
+		_, err := cg.GetEDS(leaving, &root)
+		assert.ErrorIs(t, err, context.Canceled)
+		select {
+		case <-bg.canceled:
+		case <-ctx.Done():
+			t.Fatal("retrieval not canceled")
+		}
+
+		// the next request starts a new retrieval
+		close(bg.release)
+		eds, err := cg.GetEDS(ctx, &root)
+		require.NoError(t, err)
+		assert.Same(t, square, eds)
+		assert.EqualValues(t, 2, bg.calls.Load())
+	})
+}
+
+// blockingGetter returns the EDS once released.
+type blockingGetter struct {
+	share.Getter
+
+	eds      *rsmt2d.ExtendedDataSquare
+	calls    atomic.Int64
+	release  chan struct{}
+	canceled chan struct{}
+}
+
+func newBlockingGetter(eds *rsmt2d.ExtendedDataSquare) *blockingGetter {
+	return &blockingGetter{
+		eds:      eds,
+		release:  make(chan struct{}),
+		canceled: make(chan struct{}, 1),
+	}
+}
+
+func (bg *blockingGetter) GetEDS(ctx context.Context, _ *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
+	bg.calls.Add(1)
+	select {
+	case <-bg.release:
+		return bg.eds, nil
+	case <-ctx.Done():
+		bg.canceled <- struct{}{}
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,177 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/p2p"
+)
+
+const (
+	protocolEDS = "shrex/eds"
+	protocolND  = "shrex/nd"
+)
+
+// ErrorClass classifies the failure of a request to a single peer.
+type ErrorClass string
+
+const (
+	// ClassNotFound means the peer didn't have the requested data.
+	ClassNotFound ErrorClass = "not_found"
+	// ClassRateLimited means the peer was overloaded and refused to serve the request.
+	ClassRateLimited ErrorClass = "rate_limited"
+	// ClassTimeout means the peer didn't serve the request in time.
+	ClassTimeout ErrorClass = "timeout"
+	// ClassInvalidResponse means the peer served malformed data or failed internally.
+	ClassInvalidResponse ErrorClass = "invalid_response"
+	// ClassProofFailure means the proofs of the data served by the peer didn't verify.
+	ClassProofFailure ErrorClass = "proof_failure"
+	// ClassWrongEpoch means the peer runs the protocol in another epoch.
+	ClassWrongEpoch ErrorClass = "wrong_epoch"
+	// ClassOther are all other failures, e.g. of streams.
+	ClassOther ErrorClass = "other"
+)
+
+// classifyError returns the ErrorClass of the failure of a request to a peer.
+func classifyError(err error) ErrorClass {
+	switch {
+	// rate limiting is a kind of ErrNotFound, so it is checked first
+	case errors.Is(err, p2p.ErrRateLimited):
+		return ClassRateLimited
+	case errors.Is(err, p2p.ErrNotFound), errors.Is(err, share.ErrNotFound):
+		return ClassNotFound
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return ClassTimeout
+	case errors.Is(err, p2p.ErrInvalidResponse):
+		return ClassInvalidResponse
+	case errors.Is(err, p2p.ErrWrongEpoch):
+		return ClassWrongEpoch
+	default:
+		return ClassOther
+	}
+}
+
+// PeerAttempt is a failed request of a retrieval to a single peer.
+type PeerAttempt struct {
+	Peer  peer.ID    `json:"peer"`
+	Class ErrorClass `json:"class"`
+	Err   error      `json:"-"`
+}
+
+// RetrievalError is returned by getters once all of them failed to retrieve the data. Besides the
+// errors of the getters, it lists the peers attempted over every protocol along with the classes of
+// their failures, telling whether the node knew no providers of the data, was rate limited by all
+// of them, or got data failing proofs.
+type RetrievalError struct {
+	// Attempts are the failed requests to peers by protocol, e.g. shrex/eds, in the order they were
+	// made. Protocols which were tried without any peer to request have no attempts.
+	Attempts map[string][]PeerAttempt `json:"attempts"`
+	// Err joins the errors of the getters.
+	Err error `json:"-"`
+}
+
+// newRetrievalError returns an empty RetrievalError of retrievals over the given protocols.
+func newRetrievalError(protocols ...string) *RetrievalError {
+	attempts := make(map[string][]PeerAttempt, len(protocols))
+	for _, protocol := range protocols {
+		attempts[protocol] = nil
+	}
+	return &RetrievalError{Attempts: attempts}
+}
+
+// addAttempt records the failed request to the peer over the protocol.
+func (e *RetrievalError) addAttempt(protocol string, peer peer.ID, class ErrorClass, err error) {
+	e.Attempts[protocol] = append(e.Attempts[protocol], PeerAttempt{
+		Peer:  peer,
+		Class: class,
+		Err:   err,
+	})
+}
+
+// merge collects the attempts of the RetrievalError in err's tree, if any. It returns the error
+// of the getters of the RetrievalError if err is one, so that its attempts are not summarized twice.
+func (e *RetrievalError) merge(err error) error {
+	var other *RetrievalError
+	if !errors.As(err, &other) {
+		return err
+	}
+	for protocol, attempts := range other.Attempts {
+		e.Attempts[protocol] = append(e.Attempts[protocol], attempts...)
+	}
+	if err == other {
+		return other.Err
+	}
+	return err
+}
+
+// withErr sets the error of the getters and returns the RetrievalError.
+func (e *RetrievalError) withErr(err error) *RetrievalError {
+	e.Err = err
+	return e
+}
+
+// NoProviders reports whether no peer was requested the data over any of the protocols.
+func (e *RetrievalError) NoProviders() bool {
+	for _, attempts := range e.Attempts {
+		if len(attempts) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Classes counts the attempted peers by the classes of their failures.
+func (e *RetrievalError) Classes() map[ErrorClass]int {
+	classes := make(map[ErrorClass]int)
+	for _, attempts := range e.Attempts {
+		for _, attempt := range attempts {
+			classes[attempt.Class]++
+		}
+	}
+	return classes
+}
+
+// Error returns the errors of the getters followed by the summary of the attempted peers, e.g.
+// "...: peers attempted: shrex/eds: 2 not_found, 1 timeout; shrex/nd: none".
+func (e *RetrievalError) Error() string {
+	if len(e.Attempts) == 0 {
+		return e.Err.Error()
+	}
+
+	protocols := make([]string, 0, len(e.Attempts))
+	for protocol := range e.Attempts {
+		protocols = append(protocols, protocol)
+	}
+	sort.Strings(protocols)
+
+	summaries := make([]string, len(protocols))
+	for i, protocol := range protocols {
+		counts := make(map[ErrorClass]int)
+		var classes []string
+		for _, attempt := range e.Attempts[protocol] {
+			if counts[attempt.Class] == 0 {
+				classes = append(classes, string(attempt.Class))
+			}
+			counts[attempt.Class]++
+		}
+		if len(classes) == 0 {
+			summaries[i] = protocol + ": none"
+			continue
+		}
+		for j, class := range classes {
+			classes[j] = fmt.Sprintf("%d %s", counts[ErrorClass(class)], class)
+		}
+		summaries[i] = protocol + ": " + strings.Join(classes, ", ")
+	}
+	return fmt.Sprintf("%v: peers attempted: %s", e.Err, strings.Join(summaries, "; "))
+}
+
+func (e *RetrievalError) Unwrap() error {
+	return e.Err
+}
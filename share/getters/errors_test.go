@@ -0,0 +1,90 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/p2p"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err   error
+		class ErrorClass
+	}{
+		{p2p.ErrRateLimited, ClassRateLimited},
+		{p2p.ErrNotFound, ClassNotFound},
+		{share.ErrNotFound, ClassNotFound},
+		{fmt.Errorf("request: %w", context.DeadlineExceeded), ClassTimeout},
+		{p2p.ErrInvalidResponse, ClassInvalidResponse},
+		{p2p.ErrWrongEpoch, ClassWrongEpoch},
+		{errors.New("stream reset"), ClassOther},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.class, classifyError(tt.err), tt.err.Error())
+	}
+}
+
+func TestRetrievalError(t *testing.T) {
+	t.Run("NoProviders", func(t *testing.T) {
+		err := newRetrievalError(protocolEDS).withErr(share.ErrNotFound)
+		assert.True(t, err.NoProviders())
+		assert.ErrorIs(t, err, share.ErrNotFound)
+		assert.Equal(t, share.ErrNotFound.Error()+": peers attempted: shrex/eds: none", err.Error())
+	})
+
+	t.Run("Summary", func(t *testing.T) {
+		err := newRetrievalError(protocolEDS, protocolND)
+		err.addAttempt(protocolND, peer.ID("a"), ClassRateLimited, p2p.ErrRateLimited)
+		err.addAttempt(protocolND, peer.ID("b"), ClassProofFailure, errors.New("invalid proof"))
+		err.addAttempt(protocolND, peer.ID("c"), ClassRateLimited, p2p.ErrRateLimited)
+		err.withErr(share.ErrNotFound)
+
+		assert.False(t, err.NoProviders())
+		assert.Equal(t, map[ErrorClass]int{ClassRateLimited: 2, ClassProofFailure: 1}, err.Classes())
+		assert.Equal(t,
+			share.ErrNotFound.Error()+": peers attempted: shrex/eds: none; shrex/nd: 2 rate_limited, 1 proof_failure",
+			err.Error(),
+		)
+	})
+
+	t.Run("Cascade", func(t *testing.T) {
+		first := newRetrievalError(protocolEDS)
+		first.addAttempt(protocolEDS, peer.ID("a"), ClassTimeout, context.DeadlineExceeded)
+		second := newRetrievalError(protocolND)
+		second.addAttempt(protocolND, peer.ID("b"), ClassNotFound, p2p.ErrNotFound)
+
+		getters := []share.Getter{
+			errGetter{err: first.withErr(context.DeadlineExceeded)},
+			errGetter{err: second.withErr(share.ErrNotFound)},
+		}
+		_, err := cascadeGetters(context.Background(), getters, func(ctx context.Context, g share.Getter) (share.Share, error) {
+			return g.GetShare(ctx, nil, 0, 0)
+		})
+
+		var retrievalErr *RetrievalError
+		require.ErrorAs(t, err, &retrievalErr)
+		assert.Len(t, retrievalErr.Attempts[protocolEDS], 1)
+		assert.Len(t, retrievalErr.Attempts[protocolND], 1)
+		assert.ErrorIs(t, err, share.ErrNotFound)
+		// the attempts of the getters are summarized once
+		assert.Equal(t, 1, strings.Count(err.Error(), "peers attempted"))
+	})
+}
+
+type errGetter struct {
+	share.Getter
+	err error
+}
+
+func (g errGetter) GetShare(context.Context, *share.Root, int, int) (share.Share, error) {
+	return nil, g.err
+}
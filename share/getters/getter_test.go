@@ -20,6 +20,7 @@ import (
 
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/p2p/peers"
 )
 
 func TestTeeGetter(t *testing.T) {
@@ -75,6 +76,66 @@ func TestTeeGetter(t *testing.T) {
 		require.NoError(t, err)
 		require.True(t, share.EqualEDS(eds, retrievedEDS))
 	})
+
+	t.Run("LazyValidation", func(t *testing.T) {
+		eds, dah := randomEDS(t)
+		lg := &lazyGetter{eds: eds}
+		tg := NewTeeGetter(lg, edsStore)
+
+		retrievedEDS, err := tg.GetEDS(ctx, &dah)
+		require.NoError(t, err)
+		require.True(t, share.EqualEDS(eds, retrievedEDS))
+		assert.Equal(t, []string{peers.ResultSynced}, lg.results)
+		assert.Equal(t, 1, lg.calls)
+	})
+
+	t.Run("LazyValidationRefetchesInvalid", func(t *testing.T) {
+		eds, dah := randomEDS(t)
+		invalid, _ := randomEDS(t)
+		lg := &lazyGetter{eds: eds, invalid: invalid}
+		tg := NewTeeGetter(lg, edsStore)
+
+		retrievedEDS, err := tg.GetEDS(ctx, &dah)
+		require.NoError(t, err)
+		require.True(t, share.EqualEDS(eds, retrievedEDS))
+		// the peer of the invalid EDS is blacklisted and the EDS is fetched again with validation
+		assert.Equal(t, []string{peers.ResultBlacklistPeer}, lg.results)
+		assert.Equal(t, 2, lg.calls)
+
+		// the invalid EDS is replaced in the store by the valid one
+		storedEDS, err := edsStore.Get(ctx, dah.Hash())
+		require.NoError(t, err)
+		require.True(t, share.EqualEDS(eds, storedEDS))
+	})
+}
+
+// lazyGetter returns its invalid EDS without validation first, if it is set, and its EDS afterwards.
+type lazyGetter struct {
+	share.Getter
+
+	eds, invalid *rsmt2d.ExtendedDataSquare
+	calls        int
+	results      []string
+}
+
+func (g *lazyGetter) GetEDS(ctx context.Context, _ *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
+	g.calls++
+	lv, lazy := ctx.Value(lazyValidationKey).(*lazyValidation)
+	if !lazy {
+		return g.eds, nil
+	}
+	recordResults(&lv.setStatus, &g.results)
+	if g.invalid != nil {
+		return g.invalid, nil
+	}
+	return g.eds, nil
+}
+
+// recordResults makes the peers.DoneFunc record the results it is called with.
+func recordResults[F ~func(R), R ~string](done *F, results *[]string) {
+	*done = func(result R) {
+		*results = append(*results, string(result))
+	}
 }
 
 func TestStoreGetter(t *testing.T) {
@@ -134,7 +195,8 @@ func TestStoreGetter(t *testing.T) {
 		shares, err := sg.GetSharesByNamespace(ctx, &dah, nID)
 		require.NoError(t, err)
 		require.NoError(t, shares.Verify(&dah, nID))
-		assert.Len(t, shares.Flatten(), 2)
+		flattened, _ := shares.Flatten()
+		assert.Len(t, flattened, 2)
 
 		// nid not found
 		nID = make([]byte, namespace.NamespaceSize)
@@ -146,6 +208,38 @@ func TestStoreGetter(t *testing.T) {
 		_, err = sg.GetSharesByNamespace(ctx, &root, nID)
 		require.ErrorIs(t, err, share.ErrNotFound)
 	})
+
+	t.Run("GetSharesByNamespaceMapped", func(t *testing.T) {
+		square, nID, dah := randomEDSWithDoubledNamespace(t, 4)
+		err = edsStore.Put(ctx, dah.Hash(), square)
+		require.NoError(t, err)
+
+		bs, err := edsStore.CARBlockstore(ctx, dah.Hash())
+		require.NoError(t, err)
+		blockGetter := eds.NewBlockGetter(bs)
+
+		// shares and proofs read from the mapping match the ones read through the blockstore
+		absentNID := append(nID[:len(nID)-1:len(nID)-1], nID[len(nID)-1]+1)
+		for _, nID := range [][]byte{nID, absentNID} {
+			expected, err := collectSharesByNamespace(ctx, blockGetter, &dah, nID)
+			if err != nil {
+				require.ErrorIs(t, err, share.ErrNamespaceNotFound)
+			}
+
+			shares, release, err := sg.GetSharesByNamespaceMapped(ctx, &dah, nID)
+			if expected == nil {
+				require.ErrorIs(t, err, share.ErrNamespaceNotFound)
+				continue
+			}
+			require.NoError(t, err)
+			assert.Equal(t, expected, shares)
+			release()
+		}
+
+		shares, err := sg.GetSharesByNamespace(ctx, &dah, nID)
+		require.NoError(t, err)
+		require.NoError(t, shares.Verify(&dah, nID))
+	})
 }
 
 func TestIPLDGetter(t *testing.T) {
@@ -211,7 +305,8 @@ func TestIPLDGetter(t *testing.T) {
 		shares, err := sg.GetSharesByNamespace(ctx, &dah, nID)
 		require.NoError(t, err)
 		require.NoError(t, shares.Verify(&dah, nID))
-		assert.Len(t, shares.Flatten(), 2)
+		flattened, _ := shares.Flatten()
+		assert.Len(t, flattened, 2)
 
 		// nid not found
 		nID = make([]byte, namespace.NamespaceSize)
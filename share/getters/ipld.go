@@ -2,7 +2,6 @@ package getters
 
 import (
 	"context"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"sync"
@@ -39,13 +38,20 @@ func NewIPLDGetter(bServ blockservice.BlockService) *IPLDGetter {
 	}
 }
 
+// SetPeerKnowledge sets the knowledge about peers serving data squares to plan retrieval of squares
+// by.
+func (ig *IPLDGetter) SetPeerKnowledge(peers *eds.PeerKnowledge) {
+	ig.rtrv.SetPeerKnowledge(peers)
+}
+
 // GetShare gets a single share at the given EDS coordinates from the bitswap network.
 func (ig *IPLDGetter) GetShare(ctx context.Context, dah *share.Root, row, col int) (share.Share, error) {
 	var err error
 	ctx, span := tracer.Start(ctx, "ipld/get-share", trace.WithAttributes(
-		attribute.String("root", dah.String()),
-		attribute.Int("row", row),
-		attribute.Int("col", col),
+		share.TraceAttributes(ctx, dah.Hash(), nil,
+			attribute.Int("row", row),
+			attribute.Int("col", col),
+		)...,
 	))
 	defer func() {
 		utils.SetStatusAndEnd(span, err)
@@ -64,12 +70,13 @@ func (ig *IPLDGetter) GetShare(ctx context.Context, dah *share.Root, row, col in
 		return nil, fmt.Errorf("getter/ipld: failed to retrieve share: %w", err)
 	}
 
+	recordSource(ctx, "ipld")
 	return s, nil
 }
 
 func (ig *IPLDGetter) GetEDS(ctx context.Context, root *share.Root) (eds *rsmt2d.ExtendedDataSquare, err error) {
 	ctx, span := tracer.Start(ctx, "ipld/get-eds", trace.WithAttributes(
-		attribute.String("root", root.String()),
+		share.TraceAttributes(ctx, root.Hash(), nil)...,
 	))
 	defer func() {
 		utils.SetStatusAndEnd(span, err)
@@ -87,14 +94,18 @@ func (ig *IPLDGetter) GetEDS(ctx context.Context, root *share.Root) (eds *rsmt2d
 	return eds, nil
 }
 
+// ReconstructionProgress returns the progress of the ongoing reconstructions of data squares.
+func (ig *IPLDGetter) ReconstructionProgress() []eds.ReconstructionProgress {
+	return ig.rtrv.Progress()
+}
+
 func (ig *IPLDGetter) GetSharesByNamespace(
 	ctx context.Context,
 	root *share.Root,
 	nID namespace.ID,
 ) (shares share.NamespacedShares, err error) {
 	ctx, span := tracer.Start(ctx, "ipld/get-shares-by-namespace", trace.WithAttributes(
-		attribute.String("root", root.String()),
-		attribute.String("nid", hex.EncodeToString(nID)),
+		share.TraceAttributes(ctx, root.Hash(), nID)...,
 	))
 	defer func() {
 		utils.SetStatusAndEnd(span, err)
@@ -0,0 +1,117 @@
+package getters
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+
+	appns "github.com/celestiaorg/celestia-app/pkg/namespace"
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var _ share.Getter = (*NamespaceMetricsGetter)(nil)
+
+var gettersMeter = global.MeterProvider().Meter("share/getters")
+
+// NamespaceMetricsGetter is a share.Getter that counts retrievals of shares by namespace and their
+// size for an allowlist of namespaces. Namespaces out of the allowlist are not counted, so that the
+// amount of metric labels stays bounded.
+type NamespaceMetricsGetter struct {
+	share.Getter
+
+	// labels maps allowed namespaces to their metric labels
+	labels map[string]attribute.KeyValue
+
+	requests syncint64.Counter
+	bytes    syncint64.Counter
+}
+
+// NewNamespaceMetricsGetter wraps the given share.Getter to count retrievals of the given
+// namespaces.
+func NewNamespaceMetricsGetter(getter share.Getter, allowlist []namespace.ID) (*NamespaceMetricsGetter, error) {
+	return newNamespaceMetricsGetter(getter, allowlist, gettersMeter)
+}
+
+func newNamespaceMetricsGetter(
+	getter share.Getter,
+	allowlist []namespace.ID,
+	meter metric.Meter,
+) (*NamespaceMetricsGetter, error) {
+	requests, err := meter.SyncInt64().Counter(
+		"getters_namespace_requests",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("Number of requests for shares of allowlisted namespaces"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := meter.SyncInt64().Counter(
+		"getters_namespace_bytes",
+		instrument.WithUnit(unit.Bytes),
+		instrument.WithDescription("Amount of retrieved bytes of shares of allowlisted namespaces"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make(map[string]attribute.KeyValue, len(allowlist))
+	for _, nID := range allowlist {
+		labels[string(nID)] = attribute.String("namespace", namespaceLabel(nID))
+	}
+
+	return &NamespaceMetricsGetter{
+		Getter:   getter,
+		labels:   labels,
+		requests: requests,
+		bytes:    bytes,
+	}, nil
+}
+
+func (mg *NamespaceMetricsGetter) GetSharesByNamespace(
+	ctx context.Context,
+	root *share.Root,
+	id namespace.ID,
+) (share.NamespacedShares, error) {
+	shares, err := mg.Getter.GetSharesByNamespace(ctx, root, id)
+
+	label, ok := mg.labels[string(id)]
+	if !ok {
+		return shares, err
+	}
+
+	recordCtx := ctx
+	if recordCtx.Err() != nil {
+		recordCtx = context.Background()
+	}
+	mg.requests.Add(recordCtx, 1, label, attribute.Bool("success", err == nil))
+	if err == nil {
+		var size int
+		for _, row := range shares {
+			for _, sh := range row.Shares {
+				size += len(sh)
+			}
+		}
+		mg.bytes.Add(recordCtx, int64(size), label)
+	}
+	return shares, err
+}
+
+// namespaceLabel truncates version zero namespaces to their user-specified part, as the rest of
+// them is zero padding. Other namespaces are labeled in full, so that labels never collide.
+func namespaceLabel(nID namespace.ID) string {
+	prefixSize := len(nID) - appns.NamespaceVersionZeroIDSize
+	if prefixSize > 0 && bytes.Equal(nID[:prefixSize], make([]byte, prefixSize)) {
+		nID = nID[prefixSize:]
+	}
+	return hex.EncodeToString(nID)
+}
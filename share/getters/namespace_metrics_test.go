@@ -0,0 +1,80 @@
+package getters
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/mocks"
+)
+
+func TestNamespaceMetricsGetter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	allowed, err := share.NewNamespaceV0([]byte("allowed"))
+	require.NoError(t, err)
+	other, err := share.NewNamespaceV0([]byte("other"))
+	require.NoError(t, err)
+
+	root := &share.Root{}
+	expected := share.NamespacedShares{{Shares: []share.Share{make([]byte, share.Size)}}}
+	errGet := errors.New("get")
+
+	getter := mocks.NewMockGetter(gomock.NewController(t))
+	getter.EXPECT().GetSharesByNamespace(gomock.Any(), root, allowed).Return(expected, nil)
+	getter.EXPECT().GetSharesByNamespace(gomock.Any(), root, other).Return(nil, errGet)
+
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")
+	mg, err := newNamespaceMetricsGetter(getter, []namespace.ID{allowed}, meter)
+	require.NoError(t, err)
+
+	shares, err := mg.GetSharesByNamespace(ctx, root, allowed)
+	require.NoError(t, err)
+	assert.Equal(t, expected, shares)
+
+	_, err = mg.GetSharesByNamespace(ctx, root, other)
+	assert.ErrorIs(t, err, errGet)
+
+	data, err := reader.Collect(ctx)
+	require.NoError(t, err)
+	require.Len(t, data.ScopeMetrics, 1)
+	// namespaces out of the allowlist are not counted
+	sums := make(map[string]map[string]int64)
+	for _, m := range data.ScopeMetrics[0].Metrics {
+		sum, ok := m.Data.(metricdata.Sum[int64])
+		require.True(t, ok, m.Name)
+		sums[m.Name] = make(map[string]int64)
+		for _, dp := range sum.DataPoints {
+			sums[m.Name][dp.Attributes.Encoded(attribute.DefaultEncoder())] = dp.Value
+		}
+	}
+	label := "namespace=" + namespaceLabel(allowed)
+	assert.Equal(t, map[string]map[string]int64{
+		"getters_namespace_requests": {label + ",success=true": 1},
+		"getters_namespace_bytes":    {label: share.Size},
+	}, sums)
+}
+
+func Test_namespaceLabel(t *testing.T) {
+	nID, err := share.NewNamespaceV0([]byte{0x1, 0x2})
+	require.NoError(t, err)
+	assert.Equal(t, "00000000000000000102", namespaceLabel(nID))
+
+	// namespaces with the same user-specified part, but other versions or prefixes, don't collide
+	other := append(namespace.ID{}, nID...)
+	other[0] = 0xff
+	assert.Equal(t, hex.EncodeToString(other), namespaceLabel(other))
+}
@@ -5,18 +5,27 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
+	"github.com/ipfs/go-blockservice"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	bstore "github.com/ipfs/go-ipfs-blockstore"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/metric/instrument"
 	"go.opentelemetry.io/otel/metric/instrument/syncint64"
 	"go.opentelemetry.io/otel/metric/unit"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/celestiaorg/nmt/namespace"
 	"github.com/celestiaorg/rsmt2d"
 
+	"github.com/celestiaorg/celestia-node/libs/utils"
 	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/p2p"
 	"github.com/celestiaorg/celestia-node/share/p2p/peers"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexeds"
@@ -25,12 +34,7 @@ import (
 
 var _ share.Getter = (*ShrexGetter)(nil)
 
-const (
-	// defaultMinRequestTimeout value is set according to observed time taken by healthy peer to
-	// serve getEDS request for block size 256
-	defaultMinRequestTimeout = time.Minute // should be >= shrexeds server write timeout
-	defaultMinAttemptsCount  = 3
-)
+const defaultMinAttemptsCount = 3
 
 var meter = global.MeterProvider().Meter("shrex/getter")
 
@@ -91,9 +95,13 @@ type ShrexGetter struct {
 	ndClient  *shrexnd.Client
 
 	peerManager *peers.Manager
+	// peerKnowledge records the parts of squares peers served, for the Retriever to plan by
+	peerKnowledge *eds.PeerKnowledge
 
 	// minRequestTimeout limits minimal timeout given to single peer by getter for serving the request.
-	minRequestTimeout time.Duration
+	// Zero picks the timeout from the deadline profiles by the size of the requested square.
+	minRequestTimeout atomic.Int64
+	deadlines         atomic.Pointer[share.DeadlineProfiles]
 	// minAttemptsCount will be used to split request timeout into multiple attempts. It will allow to
 	// attempt multiple peers in scope of one request before context timeout is reached
 	minAttemptsCount int
@@ -102,13 +110,50 @@ type ShrexGetter struct {
 }
 
 func NewShrexGetter(edsClient *shrexeds.Client, ndClient *shrexnd.Client, peerManager *peers.Manager) *ShrexGetter {
-	return &ShrexGetter{
-		edsClient:         edsClient,
-		ndClient:          ndClient,
-		peerManager:       peerManager,
-		minRequestTimeout: defaultMinRequestTimeout,
-		minAttemptsCount:  defaultMinAttemptsCount,
+	sg := &ShrexGetter{
+		edsClient:        edsClient,
+		ndClient:         ndClient,
+		peerManager:      peerManager,
+		minAttemptsCount: defaultMinAttemptsCount,
+	}
+	sg.SetDeadlineProfiles(share.DefaultDeadlineProfiles())
+	return sg
+}
+
+// SetPeerKnowledge sets the knowledge to record the parts of data squares peers served into.
+func (sg *ShrexGetter) SetPeerKnowledge(peers *eds.PeerKnowledge) {
+	sg.peerKnowledge = peers
+}
+
+// SetMinRequestTimeout changes the minimal timeout given to a single peer for serving a request.
+// Zero resets it to the timeout of the deadline profile of the requested square.
+func (sg *ShrexGetter) SetMinRequestTimeout(timeout time.Duration) {
+	sg.minRequestTimeout.Store(int64(timeout))
+}
+
+// SetDeadlineProfiles changes the minimal timeouts given to a single peer for serving requests of
+// squares of different sizes, unless a fixed timeout is set with SetMinRequestTimeout.
+func (sg *ShrexGetter) SetDeadlineProfiles(profiles share.DeadlineProfiles) {
+	sg.deadlines.Store(&profiles)
+}
+
+// attemptCtx returns the context of a request to a single peer, splitting the remaining time of the
+// given context between the remaining attempts. It tells the clients the size of the square.
+func (sg *ShrexGetter) attemptCtx(
+	ctx context.Context,
+	root *share.Root,
+	attempt int,
+) (context.Context, context.CancelFunc) {
+	ctx = share.WithSquareSize(ctx, len(root.RowRoots)/2)
+	return ctxWithSplitTimeout(ctx, sg.minAttemptsCount-attempt+1, sg.minTimeout(root))
+}
+
+// minTimeout returns the minimal timeout given to a single peer for serving a request of the square.
+func (sg *ShrexGetter) minTimeout(root *share.Root) time.Duration {
+	if timeout := time.Duration(sg.minRequestTimeout.Load()); timeout != 0 {
+		return timeout
 	}
+	return sg.deadlines.Load().TimeoutFor(root)
 }
 
 func (sg *ShrexGetter) Start(ctx context.Context) error {
@@ -123,15 +168,26 @@ func (sg *ShrexGetter) GetShare(context.Context, *share.Root, int, int) (share.S
 	return nil, fmt.Errorf("getter/shrex: GetShare %w", errOperationNotSupported)
 }
 
-func (sg *ShrexGetter) GetEDS(ctx context.Context, root *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
+func (sg *ShrexGetter) GetEDS(ctx context.Context, root *share.Root) (eds *rsmt2d.ExtendedDataSquare, err error) {
+	ctx, span := tracer.Start(ctx, "shrex/get-eds", trace.WithAttributes(
+		share.TraceAttributes(ctx, root.Hash(), nil)...,
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+	return sg.getEDS(ctx, root)
+}
+
+func (sg *ShrexGetter) getEDS(ctx context.Context, root *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
 	var (
 		attempt int
 		err     error
 	)
+	retrievalErr := newRetrievalError(protocolEDS)
 	for {
 		if ctx.Err() != nil {
 			sg.metrics.recordEDSAttempt(ctx, attempt, false)
-			return nil, errors.Join(err, ctx.Err())
+			return nil, retrievalErr.withErr(errors.Join(err, ctx.Err()))
 		}
 		attempt++
 		start := time.Now()
@@ -142,16 +198,31 @@ func (sg *ShrexGetter) GetEDS(ctx context.Context, root *share.Root) (*rsmt2d.Ex
 				"err", getErr,
 				"finished (s)", time.Since(start))
 			sg.metrics.recordEDSAttempt(ctx, attempt, false)
-			return nil, errors.Join(err, getErr)
+			return nil, retrievalErr.withErr(errors.Join(err, getErr))
 		}
 
 		reqStart := time.Now()
-		reqCtx, cancel := ctxWithSplitTimeout(ctx, sg.minAttemptsCount-attempt+1, sg.minRequestTimeout)
-		eds, getErr := sg.edsClient.RequestEDS(reqCtx, root.Hash(), peer)
+		reqCtx, cancel := sg.attemptCtx(ctx, root, attempt)
+		// EDSes of trusted peers are validated by the caller after they are stored, if it supports it
+		lv, lazy := ctx.Value(lazyValidationKey).(*lazyValidation)
+		lazy = lazy && sg.edsClient.IsLazyValidated(peer)
+		var eds *rsmt2d.ExtendedDataSquare
+		if lazy {
+			eds, getErr = sg.edsClient.RequestUnverifiedEDS(reqCtx, root.Hash(), peer)
+		} else {
+			eds, getErr = sg.edsClient.RequestEDS(reqCtx, root.Hash(), peer)
+		}
 		cancel()
+		class := classifyError(getErr)
 		switch {
+		case getErr == nil && lazy:
+			lv.setStatus = setStatus
+			sg.peerKnowledge.ObserveSquare(root.Hash(), peer)
+			sg.metrics.recordEDSAttempt(ctx, attempt, true)
+			return eds, nil
 		case getErr == nil:
 			setStatus(peers.ResultSynced)
+			sg.peerKnowledge.ObserveSquare(root.Hash(), peer)
 			sg.metrics.recordEDSAttempt(ctx, attempt, true)
 			return eds, nil
 		case errors.Is(getErr, context.DeadlineExceeded),
@@ -160,12 +231,15 @@ func (sg *ShrexGetter) GetEDS(ctx context.Context, root *share.Root) (*rsmt2d.Ex
 		case errors.Is(getErr, p2p.ErrNotFound):
 			getErr = share.ErrNotFound
 			setStatus(peers.ResultCooldownPeer)
+			sg.peerKnowledge.ObserveMissing(root.Hash(), peer)
 		case errors.Is(getErr, p2p.ErrInvalidResponse):
 			setStatus(peers.ResultBlacklistPeer)
 		default:
 			setStatus(peers.ResultCooldownPeer)
 		}
 
+		retrievalErr.addAttempt(protocolEDS, peer, class, getErr)
+		traceAttempt(ctx, protocolEDS, peer, attempt, getErr)
 		if !ErrorContains(err, getErr) {
 			err = errors.Join(err, getErr)
 		}
@@ -182,6 +256,20 @@ func (sg *ShrexGetter) GetSharesByNamespace(
 	ctx context.Context,
 	root *share.Root,
 	id namespace.ID,
+) (shares share.NamespacedShares, err error) {
+	ctx, span := tracer.Start(ctx, "shrex/get-shares-by-namespace", trace.WithAttributes(
+		share.TraceAttributes(ctx, root.Hash(), id)...,
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+	return sg.getSharesByNamespace(ctx, root, id)
+}
+
+func (sg *ShrexGetter) getSharesByNamespace(
+	ctx context.Context,
+	root *share.Root,
+	id namespace.ID,
 ) (share.NamespacedShares, error) {
 	var (
 		attempt int
@@ -189,19 +277,20 @@ func (sg *ShrexGetter) GetSharesByNamespace(
 	)
 
 	// verify that the namespace could exist inside the roots before starting network requests
-	roots := filterRootsByNamespace(root, id)
-	if len(roots) == 0 {
+	rows := filterRowsByNamespace(root, id)
+	if len(rows) == 0 {
 		return nil, share.ErrNamespaceNotFound
 	}
 
+	retrievalErr := newRetrievalError(protocolND)
 	for {
 		if ctx.Err() != nil {
 			sg.metrics.recordNDAttempt(ctx, attempt, false)
-			return nil, errors.Join(err, ctx.Err())
+			return nil, retrievalErr.withErr(errors.Join(err, ctx.Err()))
 		}
 		attempt++
 		start := time.Now()
-		peer, setStatus, getErr := sg.peerManager.Peer(ctx, root.Hash())
+		peer, setStatus, getErr := sg.peerManager.PeerForNamespace(ctx, root.Hash(), id)
 		if getErr != nil {
 			log.Debugw("nd: couldn't find peer",
 				"hash", root.String(),
@@ -209,25 +298,37 @@ func (sg *ShrexGetter) GetSharesByNamespace(
 				"err", getErr,
 				"finished (s)", time.Since(start))
 			sg.metrics.recordNDAttempt(ctx, attempt, false)
-			return nil, errors.Join(err, getErr)
+			return nil, retrievalErr.withErr(errors.Join(err, getErr))
 		}
 
 		reqStart := time.Now()
-		reqCtx, cancel := ctxWithSplitTimeout(ctx, sg.minAttemptsCount-attempt+1, sg.minRequestTimeout)
+		reqCtx, cancel := sg.attemptCtx(ctx, root, attempt)
 		nd, getErr := sg.ndClient.RequestND(reqCtx, root, id, peer)
 		cancel()
+		protocol := protocolND
+		if errors.Is(getErr, shrexnd.ErrRedirectToEDS) {
+			// the peer considers the namespace too large for shrex/nd, so get the whole EDS from it
+			reqCtx, cancel = sg.attemptCtx(ctx, root, attempt)
+			nd, getErr = sg.getSharesByNamespaceFromEDS(reqCtx, root, id, peer)
+			cancel()
+			protocol = protocolEDS
+		}
+		class := classifyError(getErr)
 		switch {
 		case getErr == nil:
 			if getErr = nd.Verify(root, id); getErr != nil {
+				class = ClassProofFailure
 				setStatus(peers.ResultBlacklistPeer)
 				break
 			}
 			setStatus(peers.ResultNoop)
+			sg.peerKnowledge.ObserveRows(root.Hash(), peer, rows...)
 			sg.metrics.recordNDAttempt(ctx, attempt, true)
 			return nd, getErr
 		case errors.Is(getErr, share.ErrNamespaceNotFound):
 			// TODO: will be merged with first case once non-inclusion proofs are ready
 			setStatus(peers.ResultNoop)
+			sg.peerKnowledge.ObserveRows(root.Hash(), peer, rows...)
 			sg.metrics.recordNDAttempt(ctx, attempt, true)
 			return nd, getErr
 		case errors.Is(getErr, context.DeadlineExceeded),
@@ -236,12 +337,15 @@ func (sg *ShrexGetter) GetSharesByNamespace(
 		case errors.Is(getErr, p2p.ErrNotFound):
 			getErr = share.ErrNotFound
 			setStatus(peers.ResultCooldownPeer)
+			sg.peerKnowledge.ObserveMissing(root.Hash(), peer)
 		case errors.Is(getErr, p2p.ErrInvalidResponse):
 			setStatus(peers.ResultBlacklistPeer)
 		default:
 			setStatus(peers.ResultCooldownPeer)
 		}
 
+		retrievalErr.addAttempt(protocol, peer, class, getErr)
+		traceAttempt(ctx, protocol, peer, attempt, getErr)
 		if !ErrorContains(err, getErr) {
 			err = errors.Join(err, getErr)
 		}
@@ -254,3 +358,35 @@ func (sg *ShrexGetter) GetSharesByNamespace(
 			"finished (s)", time.Since(reqStart))
 	}
 }
+
+// traceAttempt records the failed attempt of a request to the peer into the span of the request.
+func traceAttempt(ctx context.Context, protocol string, peer peer.ID, attempt int, err error) {
+	trace.SpanFromContext(ctx).AddEvent("attempt failed", trace.WithAttributes(
+		attribute.String("protocol", protocol),
+		attribute.Stringer(share.PeerAttribute, peer),
+		attribute.Int("attempt", attempt),
+		attribute.String("err", err.Error()),
+	))
+}
+
+// getSharesByNamespaceFromEDS requests the whole EDS from the given peer over shrex/eds and
+// collects shares of the namespace from it along with their proofs.
+func (sg *ShrexGetter) getSharesByNamespaceFromEDS(
+	ctx context.Context,
+	root *share.Root,
+	id namespace.ID,
+	peer peer.ID,
+) (share.NamespacedShares, error) {
+	eds, err := sg.edsClient.RequestEDS(share.WithSquareSize(ctx, len(root.RowRoots)/2), root.Hash(), peer)
+	if err != nil {
+		return nil, err
+	}
+
+	// reimport the EDS into an in-memory blockservice to reuse proof collection over the DAG
+	bs := blockservice.New(bstore.NewBlockstore(dssync.MutexWrap(ds.NewMapDatastore())), nil)
+	_, err = share.ImportShares(ctx, eds.Flattened(), bs)
+	if err != nil {
+		return nil, fmt.Errorf("getter/shrex: importing EDS: %w", err)
+	}
+	return collectSharesByNamespace(ctx, bs, root, id)
+}
@@ -12,10 +12,12 @@ import (
 	routingdisc "github.com/libp2p/go-libp2p/p2p/discovery/routing"
 	"github.com/libp2p/go-libp2p/p2p/net/conngater"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/celestiaorg/celestia-app/pkg/da"
 	"github.com/celestiaorg/celestia-app/pkg/namespace"
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
 	libhead "github.com/celestiaorg/go-header"
 	nmtnamespace "github.com/celestiaorg/nmt/namespace"
 	"github.com/celestiaorg/rsmt2d"
@@ -46,7 +48,9 @@ func TestShrexGetter(t *testing.T) {
 	err = edsStore.Start(ctx)
 	require.NoError(t, err)
 
-	ndClient, _ := newNDClientServer(ctx, t, edsStore, srvHost, clHost)
+	ndClient, _ := newNDClientServer(ctx, t, edsStore, srvHost, clHost,
+		shrexnd.WithRedirectToEDSThreshold(redirectThreshold),
+	)
 	edsClient, _ := newEDSClientServer(ctx, t, edsStore, srvHost, clHost)
 
 	// create shrex Getter
@@ -107,6 +111,25 @@ func TestShrexGetter(t *testing.T) {
 		require.ErrorIs(t, err, share.ErrNamespaceNotFound)
 	})
 
+	t.Run("ND_redirect_to_EDS", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		t.Cleanup(cancel)
+
+		// generate test data
+		eds, dah, nID := generateRedirectedEDS(t)
+		require.NoError(t, edsStore.Put(ctx, dah.Hash(), eds))
+		peerManager.Validate(ctx, srvHost.ID(), shrexsub.Notification{
+			DataHash: dah.Hash(),
+			Height:   1,
+		})
+
+		got, err := getter.GetSharesByNamespace(ctx, &dah, nID)
+		require.NoError(t, err)
+		require.NoError(t, got.Verify(&dah, nID))
+		flattened, _ := got.Flatten()
+		require.Len(t, flattened, redirectThreshold)
+	})
+
 	t.Run("EDS_Available", func(t *testing.T) {
 		ctx, cancel := context.WithTimeout(ctx, time.Second)
 		t.Cleanup(cancel)
@@ -152,6 +175,14 @@ func TestShrexGetter(t *testing.T) {
 
 		_, err := getter.GetEDS(ctx, &dah)
 		require.ErrorIs(t, err, share.ErrNotFound)
+
+		// the peer which didn't have the EDS is listed
+		var retrievalErr *RetrievalError
+		require.ErrorAs(t, err, &retrievalErr)
+		require.NotEmpty(t, retrievalErr.Attempts[protocolEDS])
+		assert.Equal(t, srvHost.ID(), retrievalErr.Attempts[protocolEDS][0].Peer)
+		assert.Equal(t, ClassNotFound, retrievalErr.Attempts[protocolEDS][0].Class)
+		assert.False(t, retrievalErr.NoProviders())
 	})
 }
 
@@ -170,6 +201,24 @@ func generateTestEDS(t *testing.T) (*rsmt2d.ExtendedDataSquare, da.DataAvailabil
 	return eds, dah, randNID
 }
 
+// redirectThreshold is the amount of namespace shares starting from which shrex/nd server used in
+// tests redirects to shrex/eds.
+const redirectThreshold = 8
+
+// generateRedirectedEDS generates EDS with a namespace fully covering the first two rows, so that
+// the namespace spans redirectThreshold shares.
+func generateRedirectedEDS(t *testing.T) (*rsmt2d.ExtendedDataSquare, da.DataAvailabilityHeader, nmtnamespace.ID) {
+	shares := share.RandShares(t, 16)
+	nID := nmtnamespace.ID(shares[0][:share.NamespaceSize])
+	for _, sh := range shares[1:redirectThreshold] {
+		copy(sh[:share.NamespaceSize], nID)
+	}
+	eds, err := rsmt2d.ComputeExtendedDataSquare(shares, share.DefaultRSMT2DCodec(), wrapper.NewConstructor(4))
+	require.NoError(t, err)
+	dah := da.NewDataAvailabilityHeader(eds)
+	return eds, dah, nID
+}
+
 func testManager(
 	ctx context.Context, host host.Host, headerSub libhead.Subscriber[*header.ExtendedHeader],
 ) (*peers.Manager, error) {
@@ -199,12 +248,12 @@ func testManager(
 }
 
 func newNDClientServer(
-	ctx context.Context, t *testing.T, edsStore *eds.Store, srvHost, clHost host.Host,
+	ctx context.Context, t *testing.T, edsStore *eds.Store, srvHost, clHost host.Host, opts ...shrexnd.Option,
 ) (*shrexnd.Client, *shrexnd.Server) {
 	params := shrexnd.DefaultParameters()
 
 	// create server and register handler
-	server, err := shrexnd.NewServer(params, srvHost, edsStore, NewStoreGetter(edsStore))
+	server, err := shrexnd.NewServer(params, srvHost, edsStore, NewStoreGetter(edsStore), opts...)
 	require.NoError(t, err)
 	require.NoError(t, server.Start(ctx))
 
@@ -2,7 +2,6 @@ package getters
 
 import (
 	"context"
-	"encoding/hex"
 	"errors"
 	"fmt"
 
@@ -38,14 +37,32 @@ func NewStoreGetter(store *eds.Store) *StoreGetter {
 func (sg *StoreGetter) GetShare(ctx context.Context, dah *share.Root, row, col int) (share.Share, error) {
 	var err error
 	ctx, span := tracer.Start(ctx, "store/get-share", trace.WithAttributes(
-		attribute.String("root", dah.String()),
-		attribute.Int("row", row),
-		attribute.Int("col", col),
+		share.TraceAttributes(ctx, dah.Hash(), nil,
+			attribute.Int("row", row),
+			attribute.Int("col", col),
+		)...,
 	))
 	defer func() {
 		utils.SetStatusAndEnd(span, err)
 	}()
 
+	if row < len(dah.RowRoots)/2 && col < len(dah.ColumnRoots)/2 {
+		// shares of the ODS are read straight from the mapped CAR file, if it can be mapped
+		var m *eds.MappedODS
+		m, err = sg.store.MappedODS(ctx, dah.Hash())
+		switch {
+		case err == nil:
+			defer m.Release()
+			recordSource(ctx, "store")
+			return copyShare(m.Share(row, col)), nil
+		case errors.Is(err, eds.ErrNotFound):
+			err = share.ErrNotFound
+			return nil, fmt.Errorf("getter/store: failed to map CAR file: %w", err)
+		case !errors.Is(err, eds.ErrMappingUnsupported):
+			return nil, fmt.Errorf("getter/store: failed to map CAR file: %w", err)
+		}
+	}
+
 	root, leaf := ipld.Translate(dah, row, col)
 	bs, err := sg.store.CARBlockstore(ctx, dah.Hash())
 	if errors.Is(err, eds.ErrNotFound) {
@@ -67,13 +84,14 @@ func (sg *StoreGetter) GetShare(ctx context.Context, dah *share.Root, row, col i
 		return nil, fmt.Errorf("getter/store: failed to retrieve share: %w", err)
 	}
 
+	recordSource(ctx, "store")
 	return s, nil
 }
 
 // GetEDS gets the EDS identified by the given root from the EDS store.
 func (sg *StoreGetter) GetEDS(ctx context.Context, root *share.Root) (data *rsmt2d.ExtendedDataSquare, err error) {
 	ctx, span := tracer.Start(ctx, "store/get-eds", trace.WithAttributes(
-		attribute.String("root", root.String()),
+		share.TraceAttributes(ctx, root.Hash(), nil)...,
 	))
 	defer func() {
 		utils.SetStatusAndEnd(span, err)
@@ -90,16 +108,39 @@ func (sg *StoreGetter) GetEDS(ctx context.Context, root *share.Root) (data *rsmt
 	return data, nil
 }
 
-// GetSharesByNamespace gets all EDS shares in the given namespace from the EDS store through the
-// corresponding CAR-level blockstore.
+// GetSharesByNamespace gets all EDS shares in the given namespace from the EDS store, reading them
+// from the memory-mapped CAR file or, if it can't be mapped, through the corresponding CAR-level
+// blockstore.
 func (sg *StoreGetter) GetSharesByNamespace(
 	ctx context.Context,
 	root *share.Root,
 	nID namespace.ID,
-) (shares share.NamespacedShares, err error) {
+) (share.NamespacedShares, error) {
+	shares, release, err := sg.GetSharesByNamespaceMapped(ctx, root, nID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// shares must outlive the mapping
+	for _, row := range shares {
+		for i := range row.Shares {
+			row.Shares[i] = copyShare(row.Shares[i])
+		}
+	}
+	return shares, nil
+}
+
+// GetSharesByNamespaceMapped is like GetSharesByNamespace, but the returned shares may reference
+// the memory-mapped CAR file of the EDS, saving their copies. The shares are read-only and valid
+// until the returned function is called, which must be done once they are not used anymore.
+func (sg *StoreGetter) GetSharesByNamespaceMapped(
+	ctx context.Context,
+	root *share.Root,
+	nID namespace.ID,
+) (shares share.NamespacedShares, release func(), err error) {
 	ctx, span := tracer.Start(ctx, "store/get-shares-by-namespace", trace.WithAttributes(
-		attribute.String("root", root.String()),
-		attribute.String("nid", hex.EncodeToString(nID)),
+		share.TraceAttributes(ctx, root.Hash(), nID)...,
 	))
 	defer func() {
 		utils.SetStatusAndEnd(span, err)
@@ -107,7 +148,7 @@ func (sg *StoreGetter) GetSharesByNamespace(
 
 	err = verifyNIDSize(nID)
 	if err != nil {
-		return nil, fmt.Errorf("getter/store: invalid namespace ID: %w", err)
+		return nil, nil, fmt.Errorf("getter/store: invalid namespace ID: %w", err)
 	}
 
 	bs, err := sg.store.CARBlockstore(ctx, root.Hash())
@@ -116,19 +157,44 @@ func (sg *StoreGetter) GetSharesByNamespace(
 		err = share.ErrNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("getter/store: failed to retrieve blockstore: %w", err)
+		return nil, nil, fmt.Errorf("getter/store: failed to retrieve blockstore: %w", err)
 	}
-
 	// wrap the read-only CAR blockstore in a getter
 	blockGetter := eds.NewBlockGetter(bs)
+
+	// shares are read straight from the mapped CAR file, if it can be mapped, while their proofs are
+	// read from the stored inner nodes
+	m, err := sg.store.MappedODS(ctx, root.Hash())
+	switch {
+	case err == nil:
+		shares, err = mappedSharesByNamespace(ctx, blockGetter, m, root, nID)
+		if err == nil {
+			return shares, m.Release, nil
+		}
+		m.Release()
+		if !errors.Is(err, eds.ErrMappingUnsupported) {
+			if errors.Is(err, ipld.ErrNodeNotFound) {
+				// convert error to satisfy getter interface contract
+				err = share.ErrNotFound
+			}
+			return nil, nil, fmt.Errorf("getter/store: failed to retrieve shares by namespace: %w", err)
+		}
+	case errors.Is(err, eds.ErrNotFound):
+		// convert error to satisfy getter interface contract
+		err = share.ErrNotFound
+		return nil, nil, fmt.Errorf("getter/store: failed to map CAR file: %w", err)
+	case !errors.Is(err, eds.ErrMappingUnsupported):
+		return nil, nil, fmt.Errorf("getter/store: failed to map CAR file: %w", err)
+	}
+
 	shares, err = collectSharesByNamespace(ctx, blockGetter, root, nID)
 	if errors.Is(err, ipld.ErrNodeNotFound) {
 		// convert error to satisfy getter interface contract
 		err = share.ErrNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("getter/store: failed to retrieve shares by namespace: %w", err)
+		return nil, nil, fmt.Errorf("getter/store: failed to retrieve shares by namespace: %w", err)
 	}
 
-	return shares, nil
+	return shares, func() {}, nil
 }
@@ -2,7 +2,6 @@ package getters
 
 import (
 	"context"
-	"encoding/hex"
 	"errors"
 	"fmt"
 
@@ -16,10 +15,21 @@ import (
 	"github.com/celestiaorg/celestia-node/libs/utils"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/p2p/peers"
 )
 
 var _ share.Getter = (*TeeGetter)(nil)
 
+// lazyValidationKey is the context key of the lazyValidation.
+var lazyValidationKey = &lazyValidation{}
+
+// lazyValidation is passed by context from the TeeGetter to the wrapped getter. The wrapped getter
+// may return an EDS of a trusted peer without validating it, in which case it sets setStatus. The
+// TeeGetter then validates the EDS once it is stored and reports the result of the peer to setStatus.
+type lazyValidation struct {
+	setStatus peers.DoneFunc
+}
+
 // TeeGetter is a share.Getter that wraps a getter and stores the results of GetEDS into an
 // eds.Store.
 type TeeGetter struct {
@@ -35,11 +45,12 @@ func NewTeeGetter(getter share.Getter, store *eds.Store) *TeeGetter {
 	}
 }
 
-func (tg *TeeGetter) GetShare(ctx context.Context, root *share.Root, row, col int) (share share.Share, err error) {
+func (tg *TeeGetter) GetShare(ctx context.Context, root *share.Root, row, col int) (shr share.Share, err error) {
 	ctx, span := tracer.Start(ctx, "tee/get-share", trace.WithAttributes(
-		attribute.String("root", root.String()),
-		attribute.Int("row", row),
-		attribute.Int("col", col),
+		share.TraceAttributes(ctx, root.Hash(), nil,
+			attribute.Int("row", row),
+			attribute.Int("col", col),
+		)...,
 	))
 	defer func() {
 		utils.SetStatusAndEnd(span, err)
@@ -50,23 +61,82 @@ func (tg *TeeGetter) GetShare(ctx context.Context, root *share.Root, row, col in
 
 func (tg *TeeGetter) GetEDS(ctx context.Context, root *share.Root) (eds *rsmt2d.ExtendedDataSquare, err error) {
 	ctx, span := tracer.Start(ctx, "tee/get-eds", trace.WithAttributes(
-		attribute.String("root", root.String()),
+		share.TraceAttributes(ctx, root.Hash(), nil)...,
 	))
 	defer func() {
 		utils.SetStatusAndEnd(span, err)
 	}()
 
-	eds, err = tg.getter.GetEDS(ctx, root)
+	// allow the wrapped getter to return the EDS of a trusted peer before validating it
+	lv := &lazyValidation{}
+	eds, stored, err := tg.getEDS(context.WithValue(ctx, lazyValidationKey, lv), root)
 	if err != nil {
+		if lv.setStatus != nil {
+			lv.setStatus(peers.ResultNoop)
+		}
 		return nil, err
 	}
+	if lv.setStatus == nil {
+		return eds, nil
+	}
+
+	// storing the EDS computes its roots, so validating it right away is cheap. Validating it once
+	// returned would race with the caller on the roots cached by the EDS.
+	err = tg.validate(ctx, root, eds, stored, lv.setStatus)
+	if err == nil {
+		return eds, nil
+	}
+	log.Errorw("getter/tee: lazily validated eds is invalid, fetching it again",
+		"root", root.String(),
+		"err", err,
+	)
+	// the peer is blacklisted, so the EDS is fetched and validated from another source
+	eds, _, err = tg.getEDS(ctx, root)
+	return eds, err
+}
+
+// getEDS gets the EDS from the wrapped getter and stores it. It reports whether the EDS was stored,
+// as opposed to being stored before.
+func (tg *TeeGetter) getEDS(ctx context.Context, root *share.Root) (*rsmt2d.ExtendedDataSquare, bool, error) {
+	eds, err := tg.getter.GetEDS(ctx, root)
+	if err != nil {
+		return nil, false, err
+	}
 
 	err = tg.store.Put(ctx, root.Hash(), eds)
-	if err != nil && !errors.Is(err, dagstore.ErrShardExists) {
-		return nil, fmt.Errorf("getter/tee: failed to store eds: %w", err)
+	if errors.Is(err, dagstore.ErrShardExists) {
+		return eds, false, nil
 	}
+	if err != nil {
+		return nil, false, fmt.Errorf("getter/tee: failed to store eds: %w", err)
+	}
+	return eds, true, nil
+}
 
-	return eds, nil
+// validate validates the lazily accepted EDS against the root after it was stored and reports the
+// result of its peer. An invalid EDS is removed from the store and its peer is blacklisted.
+func (tg *TeeGetter) validate(
+	ctx context.Context,
+	root *share.Root,
+	square *rsmt2d.ExtendedDataSquare,
+	stored bool,
+	setStatus peers.DoneFunc,
+) error {
+	err := share.ValidateAgainstRoot(square, root)
+	if err == nil {
+		setStatus(peers.ResultSynced)
+		return nil
+	}
+
+	setStatus(peers.ResultBlacklistPeer)
+	// an EDS stored before by other means must not be removed
+	if !stored {
+		return err
+	}
+	if rerr := tg.store.Remove(ctx, root.Hash()); rerr != nil {
+		log.Errorw("getter/tee: failed to remove invalid eds", "root", root.String(), "err", rerr)
+	}
+	return err
 }
 
 func (tg *TeeGetter) GetSharesByNamespace(
@@ -75,8 +145,7 @@ func (tg *TeeGetter) GetSharesByNamespace(
 	id namespace.ID,
 ) (shares share.NamespacedShares, err error) {
 	ctx, span := tracer.Start(ctx, "tee/get-shares-by-namespace", trace.WithAttributes(
-		attribute.String("root", root.String()),
-		attribute.String("nid", hex.EncodeToString(id)),
+		share.TraceAttributes(ctx, root.Hash(), id)...,
 	))
 	defer func() {
 		utils.SetStatusAndEnd(span, err)
@@ -1,24 +1,30 @@
 package getters
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ipfs/go-blockservice"
 	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log/v2"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 
+	appns "github.com/celestiaorg/celestia-app/pkg/namespace"
+	"github.com/celestiaorg/nmt"
 	"github.com/celestiaorg/nmt/namespace"
 
+	"github.com/celestiaorg/celestia-node/libs/bufpool"
 	"github.com/celestiaorg/celestia-node/libs/utils"
 	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/ipld"
 )
 
@@ -32,17 +38,29 @@ var (
 // filterRootsByNamespace returns the row roots from the given share.Root that contain the passed
 // namespace ID.
 func filterRootsByNamespace(root *share.Root, nID namespace.ID) []cid.Cid {
-	rowRootCIDs := make([]cid.Cid, 0, len(root.RowRoots))
-	for _, row := range root.RowRoots {
+	rows := filterRowsByNamespace(root, nID)
+	rowRootCIDs := make([]cid.Cid, len(rows))
+	for i, row := range rows {
+		rowRootCIDs[i] = ipld.MustCidFromNamespacedSha256(root.RowRoots[row])
+	}
+	return rowRootCIDs
+}
+
+// filterRowsByNamespace returns the indexes of the rows from the given share.Root that contain the
+// passed namespace ID.
+func filterRowsByNamespace(root *share.Root, nID namespace.ID) []int {
+	rows := make([]int, 0, len(root.RowRoots))
+	for i, row := range root.RowRoots {
 		if !ipld.NamespaceIsOutsideRange(row, row, nID) {
-			rowRootCIDs = append(rowRootCIDs, ipld.MustCidFromNamespacedSha256(row))
+			rows = append(rows, i)
 		}
 	}
-	return rowRootCIDs
+	return rows
 }
 
 // collectSharesByNamespace collects NamespaceShares within the given namespace ID from the given
-// share.Root.
+// share.Root. Rows that can't be retrieved through their row trees are recovered from the column
+// trees, see sharesByNamespaceFromCols.
 func collectSharesByNamespace(
 	ctx context.Context,
 	bg blockservice.BlockGetter,
@@ -50,25 +68,37 @@ func collectSharesByNamespace(
 	nID namespace.ID,
 ) (shares share.NamespacedShares, err error) {
 	ctx, span := tracer.Start(ctx, "collect-shares-by-namespace", trace.WithAttributes(
-		attribute.String("root", root.String()),
-		attribute.String("nid", hex.EncodeToString(nID)),
+		share.TraceAttributes(ctx, root.Hash(), nID)...,
 	))
 	defer func() {
 		utils.SetStatusAndEnd(span, err)
 	}()
 
-	rootCIDs := filterRootsByNamespace(root, nID)
-	if len(rootCIDs) == 0 {
+	rows := filterRowsByNamespace(root, nID)
+	if len(rows) == 0 {
 		return nil, share.ErrNamespaceNotFound
 	}
 
 	errGroup, ctx := errgroup.WithContext(ctx)
-	shares = make([]share.NamespacedRow, len(rootCIDs))
-	for i, rootCID := range rootCIDs {
+	shares = make([]share.NamespacedRow, len(rows))
+	for i, rowIdx := range rows {
 		// shadow loop variables, to ensure correct values are captured
-		i, rootCID := i, rootCID
+		i, rowIdx := i, rowIdx
 		errGroup.Go(func() error {
+			rootCID := ipld.MustCidFromNamespacedSha256(root.RowRoots[rowIdx])
 			row, proof, err := share.GetSharesByNamespace(ctx, bg, rootCID, nID, len(root.RowRoots))
+			if err != nil && ctx.Err() == nil {
+				log.Debugw("retrieving row by namespace failed, falling back to columns",
+					"row", rowIdx,
+					"nid", hex.EncodeToString(nID),
+					"err", err)
+				nsRow, colErr := sharesByNamespaceFromCols(ctx, bg, root, rowIdx, nID)
+				if colErr == nil {
+					shares[i] = nsRow
+					return nil
+				}
+				err = errors.Join(err, colErr)
+			}
 			shares[i] = share.NamespacedRow{
 				Shares: row,
 				Proof:  proof,
@@ -85,13 +115,218 @@ func collectSharesByNamespace(
 	}
 
 	// return ErrNamespaceNotFound if no shares are found for the namespace.ID
-	if len(rootCIDs) == 1 && len(shares[0].Shares) == 0 {
+	if len(rows) == 1 && len(shares[0].Shares) == 0 {
 		return nil, share.ErrNamespaceNotFound
 	}
 
 	return shares, nil
 }
 
+// mappedSharesByNamespace collects NamespaceShares within the given namespace ID from the rows of
+// the memory-mapped ODS, referencing the mapping. The proofs of the shares are built from the inner
+// nodes of the row trees stored in the CAR file, which are read through the given block getter. It
+// returns eds.ErrMappingUnsupported if the namespace is within shares outside the ODS.
+func mappedSharesByNamespace(
+	ctx context.Context,
+	bg blockservice.BlockGetter,
+	m *eds.MappedODS,
+	root *share.Root,
+	nID namespace.ID,
+) (shares share.NamespacedShares, err error) {
+	ctx, span := tracer.Start(ctx, "mapped-shares-by-namespace", trace.WithAttributes(
+		share.TraceAttributes(ctx, root.Hash(), nID)...,
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	for i, row := range root.RowRoots {
+		if ipld.NamespaceIsOutsideRange(row, row, nID) {
+			continue
+		}
+		if i >= m.Width() {
+			return nil, eds.ErrMappingUnsupported
+		}
+
+		nsRow, err := mappedRowByNamespace(ctx, bg, m, i, row, nID)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving nID %x for row %d: %w", nID, i, err)
+		}
+		shares = append(shares, nsRow)
+	}
+
+	if len(shares) == 0 || len(shares) == 1 && len(shares[0].Shares) == 0 {
+		return nil, share.ErrNamespaceNotFound
+	}
+	return shares, nil
+}
+
+// mappedRowByNamespace proves the shares of the given row of the mapped ODS within the namespace
+// ID from the stored inner nodes of the row tree, and slices the proven shares out of the mapping.
+func mappedRowByNamespace(
+	ctx context.Context,
+	bg blockservice.BlockGetter,
+	m *eds.MappedODS,
+	rowIdx int,
+	rowRoot []byte,
+	nID namespace.ID,
+) (share.NamespacedRow, error) {
+	// only proofs are collected, so the leaves are not read from the CAR file
+	data := ipld.NewNamespaceData(2*m.Width(), nID, ipld.WithProofs())
+	err := data.CollectLeavesByNamespace(ctx, bg, ipld.MustCidFromNamespacedSha256(rowRoot))
+	if err != nil {
+		return share.NamespacedRow{}, err
+	}
+
+	proof := data.Proof()
+	if proof.IsOfAbsence() {
+		// like the blockstore path, absence proofs come with empty shares in place of the proven leaf
+		return share.NamespacedRow{
+			Shares: make([]share.Share, proof.End()-proof.Start()),
+			Proof:  proof,
+		}, nil
+	}
+	if proof.End() > m.Width() {
+		return share.NamespacedRow{}, eds.ErrMappingUnsupported
+	}
+
+	shares := make([]share.Share, proof.End()-proof.Start())
+	for i := range shares {
+		shares[i] = m.Share(rowIdx, proof.Start()+i)
+	}
+	return share.NamespacedRow{Shares: shares, Proof: proof}, nil
+}
+
+// sharesByNamespaceFromCols recovers the given row from the column trees, when its row tree is
+// not available, and collects its shares within the namespace ID. The shares of the row are
+// retrieved as leaves of their columns, and the ones that are not available are reconstructed from
+// the others, as long as half of the row is available. The recovered row is checked against its
+// row root, and its shares are proven against it.
+func sharesByNamespaceFromCols(
+	ctx context.Context,
+	bg blockservice.BlockGetter,
+	root *share.Root,
+	rowIdx int,
+	nID namespace.ID,
+) (share.NamespacedRow, error) {
+	row, err := rowFromCols(ctx, bg, root, rowIdx)
+	if err != nil {
+		return share.NamespacedRow{}, err
+	}
+	return extendedRowByNamespace(row, rowIdx, root.RowRoots[rowIdx], nID)
+}
+
+// rowFromCols retrieves the shares of the given row from the column trees until half of the row
+// is retrieved, and reconstructs the rest of the row.
+func rowFromCols(
+	ctx context.Context,
+	bg blockservice.BlockGetter,
+	root *share.Root,
+	rowIdx int,
+) ([]share.Share, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	width := len(root.ColumnRoots)
+	var (
+		wg        sync.WaitGroup
+		lk        sync.Mutex
+		retrieved int
+	)
+	row := make([]share.Share, width)
+	for colIdx, col := range root.ColumnRoots {
+		colIdx, colCID := colIdx, ipld.MustCidFromNamespacedSha256(col)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			shr, err := share.GetShare(ctx, bg, colCID, rowIdx, width)
+			if err != nil {
+				return
+			}
+
+			lk.Lock()
+			defer lk.Unlock()
+			if retrieved == width/2 {
+				return
+			}
+			row[colIdx] = shr
+			retrieved++
+			if retrieved == width/2 {
+				// the rest of the row is reconstructed, so the pending retrievals are not needed
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if retrieved < width/2 {
+		return nil, fmt.Errorf("recovering row %d from columns: only %d of %d required shares available",
+			rowIdx, retrieved, width/2)
+	}
+	row, err := share.DefaultRSMT2DCodec().Decode(row)
+	if err != nil {
+		return nil, fmt.Errorf("recovering row %d from columns: %w", rowIdx, err)
+	}
+	return row, nil
+}
+
+// extendedRowByNamespace collects the shares of the given extended row within the namespace ID and
+// proves them against the row root.
+func extendedRowByNamespace(
+	row []share.Share,
+	rowIdx int,
+	rowRoot []byte,
+	nID namespace.ID,
+) (share.NamespacedRow, error) {
+	odsWidth := len(row) / 2
+	// the tree keeps the pushed leaves until it is dropped, so they are namespaced in a borrowed
+	// buffer given back once the proof is built, as neither the proof nor the shares reference them
+	leafSize := share.NamespaceSize + share.Size
+	buf := bufpool.Get(len(row) * leafSize)
+	defer buf.Release()
+	tree := nmt.New(
+		sha256.New(),
+		nmt.NamespaceIDSize(share.NamespaceSize),
+		nmt.IgnoreMaxNamespace(ipld.NMTIgnoreMaxNamespace),
+	)
+	for i, shr := range row {
+		ns := shr[:share.NamespaceSize]
+		if i >= odsWidth || rowIdx >= odsWidth {
+			ns = appns.ParitySharesNamespace.Bytes()
+		}
+		leaf := buf.Bytes[i*leafSize : i*leafSize : (i+1)*leafSize]
+		if err := tree.Push(append(append(leaf, ns...), shr...)); err != nil {
+			return share.NamespacedRow{}, fmt.Errorf("building row tree: %w", err)
+		}
+	}
+	treeRoot, err := tree.Root()
+	if err != nil {
+		return share.NamespacedRow{}, fmt.Errorf("building row tree: %w", err)
+	}
+	if !bytes.Equal(treeRoot, rowRoot) {
+		return share.NamespacedRow{}, fmt.Errorf("content integrity mismatch of row %d", rowIdx)
+	}
+
+	proof, err := tree.ProveNamespace(nID)
+	if err != nil {
+		return share.NamespacedRow{}, fmt.Errorf("proving namespace: %w", err)
+	}
+	nsRow := share.NamespacedRow{
+		Shares: row[proof.Start():proof.End()],
+		Proof:  &proof,
+	}
+	if proof.IsOfAbsence() {
+		// like the blockstore path, absence proofs come with empty shares in place of the proven leaf
+		nsRow.Shares = make([]share.Share, proof.End()-proof.Start())
+	}
+	return nsRow, nil
+}
+
+// copyShare copies the share, so that it outlives the mapping it references.
+func copyShare(shr share.Share) share.Share {
+	return append(make(share.Share, 0, len(shr)), shr...)
+}
+
 func verifyNIDSize(nID namespace.ID) error {
 	if len(nID) != share.NamespaceSize {
 		return fmt.Errorf("expected namespace ID of size %d, got %d",
@@ -139,3 +374,45 @@ func ErrorContains(err, target error) bool {
 	}
 	return ErrorContains(err, target)
 }
+
+// sourceKey is the context key of the sourceRecorder.
+var sourceKey = &sourceRecorder{}
+
+// sourceRecorder is an optional struct passed by context to the share.Getter methods using
+// WithSourceRecorder to learn which getter served the request.
+type sourceRecorder struct {
+	lk     sync.Mutex
+	source string
+}
+
+// WithSourceRecorder stores an empty source recorder in the context, which is filled with the name
+// of the share.Getter that successfully served a request made with the context.
+func WithSourceRecorder(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sourceKey, &sourceRecorder{})
+}
+
+// Source returns the name of the share.Getter that served a request made with the context. It is
+// empty if the request did not succeed or the context holds no source recorder.
+func Source(ctx context.Context) string {
+	r, ok := ctx.Value(sourceKey).(*sourceRecorder)
+	if !ok {
+		return ""
+	}
+
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	return r.source
+}
+
+// recordSource records the name of the share.Getter that served the request, if the context holds
+// a source recorder.
+func recordSource(ctx context.Context, source string) {
+	r, ok := ctx.Value(sourceKey).(*sourceRecorder)
+	if !ok {
+		return
+	}
+
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	r.source = source
+}
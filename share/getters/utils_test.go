@@ -1,13 +1,69 @@
 package getters
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	bsrv "github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/ipld"
 )
 
+func TestCollectSharesByNamespace_ColumnFallback(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	bs := blockstore.NewBlockstore(ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	bserv := bsrv.New(bs, offline.Exchange(bs))
+
+	eds, nID, dah := randomEDSWithDoubledNamespace(t, 4)
+	_, err := share.ImportShares(ctx, eds.Flattened(), bserv)
+	require.NoError(t, err)
+
+	expected, err := collectSharesByNamespace(ctx, bserv, &dah, nID)
+	require.NoError(t, err)
+	require.Len(t, expected, 2)
+
+	// the namespace spans rows 1 and 2
+	const rowIdx = 1
+	width := len(dah.RowRoots)
+	rowCID := ipld.MustCidFromNamespacedSha256(dah.RowRoots[rowIdx])
+	leafCIDs := make([]cid.Cid, width)
+	for i := range leafCIDs {
+		leaf, err := ipld.GetLeaf(ctx, bserv, rowCID, i, width)
+		require.NoError(t, err)
+		leafCIDs[i] = leaf.Cid()
+	}
+
+	// without the row root and some of the leaves, the row is recovered from the columns
+	require.NoError(t, bs.DeleteBlock(ctx, rowCID))
+	for _, col := range []int{1, 5, 6} {
+		require.NoError(t, bs.DeleteBlock(ctx, leafCIDs[col]))
+	}
+	shares, err := collectSharesByNamespace(ctx, bserv, &dah, nID)
+	require.NoError(t, err)
+	assert.Equal(t, expected, shares)
+	require.NoError(t, shares.Verify(&dah, nID))
+
+	// less than half of the row can't be recovered
+	for _, col := range []int{0, 2} {
+		require.NoError(t, bs.DeleteBlock(ctx, leafCIDs[col]))
+	}
+	_, err = collectSharesByNamespace(ctx, bserv, &dah, nID)
+	require.Error(t, err)
+}
+
 func Test_ErrorContains(t *testing.T) {
 	err1 := errors.New("1")
 	err2 := errors.New("2")
@@ -97,6 +97,17 @@ func GetLeaves(ctx context.Context,
 	root cid.Cid,
 	maxShares int,
 	put func(int, ipld.Node),
+) {
+	VisitLeaves(ctx, bGetter, root, maxShares, LeafVisitor(put))
+}
+
+// VisitLeaves traverses the whole tree the same way GetLeaves does, notifying the given Visitor
+// about every retrieved inner node and leaf.
+func VisitLeaves(ctx context.Context,
+	bGetter blockservice.BlockGetter,
+	root cid.Cid,
+	maxShares int,
+	visitor Visitor,
 ) {
 	ctx, span := tracer.Start(ctx, "get-leaves")
 	defer span.End()
@@ -141,9 +152,10 @@ func GetLeaves(ctx context.Context,
 					// successfully fetched a share/leaf
 					// ladies and gentlemen, we got em!
 					span.SetStatus(codes.Ok, "")
-					put(j.sharePos, nd)
+					visitor.OnLeaf(j.sharePos, nd)
 					return
 				}
+				visitor.OnInnerNode(j.depth, j.sharePos, nd)
 				// ok, we found more links
 				for i, lnk := range lnks {
 					// send those to be processed
@@ -153,6 +165,7 @@ func GetLeaves(ctx context.Context,
 						// calc position for children nodes (bin-tree-feat),
 						// s.t. 'if' above knows where to put a share
 						sharePos: j.sharePos*2 + i,
+						depth:    j.depth + 1,
 						// we pass the context to job so that spans are tracked in a tree
 						// structure
 						ctx: ctx,
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/bits"
 	"sync"
 	"sync/atomic"
 
@@ -43,15 +44,19 @@ func WithProofs() Option {
 
 // NamespaceData stores all leaves under the given namespace with their corresponding proofs.
 type NamespaceData struct {
-	leaves []ipld.Node
-	proofs *proofCollector
+	leaves  []ipld.Node
+	proofs  *proofCollector
+	visitor Visitor
 
 	bounds    fetchedBounds
 	maxShares int
+	// leafDepth is the depth of the leaves of the tree, if maxShares is a power of two
+	leafDepth int
 	nID       namespace.ID
 
 	isAbsentNamespace atomic.Bool
-	absenceProofLeaf  ipld.Node
+	// absenceProofLeaf is the hash of the leaf proving the absence of the namespace
+	absenceProofLeaf []byte
 }
 
 func NewNamespaceData(maxShares int, nID namespace.ID, options ...Option) *NamespaceData {
@@ -61,8 +66,12 @@ func NewNamespaceData(maxShares int, nID namespace.ID, options ...Option) *Names
 		// maxShares acts as a sentinel to know if we find any leaves
 		bounds:    fetchedBounds{int64(maxShares), 0},
 		maxShares: maxShares,
+		leafDepth: -1,
 		nID:       nID,
 	}
+	if maxShares > 0 && maxShares&(maxShares-1) == 0 {
+		data.leafDepth = bits.Len(uint(maxShares)) - 1
+	}
 
 	for _, opt := range options {
 		opt(data)
@@ -75,7 +84,7 @@ func (n *NamespaceData) validate(rootCid cid.Cid) error {
 		return fmt.Errorf("expected namespace ID of size %d, got %d", NamespaceSize, len(n.nID))
 	}
 
-	if n.leaves == nil && n.proofs == nil {
+	if n.leaves == nil && n.proofs == nil && n.visitor == nil {
 		return errors.New("share/ipld: empty NamespaceData, nothing specified to retrieve")
 	}
 
@@ -89,12 +98,14 @@ func (n *NamespaceData) validate(rootCid cid.Cid) error {
 func (n *NamespaceData) addLeaf(pos int, nd ipld.Node) {
 	// bounds will be needed in `Proof` method
 	n.bounds.update(int64(pos))
+	if n.visitor != nil && nd != nil {
+		n.visitor.OnLeaf(pos, nd)
+	}
 
 	if n.isAbsentNamespace.Load() {
-		if n.absenceProofLeaf != nil {
-			log.Fatal("there should be only one absence leaf")
+		if nd != nil {
+			n.addAbsenceLeaf(nd.Cid())
 		}
-		n.absenceProofLeaf = nd
 		return
 	}
 
@@ -107,6 +118,30 @@ func (n *NamespaceData) addLeaf(pos int, nd ipld.Node) {
 	}
 }
 
+// addAbsenceLeaf collects the leaf with the given CID as the one proving the absence of the
+// namespace.
+func (n *NamespaceData) addAbsenceLeaf(leaf cid.Cid) {
+	if n.absenceProofLeaf != nil {
+		log.Fatal("there should be only one absence leaf")
+	}
+	n.absenceProofLeaf = NamespacedSha256FromCID(leaf)
+}
+
+// skipsLeaves reports whether leaves are not to be retrieved, as neither them nor their data are
+// collected. Proofs are made of inner nodes and the hashes of leaves, which are known from the links
+// to them.
+func (n *NamespaceData) skipsLeaves() bool {
+	return n.leaves == nil && n.visitor == nil && n.leafDepth >= 0
+}
+
+// skipLeaf accounts for the leaf of the job without retrieving it.
+func (n *NamespaceData) skipLeaf(j job) {
+	n.bounds.update(int64(j.sharePos))
+	if n.isAbsentNamespace.Load() {
+		n.addAbsenceLeaf(j.cid)
+	}
+}
+
 // noLeaves checks that there are no leaves under the given root in the given namespace.
 func (n *NamespaceData) noLeaves() bool {
 	return n.bounds.lowest == int64(n.maxShares)
@@ -119,16 +154,22 @@ const (
 	right
 )
 
-func (n *NamespaceData) addProof(d direction, cid cid.Cid, depth int) {
+// addProof collects the given hash of the child of the job's node as a proof node. The hash is
+// referenced, not copied, and must not be modified afterwards.
+func (n *NamespaceData) addProof(d direction, hash []byte, j job) {
+	if n.visitor != nil {
+		child := j.next(d, cid.Undef, j.isAbsent)
+		n.visitor.OnProofNode(child.depth, child.sharePos, hash)
+	}
 	if n.proofs == nil {
 		return
 	}
 
 	switch d {
 	case left:
-		n.proofs.addLeft(cid, depth)
+		n.proofs.addLeft(hash, j.depth)
 	case right:
-		n.proofs.addRight(cid, depth)
+		n.proofs.addRight(hash, j.depth)
 	default:
 		panic(fmt.Sprintf("share/ipld: invalid direction: %d", d))
 	}
@@ -145,6 +186,7 @@ func (n *NamespaceData) Leaves() []ipld.Node {
 
 // Proof returns proofs within the bounds in case if `WithProofs` option was passed,
 // otherwise nil will be returned.
+// Nodes of the proof reference raw data of the retrieved IPLD nodes and must not be modified.
 func (n *NamespaceData) Proof() *nmt.Proof {
 	if n.proofs == nil {
 		return nil
@@ -155,17 +197,13 @@ func (n *NamespaceData) Proof() *nmt.Proof {
 		return &nmt.Proof{}
 	}
 
-	nodes := make([][]byte, len(n.proofs.Nodes()))
-	for i, node := range n.proofs.Nodes() {
-		nodes[i] = NamespacedSha256FromCID(node)
-	}
-
+	nodes := n.proofs.Nodes()
 	if n.isAbsentNamespace.Load() {
 		proof := nmt.NewAbsenceProof(
 			int(n.bounds.lowest),
 			int(n.bounds.highest)+1,
 			nodes,
-			NamespacedSha256FromCID(n.absenceProofLeaf.Cid()),
+			n.absenceProofLeaf,
 			NMTIgnoreMaxNamespace,
 		)
 		return &proof
@@ -237,6 +275,11 @@ func (n *NamespaceData) CollectLeavesByNamespace(
 				attribute.Int("pos", j.sharePos),
 			)
 
+			if j.depth == n.leafDepth && n.skipsLeaves() {
+				n.skipLeaf(j)
+				return
+			}
+
 			// if an error is likely to be returned or not depends on
 			// the underlying impl of the blockservice, currently it is not a realistic probability
 			nd, err := GetNode(ctx, bGetter, j.cid)
@@ -255,8 +298,7 @@ func (n *NamespaceData) CollectLeavesByNamespace(
 				return
 			}
 
-			links := nd.Links()
-			if len(links) == 0 {
+			if len(nd.RawData()) != innerNodeSize {
 				// successfully fetched a leaf belonging to the namespace
 				span.SetStatus(codes.Ok, "")
 				// we found a leaf, so we update the bounds
@@ -264,8 +306,11 @@ func (n *NamespaceData) CollectLeavesByNamespace(
 				return
 			}
 
+			if n.visitor != nil {
+				n.visitor.OnInnerNode(j.depth, j.sharePos, nd)
+			}
 			// this node has links in the namespace, so keep walking
-			newJobs := n.traverseLinks(j, links)
+			newJobs := n.traverseLinks(j, nd.RawData())
 			for _, j := range newJobs {
 				wg.add(1)
 				select {
@@ -278,27 +323,25 @@ func (n *NamespaceData) CollectLeavesByNamespace(
 	}
 }
 
-func (n *NamespaceData) traverseLinks(j job, links []*ipld.Link) []job {
+// traverseLinks takes the raw data of an inner node, which is a concatenation of hashes of its
+// children, and returns jobs for the children to be visited. Hashes of the children are sliced
+// directly out of the raw data, so that collecting them as proofs does not need any copies, and
+// CIDs are only built for the children that are going to be fetched.
+func (n *NamespaceData) traverseLinks(j job, raw []byte) []job {
+	leftLink, rightLink := raw[:NmtHashSize:NmtHashSize], raw[NmtHashSize:]
 	if j.isAbsent {
-		return n.collectAbsenceProofs(j, links)
+		return n.collectAbsenceProofs(j, leftLink, rightLink)
 	}
-	return n.collectNDWithProofs(j, links)
+	return n.collectNDWithProofs(j, leftLink, rightLink)
 }
 
-func (n *NamespaceData) collectAbsenceProofs(j job, links []*ipld.Link) []job {
-	leftLink := links[0].Cid
-	rightLink := links[1].Cid
+func (n *NamespaceData) collectAbsenceProofs(j job, leftLink, rightLink []byte) []job {
 	// traverse to the left node, while collecting right node as proof
-	n.addProof(right, rightLink, j.depth)
-	return []job{j.next(left, leftLink, j.isAbsent)}
+	n.addProof(right, rightLink, j)
+	return []job{j.next(left, MustCidFromNamespacedSha256(leftLink), j.isAbsent)}
 }
 
-func (n *NamespaceData) collectNDWithProofs(j job, links []*ipld.Link) []job {
-	leftCid := links[0].Cid
-	rightCid := links[1].Cid
-	leftLink := NamespacedSha256FromCID(leftCid)
-	rightLink := NamespacedSha256FromCID(rightCid)
-
+func (n *NamespaceData) collectNDWithProofs(j job, leftLink, rightLink []byte) []job {
 	var nextJobs []job
 	// check if target namespace is outside of boundaries of both links
 	if NamespaceIsOutsideRange(leftLink, rightLink, n.nID) {
@@ -307,24 +350,24 @@ func (n *NamespaceData) collectNDWithProofs(j job, links []*ipld.Link) []job {
 
 	if !NamespaceIsAboveMax(leftLink, n.nID) {
 		// namespace is within the range of left link
-		nextJobs = append(nextJobs, j.next(left, leftCid, false))
+		nextJobs = append(nextJobs, j.next(left, MustCidFromNamespacedSha256(leftLink), false))
 	} else {
 		// proof is on the left side, if the nID is on the right side of the range of left link
-		n.addProof(left, leftCid, j.depth)
+		n.addProof(left, leftLink, j)
 		if NamespaceIsBelowMin(rightLink, n.nID) {
 			// namespace is not included in either links, convert to absence collector
 			n.isAbsentNamespace.Store(true)
-			nextJobs = append(nextJobs, j.next(right, rightCid, true))
+			nextJobs = append(nextJobs, j.next(right, MustCidFromNamespacedSha256(rightLink), true))
 			return nextJobs
 		}
 	}
 
 	if !NamespaceIsBelowMin(rightLink, n.nID) {
 		// namespace is within the range of right link
-		nextJobs = append(nextJobs, j.next(right, rightCid, false))
+		nextJobs = append(nextJobs, j.next(right, MustCidFromNamespacedSha256(rightLink), false))
 	} else {
 		// proof is on the right side, if the nID is on the left side of the range of right link
-		n.addProof(right, rightCid, j.depth)
+		n.addProof(right, rightLink, j)
 	}
 	return nextJobs
 }
@@ -168,6 +168,18 @@ func MustCidFromNamespacedSha256(hash []byte) cid.Cid {
 	return cidFromHash
 }
 
+// ValidateCID checks that the CID addresses an inner or a leaf node of a Namespaced Merkle Tree.
+func ValidateCID(id cid.Cid) error {
+	prefix := id.Prefix()
+	if prefix.Codec != nmtCodec {
+		return fmt.Errorf("unsupported codec %d, expected %d", prefix.Codec, nmtCodec)
+	}
+	if prefix.MhType != sha256NamespaceFlagged || prefix.MhLength != NmtHashSize {
+		return fmt.Errorf("unsupported multihash %d of length %d", prefix.MhType, prefix.MhLength)
+	}
+	return nil
+}
+
 // Translate transforms square coordinates into IPLD NMT tree path to a leaf node.
 // It also adds randomization to evenly spread fetching from Rows and Columns.
 func Translate(dah *da.DataAvailabilityHeader, row, col int) (cid.Cid, int) {
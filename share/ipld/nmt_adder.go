@@ -2,7 +2,9 @@ package ipld
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"runtime"
 	"sync"
 
 	"github.com/ipfs/go-blockservice"
@@ -13,10 +15,19 @@ import (
 
 // NmtNodeAdder adds ipld.Nodes to the underlying ipld.Batch if it is inserted
 // into a nmt tree.
+//
+// Trees of a square are built concurrently, so nodes are spread over stripes by their CIDs, each
+// with its own Batch, instead of funneling all of them through a single Batch. Nodes are built
+// outside the locks of the stripes, and the stripes are committed concurrently.
 type NmtNodeAdder struct {
+	ctx     context.Context
+	stripes []*adderStripe
+}
+
+// adderStripe is the part of the nodes added by the NmtNodeAdder with CIDs falling into it.
+type adderStripe struct {
 	// lock protects Batch, Set and error from parallel writes / reads
 	lock   sync.Mutex
-	ctx    context.Context
 	add    *ipld.Batch
 	leaves *cid.Set
 	err    error
@@ -24,69 +35,98 @@ type NmtNodeAdder struct {
 
 // NewNmtNodeAdder returns a new NmtNodeAdder with the provided context and
 // batch. Note that the context provided should have a timeout
-// It is not thread-safe.
+// It is safe for concurrent use by the trees of a square.
 func NewNmtNodeAdder(ctx context.Context, bs blockservice.BlockService, opts ...ipld.BatchOption) *NmtNodeAdder {
+	dag := merkledag.NewDAGService(bs)
+	stripes := make([]*adderStripe, adderStripes)
+	for i := range stripes {
+		stripes[i] = &adderStripe{
+			add:    ipld.NewBatch(ctx, dag, opts...),
+			leaves: cid.NewSet(),
+		}
+	}
 	return &NmtNodeAdder{
-		add:    ipld.NewBatch(ctx, merkledag.NewDAGService(bs), opts...),
-		ctx:    ctx,
-		leaves: cid.NewSet(),
+		ctx:     ctx,
+		stripes: stripes,
 	}
 }
 
+// adderStripes is the amount of stripes of NmtNodeAdders, which is the amount of trees built at
+// once.
+var adderStripes = runtime.NumCPU()
+
 // Visit is a NodeVisitor that can be used during the creation of a new NMT to
 // create and add ipld.Nodes to the Batch while computing the root of the NMT.
 func (n *NmtNodeAdder) Visit(hash []byte, children ...[]byte) {
-	n.lock.Lock()
-	defer n.lock.Unlock()
+	n.visit(true, hash, children...)
+}
 
-	if n.err != nil {
-		return // protect from further visits if there is an error
-	}
-	id := MustCidFromNamespacedSha256(hash)
+// VisitInnerNodes is a NodeVisitor that does not store leaf nodes to the blockservice.
+func (n *NmtNodeAdder) VisitInnerNodes(hash []byte, children ...[]byte) {
+	n.visit(false, hash, children...)
+}
+
+func (n *NmtNodeAdder) visit(withLeaves bool, hash []byte, children ...[]byte) {
+	var data []byte
 	switch len(children) {
 	case 1:
-		if n.leaves.Visit(id) {
-			n.err = n.add.Add(n.ctx, newNMTNode(id, children[0]))
+		if !withLeaves {
+			return
 		}
+		data = children[0]
 	case 2:
-		n.err = n.add.Add(n.ctx, newNMTNode(id, append(children[0], children[1]...)))
+		// children are copied, as they may be referenced by the tree
+		data = make([]byte, 0, len(children[0])+len(children[1]))
+		data = append(append(data, children[0]...), children[1]...)
 	default:
 		panic("expected a binary tree")
 	}
-}
+	id := MustCidFromNamespacedSha256(hash)
+	nd := newNMTNode(id, data)
 
-// VisitInnerNodes is a NodeVisitor that does not store leaf nodes to the blockservice.
-func (n *NmtNodeAdder) VisitInnerNodes(hash []byte, children ...[]byte) {
-	n.lock.Lock()
-	defer n.lock.Unlock()
+	// the last byte of the hash spreads nodes evenly, while the same leaves of rows and columns
+	// fall into the same stripe to be deduplicated
+	stripe := n.stripes[int(hash[len(hash)-1])%len(n.stripes)]
+	stripe.lock.Lock()
+	defer stripe.lock.Unlock()
 
-	if n.err != nil {
+	if stripe.err != nil {
 		return // protect from further visits if there is an error
 	}
-
-	id := MustCidFromNamespacedSha256(hash)
-	switch len(children) {
-	case 1:
-		break
-	case 2:
-		n.err = n.add.Add(n.ctx, newNMTNode(id, append(children[0], children[1]...)))
-	default:
-		panic("expected a binary tree")
+	// leaves are shared by rows and columns, so they are added once
+	if len(children) == 1 && !stripe.leaves.Visit(id) {
+		return
 	}
+	stripe.err = stripe.add.Add(n.ctx, nd)
 }
 
 // Commit checks for errors happened during Visit and if absent commits data to inner Batch.
 func (n *NmtNodeAdder) Commit() error {
-	n.lock.Lock()
-	defer n.lock.Unlock()
+	for _, stripe := range n.stripes {
+		stripe.lock.Lock()
+		err := stripe.err
+		stripe.lock.Unlock()
+		if err != nil {
+			return fmt.Errorf("before batch commit: %w", err)
+		}
+	}
 
-	if n.err != nil {
-		return fmt.Errorf("before batch commit: %w", n.err)
+	errs := make([]error, len(n.stripes))
+	var wg sync.WaitGroup
+	for i, stripe := range n.stripes {
+		wg.Add(1)
+		go func(i int, stripe *adderStripe) {
+			defer wg.Done()
+			stripe.lock.Lock()
+			defer stripe.lock.Unlock()
+			stripe.err = stripe.add.Commit()
+			errs[i] = stripe.err
+		}(i, stripe)
 	}
+	wg.Wait()
 
-	n.err = n.add.Commit()
-	if n.err != nil {
-		return fmt.Errorf("after batch commit: %w", n.err)
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("after batch commit: %w", err)
 	}
 	return nil
 }
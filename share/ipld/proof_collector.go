@@ -2,51 +2,65 @@ package ipld
 
 import (
 	"math"
-
-	"github.com/ipfs/go-cid"
 )
 
-// proofCollector collects proof nodes' CIDs for the construction of a shares inclusion validation
-// nmt.Proof.
+// proofCollector collects hashes of proof nodes for the construction of a shares inclusion
+// validation nmt.Proof.
+//
+// The collector does not copy the hashes it is given. They are expected to be slices of raw data
+// of fetched IPLD nodes, which is immutable, so it is safe to reference it until the proof is
+// built and beyond.
 type proofCollector struct {
-	left, right []cid.Cid
+	left, right [][]byte
 }
 
 func newProofCollector(maxShares int) *proofCollector {
 	// maximum possible amount of required proofs from each side is equal to tree height.
 	height := int(math.Log2(float64(maxShares))) + 1
+	// both sides share one backing array to save an allocation
+	sides := make([][]byte, 2*height)
 	return &proofCollector{
-		left:  make([]cid.Cid, height),
-		right: make([]cid.Cid, height),
+		left:  sides[:height:height],
+		right: sides[height:],
 	}
 }
 
-func (c *proofCollector) addLeft(cid cid.Cid, depth int) {
-	c.left[depth] = cid
+func (c *proofCollector) addLeft(hash []byte, depth int) {
+	c.left[depth] = hash
 }
 
-func (c *proofCollector) addRight(cid cid.Cid, depth int) {
-	c.right[depth] = cid
+func (c *proofCollector) addRight(hash []byte, depth int) {
+	c.right[depth] = hash
 }
 
 // Nodes returns nodes collected by proofCollector in the order that nmt.Proof validator will use
-// to traverse the tree.
-func (c *proofCollector) Nodes() []cid.Cid {
-	cids := make([]cid.Cid, 0, len(c.left)+len(c.right))
+// to traverse the tree. The returned hashes are not copied and must not be modified.
+func (c *proofCollector) Nodes() [][]byte {
+	var amount int
+	for i := range c.left {
+		if c.left[i] != nil {
+			amount++
+		}
+		if c.right[i] != nil {
+			amount++
+		}
+	}
+
+	nodes := make([][]byte, 0, amount)
 	// left side will be traversed in bottom-up order
-	for _, cid := range c.left {
-		if cid.Defined() {
-			cids = append(cids, cid)
+	for _, hash := range c.left {
+		if hash != nil {
+			nodes = append(nodes, hash)
 		}
 	}
 
 	// right side of the tree will be traversed from top to bottom,
 	// so sort in reversed order
 	for i := len(c.right) - 1; i >= 0; i-- {
-		cid := c.right[i]
-		if cid.Defined() {
-			cids = append(cids, cid)
+		hash := c.right[i]
+		if hash != nil {
+			nodes = append(nodes, hash)
 		}
 	}
-	return cids
+	return nodes
 }
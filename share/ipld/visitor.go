@@ -0,0 +1,43 @@
+package ipld
+
+import (
+	ipld "github.com/ipfs/go-ipld-format"
+)
+
+// Visitor is notified about the nodes of an NMT tree encountered during its traversal by
+// GetLeaves, VisitLeaves or NamespaceData.CollectLeavesByNamespace. It allows computing
+// statistics, e.g. the shape of the tree or the distribution of namespaces, or building custom
+// indexes without reimplementing the traversal.
+//
+// Nodes are identified by their depth, the number of edges from the root, and their position
+// among the nodes of the same depth, counting from the left. As the traversal is concurrent,
+// methods are called from multiple goroutines and in no particular order, so implementations must
+// be safe for concurrent use. Nodes passed to the Visitor must not be modified.
+type Visitor interface {
+	// OnInnerNode is called for every retrieved inner node of the tree.
+	OnInnerNode(depth, pos int, nd ipld.Node)
+	// OnLeaf is called for every retrieved leaf of the tree. Namespace traversal also reports the
+	// leaf proving the absence of the namespace, if the namespace is not in the tree.
+	OnLeaf(pos int, nd ipld.Node)
+	// OnProofNode is called for every node of the tree collected as a proof, without being
+	// retrieved, during namespace traversal. The hash is referenced, not copied.
+	OnProofNode(depth, pos int, hash []byte)
+}
+
+// LeafVisitor is a Visitor which is only interested in leaves.
+type LeafVisitor func(pos int, nd ipld.Node)
+
+func (f LeafVisitor) OnInnerNode(int, int, ipld.Node) {}
+
+func (f LeafVisitor) OnLeaf(pos int, nd ipld.Node) {
+	f(pos, nd)
+}
+
+func (f LeafVisitor) OnProofNode(int, int, []byte) {}
+
+// WithVisitor option specifies the Visitor to be notified about the traversed nodes.
+func WithVisitor(v Visitor) Option {
+	return func(data *NamespaceData) {
+		data.visitor = v
+	}
+}
@@ -0,0 +1,41 @@
+package attestsub
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/celestiaorg/celestia-node/share"
+	pb "github.com/celestiaorg/celestia-node/share/p2p/attestsub/pb"
+)
+
+// Attestation is a result of sampling a header by a peer.
+type Attestation struct {
+	Height   uint64
+	DataHash share.DataHash
+	// Available is false if the peer failed to sample the header as its data was not available.
+	Available bool
+	// Peer is the peer that sampled the header. It is the author of the pubsub message carrying the
+	// Attestation, so it is authenticated by the message signature and is not sent on the wire.
+	Peer peer.ID
+}
+
+func (a *Attestation) toProto() *pb.SamplingAttestation {
+	return &pb.SamplingAttestation{
+		Height:    a.Height,
+		DataHash:  a.DataHash,
+		Available: a.Available,
+	}
+}
+
+func unmarshalAttestation(data []byte) (Attestation, error) {
+	var msg pb.SamplingAttestation
+	if err := msg.Unmarshal(data); err != nil {
+		return Attestation{}, fmt.Errorf("attest-sub: unmarshal attestation: %w", err)
+	}
+	return Attestation{
+		Height:    msg.Height,
+		DataHash:  msg.DataHash,
+		Available: msg.Available,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package attestsub
+
+import (
+	"context"
+	"fmt"
+
+	logging "github.com/ipfs/go-log/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+var log = logging.Logger("attest-sub")
+
+// pubsubTopicID hardcodes the name of the sampling attestations topic with the provided networkID.
+func pubsubTopicID(networkID string) string {
+	return fmt.Sprintf("%s/attest-sub/v0.1.0", networkID)
+}
+
+// PubSub manages receiving and propagating sampling results, so called attestations, from/to the
+// network over "attest-sub" subscription. Attestations are authenticated by the signatures of the
+// pubsub messages carrying them, so the pubsub.PubSub must sign and verify messages strictly, as it
+// does by default.
+type PubSub struct {
+	pubSub *pubsub.PubSub
+	topic  *pubsub.Topic
+	host   host.Host
+
+	pubsubTopic string
+	cancelRelay pubsub.RelayCancelFunc
+}
+
+// NewPubSub creates a PubSub for sampling attestations over the given libp2p.PubSub.
+func NewPubSub(ps *pubsub.PubSub, h host.Host, networkID string) *PubSub {
+	return &PubSub{
+		pubSub:      ps,
+		host:        h,
+		pubsubTopic: pubsubTopicID(networkID),
+	}
+}
+
+// Start registers the attestation validator and joins the attestations topic.
+func (s *PubSub) Start(context.Context) error {
+	err := s.pubSub.RegisterTopicValidator(s.pubsubTopic, validate)
+	if err != nil {
+		return err
+	}
+
+	topic, err := s.pubSub.Join(s.pubsubTopic)
+	if err != nil {
+		return err
+	}
+
+	cancel, err := topic.Relay()
+	if err != nil {
+		return err
+	}
+
+	s.cancelRelay = cancel
+	s.topic = topic
+	return nil
+}
+
+// Stop completely stops the PubSub:
+// * Unregisters the attestation validator
+// * Closes the `attest-sub` topic
+func (s *PubSub) Stop(context.Context) error {
+	s.cancelRelay()
+	err := s.pubSub.UnregisterTopicValidator(s.pubsubTopic)
+	if err != nil {
+		log.Warnw("unregistering topic", "err", err)
+	}
+	return s.topic.Close()
+}
+
+// validate ignores attestations of unsigned messages, as their author can't be trusted, and
+// rejects malformed ones.
+func validate(_ context.Context, _ peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	if msg.Signature == nil {
+		log.Debugw("validator: unsigned message", "from", msg.ReceivedFrom)
+		return pubsub.ValidationIgnore
+	}
+	att, err := unmarshalAttestation(msg.Data)
+	if err != nil {
+		log.Debugw("validator: unmarshal error", "err", err)
+		return pubsub.ValidationReject
+	}
+	if att.DataHash.IsEmptyRoot() {
+		// we don't attest empty EDSes, but If someone sent it to us - do hard reject
+		return pubsub.ValidationReject
+	}
+	return pubsub.ValidationAccept
+}
+
+// Subscribe provides a new Subscription for attestations.
+func (s *PubSub) Subscribe() (*Subscription, error) {
+	if s.topic == nil {
+		return nil, fmt.Errorf("attest-sub: topic is not started")
+	}
+	return newSubscription(s.topic)
+}
+
+// ID returns the peer authoring attestations published by the PubSub.
+func (s *PubSub) ID() peer.ID {
+	return s.host.ID()
+}
+
+// Publish sends the attestation to every connected peer. It returns the attestation authored by
+// the host.
+func (s *PubSub) Publish(ctx context.Context, att Attestation) (Attestation, error) {
+	att.Peer = s.ID()
+	if att.DataHash.IsEmptyRoot() {
+		// no need to attest availability of an empty EDS
+		return att, nil
+	}
+
+	data, err := att.toProto().Marshal()
+	if err != nil {
+		return Attestation{}, fmt.Errorf("attest-sub: marshal attestation, %w", err)
+	}
+	return att, s.topic.Publish(ctx, data)
+}
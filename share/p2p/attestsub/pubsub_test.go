@@ -0,0 +1,66 @@
+package attestsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPubSub(t *testing.T) {
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	pSub1 := newTestPubSub(ctx, t, net, 0)
+	pSub2 := newTestPubSub(ctx, t, net, 1)
+
+	subs, err := pSub2.Subscribe()
+	require.NoError(t, err)
+	t.Cleanup(subs.Cancel)
+
+	// wait for the peers to learn about each others subscription
+	require.Eventually(t, func() bool {
+		return len(pSub1.topic.ListPeers()) == 1
+	}, time.Second, time.Millisecond*10)
+
+	published, err := pSub1.Publish(ctx, Attestation{Height: 1, DataHash: []byte("data"), Available: true})
+	require.NoError(t, err)
+	assert.Equal(t, net.Hosts()[0].ID(), published.Peer)
+
+	got, err := subs.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, published, got)
+}
+
+func TestValidate(t *testing.T) {
+	att := Attestation{Height: 1, DataHash: []byte("data"), Available: true}
+	data, err := att.toProto().Marshal()
+	require.NoError(t, err)
+
+	msg := &pubsub.Message{Message: &pb.Message{Data: data, Signature: []byte("sig")}}
+	assert.Equal(t, pubsub.ValidationAccept, validate(context.Background(), "", msg))
+
+	msg.Signature = nil
+	assert.Equal(t, pubsub.ValidationIgnore, validate(context.Background(), "", msg))
+
+	msg = &pubsub.Message{Message: &pb.Message{Data: []byte("garbage"), Signature: []byte("sig")}}
+	assert.Equal(t, pubsub.ValidationReject, validate(context.Background(), "", msg))
+}
+
+func newTestPubSub(ctx context.Context, t *testing.T, net mocknet.Mocknet, i int) *PubSub {
+	ps, err := pubsub.NewFloodSub(ctx, net.Hosts()[i])
+	require.NoError(t, err)
+	pSub := NewPubSub(ps, net.Hosts()[i], "test")
+	require.NoError(t, pSub.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, pSub.Stop(context.Background()))
+	})
+	return pSub
+}
@@ -0,0 +1,44 @@
+package attestsub
+
+import (
+	"context"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+// Subscription is a wrapper over pubsub.Subscription that handles
+// receiving attestations from other peers.
+type Subscription struct {
+	subscription *pubsub.Subscription
+}
+
+func newSubscription(t *pubsub.Topic) (*Subscription, error) {
+	subs, err := t.Subscribe()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Subscription{subscription: subs}, nil
+}
+
+// Next blocks the caller until any new attestation arrives.
+// Returns only attestations which successfully pass validation.
+func (subs *Subscription) Next(ctx context.Context) (Attestation, error) {
+	msg, err := subs.subscription.Next(ctx)
+	if err != nil {
+		return Attestation{}, err
+	}
+
+	log.Debugw("received message", "topic", msg.Message.GetTopic(), "sender", msg.ReceivedFrom)
+	att, err := unmarshalAttestation(msg.Data)
+	if err != nil {
+		return Attestation{}, err
+	}
+	att.Peer = msg.GetFrom()
+	return att, nil
+}
+
+// Cancel stops the subscription.
+func (subs *Subscription) Cancel() {
+	subs.subscription.Cancel()
+}
@@ -0,0 +1,89 @@
+package attestsub
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// Tracker keeps the latest attestation of every peer for recent heights, so that peers can be
+// checked to agree on sampling results.
+type Tracker struct {
+	lk sync.Mutex
+	// window is the amount of heights below the highest attested one attestations are kept for
+	window  uint64
+	highest uint64
+	heights map[uint64]map[peer.ID]Attestation
+}
+
+// NewTracker creates a Tracker keeping attestations for the given amount of recent heights.
+func NewTracker(window uint64) *Tracker {
+	return &Tracker{
+		window:  window,
+		heights: make(map[uint64]map[peer.ID]Attestation),
+	}
+}
+
+// Add adds the attestation, replacing the previous one of the same peer for the same height. It
+// reports whether the attestation changes the results of the height.
+func (t *Tracker) Add(att Attestation) bool {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	if att.Height+t.window <= t.highest {
+		// too old
+		return false
+	}
+	if att.Height > t.highest {
+		t.highest = att.Height
+		t.prune()
+	}
+
+	atts, ok := t.heights[att.Height]
+	if !ok {
+		atts = make(map[peer.ID]Attestation)
+		t.heights[att.Height] = atts
+	}
+	prev, ok := atts[att.Peer]
+	atts[att.Peer] = att
+	return !ok || prev.Available != att.Available || !bytes.Equal(prev.DataHash, att.DataHash)
+}
+
+// Get returns the attestation of the peer for the height, if any.
+func (t *Tracker) Get(height uint64, p peer.ID) (Attestation, bool) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	att, ok := t.heights[height][p]
+	return att, ok
+}
+
+// Tally counts peers that attested the data committed to the given DataHash available and
+// unavailable, except for the excluded peer.
+func (t *Tracker) Tally(height uint64, dataHash share.DataHash, exclude peer.ID) (available, unavailable int) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+
+	for p, att := range t.heights[height] {
+		if p == exclude || !bytes.Equal(att.DataHash, dataHash) {
+			continue
+		}
+		if att.Available {
+			available++
+		} else {
+			unavailable++
+		}
+	}
+	return available, unavailable
+}
+
+func (t *Tracker) prune() {
+	for h := range t.heights {
+		if h+t.window <= t.highest {
+			delete(t.heights, h)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package attestsub
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracker(t *testing.T) {
+	tr := NewTracker(2)
+	hash, other := []byte("hash"), []byte("other")
+
+	assert.True(t, tr.Add(Attestation{Height: 1, DataHash: hash, Available: true, Peer: "a"}))
+	assert.True(t, tr.Add(Attestation{Height: 1, DataHash: hash, Available: true, Peer: "b"}))
+	assert.True(t, tr.Add(Attestation{Height: 1, DataHash: hash, Available: false, Peer: "c"}))
+	// attestations for another DataHash are not counted
+	assert.True(t, tr.Add(Attestation{Height: 1, DataHash: other, Available: true, Peer: "d"}))
+	// the same attestation does not change anything
+	assert.False(t, tr.Add(Attestation{Height: 1, DataHash: hash, Available: true, Peer: "b"}))
+
+	available, unavailable := tr.Tally(1, hash, peer.ID("a"))
+	assert.Equal(t, 1, available)
+	assert.Equal(t, 1, unavailable)
+
+	// the peer changes its mind
+	assert.True(t, tr.Add(Attestation{Height: 1, DataHash: hash, Available: true, Peer: "c"}))
+	available, unavailable = tr.Tally(1, hash, "")
+	assert.Equal(t, 3, available)
+	assert.Equal(t, 0, unavailable)
+
+	att, ok := tr.Get(1, "d")
+	assert.True(t, ok)
+	assert.Equal(t, other, []byte(att.DataHash))
+
+	// attestations for heights out of the window are dropped
+	assert.True(t, tr.Add(Attestation{Height: 3, DataHash: hash, Available: true, Peer: "a"}))
+	_, ok = tr.Get(1, "a")
+	assert.False(t, ok)
+	assert.False(t, tr.Add(Attestation{Height: 1, DataHash: hash, Available: true, Peer: "a"}))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	logging "github.com/ipfs/go-log/v2"
@@ -12,8 +13,11 @@ import (
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/libp2p/go-libp2p/p2p/host/eventbus"
 	"golang.org/x/sync/errgroup"
+
+	"github.com/celestiaorg/celestia-node/libs/watchdog"
 )
 
 var log = logging.Logger("share/discovery")
@@ -45,14 +49,26 @@ type Discovery struct {
 	host      host.Host
 	disc      discovery.Discovery
 	connector *backoffConnector
+	// servingHost is the host peer exchange requests of other nodes are served on
+	servingHost host.Host
+	// px rate limits peer exchange requests of other nodes
+	px *peerExchange
+	// exchangedPeersProtocol is the protocol peers shared over peer exchange must serve to be added
+	exchangedPeersProtocol protocol.ID
 	// onUpdatedPeers will be called on peer set changes
 	onUpdatedPeers OnUpdatedPeers
 
 	triggerDisc chan struct{}
 
 	metrics *metrics
+	// heartbeats is nil unless the loops of discovery are watched for getting stuck
+	heartbeats *watchdog.Registry
 
+	// lk guards the loops against being started, stopped and restarted concurrently
+	lk     sync.Mutex
 	cancel context.CancelFunc
+	// loops tracks the running loops, so that they are restarted only once they exited
+	loops sync.WaitGroup
 
 	params Parameters
 }
@@ -74,15 +90,48 @@ func NewDiscovery(
 	return &Discovery{
 		set:            newLimitedSet(params.PeersLimit),
 		host:           h,
+		servingHost:    h,
 		disc:           d,
 		connector:      newBackoffConnector(h, defaultBackoffFactory),
+		px:             newPeerExchange(params.PeerExchangeInterval),
 		onUpdatedPeers: func(peer.ID, bool) {},
 		params:         params,
 		triggerDisc:    make(chan struct{}),
+
+		exchangedPeersProtocol: peerExchangeProtocolID,
 	}
 }
 
-func (d *Discovery) Start(context.Context) error {
+func (d *Discovery) Start(ctx context.Context) error {
+	d.lk.Lock()
+	defer d.lk.Unlock()
+	return d.start(ctx)
+}
+
+func (d *Discovery) Stop(ctx context.Context) error {
+	d.lk.Lock()
+	defer d.lk.Unlock()
+	d.servingHost.RemoveStreamHandler(peerExchangeProtocolID)
+	return d.stop(ctx)
+}
+
+// Restart restarts the loops of discovery, e.g. once they got stuck. Advertising is not affected.
+// The loops are only restarted once the previous ones exited, so that they never run twice; if they
+// don't exit before the context is done, the restart fails and may be retried.
+func (d *Discovery) Restart(ctx context.Context) error {
+	d.lk.Lock()
+	defer d.lk.Unlock()
+	if d.cancel == nil {
+		// stopped or not started yet, so there is nothing to restart
+		return nil
+	}
+	if err := d.stop(ctx); err != nil {
+		return err
+	}
+	return d.start(ctx)
+}
+
+func (d *Discovery) start(context.Context) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	d.cancel = cancel
 
@@ -96,15 +145,45 @@ func (d *Discovery) Start(context.Context) error {
 		return fmt.Errorf("subscribing for connection events: %w", err)
 	}
 
-	go d.discoveryLoop(ctx)
-	go d.disconnectsLoop(ctx, sub)
-	go d.connector.GC(ctx)
+	d.run(ctx, d.discoveryLoop)
+	d.run(ctx, func(ctx context.Context) {
+		d.disconnectsLoop(ctx, sub)
+	})
+	d.run(ctx, d.connector.GC)
+	if d.params.PeerExchange {
+		d.run(ctx, d.peerExchangeLoop)
+	}
 	return nil
 }
 
-func (d *Discovery) Stop(context.Context) error {
+// stop stops the loops and waits for them to exit.
+func (d *Discovery) stop(ctx context.Context) error {
+	if d.cancel == nil {
+		return nil
+	}
 	d.cancel()
-	return nil
+
+	exited := make(chan struct{})
+	go func() {
+		d.loops.Wait()
+		close(exited)
+	}()
+	select {
+	case <-exited:
+		d.cancel = nil
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("discovery: waiting for the loops to exit: %w", ctx.Err())
+	}
+}
+
+// run runs the loop tracked by the loops.
+func (d *Discovery) run(ctx context.Context, loop func(context.Context)) {
+	d.loops.Add(1)
+	go func() {
+		defer d.loops.Done()
+		loop(ctx)
+	}()
 }
 
 // WithOnPeersUpdate chains OnPeersUpdate callbacks on every update of discovered peers list.
@@ -116,12 +195,53 @@ func (d *Discovery) WithOnPeersUpdate(f OnUpdatedPeers) {
 	}
 }
 
+// WithHeartbeats makes the loops of discovery check in with the given watchdog.Registry, so that
+// they are flagged once stuck.
+func (d *Discovery) WithHeartbeats(r *watchdog.Registry) {
+	d.heartbeats = r
+}
+
+// WithExchangedPeersProtocol sets the protocol peers shared over peer exchange must serve to be
+// added, so that a single peer can't fill the set with arbitrary nodes. Defaults to the peer
+// exchange protocol itself.
+func (d *Discovery) WithExchangedPeersProtocol(id protocol.ID) {
+	d.exchangedPeersProtocol = id
+}
+
+// WithServingHost sets the host peer exchange requests of other nodes are served on, so that a node
+// serving nothing to peers doesn't serve them. Defaults to the host of discovery.
+func (d *Discovery) WithServingHost(h host.Host) {
+	d.servingHost = h
+}
+
+// SetPeersLimit changes the soft limit of peers to discover and triggers discovery if the limit is
+// raised. Disabling or enabling discovery by setting the limit to or from 0 requires a restart.
+func (d *Discovery) SetPeersLimit(limit uint) error {
+	if (limit == 0) != (d.set.Limit() == 0) {
+		return fmt.Errorf("discovery: peers limit can't be changed to or from 0 without a restart")
+	}
+
+	d.set.SetLimit(limit)
+	if d.set.Size() < limit {
+		select {
+		case d.triggerDisc <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
 // Peers provides a list of discovered peers in the "full" topic.
 // If Discovery hasn't found any peers, it blocks until at least one peer is found.
 func (d *Discovery) Peers(ctx context.Context) ([]peer.ID, error) {
 	return d.set.Peers(ctx)
 }
 
+// Size returns the amount of discovered peers.
+func (d *Discovery) Size() int {
+	return int(d.set.Size())
+}
+
 // Discard removes the peer from the peer set and rediscovers more if soft peer limit is not
 // reached. Reports whether peer was removed with bool.
 func (d *Discovery) Discard(id peer.ID) bool {
@@ -147,12 +267,14 @@ func (d *Discovery) Discard(id peer.ID) bool {
 }
 
 // Advertise is a utility function that persistently advertises a service through an Advertiser.
+// If peer exchange is enabled, the advertised node also shares discovered full nodes with others.
 // TODO: Start advertising only after the reachability is confirmed by AutoNAT
 func (d *Discovery) Advertise(ctx context.Context) {
 	if d.params.AdvertiseInterval == -1 {
 		log.Warn("AdvertiseInterval is set to -1. Skipping advertising...")
 		return
 	}
+	d.servePeerExchange()
 
 	timer := time.NewTimer(d.params.AdvertiseInterval)
 	defer timer.Stop()
@@ -199,9 +321,13 @@ func (d *Discovery) Advertise(ctx context.Context) {
 func (d *Discovery) discoveryLoop(ctx context.Context) {
 	t := time.NewTicker(discoveryRetryTimeout)
 	defer t.Stop()
+
+	hb := d.heartbeats.Register("share", "share/discovery/discover")
+	defer hb.Stop()
 	for {
 		// drain all previous ticks from channel
 		drainChannel(t.C)
+		hb.Beat()
 		select {
 		case <-t.C:
 			found := d.discover(ctx)
@@ -213,10 +339,16 @@ func (d *Discovery) discoveryLoop(ctx context.Context) {
 			return
 		}
 
-		select {
-		case <-d.triggerDisc:
-		case <-ctx.Done():
-			return
+	wait:
+		for {
+			select {
+			case <-d.triggerDisc:
+				break wait
+			case <-hb.Tick():
+				hb.Beat()
+			case <-ctx.Done():
+				return
+			}
 		}
 	}
 }
@@ -226,10 +358,14 @@ func (d *Discovery) discoveryLoop(ctx context.Context) {
 func (d *Discovery) disconnectsLoop(ctx context.Context, sub event.Subscription) {
 	defer sub.Close()
 
+	hb := d.heartbeats.Register("share", "share/discovery/disconnects")
+	defer hb.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case <-hb.Tick():
+			hb.Beat()
 		case e, ok := <-sub.Out():
 			if !ok {
 				log.Error("connection subscription was closed unexpectedly")
@@ -245,18 +381,18 @@ func (d *Discovery) disconnectsLoop(ctx context.Context, sub event.Subscription)
 
 // discover finds new peers and reports whether it succeeded.
 func (d *Discovery) discover(ctx context.Context) bool {
-	size := d.set.Size()
-	want := d.set.Limit() - size
-	if want == 0 {
+	size, limit := d.set.Size(), d.set.Limit()
+	// the limit might have been lowered below the amount of discovered peers
+	if size >= limit {
 		log.Debugw("reached soft peer limit, skipping discovery", "size", size)
 		return true
 	}
-	log.Infow("discovering peers", "want", want)
+	log.Infow("discovering peers", "want", limit-size)
 
 	// we use errgroup as it provide limits
 	var wg errgroup.Group
 	// limit to minimize chances of overreaching the limit
-	wg.SetLimit(int(d.set.Limit()))
+	wg.SetLimit(int(limit))
 
 	findCtx, findCancel := context.WithTimeout(ctx, findPeersTimeout)
 	defer func() {
@@ -287,7 +423,7 @@ func (d *Discovery) discover(ctx context.Context) bool {
 
 				// we don't pass findCtx so that we don't cancel in progress connections
 				// that are likely to be valuable
-				if !d.handleDiscoveredPeer(ctx, peer) {
+				if !d.handleDiscoveredPeer(ctx, peer, nil) {
 					return nil
 				}
 
@@ -313,9 +449,13 @@ func (d *Discovery) discover(ctx context.Context) bool {
 	}
 }
 
-// handleDiscoveredPeer adds peer to the internal if can connect or is connected.
-// Report whether it succeeded.
-func (d *Discovery) handleDiscoveredPeer(ctx context.Context, peer peer.AddrInfo) bool {
+// handleDiscoveredPeer adds peer to the internal if can connect or is connected, and passes the
+// verification, if any. Report whether it succeeded.
+func (d *Discovery) handleDiscoveredPeer(
+	ctx context.Context,
+	peer peer.AddrInfo,
+	verify func(context.Context, peer.ID) bool,
+) bool {
 	logger := log.With("peer", peer.ID.String())
 	switch {
 	case peer.ID == d.host.ID():
@@ -351,6 +491,12 @@ func (d *Discovery) handleDiscoveredPeer(ctx context.Context, peer peer.AddrInfo
 		panic("unknown connectedness")
 	}
 
+	if verify != nil && !verify(ctx, peer.ID) {
+		d.metrics.observeHandlePeer(ctx, handlePeerUnverified)
+		logger.Debug("skip handle: verification failed")
+		return false
+	}
+
 	if !d.set.Add(peer.ID) {
 		d.metrics.observeHandlePeer(ctx, handlePeerInSet)
 		logger.Debug("peer is already in discovery set")
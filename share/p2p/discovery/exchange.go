@@ -0,0 +1,217 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/celestiaorg/celestia-node/libs/cache"
+)
+
+const (
+	// peerExchangeProtocolID is the protocol full nodes use to share addresses of other full nodes.
+	peerExchangeProtocolID protocol.ID = "/full/px/v0.0.1"
+
+	// peerExchangeAmount is the maximum amount of peers shared in a single response.
+	peerExchangeAmount = 5
+
+	// peerExchangeTimeout limits a single peer exchange request in time.
+	peerExchangeTimeout = 10 * time.Second
+
+	// peerExchangeMaxMsgSize limits the size of a peer exchange response.
+	peerExchangeMaxMsgSize = 64 * 1024
+
+	// peerExchangeMaxRequesters is the maximum amount of recently served peers kept to rate limit
+	// peer exchange requests.
+	peerExchangeMaxRequesters = 1024
+)
+
+// peerExchange keeps the peers served recently to rate limit peer exchange requests.
+type peerExchange struct {
+	// served keeps the served peers until they can be served again
+	served *cache.Cache[peer.ID, struct{}]
+}
+
+// newPeerExchange creates a new peerExchange serving a peer at most twice per the given interval
+// of peer exchange requests, which leaves some slack for timer drift.
+func newPeerExchange(interval time.Duration) *peerExchange {
+	return &peerExchange{
+		served: cache.New[peer.ID, struct{}](peerExchangeMaxRequesters, cache.WithTTL(interval/2)),
+	}
+}
+
+// allow reports whether the request of the peer can be served.
+func (px *peerExchange) allow(id peer.ID) bool {
+	var allowed bool
+	px.served.GetOrAdd(id, func() struct{} {
+		allowed = true
+		return struct{}{}
+	})
+	return allowed
+}
+
+// servePeerExchange starts serving peer exchange requests of other nodes with addresses of
+// discovered full nodes.
+func (d *Discovery) servePeerExchange() {
+	if !d.params.PeerExchange {
+		return
+	}
+	d.servingHost.SetStreamHandler(peerExchangeProtocolID, d.handlePeerExchange)
+}
+
+// handlePeerExchange responds with the addresses of a few discovered full nodes, excluding the
+// requester.
+func (d *Discovery) handlePeerExchange(s network.Stream) {
+	logger := log.With("peer", s.Conn().RemotePeer().String())
+	if !d.px.allow(s.Conn().RemotePeer()) {
+		logger.Debug("peer exchange: rate limited")
+		s.Reset() //nolint:errcheck
+		return
+	}
+	defer s.Close()
+
+	if err := s.CloseRead(); err != nil {
+		logger.Debugw("peer exchange: closing read side of the stream", "err", err)
+	}
+
+	var ids []peer.ID
+	if d.set.Size() > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), peerExchangeTimeout)
+		// error is only possible if the set became empty, so there is nothing to share
+		ids, _ = d.set.Peers(ctx)
+		cancel()
+	}
+
+	infos := make([]peer.AddrInfo, 0, peerExchangeAmount)
+	for _, id := range ids {
+		if len(infos) == peerExchangeAmount {
+			break
+		}
+		if id == s.Conn().RemotePeer() {
+			continue
+		}
+		info := d.host.Peerstore().PeerInfo(id)
+		if len(info.Addrs) == 0 {
+			continue
+		}
+		infos = append(infos, info)
+	}
+
+	err := s.SetWriteDeadline(time.Now().Add(peerExchangeTimeout))
+	if err != nil {
+		logger.Debugw("peer exchange: setting write deadline", "err", err)
+	}
+	if err = json.NewEncoder(s).Encode(infos); err != nil {
+		logger.Debugw("peer exchange: writing response", "err", err)
+		s.Reset() //nolint:errcheck
+		return
+	}
+	logger.Debugw("peer exchange: shared peers", "amount", len(infos))
+}
+
+// requestPeers requests addresses of full nodes known to the given peer.
+func (d *Discovery) requestPeers(ctx context.Context, id peer.ID) ([]peer.AddrInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, peerExchangeTimeout)
+	defer cancel()
+
+	s, err := d.host.NewStream(ctx, id, peerExchangeProtocolID)
+	if err != nil {
+		return nil, fmt.Errorf("peer exchange: opening stream: %w", err)
+	}
+	defer s.Close()
+
+	if dl, ok := ctx.Deadline(); ok {
+		if err = s.SetReadDeadline(dl); err != nil {
+			log.Debugw("peer exchange: setting read deadline", "err", err)
+		}
+	}
+	if err = s.CloseWrite(); err != nil {
+		log.Debugw("peer exchange: closing write side of the stream", "err", err)
+	}
+
+	var infos []peer.AddrInfo
+	err = json.NewDecoder(io.LimitReader(s, peerExchangeMaxMsgSize)).Decode(&infos)
+	if err != nil {
+		s.Reset() //nolint:errcheck
+		return nil, fmt.Errorf("peer exchange: reading response: %w", err)
+	}
+	if len(infos) > peerExchangeAmount {
+		infos = infos[:peerExchangeAmount]
+	}
+	return infos, nil
+}
+
+// peerExchangeLoop periodically requests discovered full nodes for other full nodes they know
+// until the soft limit of peers is reached.
+func (d *Discovery) peerExchangeLoop(ctx context.Context) {
+	t := time.NewTicker(d.params.PeerExchangeInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			d.exchangePeers(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// exchangePeers requests every discovered full node for other full nodes and tries to add them
+// to the peer set. Shared peers are added only if they serve the exchangedPeersProtocol, as the
+// sharing peer could share any peer.
+func (d *Discovery) exchangePeers(ctx context.Context) {
+	if d.set.Size() == 0 || d.set.Size() >= d.set.Limit() {
+		return
+	}
+
+	peersCtx, cancel := context.WithTimeout(ctx, peerExchangeTimeout)
+	ids, err := d.set.Peers(peersCtx)
+	cancel()
+	if err != nil {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		id := id
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			infos, err := d.requestPeers(ctx, id)
+			if err != nil {
+				log.Debugw("peer exchange: requesting peers", "peer", id.String(), "err", err)
+				return
+			}
+
+			for _, info := range infos {
+				if d.handleDiscoveredPeer(ctx, info, d.servesExchangedPeersProtocol) {
+					log.Debugw("peer exchange: found peer", "peer", info.ID.String(), "from", id.String())
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// servesExchangedPeersProtocol reports whether the connected peer serves the
+// exchangedPeersProtocol. The peerstore learns the protocols of a peer only once it is identified,
+// which may still be in progress for a fresh connection, so a stream is opened instead, as the host
+// waits for the identification before negotiating it. The stream is reset right away, never
+// reaching the handler of the peer.
+func (d *Discovery) servesExchangedPeersProtocol(ctx context.Context, id peer.ID) bool {
+	ctx, cancel := context.WithTimeout(ctx, peerExchangeTimeout)
+	defer cancel()
+	s, err := d.host.NewStream(ctx, id, d.exchangedPeersProtocol)
+	if err != nil {
+		return false
+	}
+	_ = s.Reset()
+	return true
+}
@@ -0,0 +1,96 @@
+package discovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerExchange(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshConnected(3)
+	require.NoError(t, err)
+	server, client, full := net.Hosts()[0], net.Hosts()[1], net.Hosts()[2]
+
+	serverDisc := NewDiscovery(server, nil, WithPeerExchange(true, time.Minute))
+	serverDisc.servePeerExchange()
+	t.Cleanup(func() {
+		server.RemoveStreamHandler(peerExchangeProtocolID)
+	})
+	// the addresses of connected peers are learned by identifying them, which may still be in
+	// progress, so the server is given them upfront
+	knowAddrs(server, client, full)
+	// the server shares both known full nodes, except for the requester
+	require.True(t, serverDisc.set.Add(full.ID()))
+	require.True(t, serverDisc.set.Add(client.ID()))
+
+	clientDisc := NewDiscovery(client, nil, WithPeerExchange(true, time.Minute))
+	infos, err := clientDisc.requestPeers(ctx, server.ID())
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, full.ID(), infos[0].ID)
+	assert.NotEmpty(t, infos[0].Addrs)
+
+	// the second request within the interval is rate limited
+	_, err = clientDisc.requestPeers(ctx, server.ID())
+	assert.Error(t, err)
+
+	// other peers are served independently
+	fullDisc := NewDiscovery(full, nil, WithPeerExchange(true, time.Minute))
+	infos, err = fullDisc.requestPeers(ctx, server.ID())
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, client.ID(), infos[0].ID)
+}
+
+func TestPeerExchange_VerifiesExchangedPeers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(4)
+	require.NoError(t, err)
+	server, client, full, other := net.Hosts()[0], net.Hosts()[1], net.Hosts()[2], net.Hosts()[3]
+	// the client knows only the server, which knows the addresses of the other nodes
+	for _, h := range []host.Host{client, full, other} {
+		_, err = net.ConnectPeers(server.ID(), h.ID())
+		require.NoError(t, err)
+	}
+
+	knowAddrs(server, client, full, other)
+
+	const edsProtocol = "/test/shrex/eds"
+	full.SetStreamHandler(edsProtocol, func(s network.Stream) { s.Close() }) //nolint:errcheck
+
+	serverDisc := NewDiscovery(server, nil, WithPeerExchange(true, time.Minute))
+	serverDisc.servePeerExchange()
+	t.Cleanup(func() {
+		server.RemoveStreamHandler(peerExchangeProtocolID)
+	})
+	// the server shares a full node and a node that does not serve EDSes
+	require.True(t, serverDisc.set.Add(full.ID()))
+	require.True(t, serverDisc.set.Add(other.ID()))
+
+	clientDisc := NewDiscovery(client, nil, WithPeerExchange(true, time.Minute))
+	clientDisc.WithExchangedPeersProtocol(edsProtocol)
+	require.True(t, clientDisc.set.Add(server.ID()))
+	clientDisc.exchangePeers(ctx)
+
+	assert.True(t, clientDisc.set.Contains(full.ID()))
+	assert.False(t, clientDisc.set.Contains(other.ID()))
+}
+
+// knowAddrs adds the addresses of the given peers to the peerstore of the host.
+func knowAddrs(h host.Host, peers ...host.Host) {
+	for _, p := range peers {
+		h.Peerstore().AddAddrs(p.ID(), p.Addrs(), peerstore.PermanentAddrTTL)
+	}
+}
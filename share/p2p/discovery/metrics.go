@@ -23,6 +23,7 @@ const (
 	handlePeerConnected   handlePeerResult = "connected"
 	handlePeerConnErr     handlePeerResult = "conn_err"
 	handlePeerInSet       handlePeerResult = "in_set"
+	handlePeerUnverified  handlePeerResult = "unverified"
 
 	advertiseFailedKey = "failed"
 )
@@ -14,6 +14,12 @@ type Parameters struct {
 	// Set -1 to disable.
 	// NOTE: only full and bridge can advertise themselves.
 	AdvertiseInterval time.Duration
+	// PeerExchange enables periodic exchange of discovered full nodes with connected full nodes.
+	// It helps to find full nodes in networks where DHT discovery is slow.
+	PeerExchange bool
+	// PeerExchangeInterval is an interval between peer exchange requests. Full nodes serve a peer
+	// at most twice per interval.
+	PeerExchangeInterval time.Duration
 }
 
 // Option is a function that configures Discovery Parameters
@@ -25,7 +31,8 @@ func DefaultParameters() Parameters {
 	return Parameters{
 		PeersLimit: 5,
 		// based on https://github.com/libp2p/go-libp2p-kad-dht/pull/793
-		AdvertiseInterval: time.Hour * 22,
+		AdvertiseInterval:    time.Hour * 22,
+		PeerExchangeInterval: time.Minute * 10,
 	}
 }
 
@@ -39,6 +46,14 @@ func (p *Parameters) Validate() error {
 		)
 	}
 
+	if p.PeerExchange && p.PeerExchangeInterval <= 0 {
+		return fmt.Errorf(
+			"discovery: invalid option: value PeerExchangeInterval %s, %s",
+			"is 0 or negative.",
+			"value must be positive",
+		)
+	}
+
 	return nil
 }
 
@@ -57,3 +72,12 @@ func WithAdvertiseInterval(advInterval time.Duration) Option {
 		p.AdvertiseInterval = advInterval
 	}
 }
+
+// WithPeerExchange is a functional option that Discovery
+// uses to set the PeerExchange and PeerExchangeInterval configuration params
+func WithPeerExchange(enabled bool, interval time.Duration) Option {
+	return func(p *Parameters) {
+		p.PeerExchange = enabled
+		p.PeerExchangeInterval = interval
+	}
+}
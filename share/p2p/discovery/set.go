@@ -34,9 +34,18 @@ func (ps *limitedSet) Contains(p peer.ID) bool {
 }
 
 func (ps *limitedSet) Limit() uint {
+	ps.lk.RLock()
+	defer ps.lk.RUnlock()
 	return ps.limit
 }
 
+// SetLimit changes the maximum peers amount. Peers above the new limit are kept in the set.
+func (ps *limitedSet) SetLimit(limit uint) {
+	ps.lk.Lock()
+	ps.limit = limit
+	ps.lk.Unlock()
+}
+
 func (ps *limitedSet) Size() uint {
 	ps.lk.RLock()
 	defer ps.lk.RUnlock()
@@ -0,0 +1,55 @@
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// epochSuffix precedes the epoch in protocol IDs.
+const epochSuffix = "/epoch-"
+
+// ErrWrongEpoch is returned when a peer runs the protocol in another epoch, e.g. as it did not
+// upgrade through a breaking fork of the network yet. The peer can't serve the request until
+// either side upgrades.
+var ErrWrongEpoch = errors.New("peer runs the protocol in another epoch")
+
+// CheckEpoch explains a failure to open a stream of the given protocol to the peer. It returns
+// ErrWrongEpoch if the peer is known to run the protocol in another epoch only, and nil otherwise.
+func CheckEpoch(h host.Host, p peer.ID, id protocol.ID) error {
+	protocols, err := h.Peerstore().GetProtocols(p)
+	if err != nil {
+		return nil
+	}
+
+	base := trimEpoch(id)
+	for _, other := range protocols {
+		if other == id {
+			return nil
+		}
+	}
+	for _, other := range protocols {
+		if trimEpoch(other) == base {
+			return fmt.Errorf("%w: peer %s runs %s instead of %s", ErrWrongEpoch, p, other, id)
+		}
+	}
+	return nil
+}
+
+// trimEpoch returns the protocol ID without its epoch.
+func trimEpoch(id protocol.ID) protocol.ID {
+	s := string(id)
+	i := strings.LastIndex(s, epochSuffix)
+	if i == -1 {
+		return id
+	}
+	if _, err := strconv.ParseUint(s[i+len(epochSuffix):], 10, 64); err != nil {
+		return id
+	}
+	return protocol.ID(s[:i])
+}
@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"errors"
+	"fmt"
 )
 
 // ErrNotFound is returned when a peer is unable to find the requested data or resource.
@@ -9,6 +10,10 @@ import (
 // available at the moment. The request may be retried later, but it's unlikely to succeed.
 var ErrNotFound = errors.New("the requested data or resource could not be found")
 
+// ErrRateLimited is returned when a peer is overloaded and closes the stream without serving the
+// request. It is an ErrNotFound, as the request may be retried with other peers.
+var ErrRateLimited = fmt.Errorf("%w: peer is rate limiting requests", ErrNotFound)
+
 // ErrInvalidResponse is returned when a peer returns an invalid response or caused an internal
 // error. It is used to signal that the peer couldn't serve the data successfully, and should not be
 // retried.
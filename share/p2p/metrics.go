@@ -21,6 +21,8 @@ const (
 	StatusTimeout     status = "timeout"
 	StatusSuccess     status = "success"
 	StatusRateLimited status = "rate_limited"
+	StatusRedirected  status = "redirected"
+	StatusWrongEpoch  status = "wrong_epoch"
 )
 
 type Metrics struct {
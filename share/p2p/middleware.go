@@ -11,7 +11,7 @@ var log = logging.Logger("shrex/middleware")
 
 type Middleware struct {
 	// concurrencyLimit is the maximum number of requests that can be processed at once.
-	concurrencyLimit int64
+	concurrencyLimit atomic.Int64
 	// parallelRequests is the number of requests currently being processed.
 	parallelRequests atomic.Int64
 	// numRateLimited is the number of requests that were rate limited.
@@ -19,9 +19,15 @@ type Middleware struct {
 }
 
 func NewMiddleware(concurrencyLimit int) *Middleware {
-	return &Middleware{
-		concurrencyLimit: int64(concurrencyLimit),
-	}
+	m := &Middleware{}
+	m.concurrencyLimit.Store(int64(concurrencyLimit))
+	return m
+}
+
+// SetConcurrencyLimit changes the maximum number of requests that can be processed at once.
+// Requests being processed are not affected.
+func (m *Middleware) SetConcurrencyLimit(concurrencyLimit int) {
+	m.concurrencyLimit.Store(int64(concurrencyLimit))
 }
 
 // DrainCounter returns the current value of the rate limit counter and resets it to 0.
@@ -34,7 +40,7 @@ func (m *Middleware) RateLimitHandler(handler network.StreamHandler) network.Str
 		current := m.parallelRequests.Add(1)
 		defer m.parallelRequests.Add(-1)
 
-		if current > m.concurrencyLimit {
+		if current > m.concurrencyLimit.Load() {
 			m.numRateLimited.Add(1)
 			log.Debug("concurrency limit reached")
 			err := stream.Close()
@@ -24,6 +24,9 @@ type Parameters struct {
 	// networkID is prepended to the protocolID and represents the network the protocol is
 	// running on.
 	networkID string
+	// protocolEpoch is appended to the protocolID and represents the breaking fork of the network
+	// the protocol is running after.
+	protocolEpoch uint
 }
 
 func DefaultParameters() *Parameters {
@@ -63,7 +66,21 @@ func (p *Parameters) NetworkID() string {
 	return p.networkID
 }
 
-// ProtocolID creates a protocol ID string according to common format
-func ProtocolID(networkID, protocolString string) protocol.ID {
-	return protocol.ID(fmt.Sprintf("/%s%s", networkID, protocolString))
+// WithProtocolEpoch sets the value of protocolEpoch in params
+func (p *Parameters) WithProtocolEpoch(epoch uint) {
+	p.protocolEpoch = epoch
+}
+
+// ProtocolEpoch returns the value of protocolEpoch stored in params
+func (p *Parameters) ProtocolEpoch() uint {
+	return p.protocolEpoch
+}
+
+// ProtocolID creates a protocol ID string according to common format. The protocol ID of the
+// initial epoch is not suffixed, so that it stays compatible with peers unaware of epochs.
+func ProtocolID(networkID string, epoch uint, protocolString string) protocol.ID {
+	if epoch == 0 {
+		return protocol.ID(fmt.Sprintf("/%s%s", networkID, protocolString))
+	}
+	return protocol.ID(fmt.Sprintf("/%s%s%s%d", networkID, protocolString, epochSuffix, epoch))
 }
@@ -21,6 +21,7 @@ import (
 	libhead "github.com/celestiaorg/go-header"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/cache"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/p2p/discovery"
 	"github.com/celestiaorg/celestia-node/share/p2p/shrexsub"
@@ -59,14 +60,17 @@ type Manager struct {
 	host      host.Host
 	connGater *conngater.BasicConnectionGater
 
-	// pools collecting peers from shrexSub
-	pools map[string]*syncPool
+	// pools collecting peers from shrexSub. Pools are not bounded by the cache, as unvalidated pools
+	// are cleaned up by GC
+	pools *cache.Cache[string, *syncPool]
 	// messages from shrex.Sub with height below initialHeight will be ignored, since we don't need to
 	// track peers for those headers
 	initialHeight atomic.Uint64
 
 	// fullNodes collects full nodes peer.ID found via discovery
 	fullNodes *pool
+	// pinned maps namespaces onto the pools of their pinned providers
+	pinned map[string]*pinnedPool
 
 	// hashes that are not in the chain
 	blacklistedHashes map[string]bool
@@ -116,7 +120,8 @@ func NewManager(
 		connGater:             connGater,
 		disc:                  discovery,
 		host:                  host,
-		pools:                 make(map[string]*syncPool),
+		pools:                 cache.New[string, *syncPool](0),
+		pinned:                make(map[string]*pinnedPool),
 		blacklistedHashes:     make(map[string]bool),
 		headerSubDone:         make(chan struct{}),
 		disconnectedPeersDone: make(chan struct{}),
@@ -265,9 +270,13 @@ func (m *Manager) doneFunc(datahash share.DataHash, peerID peer.ID, source peerS
 		case ResultSynced:
 			m.markPoolAsSynced(datahash.String())
 		case ResultCooldownPeer:
-			if source == sourceFullNodes {
+			switch source {
+			case sourceFullNodes:
 				m.fullNodes.putOnCooldown(peerID)
 				return
+			case sourcePinned:
+				// pinned providers are put on cooldown for the data square by PeerForNamespace
+				return
 			}
 			m.getOrCreatePool(datahash.String()).putOnCooldown(peerID)
 		case ResultBlacklistPeer:
@@ -374,19 +383,12 @@ func (m *Manager) Validate(_ context.Context, peerID peer.ID, msg shrexsub.Notif
 }
 
 func (m *Manager) getOrCreatePool(datahash string) *syncPool {
-	m.lock.Lock()
-	defer m.lock.Unlock()
-
-	p, ok := m.pools[datahash]
-	if !ok {
-		p = &syncPool{
+	return m.pools.GetOrAdd(datahash, func() *syncPool {
+		return &syncPool{
 			pool:      newPool(m.params.PeerCooldown),
 			createdAt: time.Now(),
 		}
-		m.pools[datahash] = p
-	}
-
-	return p
+	})
 }
 
 func (m *Manager) blacklistPeers(reason blacklistPeerReason, peerIDs ...peer.ID) {
@@ -473,12 +475,12 @@ func (m *Manager) cleanUp() []peer.ID {
 	defer m.lock.Unlock()
 
 	addToBlackList := make(map[peer.ID]struct{})
-	for h, p := range m.pools {
+	m.pools.Range(func(h string, p *syncPool) bool {
 		if !p.isValidatedDataHash.Load() && time.Since(p.createdAt) > m.params.PoolValidationTimeout {
-			delete(m.pools, h)
+			m.pools.Remove(h)
 			if p.headerHeight.Load() < m.initialHeight.Load() {
 				// outdated pools could still be valid even if not validated, no need to blacklist
-				continue
+				return true
 			}
 			log.Debug("blacklisting datahash with all corresponding peers",
 				"hash", h,
@@ -491,7 +493,8 @@ func (m *Manager) cleanUp() []peer.ID {
 				addToBlackList[peer] = struct{}{}
 			}
 		}
-	}
+		return true
+	})
 
 	blacklist := make([]peer.ID, 0, len(addToBlackList))
 	for peerID := range addToBlackList {
@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	libhead "github.com/celestiaorg/go-header"
+	"github.com/celestiaorg/nmt/namespace"
 
 	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/share"
@@ -45,7 +46,9 @@ func TestManager(t *testing.T) {
 		require.NoError(t, err)
 
 		// check validation
-		require.True(t, manager.pools[h.DataHash.String()].isValidatedDataHash.Load())
+		pool, ok := manager.pools.Get(h.DataHash.String())
+		require.True(t, ok)
+		require.True(t, pool.isValidatedDataHash.Load())
 		stopManager(t, manager)
 	})
 
@@ -73,7 +76,7 @@ func TestManager(t *testing.T) {
 
 		done(ResultSynced)
 		// pool should not be removed after success
-		require.Len(t, manager.pools, 1)
+		require.Equal(t, 1, manager.pools.Len())
 		require.Len(t, manager.getOrCreatePool(h.DataHash.String()).pool.peersList, 0)
 	})
 
@@ -113,6 +116,57 @@ func TestManager(t *testing.T) {
 		stopManager(t, manager)
 	})
 
+	t.Run("pinned providers", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		t.Cleanup(cancel)
+
+		h := testHeader()
+		headerSub := newSubLock(h, nil)
+
+		// start test manager
+		manager, err := testManager(ctx, headerSub)
+		require.NoError(t, err)
+
+		// add a peer for the datahash from shrexsub
+		peerID := peer.ID("peer1")
+		manager.Validate(ctx, peerID, newShrexSubMsg(h))
+		manager.fullNodes.add(peerID)
+
+		nID := namespace.ID("namespace")
+		pinnedID := peer.ID("pinned")
+		manager.PinPeers(nID, pinnedID)
+		require.Equal(t, []peer.ID{pinnedID}, manager.PinnedPeers(nID))
+
+		// pinned provider is preferred for its namespace only
+		pID, done, err := manager.PeerForNamespace(ctx, h.DataHash.Bytes(), nID)
+		require.NoError(t, err)
+		require.Equal(t, pinnedID, pID)
+		done(ResultCooldownPeer)
+
+		pID, done, err = manager.PeerForNamespace(ctx, h.DataHash.Bytes(), namespace.ID("other"))
+		require.NoError(t, err)
+		require.Equal(t, peerID, pID)
+		done(ResultNoop)
+
+		// the general pool is used while the pinned provider is on cooldown
+		pID, done, err = manager.PeerForNamespace(ctx, h.DataHash.Bytes(), nID)
+		require.NoError(t, err)
+		require.Equal(t, peerID, pID)
+		done(ResultNoop)
+
+		// the pinned provider is on cooldown for the data square it failed to serve only
+		otherHash := share.DataHash("other")
+		pID, done, err = manager.PeerForNamespace(ctx, otherHash, nID)
+		require.NoError(t, err)
+		require.Equal(t, pinnedID, pID)
+		done(ResultNoop)
+
+		manager.UnpinPeers(nID, pinnedID)
+		require.Empty(t, manager.PinnedPeers(nID))
+
+		stopManager(t, manager)
+	})
+
 	t.Run("cleanup", func(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 		t.Cleanup(cancel)
@@ -269,7 +323,7 @@ func TestManager(t *testing.T) {
 		// unlock headerSub to read first header
 		require.NoError(t, headerSub.wait(ctx, 1))
 		// pool will be created for first headerSub header datahash
-		require.Len(t, manager.pools, 1)
+		require.Equal(t, 1, manager.pools.Len())
 
 		// create shrexSub msg with height lower than first header from headerSub
 		msg := shrexsub.Notification{
@@ -280,7 +334,7 @@ func TestManager(t *testing.T) {
 		require.Equal(t, pubsub.ValidationIgnore, result)
 
 		// amount of pools should not change
-		require.Len(t, manager.pools, 1)
+		require.Equal(t, 1, manager.pools.Len())
 	})
 
 	t.Run("shrexSub sends a message lower than first headerSub header height, headerSub first", func(t *testing.T) {
@@ -306,7 +360,7 @@ func TestManager(t *testing.T) {
 		// unlock header sub after message validator
 		require.NoError(t, headerSub.wait(ctx, 1))
 		// pool will be created for first headerSub header datahash
-		require.Len(t, manager.pools, 2)
+		require.Equal(t, 2, manager.pools.Len())
 
 		// trigger cleanup and check that no peers or hashes were blacklisted
 		manager.params.PoolValidationTimeout = 0
@@ -315,7 +369,7 @@ func TestManager(t *testing.T) {
 		require.Len(t, manager.blacklistedHashes, 0)
 
 		// outdated pool should be removed
-		require.Len(t, manager.pools, 1)
+		require.Equal(t, 1, manager.pools.Len())
 	})
 }
 
@@ -24,6 +24,7 @@ const (
 	sourceKey                  = "source"
 	sourceShrexSub  peerSource = "shrexsub"
 	sourceFullNodes peerSource = "full_nodes"
+	sourcePinned    peerSource = "pinned"
 
 	blacklistPeerReasonKey                     = "blacklist_reason"
 	reasonInvalidHash      blacklistPeerReason = "invalid_hash"
@@ -259,20 +260,18 @@ func (m *Manager) shrexPools() map[poolStatus]int64 {
 	defer m.lock.Unlock()
 
 	shrexPools := make(map[poolStatus]int64)
-	for _, p := range m.pools {
-		if !p.isValidatedDataHash.Load() {
+	m.pools.Range(func(_ string, p *syncPool) bool {
+		switch {
+		case !p.isValidatedDataHash.Load():
 			shrexPools[poolStatusCreated]++
-			continue
-		}
-
-		if p.isSynced.Load() {
+		case p.isSynced.Load():
 			shrexPools[poolStatusSynced]++
-			continue
+		default:
+			// pool is validated but not synced
+			shrexPools[poolStatusValidated]++
 		}
-
-		// pool is validated but not synced
-		shrexPools[poolStatusValidated]++
-	}
+		return true
+	})
 
 	shrexPools[poolStatusBlacklisted] = int64(len(m.blacklistedHashes))
 	return shrexPools
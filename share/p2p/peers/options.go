@@ -62,6 +62,10 @@ func (m *Manager) WithMetrics() error {
 	if err != nil {
 		return fmt.Errorf("peer-manager: init metrics: %w", err)
 	}
+	err = m.pools.WithMetrics("shrex_peer_manager_pools")
+	if err != nil {
+		return fmt.Errorf("peer-manager: init metrics: %w", err)
+	}
 	m.metrics = metrics
 	return nil
 }
@@ -0,0 +1,132 @@
+package peers
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/libs/cache"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// pinnedCooldownsSize is the amount of cooldowns of pinned providers kept per namespace.
+const pinnedCooldownsSize = 1024
+
+// pinnedPool is the pool of the providers pinned for a namespace. Providers failing to serve a data
+// square, e.g. as they haven't synced its height yet, are put on cooldown for that data square only,
+// as they likely serve the other ones.
+type pinnedPool struct {
+	*pool
+	// cooldowns holds the pairs of data squares and providers put on cooldown for them, until the
+	// cooldown expires
+	cooldowns *cache.Cache[string, struct{}]
+}
+
+func newPinnedPool(cooldown time.Duration) *pinnedPool {
+	return &pinnedPool{
+		pool:      newPool(cooldown),
+		cooldowns: cache.New[string, struct{}](pinnedCooldownsSize, cache.WithTTL(cooldown)),
+	}
+}
+
+// putOnCooldownFor puts the provider on cooldown for the data square of the given DataHash.
+func (p *pinnedPool) putOnCooldownFor(datahash share.DataHash, peerID peer.ID) {
+	p.cooldowns.Add(cooldownKey(datahash, peerID), struct{}{})
+}
+
+// isOnCooldownFor reports whether the provider is on cooldown for the data square of the given
+// DataHash.
+func (p *pinnedPool) isOnCooldownFor(datahash share.DataHash, peerID peer.ID) bool {
+	_, ok := p.cooldowns.Get(cooldownKey(datahash, peerID))
+	return ok
+}
+
+func cooldownKey(datahash share.DataHash, peerID peer.ID) string {
+	return datahash.String() + "/" + peerID.String()
+}
+
+// PinPeers pins the given peers as preferred providers of shares of the namespace, e.g. full nodes
+// of the rollup using it. PeerForNamespace returns pinned providers of the namespace before any
+// other peer.
+func (m *Manager) PinPeers(nID namespace.ID, peerIDs ...peer.ID) {
+	m.lock.Lock()
+	p, ok := m.pinned[string(nID)]
+	if !ok {
+		p = newPinnedPool(m.params.PeerCooldown)
+		m.pinned[string(nID)] = p
+	}
+	m.lock.Unlock()
+
+	p.add(peerIDs...)
+	log.Infow("pinned providers", "namespace", nID.String(), "peers", peerIDs)
+}
+
+// UnpinPeers unpins the given providers of shares of the namespace.
+func (m *Manager) UnpinPeers(nID namespace.ID, peerIDs ...peer.ID) {
+	p := m.pinnedPool(nID)
+	if p == nil {
+		return
+	}
+	p.remove(peerIDs...)
+	log.Infow("unpinned providers", "namespace", nID.String(), "peers", peerIDs)
+}
+
+// PinnedPeers returns the providers pinned for the namespace.
+func (m *Manager) PinnedPeers(nID namespace.ID) []peer.ID {
+	p := m.pinnedPool(nID)
+	if p == nil {
+		return nil
+	}
+	return p.peers()
+}
+
+// PeerForNamespace returns a provider pinned for the namespace, if any is available. Pinned
+// providers put on cooldown for the data square are skipped until their cooldown is over. If none is
+// available, it falls back to Peer.
+func (m *Manager) PeerForNamespace(
+	ctx context.Context,
+	datahash share.DataHash,
+	nID namespace.ID,
+) (peer.ID, DoneFunc, error) {
+	p := m.pinnedPool(nID)
+	if p == nil {
+		return m.Peer(ctx, datahash)
+	}
+
+	// every provider is tried at most once
+	for tries := p.len(); tries > 0; tries-- {
+		peerID, ok := p.tryGet()
+		if !ok {
+			break
+		}
+		// blacklisted providers stay pinned, as the operator trusts them, but are not requested
+		if m.isBlacklistedPeer(peerID) {
+			p.putOnCooldown(peerID)
+			continue
+		}
+		if p.isOnCooldownFor(datahash, peerID) {
+			continue
+		}
+
+		peerID, done, err := m.newPeer(ctx, datahash, peerID, sourcePinned, p.len(), 0)
+		if err != nil {
+			return "", nil, err
+		}
+		return peerID, func(result result) {
+			if result == ResultCooldownPeer {
+				p.putOnCooldownFor(datahash, peerID)
+			}
+			done(result)
+		}, nil
+	}
+	return m.Peer(ctx, datahash)
+}
+
+func (m *Manager) pinnedPool(nID namespace.ID) *pinnedPool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.pinned[string(nID)]
+}
@@ -6,16 +6,20 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"sync/atomic"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/celestiaorg/go-libp2p-messenger/serde"
 	"github.com/celestiaorg/rsmt2d"
 
+	"github.com/celestiaorg/celestia-node/libs/utils"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/p2p"
@@ -27,8 +31,13 @@ type Client struct {
 	params     *Parameters
 	protocolID protocol.ID
 	host       host.Host
+	// lazyPeers are trusted peers, whose EDSes may be requested without validation
+	lazyPeers map[peer.ID]struct{}
 
 	metrics *p2p.Metrics
+
+	// deadlines give requests without a deadline a timeout by the size of the requested square.
+	deadlines atomic.Pointer[share.DeadlineProfiles]
 }
 
 // NewClient creates a new ShrEx/EDS client.
@@ -36,21 +45,80 @@ func NewClient(params *Parameters, host host.Host) (*Client, error) {
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("shrex-eds: client creation failed: %w", err)
 	}
+	lazyPeers, err := params.lazyValidationPeers()
+	if err != nil {
+		return nil, fmt.Errorf("shrex-eds: client creation failed: %w", err)
+	}
 
 	return &Client{
 		params:     params,
 		host:       host,
-		protocolID: p2p.ProtocolID(params.NetworkID(), protocolString),
+		protocolID: ProtocolID(params.NetworkID(), params.ProtocolEpoch()),
+		lazyPeers:  lazyPeers,
 	}, nil
 }
 
+// SetDeadlineProfiles sets the timeouts of requests without a deadline by the size of the requested
+// square, as set with share.WithSquareSize. Requests of squares of unknown size get the timeout of
+// the biggest squares. Requests without a deadline are otherwise bounded by the server timeouts only.
+func (c *Client) SetDeadlineProfiles(profiles share.DeadlineProfiles) {
+	c.deadlines.Store(&profiles)
+}
+
 // RequestEDS requests the ODS from the given peers and returns the EDS upon success.
 func (c *Client) RequestEDS(
 	ctx context.Context,
 	dataHash share.DataHash,
 	peer peer.ID,
 ) (*rsmt2d.ExtendedDataSquare, error) {
-	eds, err := c.doRequest(ctx, dataHash, peer)
+	return c.requestEDS(ctx, dataHash, peer, true)
+}
+
+// IsLazyValidated reports whether the peer is trusted to have its EDSes requested without
+// validation.
+func (c *Client) IsLazyValidated(peer peer.ID) bool {
+	_, ok := c.lazyPeers[peer]
+	return ok
+}
+
+// RequestUnverifiedEDS requests the ODS from the given trusted peer and returns the EDS upon
+// success, like RequestEDS, but skips validation of the EDS against the dataHash. The caller is
+// responsible for validating the EDS afterwards with share.ValidateAgainstRoot.
+func (c *Client) RequestUnverifiedEDS(
+	ctx context.Context,
+	dataHash share.DataHash,
+	peer peer.ID,
+) (*rsmt2d.ExtendedDataSquare, error) {
+	if !c.IsLazyValidated(peer) {
+		return nil, fmt.Errorf("shrex-eds: peer %s is not trusted for lazy validation", peer)
+	}
+	return c.requestEDS(ctx, dataHash, peer, false)
+}
+
+func (c *Client) requestEDS(
+	ctx context.Context,
+	dataHash share.DataHash,
+	peer peer.ID,
+	verify bool,
+) (eds *rsmt2d.ExtendedDataSquare, err error) {
+	ctx, span := tracer.Start(ctx, "shrex/eds/request", trace.WithAttributes(
+		share.TraceAttributes(ctx, dataHash, nil,
+			attribute.Stringer(share.PeerAttribute, peer),
+			attribute.Bool("verify", verify),
+		)...,
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	if _, ok := ctx.Deadline(); !ok {
+		if deadlines := c.deadlines.Load(); deadlines != nil {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, deadlines.TimeoutFromContext(ctx))
+			defer cancel()
+		}
+	}
+	eds, err = c.doRequest(ctx, dataHash, peer, verify)
 	if err == nil {
 		return eds, nil
 	}
@@ -59,6 +127,11 @@ func (c *Client) RequestEDS(
 		c.metrics.ObserveRequests(ctx, 1, p2p.StatusTimeout)
 		return nil, err
 	}
+	if errors.Is(err, p2p.ErrWrongEpoch) {
+		c.metrics.ObserveRequests(ctx, 1, p2p.StatusWrongEpoch)
+		log.Warnw("client: peer runs shrex/eds in another epoch", "err", err)
+		return nil, err
+	}
 	// some net.Errors also mean the context deadline was exceeded, but yamux/mocknet do not
 	// unwrap to a ctx err
 	var ne net.Error
@@ -68,7 +141,7 @@ func (c *Client) RequestEDS(
 			return nil, context.DeadlineExceeded
 		}
 	}
-	if err != p2p.ErrNotFound {
+	if !errors.Is(err, p2p.ErrNotFound) {
 		log.Warnw("client: eds request to peer failed",
 			"peer", peer.String(),
 			"hash", dataHash.String(),
@@ -82,17 +155,26 @@ func (c *Client) doRequest(
 	ctx context.Context,
 	dataHash share.DataHash,
 	to peer.ID,
+	verify bool,
 ) (*rsmt2d.ExtendedDataSquare, error) {
 	streamOpenCtx, cancel := context.WithTimeout(ctx, c.params.ServerReadTimeout)
 	defer cancel()
 	stream, err := c.host.NewStream(streamOpenCtx, to, c.protocolID)
 	if err != nil {
+		if epochErr := p2p.CheckEpoch(c.host, to, c.protocolID); epochErr != nil {
+			return nil, epochErr
+		}
 		return nil, fmt.Errorf("failed to open stream: %w", err)
 	}
 
 	c.setStreamDeadlines(ctx, stream)
 
-	req := &pb.EDSRequest{Hash: dataHash}
+	height, _ := share.HeightFromContext(ctx)
+	req := &pb.EDSRequest{
+		Hash:        dataHash,
+		Traceparent: p2p.TraceParent(ctx),
+		Height:      height,
+	}
 
 	// request ODS
 	log.Debugw("client: requesting ods", "hash", dataHash.String(), "peer", to.String())
@@ -117,7 +199,7 @@ func (c *Client) doRequest(
 		// server closes the stream here if we are rate limited
 		if errors.Is(err, io.EOF) {
 			c.metrics.ObserveRequests(ctx, 1, p2p.StatusRateLimited)
-			return nil, p2p.ErrNotFound
+			return nil, p2p.ErrRateLimited
 		}
 		stream.Reset() //nolint:errcheck
 		return nil, fmt.Errorf("failed to read status from stream: %w", err)
@@ -127,13 +209,19 @@ func (c *Client) doRequest(
 	case pb.Status_OK:
 		// reset stream deadlines to original values, since read deadline was changed during status read
 		c.setStreamDeadlines(ctx, stream)
-		// use header and ODS bytes to construct EDS and verify it against dataHash
-		eds, err := eds.ReadEDS(ctx, stream, dataHash)
+		// use header and ODS bytes to construct EDS and verify it against dataHash, unless the peer is
+		// trusted and the EDS is validated later by the caller
+		var square *rsmt2d.ExtendedDataSquare
+		if verify {
+			square, err = eds.ReadEDS(ctx, stream, dataHash)
+		} else {
+			square, err = eds.ReadUnverifiedEDS(ctx, stream)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to read eds from ods bytes: %w", err)
 		}
 		c.metrics.ObserveRequests(ctx, 1, p2p.StatusSuccess)
-		return eds, nil
+		return square, nil
 	case pb.Status_NOT_FOUND:
 		c.metrics.ObserveRequests(ctx, 1, p2p.StatusNotFound)
 		return nil, p2p.ErrNotFound
@@ -13,12 +13,19 @@ import (
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"golang.org/x/exp/slices"
 
 	"github.com/celestiaorg/celestia-app/pkg/da"
 
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/p2p"
+	pb "github.com/celestiaorg/celestia-node/share/p2p/shrexeds/pb"
 )
 
 func TestExchange_RequestEDS(t *testing.T) {
@@ -125,6 +132,137 @@ func TestExchange_RequestEDS(t *testing.T) {
 	})
 }
 
+func TestExchange_RequestUnverifiedEDS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store := newStore(t)
+	hosts := createMocknet(t, 3)
+	server, err := NewServer(DefaultParameters(), hosts[0], store)
+	require.NoError(t, err)
+	require.NoError(t, store.Start(ctx))
+	require.NoError(t, server.Start(ctx))
+
+	params := DefaultParameters()
+	params.LazyValidationPeers = []string{hosts[0].ID().String()}
+	client, err := NewClient(params, hosts[1])
+	require.NoError(t, err)
+
+	// store the EDS under the hash of another EDS, so that it is invalid for the requested hash
+	eds := share.RandEDS(t, 4)
+	dah := da.NewDataAvailabilityHeader(share.RandEDS(t, 4))
+	err = store.Put(ctx, dah.Hash(), eds)
+	require.NoError(t, err)
+
+	_, err = client.RequestEDS(ctx, dah.Hash(), server.host.ID())
+	require.Error(t, err)
+
+	// the trusted peer is not validated
+	requestedEDS, err := client.RequestUnverifiedEDS(ctx, dah.Hash(), server.host.ID())
+	require.NoError(t, err)
+	assert.Equal(t, eds.Flattened(), requestedEDS.Flattened())
+
+	// other peers are always validated
+	_, err = client.RequestUnverifiedEDS(ctx, dah.Hash(), hosts[2].ID())
+	require.Error(t, err)
+}
+
+func TestExchange_WrongEpoch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	store := newStore(t)
+	hosts := createMocknet(t, 2)
+	// the server upgraded through a breaking fork, while the client did not
+	serverParams := DefaultParameters()
+	serverParams.WithProtocolEpoch(1)
+	server, err := NewServer(serverParams, hosts[0], store)
+	require.NoError(t, err)
+	require.NoError(t, store.Start(ctx))
+	require.NoError(t, server.Start(ctx))
+
+	client, err := NewClient(DefaultParameters(), hosts[1])
+	require.NoError(t, err)
+
+	// wait for the client to learn the protocols of the server
+	require.Eventually(t, func() bool {
+		protocols, err := hosts[1].Peerstore().GetProtocols(hosts[0].ID())
+		return err == nil && slices.Contains(protocols, server.protocolID)
+	}, time.Second*3, time.Millisecond*10)
+
+	dah := da.NewDataAvailabilityHeader(share.RandEDS(t, 4))
+	_, err = client.RequestEDS(ctx, dah.Hash(), server.host.ID())
+	require.ErrorIs(t, err, p2p.ErrWrongEpoch)
+}
+
+func TestExchange_Trace(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prevProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+	})
+
+	store, client, server := makeExchange(t)
+	require.NoError(t, store.Start(ctx))
+	require.NoError(t, server.Start(ctx))
+
+	square := share.RandEDS(t, 4)
+	dah := da.NewDataAvailabilityHeader(square)
+	require.NoError(t, store.Put(ctx, dah.Hash(), square))
+	missingDAH := da.NewDataAvailabilityHeader(share.RandEDS(t, 4))
+
+	// serveSpan waits for the span of the server serving the request of the given client span
+	serveSpan := func(request sdktrace.ReadOnlySpan) sdktrace.ReadOnlySpan {
+		var serve sdktrace.ReadOnlySpan
+		require.Eventually(t, func() bool {
+			for _, span := range recorder.Ended() {
+				if span.Name() == "shrex/eds/serve" && span.Parent().SpanID() == request.SpanContext().SpanID() {
+					serve = span
+					return true
+				}
+			}
+			return false
+		}, time.Second, time.Millisecond*10)
+		return serve
+	}
+	requestSpan := func(hash share.DataHash) sdktrace.ReadOnlySpan {
+		for _, span := range recorder.Ended() {
+			if span.Name() != "shrex/eds/request" {
+				continue
+			}
+			for _, attr := range span.Attributes() {
+				if attr.Key == share.DataHashAttribute && attr.Value.AsString() == hash.String() {
+					return span
+				}
+			}
+		}
+		require.FailNow(t, "no request span")
+		return nil
+	}
+
+	_, err := client.RequestEDS(share.WithHeight(ctx, 42), dah.Hash(), server.host.ID())
+	require.NoError(t, err)
+	request := requestSpan(dah.Hash())
+	serve := serveSpan(request)
+	// the server span continues the trace of the client
+	assert.Equal(t, request.SpanContext().TraceID(), serve.SpanContext().TraceID())
+	assert.Equal(t, codes.Ok, serve.Status().Code)
+	assert.Contains(t, serve.Attributes(), attribute.Int64(share.HeightAttribute, 42))
+	assert.Contains(t, serve.Attributes(), attribute.String("status", pb.Status_OK.String()))
+
+	_, err = client.RequestEDS(ctx, missingDAH.Hash(), server.host.ID())
+	require.ErrorIs(t, err, p2p.ErrNotFound)
+	serve = serveSpan(requestSpan(missingDAH.Hash()))
+	// serves of unavailable EDSes are recorded as failed
+	assert.Equal(t, codes.Error, serve.Status().Code)
+	assert.Contains(t, serve.Attributes(), attribute.String("status", pb.Status_NOT_FOUND.String()))
+}
+
 func newStore(t *testing.T) *eds.Store {
 	t.Helper()
 
@@ -4,13 +4,24 @@ import (
 	"fmt"
 
 	logging "github.com/ipfs/go-log/v2"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"go.opentelemetry.io/otel"
 
 	"github.com/celestiaorg/celestia-node/share/p2p"
 )
 
 const protocolString = "/shrex/eds/v0.0.1"
 
-var log = logging.Logger("shrex/eds")
+var (
+	log    = logging.Logger("shrex/eds")
+	tracer = otel.Tracer("shrex/eds")
+)
+
+// ProtocolID returns the ID of the shrex/eds protocol of the given network and protocol epoch.
+func ProtocolID(networkID string, epoch uint) protocol.ID {
+	return p2p.ProtocolID(networkID, epoch, protocolString)
+}
 
 // Parameters is the set of parameters that must be configured for the shrex/eds protocol.
 type Parameters struct {
@@ -18,6 +29,12 @@ type Parameters struct {
 
 	// BufferSize defines the size of the buffer used for writing an ODS over the stream.
 	BufferSize uint64
+
+	// LazyValidationPeers lists IDs of trusted peers, whose EDSes are accepted and stored before
+	// being validated. An invalid EDS is then removed from the store and fetched again from another
+	// peer, while its peer is blacklisted. It is meant for private replication setups, e.g.
+	// between bridge and full nodes of the same operator.
+	LazyValidationPeers []string
 }
 
 func DefaultParameters() *Parameters {
@@ -31,10 +48,26 @@ func (p *Parameters) Validate() error {
 	if p.BufferSize <= 0 {
 		return fmt.Errorf("invalid buffer size: %v, value should be positive and non-zero", p.BufferSize)
 	}
+	if _, err := p.lazyValidationPeers(); err != nil {
+		return err
+	}
 
 	return p.Parameters.Validate()
 }
 
+// lazyValidationPeers parses LazyValidationPeers into a set of peer IDs.
+func (p *Parameters) lazyValidationPeers() (map[peer.ID]struct{}, error) {
+	peers := make(map[peer.ID]struct{}, len(p.LazyValidationPeers))
+	for _, s := range p.LazyValidationPeers {
+		id, err := peer.Decode(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lazy validation peer %s: %w", s, err)
+		}
+		peers[id] = struct{}{}
+	}
+	return peers, nil
+}
+
 func (c *Client) WithMetrics() error {
 	metrics, err := p2p.InitClientMetrics("eds")
 	if err != nil {
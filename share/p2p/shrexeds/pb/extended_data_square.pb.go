@@ -54,7 +54,9 @@ func (Status) EnumDescriptor() ([]byte, []int) {
 }
 
 type EDSRequest struct {
-	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Hash        []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Traceparent string `protobuf:"bytes,2,opt,name=traceparent,proto3" json:"traceparent,omitempty"`
+	Height      uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
 }
 
 func (m *EDSRequest) Reset()         { *m = EDSRequest{} }
@@ -97,6 +99,20 @@ func (m *EDSRequest) GetHash() []byte {
 	return nil
 }
 
+func (m *EDSRequest) GetTraceparent() string {
+	if m != nil {
+		return m.Traceparent
+	}
+	return ""
+}
+
+func (m *EDSRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 type EDSResponse struct {
 	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=Status" json:"status,omitempty"`
 }
@@ -152,22 +168,24 @@ func init() {
 }
 
 var fileDescriptor_49d42aa96098056e = []byte{
-	// 227 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x28, 0xce, 0x48, 0x2c,
-	0x4a, 0xd5, 0x2f, 0x30, 0x2a, 0xd0, 0x2f, 0xce, 0x28, 0x4a, 0xad, 0x48, 0x4d, 0x29, 0xd6, 0x2f,
-	0x48, 0xd2, 0x4f, 0xad, 0x28, 0x49, 0xcd, 0x4b, 0x49, 0x4d, 0x89, 0x4f, 0x49, 0x2c, 0x49, 0x8c,
-	0x2f, 0x2e, 0x2c, 0x4d, 0x2c, 0x4a, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x57, 0x52, 0xe0, 0xe2,
-	0x72, 0x75, 0x09, 0x0e, 0x4a, 0x2d, 0x2c, 0x4d, 0x2d, 0x2e, 0x11, 0x12, 0xe2, 0x62, 0xc9, 0x48,
-	0x2c, 0xce, 0x90, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x09, 0x02, 0xb3, 0x95, 0xf4, 0xb8, 0xb8, 0xc1,
-	0x2a, 0x8a, 0x0b, 0xf2, 0xf3, 0x8a, 0x53, 0x85, 0xe4, 0xb9, 0xd8, 0x8a, 0x4b, 0x12, 0x4b, 0x4a,
-	0x8b, 0xc1, 0x8a, 0xf8, 0x8c, 0xd8, 0xf5, 0x82, 0xc1, 0xdc, 0x20, 0xa8, 0xb0, 0x96, 0x15, 0x17,
-	0x1b, 0x44, 0x44, 0x88, 0x9b, 0x8b, 0xdd, 0xd3, 0x2f, 0xcc, 0xd1, 0xc7, 0xd3, 0x45, 0x80, 0x41,
-	0x88, 0x8d, 0x8b, 0xc9, 0xdf, 0x5b, 0x80, 0x51, 0x88, 0x97, 0x8b, 0xd3, 0xcf, 0x3f, 0x24, 0xde,
-	0xcd, 0x3f, 0xd4, 0xcf, 0x45, 0x80, 0x49, 0x88, 0x87, 0x8b, 0xc3, 0xd3, 0x2f, 0xc4, 0x35, 0xc8,
-	0xcf, 0xd1, 0x47, 0x80, 0xd9, 0x49, 0xe2, 0xc4, 0x23, 0x39, 0xc6, 0x0b, 0x8f, 0xe4, 0x18, 0x1f,
-	0x3c, 0x92, 0x63, 0x9c, 0xf0, 0x58, 0x8e, 0xe1, 0xc2, 0x63, 0x39, 0x86, 0x1b, 0x8f, 0xe5, 0x18,
-	0x92, 0xd8, 0xc0, 0xce, 0x35, 0x06, 0x04, 0x00, 0x00, 0xff, 0xff, 0x7b, 0x1d, 0xd4, 0xa7, 0xe2,
-	0x00, 0x00, 0x00,
+	// 265 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x8f, 0x31, 0x4b, 0xf3, 0x40,
+	0x1c, 0x87, 0x73, 0x6d, 0x49, 0xdf, 0xfe, 0xd3, 0x57, 0xc2, 0x0d, 0x92, 0xe9, 0x0c, 0x9d, 0x82,
+	0x43, 0x22, 0x75, 0x73, 0xab, 0xa4, 0x42, 0xb0, 0x24, 0x70, 0xad, 0x0e, 0x2e, 0xe1, 0x6a, 0xfe,
+	0x78, 0x2e, 0xc9, 0xf5, 0xee, 0x02, 0xfd, 0x18, 0x7e, 0x2c, 0xc7, 0x8e, 0x8e, 0x92, 0x7c, 0x11,
+	0x21, 0x76, 0x70, 0xfb, 0x3d, 0xcf, 0xf0, 0xc0, 0x0f, 0x6e, 0x8c, 0x14, 0x1a, 0x13, 0xb5, 0x54,
+	0x89, 0x91, 0x1a, 0x8f, 0x58, 0x99, 0x44, 0xed, 0x13, 0x3c, 0x5a, 0xac, 0x2b, 0xac, 0xca, 0x4a,
+	0x58, 0x51, 0x9a, 0x43, 0x2b, 0x34, 0xc6, 0x4a, 0x37, 0xb6, 0x59, 0xbc, 0x00, 0xac, 0xd3, 0x2d,
+	0xc7, 0x43, 0x8b, 0xc6, 0x52, 0x0a, 0x13, 0x29, 0x8c, 0x0c, 0x48, 0x48, 0xa2, 0x39, 0x1f, 0x36,
+	0x0d, 0xc1, 0xb3, 0x5a, 0xbc, 0xa2, 0x12, 0x1a, 0x6b, 0x1b, 0x8c, 0x42, 0x12, 0xcd, 0xf8, 0x5f,
+	0x45, 0x2f, 0xc1, 0x95, 0xf8, 0xfe, 0x26, 0x6d, 0x30, 0x0e, 0x49, 0x34, 0xe1, 0x67, 0x5a, 0xc4,
+	0xe0, 0x0d, 0x6d, 0xa3, 0x9a, 0xda, 0x20, 0xbd, 0x02, 0xd7, 0x58, 0x61, 0x5b, 0x33, 0xe4, 0x2f,
+	0x96, 0xd3, 0x78, 0x3b, 0x20, 0x3f, 0xeb, 0xeb, 0x3b, 0x70, 0x7f, 0x0d, 0xf5, 0x60, 0x9a, 0xe5,
+	0xcf, 0xab, 0x4d, 0x96, 0xfa, 0x0e, 0x75, 0x61, 0x54, 0x3c, 0xfa, 0x84, 0xfe, 0x87, 0x59, 0x5e,
+	0xec, 0xca, 0x87, 0xe2, 0x29, 0x4f, 0xfd, 0x11, 0x9d, 0xc3, 0xbf, 0x2c, 0xdf, 0xad, 0x79, 0xbe,
+	0xda, 0xf8, 0xe3, 0xfb, 0xe0, 0xb3, 0x63, 0xe4, 0xd4, 0x31, 0xf2, 0xdd, 0x31, 0xf2, 0xd1, 0x33,
+	0xe7, 0xd4, 0x33, 0xe7, 0xab, 0x67, 0xce, 0xde, 0x1d, 0x8e, 0xde, 0xfe, 0x04, 0x00, 0x00, 0xff,
+	0xff, 0x79, 0x76, 0x96, 0x8c, 0x1c, 0x01, 0x00, 0x00,
 }
 
 func (m *EDSRequest) Marshal() (dAtA []byte, err error) {
@@ -190,6 +208,18 @@ func (m *EDSRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Height != 0 {
+		i = encodeVarintExtendedDataSquare(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Traceparent) > 0 {
+		i -= len(m.Traceparent)
+		copy(dAtA[i:], m.Traceparent)
+		i = encodeVarintExtendedDataSquare(dAtA, i, uint64(len(m.Traceparent)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.Hash) > 0 {
 		i -= len(m.Hash)
 		copy(dAtA[i:], m.Hash)
@@ -249,6 +279,13 @@ func (m *EDSRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovExtendedDataSquare(uint64(l))
 	}
+	l = len(m.Traceparent)
+	if l > 0 {
+		n += 1 + l + sovExtendedDataSquare(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovExtendedDataSquare(uint64(m.Height))
+	}
 	return n
 }
 
@@ -333,6 +370,57 @@ func (m *EDSRequest) Unmarshal(dAtA []byte) error {
 				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Traceparent", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExtendedDataSquare
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExtendedDataSquare
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExtendedDataSquare
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Traceparent = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExtendedDataSquare
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipExtendedDataSquare(dAtA[iNdEx:])
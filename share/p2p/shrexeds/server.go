@@ -10,10 +10,14 @@ import (
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/celestiaorg/go-libp2p-messenger/serde"
 
+	"github.com/celestiaorg/celestia-node/libs/bufpool"
+	"github.com/celestiaorg/celestia-node/libs/utils"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/p2p"
@@ -44,7 +48,7 @@ func NewServer(params *Parameters, host host.Host, store *eds.Store) (*Server, e
 	return &Server{
 		host:       host,
 		store:      store,
-		protocolID: p2p.ProtocolID(params.NetworkID(), protocolString),
+		protocolID: ProtocolID(params.NetworkID(), params.ProtocolEpoch()),
 		params:     params,
 		middleware: p2p.NewMiddleware(params.ConcurrencyLimit),
 	}, nil
@@ -95,11 +99,23 @@ func (s *Server) handleStream(stream network.Stream) {
 
 	ctx, cancel := context.WithTimeout(s.ctx, s.params.HandleRequestTimeout)
 	defer cancel()
+	ctx = p2p.ContextWithTraceParent(ctx, req.Traceparent)
+	attrs := append(p2p.HeightAttributes(req.Height),
+		attribute.Stringer(share.PeerAttribute, stream.Conn().RemotePeer()),
+	)
+	ctx, span := tracer.Start(ctx, "shrex/eds/serve", trace.WithAttributes(
+		share.TraceAttributes(ctx, hash, nil, attrs...)...,
+	))
+	// err records the outcome of serving the request, i.e. the served status or the failure to
+	// write the response
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
 
 	// determine whether the EDS is available in our store
 	// we do not close the reader, so that other requests will not need to re-open the file.
 	// closing is handled by the LRU cache.
-	edsReader, err := s.store.GetCAR(ctx, hash)
+	odsReader, err := s.store.GetODS(ctx, hash)
 	status := p2p_pb.Status_OK
 	switch {
 	case errors.Is(err, eds.ErrNotFound):
@@ -107,28 +123,28 @@ func (s *Server) handleStream(stream network.Stream) {
 		s.metrics.ObserveRequests(ctx, 1, p2p.StatusNotFound)
 		status = p2p_pb.Status_NOT_FOUND
 	case err != nil:
-		logger.Errorw("server: get CAR", "err", err)
+		logger.Errorw("server: get ODS", "err", err)
 		status = p2p_pb.Status_INTERNAL
 	}
+	span.SetAttributes(attribute.Stringer("status", status))
 
 	// inform the client of our status
-	err = s.writeStatus(logger, status, stream)
-	if err != nil {
-		logger.Warnw("server: writing status to stream", "err", err)
+	if werr := s.writeStatus(logger, status, stream); werr != nil {
+		logger.Warnw("server: writing status to stream", "err", werr)
 		stream.Reset() //nolint:errcheck
+		err = werr
 		return
 	}
 	// if we cannot serve the EDS, we are already done
 	if status != p2p_pb.Status_OK {
-		err = stream.Close()
-		if err != nil {
-			logger.Debugw("server: closing stream", "err", err)
+		if cerr := stream.Close(); cerr != nil {
+			logger.Debugw("server: closing stream", "err", cerr)
 		}
 		return
 	}
 
 	// start streaming the ODS to the client
-	err = s.writeODS(logger, edsReader, stream)
+	err = s.writeODS(logger, odsReader, stream)
 	if err != nil {
 		logger.Warnw("server: writing ods to stream", "err", err)
 		stream.Reset() //nolint:errcheck
@@ -136,9 +152,8 @@ func (s *Server) handleStream(stream network.Stream) {
 	}
 
 	s.metrics.ObserveRequests(ctx, 1, p2p.StatusSuccess)
-	err = stream.Close()
-	if err != nil {
-		logger.Debugw("server: closing stream", "err", err)
+	if cerr := stream.Close(); cerr != nil {
+		logger.Debugw("server: closing stream", "err", cerr)
 	}
 }
 
@@ -172,21 +187,23 @@ func (s *Server) writeStatus(logger *zap.SugaredLogger, status p2p_pb.Status, st
 	return err
 }
 
-func (s *Server) writeODS(logger *zap.SugaredLogger, edsReader io.Reader, stream network.Stream) error {
+func (s *Server) writeODS(logger *zap.SugaredLogger, odsReader io.Reader, stream network.Stream) error {
 	err := stream.SetWriteDeadline(time.Now().Add(s.params.ServerWriteTimeout))
 	if err != nil {
 		logger.Debugw("server: set read deadline", "err", err)
 	}
 
-	odsReader, err := eds.ODSReader(edsReader)
-	if err != nil {
-		return fmt.Errorf("creating ODS reader: %w", err)
-	}
-	buf := make([]byte, s.params.BufferSize)
-	_, err = io.CopyBuffer(stream, odsReader, buf)
+	buf := bufpool.Get(int(s.params.BufferSize))
+	defer buf.Release()
+	_, err = io.CopyBuffer(stream, odsReader, buf.Bytes)
 	if err != nil {
 		return fmt.Errorf("writing ODS bytes: %w", err)
 	}
 
 	return nil
 }
+
+// SetConcurrencyLimit changes the maximum number of concurrently handled streams.
+func (s *Server) SetConcurrencyLimit(limit int) {
+	s.middleware.SetConcurrencyLimit(limit)
+}
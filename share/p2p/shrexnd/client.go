@@ -6,23 +6,32 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"sync/atomic"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/celestiaorg/go-libp2p-messenger/serde"
 	"github.com/celestiaorg/nmt"
 	"github.com/celestiaorg/nmt/namespace"
 
+	"github.com/celestiaorg/celestia-node/libs/utils"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/ipld"
 	"github.com/celestiaorg/celestia-node/share/p2p"
 	pb "github.com/celestiaorg/celestia-node/share/p2p/shrexnd/pb"
 )
 
+// ErrRedirectToEDS is returned when the server refuses to serve the namespace over shrex/nd, as
+// the namespace covers most of the square. The whole EDS should be requested over shrex/eds
+// instead.
+var ErrRedirectToEDS = errors.New("namespace should be requested as a whole EDS")
+
 // Client implements client side of shrex/nd protocol to obtain namespaced shares data from remote
 // peers.
 type Client struct {
@@ -31,6 +40,9 @@ type Client struct {
 
 	host    host.Host
 	metrics *p2p.Metrics
+
+	// deadlines give requests without a deadline a timeout by the size of the requested square.
+	deadlines atomic.Pointer[share.DeadlineProfiles]
 }
 
 // NewClient creates a new shrEx/nd client
@@ -41,11 +53,17 @@ func NewClient(params *Parameters, host host.Host) (*Client, error) {
 
 	return &Client{
 		host:       host,
-		protocolID: p2p.ProtocolID(params.NetworkID(), protocolString),
+		protocolID: p2p.ProtocolID(params.NetworkID(), params.ProtocolEpoch(), protocolString),
 		params:     params,
 	}, nil
 }
 
+// SetDeadlineProfiles sets the timeouts of requests without a deadline by the size of the
+// requested square. Requests without a deadline are otherwise bounded by the server timeouts only.
+func (c *Client) SetDeadlineProfiles(profiles share.DeadlineProfiles) {
+	c.deadlines.Store(&profiles)
+}
+
 // RequestND requests namespaced data from the given peer.
 // Returns valid data with its verified inclusion against the share.Root.
 func (c *Client) RequestND(
@@ -53,8 +71,24 @@ func (c *Client) RequestND(
 	root *share.Root,
 	nID namespace.ID,
 	peer peer.ID,
-) (share.NamespacedShares, error) {
-	shares, err := c.doRequest(ctx, root, nID, peer)
+) (shares share.NamespacedShares, err error) {
+	ctx, span := tracer.Start(ctx, "shrex/nd/request", trace.WithAttributes(
+		share.TraceAttributes(ctx, root.Hash(), nID,
+			attribute.Stringer(share.PeerAttribute, peer),
+		)...,
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
+
+	if _, ok := ctx.Deadline(); !ok {
+		if deadlines := c.deadlines.Load(); deadlines != nil {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, deadlines.TimeoutFor(root))
+			defer cancel()
+		}
+	}
+	shares, err = c.doRequest(ctx, root, nID, peer)
 	if err == nil {
 		return shares, err
 	}
@@ -62,6 +96,11 @@ func (c *Client) RequestND(
 		c.metrics.ObserveRequests(ctx, 1, p2p.StatusTimeout)
 		return nil, err
 	}
+	if errors.Is(err, p2p.ErrWrongEpoch) {
+		c.metrics.ObserveRequests(ctx, 1, p2p.StatusWrongEpoch)
+		log.Warnw("client-nd: peer runs shrex/nd in another epoch", "err", err)
+		return nil, err
+	}
 	// some net.Errors also mean the context deadline was exceeded, but yamux/mocknet do not
 	// unwrap to a ctx err
 	var ne net.Error
@@ -71,7 +110,7 @@ func (c *Client) RequestND(
 			return nil, context.DeadlineExceeded
 		}
 	}
-	if err != p2p.ErrNotFound && err != share.ErrNamespaceNotFound {
+	if !errors.Is(err, p2p.ErrNotFound) && err != share.ErrNamespaceNotFound && !errors.Is(err, ErrRedirectToEDS) {
 		log.Warnw("client-nd: peer returned err", "err", err)
 	}
 	return nil, err
@@ -85,15 +124,21 @@ func (c *Client) doRequest(
 ) (share.NamespacedShares, error) {
 	stream, err := c.host.NewStream(ctx, peerID, c.protocolID)
 	if err != nil {
+		if epochErr := p2p.CheckEpoch(c.host, peerID, c.protocolID); epochErr != nil {
+			return nil, epochErr
+		}
 		return nil, err
 	}
 	defer stream.Close()
 
 	c.setStreamDeadlines(ctx, stream)
 
+	height, _ := share.HeightFromContext(ctx)
 	req := &pb.GetSharesByNamespaceRequest{
 		RootHash:    root.Hash(),
 		NamespaceId: nID,
+		Traceparent: p2p.TraceParent(ctx),
+		Height:      height,
 	}
 
 	_, err = serde.Write(stream, req)
@@ -113,7 +158,7 @@ func (c *Client) doRequest(
 		// server is overloaded and closed the stream
 		if errors.Is(err, io.EOF) {
 			c.metrics.ObserveRequests(ctx, 1, p2p.StatusRateLimited)
-			return nil, p2p.ErrNotFound
+			return nil, p2p.ErrRateLimited
 		}
 		stream.Reset() //nolint:errcheck
 		return nil, fmt.Errorf("client-nd: reading response: %w", err)
@@ -191,6 +236,9 @@ func (c *Client) statusToErr(ctx context.Context, code pb.StatusCode) error {
 		return p2p.ErrNotFound
 	case pb.StatusCode_NAMESPACE_NOT_FOUND:
 		return share.ErrNamespaceNotFound
+	case pb.StatusCode_REDIRECT_TO_EDS:
+		c.metrics.ObserveRequests(ctx, 1, p2p.StatusRedirected)
+		return ErrRedirectToEDS
 	case pb.StatusCode_INVALID:
 		log.Debug("client-nd: invalid request")
 		fallthrough
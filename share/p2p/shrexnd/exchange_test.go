@@ -15,6 +15,7 @@ import (
 
 	"github.com/celestiaorg/celestia-app/pkg/da"
 	"github.com/celestiaorg/celestia-app/pkg/namespace"
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
 	nmtnamespace "github.com/celestiaorg/nmt/namespace"
 	"github.com/celestiaorg/rsmt2d"
 
@@ -54,6 +55,32 @@ func TestExchange_RequestND_NotFound(t *testing.T) {
 	})
 }
 
+func TestExchange_RequestND_RedirectToEDS(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	edsStore, client, server := makeExchange(t, notFoundGetter{}, WithRedirectToEDSThreshold(8))
+	require.NoError(t, edsStore.Start(ctx))
+	require.NoError(t, server.Start(ctx))
+
+	// the first namespace of the square fully covers its first two rows
+	shares := share.RandShares(t, 16)
+	nID := nmtnamespace.ID(shares[0][:share.NamespaceSize])
+	for _, sh := range shares[1:8] {
+		copy(sh[:share.NamespaceSize], nID)
+	}
+	eds, err := rsmt2d.ComputeExtendedDataSquare(shares, share.DefaultRSMT2DCodec(), wrapper.NewConstructor(4))
+	require.NoError(t, err)
+	dah := da.NewDataAvailabilityHeader(eds)
+	require.NoError(t, edsStore.Put(ctx, dah.Hash(), eds))
+
+	_, err = client.RequestND(ctx, &dah, nID, server.host.ID())
+	require.ErrorIs(t, err, ErrRedirectToEDS)
+
+	// namespaces below the threshold are served as usual
+	_, err = client.RequestND(ctx, &dah, shares[8][:share.NamespaceSize], server.host.ID())
+	require.ErrorIs(t, err, share.ErrNamespaceNotFound)
+}
+
 func TestExchange_RequestND(t *testing.T) {
 	t.Run("ND_concurrency_limit", func(t *testing.T) {
 		net, err := mocknet.FullMeshConnected(2)
@@ -141,14 +168,14 @@ func createMocknet(t *testing.T, amount int) []libhost.Host {
 	return net.Hosts()
 }
 
-func makeExchange(t *testing.T, getter share.Getter) (*eds.Store, *Client, *Server) {
+func makeExchange(t *testing.T, getter share.Getter, opts ...Option) (*eds.Store, *Client, *Server) {
 	t.Helper()
 	store := newStore(t)
 	hosts := createMocknet(t, 2)
 
 	client, err := NewClient(DefaultParameters(), hosts[0])
 	require.NoError(t, err)
-	server, err := NewServer(DefaultParameters(), hosts[1], store, getter)
+	server, err := NewServer(DefaultParameters(), hosts[1], store, getter, opts...)
 	require.NoError(t, err)
 
 	return store, client, server
@@ -4,13 +4,17 @@ import (
 	"fmt"
 
 	logging "github.com/ipfs/go-log/v2"
+	"go.opentelemetry.io/otel"
 
 	"github.com/celestiaorg/celestia-node/share/p2p"
 )
 
 const protocolString = "/shrex/nd/v0.0.2"
 
-var log = logging.Logger("shrex/nd")
+var (
+	log    = logging.Logger("shrex/nd")
+	tracer = otel.Tracer("shrex/nd")
+)
 
 // Parameters is the set of parameters that must be configured for the shrex/eds protocol.
 type Parameters = p2p.Parameters
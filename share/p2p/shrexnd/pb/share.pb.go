@@ -30,6 +30,7 @@ const (
 	StatusCode_NOT_FOUND           StatusCode = 2
 	StatusCode_INTERNAL            StatusCode = 3
 	StatusCode_NAMESPACE_NOT_FOUND StatusCode = 4
+	StatusCode_REDIRECT_TO_EDS     StatusCode = 5
 )
 
 var StatusCode_name = map[int32]string{
@@ -38,6 +39,7 @@ var StatusCode_name = map[int32]string{
 	2: "NOT_FOUND",
 	3: "INTERNAL",
 	4: "NAMESPACE_NOT_FOUND",
+	5: "REDIRECT_TO_EDS",
 }
 
 var StatusCode_value = map[string]int32{
@@ -46,6 +48,7 @@ var StatusCode_value = map[string]int32{
 	"NOT_FOUND":           2,
 	"INTERNAL":            3,
 	"NAMESPACE_NOT_FOUND": 4,
+	"REDIRECT_TO_EDS":     5,
 }
 
 func (x StatusCode) String() string {
@@ -59,6 +62,8 @@ func (StatusCode) EnumDescriptor() ([]byte, []int) {
 type GetSharesByNamespaceRequest struct {
 	RootHash    []byte `protobuf:"bytes,1,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
 	NamespaceId []byte `protobuf:"bytes,2,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	Traceparent string `protobuf:"bytes,3,opt,name=traceparent,proto3" json:"traceparent,omitempty"`
+	Height      uint64 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
 }
 
 func (m *GetSharesByNamespaceRequest) Reset()         { *m = GetSharesByNamespaceRequest{} }
@@ -108,6 +113,20 @@ func (m *GetSharesByNamespaceRequest) GetNamespaceId() []byte {
 	return nil
 }
 
+func (m *GetSharesByNamespaceRequest) GetTraceparent() string {
+	if m != nil {
+		return m.Traceparent
+	}
+	return ""
+}
+
+func (m *GetSharesByNamespaceRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 type GetSharesByNamespaceResponse struct {
 	Status StatusCode `protobuf:"varint,1,opt,name=status,proto3,enum=share.p2p.shrex.nd.StatusCode" json:"status,omitempty"`
 	Rows   []*Row     `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
@@ -283,32 +302,35 @@ func init() {
 func init() { proto.RegisterFile("share/p2p/shrexnd/pb/share.proto", fileDescriptor_ed9f13149b0de397) }
 
 var fileDescriptor_ed9f13149b0de397 = []byte{
-	// 386 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xc1, 0xae, 0x93, 0x40,
-	0x14, 0x86, 0x81, 0xb9, 0xc5, 0x7b, 0x0f, 0x68, 0xc8, 0x68, 0xbc, 0x98, 0x6b, 0x48, 0x65, 0xd5,
-	0x68, 0x02, 0x09, 0x26, 0xee, 0x69, 0x8b, 0x4a, 0xac, 0xd3, 0x66, 0x5a, 0x75, 0x65, 0x08, 0x95,
-	0x31, 0xb8, 0x90, 0x19, 0x99, 0x69, 0xaa, 0x6b, 0x5f, 0xc0, 0xc7, 0x72, 0xd9, 0xa5, 0x4b, 0xd3,
-	0xbe, 0x88, 0x61, 0xa8, 0x76, 0x61, 0x77, 0xfc, 0xff, 0xf9, 0xce, 0x7f, 0xce, 0x21, 0x03, 0x43,
-	0x59, 0x97, 0x2d, 0x8b, 0x45, 0x22, 0x62, 0x59, 0xb7, 0xec, 0x6b, 0x53, 0xc5, 0x62, 0x1d, 0x6b,
-	0x33, 0x12, 0x2d, 0x57, 0x1c, 0xe3, 0xa3, 0x48, 0x44, 0xa4, 0x89, 0xa8, 0xa9, 0xc2, 0xf7, 0x70,
-	0xf3, 0x82, 0xa9, 0x65, 0x57, 0x90, 0xe3, 0x6f, 0xa4, 0xfc, 0xcc, 0xa4, 0x28, 0x3f, 0x30, 0xca,
-	0xbe, 0x6c, 0x98, 0x54, 0xf8, 0x06, 0xae, 0x5a, 0xce, 0x55, 0x51, 0x97, 0xb2, 0xf6, 0xcd, 0xa1,
-	0x39, 0x72, 0xe9, 0x65, 0x67, 0xbc, 0x2c, 0x65, 0x8d, 0x1f, 0x81, 0xdb, 0xfc, 0x6d, 0x28, 0x3e,
-	0x55, 0xbe, 0xa5, 0xeb, 0xce, 0x3f, 0x2f, 0xaf, 0xc2, 0xef, 0x26, 0x3c, 0x3c, 0x9f, 0x2f, 0x05,
-	0x6f, 0x24, 0xc3, 0xcf, 0xc0, 0x96, 0xaa, 0x54, 0x1b, 0xa9, 0xd3, 0xef, 0x24, 0x41, 0xf4, 0xff,
-	0x92, 0xd1, 0x52, 0x13, 0x13, 0x5e, 0x31, 0x7a, 0xa4, 0xf1, 0x13, 0xb8, 0x68, 0xf9, 0x56, 0xfa,
-	0xd6, 0x10, 0x8d, 0x9c, 0xe4, 0xfa, 0x5c, 0x17, 0xe5, 0x5b, 0xaa, 0xa1, 0x90, 0x00, 0xa2, 0x7c,
-	0x8b, 0xef, 0x83, 0xad, 0xb1, 0x6e, 0x16, 0x1a, 0xb9, 0xf4, 0xa8, 0x70, 0x0c, 0x03, 0xd1, 0x72,
-	0xfe, 0x51, 0x1f, 0xe0, 0x24, 0x0f, 0xce, 0x85, 0x2d, 0x3a, 0x80, 0xf6, 0x5c, 0x98, 0xc1, 0x40,
-	0x6b, 0x7c, 0x0f, 0x06, 0x52, 0x95, 0xad, 0xd2, 0xcb, 0x23, 0xda, 0x0b, 0xec, 0x01, 0x62, 0x4d,
-	0xff, 0x3b, 0x10, 0xed, 0x3e, 0x3b, 0x8e, 0xf0, 0x8a, 0x49, 0x1f, 0xe9, 0xc1, 0xbd, 0x78, 0xfc,
-	0x0e, 0xe0, 0x74, 0x19, 0x76, 0xe0, 0x56, 0x4e, 0xde, 0xa6, 0xb3, 0x7c, 0xea, 0x19, 0xd8, 0x06,
-	0x6b, 0xfe, 0xca, 0x33, 0xf1, 0x6d, 0xb8, 0x22, 0xf3, 0x55, 0xf1, 0x7c, 0xfe, 0x86, 0x4c, 0x3d,
-	0x0b, 0xbb, 0x70, 0x99, 0x93, 0x55, 0x46, 0x49, 0x3a, 0xf3, 0x10, 0xbe, 0x86, 0xbb, 0x24, 0x7d,
-	0x9d, 0x2d, 0x17, 0xe9, 0x24, 0x2b, 0x4e, 0xd8, 0xc5, 0xd8, 0xff, 0xb9, 0x0f, 0xcc, 0xdd, 0x3e,
-	0x30, 0x7f, 0xef, 0x03, 0xf3, 0xc7, 0x21, 0x30, 0x76, 0x87, 0xc0, 0xf8, 0x75, 0x08, 0x8c, 0xb5,
-	0xad, 0x5f, 0xc2, 0xd3, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xba, 0xc1, 0x4e, 0xec, 0x2d, 0x02,
-	0x00, 0x00,
+	// 437 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x41, 0x6f, 0xd3, 0x30,
+	0x14, 0xc7, 0xeb, 0xba, 0x2d, 0xeb, 0x4b, 0x81, 0xc8, 0x43, 0x2c, 0x68, 0x28, 0x0a, 0x3d, 0x45,
+	0x20, 0x25, 0x52, 0x90, 0xb8, 0x77, 0x6d, 0x80, 0x88, 0xe1, 0x4e, 0x6e, 0xe1, 0x1a, 0x79, 0x8b,
+	0x21, 0x93, 0x20, 0x36, 0xb6, 0xa7, 0xc2, 0x99, 0x2f, 0xc0, 0x8d, 0xaf, 0xc4, 0x71, 0x47, 0x8e,
+	0xa8, 0xfd, 0x22, 0x28, 0x6e, 0x61, 0x48, 0xf4, 0xe6, 0xff, 0xff, 0xfd, 0x9e, 0xdf, 0xff, 0x59,
+	0x86, 0xc8, 0xd4, 0x5c, 0x8b, 0x54, 0x65, 0x2a, 0x35, 0xb5, 0x16, 0x9f, 0x9b, 0x2a, 0x55, 0xe7,
+	0xa9, 0x33, 0x13, 0xa5, 0xa5, 0x95, 0x84, 0xec, 0x44, 0xa6, 0x12, 0x47, 0x24, 0x4d, 0x35, 0xfe,
+	0x8e, 0xe0, 0xf8, 0x85, 0xb0, 0x8b, 0xb6, 0x62, 0x4e, 0xbe, 0x50, 0xfe, 0x51, 0x18, 0xc5, 0x2f,
+	0x04, 0x13, 0x9f, 0xae, 0x84, 0xb1, 0xe4, 0x18, 0x86, 0x5a, 0x4a, 0x5b, 0xd6, 0xdc, 0xd4, 0x01,
+	0x8a, 0x50, 0x3c, 0x62, 0x07, 0xad, 0xf1, 0x92, 0x9b, 0x9a, 0x3c, 0x82, 0x51, 0xf3, 0xa7, 0xa1,
+	0xbc, 0xac, 0x82, 0xae, 0xab, 0x7b, 0x7f, 0xbd, 0xa2, 0x22, 0x11, 0x78, 0x56, 0xf3, 0x0b, 0xa1,
+	0xb8, 0x16, 0x8d, 0x0d, 0x70, 0x84, 0xe2, 0x21, 0xfb, 0xd7, 0x22, 0xf7, 0x61, 0x50, 0x8b, 0xcb,
+	0xf7, 0xb5, 0x0d, 0x7a, 0x11, 0x8a, 0x7b, 0x6c, 0xa7, 0xc6, 0x5f, 0x11, 0x3c, 0xdc, 0x9f, 0xcc,
+	0x28, 0xd9, 0x18, 0x41, 0x9e, 0xc1, 0xc0, 0x58, 0x6e, 0xaf, 0x8c, 0xcb, 0x75, 0x27, 0x0b, 0x93,
+	0xff, 0xf7, 0x4b, 0x16, 0x8e, 0x98, 0xca, 0x4a, 0xb0, 0x1d, 0x4d, 0x9e, 0x40, 0x4f, 0xcb, 0x95,
+	0x09, 0xba, 0x11, 0x8e, 0xbd, 0xec, 0x68, 0x5f, 0x17, 0x93, 0x2b, 0xe6, 0xa0, 0x31, 0x05, 0xcc,
+	0xe4, 0xaa, 0x0d, 0xe9, 0xb0, 0x76, 0x16, 0x8e, 0x47, 0x6c, 0xa7, 0x48, 0x0a, 0x7d, 0xa5, 0xa5,
+	0x7c, 0xe7, 0x56, 0xf7, 0xb2, 0x07, 0xfb, 0x2e, 0x3b, 0x6b, 0x01, 0xb6, 0xe5, 0xc6, 0x39, 0xf4,
+	0x9d, 0x26, 0xf7, 0xa0, 0x6f, 0x2c, 0xd7, 0xd6, 0x85, 0xc7, 0x6c, 0x2b, 0x88, 0x0f, 0x58, 0x34,
+	0xdb, 0x87, 0xc4, 0xac, 0x3d, 0xb6, 0x1c, 0x95, 0x95, 0x30, 0x01, 0x76, 0x83, 0xb7, 0xe2, 0xf1,
+	0x07, 0x80, 0x9b, 0xcd, 0x88, 0x07, 0xb7, 0x0a, 0xfa, 0x76, 0x72, 0x5a, 0xcc, 0xfc, 0x0e, 0x19,
+	0x40, 0x77, 0xfe, 0xca, 0x47, 0xe4, 0x36, 0x0c, 0xe9, 0x7c, 0x59, 0x3e, 0x9f, 0xbf, 0xa1, 0x33,
+	0xbf, 0x4b, 0x46, 0x70, 0x50, 0xd0, 0x65, 0xce, 0xe8, 0xe4, 0xd4, 0xc7, 0xe4, 0x08, 0x0e, 0xe9,
+	0xe4, 0x75, 0xbe, 0x38, 0x9b, 0x4c, 0xf3, 0xf2, 0x06, 0xeb, 0x91, 0x43, 0xb8, 0xcb, 0xf2, 0x59,
+	0xc1, 0xf2, 0xe9, 0xb2, 0x5c, 0xce, 0xcb, 0x7c, 0xb6, 0xf0, 0xfb, 0x27, 0xc1, 0x8f, 0x75, 0x88,
+	0xae, 0xd7, 0x21, 0xfa, 0xb5, 0x0e, 0xd1, 0xb7, 0x4d, 0xd8, 0xb9, 0xde, 0x84, 0x9d, 0x9f, 0x9b,
+	0xb0, 0x73, 0x3e, 0x70, 0x3f, 0xeb, 0xe9, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0xb1, 0x1e, 0xd0,
+	0x2e, 0x7d, 0x02, 0x00, 0x00,
 }
 
 func (m *GetSharesByNamespaceRequest) Marshal() (dAtA []byte, err error) {
@@ -331,6 +353,18 @@ func (m *GetSharesByNamespaceRequest) MarshalToSizedBuffer(dAtA []byte) (int, er
 	_ = i
 	var l int
 	_ = l
+	if m.Height != 0 {
+		i = encodeVarintShare(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Traceparent) > 0 {
+		i -= len(m.Traceparent)
+		copy(dAtA[i:], m.Traceparent)
+		i = encodeVarintShare(dAtA, i, uint64(len(m.Traceparent)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.NamespaceId) > 0 {
 		i -= len(m.NamespaceId)
 		copy(dAtA[i:], m.NamespaceId)
@@ -501,6 +535,13 @@ func (m *GetSharesByNamespaceRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovShare(uint64(l))
 	}
+	l = len(m.Traceparent)
+	if l > 0 {
+		n += 1 + l + sovShare(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovShare(uint64(m.Height))
+	}
 	return n
 }
 
@@ -665,6 +706,57 @@ func (m *GetSharesByNamespaceRequest) Unmarshal(dAtA []byte) error {
 				m.NamespaceId = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Traceparent", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShare
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthShare
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthShare
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Traceparent = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowShare
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipShare(dAtA[iNdEx:])
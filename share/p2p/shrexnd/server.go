@@ -11,10 +11,15 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/minio/sha256-simd"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/celestiaorg/go-libp2p-messenger/serde"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/nmt/namespace"
 
+	"github.com/celestiaorg/celestia-node/libs/utils"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds"
 	"github.com/celestiaorg/celestia-node/share/ipld"
@@ -33,13 +38,37 @@ type Server struct {
 	getter share.Getter
 	store  *eds.Store
 
+	// redirectThreshold is the amount of namespace shares starting from which the namespace is not
+	// served over shrex/nd and the client is redirected to shrex/eds. Zero disables redirection.
+	redirectThreshold int
+
 	params     *Parameters
 	middleware *p2p.Middleware
 	metrics    *p2p.Metrics
 }
 
+// Option configures the Server.
+type Option func(*Server)
+
+// WithRedirectToEDSThreshold makes the Server respond with REDIRECT_TO_EDS status instead of
+// constructing per-row proofs for namespaces spanning at least the given amount of shares, as
+// serving the whole EDS is cheaper for them. Zero disables redirection.
+//
+// NOTE: Clients unaware of the status treat it as an invalid response.
+func WithRedirectToEDSThreshold(shares int) Option {
+	return func(srv *Server) {
+		srv.redirectThreshold = shares
+	}
+}
+
 // NewServer creates new Server
-func NewServer(params *Parameters, host host.Host, store *eds.Store, getter share.Getter) (*Server, error) {
+func NewServer(
+	params *Parameters,
+	host host.Host,
+	store *eds.Store,
+	getter share.Getter,
+	opts ...Option,
+) (*Server, error) {
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("shrex-nd: server creation failed: %w", err)
 	}
@@ -49,9 +78,15 @@ func NewServer(params *Parameters, host host.Host, store *eds.Store, getter shar
 		store:      store,
 		host:       host,
 		params:     params,
-		protocolID: p2p.ProtocolID(params.NetworkID(), protocolString),
+		protocolID: p2p.ProtocolID(params.NetworkID(), params.ProtocolEpoch(), protocolString),
 		middleware: p2p.NewMiddleware(params.ConcurrencyLimit),
 	}
+	for _, opt := range opts {
+		opt(srv)
+	}
+	if srv.redirectThreshold < 0 {
+		return nil, fmt.Errorf("shrex-nd: invalid redirect threshold: %d", srv.redirectThreshold)
+	}
 
 	return srv, nil
 }
@@ -117,36 +152,74 @@ func (srv *Server) handleNamespacedData(ctx context.Context, stream network.Stre
 
 	ctx, cancel := context.WithTimeout(ctx, srv.params.HandleRequestTimeout)
 	defer cancel()
+	ctx = p2p.ContextWithTraceParent(ctx, req.Traceparent)
+	attrs := append(p2p.HeightAttributes(req.Height),
+		attribute.Stringer(share.PeerAttribute, stream.Conn().RemotePeer()),
+	)
+	ctx, span := tracer.Start(ctx, "shrex/nd/serve", trace.WithAttributes(
+		share.TraceAttributes(ctx, req.RootHash, req.NamespaceId, attrs...)...,
+	))
+	defer func() {
+		utils.SetStatusAndEnd(span, err)
+	}()
 
 	dah, err := srv.store.GetDAH(ctx, req.RootHash)
 	if err != nil {
 		if errors.Is(err, eds.ErrNotFound) {
 			logger.Warn("server: DAH not found")
-			srv.respondNotFoundError(ctx, logger, stream)
+			srv.respondNotFoundError(ctx, logger, stream) //nolint:errcheck
 			return
 		}
 		logger.Errorw("server: retrieving DAH", "err", err)
-		srv.respondInternalError(ctx, logger, stream)
+		srv.respondInternalError(ctx, logger, stream) //nolint:errcheck
+		return
+	}
+
+	if srv.shouldRedirect(dah, req.NamespaceId) {
+		logger.Debug("server: redirecting to eds")
+		err = srv.respondRedirectToEDS(ctx, logger, stream)
 		return
 	}
 
-	shares, err := srv.getter.GetSharesByNamespace(ctx, dah, req.NamespaceId)
+	shares, release, err := srv.getSharesByNamespace(ctx, dah, req.NamespaceId)
 	switch {
 	case errors.Is(err, share.ErrNotFound):
 		logger.Warn("server: nd not found")
-		srv.respondNotFoundError(ctx, logger, stream)
+		srv.respondNotFoundError(ctx, logger, stream) //nolint:errcheck
 		return
 	case errors.Is(err, share.ErrNamespaceNotFound):
-		srv.respondNamespaceNotFoundError(ctx, logger, stream)
+		srv.respondNamespaceNotFoundError(ctx, logger, stream) //nolint:errcheck
 		return
 	case err != nil:
 		logger.Errorw("server: retrieving shares", "err", err)
-		srv.respondInternalError(ctx, logger, stream)
+		srv.respondInternalError(ctx, logger, stream) //nolint:errcheck
 		return
 	}
 
+	// shares may reference the memory-mapped CAR file until the response is written
+	defer release()
 	resp := namespacedSharesToResponse(shares)
-	srv.respond(ctx, logger, stream, resp)
+	err = srv.respond(ctx, logger, stream, resp)
+}
+
+// mappedGetter is implemented by getters able to serve shares referencing memory-mapped CAR files,
+// like getters.StoreGetter.
+type mappedGetter interface {
+	GetSharesByNamespaceMapped(context.Context, *share.Root, namespace.ID) (share.NamespacedShares, func(), error)
+}
+
+// getSharesByNamespace gets the shares of the namespace without copying them, if the getter
+// supports it. The returned function releases the shares once the response is written.
+func (srv *Server) getSharesByNamespace(
+	ctx context.Context,
+	dah *share.Root,
+	nID namespace.ID,
+) (share.NamespacedShares, func(), error) {
+	if getter, ok := srv.getter.(mappedGetter); ok {
+		return getter.GetSharesByNamespaceMapped(ctx, dah, nID)
+	}
+	shares, err := srv.getter.GetSharesByNamespace(ctx, dah, nID)
+	return shares, func() {}, err
 }
 
 // validateRequest checks correctness of the request
@@ -161,31 +234,59 @@ func validateRequest(req pb.GetSharesByNamespaceRequest) error {
 	return nil
 }
 
+// shouldRedirect checks whether the namespace spans enough shares for the client to be redirected
+// to shrex/eds. Only rows fully covered by the namespace are counted, so that the decision is made
+// from the DAH alone without reading any shares.
+func (srv *Server) shouldRedirect(dah *share.Root, nID namespace.ID) bool {
+	if srv.redirectThreshold == 0 {
+		return false
+	}
+
+	// parity rows never contain user namespaces, so only original rows are inspected
+	odsWidth := len(dah.RowRoots) / 2
+	var count int
+	for _, row := range dah.RowRoots[:odsWidth] {
+		if nID.Equal(nmt.MinNamespace(row, nID.Size())) && nID.Equal(nmt.MaxNamespace(row, nID.Size())) {
+			count += odsWidth
+		}
+	}
+	return count >= srv.redirectThreshold
+}
+
+// respondRedirectToEDS sends a response redirecting the client to shrex/eds
+func (srv *Server) respondRedirectToEDS(ctx context.Context,
+	logger *zap.SugaredLogger, stream network.Stream) error {
+	resp := &pb.GetSharesByNamespaceResponse{
+		Status: pb.StatusCode_REDIRECT_TO_EDS,
+	}
+	return srv.respond(ctx, logger, stream, resp)
+}
+
 // respondNotFoundError sends a not found response to client
 func (srv *Server) respondNotFoundError(ctx context.Context,
-	logger *zap.SugaredLogger, stream network.Stream) {
+	logger *zap.SugaredLogger, stream network.Stream) error {
 	resp := &pb.GetSharesByNamespaceResponse{
 		Status: pb.StatusCode_NOT_FOUND,
 	}
-	srv.respond(ctx, logger, stream, resp)
+	return srv.respond(ctx, logger, stream, resp)
 }
 
 // respondNamespaceNotFoundError sends a namespace not found response to client
 func (srv *Server) respondNamespaceNotFoundError(ctx context.Context,
-	logger *zap.SugaredLogger, stream network.Stream) {
+	logger *zap.SugaredLogger, stream network.Stream) error {
 	resp := &pb.GetSharesByNamespaceResponse{
 		Status: pb.StatusCode_NAMESPACE_NOT_FOUND,
 	}
-	srv.respond(ctx, logger, stream, resp)
+	return srv.respond(ctx, logger, stream, resp)
 }
 
 // respondInternalError sends internal error response to client
 func (srv *Server) respondInternalError(ctx context.Context,
-	logger *zap.SugaredLogger, stream network.Stream) {
+	logger *zap.SugaredLogger, stream network.Stream) error {
 	resp := &pb.GetSharesByNamespaceResponse{
 		Status: pb.StatusCode_INTERNAL,
 	}
-	srv.respond(ctx, logger, stream, resp)
+	return srv.respond(ctx, logger, stream, resp)
 }
 
 // namespacedSharesToResponse encodes shares into proto and sends it to client with OK status code
@@ -212,8 +313,11 @@ func namespacedSharesToResponse(shares share.NamespacedShares) *pb.GetSharesByNa
 	}
 }
 
+// respond writes the response to the client and records its status on the span of the request. It
+// returns the error of writing the response, if any.
 func (srv *Server) respond(ctx context.Context,
-	logger *zap.SugaredLogger, stream network.Stream, resp *pb.GetSharesByNamespaceResponse) {
+	logger *zap.SugaredLogger, stream network.Stream, resp *pb.GetSharesByNamespaceResponse) error {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Stringer("status", resp.Status))
 	err := stream.SetWriteDeadline(time.Now().Add(srv.params.ServerWriteTimeout))
 	if err != nil {
 		logger.Debugw("server: setting write deadline", "err", err)
@@ -223,7 +327,7 @@ func (srv *Server) respond(ctx context.Context,
 	if err != nil {
 		logger.Warnw("server: writing response", "err", err)
 		stream.Reset() //nolint:errcheck
-		return
+		return err
 	}
 
 	switch {
@@ -233,8 +337,16 @@ func (srv *Server) respond(ctx context.Context,
 		srv.metrics.ObserveRequests(ctx, 1, p2p.StatusNotFound)
 	case resp.Status == pb.StatusCode_INTERNAL:
 		srv.metrics.ObserveRequests(ctx, 1, p2p.StatusInternalErr)
+	case resp.Status == pb.StatusCode_REDIRECT_TO_EDS:
+		srv.metrics.ObserveRequests(ctx, 1, p2p.StatusRedirected)
 	}
 	if err = stream.Close(); err != nil {
 		logger.Debugw("server: closing stream", "err", err)
 	}
+	return nil
+}
+
+// SetConcurrencyLimit changes the maximum number of concurrently handled streams.
+func (srv *Server) SetConcurrencyLimit(limit int) {
+	srv.middleware.SetConcurrencyLimit(limit)
 }
@@ -40,20 +40,46 @@ type PubSub struct {
 	pubSub *pubsub.PubSub
 	topic  *pubsub.Topic
 
+	// allowUnsigned makes unsigned notifications validated instead of ignored
+	allowUnsigned bool
+
 	pubsubTopic string
 	cancelRelay pubsub.RelayCancelFunc
 }
 
+// Option configures the PubSub.
+type Option func(*PubSub)
+
+// WithUnsignedNotifications makes the PubSub accept notifications of peers not signing their
+// messages, for networks with relaxed gossip signing. Unsigned notifications are attributed to the
+// peer relaying them, as their author can't be told. Signed notifications are still verified and
+// broadcast notifications are still signed.
+func WithUnsignedNotifications() Option {
+	return func(s *PubSub) {
+		s.allowUnsigned = true
+	}
+}
+
 // NewPubSub creates a libp2p.PubSub wrapper.
-func NewPubSub(ctx context.Context, h host.Host, networkID string) (*PubSub, error) {
-	pubsub, err := pubsub.NewFloodSub(ctx, h)
+func NewPubSub(ctx context.Context, h host.Host, networkID string, opts ...Option) (*PubSub, error) {
+	s := &PubSub{
+		pubsubTopic: pubsubTopicID(networkID),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	policy := pubsub.StrictSign
+	if s.allowUnsigned {
+		// signs messages and verifies the signed ones only
+		policy = pubsub.LaxSign
+	}
+	ps, err := pubsub.NewFloodSub(ctx, h, pubsub.WithMessageSignaturePolicy(policy))
 	if err != nil {
 		return nil, err
 	}
-	return &PubSub{
-		pubSub:      pubsub,
-		pubsubTopic: pubsubTopicID(networkID),
-	}, nil
+	s.pubSub = ps
+	return s, nil
 }
 
 // Start creates an instances of FloodSub and joins specified topic.
@@ -88,10 +114,35 @@ func (s *PubSub) Stop(context.Context) error {
 // AddValidator registers given ValidatorFn for EDS notifications.
 // Any amount of Validators can be registered.
 func (s *PubSub) AddValidator(v ValidatorFn) error {
-	return s.pubSub.RegisterTopicValidator(s.pubsubTopic, v.validate)
+	return s.pubSub.RegisterTopicValidator(s.pubsubTopic, v.validate(s.allowUnsigned))
+}
+
+// validate wraps the ValidatorFn into the topic validator. The ValidatorFn receives the author of
+// signed notifications rather than the peer propagating them, as the author is authenticated by the
+// signature of the message, which floodsub verifies. Unsigned messages are ignored, as their author
+// can't be told, unless allowUnsigned is set, in which case they are attributed to the propagating
+// peer.
+func (v ValidatorFn) validate(allowUnsigned bool) pubsub.ValidatorEx {
+	return func(ctx context.Context, p peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+		author := msg.GetFrom()
+		if len(msg.GetSignature()) == 0 || author == "" {
+			if !allowUnsigned {
+				log.Debugw("validator: unsigned message", "received_from", msg.ReceivedFrom)
+				return pubsub.ValidationIgnore
+			}
+			author = p
+		}
+		return v.validateNotification(ctx, author, msg)
+	}
 }
 
-func (v ValidatorFn) validate(ctx context.Context, p peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+// validateNotification decodes the notification of the message and passes it to the ValidatorFn
+// along with its author.
+func (v ValidatorFn) validateNotification(
+	ctx context.Context,
+	author peer.ID,
+	msg *pubsub.Message,
+) pubsub.ValidationResult {
 	var pbmsg pb.RecentEDSNotification
 	if err := pbmsg.Unmarshal(msg.Data); err != nil {
 		log.Debugw("validator: unmarshal error", "err", err)
@@ -106,7 +157,7 @@ func (v ValidatorFn) validate(ctx context.Context, p peer.ID, msg *pubsub.Messag
 		// we don't send empty EDS data hashes, but If someone sent it to us - do hard reject
 		return pubsub.ValidationReject
 	}
-	return v(ctx, p, n)
+	return v(ctx, author, n)
 }
 
 // Subscribe provides a new Subscription for EDS notifications.
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	"github.com/stretchr/testify/require"
 
@@ -50,3 +52,100 @@ func TestPubSub(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, notification, got)
 }
+
+func TestPubSub_ValidatesAuthor(t *testing.T) {
+	net, err := mocknet.WithNPeers(3)
+	require.NoError(t, err)
+	require.NoError(t, net.LinkAll())
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	// the notification of the author reaches the receiver through the relay only
+	author, relay, receiver := net.Hosts()[0], net.Hosts()[1], net.Hosts()[2]
+	_, err = net.ConnectPeers(author.ID(), relay.ID())
+	require.NoError(t, err)
+	_, err = net.ConnectPeers(relay.ID(), receiver.ID())
+	require.NoError(t, err)
+
+	pubsubs := make([]*PubSub, 0, 3)
+	for _, h := range net.Hosts() {
+		pSub, err := NewPubSub(ctx, h, "test")
+		require.NoError(t, err)
+		pubsubs = append(pubsubs, pSub)
+	}
+	validated := make(chan peer.ID, 1)
+	require.NoError(t, pubsubs[2].AddValidator(
+		func(_ context.Context, p peer.ID, _ Notification) pubsub.ValidationResult {
+			validated <- p
+			return pubsub.ValidationAccept
+		}),
+	)
+	for _, pSub := range pubsubs {
+		require.NoError(t, pSub.Start(ctx))
+	}
+	subs, err := pubsubs[2].Subscribe()
+	require.NoError(t, err)
+	_, err = pubsubs[1].topic.Subscribe()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(pubsubs[0].topic.ListPeers()) == 1 && len(pubsubs[1].topic.ListPeers()) == 2
+	}, time.Second, time.Millisecond*10)
+
+	err = pubsubs[0].Broadcast(ctx, Notification{DataHash: []byte("data"), Height: 1})
+	require.NoError(t, err)
+	_, err = subs.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, author.ID(), <-validated)
+}
+
+func TestPubSub_UnsignedNotifications(t *testing.T) {
+	net, err := mocknet.WithNPeers(3)
+	require.NoError(t, err)
+	require.NoError(t, net.LinkAll())
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	// the unsigned notification of the author reaches the receiver through the relay only
+	author, relay, receiver := net.Hosts()[0], net.Hosts()[1], net.Hosts()[2]
+	_, err = net.ConnectPeers(author.ID(), relay.ID())
+	require.NoError(t, err)
+	_, err = net.ConnectPeers(relay.ID(), receiver.ID())
+	require.NoError(t, err)
+
+	unsigned, err := pubsub.NewFloodSub(ctx, author, pubsub.WithMessageSignaturePolicy(pubsub.StrictNoSign))
+	require.NoError(t, err)
+	topic, err := unsigned.Join(pubsubTopicID("test"))
+	require.NoError(t, err)
+
+	pubsubs := make([]*PubSub, 0, 2)
+	for _, h := range []host.Host{relay, receiver} {
+		pSub, err := NewPubSub(ctx, h, "test", WithUnsignedNotifications())
+		require.NoError(t, err)
+		pubsubs = append(pubsubs, pSub)
+	}
+	validated := make(chan peer.ID, 1)
+	require.NoError(t, pubsubs[1].AddValidator(
+		func(_ context.Context, p peer.ID, _ Notification) pubsub.ValidationResult {
+			validated <- p
+			return pubsub.ValidationAccept
+		}),
+	)
+	for _, pSub := range pubsubs {
+		require.NoError(t, pSub.Start(ctx))
+	}
+	subs, err := pubsubs[1].Subscribe()
+	require.NoError(t, err)
+	_, err = pubsubs[0].topic.Subscribe()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(topic.ListPeers()) == 1 && len(pubsubs[0].topic.ListPeers()) == 1
+	}, time.Second, time.Millisecond*10)
+
+	data, err := (&pb.RecentEDSNotification{DataHash: []byte("data"), Height: 1}).Marshal()
+	require.NoError(t, err)
+	require.NoError(t, topic.Publish(ctx, data))
+	_, err = subs.Next(ctx)
+	require.NoError(t, err)
+	// the author of an unsigned notification can't be told, so the relay is held accountable for it
+	require.Equal(t, relay.ID(), <-validated)
+}
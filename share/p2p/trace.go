@@ -0,0 +1,41 @@
+package p2p
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// traceParentKey is the key of the W3C trace context in the propagation carrier.
+const traceParentKey = "traceparent"
+
+// TraceParent returns the W3C trace context of the span of the given context, if any. It is sent
+// along with shrex requests, so that spans serving them continue the trace of the client.
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.Get(traceParentKey)
+}
+
+// ContextWithTraceParent returns a context continuing the trace of the given W3C trace context, as
+// returned by TraceParent. The context is returned as is, if the trace context is empty or invalid.
+func ContextWithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	carrier := propagation.MapCarrier{traceParentKey: traceParent}
+	return propagation.TraceContext{}.Extract(ctx, carrier)
+}
+
+// HeightAttributes returns the height attribute of a span serving a request for the EDS of the
+// given height, if the client knew it. The height isn't set on the context of the request, as it
+// can't be trusted.
+func HeightAttributes(height uint64) []attribute.KeyValue {
+	if height == 0 {
+		return nil
+	}
+	return []attribute.KeyValue{attribute.Int64(share.HeightAttribute, int64(height))}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	"github.com/celestiaorg/nmt/namespace"
 
+	"github.com/celestiaorg/celestia-node/libs/encoding"
 	"github.com/celestiaorg/celestia-node/share/ipld"
 )
 
@@ -16,6 +17,10 @@ var (
 	tracer = otel.Tracer("share")
 
 	// DefaultRSMT2DCodec sets the default rsmt2d.Codec for shares.
+	//
+	// NOTE: The codec is not configurable. Leopard already uses the fastest SIMD instructions the
+	// CPU supports, i.e. AVX512, AVX2 or SSSE3 on amd64 and NEON on arm64, and any other codec must
+	// compute the exact same parity shares, as they are committed to by the DAH.
 	DefaultRSMT2DCodec = appconsts.DefaultCodec
 )
 
@@ -62,6 +67,21 @@ func (dh DataHash) String() string {
 	return fmt.Sprintf("%X", []byte(dh))
 }
 
+// MarshalJSON encodes DataHash as a 0x-prefixed hex string.
+func (dh DataHash) MarshalJSON() ([]byte, error) {
+	return encoding.MarshalHex(dh)
+}
+
+// UnmarshalJSON decodes DataHash from a 0x-prefixed hex string.
+func (dh *DataHash) UnmarshalJSON(data []byte) error {
+	b, err := encoding.UnmarshalHex(data)
+	if err != nil {
+		return err
+	}
+	*dh = b
+	return nil
+}
+
 // IsEmptyRoot check whether DataHash corresponds to the root of an empty block EDS.
 func (dh DataHash) IsEmptyRoot() bool {
 	return bytes.Equal(EmptyRoot().Hash(), dh)
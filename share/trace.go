@@ -0,0 +1,43 @@
+package share
+
+import (
+	"context"
+	"encoding/hex"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/celestiaorg/nmt/namespace"
+)
+
+// Attribute keys of the trace spans of share retrievals. Every hop of a retrieval, i.e. the
+// getters, shrex clients and servers, IPLD retrievals and the EDS store, tags its spans with them,
+// so that a slow retrieval can be attributed to the hop it spent its time in.
+const (
+	HeightAttribute    = "height"
+	DataHashAttribute  = "datahash"
+	NamespaceAttribute = "namespace"
+	PeerAttribute      = "peer"
+	// RowRootAttribute tags spans of retrievals from a single row tree, which don't know the
+	// DataHash of the square.
+	RowRootAttribute = "row_root"
+)
+
+// TraceAttributes returns the attributes of a span of a retrieval from the data square with the
+// given DataHash: the height set with WithHeight, if any, the data hash, the namespace ID, if
+// given, and the extra attributes.
+func TraceAttributes(
+	ctx context.Context,
+	hash DataHash,
+	nID namespace.ID,
+	extra ...attribute.KeyValue,
+) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, 3+len(extra))
+	if height, ok := HeightFromContext(ctx); ok {
+		attrs = append(attrs, attribute.Int64(HeightAttribute, int64(height)))
+	}
+	attrs = append(attrs, attribute.String(DataHashAttribute, hash.String()))
+	if nID != nil {
+		attrs = append(attrs, attribute.String(NamespaceAttribute, hex.EncodeToString(nID)))
+	}
+	return append(attrs, extra...)
+}
@@ -0,0 +1,30 @@
+package share
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/celestiaorg/nmt/namespace"
+)
+
+func TestTraceAttributes(t *testing.T) {
+	hash := DataHash(make([]byte, 32))
+	nID := namespace.ID{1, 2, 3, 4, 5, 6, 7, 8}
+
+	attrs := TraceAttributes(context.Background(), hash, nil)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String(DataHashAttribute, hash.String()),
+	}, attrs)
+
+	ctx := WithHeight(context.Background(), 42)
+	attrs = TraceAttributes(ctx, hash, nID, attribute.String(PeerAttribute, "peer"))
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Int64(HeightAttribute, 42),
+		attribute.String(DataHashAttribute, hash.String()),
+		attribute.String(NamespaceAttribute, "0102030405060708"),
+		attribute.String(PeerAttribute, "peer"),
+	}, attrs)
+}
@@ -0,0 +1,45 @@
+package share
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/celestiaorg/rsmt2d"
+)
+
+// ErrRootMismatch is returned when the roots recomputed from an EDS do not match the roots of the
+// DAH it is expected to be committed to.
+type ErrRootMismatch struct {
+	// Height of the header the EDS was validated against. Zero if validated against the bare DAH.
+	Height uint64
+	// Axis and Index locate the first row or col, which root differs.
+	Axis  rsmt2d.Axis
+	Index int
+}
+
+func (e *ErrRootMismatch) Error() string {
+	if e.Height != 0 {
+		return fmt.Sprintf("share: eds root mismatch at height %d (Axis:%v, Index:%v)", e.Height, e.Axis, e.Index)
+	}
+	return fmt.Sprintf("share: eds root mismatch (Axis:%v, Index:%v)", e.Axis, e.Index)
+}
+
+// ValidateAgainstRoot ensures the roots recomputed from the given EDS match the given DAH. It
+// returns ErrRootMismatch with the first differing row or col if the roots differ.
+func ValidateAgainstRoot(eds *rsmt2d.ExtendedDataSquare, root *Root) error {
+	if int(eds.Width()) != len(root.RowRoots) || int(eds.Width()) != len(root.ColumnRoots) {
+		return fmt.Errorf("share: eds width %d doesn't match DAH width %d", eds.Width(), len(root.RowRoots))
+	}
+
+	for i, rowRoot := range eds.RowRoots() {
+		if !bytes.Equal(rowRoot, root.RowRoots[i]) {
+			return &ErrRootMismatch{Axis: rsmt2d.Row, Index: i}
+		}
+	}
+	for i, colRoot := range eds.ColRoots() {
+		if !bytes.Equal(colRoot, root.ColumnRoots[i]) {
+			return &ErrRootMismatch{Axis: rsmt2d.Col, Index: i}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package share
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/rsmt2d"
+)
+
+func TestValidateAgainstRoot(t *testing.T) {
+	eds := RandEDS(t, 4)
+	dah := da.NewDataAvailabilityHeader(eds)
+	require.NoError(t, ValidateAgainstRoot(eds, &dah))
+
+	// the square of another block differs starting from the first row
+	err := ValidateAgainstRoot(RandEDS(t, 4), &dah)
+	var errMismatch *ErrRootMismatch
+	require.ErrorAs(t, err, &errMismatch)
+	require.Equal(t, &ErrRootMismatch{Axis: rsmt2d.Row, Index: 0}, errMismatch)
+
+	colRoots := make([][]byte, len(dah.ColumnRoots))
+	copy(colRoots, dah.ColumnRoots)
+	colRoots[2] = dah.RowRoots[2]
+	other := &da.DataAvailabilityHeader{RowRoots: dah.RowRoots, ColumnRoots: colRoots}
+	err = ValidateAgainstRoot(eds, other)
+	require.ErrorAs(t, err, &errMismatch)
+	require.Equal(t, &ErrRootMismatch{Axis: rsmt2d.Col, Index: 2}, errMismatch)
+}
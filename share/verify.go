@@ -0,0 +1,125 @@
+package share
+
+import (
+	"fmt"
+	"hash"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/minio/sha256-simd"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+// VerifyNamespacedShares validates all the rows of NamespacedShares against the row roots of the
+// given root in one pass. Rows are verified in parallel, while every worker reuses its hasher and
+// leaf buffer across the rows it verifies. The first failed row, in order of rows, is reported.
+func VerifyNamespacedShares(root *Root, nID namespace.ID, shares NamespacedShares) error {
+	rowRoots := make([][]byte, 0, len(shares))
+	for _, row := range root.RowRoots {
+		if !ipld.NamespaceIsOutsideRange(row, row, nID) {
+			rowRoots = append(rowRoots, row)
+		}
+	}
+
+	if len(rowRoots) != len(shares) {
+		return fmt.Errorf("amount of rows differs between root and namespace shares: expected %d, got %d",
+			len(rowRoots), len(shares))
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(shares) {
+		workers = len(shares)
+	}
+
+	var (
+		next   atomic.Int64
+		failed atomic.Int64
+		wg     sync.WaitGroup
+	)
+	// no row has failed yet
+	failed.Store(int64(len(shares)))
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v := &rowVerifier{hasher: sha256.New()}
+			for {
+				i := int(next.Add(1) - 1)
+				// rows after the failed one are not verified anymore
+				if i >= len(shares) || int64(i) > failed.Load() {
+					return
+				}
+				if v.verify(&shares[i], rowRoots[i], nID) {
+					continue
+				}
+				for {
+					prev := failed.Load()
+					if int64(i) >= prev || failed.CompareAndSwap(prev, int64(i)) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if i := int(failed.Load()); i < len(shares) {
+		return fmt.Errorf("row verification failed: row %d doesn't match original root: %s", i, root.String())
+	}
+	return nil
+}
+
+// rowVerifier verifies NamespacedRows one at a time, reusing its state between them.
+type rowVerifier struct {
+	hasher hash.Hash
+	// leaves and buf back the nmt leaves of the row being verified
+	leaves [][]byte
+	buf    []byte
+}
+
+// verify validates the row using nmt inclusion proof.
+func (v *rowVerifier) verify(row *NamespacedRow, rowRoot []byte, nID namespace.ID) bool {
+	if row.Proof == nil {
+		return false
+	}
+
+	// rows proving absence of the namespace may come with empty shares standing for the leaves of
+	// the proven range, which are not part of the proof
+	present := false
+	for _, sh := range row.Shares {
+		if len(sh) != 0 {
+			present = true
+			break
+		}
+	}
+
+	// construct nmt leaves from shares by prepending namespace
+	v.leaves = v.leaves[:0]
+	if present {
+		size := 0
+		for _, sh := range row.Shares {
+			if len(sh) < NamespaceSize {
+				return false
+			}
+			size += NamespaceSize + len(sh)
+		}
+		if cap(v.buf) < size {
+			v.buf = make([]byte, size)
+		}
+		buf := v.buf[:size]
+		for _, sh := range row.Shares {
+			leaf := buf[:NamespaceSize+len(sh)]
+			copy(leaf, sh[:NamespaceSize])
+			copy(leaf[NamespaceSize:], sh)
+			v.leaves = append(v.leaves, leaf)
+			buf = buf[len(leaf):]
+		}
+	}
+
+	// verify namespace
+	return row.Proof.VerifyNamespace(v.hasher, nID, v.leaves, rowRoot)
+}
@@ -0,0 +1,119 @@
+package share
+
+import (
+	"testing"
+
+	"github.com/minio/sha256-simd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	appns "github.com/celestiaorg/celestia-app/pkg/namespace"
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
+
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+func TestVerifyNamespacedShares(t *testing.T) {
+	const odsWidth = 8
+	shares := RandShares(t, odsWidth*odsWidth)
+	// the namespace spans multiple rows
+	nID := shares[5][:NamespaceSize]
+	for _, sh := range shares[6 : 4*odsWidth] {
+		copy(sh[:NamespaceSize], nID)
+	}
+	// leave room for an absent namespace right after the one of share 41
+	shares[41][NamespaceSize-1] = 0
+	eds, err := rsmt2d.ComputeExtendedDataSquare(shares, DefaultRSMT2DCodec(), wrapper.NewConstructor(odsWidth))
+	require.NoError(t, err)
+	dah := da.NewDataAvailabilityHeader(eds)
+
+	ns := namespacedSharesFromEDS(t, eds, &dah, nID)
+	require.Len(t, ns, 4)
+	require.NoError(t, VerifyNamespacedShares(&dah, nID, ns))
+	require.NoError(t, ns.Verify(&dah, nID))
+
+	// absent namespaces are proven with empty shares
+	absent := append(namespace.ID{}, shares[41][:NamespaceSize]...)
+	absent[NamespaceSize-1]++
+	absentShares := namespacedSharesFromEDS(t, eds, &dah, absent)
+	require.Len(t, absentShares, 1)
+	require.True(t, absentShares[0].Proof.IsOfAbsence())
+	require.NoError(t, VerifyNamespacedShares(&dah, absent, absentShares))
+
+	// the failed row is reported
+	tampered := namespacedSharesFromEDS(t, eds, &dah, nID)
+	tampered[2].Shares[0] = append(Share{}, tampered[2].Shares[0]...)
+	tampered[2].Shares[0][NamespaceSize]++
+	err = VerifyNamespacedShares(&dah, nID, tampered)
+	require.ErrorContains(t, err, "row 2")
+
+	// all rows of the namespace are required
+	require.Error(t, VerifyNamespacedShares(&dah, nID, ns[:3]))
+	// proofs are required
+	noProof := namespacedSharesFromEDS(t, eds, &dah, nID)
+	noProof[1].Proof = nil
+	require.Error(t, VerifyNamespacedShares(&dah, nID, noProof))
+}
+
+func BenchmarkVerifyNamespacedShares(b *testing.B) {
+	const odsWidth = 128
+	shares := RandShares(b, odsWidth*odsWidth)
+	nID := shares[0][:NamespaceSize]
+	for _, sh := range shares {
+		copy(sh[:NamespaceSize], nID)
+	}
+	eds, err := rsmt2d.ComputeExtendedDataSquare(shares, DefaultRSMT2DCodec(), wrapper.NewConstructor(odsWidth))
+	require.NoError(b, err)
+	dah := da.NewDataAvailabilityHeader(eds)
+	ns := namespacedSharesFromEDS(b, eds, &dah, nID)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := VerifyNamespacedShares(&dah, nID, ns)
+		require.NoError(b, err)
+	}
+}
+
+// namespacedSharesFromEDS collects the shares of the namespace from every row of the EDS, proving
+// them against the row roots.
+func namespacedSharesFromEDS(
+	t require.TestingT,
+	eds *rsmt2d.ExtendedDataSquare,
+	dah *Root,
+	nID namespace.ID,
+) NamespacedShares {
+	odsWidth := int(eds.Width() / 2)
+	ns := make(NamespacedShares, 0)
+	for i, rowRoot := range dah.RowRoots {
+		if ipld.NamespaceIsOutsideRange(rowRoot, rowRoot, nID) {
+			continue
+		}
+
+		tree := nmt.New(
+			sha256.New(),
+			nmt.NamespaceIDSize(NamespaceSize),
+			nmt.IgnoreMaxNamespace(ipld.NMTIgnoreMaxNamespace),
+		)
+		row := eds.Row(uint(i))
+		for j, sh := range row {
+			leafNs := sh[:NamespaceSize]
+			if j >= odsWidth {
+				leafNs = appns.ParitySharesNamespace.Bytes()
+			}
+			leaf := append(append(make([]byte, 0, NamespaceSize+len(sh)), leafNs...), sh...)
+			require.NoError(t, tree.Push(leaf))
+		}
+		proof, err := tree.ProveNamespace(nID)
+		require.NoError(t, err)
+
+		nsRow := NamespacedRow{Shares: row[proof.Start():proof.End()], Proof: &proof}
+		if proof.IsOfAbsence() {
+			nsRow.Shares = make([]Share, proof.End()-proof.Start())
+		}
+		ns = append(ns, nsRow)
+	}
+	return ns
+}
@@ -0,0 +1,116 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+
+	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
+)
+
+// ErrBalanceBelowMinimum is returned when a submission would leave the account with less than the
+// minimum balance.
+var ErrBalanceBelowMinimum = errors.New("state: submission would drain the account below the minimum balance")
+
+// ErrReadOnly is returned by the methods requiring keys, when the node runs in the read-only mode,
+// without keys.
+var ErrReadOnly = errors.New("state: read-only mode, no keys are configured")
+
+// Account is a key in the node's keyring, which can sign and pay for submissions.
+type Account struct {
+	Name    string  `json:"name"`
+	Address Address `json:"address"`
+}
+
+// Accounts lists the accounts in the node's keyring.
+func (ca *CoreAccessor) Accounts(context.Context) ([]Account, error) {
+	if ca.signer == nil {
+		return nil, ErrReadOnly
+	}
+	records, err := ca.signer.List()
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]Account, 0, len(records))
+	for _, record := range records {
+		addr, err := record.GetAddress()
+		if err != nil {
+			return nil, fmt.Errorf("state: getting address of account %s: %w", record.Name, err)
+		}
+		accounts = append(accounts, Account{Name: record.Name, Address: Address{addr}})
+	}
+	return accounts, nil
+}
+
+// signerFor returns the signer of the account with the given name. An empty name stands for the
+// default account of the node. Signers track the sequences of their accounts, so they are cached
+// and reused for subsequent submissions.
+func (ca *CoreAccessor) signerFor(account string) (*apptypes.KeyringSigner, error) {
+	if ca.signer == nil {
+		return nil, ErrReadOnly
+	}
+	if account == "" || account == ca.signer.GetSignerInfo().Name {
+		return ca.signer, nil
+	}
+
+	ca.signersLk.Lock()
+	defer ca.signersLk.Unlock()
+	if signer, ok := ca.signers[account]; ok {
+		return signer, nil
+	}
+
+	if _, err := ca.signer.Key(account); err != nil {
+		return nil, fmt.Errorf("state: account %s: %w", account, err)
+	}
+	// accounts share the keyring and the chain of the default signer
+	data, err := ca.signer.GetSignerData()
+	if err != nil {
+		return nil, err
+	}
+	signer := apptypes.NewKeyringSigner(ca.signer.Keyring, account, data.ChainID)
+	ca.signers[account] = signer
+	return signer, nil
+}
+
+// signerAddress returns the address of the default account of the node.
+func (ca *CoreAccessor) signerAddress() (sdktypes.AccAddress, error) {
+	if ca.signer == nil {
+		return nil, ErrReadOnly
+	}
+	return ca.signer.GetSignerInfo().GetAddress()
+}
+
+// checkBalance refuses spending the given amounts from the signer's account, if it would leave
+// the account with less than the minimum balance.
+//
+// NOTE: The balance is the one verified against the node's head, so transactions of the account
+// included since aren't accounted for.
+func (ca *CoreAccessor) checkBalance(ctx context.Context, signer *apptypes.KeyringSigner, amounts ...Int) error {
+	if ca.minBalance.IsNil() || !ca.minBalance.IsPositive() {
+		return nil
+	}
+
+	addr, err := signer.GetSignerInfo().GetAddress()
+	if err != nil {
+		return err
+	}
+	balance, err := ca.BalanceForAddress(ctx, Address{addr})
+	if err != nil {
+		return fmt.Errorf("state: checking balance of %s: %w", addr, err)
+	}
+
+	remaining := balance.Amount
+	for _, amount := range amounts {
+		if !amount.IsNil() {
+			remaining = remaining.Sub(amount)
+		}
+	}
+	if remaining.LT(ca.minBalance) {
+		return fmt.Errorf("%w: account %s has %s, %s would remain of minimum %s",
+			ErrBalanceBelowMinimum, addr, balance.Amount, remaining, ca.minBalance)
+	}
+	return nil
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	sdkErrors "cosmossdk.io/errors"
 	"github.com/cosmos/cosmos-sdk/api/tendermint/abci"
+	nodeservice "github.com/cosmos/cosmos-sdk/client/grpc/node"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
@@ -17,11 +19,11 @@ import (
 	"github.com/tendermint/tendermint/crypto/merkle"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	"github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/celestiaorg/celestia-app/app"
-	appblob "github.com/celestiaorg/celestia-app/x/blob"
 	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
 	libhead "github.com/celestiaorg/go-header"
 
@@ -40,22 +42,48 @@ type CoreAccessor struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
-	signer *apptypes.KeyringSigner
-	getter libhead.Head[*header.ExtendedHeader]
+	// signer signs for the default account, and signers for the other accounts of the keyring.
+	signer    *apptypes.KeyringSigner
+	signersLk sync.Mutex
+	signers   map[string]*apptypes.KeyringSigner
+	// sequences track the sequences of the accounts, guarded by signersLk.
+	sequences map[string]*accountSequence
+	getter    libhead.Head[*header.ExtendedHeader]
+
+	// minBalance is the balance submissions must not drain accounts below.
+	minBalance Int
+	// resubmission makes PayForBlob transactions resubmitted with escalated fees, if set.
+	resubmission *resubmission
 
 	queryCli   banktypes.QueryClient
 	stakingCli stakingtypes.QueryClient
+	blobCli    apptypes.QueryClient
+	nodeCli    nodeservice.ServiceClient
 	rpcCli     rpcclient.ABCIClient
 
 	prt *merkle.ProofRuntime
 
 	coreConn *grpc.ClientConn
-	coreIP   string
-	rpcPort  string
-	grpcPort string
+	// pool fails over to the failoverEndpoints, if any are configured.
+	pool              *endpointPool
+	failoverEndpoints []Endpoint
+	coreIP            string
+	rpcPort           string
+	grpcPort          string
+
+	lastPayForBlob  atomic.Int64
+	payForBlobCount atomic.Int64
+}
+
+// Option configures the CoreAccessor.
+type Option func(*CoreAccessor)
 
-	lastPayForBlob  int64
-	payForBlobCount int64
+// WithMinBalance makes the CoreAccessor refuse submissions, which would leave the paying account
+// with less than the given balance, so that funds needed to operate the node are not spent.
+func WithMinBalance(min Int) Option {
+	return func(ca *CoreAccessor) {
+		ca.minBalance = min
+	}
 }
 
 // NewCoreAccessor dials the given celestia-core endpoint and
@@ -67,19 +95,26 @@ func NewCoreAccessor(
 	coreIP,
 	rpcPort string,
 	grpcPort string,
+	opts ...Option,
 ) *CoreAccessor {
 	// create verifier
 	prt := merkle.DefaultProofRuntime()
 	prt.RegisterOpDecoder(storetypes.ProofOpIAVLCommitment, storetypes.CommitmentOpDecoder)
 	prt.RegisterOpDecoder(storetypes.ProofOpSimpleMerkleCommitment, storetypes.CommitmentOpDecoder)
-	return &CoreAccessor{
-		signer:   signer,
-		getter:   getter,
-		coreIP:   coreIP,
-		rpcPort:  rpcPort,
-		grpcPort: grpcPort,
-		prt:      prt,
-	}
+	ca := &CoreAccessor{
+		signer:    signer,
+		signers:   make(map[string]*apptypes.KeyringSigner),
+		sequences: make(map[string]*accountSequence),
+		getter:    getter,
+		coreIP:    coreIP,
+		rpcPort:   rpcPort,
+		grpcPort:  grpcPort,
+		prt:       prt,
+	}
+	for _, opt := range opts {
+		opt(ca)
+	}
+	return ca
 }
 
 func (ca *CoreAccessor) Start(ctx context.Context) error {
@@ -88,26 +123,44 @@ func (ca *CoreAccessor) Start(ctx context.Context) error {
 	}
 	ca.ctx, ca.cancel = context.WithCancel(context.Background())
 
-	// dial given celestia-core endpoint
-	endpoint := fmt.Sprintf("%s:%s", ca.coreIP, ca.grpcPort)
-	client, err := grpc.DialContext(ctx, endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		return err
+	if len(ca.failoverEndpoints) > 0 {
+		// dial all the celestia-core endpoints, and route requests to the preferred one
+		pool, err := newEndpointPool(append(
+			[]Endpoint{{IP: ca.coreIP, RPCPort: ca.rpcPort, GRPCPort: ca.grpcPort}},
+			ca.failoverEndpoints...,
+		))
+		if err != nil {
+			return err
+		}
+		ca.pool = pool
+		ca.coreConn = pool.main.conn
+		ca.rpcCli = pool.main.rpcCli
+		go pool.checkHealth(ca.ctx)
+	} else {
+		// dial given celestia-core endpoint
+		endpoint := fmt.Sprintf("%s:%s", ca.coreIP, ca.grpcPort)
+		client, err := grpc.DialContext(ctx, endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return err
+		}
+		ca.coreConn = client
+		// create ABCI query client
+		cli, err := http.New(fmt.Sprintf("http://%s:%s", ca.coreIP, ca.rpcPort), "/websocket")
+		if err != nil {
+			return err
+		}
+		ca.rpcCli = cli
 	}
-	ca.coreConn = client
 	// create the query client
 	queryCli := banktypes.NewQueryClient(ca.coreConn)
 	ca.queryCli = queryCli
 	// create the staking query client
 	stakingCli := stakingtypes.NewQueryClient(ca.coreConn)
 	ca.stakingCli = stakingCli
-	// create ABCI query client
-	cli, err := http.New(fmt.Sprintf("http://%s:%s", ca.coreIP, ca.rpcPort), "/websocket")
-	if err != nil {
-		return err
-	}
-	ca.rpcCli = cli
-
+	// create the blob query client
+	ca.blobCli = apptypes.NewQueryClient(ca.coreConn)
+	// create the node service client
+	ca.nodeCli = nodeservice.NewServiceClient(ca.coreConn)
 	return nil
 }
 
@@ -122,9 +175,11 @@ func (ca *CoreAccessor) Stop(context.Context) error {
 	}
 	defer ca.cancelCtx()
 
-	// close out core connection
-	err := ca.coreConn.Close()
-	if err != nil {
+	// close out core connections
+	if ca.pool != nil {
+		ca.pool.close()
+		ca.pool = nil
+	} else if err := ca.coreConn.Close(); err != nil {
 		return err
 	}
 
@@ -138,22 +193,46 @@ func (ca *CoreAccessor) cancelCtx() {
 	ca.cancel = nil
 }
 
-func (ca *CoreAccessor) constructSignedTx(
+// submitMsg signs the message with the next sequence of the signer's account and submits it,
+// blocking until its inclusion.
+func (ca *CoreAccessor) submitMsg(
 	ctx context.Context,
+	signer *apptypes.KeyringSigner,
 	msg sdktypes.Msg,
 	opts ...apptypes.TxBuilderOption,
-) ([]byte, error) {
-	// should be called first in order to make a valid tx
-	err := ca.signer.QueryAccountNumber(ctx, ca.coreConn)
+) (*TxResponse, error) {
+	return ca.submitNext(ctx, signer, func(signer *apptypes.KeyringSigner) ([]byte, error) {
+		tx, err := signer.BuildSignedTx(signer.NewTxBuilder(opts...), msg)
+		if err != nil {
+			return nil, err
+		}
+		return signer.EncodeTx(tx)
+	})
+}
+
+// submitNext signs the transaction with the next sequence of the signer's account and submits it,
+// blocking until its inclusion. Transactions are accepted to the mempool one by one in the order
+// of their sequences, but their inclusion is awaited concurrently.
+func (ca *CoreAccessor) submitNext(
+	ctx context.Context,
+	signer *apptypes.KeyringSigner,
+	build txBuilder,
+) (*TxResponse, error) {
+	head, err := ca.getter.Head(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	tx, err := ca.signer.BuildSignedTx(ca.signer.NewTxBuilder(opts...), msg)
+	resp, _, err := ca.broadcastNext(ctx, signer, build)
 	if err != nil {
-		return nil, err
+		return resp, err
+	}
+	included, err := ca.awaitInclusion(ctx, []string{resp.TxHash}, head.Height()+inclusionBlocks)
+	if included == nil && err == nil {
+		// the sequence is free again, if the transaction is dropped from the mempool
+		ca.sequenceOf(signer).invalidate()
+		return resp, fmt.Errorf("%w: hash %s, blocks %d", ErrNotIncluded, resp.TxHash, inclusionBlocks)
 	}
-	return ca.signer.EncodeTx(tx)
+	return included, err
 }
 
 func (ca *CoreAccessor) SubmitPayForBlob(
@@ -161,38 +240,72 @@ func (ca *CoreAccessor) SubmitPayForBlob(
 	fee Int,
 	gasLim uint64,
 	blobs []*blob.Blob,
+) (*TxResponse, error) {
+	return ca.SubmitPayForBlobFrom(ctx, "", fee, gasLim, blobs)
+}
+
+// SubmitPayForBlobFrom submits a PayForBlob transaction paid by the account with the given name.
+func (ca *CoreAccessor) SubmitPayForBlobFrom(
+	ctx context.Context,
+	account string,
+	fee Int,
+	gasLim uint64,
+	blobs []*blob.Blob,
 ) (*TxResponse, error) {
 	if len(blobs) == 0 {
 		return nil, errors.New("state: no blobs provided")
 	}
 
+	signer, err := ca.signerFor(account)
+	if err != nil {
+		return nil, err
+	}
+	if err = ca.checkBalance(ctx, signer, fee); err != nil {
+		return nil, err
+	}
+
 	appblobs := make([]*apptypes.Blob, len(blobs))
 	for i, blob := range blobs {
 		appblobs[i] = &blob.Blob
 	}
+	var resp *TxResponse
+	if ca.resubmission != nil {
+		resp, err = ca.submitWithResubmission(ctx, signer, fee, gasLim, appblobs)
+	} else {
+		resp, err = ca.submitNext(ctx, signer, payForBlobTx(fee, gasLim, appblobs))
+	}
+	if err == nil && resp != nil {
+		ca.lastPayForBlob.Store(time.Now().UnixMilli())
+		ca.payForBlobCount.Add(1)
+	}
+	return resp, err
+}
 
-	response, err := appblob.SubmitPayForBlob(
-		ctx,
-		ca.signer,
-		ca.coreConn,
-		appblobs,
-		apptypes.SetGasLimit(gasLim),
-		withFee(fee),
-	)
-	// metrics should only be counted on a successful PFD tx
-	if err == nil && response.Code == 0 {
-		ca.lastPayForBlob = time.Now().UnixMilli()
-		ca.payForBlobCount++
+// GasPerBlobByte returns the amount of gas the network currently charges per byte of blob data.
+func (ca *CoreAccessor) GasPerBlobByte(ctx context.Context) (uint32, error) {
+	resp, err := ca.blobCli.Params(ctx, &apptypes.QueryParamsRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("querying blob params: %w", err)
 	}
+	return resp.Params.GasPerBlobByte, nil
+}
 
-	if response != nil && response.Code != 0 {
-		err = errors.Join(err, sdkErrors.ABCIError(response.Codespace, response.Code, response.Logs.String()))
+// MinGasPrice returns the minimum gas price, in utia, the connected celestia-core node accepts
+// transactions at.
+func (ca *CoreAccessor) MinGasPrice(ctx context.Context) (float64, error) {
+	resp, err := ca.nodeCli.Config(ctx, &nodeservice.ConfigRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("querying node config: %w", err)
+	}
+	prices, err := sdktypes.ParseDecCoins(resp.MinimumGasPrice)
+	if err != nil {
+		return 0, fmt.Errorf("parsing minimum gas price %q: %w", resp.MinimumGasPrice, err)
 	}
-	return response, err
+	return prices.AmountOf(app.BondDenom).Float64()
 }
 
 func (ca *CoreAccessor) AccountAddress(context.Context) (Address, error) {
-	addr, err := ca.signer.GetSignerInfo().GetAddress()
+	addr, err := ca.signerAddress()
 	if err != nil {
 		return Address{nil}, err
 	}
@@ -200,7 +313,7 @@ func (ca *CoreAccessor) AccountAddress(context.Context) (Address, error) {
 }
 
 func (ca *CoreAccessor) Balance(ctx context.Context) (*Balance, error) {
-	addr, err := ca.signer.GetSignerInfo().GetAddress()
+	addr, err := ca.signerAddress()
 	if err != nil {
 		return nil, err
 	}
@@ -229,7 +342,12 @@ func (ca *CoreAccessor) BalanceForAddress(ctx context.Context, addr Address) (*B
 		Height: abciReq.Height,
 		Prove:  abciReq.Prove,
 	}
-	result, err := ca.rpcCli.ABCIQueryWithOptions(ctx, abciReq.Path, abciReq.Data, opts)
+	var result *coretypes.ResultABCIQuery
+	if ca.pool != nil {
+		result, err = ca.pool.abciQuery(ctx, abciReq.Path, abciReq.Data, opts)
+	} else {
+		result, err = ca.rpcCli.ABCIQueryWithOptions(ctx, abciReq.Path, abciReq.Data, opts)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -293,22 +411,37 @@ func (ca *CoreAccessor) Transfer(
 	amount,
 	fee Int,
 	gasLim uint64,
+) (*TxResponse, error) {
+	return ca.TransferFrom(ctx, "", addr, amount, fee, gasLim)
+}
+
+// TransferFrom sends the given amount of coins from the account with the given name.
+func (ca *CoreAccessor) TransferFrom(
+	ctx context.Context,
+	account string,
+	addr AccAddress,
+	amount,
+	fee Int,
+	gasLim uint64,
 ) (*TxResponse, error) {
 	if amount.IsNil() || amount.Int64() <= 0 {
 		return nil, ErrInvalidAmount
 	}
 
-	from, err := ca.signer.GetSignerInfo().GetAddress()
+	signer, err := ca.signerFor(account)
 	if err != nil {
 		return nil, err
 	}
-	coins := sdktypes.NewCoins(sdktypes.NewCoin(app.BondDenom, amount))
-	msg := banktypes.NewMsgSend(from, addr, coins)
-	signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
+	if err = ca.checkBalance(ctx, signer, amount, fee); err != nil {
+		return nil, err
+	}
+	from, err := signer.GetSignerInfo().GetAddress()
 	if err != nil {
 		return nil, err
 	}
-	return ca.SubmitTx(ctx, signedTx)
+	coins := sdktypes.NewCoins(sdktypes.NewCoin(app.BondDenom, amount))
+	msg := banktypes.NewMsgSend(from, addr, coins)
+	return ca.submitMsg(ctx, signer, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
 }
 
 func (ca *CoreAccessor) CancelUnbondingDelegation(
@@ -322,18 +455,16 @@ func (ca *CoreAccessor) CancelUnbondingDelegation(
 	if amount.IsNil() || amount.Int64() <= 0 {
 		return nil, ErrInvalidAmount
 	}
-
-	from, err := ca.signer.GetSignerInfo().GetAddress()
+	from, err := ca.signerAddress()
 	if err != nil {
 		return nil, err
 	}
-	coins := sdktypes.NewCoin(app.BondDenom, amount)
-	msg := stakingtypes.NewMsgCancelUnbondingDelegation(from, valAddr, height.Int64(), coins)
-	signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
-	if err != nil {
+	if err = ca.checkBalance(ctx, ca.signer, fee); err != nil {
 		return nil, err
 	}
-	return ca.SubmitTx(ctx, signedTx)
+	coins := sdktypes.NewCoin(app.BondDenom, amount)
+	msg := stakingtypes.NewMsgCancelUnbondingDelegation(from, valAddr, height.Int64(), coins)
+	return ca.submitMsg(ctx, ca.signer, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
 }
 
 func (ca *CoreAccessor) BeginRedelegate(
@@ -347,18 +478,16 @@ func (ca *CoreAccessor) BeginRedelegate(
 	if amount.IsNil() || amount.Int64() <= 0 {
 		return nil, ErrInvalidAmount
 	}
-
-	from, err := ca.signer.GetSignerInfo().GetAddress()
+	from, err := ca.signerAddress()
 	if err != nil {
 		return nil, err
 	}
-	coins := sdktypes.NewCoin(app.BondDenom, amount)
-	msg := stakingtypes.NewMsgBeginRedelegate(from, srcValAddr, dstValAddr, coins)
-	signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
-	if err != nil {
+	if err = ca.checkBalance(ctx, ca.signer, fee); err != nil {
 		return nil, err
 	}
-	return ca.SubmitTx(ctx, signedTx)
+	coins := sdktypes.NewCoin(app.BondDenom, amount)
+	msg := stakingtypes.NewMsgBeginRedelegate(from, srcValAddr, dstValAddr, coins)
+	return ca.submitMsg(ctx, ca.signer, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
 }
 
 func (ca *CoreAccessor) Undelegate(
@@ -371,18 +500,16 @@ func (ca *CoreAccessor) Undelegate(
 	if amount.IsNil() || amount.Int64() <= 0 {
 		return nil, ErrInvalidAmount
 	}
-
-	from, err := ca.signer.GetSignerInfo().GetAddress()
+	from, err := ca.signerAddress()
 	if err != nil {
 		return nil, err
 	}
-	coins := sdktypes.NewCoin(app.BondDenom, amount)
-	msg := stakingtypes.NewMsgUndelegate(from, delAddr, coins)
-	signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
-	if err != nil {
+	if err = ca.checkBalance(ctx, ca.signer, fee); err != nil {
 		return nil, err
 	}
-	return ca.SubmitTx(ctx, signedTx)
+	coins := sdktypes.NewCoin(app.BondDenom, amount)
+	msg := stakingtypes.NewMsgUndelegate(from, delAddr, coins)
+	return ca.submitMsg(ctx, ca.signer, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
 }
 
 func (ca *CoreAccessor) Delegate(
@@ -395,25 +522,23 @@ func (ca *CoreAccessor) Delegate(
 	if amount.IsNil() || amount.Int64() <= 0 {
 		return nil, ErrInvalidAmount
 	}
-
-	from, err := ca.signer.GetSignerInfo().GetAddress()
+	from, err := ca.signerAddress()
 	if err != nil {
 		return nil, err
 	}
-	coins := sdktypes.NewCoin(app.BondDenom, amount)
-	msg := stakingtypes.NewMsgDelegate(from, delAddr, coins)
-	signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
-	if err != nil {
+	if err = ca.checkBalance(ctx, ca.signer, amount, fee); err != nil {
 		return nil, err
 	}
-	return ca.SubmitTx(ctx, signedTx)
+	coins := sdktypes.NewCoin(app.BondDenom, amount)
+	msg := stakingtypes.NewMsgDelegate(from, delAddr, coins)
+	return ca.submitMsg(ctx, ca.signer, msg, apptypes.SetGasLimit(gasLim), withFee(fee))
 }
 
 func (ca *CoreAccessor) QueryDelegation(
 	ctx context.Context,
 	valAddr ValAddress,
 ) (*stakingtypes.QueryDelegationResponse, error) {
-	delAddr, err := ca.signer.GetSignerInfo().GetAddress()
+	delAddr, err := ca.signerAddress()
 	if err != nil {
 		return nil, err
 	}
@@ -427,7 +552,7 @@ func (ca *CoreAccessor) QueryUnbonding(
 	ctx context.Context,
 	valAddr ValAddress,
 ) (*stakingtypes.QueryUnbondingDelegationResponse, error) {
-	delAddr, err := ca.signer.GetSignerInfo().GetAddress()
+	delAddr, err := ca.signerAddress()
 	if err != nil {
 		return nil, err
 	}
@@ -441,7 +566,7 @@ func (ca *CoreAccessor) QueryRedelegations(
 	srcValAddr,
 	dstValAddr ValAddress,
 ) (*stakingtypes.QueryRedelegationsResponse, error) {
-	delAddr, err := ca.signer.GetSignerInfo().GetAddress()
+	delAddr, err := ca.signerAddress()
 	if err != nil {
 		return nil, err
 	}
@@ -2,9 +2,14 @@ package state
 
 import (
 	"context"
+	"net"
 	"testing"
+	"time"
 
+	"cosmossdk.io/math"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 func TestLifecycle(t *testing.T) {
@@ -31,3 +36,68 @@ func TestLifecycle(t *testing.T) {
 	err = ca.Stop(stopCtx)
 	require.NoError(t, err)
 }
+
+func TestFailover(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	// the main endpoint is unreachable
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	_, unreachablePort, err := net.SplitHostPort(lis.Addr().String())
+	require.NoError(t, err)
+	require.NoError(t, lis.Close())
+
+	lis, err = net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	banktypes.RegisterQueryServer(srv, &bankParamsServer{})
+	go srv.Serve(lis) //nolint:errcheck
+	t.Cleanup(srv.Stop)
+	_, port, err := net.SplitHostPort(lis.Addr().String())
+	require.NoError(t, err)
+
+	ca := NewCoreAccessor(nil, nil, "127.0.0.1", unreachablePort, unreachablePort,
+		WithFailoverEndpoints(Endpoint{IP: "127.0.0.1", RPCPort: port, GRPCPort: port}))
+	require.NoError(t, ca.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, ca.Stop(context.Background()))
+	})
+
+	// requests to the main endpoint are served by the failover one
+	_, err = ca.queryCli.Params(ctx, &banktypes.QueryParamsRequest{})
+	require.NoError(t, err)
+
+	endpoints := ca.pool.ordered()
+	require.Len(t, endpoints, 2)
+	require.Equal(t, port, endpoints[0].GRPCPort)
+	require.True(t, endpoints[0].healthy.Load())
+	require.False(t, endpoints[1].healthy.Load())
+}
+
+type bankParamsServer struct {
+	banktypes.UnimplementedQueryServer
+}
+
+func (*bankParamsServer) Params(
+	context.Context,
+	*banktypes.QueryParamsRequest,
+) (*banktypes.QueryParamsResponse, error) {
+	return &banktypes.QueryParamsResponse{}, nil
+}
+
+func TestReadOnly(t *testing.T) {
+	ca := NewCoreAccessor(nil, nil, "", "", "", WithMinBalance(math.NewInt(1)))
+	ctx := context.Background()
+
+	_, err := ca.AccountAddress(ctx)
+	require.ErrorIs(t, err, ErrReadOnly)
+	_, err = ca.Accounts(ctx)
+	require.ErrorIs(t, err, ErrReadOnly)
+	_, err = ca.Transfer(ctx, AccAddress{}, math.NewInt(1), math.NewInt(1), 1)
+	require.ErrorIs(t, err, ErrReadOnly)
+	_, err = ca.Delegate(ctx, ValAddress{}, math.NewInt(1), math.NewInt(1), 1)
+	require.ErrorIs(t, err, ErrReadOnly)
+	_, err = ca.QueryDelegation(ctx, ValAddress{})
+	require.ErrorIs(t, err, ErrReadOnly)
+}
@@ -0,0 +1,238 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	"github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// healthCheckInterval is how often the health and latency of the core endpoints are checked.
+	healthCheckInterval = 10 * time.Second
+	// healthCheckTimeout is the time a core endpoint has to respond to a health check.
+	healthCheckTimeout = 5 * time.Second
+)
+
+// Endpoint is a celestia-core endpoint.
+type Endpoint struct {
+	IP       string
+	RPCPort  string
+	GRPCPort string
+}
+
+// WithFailoverEndpoints makes the CoreAccessor fail over to the given endpoints, if the main one is
+// unhealthy. Requests are served by the healthy endpoint with the lowest latency.
+func WithFailoverEndpoints(endpoints ...Endpoint) Option {
+	return func(ca *CoreAccessor) {
+		ca.failoverEndpoints = append(ca.failoverEndpoints, endpoints...)
+	}
+}
+
+// coreEndpoint is a connection to a core endpoint along with its health.
+type coreEndpoint struct {
+	Endpoint
+
+	conn   *grpc.ClientConn
+	rpcCli rpcclient.ABCIClient
+
+	healthy atomic.Bool
+	// latency is the duration of the last health check in nanoseconds.
+	latency atomic.Int64
+}
+
+// endpointPool routes gRPC requests of the CoreAccessor to the preferred endpoint, and retries
+// them on the other endpoints on connection errors.
+type endpointPool struct {
+	// main is the endpoint which connection is used by the clients of the CoreAccessor. Its
+	// requests are routed through the pool.
+	main *coreEndpoint
+
+	lk sync.RWMutex
+	// endpoints are ordered by preference: healthy first, then by latency.
+	endpoints []*coreEndpoint
+}
+
+// bypassFailover marks health check requests, which must be served by the endpoint they are sent
+// to.
+type bypassFailover struct{}
+
+func newEndpointPool(endpoints []Endpoint) (*endpointPool, error) {
+	pool := &endpointPool{endpoints: make([]*coreEndpoint, 0, len(endpoints))}
+	for i, endpoint := range endpoints {
+		dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+		if i == 0 {
+			dialOpts = append(dialOpts, grpc.WithUnaryInterceptor(pool.intercept))
+		}
+		conn, err := grpc.Dial(fmt.Sprintf("%s:%s", endpoint.IP, endpoint.GRPCPort), dialOpts...)
+		if err != nil {
+			pool.close()
+			return nil, err
+		}
+		rpcCli, err := http.New(fmt.Sprintf("http://%s:%s", endpoint.IP, endpoint.RPCPort), "/websocket")
+		if err != nil {
+			_ = conn.Close()
+			pool.close()
+			return nil, err
+		}
+
+		ep := &coreEndpoint{Endpoint: endpoint, conn: conn, rpcCli: rpcCli}
+		ep.healthy.Store(true)
+		if i == 0 {
+			pool.main = ep
+		}
+		pool.endpoints = append(pool.endpoints, ep)
+	}
+	return pool, nil
+}
+
+// ordered returns the endpoints in the order of preference.
+func (p *endpointPool) ordered() []*coreEndpoint {
+	p.lk.RLock()
+	defer p.lk.RUnlock()
+	return append([]*coreEndpoint(nil), p.endpoints...)
+}
+
+// sort orders the endpoints by preference. The order of endpoints of the same health and latency
+// is kept, so that the configured order is preferred.
+func (p *endpointPool) sort() {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	sort.SliceStable(p.endpoints, func(i, j int) bool {
+		iHealthy, jHealthy := p.endpoints[i].healthy.Load(), p.endpoints[j].healthy.Load()
+		if iHealthy != jHealthy {
+			return iHealthy
+		}
+		return p.endpoints[i].latency.Load() < p.endpoints[j].latency.Load()
+	})
+}
+
+// intercept sends the request to the endpoints in the order of preference, until one of them is
+// reachable.
+func (p *endpointPool) intercept(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if ctx.Value(bypassFailover{}) != nil {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	var err error
+	for _, ep := range p.ordered() {
+		if ep.conn == cc {
+			err = invoker(ctx, method, req, reply, cc, opts...)
+		} else {
+			err = ep.conn.Invoke(ctx, method, req, reply, opts...)
+		}
+		if status.Code(err) != codes.Unavailable || ctx.Err() != nil {
+			return err
+		}
+
+		log.Warnw("core endpoint unavailable, failing over",
+			"ip", ep.IP,
+			"grpc_port", ep.GRPCPort,
+			"method", method,
+			"err", err,
+		)
+		if ep.healthy.Swap(false) {
+			p.sort()
+		}
+	}
+	return err
+}
+
+// abciQuery sends the ABCI query to the endpoints in the order of preference, until one of them
+// responds.
+func (p *endpointPool) abciQuery(
+	ctx context.Context,
+	path string,
+	data []byte,
+	opts rpcclient.ABCIQueryOptions,
+) (result *coretypes.ResultABCIQuery, err error) {
+	for _, ep := range p.ordered() {
+		result, err = ep.rpcCli.ABCIQueryWithOptions(ctx, path, data, opts)
+		if err == nil || ctx.Err() != nil {
+			return result, err
+		}
+
+		log.Warnw("core endpoint unavailable, failing over",
+			"ip", ep.IP,
+			"rpc_port", ep.RPCPort,
+			"err", err,
+		)
+		if ep.healthy.Swap(false) {
+			p.sort()
+		}
+	}
+	return nil, err
+}
+
+// checkHealth periodically checks the health and latency of the endpoints, and reorders them by
+// preference, until the context is canceled.
+func (p *endpointPool) checkHealth(ctx context.Context) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		var wg sync.WaitGroup
+		for _, ep := range p.ordered() {
+			wg.Add(1)
+			go func(ep *coreEndpoint) {
+				defer wg.Done()
+				p.checkEndpoint(ctx, ep)
+			}(ep)
+		}
+		wg.Wait()
+		p.sort()
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkEndpoint checks the health of the endpoint by querying bank params, and records its latency.
+func (p *endpointPool) checkEndpoint(ctx context.Context, ep *coreEndpoint) {
+	ctx, cancel := context.WithTimeout(context.WithValue(ctx, bypassFailover{}, true), healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	_, err := banktypes.NewQueryClient(ep.conn).Params(ctx, &banktypes.QueryParamsRequest{})
+	if err != nil {
+		if ep.healthy.Swap(false) {
+			log.Warnw("core endpoint unhealthy", "ip", ep.IP, "grpc_port", ep.GRPCPort, "err", err)
+		}
+		return
+	}
+	ep.latency.Store(int64(time.Since(start)))
+	if !ep.healthy.Swap(true) {
+		log.Infow("core endpoint healthy again", "ip", ep.IP, "grpc_port", ep.GRPCPort)
+	}
+}
+
+func (p *endpointPool) close() {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	for _, ep := range p.endpoints {
+		if err := ep.conn.Close(); err != nil {
+			log.Warnw("closing core endpoint connection", "ip", ep.IP, "err", err)
+		}
+	}
+	p.endpoints = nil
+}
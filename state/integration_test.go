@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/stretchr/testify/require"
@@ -21,8 +23,10 @@ import (
 	"github.com/celestiaorg/celestia-app/test/util/testnode"
 	blobtypes "github.com/celestiaorg/celestia-app/x/blob/types"
 
+	"github.com/celestiaorg/celestia-node/blob"
 	"github.com/celestiaorg/celestia-node/core"
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
 )
 
 func TestIntegrationTestSuite(t *testing.T) {
@@ -144,3 +148,100 @@ func (s *IntegrationTestSuite) TestGenerateJSONBlock() {
 	err = json.NewEncoder(file).Encode(pBlock)
 	require.NoError(err)
 }
+
+func (s *IntegrationTestSuite) TestAccounts_MinBalance() {
+	require := s.Require()
+	ctx := context.Background()
+
+	accounts, err := s.accessor.Accounts(ctx)
+	require.NoError(err)
+	names := make([]string, 0, len(accounts))
+	for _, acc := range accounts {
+		names = append(names, acc.Name)
+		require.Equal(s.getAddress(acc.Name), acc.Address.Address)
+	}
+	require.Subset(names, s.accounts)
+
+	_, err = s.accessor.TransferFrom(ctx, "unknown", s.getAddress(s.accounts[0]).Bytes(),
+		sdk.NewInt(1), sdk.NewInt(1), 100000)
+	require.Error(err)
+
+	// the whole balance is reserved, so no account can pay for anything
+	s.accessor.minBalance = sdk.NewInt(int64(99999999999999999))
+	defer func() { s.accessor.minBalance = Int{} }()
+	for _, acc := range s.accounts[:2] {
+		_, err = s.accessor.TransferFrom(ctx, acc, s.getAddress(s.accounts[2]).Bytes(),
+			sdk.NewInt(1), sdk.NewInt(1), 100000)
+		require.ErrorIs(err, ErrBalanceBelowMinimum)
+	}
+	_, err = s.accessor.Delegate(ctx, s.getAddress(s.accounts[0]).Bytes(),
+		sdk.NewInt(1), sdk.NewInt(1), 100000)
+	require.ErrorIs(err, ErrBalanceBelowMinimum)
+}
+
+func (s *IntegrationTestSuite) TestSubmitPayForBlob_Resubmission() {
+	require := s.Require()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	s.accessor.resubmission = &resubmission{blocks: 2, feeMultiplier: sdk.NewDec(2), attempts: 2}
+	defer func() { s.accessor.resubmission = nil }()
+
+	nID, err := share.NewNamespaceV0([]byte("resubmit"))
+	require.NoError(err)
+	b, err := blob.NewBlob(0, nID, []byte("data"))
+	require.NoError(err)
+
+	require.NoError(s.accessor.signer.QueryAccountNumber(ctx, s.accessor.coreConn))
+	signerData, err := s.accessor.signer.GetSignerData()
+	require.NoError(err)
+
+	resp, err := s.accessor.SubmitPayForBlob(ctx, sdk.NewInt(100000), 200000, []*blob.Blob{b})
+	require.NoError(err)
+	require.EqualValues(0, resp.Code)
+	require.Positive(resp.Height)
+
+	// a resubmission of the included transaction conflicts with it
+	s.accessor.signer.SetSequence(signerData.Sequence)
+	tx, err := payForBlobTx(sdk.NewInt(200000), 200000, []*blobtypes.Blob{&b.Blob})(s.accessor.signer)
+	require.NoError(err)
+	_, err = s.accessor.broadcastSync(ctx, tx)
+	require.ErrorIs(err, sdkerrors.ErrWrongSequence)
+}
+
+func (s *IntegrationTestSuite) TestSubmitPayForBlob_Concurrent() {
+	require := s.Require()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	nID, err := share.NewNamespaceV0([]byte("concurrent"))
+	require.NoError(err)
+
+	const submissions = 4
+	errs := make(chan error, submissions)
+	for i := 0; i < submissions; i++ {
+		go func(i int) {
+			b, err := blob.NewBlob(0, nID, []byte{byte(i)})
+			if err != nil {
+				errs <- err
+				return
+			}
+			_, err = s.accessor.SubmitPayForBlob(ctx, sdk.NewInt(100000), 200000, []*blob.Blob{b})
+			errs <- err
+		}(i)
+	}
+	for i := 0; i < submissions; i++ {
+		require.NoError(<-errs)
+	}
+
+	// a diverged local sequence is recovered from the mismatch error
+	seq := s.accessor.sequenceOf(s.accessor.signer)
+	seq.lk.Lock()
+	seq.next += 10
+	seq.lk.Unlock()
+	b, err := blob.NewBlob(0, nID, []byte("recovered"))
+	require.NoError(err)
+	resp, err := s.accessor.SubmitPayForBlob(ctx, sdk.NewInt(100000), 200000, []*blob.Blob{b})
+	require.NoError(err)
+	require.EqualValues(0, resp.Code)
+}
@@ -25,8 +25,8 @@ func WithMetrics(ca *CoreAccessor) {
 	err := meter.RegisterCallback(
 		[]instrument.Asynchronous{pfbCounter, lastPfbTimestamp},
 		func(ctx context.Context) {
-			pfbCounter.Observe(ctx, ca.payForBlobCount)
-			lastPfbTimestamp.Observe(ctx, ca.lastPayForBlob)
+			pfbCounter.Observe(ctx, ca.payForBlobCount.Load())
+			lastPfbTimestamp.Observe(ctx, ca.lastPayForBlob.Load())
 		},
 	)
 	if err != nil {
@@ -0,0 +1,221 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	sdkErrors "cosmossdk.io/errors"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	coretypes "github.com/tendermint/tendermint/types"
+
+	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
+)
+
+// ErrNotIncluded is returned when a transaction accepted to the mempool is not included in a block
+// in time, after all the resubmissions, if enabled.
+var ErrNotIncluded = errors.New("state: transaction not included")
+
+var (
+	// inclusionPollInterval is how often inclusion of submitted transactions is checked.
+	inclusionPollInterval = time.Second
+	// inclusionBlocks is the amount of blocks to wait for the inclusion of a transaction, which is not
+	// resubmitted.
+	inclusionBlocks int64 = 20
+)
+
+// resubmission configures resubmission of PayForBlob transactions stuck in the mempool.
+type resubmission struct {
+	// blocks is the amount of blocks to wait for the inclusion of a transaction before it is
+	// resubmitted.
+	blocks uint64
+	// feeMultiplier escalates the fee of every resubmission.
+	feeMultiplier sdktypes.Dec
+	// attempts is the maximum amount of times a transaction is submitted.
+	attempts int
+}
+
+// WithResubmission makes the CoreAccessor broadcast PayForBlob transactions without blocking
+// until their inclusion, and resubmit them with the fee multiplied by the given factor, if they are
+// not included within the given amount of blocks, until they are submitted the given amount of
+// times.
+func WithResubmission(blocks uint64, feeMultiplier float64, attempts int) Option {
+	return func(ca *CoreAccessor) {
+		ca.resubmission = &resubmission{
+			blocks:        blocks,
+			feeMultiplier: sdktypes.MustNewDecFromStr(strconv.FormatFloat(feeMultiplier, 'f', -1, 64)),
+			attempts:      attempts,
+		}
+	}
+}
+
+// submitWithResubmission submits the PayForBlob transaction and resubmits it with an escalated fee
+// until it is included. Resubmissions are signed again with the sequence of the first submission, so
+// that at most one of them can be included. A resubmission rejected for a sequence mismatch is
+// handled by the cause of the mismatch:
+//   - the pending submission is awaited, as the mempool does not replace pending transactions, and
+//     replaced by the next resubmission, once it is evicted;
+//   - the submission is signed with a new sequence, if the sequence is consumed by another
+//     transaction of the account or can't be included anymore, as transactions before it are
+//     dropped.
+func (ca *CoreAccessor) submitWithResubmission(
+	ctx context.Context,
+	signer *apptypes.KeyringSigner,
+	fee Int,
+	gasLim uint64,
+	blobs []*apptypes.Blob,
+) (*TxResponse, error) {
+	var sequence uint64
+	hashes := make([]string, 0, ca.resubmission.attempts)
+	for attempt := 0; attempt < ca.resubmission.attempts; attempt++ {
+		head, err := ca.getter.Head(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp *TxResponse
+		if attempt == 0 {
+			resp, sequence, err = ca.broadcastNext(ctx, signer, payForBlobTx(fee, gasLim, blobs))
+		} else {
+			resp, err = ca.broadcastAt(ctx, signer, sequence, payForBlobTx(fee, gasLim, blobs))
+			if errors.Is(err, sdkerrors.ErrWrongSequence) && resp != nil {
+				resp, sequence, err = ca.resolveMismatch(ctx, signer, sequence, resp.RawLog, hashes,
+					payForBlobTx(fee, gasLim, blobs))
+			}
+		}
+		if err != nil {
+			return resp, err
+		}
+		if resp != nil && resp.Height != 0 {
+			// one of the previous submissions is included
+			return resp, nil
+		}
+		if resp != nil {
+			hashes = append(hashes, resp.TxHash)
+		}
+
+		resp, err = ca.awaitInclusion(ctx, hashes, head.Height()+int64(ca.resubmission.blocks))
+		if resp != nil || err != nil {
+			return resp, err
+		}
+
+		log.Warnw("transaction not included, resubmitting with escalated fee",
+			"attempt", attempt,
+			"blocks", ca.resubmission.blocks,
+			"fee", fee,
+		)
+		fee = sdktypes.NewDecFromInt(fee).Mul(ca.resubmission.feeMultiplier).Ceil().TruncateInt()
+	}
+	// the sequence is free again, if the transaction is dropped from the mempool
+	ca.sequenceOf(signer).invalidate()
+	return nil, fmt.Errorf("%w: attempts %d, hashes %v", ErrNotIncluded, ca.resubmission.attempts, hashes)
+}
+
+// resolveMismatch handles the rejection of the resubmission signed with the given sequence for a
+// sequence mismatch. It returns the included previous submission, the resubmission signed with a
+// new sequence, or nil response, if a previous submission is still pending.
+func (ca *CoreAccessor) resolveMismatch(
+	ctx context.Context,
+	signer *apptypes.KeyringSigner,
+	sequence uint64,
+	rawLog string,
+	hashes []string,
+	build txBuilder,
+) (*TxResponse, uint64, error) {
+	mismatch, err := ca.classifyMismatch(ctx, signer, sequence, rawLog)
+	if err != nil {
+		return nil, sequence, err
+	}
+	switch mismatch {
+	case sequencePending:
+		log.Debugw("resubmission conflicts with a pending transaction", "sequence", sequence)
+		return nil, sequence, nil
+	case sequenceConsumed:
+		// the head is not awaited, so that inclusion of the previous submissions is only checked
+		included, err := ca.awaitInclusion(ctx, hashes, 0)
+		if included != nil || err != nil {
+			return included, sequence, err
+		}
+		log.Warnw("sequence is consumed by another transaction, signing with a new one", "sequence", sequence)
+	case sequenceAhead:
+		log.Warnw("transactions before the sequence are dropped, signing with a new one", "sequence", sequence)
+		ca.sequenceOf(signer).invalidate()
+	}
+	return ca.broadcastNext(ctx, signer, build)
+}
+
+// payForBlobTx returns the builder of the PayForBlob transaction of the blobs.
+func payForBlobTx(fee Int, gasLim uint64, blobs []*apptypes.Blob) txBuilder {
+	return func(signer *apptypes.KeyringSigner) ([]byte, error) {
+		addr, err := signer.GetSignerInfo().GetAddress()
+		if err != nil {
+			return nil, err
+		}
+		msg, err := apptypes.NewMsgPayForBlobs(addr.String(), blobs...)
+		if err != nil {
+			return nil, err
+		}
+		tx, err := signer.BuildSignedTx(signer.NewTxBuilder(apptypes.SetGasLimit(gasLim), withFee(fee)), msg)
+		if err != nil {
+			return nil, err
+		}
+		rawTx, err := signer.EncodeTx(tx)
+		if err != nil {
+			return nil, err
+		}
+		return coretypes.MarshalBlobTx(rawTx, blobs...)
+	}
+}
+
+// broadcastSync broadcasts the transaction, returning once it is accepted to the mempool.
+func (ca *CoreAccessor) broadcastSync(ctx context.Context, tx []byte) (*TxResponse, error) {
+	resp, err := apptypes.BroadcastTx(ctx, ca.coreConn, sdktx.BroadcastMode_BROADCAST_MODE_SYNC, tx)
+	if err != nil {
+		return nil, err
+	}
+	if resp.TxResponse.Code != 0 {
+		return resp.TxResponse, sdkErrors.ABCIError(resp.TxResponse.Codespace, resp.TxResponse.Code,
+			resp.TxResponse.RawLog)
+	}
+	return resp.TxResponse, nil
+}
+
+// awaitInclusion waits until any of the transactions with the given hashes is included in a block
+// or the head reaches the given height, in which case nil response is returned.
+func (ca *CoreAccessor) awaitInclusion(ctx context.Context, hashes []string, height int64) (*TxResponse, error) {
+	txCli := sdktx.NewServiceClient(ca.coreConn)
+	ticker := time.NewTicker(inclusionPollInterval)
+	defer ticker.Stop()
+	for {
+		for _, hash := range hashes {
+			resp, err := txCli.GetTx(ctx, &sdktx.GetTxRequest{Hash: hash})
+			if err != nil {
+				// the transaction is not included yet
+				continue
+			}
+			if resp.TxResponse.Code != 0 {
+				return resp.TxResponse, sdkErrors.ABCIError(resp.TxResponse.Codespace, resp.TxResponse.Code,
+					resp.TxResponse.RawLog)
+			}
+			return resp.TxResponse, nil
+		}
+
+		head, err := ca.getter.Head(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if head.Height() >= height {
+			return nil, nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
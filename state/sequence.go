@@ -0,0 +1,205 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"sync"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	apptypes "github.com/celestiaorg/celestia-app/x/blob/types"
+)
+
+// sequenceMismatchRetries is the amount of times a transaction rejected for a sequence mismatch is
+// signed again with the recovered sequence and rebroadcasted.
+var sequenceMismatchRetries = 2
+
+// expectedSequenceRe extracts the expected sequence from the log of sequence mismatch errors.
+var expectedSequenceRe = regexp.MustCompile(`expected (\d+), got (\d+)`)
+
+// accountSequence tracks the sequence of an account locally, so that concurrent submissions from
+// the account get consecutive sequences without waiting for the inclusion of each other.
+type accountSequence struct {
+	// lk serializes signing and broadcasting of the account's transactions, so that they reach the
+	// mempool in the order of their sequences.
+	lk sync.Mutex
+	// synced is false until the sequence is queried from the chain, and after a transaction with a
+	// reserved sequence is known to be dropped.
+	synced bool
+	next   uint64
+}
+
+// txBuilder builds and encodes a transaction signed by the signer with its current sequence.
+type txBuilder func(signer *apptypes.KeyringSigner) ([]byte, error)
+
+// sequenceOf returns the sequence of the signer's account.
+func (ca *CoreAccessor) sequenceOf(signer *apptypes.KeyringSigner) *accountSequence {
+	name := signer.GetSignerInfo().Name
+	ca.signersLk.Lock()
+	defer ca.signersLk.Unlock()
+	seq, ok := ca.sequences[name]
+	if !ok {
+		seq = &accountSequence{}
+		ca.sequences[name] = seq
+	}
+	return seq
+}
+
+// sync queries the account number and the sequence of the signer's account from the chain. It must
+// be called with the lock held.
+func (s *accountSequence) sync(ctx context.Context, ca *CoreAccessor, signer *apptypes.KeyringSigner) error {
+	if err := signer.QueryAccountNumber(ctx, ca.coreConn); err != nil {
+		return err
+	}
+	data, err := signer.GetSignerData()
+	if err != nil {
+		return err
+	}
+	s.next, s.synced = data.Sequence, true
+	return nil
+}
+
+// invalidate makes the sequence queried from the chain again before the next transaction, e.g.
+// after a transaction with a reserved sequence was dropped from the mempool.
+func (s *accountSequence) invalidate() {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.synced = false
+}
+
+// broadcastNext signs the transaction with the next sequence of the signer's account and
+// broadcasts it, returning once the transaction is accepted to the mempool along with its
+// sequence. Transactions rejected for a sequence mismatch, e.g. after transactions submitted by
+// other clients of the account, are retried with the sequence recovered from the error or queried
+// from the chain.
+func (ca *CoreAccessor) broadcastNext(
+	ctx context.Context,
+	signer *apptypes.KeyringSigner,
+	build txBuilder,
+) (*TxResponse, uint64, error) {
+	s := ca.sequenceOf(signer)
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	if !s.synced {
+		if err := s.sync(ctx, ca, signer); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		signer.SetSequence(s.next)
+		tx, err := build(signer)
+		if err != nil {
+			return nil, 0, err
+		}
+		resp, err := ca.broadcastSync(ctx, tx)
+		switch {
+		case err == nil:
+			sequence := s.next
+			s.next++
+			return resp, sequence, nil
+		case errors.Is(err, sdkerrors.ErrWrongSequence) && attempt < sequenceMismatchRetries:
+			log.Debugw("recovering from sequence mismatch", "sequence", s.next, "err", err)
+			if expected, ok := parseExpectedSequence(resp.RawLog); ok {
+				s.next = expected
+				continue
+			}
+			if err = s.sync(ctx, ca, signer); err != nil {
+				return nil, 0, err
+			}
+		case resp == nil:
+			// the transaction may have reached the mempool
+			s.synced = false
+			return nil, 0, err
+		default:
+			return resp, 0, err
+		}
+	}
+}
+
+// broadcastAt signs the transaction with the given sequence of the signer's account and
+// broadcasts it, returning once the transaction is accepted to the mempool. It is used to replace
+// transactions which sequences are already reserved.
+func (ca *CoreAccessor) broadcastAt(
+	ctx context.Context,
+	signer *apptypes.KeyringSigner,
+	sequence uint64,
+	build txBuilder,
+) (*TxResponse, error) {
+	s := ca.sequenceOf(signer)
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	signer.SetSequence(sequence)
+	tx, err := build(signer)
+	if err != nil {
+		return nil, err
+	}
+	return ca.broadcastSync(ctx, tx)
+}
+
+// sequenceMismatch classifies why a transaction signed with a reserved sequence is rejected for a
+// sequence mismatch.
+type sequenceMismatch int
+
+const (
+	// sequencePending means a transaction with the sequence is pending in the mempool, which does not
+	// replace pending transactions of an account until they are evicted.
+	sequencePending sequenceMismatch = iota
+	// sequenceConsumed means a transaction with the sequence is committed to the chain.
+	sequenceConsumed
+	// sequenceAhead means transactions with lower sequences are dropped, so that the sequence can't be
+	// included anymore.
+	sequenceAhead
+)
+
+// classifyMismatch queries the sequence committed to the chain for the signer's account and
+// classifies the sequence mismatch of the transaction signed with the given sequence, given the log
+// of the mismatch error.
+func (ca *CoreAccessor) classifyMismatch(
+	ctx context.Context,
+	signer *apptypes.KeyringSigner,
+	sequence uint64,
+	log string,
+) (sequenceMismatch, error) {
+	s := ca.sequenceOf(signer)
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	if err := signer.QueryAccountNumber(ctx, ca.coreConn); err != nil {
+		return 0, err
+	}
+	data, err := signer.GetSignerData()
+	if err != nil {
+		return 0, err
+	}
+	expected, ok := parseExpectedSequence(log)
+	return classifySequenceMismatch(sequence, expected, ok, data.Sequence), nil
+}
+
+// classifySequenceMismatch classifies the sequence mismatch of the transaction signed with the given
+// sequence from the sequence expected by the mempool, if known, and the next sequence committed to
+// the chain.
+func classifySequenceMismatch(sequence, expected uint64, expectedKnown bool, committed uint64) sequenceMismatch {
+	switch {
+	case committed > sequence:
+		return sequenceConsumed
+	case expectedKnown && expected < sequence:
+		return sequenceAhead
+	default:
+		// the mempool is ahead of the chain by the pending transactions
+		return sequencePending
+	}
+}
+
+// parseExpectedSequence returns the sequence expected by the chain from the log of a sequence
+// mismatch error.
+func parseExpectedSequence(log string) (uint64, bool) {
+	matches := expectedSequenceRe.FindStringSubmatch(log)
+	if len(matches) != 3 {
+		return 0, false
+	}
+	expected, err := strconv.ParseUint(matches[1], 10, 64)
+	return expected, err == nil
+}
@@ -0,0 +1,37 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpectedSequence(t *testing.T) {
+	expected, ok := parseExpectedSequence("account sequence mismatch, expected 12, got 15: incorrect account sequence")
+	require.True(t, ok)
+	require.EqualValues(t, 12, expected)
+
+	_, ok = parseExpectedSequence("insufficient fees")
+	require.False(t, ok)
+}
+
+func TestClassifySequenceMismatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		expected      uint64
+		expectedKnown bool
+		committed     uint64
+		mismatch      sequenceMismatch
+	}{
+		{name: "pending", expected: 6, expectedKnown: true, committed: 5, mismatch: sequencePending},
+		{name: "pending, unknown expected", committed: 5, mismatch: sequencePending},
+		{name: "consumed", expected: 6, expectedKnown: true, committed: 6, mismatch: sequenceConsumed},
+		{name: "consumed, unknown expected", committed: 7, mismatch: sequenceConsumed},
+		{name: "ahead", expected: 3, expectedKnown: true, committed: 3, mismatch: sequenceAhead},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.mismatch, classifySequenceMismatch(5, tt.expected, tt.expectedKnown, tt.committed))
+		})
+	}
+}